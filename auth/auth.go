@@ -0,0 +1,168 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+)
+
+var (
+	// ErrInvalidToken the token of the request is missing or invalid
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Authenticator validates the token carried in the request
+type Authenticator interface {
+	// Authenticate returns the user of the token, returns an error if the token is invalid
+	Authenticate(token string) (string, error)
+}
+
+// Authorizer checks the permissions of the users
+type Authorizer interface {
+	// Authorize returns true if the user has the permission to execute the request
+	Authorize(user string, req *raftcmdpb.Request) bool
+}
+
+// Checker checks the requests by the authenticator and the authorizer before they are executed.
+// A nil Checker allows all the requests.
+type Checker struct {
+	authenticator Authenticator
+	authorizer    Authorizer
+	internalToken string
+}
+
+// NewChecker returns a Checker, returns nil if the authenticator is nil. The requests created by
+// the stores themselves carry the internal token, and they are always allowed. All the requests
+// of the authenticated users are allowed if the authorizer is nil.
+func NewChecker(authenticator Authenticator, authorizer Authorizer, internalToken string) *Checker {
+	if authenticator == nil {
+		return nil
+	}
+
+	return &Checker{
+		authenticator: authenticator,
+		authorizer:    authorizer,
+		internalToken: internalToken,
+	}
+}
+
+// InternalToken returns the token of the requests created by the stores
+func (c *Checker) InternalToken() string {
+	if c == nil {
+		return ""
+	}
+
+	return c.internalToken
+}
+
+// Check returns a permission denied error if the request is not allowed
+func (c *Checker) Check(req *raftcmdpb.Request) *errorpb.Error {
+	if c == nil {
+		return nil
+	}
+
+	if c.internalToken != "" && req.Token == c.internalToken {
+		return nil
+	}
+
+	user, err := c.authenticator.Authenticate(req.Token)
+	if err != nil {
+		return permissionDenied(req, err.Error())
+	}
+
+	if c.authorizer != nil && !c.authorizer.Authorize(user, req) {
+		return permissionDenied(req, fmt.Sprintf("user %s has no permission of command %d in group %d",
+			user,
+			req.CustemType,
+			req.Group))
+	}
+
+	return nil
+}
+
+func permissionDenied(req *raftcmdpb.Request, message string) *errorpb.Error {
+	return &errorpb.Error{
+		Message: message,
+		PermissionDenied: &errorpb.PermissionDenied{
+			Group:      req.Group,
+			CustemType: req.CustemType,
+		},
+	}
+}
+
+type tokenAuthenticator struct {
+	tokens map[string]string
+}
+
+// NewTokenAuthenticator returns an Authenticator with the static tokens, the key of the map is
+// the token and the value is the user of the token.
+func NewTokenAuthenticator(tokens map[string]string) Authenticator {
+	return &tokenAuthenticator{tokens: tokens}
+}
+
+func (a *tokenAuthenticator) Authenticate(token string) (string, error) {
+	if user, ok := a.tokens[token]; ok && token != "" {
+		return user, nil
+	}
+
+	return "", ErrInvalidToken
+}
+
+// Permission the permission of the commands in a group
+type Permission struct {
+	Group uint64
+	// CustemTypes the allowed custom command types, all the commands of the group are allowed
+	// if it's empty.
+	CustemTypes []uint64
+}
+
+func (p Permission) allowed(req *raftcmdpb.Request) bool {
+	if p.Group != req.Group {
+		return false
+	}
+
+	if len(p.CustemTypes) == 0 {
+		return true
+	}
+
+	for _, t := range p.CustemTypes {
+		if t == req.CustemType {
+			return true
+		}
+	}
+	return false
+}
+
+type permissionAuthorizer struct {
+	permissions map[string][]Permission
+}
+
+// NewPermissionAuthorizer returns an Authorizer with the static permissions, the key of the map
+// is the user.
+func NewPermissionAuthorizer(permissions map[string][]Permission) Authorizer {
+	return &permissionAuthorizer{permissions: permissions}
+}
+
+func (a *permissionAuthorizer) Authorize(user string, req *raftcmdpb.Request) bool {
+	for _, p := range a.permissions[user] {
+		if p.allowed(req) {
+			return true
+		}
+	}
+	return false
+}
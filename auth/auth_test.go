@@ -0,0 +1,66 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilChecker(t *testing.T) {
+	c := NewChecker(nil, nil, "internal")
+	assert.Nil(t, c)
+	assert.Nil(t, c.Check(&raftcmdpb.Request{}))
+	assert.Equal(t, "", c.InternalToken())
+}
+
+func TestCheck(t *testing.T) {
+	c := NewChecker(NewTokenAuthenticator(map[string]string{"t1": "u1", "t2": "u2"}),
+		NewPermissionAuthorizer(map[string][]Permission{
+			"u1": {{Group: 0}},
+			"u2": {{Group: 1, CustemTypes: []uint64{1, 2}}},
+		}), "internal")
+
+	cases := []struct {
+		req     raftcmdpb.Request
+		allowed bool
+	}{
+		{req: raftcmdpb.Request{}, allowed: false},
+		{req: raftcmdpb.Request{Token: "t3"}, allowed: false},
+		{req: raftcmdpb.Request{Token: "internal", Group: 2}, allowed: true},
+		{req: raftcmdpb.Request{Token: "t1", CustemType: 100}, allowed: true},
+		{req: raftcmdpb.Request{Token: "t1", Group: 1}, allowed: false},
+		{req: raftcmdpb.Request{Token: "t2", Group: 1, CustemType: 2}, allowed: true},
+		{req: raftcmdpb.Request{Token: "t2", Group: 1, CustemType: 3}, allowed: false},
+		{req: raftcmdpb.Request{Token: "t2", CustemType: 1}, allowed: false},
+	}
+
+	for i, c2 := range cases {
+		err := c.Check(&c2.req)
+		assert.Equal(t, c2.allowed, err == nil, "index %d", i)
+		if err != nil {
+			assert.NotNil(t, err.PermissionDenied, "index %d", i)
+			assert.Equal(t, c2.req.Group, err.PermissionDenied.Group, "index %d", i)
+			assert.Equal(t, c2.req.CustemType, err.PermissionDenied.CustemType, "index %d", i)
+		}
+	}
+}
+
+func TestCheckWithoutAuthorizer(t *testing.T) {
+	c := NewChecker(NewTokenAuthenticator(map[string]string{"t1": "u1"}), nil, "")
+	assert.Nil(t, c.Check(&raftcmdpb.Request{Token: "t1", Group: 10}))
+	assert.NotNil(t, c.Check(&raftcmdpb.Request{Token: ""}))
+}
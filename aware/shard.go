@@ -23,6 +23,9 @@ type ShardStateAware interface {
 	Created(bhmetapb.Shard)
 	// Splited the shard was splited on the current store
 	Splited(bhmetapb.Shard)
+	// Merged the shard was merged on the current store, the shard is the target shard
+	// which contains the range of the source shard
+	Merged(bhmetapb.Shard)
 	// Destory the shard was destoryed on the current store
 	Destory(bhmetapb.Shard)
 	// BecomeLeader the shard was become leader on the current store
@@ -31,6 +34,9 @@ type ShardStateAware interface {
 	BecomeFollower(bhmetapb.Shard)
 	// SnapshotApplied snapshot applied
 	SnapshotApplied(bhmetapb.Shard)
+	// ConsistencyCheckFailed the data of the shard on the current store is inconsistent with
+	// the leader's at the raft log index
+	ConsistencyCheckFailed(shard bhmetapb.Shard, index uint64)
 }
 
 // TestShardStateAware just for test
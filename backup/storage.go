@@ -0,0 +1,93 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/errors/oserror"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+// Storage is the storage of the backup files, the backup files of all the shards and the
+// meta of the backup are saved by name. It can be implemented by an object store, e.g. s3.
+type Storage interface {
+	// Put saves the local file with the name, the file with the same name is replaced.
+	Put(name string, file string) error
+	// Get downloads the file with the name to the local file.
+	Get(name string, file string) error
+	// Exists returns true if the file with the name exists.
+	Exists(name string) (bool, error)
+}
+
+type localStorage struct {
+	fs  vfs.FS
+	dir string
+}
+
+// NewLocalStorage returns a storage which saves the backup files in the local dir
+func NewLocalStorage(fs vfs.FS, dir string) (Storage, error) {
+	if err := fs.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+
+	return &localStorage{fs: fs, dir: dir}, nil
+}
+
+func (s *localStorage) Put(name string, file string) error {
+	// copy to a tmp file first, the file with the name is always a completed one.
+	tmp := fmt.Sprintf("%s.tmp", s.fs.PathJoin(s.dir, name))
+	if err := copyFile(s.fs, file, tmp); err != nil {
+		s.fs.RemoveAll(tmp)
+		return err
+	}
+
+	return s.fs.Rename(tmp, s.fs.PathJoin(s.dir, name))
+}
+
+func (s *localStorage) Get(name string, file string) error {
+	return copyFile(s.fs, s.fs.PathJoin(s.dir, name), file)
+}
+
+func (s *localStorage) Exists(name string) (bool, error) {
+	_, err := s.fs.Stat(s.fs.PathJoin(s.dir, name))
+	if err == nil {
+		return true, nil
+	}
+	if oserror.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func copyFile(fs vfs.FS, from, to string) error {
+	src, err := fs.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := fs.Create(to)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+
+	return dst.Sync()
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	fs := vfs.NewMemFS()
+	assert.NoError(t, fs.MkdirAll("/tmp", 0750))
+
+	s, err := NewLocalStorage(fs, "/backup")
+	assert.NoError(t, err)
+
+	ok, err := s.Exists("f1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	writeFile(t, fs, "/tmp/f1", "v1")
+	assert.NoError(t, s.Put("f1", "/tmp/f1"))
+	ok, err = s.Exists("f1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	writeFile(t, fs, "/tmp/f1", "v2")
+	assert.NoError(t, s.Put("f1", "/tmp/f1"))
+	assert.NoError(t, s.Get("f1", "/tmp/f2"))
+	assert.Equal(t, "v2", readFile(t, fs, "/tmp/f2"))
+
+	assert.Error(t, s.Get("f3", "/tmp/f3"))
+}
+
+func writeFile(t *testing.T, fs vfs.FS, file, value string) {
+	f, err := fs.Create(file)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte(value))
+	assert.NoError(t, err)
+}
+
+func readFile(t *testing.T, fs vfs.FS, file string) string {
+	f, err := fs.Open(file)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	v, err := ioutil.ReadAll(f)
+	assert.NoError(t, err)
+	return string(v)
+}
@@ -0,0 +1,430 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/event"
+	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+)
+
+var (
+	errSnapshotTimeout = errors.New("wait cluster snapshot timeout")
+	errWatcherClosed   = errors.New("watcher closed")
+)
+
+// snapshot the stores and shards snapshot of the prophet leader
+type snapshot struct {
+	stores  []metadata.Container
+	shards  []metadata.Resource
+	leaders map[uint64]uint64 // shard id -> leader peer id
+}
+
+// snapshot returns the stores and shards snapshot, which is the init event of a watcher.
+func (c *cli) snapshot() (*snapshot, error) {
+	w, err := c.client.NewWatcher(event.EventInit)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	adapter := raftstore.NewProphetAdapter()
+	for {
+		select {
+		case e, ok := <-w.GetNotify():
+			if !ok {
+				return nil, errWatcherClosed
+			}
+			if e.Type != event.EventInit {
+				continue
+			}
+
+			snap := &snapshot{leaders: make(map[uint64]uint64)}
+			for _, data := range e.InitEvent.Containers {
+				store := adapter.NewContainer()
+				if err := store.Unmarshal(data); err != nil {
+					return nil, err
+				}
+				snap.stores = append(snap.stores, store)
+			}
+			for idx, data := range e.InitEvent.Resources {
+				shard := adapter.NewResource()
+				if err := shard.Unmarshal(data); err != nil {
+					return nil, err
+				}
+				snap.shards = append(snap.shards, shard)
+				snap.leaders[shard.ID()] = e.InitEvent.Leaders[idx]
+			}
+			sort.Slice(snap.stores, func(i, j int) bool { return snap.stores[i].ID() < snap.stores[j].ID() })
+			sort.Slice(snap.shards, func(i, j int) bool { return snap.shards[i].ID() < snap.shards[j].ID() })
+			return snap, nil
+		case <-time.After(c.timeout):
+			return nil, errSnapshotTimeout
+		}
+	}
+}
+
+func (s *snapshot) shardInfo(shard metadata.Resource) shardInfo {
+	start, end := shard.Range()
+	info := shardInfo{
+		ID:       shard.ID(),
+		Group:    shard.Group(),
+		StartKey: hex.EncodeToString(start),
+		EndKey:   hex.EncodeToString(end),
+		Epoch:    shard.Epoch(),
+		State:    shard.State().String(),
+		Peers:    shard.Peers(),
+	}
+	for idx := range info.Peers {
+		if info.Peers[idx].ID == s.leaders[shard.ID()] {
+			info.Leader = &info.Peers[idx]
+		}
+	}
+	return info
+}
+
+func (c *cli) listStores(args []string) error {
+	if err := noArgs(args); err != nil {
+		return err
+	}
+
+	snap, err := c.snapshot()
+	if err != nil {
+		return err
+	}
+
+	infos := make([]storeInfo, 0, len(snap.stores))
+	for _, store := range snap.stores {
+		version, githash := store.Version()
+		infos = append(infos, storeInfo{
+			ID:            store.ID(),
+			ClientAddr:    store.Addr(),
+			RaftAddr:      store.ShardAddr(),
+			Labels:        store.Labels(),
+			State:         store.State().String(),
+			Version:       version,
+			GitHash:       githash,
+			LastHeartbeat: store.LastHeartbeat(),
+		})
+	}
+	return c.printer.printStores(infos)
+}
+
+func (c *cli) listShards(args []string) error {
+	fs := flag.NewFlagSet("shards", flag.ContinueOnError)
+	store := fs.Uint64("store", 0, "Only list the shards which have a replica on the store")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs.Args()); err != nil {
+		return err
+	}
+
+	snap, err := c.snapshot()
+	if err != nil {
+		return err
+	}
+
+	infos := make([]shardInfo, 0, len(snap.shards))
+	for _, shard := range snap.shards {
+		if *store > 0 {
+			if _, ok := findPeerByStore(shard.Peers(), *store); !ok {
+				continue
+			}
+		}
+		infos = append(infos, snap.shardInfo(shard))
+	}
+	return c.printer.printShards(infos)
+}
+
+func (c *cli) showShard(args []string) error {
+	ids, err := parseIDs(args, 1)
+	if err != nil {
+		return err
+	}
+
+	snap, err := c.snapshot()
+	if err != nil {
+		return err
+	}
+
+	for _, shard := range snap.shards {
+		if shard.ID() == ids[0] {
+			return c.printer.printShard(snap.shardInfo(shard))
+		}
+	}
+	return fmt.Errorf("shard %d not found", ids[0])
+}
+
+func (c *cli) putRule(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expect the rule json file, but %+v", args)
+	}
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	rule := rpcpb.PlacementRule{}
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return err
+	}
+	if err := c.client.PutPlacementRule(rule); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("rule %s/%s", rule.GroupID, rule.ID))
+}
+
+func (c *cli) getRules(args []string) error {
+	ids, err := parseIDs(args, 1)
+	if err != nil {
+		return err
+	}
+
+	rules, err := c.client.GetAppliedRules(ids[0])
+	if err != nil {
+		return err
+	}
+	return c.printer.printRules(rules)
+}
+
+func (c *cli) createJob(args []string) error {
+	job, err := parseJob("create-job", args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.CreateJob(job); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("job %s", job.Type.String()))
+}
+
+func (c *cli) removeJob(args []string) error {
+	job, err := parseJob("remove-job", args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.RemoveJob(job); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("job %s", job.Type.String()))
+}
+
+func (c *cli) transferLeader(args []string) error {
+	ids, err := parseIDs(args, 2)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.TransferLeader(ids[0], ids[1]); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("shard %d transfer leader to store %d", ids[0], ids[1]))
+}
+
+func (c *cli) removeShards(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing shard id")
+	}
+
+	ids, err := parseIDs(args, len(args))
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.AsyncRemoveResources(ids...); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("shards %+v removed", ids))
+}
+
+func (c *cli) putRateLimit(args []string) error {
+	values, err := parseIDs(args, 4)
+	if err != nil {
+		return err
+	}
+
+	limit := metapb.RateLimit{Group: values[0], Tenant: values[1], Requests: values[2], Bytes: values[3]}
+	if err := c.client.PutRateLimit(limit); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("rate limit of tenant %d in group %d", limit.Tenant, limit.Group))
+}
+
+func (c *cli) getRateLimits(args []string) error {
+	if err := noArgs(args); err != nil {
+		return err
+	}
+
+	limits, err := c.client.GetRateLimits()
+	if err != nil {
+		return err
+	}
+	return c.printer.printRateLimits(limits)
+}
+
+func (c *cli) freezeWrites(args []string) error {
+	return c.setWriteFreeze(args, true)
+}
+
+func (c *cli) unfreezeWrites(args []string) error {
+	return c.setWriteFreeze(args, false)
+}
+
+func (c *cli) setWriteFreeze(args []string, frozen bool) error {
+	ids, err := parseIDs(args, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.SetWriteFreeze(ids[0], frozen); err != nil {
+		return err
+	}
+	if frozen {
+		return c.printer.printDone(fmt.Sprintf("writes of group %d frozen", ids[0]))
+	}
+	return c.printer.printDone(fmt.Sprintf("writes of group %d unfrozen", ids[0]))
+}
+
+func (c *cli) getWriteFrozenGroups(args []string) error {
+	if err := noArgs(args); err != nil {
+		return err
+	}
+
+	groups, err := c.client.GetWriteFrozenGroups()
+	if err != nil {
+		return err
+	}
+	return c.printer.printWriteFrozenGroups(groups)
+}
+
+func (c *cli) decommissionStore(args []string) error {
+	if len(args) == 1 {
+		args = append(args, "0")
+	}
+	values, err := parseIDs(args, 2)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.DecommissionContainer(values[0], values[1]); err != nil {
+		return err
+	}
+	return c.printer.printDone(fmt.Sprintf("store %d decommissioning", values[0]))
+}
+
+func (c *cli) decommissionProgress(args []string) error {
+	ids, err := parseIDs(args, 1)
+	if err != nil {
+		return err
+	}
+
+	progress, err := c.client.GetDecommissionProgress(ids[0])
+	if err != nil {
+		return err
+	}
+	return c.printer.printDecommission(decommissionInfo{
+		Store:   ids[0],
+		State:   progress.State.String(),
+		Shards:  progress.ResourceCount,
+		Leaders: progress.LeaderCount,
+	})
+}
+
+func parseJob(name string, args []string) (metapb.Job, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	jobType := fs.String("type", "", "Job type, the name or the value of the job type")
+	content := fs.String("content", "", "Job content")
+	isHex := fs.Bool("hex", false, "The job content is hex encoded")
+	if err := fs.Parse(args); err != nil {
+		return metapb.Job{}, err
+	}
+	if err := noArgs(fs.Args()); err != nil {
+		return metapb.Job{}, err
+	}
+
+	job := metapb.Job{}
+	if v, ok := metapb.JobType_value[*jobType]; ok {
+		job.Type = metapb.JobType(v)
+	} else if v, err := strconv.ParseInt(*jobType, 10, 32); err == nil {
+		job.Type = metapb.JobType(v)
+	} else {
+		return metapb.Job{}, fmt.Errorf("invalid job type %s", *jobType)
+	}
+
+	if *isHex {
+		data, err := hex.DecodeString(*content)
+		if err != nil {
+			return metapb.Job{}, err
+		}
+		job.Content = data
+	} else if *content != "" {
+		job.Content = []byte(*content)
+	}
+	return job, nil
+}
+
+func parseIDs(args []string, n int) ([]uint64, error) {
+	if len(args) != n {
+		return nil, fmt.Errorf("expect %d id args, but %+v", n, args)
+	}
+
+	ids := make([]uint64, 0, n)
+	for _, arg := range args {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %s", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func noArgs(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected args %+v", args)
+	}
+	return nil
+}
+
+func findPeerByStore(peers []metapb.Peer, store uint64) (metapb.Peer, bool) {
+	for _, p := range peers {
+		if p.ContainerID == store {
+			return p, true
+		}
+	}
+	return metapb.Peer{}, false
+}
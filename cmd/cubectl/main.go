@@ -0,0 +1,226 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// cubectl is a command line admin tool for MatrixCube, it speaks the prophet rpc
+// to the prophet leader.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fagongzi/log"
+	"github.com/matrixorigin/matrixcube/components/prophet"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	putil "github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
+	"github.com/matrixorigin/matrixcube/raftstore"
+)
+
+var (
+	addrs   = flag.String("prophet", "127.0.0.1:10001", "Comma separated prophet rpc addresses")
+	timeout = flag.Duration("timeout", time.Second*10, "Timeout of the prophet rpc")
+	output  = flag.String("o", outputTable, "Output format, table or json")
+	tlsCert = flag.String("tls-cert", "", "Client certificate file, the prophet rpc uses tls if it's set")
+	tlsKey  = flag.String("tls-key", "", "Client private key file")
+	tlsCA   = flag.String("tls-ca", "", "CA file to verify the certificate of the prophet")
+)
+
+// command a sub command of the cubectl
+type command struct {
+	usage string
+	desc  string
+	fn    func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"stores": {
+		usage: "stores",
+		desc:  "List all stores",
+		fn:    (*cli).listStores,
+	},
+	"shards": {
+		usage: "shards [-store id]",
+		desc:  "List all shards, or the shards which have a replica on the store",
+		fn:    (*cli).listShards,
+	},
+	"shard": {
+		usage: "shard <shard-id>",
+		desc:  "Show the peers, leader and epoch of the shard",
+		fn:    (*cli).showShard,
+	},
+	"put-rule": {
+		usage: "put-rule <rule-json-file>",
+		desc:  "Put a placement rule, `-` means read the rule from stdin",
+		fn:    (*cli).putRule,
+	},
+	"get-rules": {
+		usage: "get-rules <shard-id>",
+		desc:  "Show the placement rules applied to the shard",
+		fn:    (*cli).getRules,
+	},
+	"create-job": {
+		usage: "create-job -type type [-content content] [-hex]",
+		desc:  "Create a job",
+		fn:    (*cli).createJob,
+	},
+	"remove-job": {
+		usage: "remove-job -type type [-content content] [-hex]",
+		desc:  "Remove a job",
+		fn:    (*cli).removeJob,
+	},
+	"transfer-leader": {
+		usage: "transfer-leader <shard-id> <store-id>",
+		desc:  "Transfer the leader of the shard to the store",
+		fn:    (*cli).transferLeader,
+	},
+	"remove-shards": {
+		usage: "remove-shards <shard-id> [shard-id...]",
+		desc:  "Remove the shards",
+		fn:    (*cli).removeShards,
+	},
+	"put-rate-limit": {
+		usage: "put-rate-limit <group> <tenant> <requests> <bytes>",
+		desc:  "Put the requests and written bytes per second limits of the tenant in the group, 0 means unlimited",
+		fn:    (*cli).putRateLimit,
+	},
+	"get-rate-limits": {
+		usage: "get-rate-limits",
+		desc:  "Show all the rate limits",
+		fn:    (*cli).getRateLimits,
+	},
+	"freeze-writes": {
+		usage: "freeze-writes <group>",
+		desc:  "Reject the write requests of the group, the read requests are still served",
+		fn:    (*cli).freezeWrites,
+	},
+	"unfreeze-writes": {
+		usage: "unfreeze-writes <group>",
+		desc:  "Accept the write requests of the group again",
+		fn:    (*cli).unfreezeWrites,
+	},
+	"get-write-frozen-groups": {
+		usage: "get-write-frozen-groups",
+		desc:  "Show all the write frozen groups",
+		fn:    (*cli).getWriteFrozenGroups,
+	},
+	"decommission-store": {
+		usage: "decommission-store <store-id> [rate]",
+		desc:  "Take the store offline and move its leaders and shards away, at most rate shards per minute, 0 keeps the configured store limit",
+		fn:    (*cli).decommissionStore,
+	},
+	"decommission-progress": {
+		usage: "decommission-progress <store-id>",
+		desc:  "Show the state and the remaining shards and leaders of the decommissioning store",
+		fn:    (*cli).decommissionProgress,
+	},
+}
+
+func main() {
+	os.Exit(run())
+}
+
+// run runs the command and returns the exit code, the deferred cleanups run before the exit
+func run() int {
+	// only print the errors of the prophet client by default, use `-log-level` to change it
+	flag.Set("log-level", "error")
+	flag.Lookup("log-level").DefValue = "error"
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		return 2
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %s\n", flag.Arg(0))
+		usage()
+		return 2
+	}
+
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(os.Stderr, "invalid output format %s\n", *output)
+		return 2
+	}
+
+	log.InitLog()
+	putil.SetLogger(log.NewLoggerWithPrefix("[cubectl]"))
+
+	tls, err := tlsutil.New(tlsutil.Config{CertFile: *tlsCert, KeyFile: *tlsKey, CAFile: *tlsCA})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid tls config: %v\n", err)
+		return 2
+	}
+
+	c := newCLI(strings.Split(*addrs, ","), *timeout, tls, newPrinter(os.Stdout, *output))
+	defer c.close()
+
+	if err := cmd.fn(c, flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed: %v\n", flag.Arg(0), err)
+		return 1
+	}
+	return 0
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: cubectl [flags] <command> [args]\n\nCommands:\n")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-50s %s\n", commands[name].usage, commands[name].desc)
+	}
+	fmt.Fprintf(os.Stderr, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+type cli struct {
+	client  prophet.Client
+	tls     *tlsutil.TLS
+	timeout time.Duration
+	printer *printer
+}
+
+func newCLI(addrs []string, timeout time.Duration, tls *tlsutil.TLS, printer *printer) *cli {
+	// the prophet client reconnects by the leader getter if the connected prophet is not
+	// the leader, so we try the addresses one by one until we meet the leader.
+	var next uint64
+	leaderGetter := func() *metapb.Member {
+		idx := atomic.AddUint64(&next, 1)
+		return &metapb.Member{Addr: addrs[idx%uint64(len(addrs))]}
+	}
+
+	return &cli{
+		client: prophet.NewClient(raftstore.NewProphetAdapter(),
+			prophet.WithRPCTimeout(timeout),
+			prophet.WithLeaderGetter(leaderGetter),
+			prophet.WithTLS(tls)),
+		tls:     tls,
+		timeout: timeout,
+		printer: printer,
+	}
+}
+
+func (c *cli) close() {
+	c.client.Close()
+	c.tls.Close()
+}
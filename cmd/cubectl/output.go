@@ -0,0 +1,239 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type storeInfo struct {
+	ID            uint64        `json:"id"`
+	ClientAddr    string        `json:"client_addr"`
+	RaftAddr      string        `json:"raft_addr"`
+	Labels        []metapb.Pair `json:"labels,omitempty"`
+	State         string        `json:"state"`
+	Version       string        `json:"version"`
+	GitHash       string        `json:"git_hash"`
+	LastHeartbeat int64         `json:"last_heartbeat"`
+}
+
+type shardInfo struct {
+	ID       uint64               `json:"id"`
+	Group    uint64               `json:"group"`
+	StartKey string               `json:"start_key"`
+	EndKey   string               `json:"end_key"`
+	Epoch    metapb.ResourceEpoch `json:"epoch"`
+	State    string               `json:"state"`
+	Peers    []metapb.Peer        `json:"peers"`
+	Leader   *metapb.Peer         `json:"leader,omitempty"`
+}
+
+type decommissionInfo struct {
+	Store   uint64 `json:"store"`
+	State   string `json:"state"`
+	Shards  uint64 `json:"shards"`
+	Leaders uint64 `json:"leaders"`
+}
+
+type doneInfo struct {
+	Done string `json:"done"`
+}
+
+// printer print the command result as table or json
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) *printer {
+	return &printer{w: w, format: format}
+}
+
+func (p *printer) printStores(stores []storeInfo) error {
+	if p.format == outputJSON {
+		return p.printJSON(stores)
+	}
+
+	return p.printTable([]string{"ID", "CLIENT-ADDR", "RAFT-ADDR", "STATE", "VERSION", "LAST-HEARTBEAT", "LABELS"},
+		len(stores),
+		func(i int) []interface{} {
+			s := stores[i]
+			return []interface{}{s.ID, s.ClientAddr, s.RaftAddr, s.State, s.Version,
+				formatTimestamp(s.LastHeartbeat), formatLabels(s.Labels)}
+		})
+}
+
+func (p *printer) printShards(shards []shardInfo) error {
+	if p.format == outputJSON {
+		return p.printJSON(shards)
+	}
+
+	return p.printTable([]string{"ID", "GROUP", "START", "END", "EPOCH", "STATE", "LEADER", "PEERS"},
+		len(shards),
+		func(i int) []interface{} {
+			s := shards[i]
+			return []interface{}{s.ID, s.Group, s.StartKey, s.EndKey, formatEpoch(s.Epoch), s.State,
+				formatPeer(s.Leader), formatPeers(s.Peers)}
+		})
+}
+
+func (p *printer) printShard(shard shardInfo) error {
+	if p.format == outputJSON {
+		return p.printJSON(shard)
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "ID:\t%d\n", shard.ID)
+	fmt.Fprintf(w, "Group:\t%d\n", shard.Group)
+	fmt.Fprintf(w, "Range:\t[%s, %s)\n", shard.StartKey, shard.EndKey)
+	fmt.Fprintf(w, "Epoch:\t%s\n", formatEpoch(shard.Epoch))
+	fmt.Fprintf(w, "State:\t%s\n", shard.State)
+	fmt.Fprintf(w, "Leader:\t%s\n", formatPeer(shard.Leader))
+	fmt.Fprintf(w, "Peers:\t\n")
+	fmt.Fprintf(w, "  ID\tSTORE\tROLE\n")
+	for _, peer := range shard.Peers {
+		fmt.Fprintf(w, "  %d\t%d\t%s\n", peer.ID, peer.ContainerID, peer.Role.String())
+	}
+	return w.Flush()
+}
+
+func (p *printer) printRules(rules []rpcpb.PlacementRule) error {
+	if p.format == outputJSON {
+		return p.printJSON(rules)
+	}
+
+	return p.printTable([]string{"GROUP", "ID", "INDEX", "OVERRIDE", "ROLE", "COUNT", "LOCATION-LABELS"},
+		len(rules),
+		func(i int) []interface{} {
+			r := rules[i]
+			return []interface{}{r.GroupID, r.ID, r.Index, r.Override, r.Role.String(), r.Count,
+				strings.Join(r.LocationLabels, ",")}
+		})
+}
+
+func (p *printer) printRateLimits(limits []metapb.RateLimit) error {
+	if p.format == outputJSON {
+		return p.printJSON(limits)
+	}
+
+	return p.printTable([]string{"GROUP", "TENANT", "REQUESTS", "BYTES"},
+		len(limits),
+		func(i int) []interface{} {
+			l := limits[i]
+			return []interface{}{l.Group, l.Tenant, l.Requests, l.Bytes}
+		})
+}
+
+func (p *printer) printWriteFrozenGroups(groups []uint64) error {
+	if p.format == outputJSON {
+		return p.printJSON(groups)
+	}
+
+	return p.printTable([]string{"GROUP"},
+		len(groups),
+		func(i int) []interface{} {
+			return []interface{}{groups[i]}
+		})
+}
+
+func (p *printer) printDecommission(info decommissionInfo) error {
+	if p.format == outputJSON {
+		return p.printJSON(info)
+	}
+
+	return p.printTable([]string{"STORE", "STATE", "SHARDS", "LEADERS"},
+		1,
+		func(i int) []interface{} {
+			return []interface{}{info.Store, info.State, info.Shards, info.Leaders}
+		})
+}
+
+func (p *printer) printDone(what string) error {
+	if p.format == outputJSON {
+		return p.printJSON(doneInfo{Done: what})
+	}
+
+	_, err := fmt.Fprintf(p.w, "%s: OK\n", what)
+	return err
+}
+
+func (p *printer) printJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(p.w, "%s\n", data)
+	return err
+}
+
+func (p *printer) printTable(header []string, rows int, row func(int) []interface{}) error {
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for i := 0; i < rows; i++ {
+		var values []string
+		for _, v := range row(i) {
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
+
+func formatEpoch(epoch metapb.ResourceEpoch) string {
+	return fmt.Sprintf("%d/%d", epoch.Version, epoch.ConfVer)
+}
+
+func formatPeer(peer *metapb.Peer) string {
+	if peer == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d@%d", peer.ID, peer.ContainerID)
+}
+
+func formatPeers(peers []metapb.Peer) string {
+	var values []string
+	for idx := range peers {
+		values = append(values, formatPeer(&peers[idx]))
+	}
+	return strings.Join(values, ",")
+}
+
+func formatLabels(labels []metapb.Pair) string {
+	var values []string
+	for _, label := range labels {
+		values = append(values, label.Key+"="+label.Value)
+	}
+	return strings.Join(values, ",")
+}
+
+func formatTimestamp(ts int64) string {
+	if ts == 0 {
+		return "-"
+	}
+	return time.Unix(0, ts).Format(time.RFC3339)
+}
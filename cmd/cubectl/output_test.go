@@ -0,0 +1,62 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintShards(t *testing.T) {
+	peers := []metapb.Peer{{ID: 2, ContainerID: 1}, {ID: 3, ContainerID: 2}}
+	shards := []shardInfo{{ID: 1, StartKey: "61", EndKey: "62", Epoch: metapb.ResourceEpoch{Version: 2, ConfVer: 3},
+		State: "Running", Peers: peers, Leader: &peers[1]}}
+
+	buf := bytes.NewBuffer(nil)
+	assert.NoError(t, newPrinter(buf, outputTable).printShards(shards))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, []string{"ID", "GROUP", "START", "END", "EPOCH", "STATE", "LEADER", "PEERS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"1", "0", "61", "62", "2/3", "Running", "3@2", "2@1,3@2"}, strings.Fields(lines[1]))
+
+	buf.Reset()
+	assert.NoError(t, newPrinter(buf, outputJSON).printShards(shards))
+	var values []shardInfo
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &values))
+	assert.Equal(t, shards, values)
+}
+
+func TestParseJob(t *testing.T) {
+	job, err := parseJob("create-job", []string{"-type", "CreateResourcePool", "-content", "abc"})
+	assert.NoError(t, err)
+	assert.Equal(t, metapb.Job{Type: metapb.JobType_CreateResourcePool, Content: []byte("abc")}, job)
+
+	job, err = parseJob("create-job", []string{"-type", "100", "-content", "0102", "-hex"})
+	assert.NoError(t, err)
+	assert.Equal(t, metapb.Job{Type: metapb.JobType_CustomStartAt, Content: []byte{1, 2}}, job)
+
+	_, err = parseJob("create-job", []string{"-type", "unknown"})
+	assert.Error(t, err)
+
+	_, err = parseIDs([]string{"1"}, 2)
+	assert.Error(t, err)
+	ids, err := parseIDs([]string{"1", "2"}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, ids)
+}
@@ -16,6 +16,7 @@ package prophet
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/components/prophet/tso"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
 )
 
@@ -40,10 +42,24 @@ var (
 	stateStopped = int32(1)
 )
 
+const (
+	// maxTimestampBatch the max number of the timestamps allocated by one request
+	maxTimestampBatch = 10000
+	// timestampRetryInterval the interval to retry if the tso of the leader is not ready
+	timestampRetryInterval = time.Millisecond * 10
+	// writeFreezeTimeout the max time to wait for all the containers put the write freeze in effect
+	writeFreezeTimeout = time.Second * 30
+	// writeFreezeCheckInterval the interval to check whether the write freeze is in effect
+	writeFreezeCheckInterval = time.Millisecond * 100
+)
+
 // Client prophet client
 type Client interface {
 	Close() error
 	AllocID() (uint64, error)
+	// AllocTimestamp returns a timestamp from the tso of the prophet leader, the timestamps are strictly
+	// increasing across the leader changes. The concurrent requests are batched into one rpc.
+	AllocTimestamp() (uint64, error)
 	PutContainer(container metadata.Container) error
 	GetContainer(containerID uint64) (metadata.Container, error)
 	ResourceHeartbeat(meta metadata.Resource, hb rpcpb.ResourceHeartbeatReq) error
@@ -61,6 +77,10 @@ type Client interface {
 	// AsyncAddResourcesWithLeastPeers same of `AsyncAddResources`, but if the number of peers successfully
 	// allocated exceed the `leastPeers`, no error will be returned.
 	AsyncAddResourcesWithLeastPeers(resources []metadata.Resource, leastPeers []int) error
+	// AsyncAddAndScatterResources same of `AsyncAddResources`, but once the resources are created and
+	// fully replicated, the prophet leader scatters their peers and leaders across the containers by
+	// the resource scatterer, instead of waiting for the balance schedulers to spread them.
+	AsyncAddAndScatterResources(resources ...metadata.Resource) error
 	// AsyncRemoveResources remove resource asynchronously. The operation only update the resource state
 	// on the prophet leader cache and embed etcd. The resource actual destory triggered in three ways as below:
 	// a) Each cube node starts a backgroud goroutine to check all the resources state, and resource will
@@ -82,6 +102,34 @@ type Client interface {
 	RemoveJob(metapb.Job) error
 	// ExecuteJob execute on job and returns the execute result
 	ExecuteJob(metapb.Job, []byte) ([]byte, error)
+
+	// TransferLeader transfer the leader of the resource to the container asynchronously. The prophet
+	// leader creates an admin operator, and the operator will be sent to the resource leader by the
+	// resource heartbeat response.
+	TransferLeader(resourceID, containerID uint64) error
+
+	// PutRateLimit put the rate limit of the tenant in the group, the limit is removed if both
+	// the requests and the bytes are 0. The limits are sent to all containers by the container
+	// heartbeat responses.
+	PutRateLimit(limit metapb.RateLimit) error
+	// GetRateLimits returns all rate limits
+	GetRateLimits() ([]metapb.RateLimit, error)
+
+	// SetWriteFreeze freeze or unfreeze the writes of the group. The frozen groups are pushed
+	// to all containers, the write requests of a frozen group are rejected and the read requests
+	// are still served. It returns once all the containers put the change in effect, or an error
+	// after a timeout of 30 seconds.
+	SetWriteFreeze(group uint64, frozen bool) error
+	// GetWriteFrozenGroups returns all write frozen groups
+	GetWriteFrozenGroups() ([]uint64, error)
+
+	// DecommissionContainer marks the container as offline and drains it. The leaders on the
+	// container are transferred first, then the peers are moved to the other containers, at most
+	// rate peers are removed per minute and 0 keeps the configured store limit. The container turns
+	// into tombstone once it holds no resources.
+	DecommissionContainer(containerID uint64, rate uint64) error
+	// GetDecommissionProgress returns the state and the remaining resources and leaders of the container
+	GetDecommissionProgress(containerID uint64) (rpcpb.GetDecommissionProgressRsp, error)
 }
 
 type asyncClient struct {
@@ -101,6 +149,16 @@ type asyncClient struct {
 	resetLeaderConnC      chan struct{}
 	writeC                chan *ctx
 	resourceHeartbeatRspC chan rpcpb.ResourceHeartbeatRsp
+	timestampC            chan *timestampRequest
+}
+
+type timestampRequest struct {
+	c chan timestampResult
+}
+
+type timestampResult struct {
+	ts  uint64
+	err error
 }
 
 // NewClient create a prophet client
@@ -108,17 +166,18 @@ func NewClient(adapter metadata.Adapter, opts ...Option) Client {
 	c := &asyncClient{
 		opts:                  &options{},
 		adapter:               adapter,
-		leaderConn:            createConn(),
 		resetReadC:            make(chan struct{}),
 		resetLeaderConnC:      make(chan struct{}),
 		writeC:                make(chan *ctx, 128),
 		resourceHeartbeatRspC: make(chan rpcpb.ResourceHeartbeatRsp, 128),
+		timestampC:            make(chan *timestampRequest, 1024),
 	}
 
 	for _, opt := range opts {
 		opt(c.opts)
 	}
 	c.opts.adjust()
+	c.leaderConn = createConn(c.opts.tls)
 
 	c.ctx, c.cancel = context.WithCancel(context.Background())
 	c.start()
@@ -158,6 +217,26 @@ func (c *asyncClient) AllocID() (uint64, error) {
 	return resp.AllocID.ID, nil
 }
 
+func (c *asyncClient) AllocTimestamp() (uint64, error) {
+	if !c.running() {
+		return 0, ErrClosed
+	}
+
+	req := &timestampRequest{c: make(chan timestampResult, 1)}
+	select {
+	case c.timestampC <- req:
+	case <-c.ctx.Done():
+		return 0, ErrClosed
+	}
+
+	select {
+	case result := <-req.c:
+		return result.ts, result.err
+	case <-c.ctx.Done():
+		return 0, ErrClosed
+	}
+}
+
 func (c *asyncClient) ResourceHeartbeat(meta metadata.Resource, hb rpcpb.ResourceHeartbeatReq) error {
 	if !c.running() {
 		return ErrClosed
@@ -365,12 +444,21 @@ func (c *asyncClient) AsyncAddResources(resources ...metadata.Resource) error {
 }
 
 func (c *asyncClient) AsyncAddResourcesWithLeastPeers(resources []metadata.Resource, leastPeers []int) error {
+	return c.asyncAddResources(resources, leastPeers, false)
+}
+
+func (c *asyncClient) AsyncAddAndScatterResources(resources ...metadata.Resource) error {
+	return c.asyncAddResources(resources, make([]int, len(resources)), true)
+}
+
+func (c *asyncClient) asyncAddResources(resources []metadata.Resource, leastPeers []int, scatter bool) error {
 	if !c.running() {
 		return ErrClosed
 	}
 
 	req := &rpcpb.Request{}
 	req.Type = rpcpb.TypeCreateResourcesReq
+	req.CreateResources.Scatter = scatter
 	for idx, res := range resources {
 		data, err := res.Marshal()
 		if err != nil {
@@ -508,9 +596,149 @@ func (c *asyncClient) ExecuteJob(job metapb.Job, data []byte) ([]byte, error) {
 	return rsp.ExecuteJob.Data, nil
 }
 
+func (c *asyncClient) TransferLeader(resourceID, containerID uint64) error {
+	if !c.running() {
+		return ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeTransferLeaderReq
+	req.TransferLeader.ResourceID = resourceID
+	req.TransferLeader.ContainerID = containerID
+
+	_, err := c.syncDo(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *asyncClient) PutRateLimit(limit metapb.RateLimit) error {
+	if !c.running() {
+		return ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypePutRateLimitReq
+	req.PutRateLimit.Limit = limit
+
+	_, err := c.syncDo(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *asyncClient) GetRateLimits() ([]metapb.RateLimit, error) {
+	if !c.running() {
+		return nil, ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeGetRateLimitsReq
+
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.GetRateLimits.Limits, nil
+}
+
+func (c *asyncClient) SetWriteFreeze(group uint64, frozen bool) error {
+	if !c.running() {
+		return ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeSetWriteFreezeReq
+	req.SetWriteFreeze.Group = group
+	req.SetWriteFreeze.Frozen = frozen
+
+	_, err := c.syncDo(req)
+	if err != nil {
+		return err
+	}
+
+	timeoutC := time.After(writeFreezeTimeout)
+	for {
+		req := &rpcpb.Request{}
+		req.Type = rpcpb.TypeGetWriteFrozenGroupsReq
+		rsp, err := c.syncDo(req)
+		if err != nil {
+			return err
+		}
+		if len(rsp.GetWriteFrozenGroups.PendingContainers) == 0 {
+			return nil
+		}
+
+		select {
+		case <-timeoutC:
+			return fmt.Errorf("write freeze of group %d not in effect on containers %+v",
+				group,
+				rsp.GetWriteFrozenGroups.PendingContainers)
+		case <-time.After(writeFreezeCheckInterval):
+		}
+	}
+}
+
+func (c *asyncClient) GetWriteFrozenGroups() ([]uint64, error) {
+	if !c.running() {
+		return nil, ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeGetWriteFrozenGroupsReq
+
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.GetWriteFrozenGroups.Groups, nil
+}
+
+func (c *asyncClient) DecommissionContainer(containerID uint64, rate uint64) error {
+	if !c.running() {
+		return ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeDecommissionContainerReq
+	req.DecommissionContainer.ContainerID = containerID
+	req.DecommissionContainer.Rate = rate
+
+	_, err := c.syncDo(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *asyncClient) GetDecommissionProgress(containerID uint64) (rpcpb.GetDecommissionProgressRsp, error) {
+	if !c.running() {
+		return rpcpb.GetDecommissionProgressRsp{}, ErrClosed
+	}
+
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeGetDecommissionProgressReq
+	req.GetDecommissionProgress.ContainerID = containerID
+
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return rpcpb.GetDecommissionProgressRsp{}, err
+	}
+
+	return rsp.GetDecommissionProgress, nil
+}
+
 func (c *asyncClient) start() {
 	go c.readLoop()
 	go c.writeLoop()
+	go c.timestampLoop()
 	c.scheduleResetLeaderConn()
 	util.GetLogger().Infof("client started")
 }
@@ -604,6 +832,55 @@ func (c *asyncClient) writeLoop() {
 	}
 }
 
+func (c *asyncClient) timestampLoop() {
+	var batch []*timestampRequest
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case req := <-c.timestampC:
+			batch = append(batch[:0], req)
+		DRAIN:
+			for len(batch) < maxTimestampBatch {
+				select {
+				case req := <-c.timestampC:
+					batch = append(batch, req)
+				default:
+					break DRAIN
+				}
+			}
+			c.doAllocTimestamp(batch)
+		}
+	}
+}
+
+// doAllocTimestamp allocates the timestamps for the batch by one request, the response
+// is the max timestamp of the allocated range.
+func (c *asyncClient) doAllocTimestamp(batch []*timestampRequest) {
+	req := &rpcpb.Request{}
+	req.Type = rpcpb.TypeAllocTimestampReq
+	req.AllocTimestamp.Count = uint32(len(batch))
+
+	for {
+		resp, err := c.syncDo(req)
+		if err != nil && c.running() &&
+			(err.Error() == tso.ErrNotReady.Error() || err.Error() == tso.ErrLogicalOverflow.Error()) {
+			time.Sleep(timestampRetryInterval)
+			continue
+		}
+
+		n := uint64(len(batch))
+		for i, r := range batch {
+			if err != nil {
+				r.c <- timestampResult{err: err}
+				continue
+			}
+			r.c <- timestampResult{ts: resp.AllocTimestamp.Timestamp - n + uint64(i) + 1}
+		}
+		return
+	}
+}
+
 func (c *asyncClient) readLoop() {
 	util.GetLogger().Info("client read loop started")
 	defer func() {
@@ -704,7 +981,7 @@ func (c *asyncClient) initLeaderConn(conn goetty.IOSession, timeout time.Duratio
 			if addr != "" {
 				util.GetLogger().Infof("client start init connection to leader %+s", addr)
 				conn.Close()
-				ok, err := conn.Connect(addr, c.opts.rpcTimeout)
+				ok, err := c.connect(conn, addr)
 				if err == nil && ok {
 					if registerContainer {
 						c.maybeRegisterContainer()
@@ -731,6 +1008,15 @@ func (c *asyncClient) initLeaderConn(conn goetty.IOSession, timeout time.Duratio
 	}
 }
 
+func (c *asyncClient) connect(conn goetty.IOSession, addr string) (bool, error) {
+	addr, err := c.opts.tls.DialAddr(addr)
+	if err != nil {
+		return false, err
+	}
+
+	return conn.Connect(addr, c.opts.rpcTimeout)
+}
+
 func (c *asyncClient) maybeRegisterContainer() {
 	if c.containerID > 0 {
 		req := &rpcpb.Request{}
@@ -15,6 +15,7 @@ package prophet
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,6 +71,35 @@ func TestClientGetContainer(t *testing.T) {
 	assert.Nil(t, value)
 }
 
+func TestClientAllocTimestamp(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	last, err := c.AllocTimestamp()
+	assert.NoError(t, err)
+	assert.True(t, last > 0)
+
+	n := 100
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	values := make(map[uint64]struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ts, err := c.AllocTimestamp()
+			assert.NoError(t, err)
+			assert.True(t, ts > last)
+			mu.Lock()
+			values[ts] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, n, len(values))
+}
+
 func TestAsyncCreateResources(t *testing.T) {
 	p := newTestSingleProphet(t, nil)
 	defer p.Stop()
@@ -153,6 +184,148 @@ func TestPutPlacementRule(t *testing.T) {
 	assert.Equal(t, 1, len(rules))
 }
 
+func TestTransferLeader(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	for i := uint64(1); i <= 3; i++ {
+		assert.NoError(t, c.PutContainer(newTestContainerMeta(i)))
+		_, err := c.ContainerHeartbeat(newTestContainerHeartbeat(i, 1))
+		assert.NoError(t, err)
+	}
+
+	peer1 := metapb.Peer{ID: 1, ContainerID: 1}
+	peer2 := metapb.Peer{ID: 2, ContainerID: 2}
+	assert.NoError(t, c.ResourceHeartbeat(newTestResourceMeta(4, peer1, peer2), rpcpb.ResourceHeartbeatReq{
+		ContainerID: 1,
+		Leader:      &peer1}))
+
+	assert.Error(t, c.TransferLeader(5, 2))
+	assert.Error(t, c.TransferLeader(4, 3))
+	assert.NoError(t, c.TransferLeader(4, 1))
+	assert.Nil(t, p.(*defaultProphet).GetRaftCluster().GetOperatorController().GetOperator(4))
+
+	assert.NoError(t, c.TransferLeader(4, 2))
+	op := p.(*defaultProphet).GetRaftCluster().GetOperatorController().GetOperator(4)
+	assert.NotNil(t, op)
+	assert.Equal(t, operator.OpAdmin, op.Kind()&operator.OpAdmin)
+}
+
+func TestRateLimits(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.PutRateLimit(metapb.RateLimit{Group: 1, Tenant: 2, Requests: 100}))
+	assert.NoError(t, c.PutRateLimit(metapb.RateLimit{Group: 1, Tenant: 1, Bytes: 1024}))
+	limits, err := c.GetRateLimits()
+	assert.NoError(t, err)
+	assert.Equal(t, []metapb.RateLimit{{Group: 1, Tenant: 1, Bytes: 1024}, {Group: 1, Tenant: 2, Requests: 100}}, limits)
+
+	assert.NoError(t, c.PutContainer(newTestContainerMeta(1)))
+	rsp, err := c.ContainerHeartbeat(newTestContainerHeartbeat(1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, limits, rsp.RateLimits)
+
+	// both 0 means no limit
+	assert.NoError(t, c.PutRateLimit(metapb.RateLimit{Group: 1, Tenant: 1}))
+	limits, err = c.GetRateLimits()
+	assert.NoError(t, err)
+	assert.Equal(t, []metapb.RateLimit{{Group: 1, Tenant: 2, Requests: 100}}, limits)
+}
+
+func TestWriteFreeze(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.SetWriteFreeze(2, true))
+	assert.NoError(t, c.SetWriteFreeze(1, true))
+	groups, err := c.GetWriteFrozenGroups()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, groups)
+
+	assert.NoError(t, c.PutContainer(newTestContainerMeta(1)))
+	rsp, err := c.ContainerHeartbeat(newTestContainerHeartbeat(1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, groups, rsp.WriteFrozenGroups)
+
+	w, err := c.NewWatcher(event.EventWriteFreeze)
+	assert.NoError(t, err)
+	defer w.Close()
+
+	// the change is pushed to the watchers, and SetWriteFreeze returns after all the containers
+	// put it in effect
+	errC := make(chan error, 1)
+	go func() {
+		errC <- c.SetWriteFreeze(1, false)
+	}()
+	for e := range w.GetNotify() {
+		// the first event is the response of the watcher created
+		if e.Type == event.EventWriteFreeze {
+			assert.Equal(t, []uint64{2}, e.WriteFrozenGroups)
+			break
+		}
+	}
+	select {
+	case err := <-errC:
+		assert.FailNowf(t, "", "write freeze returned before in effect with %+v", err)
+	case <-time.After(time.Millisecond * 300):
+	}
+
+	hb := newTestContainerHeartbeat(1, 1)
+	hb.WriteFrozenGroups = []uint64{2}
+	_, err = c.ContainerHeartbeat(hb)
+	assert.NoError(t, err)
+	select {
+	case err := <-errC:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		assert.FailNow(t, "timeout")
+	}
+
+	groups, err = c.GetWriteFrozenGroups()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2}, groups)
+}
+
+func TestDecommissionContainer(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.PutContainer(newTestContainerMeta(1)))
+	progress, err := c.GetDecommissionProgress(1)
+	assert.NoError(t, err)
+	assert.Equal(t, metapb.ContainerState_UP, progress.State)
+
+	assert.NoError(t, c.DecommissionContainer(1, 10))
+	progress, err = c.GetDecommissionProgress(1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, metapb.ContainerState_UP, progress.State)
+	assert.Equal(t, uint64(0), progress.ResourceCount)
+	assert.Equal(t, uint64(0), progress.LeaderCount)
+
+	_, err = c.GetDecommissionProgress(2)
+	assert.Error(t, err)
+}
+
+func TestAsyncAddAndScatterResources(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	for id := uint64(1); id <= 3; id++ {
+		assert.NoError(t, c.PutContainer(newTestContainerMeta(id)))
+		_, err := c.ContainerHeartbeat(newTestContainerHeartbeat(id, 1))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, c.AsyncAddAndScatterResources(newTestResourceMeta(1)))
+	assert.Equal(t, 1, len(p.(*defaultProphet).GetRaftCluster().GetScatterResources()))
+}
+
 func TestIssue106(t *testing.T) {
 	cluster := newTestClusterProphet(t, 3, func(c *config.Config) {
 		c.RPCTimeout.Duration = time.Millisecond * 200
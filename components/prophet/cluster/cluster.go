@@ -103,11 +103,16 @@ type RaftCluster struct {
 	coordinator      *coordinator
 	suspectResources *cache.TTLUint64 // suspectResources are resources that may need fix
 	suspectKeyRanges *cache.TTLString // suspect key-range resources that may need fix
+	scatterResources *cache.TTLUint64 // scatterResources are created resources waiting to be scattered
 
 	wg   sync.WaitGroup
 	quit chan struct{}
 
-	ruleManager                 *placement.RuleManager
+	ruleManager       *placement.RuleManager
+	rateLimits        map[rateLimitKey]metapb.RateLimit
+	writeFrozenGroups map[uint64]struct{}
+	// containerWriteFrozenGroups the write frozen groups in effect reported by the containers
+	containerWriteFrozenGroups  map[uint64][]uint64
 	etcdClient                  *clientv3.Client
 	adapter                     metadata.Adapter
 	resourceStateChangedHandler func(res metadata.Resource, from metapb.ResourceState, to metapb.ResourceState)
@@ -149,9 +154,13 @@ func (c *RaftCluster) InitCluster(opt *config.PersistOptions, storage storage.St
 	c.prepareChecker = newPrepareChecker()
 	c.suspectResources = cache.NewIDTTL(c.ctx, time.Minute, 3*time.Minute)
 	c.suspectKeyRanges = cache.NewStringTTL(c.ctx, time.Minute, 3*time.Minute)
+	c.scatterResources = cache.NewIDTTL(c.ctx, time.Minute, 10*time.Minute)
 
 	c.changedEvents = make(chan rpcpb.EventNotify, defaultChangedEventLimit)
 	c.createResourceC = make(chan struct{}, 1)
+	c.rateLimits = make(map[rateLimitKey]metapb.RateLimit)
+	c.writeFrozenGroups = make(map[uint64]struct{})
+	c.containerWriteFrozenGroups = make(map[uint64][]uint64)
 }
 
 // Start starts a cluster.
@@ -175,7 +184,7 @@ func (c *RaftCluster) Start(s Server) error {
 
 	c.ruleManager = placement.NewRuleManager(c.storage, c)
 	if c.opt.IsPlacementRulesEnabled() {
-		err = c.ruleManager.Initialize(c.opt.GetMaxReplicas(), c.opt.GetLocationLabels())
+		err = c.ruleManager.Initialize(c.opt.GetMaxReplicas(), c.opt.GetWitnessReplicas(), c.opt.GetLocationLabels())
 		if err != nil {
 			return err
 		}
@@ -219,6 +228,18 @@ func (c *RaftCluster) LoadClusterInfo() (*RaftCluster, error) {
 		c.GetResourceCount(),
 		time.Since(start))
 
+	if err := c.storage.LoadRateLimits(batch, func(limit metapb.RateLimit) {
+		c.rateLimits[rateLimitKey{group: limit.Group, tenant: limit.Tenant}] = limit
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.storage.LoadWriteFrozenGroups(batch, func(group uint64) {
+		c.writeFrozenGroups[group] = struct{}{}
+	}); err != nil {
+		return nil, err
+	}
+
 	for _, container := range c.GetContainers() {
 		c.hotStat.GetOrCreateRollingContainerStats(container.Meta.ID())
 	}
@@ -366,6 +387,20 @@ func (c *RaftCluster) RemoveSuspectResource(id uint64) {
 	c.suspectResources.Remove(id)
 }
 
+// GetScatterResources gets all the created resources waiting to be scattered.
+func (c *RaftCluster) GetScatterResources() []uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	return c.scatterResources.GetAllID()
+}
+
+// RemoveScatterResource removes resource from scatter list.
+func (c *RaftCluster) RemoveScatterResource(id uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.scatterResources.Remove(id)
+}
+
 // AddSuspectKeyRange adds the key range with the its ruleID as the key
 // The instance of each keyRange is like following format:
 // [2][]byte: start key/end key
@@ -762,7 +762,7 @@ func newTestCluster(opt *config.PersistOptions) *testCluster {
 	rc := newTestRaftCluster(opt, storage, core.NewBasicCluster(metadata.TestResourceFactory))
 	rc.ruleManager = placement.NewRuleManager(storage, rc)
 	if opt.IsPlacementRulesEnabled() {
-		err := rc.ruleManager.Initialize(opt.GetMaxReplicas(), opt.GetLocationLabels())
+		err := rc.ruleManager.Initialize(opt.GetMaxReplicas(), opt.GetWitnessReplicas(), opt.GetLocationLabels())
 		if err != nil {
 			panic(err)
 		}
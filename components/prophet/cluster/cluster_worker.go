@@ -18,13 +18,16 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
 	"github.com/matrixorigin/matrixcube/components/prophet/event"
+	"github.com/matrixorigin/matrixcube/components/prophet/limit"
 	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/placement"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
 )
@@ -351,6 +354,12 @@ func (c *RaftCluster) HandleCreateResources(request *rpcpb.Request) (*rpcpb.Crea
 	}
 
 	c.core.AddWaittingCreateResources(createResources...)
+	if request.CreateResources.Scatter {
+		// the resources are scattered by the coordinator after they are created and replicated
+		for _, res := range createResources {
+			c.scatterResources.Put(res.ID(), nil)
+		}
+	}
 	c.triggerNotifyCreateResources()
 	return &rpcpb.CreateResourcesRsp{}, nil
 }
@@ -429,3 +438,208 @@ func (c *RaftCluster) HandleAppliedRules(request *rpcpb.Request) (*rpcpb.GetAppl
 		Rules: placement.RPCRules(rules),
 	}, nil
 }
+
+// HandleTransferLeader handle transfer the leader of the resource to the target container
+func (c *RaftCluster) HandleTransferLeader(request *rpcpb.Request) (*rpcpb.TransferLeaderRsp, error) {
+	res := c.GetResource(request.TransferLeader.ResourceID)
+	if res == nil {
+		return nil, fmt.Errorf("resource %d not found", request.TransferLeader.ResourceID)
+	}
+
+	target := request.TransferLeader.ContainerID
+	if _, ok := res.GetContainerPeer(target); !ok {
+		return nil, fmt.Errorf("resource %d has no peer on container %d",
+			request.TransferLeader.ResourceID, target)
+	}
+	if res.GetLeader().GetContainerID() == target {
+		return &rpcpb.TransferLeaderRsp{}, nil
+	}
+
+	op, err := operator.CreateTransferLeaderOperator("admin-transfer-leader", c, res,
+		res.GetLeader().GetContainerID(), target, operator.OpAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !c.GetOperatorController().AddOperator(op) {
+		return nil, fmt.Errorf("resource %d transfer leader to container %d failed, operator not added",
+			request.TransferLeader.ResourceID, target)
+	}
+
+	return &rpcpb.TransferLeaderRsp{}, nil
+}
+
+type rateLimitKey struct {
+	group  uint64
+	tenant uint64
+}
+
+// HandlePutRateLimit handle put the rate limit of the tenant in the group, the limit
+// is removed if both the requests and the bytes are 0
+func (c *RaftCluster) HandlePutRateLimit(request *rpcpb.Request) error {
+	limit := request.PutRateLimit.Limit
+	key := rateLimitKey{group: limit.Group, tenant: limit.Tenant}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if limit.Requests == 0 && limit.Bytes == 0 {
+		if err := c.storage.RemoveRateLimit(limit.Group, limit.Tenant); err != nil {
+			return err
+		}
+		delete(c.rateLimits, key)
+		return nil
+	}
+
+	if err := c.storage.PutRateLimit(limit); err != nil {
+		return err
+	}
+	c.rateLimits[key] = limit
+	return nil
+}
+
+// HandleGetRateLimits handle get all rate limits
+func (c *RaftCluster) HandleGetRateLimits(request *rpcpb.Request) (*rpcpb.GetRateLimitsRsp, error) {
+	return &rpcpb.GetRateLimitsRsp{Limits: c.GetRateLimits()}, nil
+}
+
+// GetRateLimits returns all rate limits ordered by group and tenant
+func (c *RaftCluster) GetRateLimits() []metapb.RateLimit {
+	c.RLock()
+	defer c.RUnlock()
+
+	limits := make([]metapb.RateLimit, 0, len(c.rateLimits))
+	for _, limit := range c.rateLimits {
+		limits = append(limits, limit)
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Group == limits[j].Group {
+			return limits[i].Tenant < limits[j].Tenant
+		}
+		return limits[i].Group < limits[j].Group
+	})
+	return limits
+}
+
+// HandleSetWriteFreeze handle freeze or unfreeze the writes of the group, the change is pushed
+// to the containers by the watchers.
+func (c *RaftCluster) HandleSetWriteFreeze(request *rpcpb.Request) error {
+	group := request.SetWriteFreeze.Group
+
+	c.Lock()
+	defer c.Unlock()
+
+	if !request.SetWriteFreeze.Frozen {
+		if err := c.storage.RemoveWriteFrozenGroup(group); err != nil {
+			return err
+		}
+		delete(c.writeFrozenGroups, group)
+	} else {
+		if err := c.storage.PutWriteFrozenGroup(group); err != nil {
+			return err
+		}
+		c.writeFrozenGroups[group] = struct{}{}
+	}
+
+	c.changedEvents <- event.NewWriteFreezeEvent(c.getWriteFrozenGroupsLocked())
+	return nil
+}
+
+// HandleContainerWriteFrozenGroups handle the write frozen groups in effect on the container
+func (c *RaftCluster) HandleContainerWriteFrozenGroups(containerID uint64, groups []uint64) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.containerWriteFrozenGroups[containerID] = append([]uint64(nil), groups...)
+}
+
+// HandleGetWriteFrozenGroups handle get all write frozen groups, and the containers which have
+// not put the groups in effect. The tombstone and disconnected containers are skipped.
+func (c *RaftCluster) HandleGetWriteFrozenGroups(request *rpcpb.Request) (*rpcpb.GetWriteFrozenGroupsRsp, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	rsp := &rpcpb.GetWriteFrozenGroupsRsp{Groups: c.getWriteFrozenGroupsLocked()}
+	for _, container := range c.core.GetContainers() {
+		if container.IsTombstone() || container.IsDisconnected() {
+			continue
+		}
+
+		id := container.Meta.ID()
+		if !equalGroups(rsp.Groups, c.containerWriteFrozenGroups[id]) {
+			rsp.PendingContainers = append(rsp.PendingContainers, id)
+		}
+	}
+	sort.Slice(rsp.PendingContainers, func(i, j int) bool {
+		return rsp.PendingContainers[i] < rsp.PendingContainers[j]
+	})
+	return rsp, nil
+}
+
+// GetWriteFrozenGroups returns all write frozen groups in ascending order
+func (c *RaftCluster) GetWriteFrozenGroups() []uint64 {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.getWriteFrozenGroupsLocked()
+}
+
+func (c *RaftCluster) getWriteFrozenGroupsLocked() []uint64 {
+	groups := make([]uint64, 0, len(c.writeFrozenGroups))
+	for group := range c.writeFrozenGroups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i] < groups[j]
+	})
+	return groups
+}
+
+// HandleDecommissionContainer handle decommission the container. The container is marked as
+// offline, the checkers transfer the leaders and move the peers away from it, at most rate peers
+// are removed per minute, and the container turns into tombstone once it holds no resources. The
+// configured store limit is kept if the rate is 0.
+func (c *RaftCluster) HandleDecommissionContainer(request *rpcpb.Request) error {
+	containerID := request.DecommissionContainer.ContainerID
+	// RemoveContainer lifts the remove peer limit of the offline container
+	rate := c.opt.GetContainerLimitByType(containerID, limit.RemovePeer)
+	if request.DecommissionContainer.Rate > 0 {
+		rate = float64(request.DecommissionContainer.Rate)
+	}
+	if err := c.RemoveContainer(containerID, false); err != nil {
+		return err
+	}
+
+	return c.SetContainerLimit(containerID, limit.RemovePeer, rate)
+}
+
+// HandleGetDecommissionProgress handle get the state and the remaining resources of the container
+func (c *RaftCluster) HandleGetDecommissionProgress(request *rpcpb.Request) (*rpcpb.GetDecommissionProgressRsp, error) {
+	containerID := request.GetDecommissionProgress.ContainerID
+	container := c.GetContainer(containerID)
+	if container == nil {
+		return nil, fmt.Errorf("container %d not found", containerID)
+	}
+
+	return &rpcpb.GetDecommissionProgressRsp{
+		State:         container.GetState(),
+		ResourceCount: uint64(c.core.GetContainerResourceCount(containerID)),
+		LeaderCount:   uint64(c.core.GetContainerLeaderCount(containerID)),
+	}, nil
+}
+
+func equalGroups(sorted []uint64, groups []uint64) bool {
+	if len(sorted) != len(groups) {
+		return false
+	}
+
+	values := make(map[uint64]struct{}, len(groups))
+	for _, group := range groups {
+		values[group] = struct{}{}
+	}
+	for _, group := range sorted {
+		if _, ok := values[group]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
 	"github.com/matrixorigin/matrixcube/components/prophet/event"
+	"github.com/matrixorigin/matrixcube/components/prophet/limit"
 	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
 	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockhbstream"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
@@ -167,6 +168,40 @@ func TestCreateResourcesRestart(t *testing.T) {
 	assert.True(t, e.ResourceEvent.Create)
 }
 
+func TestCreateResourcesWithScatter(t *testing.T) {
+	cluster, co, cleanup := prepare(t, nil, nil, nil)
+	defer cleanup()
+
+	cluster.coordinator = co
+	cluster.addResourceContainer(1, 1)
+	cluster.addResourceContainer(2, 1)
+	cluster.addResourceContainer(3, 1)
+
+	res := newTestResourceMeta(1)
+	res.SetUnique("res1")
+	data, err := res.Marshal()
+	assert.NoError(t, err)
+	req := &rpcpb.Request{}
+	req.CreateResources.Resources = append(req.CreateResources.Resources, data)
+	_, err = cluster.HandleCreateResources(req)
+	assert.NoError(t, err)
+	assert.Empty(t, cluster.GetScatterResources())
+
+	res = newTestResourceMeta(2)
+	res.SetUnique("res2")
+	data, err = res.Marshal()
+	assert.NoError(t, err)
+	req = &rpcpb.Request{}
+	req.CreateResources.Resources = append(req.CreateResources.Resources, data)
+	req.CreateResources.Scatter = true
+	_, err = cluster.HandleCreateResources(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(cluster.core.WaittingCreateResources))
+	ids := cluster.GetScatterResources()
+	assert.Equal(t, 1, len(ids))
+	assert.Equal(t, "res2", cluster.core.WaittingCreateResources[ids[0]].Unique())
+}
+
 func TestRemoveResources(t *testing.T) {
 	_, opt, err := newTestScheduleConfig()
 	assert.NoError(t, err)
@@ -319,3 +354,56 @@ func checkNotifyCount(t *testing.T, nc <-chan rpcpb.EventNotify, expectNotifyTyp
 		}
 	}
 }
+
+func TestHandleDecommissionContainer(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	assert.NoError(t, err)
+	cluster := newTestRaftCluster(opt, storage.NewTestStorage(), core.NewBasicCluster(metadata.TestResourceFactory))
+	for _, container := range newTestContainers(3, "2.0.0") {
+		assert.NoError(t, cluster.PutContainer(container.Meta))
+	}
+	// container 1 has the follower of resource 0 and the leader of resource 1
+	resources := newTestResources(3, 2)
+	for _, res := range resources {
+		assert.NoError(t, cluster.processResourceHeartbeat(res))
+	}
+
+	assert.NoError(t, cluster.HandleDecommissionContainer(&rpcpb.Request{
+		DecommissionContainer: rpcpb.DecommissionContainerReq{ContainerID: 1, Rate: 5},
+	}))
+	assert.True(t, cluster.GetContainer(1).IsOffline())
+	assert.Equal(t, float64(5), opt.GetContainerLimitByType(1, limit.RemovePeer))
+
+	req := &rpcpb.Request{GetDecommissionProgress: rpcpb.GetDecommissionProgressReq{ContainerID: 1}}
+	rsp, err := cluster.HandleGetDecommissionProgress(req)
+	assert.NoError(t, err)
+	assert.Equal(t, rpcpb.GetDecommissionProgressRsp{State: metapb.ContainerState_Offline, ResourceCount: 2, LeaderCount: 1}, *rsp)
+
+	// the container is not buried until it holds no resources
+	cluster.checkContainers()
+	assert.True(t, cluster.GetContainer(1).IsOffline())
+
+	assert.NoError(t, cluster.processResourceHeartbeat(resources[0].Clone(core.WithRemoveContainerPeer(1), core.WithIncConfVer())))
+	res := resources[1].Clone(core.WithRemoveContainerPeer(1), core.WithIncConfVer())
+	res = res.Clone(core.WithLeader(&res.Meta.Peers()[0]))
+	assert.NoError(t, cluster.processResourceHeartbeat(res))
+	cluster.checkContainers()
+	rsp, err = cluster.HandleGetDecommissionProgress(req)
+	assert.NoError(t, err)
+	assert.Equal(t, rpcpb.GetDecommissionProgressRsp{State: metapb.ContainerState_Tombstone}, *rsp)
+
+	// 0 keeps the configured limit
+	configured := opt.GetContainerLimitByType(2, limit.RemovePeer)
+	assert.NoError(t, cluster.HandleDecommissionContainer(&rpcpb.Request{
+		DecommissionContainer: rpcpb.DecommissionContainerReq{ContainerID: 2},
+	}))
+	assert.Equal(t, configured, opt.GetContainerLimitByType(2, limit.RemovePeer))
+
+	assert.Error(t, cluster.HandleDecommissionContainer(&rpcpb.Request{
+		DecommissionContainer: rpcpb.DecommissionContainerReq{ContainerID: 4},
+	}))
+	_, err = cluster.HandleGetDecommissionProgress(&rpcpb.Request{
+		GetDecommissionProgress: rpcpb.GetDecommissionProgressReq{ContainerID: 4},
+	})
+	assert.Error(t, err)
+}
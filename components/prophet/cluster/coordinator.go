@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -114,6 +115,8 @@ func (c *coordinator) patrolResources() {
 		c.checkSuspectKeyRanges()
 		// Check resources in the waiting list
 		c.checkWaitingResources()
+		// Scatter the created resources
+		c.checkScatterResources()
 
 		for _, group := range c.cluster.GetReplicationConfig().Groups {
 			resources := c.cluster.ScanResources(group, key, nil, patrolScanResourceLimit)
@@ -230,6 +233,31 @@ func (c *coordinator) checkWaitingResources() {
 	}
 }
 
+// checkScatterResources scatters the created resources once they are replicated and have a leader.
+// The resources of the same group are scattered together, so their peers and leaders are spread
+// across the containers.
+func (c *coordinator) checkScatterResources() {
+	for _, id := range c.cluster.GetScatterResources() {
+		res := c.cluster.GetResource(id)
+		if res == nil {
+			// the resource is not created on the containers yet, continue to wait.
+			continue
+		}
+		if c.opController.GetOperator(id) != nil {
+			continue
+		}
+		op, err := c.resourceScatterer.Scatter(res, strconv.FormatUint(res.Meta.Group(), 10))
+		if err != nil {
+			// the resource is not ready to scatter, retry at the next patrol.
+			continue
+		}
+		if op != nil {
+			c.opController.AddWaitingOperator(op)
+		}
+		c.cluster.RemoveScatterResource(id)
+	}
+}
+
 // drivePushOperator is used to push the unfinished operator to the executor.
 func (c *coordinator) drivePushOperator() {
 	defer func() {
@@ -17,6 +17,7 @@ package cluster
 import (
 	"context"
 	"math/rand"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -530,11 +531,12 @@ func TestAddScheduler(t *testing.T) {
 	tc, co, cleanup := prepare(t, nil, nil, func(co *coordinator) { co.run() })
 	defer cleanup()
 
-	assert.Equal(t, 4, len(co.schedulers))
+	assert.Equal(t, 5, len(co.schedulers))
 	assert.Nil(t, co.removeScheduler(schedulers.BalanceLeaderName))
 	assert.Nil(t, co.removeScheduler(schedulers.BalanceResourceName))
 	assert.Nil(t, co.removeScheduler(schedulers.HotResourceName))
 	assert.Nil(t, co.removeScheduler(schedulers.LabelName))
+	assert.Nil(t, co.removeScheduler(schedulers.EvictSlowStoreName))
 	assert.Empty(t, co.schedulers)
 
 	stream := mockhbstream.NewHeartbeatStream()
@@ -586,23 +588,24 @@ func TestRemoveScheduler(t *testing.T) {
 	assert.Nil(t, tc.addLeaderContainer(1, 1))
 	assert.Nil(t, tc.addLeaderContainer(2, 1))
 
-	assert.Equal(t, 4, len(co.schedulers))
+	assert.Equal(t, 5, len(co.schedulers))
 	oc := co.opController
 	storage := tc.RaftCluster.storage
 
 	gls1, err := schedule.CreateScheduler(schedulers.GrantLeaderType, oc, storage, schedule.ConfigSliceDecoder(schedulers.GrantLeaderType, []string{"1"}))
 	assert.Nil(t, err)
 	assert.Nil(t, co.addScheduler(gls1, "1"))
-	assert.Equal(t, 5, len(co.schedulers))
+	assert.Equal(t, 6, len(co.schedulers))
 	sches, _, err := storage.LoadAllScheduleConfig()
 	assert.Nil(t, err)
-	assert.Equal(t, 5, len(sches))
+	assert.Equal(t, 6, len(sches))
 
 	// remove all schedulers
 	assert.Nil(t, co.removeScheduler(schedulers.BalanceLeaderName))
 	assert.Nil(t, co.removeScheduler(schedulers.BalanceResourceName))
 	assert.Nil(t, co.removeScheduler(schedulers.HotResourceName))
 	assert.Nil(t, co.removeScheduler(schedulers.LabelName))
+	assert.Nil(t, co.removeScheduler(schedulers.EvictSlowStoreName))
 	assert.Nil(t, co.removeScheduler(schedulers.GrantLeaderName))
 	// all removed
 	sches, _, err = storage.LoadAllScheduleConfig()
@@ -1031,3 +1034,36 @@ func waitNoResponse(t *testing.T, stream mockhbstream.HeartbeatStream) {
 		return res == nil
 	})
 }
+
+func TestCheckScatterResources(t *testing.T) {
+	tc, co, cleanup := prepare(t, nil, nil, nil)
+	defer cleanup()
+
+	for id := uint64(1); id <= 6; id++ {
+		assert.Nil(t, tc.addResourceContainer(id, 0))
+	}
+	for id := uint64(1); id <= 4; id++ {
+		assert.Nil(t, tc.addLeaderResource(id, 1, 2, 3))
+		tc.scatterResources.Put(id, nil)
+	}
+	// resource 5 is not created, resource 6 is not replicated
+	tc.scatterResources.Put(5, nil)
+	assert.Nil(t, tc.addLeaderResource(6, 1))
+	tc.scatterResources.Put(6, nil)
+
+	co.checkScatterResources()
+	assert.Equal(t, []uint64{5, 6}, sortedIDs(tc.GetScatterResources()))
+	ops := 0
+	for id := uint64(1); id <= 4; id++ {
+		if op := co.opController.GetOperator(id); op != nil {
+			assert.Equal(t, "scatter-resource", op.Desc())
+			ops++
+		}
+	}
+	assert.True(t, ops > 0)
+}
+
+func sortedIDs(ids []uint64) []uint64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/components/prophet/storage"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/vfs"
 	"go.etcd.io/etcd/server/v3/embed"
@@ -37,6 +38,12 @@ type Config struct {
 	DataDir    string            `toml:"data-dir"`
 	RPCAddr    string            `toml:"rpc-addr"`
 	RPCTimeout typeutil.Duration `toml:"rpc-timeout"`
+	// HTTPAddr the address of the http admin api, the api only serves on the
+	// prophet leader. Empty means the http admin api is disabled. The api serves
+	// by https with the TLS config if the TLS is enabled.
+	HTTPAddr string `toml:"http-addr"`
+	// TLS the tls config of the rpc and the http admin api of the prophet
+	TLS tlsutil.Config `toml:"tls"`
 
 	// etcd configuration
 	StorageNode  bool            `toml:"storage-node"`
@@ -280,6 +287,7 @@ var DefaultSchedulers = SchedulerConfigs{
 	{Type: "balance-leader"},
 	{Type: "hot-resource"},
 	{Type: "label"},
+	{Type: "evict-slow-store"},
 }
 
 // IsDefaultScheduler checks whether the scheduler is enable by default.
@@ -410,6 +418,9 @@ func (c *ScheduleConfig) Validate() error {
 type ReplicationConfig struct {
 	// MaxReplicas is the number of replicas for each resource.
 	MaxReplicas uint64 `toml:"max-replicas" json:"max-replicas"`
+	// WitnessReplicas is the number of witness replicas in MaxReplicas for each resource.
+	// The witness only persists the raft log and never becomes the leader.
+	WitnessReplicas uint64 `toml:"witness-replicas" json:"witness-replicas"`
 
 	// The label keys specified the location of a container.
 	// The placement priorities is implied by the order of label keys.
@@ -460,6 +471,10 @@ func (c *ReplicationConfig) Validate() error {
 	if c.IsolationLevel != "" && !foundIsolationLevel {
 		return errors.New("isolation-level must be one of location-labels or empty")
 	}
+	// the data replicas must be the majority to make sure the committed data is not lost
+	if c.WitnessReplicas > 0 && c.WitnessReplicas*2 >= c.MaxReplicas {
+		return errors.New("witness-replicas must be less than half of max-replicas")
+	}
 	return nil
 }
 
@@ -130,6 +130,18 @@ func (o *PersistOptions) GetMaxReplicas() int {
 	return int(o.GetReplicationConfig().MaxReplicas)
 }
 
+// GetWitnessReplicas returns the number of witness replicas for each resource.
+func (o *PersistOptions) GetWitnessReplicas() int {
+	return int(o.GetReplicationConfig().WitnessReplicas)
+}
+
+// SetWitnessReplicas sets the number of witness replicas for each resource.
+func (o *PersistOptions) SetWitnessReplicas(replicas int) {
+	v := o.GetReplicationConfig().Clone()
+	v.WitnessReplicas = uint64(replicas)
+	o.SetReplicationConfig(v)
+}
+
 // SetMaxReplicas sets the number of replicas for each resource.
 func (o *PersistOptions) SetMaxReplicas(replicas int) {
 	v := o.GetReplicationConfig().Clone()
@@ -173,7 +173,11 @@ func (bc *BasicCluster) GetFollowerContainers(res *CachedResource) []*CachedCont
 	bc.RLock()
 	defer bc.RUnlock()
 	var containers []*CachedContainer
-	for id := range res.GetFollowers() {
+	for id, peer := range res.GetFollowers() {
+		// the witness can not be the leader
+		if peer.Role == metapb.PeerRole_Witness {
+			continue
+		}
 		if container := bc.Containers.GetContainer(id); container != nil {
 			containers = append(containers, container)
 		}
@@ -22,6 +22,18 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/util/movingaverage"
 )
 
+const (
+	// RaftAppendLatencyKey the key of the average raft log append latency in milliseconds
+	// in the OpLatencies of the container stats.
+	RaftAppendLatencyKey = "raft-append"
+	// RaftApplyLatencyKey the key of the average raft log apply latency in milliseconds
+	// in the OpLatencies of the container stats.
+	RaftApplyLatencyKey = "raft-apply"
+	// SlowScoreKey the key of the slow score in the OpLatencies of the container stats. The
+	// slow score is in [1, 100], and the higher score means the slower container.
+	SlowScoreKey = "slow-score"
+)
+
 type containerStats struct {
 	mu       sync.RWMutex
 	rawStats *metapb.ContainerStats
@@ -123,6 +135,18 @@ func (ss *containerStats) IsBusy() bool {
 	return ss.rawStats.GetIsBusy()
 }
 
+// GetSlowScore returns the slow score of the container, 0 if the container does not report it.
+func (ss *containerStats) GetSlowScore() uint64 {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	for _, v := range ss.rawStats.GetOpLatencies() {
+		if v.Key == SlowScoreKey {
+			return v.Value
+		}
+	}
+	return 0
+}
+
 // GetSendingSnapCount returns the current sending snapshot count of the container.
 func (ss *containerStats) GetSendingSnapCount() uint64 {
 	ss.mu.RLock()
@@ -30,6 +30,8 @@ var (
 	EventResourceStats uint32 = 1 << 4
 	// EventContainerStats container stats
 	EventContainerStats uint32 = 1 << 5
+	// EventWriteFreeze write frozen groups changed
+	EventWriteFreeze uint32 = 1 << 6
 	// EventFlagAll all event
 	EventFlagAll = 0xffffffff
 )
@@ -120,3 +122,11 @@ func NewContainerEvent(target metadata.Container) rpcpb.EventNotify {
 		},
 	}
 }
+
+// NewWriteFreezeEvent create write freeze event with all the write frozen groups
+func NewWriteFreezeEvent(groups []uint64) rpcpb.EventNotify {
+	return rpcpb.EventNotify{
+		Type:              EventWriteFreeze,
+		WriteFrozenGroups: groups,
+	}
+}
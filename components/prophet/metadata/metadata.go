@@ -122,6 +122,11 @@ func IsLearner(peer metapb.Peer) bool {
 	return peer.Role == metapb.PeerRole_Learner
 }
 
+// IsWitness judges whether the Peer's Role is Witness.
+func IsWitness(peer metapb.Peer) bool {
+	return peer.Role == metapb.PeerRole_Witness
+}
+
 // IsVoterOrIncomingVoter judges whether peer role will become Voter.
 // The peer is not nil and the role is equal to IncomingVoter or Voter.
 func IsVoterOrIncomingVoter(peer metapb.Peer) bool {
@@ -161,7 +161,7 @@ func (mc *Cluster) AllocPeer(containerID uint64) (metapb.Peer, error) {
 func (mc *Cluster) initRuleManager() {
 	if mc.RuleManager == nil {
 		mc.RuleManager = placement.NewRuleManager(mc.storage, mc)
-		mc.RuleManager.Initialize(int(mc.GetReplicationConfig().MaxReplicas), mc.GetReplicationConfig().LocationLabels)
+		mc.RuleManager.Initialize(int(mc.GetReplicationConfig().MaxReplicas), int(mc.GetReplicationConfig().WitnessReplicas), mc.GetReplicationConfig().LocationLabels)
 	}
 }
 
@@ -224,6 +224,18 @@ func (mc *Cluster) SetContainerBusy(containerID uint64, busy bool) {
 	mc.PutContainer(newContainer)
 }
 
+// SetContainerSlowScore sets the slow score reported by the container heartbeat.
+func (mc *Cluster) SetContainerSlowScore(containerID uint64, score uint64) {
+	container := mc.GetContainer(containerID)
+	newStats := proto.Clone(container.GetContainerStats()).(*metapb.ContainerStats)
+	newStats.OpLatencies = []metapb.RecordPair{{Key: core.SlowScoreKey, Value: score}}
+	newContainer := container.Clone(
+		core.SetContainerStats(newStats),
+		core.SetLastHeartbeatTS(time.Now()),
+	)
+	mc.PutContainer(newContainer)
+}
+
 // AddLeaderContainer adds container with specified count of leader.
 func (mc *Cluster) AddLeaderContainer(containerID uint64, leaderCount int, leaderSizes ...int64) {
 	stats := &metapb.ContainerStats{}
@@ -20,6 +20,7 @@ import (
 	"github.com/fagongzi/goetty/buf"
 	"github.com/matrixorigin/matrixcube/components/prophet/codec"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"go.uber.org/zap"
 )
 
@@ -29,6 +30,7 @@ type Option func(*options)
 type options struct {
 	leaderGetter func() *metapb.Member
 	rpcTimeout   time.Duration
+	tls          *tlsutil.TLS
 }
 
 func (opts *options) adjust() {
@@ -51,10 +53,18 @@ func WithRPCTimeout(value time.Duration) Option {
 	}
 }
 
-func createConn() goetty.IOSession {
+// WithTLS set the tls to connect to the leader, the tls is closed by the caller
+func WithTLS(value *tlsutil.TLS) Option {
+	return func(opts *options) {
+		opts.tls = value
+	}
+}
+
+func createConn(tls *tlsutil.TLS) goetty.IOSession {
 	encoder, decoder := codec.NewClientCodec(10 * buf.MB)
-	return goetty.NewIOSession(goetty.WithCodec(encoder, decoder),
+	opts := []goetty.Option{goetty.WithCodec(encoder, decoder),
 		goetty.WithLogger(zap.L().Named("cube-prophet-client")),
-		goetty.WithEnableAsyncWrite(16))
+		goetty.WithEnableAsyncWrite(16)}
+	return goetty.NewIOSession(append(opts, tls.SessionOptions()...)...)
 
 }
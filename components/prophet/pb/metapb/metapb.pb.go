@@ -182,6 +182,9 @@ const (
 	PeerRole_Learner       PeerRole = 1
 	PeerRole_IncomingVoter PeerRole = 2
 	PeerRole_DemotingVoter PeerRole = 3
+	// Witness votes and persists the raft log, but holds no data and never
+	// becomes the leader.
+	PeerRole_Witness PeerRole = 4
 )
 
 var PeerRole_name = map[int32]string{
@@ -189,6 +192,7 @@ var PeerRole_name = map[int32]string{
 	1: "Learner",
 	2: "IncomingVoter",
 	3: "DemotingVoter",
+	4: "Witness",
 }
 
 var PeerRole_value = map[string]int32{
@@ -196,6 +200,7 @@ var PeerRole_value = map[string]int32{
 	"Learner":       1,
 	"IncomingVoter": 2,
 	"DemotingVoter": 3,
+	"Witness":       4,
 }
 
 func (x PeerRole) String() string {
@@ -278,6 +283,8 @@ const (
 	JobType_RemoveResource JobType = 0
 	// CreateResourcePool create resource pool
 	JobType_CreateResourcePool JobType = 1
+	// Backup backup the cluster
+	JobType_Backup JobType = 2
 	// CustomStartAt custom job
 	JobType_CustomStartAt JobType = 100
 )
@@ -285,12 +292,14 @@ const (
 var JobType_name = map[int32]string{
 	0:   "RemoveResource",
 	1:   "CreateResourcePool",
+	2:   "Backup",
 	100: "CustomStartAt",
 }
 
 var JobType_value = map[string]int32{
 	"RemoveResource":     0,
 	"CreateResourcePool": 1,
+	"Backup":             2,
 	"CustomStartAt":      100,
 }
 
@@ -1365,6 +1374,78 @@ func (m *ResourcePool) GetRangePrefix() []byte {
 	return nil
 }
 
+// RateLimit the rate limit of the requests of a tenant in a shard group, 0 means unlimited
+type RateLimit struct {
+	Group                uint64   `protobuf:"varint,1,opt,name=group,proto3" json:"group,omitempty"`
+	Tenant               uint64   `protobuf:"varint,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Requests             uint64   `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	Bytes                uint64   `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{14}
+}
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(m, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetGroup() uint64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *RateLimit) GetTenant() uint64 {
+	if m != nil {
+		return m.Tenant
+	}
+	return 0
+}
+
+func (m *RateLimit) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("metapb.Action", Action_name, Action_value)
 	proto.RegisterEnum("metapb.ResourceKind", ResourceKind_name, ResourceKind_value)
@@ -1390,92 +1471,97 @@ func init() {
 	proto.RegisterType((*RemoveResourceJob)(nil), "metapb.RemoveResourceJob")
 	proto.RegisterType((*ResourcePoolJob)(nil), "metapb.ResourcePoolJob")
 	proto.RegisterType((*ResourcePool)(nil), "metapb.ResourcePool")
+	proto.RegisterType((*RateLimit)(nil), "metapb.RateLimit")
 }
 
 func init() { proto.RegisterFile("metapb.proto", fileDescriptor_77b4d575d5a68dda) }
 
 var fileDescriptor_77b4d575d5a68dda = []byte{
-	// 1280 bytes of a gzipped FileDescriptorProto
+	// 1330 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xe1, 0x6e, 0xdb, 0x36,
-	0x10, 0x8e, 0x6c, 0xc5, 0xb1, 0xcf, 0x8e, 0xa3, 0x70, 0x45, 0x61, 0x14, 0x45, 0x1a, 0x68, 0x45,
-	0x11, 0x18, 0x5b, 0x5a, 0xa4, 0x45, 0x7f, 0x0c, 0xdb, 0x0f, 0x47, 0x31, 0x56, 0xb7, 0x69, 0x62,
-	0xc8, 0x71, 0xbb, 0xfd, 0x1b, 0x2d, 0x5d, 0x1c, 0xa2, 0x32, 0x29, 0x50, 0x74, 0x5a, 0xef, 0x19,
-	0x86, 0xbd, 0xcd, 0xde, 0xa1, 0x3f, 0xfb, 0x04, 0xc5, 0x96, 0x27, 0x19, 0x48, 0x49, 0xb6, 0x6c,
-	0xb7, 0xcb, 0xfe, 0xe9, 0xee, 0xbe, 0x3b, 0xde, 0x7d, 0xbc, 0x3b, 0x0a, 0x1a, 0x13, 0x54, 0x34,
-	0x1e, 0x1d, 0xc6, 0x52, 0x28, 0x41, 0x2a, 0xa9, 0x74, 0xef, 0xfb, 0x31, 0x53, 0x57, 0xd3, 0xd1,
-	0x61, 0x20, 0x26, 0x8f, 0xc7, 0x62, 0x2c, 0x1e, 0x1b, 0xf3, 0x68, 0x7a, 0x69, 0x24, 0x23, 0x98,
-	0xaf, 0xd4, 0xcd, 0xf5, 0x60, 0xdb, 0xc7, 0x44, 0x4c, 0x65, 0x80, 0xdd, 0x58, 0x04, 0x57, 0xa4,
-	0x05, 0x5b, 0x81, 0xe0, 0x97, 0x6f, 0x50, 0xb6, 0xac, 0x7d, 0xeb, 0xc0, 0xf6, 0x73, 0x51, 0x5b,
-	0xae, 0x51, 0x26, 0x4c, 0xf0, 0x56, 0x29, 0xb5, 0x64, 0xa2, 0xfb, 0x87, 0x05, 0x76, 0x1f, 0x51,
-	0x92, 0xbb, 0x50, 0x62, 0x61, 0xea, 0x77, 0x5c, 0xb9, 0xf9, 0xfc, 0xa0, 0xd4, 0x3b, 0xf1, 0x4b,
-	0x2c, 0x24, 0xfb, 0x50, 0x0f, 0x04, 0x57, 0x94, 0x71, 0x94, 0xbd, 0x93, 0xcc, 0xbd, 0xa8, 0x22,
-	0x0f, 0xc1, 0x96, 0x22, 0xc2, 0x56, 0x79, 0xdf, 0x3a, 0x68, 0x1e, 0x39, 0x87, 0x59, 0x6d, 0x3a,
-	0xaa, 0x2f, 0x22, 0xf4, 0x8d, 0x95, 0x3c, 0x84, 0x6d, 0xc6, 0x99, 0x62, 0x34, 0x7a, 0x8d, 0x93,
-	0x11, 0xca, 0x96, 0xbd, 0x6f, 0x1d, 0x54, 0xfd, 0x65, 0xa5, 0x3b, 0x84, 0x9a, 0xf6, 0x1b, 0x28,
-	0xaa, 0x12, 0xf2, 0x08, 0xec, 0x18, 0xb3, 0x62, 0xea, 0x47, 0x8d, 0x62, 0xe0, 0x63, 0xfb, 0xe3,
-	0xe7, 0x07, 0x1b, 0xbe, 0xb1, 0xeb, 0x14, 0x43, 0xf1, 0x9e, 0x0f, 0x30, 0x10, 0x3c, 0x4c, 0xf2,
-	0x14, 0x0b, 0x2a, 0xf7, 0x10, 0xec, 0x3e, 0x65, 0x92, 0x38, 0x50, 0x7e, 0x87, 0x33, 0x13, 0xb0,
-	0xe6, 0xeb, 0x4f, 0x72, 0x07, 0x36, 0xaf, 0x69, 0x34, 0x45, 0xe3, 0x55, 0xf3, 0x53, 0xc1, 0xfd,
-	0xab, 0xb4, 0xe0, 0x36, 0xcd, 0x65, 0x0f, 0x40, 0x66, 0x8a, 0xde, 0x49, 0x46, 0x6f, 0x41, 0x43,
-	0x5c, 0x68, 0xbc, 0x97, 0x4c, 0x29, 0xe4, 0xc7, 0x33, 0x85, 0x79, 0x12, 0x4b, 0x3a, 0x9d, 0x67,
-	0x26, 0xbf, 0xc2, 0x59, 0x62, 0xf8, 0xb2, 0xfd, 0xa2, 0x8a, 0xdc, 0x87, 0x9a, 0x44, 0x1a, 0xa6,
-	0x21, 0x6c, 0x63, 0x5f, 0x28, 0xc8, 0x3d, 0xa8, 0x6a, 0xc1, 0x38, 0x6f, 0x1a, 0xe3, 0x5c, 0x26,
-	0x07, 0xb0, 0x43, 0xe3, 0x58, 0x8a, 0x0f, 0x6c, 0x42, 0x15, 0x0e, 0xd8, 0xef, 0xd8, 0xaa, 0x18,
-	0xc8, 0xaa, 0x7a, 0x05, 0x69, 0x82, 0x6d, 0xad, 0x21, 0x4d, 0xcc, 0x27, 0x50, 0x65, 0x5c, 0xa1,
-	0xbc, 0xa6, 0x51, 0xab, 0x6a, 0xee, 0xe0, 0x4e, 0x7e, 0x07, 0x17, 0x6c, 0x82, 0xbd, 0xcc, 0xe6,
-	0xcf, 0x51, 0xee, 0x9f, 0x15, 0x68, 0x7a, 0x79, 0x6b, 0xa4, 0xc4, 0xad, 0xf4, 0x8f, 0xb5, 0xde,
-	0x3f, 0xf7, 0xa1, 0x96, 0x28, 0x2a, 0x95, 0x8e, 0x99, 0xf1, 0xb6, 0x50, 0x2c, 0x25, 0x51, 0xfe,
-	0x3f, 0x49, 0x68, 0x9a, 0x02, 0x1a, 0xd3, 0x80, 0xa9, 0x59, 0xc6, 0xe1, 0x5c, 0xd6, 0x67, 0xd1,
-	0x6b, 0xca, 0x22, 0x3a, 0x8a, 0x30, 0xe3, 0x70, 0xa1, 0xd0, 0x9e, 0xd3, 0x04, 0xc3, 0x02, 0x7b,
-	0x73, 0x99, 0xdc, 0x85, 0x0a, 0x4b, 0x8e, 0xa7, 0xc9, 0xcc, 0xb0, 0x55, 0xf5, 0x33, 0x49, 0xf7,
-	0x75, 0xde, 0x06, 0x9e, 0x98, 0x72, 0x65, 0x98, 0xb2, 0xfd, 0x65, 0x25, 0x69, 0x83, 0x93, 0x20,
-	0x0f, 0x19, 0x1f, 0x0f, 0x38, 0x8d, 0x53, 0x60, 0xcd, 0x00, 0xd7, 0xf4, 0xe4, 0x10, 0x88, 0xc4,
-	0x00, 0xd9, 0xf5, 0x12, 0x1a, 0x0c, 0xfa, 0x0b, 0x16, 0xf2, 0x1d, 0xec, 0xd2, 0x38, 0x8e, 0x66,
-	0x4b, 0xf0, 0xba, 0x81, 0xaf, 0x1b, 0xd6, 0x1a, 0xb5, 0xf1, 0x85, 0x46, 0x5d, 0x6a, 0xc3, 0xed,
-	0xd5, 0x36, 0x5c, 0x69, 0xe3, 0xe6, 0x7a, 0x1b, 0x17, 0x1b, 0x75, 0x67, 0xa5, 0x51, 0x9f, 0x43,
-	0x2d, 0x88, 0xa7, 0xc3, 0x84, 0x8e, 0x31, 0x69, 0x39, 0xfb, 0xe5, 0x83, 0xfa, 0x11, 0xc9, 0x2f,
-	0xd4, 0xc7, 0x40, 0xc8, 0x50, 0x4f, 0x6a, 0x36, 0xdf, 0x0b, 0x28, 0xf9, 0x01, 0xea, 0x3a, 0x46,
-	0xef, 0xdc, 0xa7, 0x3a, 0xab, 0xdd, 0x5b, 0x3c, 0x8b, 0x60, 0xf2, 0x63, 0x5a, 0x33, 0xe6, 0xce,
-	0xe4, 0x16, 0xe7, 0x25, 0xb4, 0x3e, 0x59, 0xc4, 0xa7, 0x54, 0x21, 0x0f, 0x18, 0x26, 0xad, 0x6f,
-	0x6e, 0x3b, 0xb9, 0x00, 0x76, 0x9f, 0x01, 0x2c, 0x00, 0xb7, 0xad, 0x1f, 0x3b, 0x5f, 0x3f, 0x2f,
-	0xa0, 0x92, 0xee, 0xc3, 0xaf, 0x6e, 0x65, 0x02, 0x36, 0xa7, 0x93, 0x7c, 0x6b, 0x99, 0x6f, 0xad,
-	0xa3, 0x61, 0x28, 0xcd, 0x94, 0xd4, 0x7c, 0xf3, 0xed, 0x76, 0x61, 0xcb, 0x8b, 0xa6, 0x89, 0xfa,
-	0x8f, 0x50, 0x2e, 0x34, 0x26, 0xf4, 0x83, 0x5e, 0xaa, 0x69, 0xe7, 0xe8, 0x90, 0xdb, 0xfe, 0x92,
-	0xce, 0x7d, 0x0e, 0x8d, 0xe2, 0xb0, 0xe9, 0xb4, 0xcd, 0x84, 0x66, 0xe3, 0x9c, 0x0a, 0xba, 0x3c,
-	0xe4, 0x61, 0x56, 0x8a, 0xfe, 0x74, 0x23, 0x28, 0xbf, 0x14, 0x23, 0xf2, 0x2d, 0xd8, 0x6a, 0x16,
-	0xa3, 0x41, 0x37, 0x8f, 0x76, 0x72, 0xea, 0x5e, 0x8a, 0xd1, 0xc5, 0x2c, 0x46, 0xdf, 0x18, 0xb3,
-	0xd7, 0x4b, 0x61, 0x96, 0x42, 0xc3, 0xcf, 0x45, 0xf2, 0xc8, 0x9c, 0xa6, 0xd6, 0x5e, 0x98, 0x97,
-	0x62, 0xa4, 0x77, 0x0c, 0xfa, 0xa9, 0xd9, 0x45, 0xd8, 0xf5, 0x71, 0x22, 0xae, 0x31, 0x5f, 0xdd,
-	0xfa, 0xec, 0x47, 0xeb, 0x8b, 0x7b, 0x5e, 0x7e, 0x71, 0x81, 0x1f, 0xc0, 0xa6, 0x7e, 0x4c, 0xf4,
-	0xe6, 0x2e, 0x7f, 0xe5, 0xb5, 0x49, 0x01, 0xae, 0x07, 0x3b, 0xf9, 0x01, 0x7d, 0x21, 0x22, 0x7d,
-	0xc8, 0x13, 0xd8, 0x8c, 0x85, 0x88, 0x92, 0x96, 0x65, 0x9c, 0xef, 0x2c, 0x9a, 0x63, 0x81, 0x9b,
-	0x07, 0xd1, 0x40, 0x77, 0x04, 0x8d, 0xa2, 0x51, 0x33, 0x3a, 0x96, 0x62, 0x1a, 0xe7, 0x8c, 0x1a,
-	0x61, 0x69, 0x95, 0x95, 0x56, 0x56, 0xd9, 0x3e, 0xd4, 0x25, 0xe5, 0x63, 0xec, 0x4b, 0xbc, 0x64,
-	0x1f, 0x0c, 0x37, 0x0d, 0xbf, 0xa8, 0x6a, 0xef, 0x43, 0xa5, 0x13, 0x28, 0x26, 0x38, 0xa9, 0x82,
-	0x7d, 0x26, 0x38, 0x3a, 0x1b, 0xa4, 0x01, 0xd5, 0x41, 0x40, 0x23, 0x3c, 0x9f, 0x2a, 0xc7, 0x6a,
-	0x3f, 0x5e, 0x64, 0xf1, 0x8a, 0xf1, 0x90, 0x34, 0x01, 0x4e, 0x91, 0x86, 0x28, 0xb5, 0xe4, 0x6c,
-	0x90, 0x1d, 0xa8, 0xfb, 0x18, 0x47, 0x2c, 0xa0, 0x46, 0x61, 0xb5, 0x9f, 0xad, 0xec, 0x77, 0x24,
-	0x15, 0x28, 0x0d, 0xfb, 0xce, 0x06, 0xa9, 0xc3, 0xd6, 0xf9, 0xe5, 0x65, 0xc4, 0x38, 0x3a, 0x16,
-	0xd9, 0x86, 0xda, 0x85, 0x98, 0x8c, 0x12, 0xa5, 0x0f, 0x2d, 0xb5, 0x7f, 0x5a, 0x7e, 0x4d, 0x51,
-	0x83, 0xfd, 0x29, 0xe7, 0x8c, 0x8f, 0x9d, 0x0d, 0x42, 0xa0, 0xf9, 0x96, 0x32, 0xa5, 0x18, 0x1f,
-	0x7b, 0x12, 0xa9, 0xd2, 0x01, 0x34, 0xc0, 0x5c, 0x65, 0xe8, 0x94, 0xda, 0xbf, 0x41, 0xd3, 0xbb,
-	0x32, 0x75, 0x21, 0x4a, 0xdd, 0x31, 0xda, 0xdc, 0x09, 0xc3, 0x33, 0x11, 0xea, 0x92, 0x9a, 0x7a,
-	0xc6, 0x34, 0xd6, 0xc8, 0x96, 0x96, 0x87, 0x71, 0x48, 0x55, 0x2a, 0x97, 0x74, 0xfc, 0x4e, 0x18,
-	0x9e, 0x22, 0x95, 0x1c, 0xa5, 0xd1, 0x95, 0x75, 0x82, 0x86, 0x06, 0x1d, 0xd1, 0xb1, 0xdb, 0x2f,
-	0xa0, 0x9a, 0xff, 0xae, 0x90, 0x1a, 0x6c, 0xbe, 0x11, 0x0a, 0x65, 0x5a, 0x53, 0xe6, 0xe6, 0x58,
-	0x64, 0x17, 0xb6, 0x7b, 0x3c, 0x10, 0x13, 0xc6, 0xc7, 0xa9, 0xbd, 0xa4, 0x55, 0x27, 0x38, 0x11,
-	0x6a, 0xae, 0x2a, 0xb7, 0x9f, 0x41, 0xdd, 0xbb, 0xc2, 0xe0, 0x5d, 0x5f, 0x44, 0x2c, 0x98, 0x69,
-	0xe2, 0x07, 0x5e, 0xe7, 0x2c, 0xa5, 0xb2, 0xd3, 0xef, 0xfb, 0xe7, 0xbf, 0xf4, 0x5e, 0x77, 0x2e,
-	0xba, 0x8e, 0x45, 0x00, 0x2a, 0xc3, 0x41, 0xf7, 0x55, 0xf7, 0x57, 0xa7, 0xd4, 0xee, 0x43, 0xf3,
-	0x3c, 0x46, 0x49, 0x95, 0x30, 0xac, 0x4e, 0x13, 0x7d, 0xf4, 0x60, 0xe8, 0x79, 0xdd, 0xc1, 0x20,
-	0xcd, 0xe3, 0xa2, 0xf7, 0xba, 0x7b, 0x3e, 0xbc, 0x48, 0xfd, 0xbc, 0xce, 0x99, 0xd7, 0x3d, 0x75,
-	0x4a, 0x86, 0xa6, 0x6e, 0xff, 0xb4, 0xe3, 0x75, 0x9d, 0xb2, 0x11, 0x86, 0x67, 0x67, 0xbd, 0xb3,
-	0x9f, 0x4d, 0x45, 0x5b, 0xd9, 0x78, 0xe9, 0xfa, 0x97, 0xc7, 0xc2, 0xd9, 0x20, 0x77, 0x81, 0xa4,
-	0x5c, 0x17, 0x9b, 0x30, 0x2d, 0xd2, 0x9b, 0x26, 0x4a, 0x4c, 0x06, 0x7a, 0xa2, 0x3b, 0xca, 0x09,
-	0xdb, 0x4f, 0xa1, 0x9a, 0x0f, 0x9a, 0x3e, 0x22, 0x75, 0x0b, 0xd3, 0xac, 0xde, 0x0a, 0xf9, 0x4e,
-	0x5f, 0xa2, 0xb9, 0x71, 0x4f, 0x4c, 0xe2, 0x08, 0xb5, 0xad, 0x74, 0xec, 0x7c, 0xfa, 0x67, 0xcf,
-	0xfa, 0x78, 0xb3, 0x67, 0x7d, 0xba, 0xd9, 0xb3, 0xfe, 0xbe, 0xd9, 0xb3, 0x46, 0x15, 0xf3, 0xd3,
-	0xfa, 0xf4, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x34, 0x1a, 0x52, 0x1f, 0xfb, 0x0a, 0x00, 0x00,
+	0x10, 0x8e, 0x6c, 0xc5, 0xb1, 0xcf, 0x8e, 0xa3, 0x70, 0x45, 0x60, 0x14, 0x45, 0x6a, 0x68, 0x45,
+	0x11, 0x18, 0x5b, 0x5a, 0xa4, 0x45, 0x7f, 0x0c, 0xdb, 0x0f, 0x47, 0x31, 0x36, 0xb7, 0x69, 0x62,
+	0xc8, 0x71, 0xbb, 0xfd, 0x1b, 0x2d, 0x5d, 0x1c, 0x22, 0xb2, 0xa8, 0x49, 0x54, 0x5a, 0xef, 0x19,
+	0x86, 0xbd, 0xcd, 0xde, 0xa1, 0x3f, 0xfb, 0x04, 0xc5, 0x96, 0x27, 0x19, 0x48, 0x4a, 0xb6, 0x6c,
+	0xb7, 0xcb, 0xfe, 0xe9, 0xbb, 0xfb, 0xee, 0x78, 0xfc, 0x78, 0x3c, 0x0a, 0x1a, 0x53, 0x14, 0x34,
+	0x1a, 0x1f, 0x46, 0x31, 0x17, 0x9c, 0x54, 0x34, 0xba, 0xff, 0xed, 0x84, 0x89, 0xab, 0x74, 0x7c,
+	0xe8, 0xf1, 0xe9, 0x93, 0x09, 0x9f, 0xf0, 0x27, 0xca, 0x3d, 0x4e, 0x2f, 0x15, 0x52, 0x40, 0x7d,
+	0xe9, 0x30, 0xdb, 0x81, 0x6d, 0x17, 0x13, 0x9e, 0xc6, 0x1e, 0xf6, 0x22, 0xee, 0x5d, 0x91, 0x16,
+	0x6c, 0x79, 0x3c, 0xbc, 0x7c, 0x83, 0x71, 0xcb, 0x68, 0x1b, 0x07, 0xa6, 0x9b, 0x43, 0xe9, 0xb9,
+	0xc1, 0x38, 0x61, 0x3c, 0x6c, 0x95, 0xb4, 0x27, 0x83, 0xf6, 0x1f, 0x06, 0x98, 0x03, 0xc4, 0x98,
+	0xec, 0x41, 0x89, 0xf9, 0x3a, 0xee, 0xb8, 0x72, 0xfb, 0xe9, 0x61, 0xa9, 0x7f, 0xe2, 0x96, 0x98,
+	0x4f, 0xda, 0x50, 0xf7, 0x78, 0x28, 0x28, 0x0b, 0x31, 0xee, 0x9f, 0x64, 0xe1, 0x45, 0x13, 0x79,
+	0x04, 0x66, 0xcc, 0x03, 0x6c, 0x95, 0xdb, 0xc6, 0x41, 0xf3, 0xc8, 0x3a, 0xcc, 0xf6, 0x26, 0xb3,
+	0xba, 0x3c, 0x40, 0x57, 0x79, 0xc9, 0x23, 0xd8, 0x66, 0x21, 0x13, 0x8c, 0x06, 0xaf, 0x71, 0x3a,
+	0xc6, 0xb8, 0x65, 0xb6, 0x8d, 0x83, 0xaa, 0xbb, 0x6c, 0xb4, 0x47, 0x50, 0x93, 0x71, 0x43, 0x41,
+	0x45, 0x42, 0x1e, 0x83, 0x19, 0x61, 0xb6, 0x99, 0xfa, 0x51, 0xa3, 0x98, 0xf8, 0xd8, 0xfc, 0xf0,
+	0xe9, 0xe1, 0x86, 0xab, 0xfc, 0xb2, 0x44, 0x9f, 0xbf, 0x0b, 0x87, 0xe8, 0xf1, 0xd0, 0x4f, 0xf2,
+	0x12, 0x0b, 0x26, 0xfb, 0x10, 0xcc, 0x01, 0x65, 0x31, 0xb1, 0xa0, 0x7c, 0x8d, 0x33, 0x95, 0xb0,
+	0xe6, 0xca, 0x4f, 0x72, 0x0f, 0x36, 0x6f, 0x68, 0x90, 0xa2, 0x8a, 0xaa, 0xb9, 0x1a, 0xd8, 0x7f,
+	0x95, 0x16, 0xda, 0xea, 0x5a, 0xf6, 0x01, 0xe2, 0xcc, 0xd0, 0x3f, 0xc9, 0xe4, 0x2d, 0x58, 0x88,
+	0x0d, 0x8d, 0x77, 0x31, 0x13, 0x02, 0xc3, 0xe3, 0x99, 0xc0, 0xbc, 0x88, 0x25, 0x9b, 0xac, 0x33,
+	0xc3, 0xaf, 0x70, 0x96, 0x28, 0xbd, 0x4c, 0xb7, 0x68, 0x22, 0x0f, 0xa0, 0x16, 0x23, 0xf5, 0x75,
+	0x0a, 0x53, 0xf9, 0x17, 0x06, 0x72, 0x1f, 0xaa, 0x12, 0xa8, 0xe0, 0x4d, 0xe5, 0x9c, 0x63, 0x72,
+	0x00, 0x3b, 0x34, 0x8a, 0x62, 0xfe, 0x9e, 0x4d, 0xa9, 0xc0, 0x21, 0xfb, 0x1d, 0x5b, 0x15, 0x45,
+	0x59, 0x35, 0xaf, 0x30, 0x55, 0xb2, 0xad, 0x35, 0xa6, 0xca, 0xf9, 0x14, 0xaa, 0x2c, 0x14, 0x18,
+	0xdf, 0xd0, 0xa0, 0x55, 0x55, 0x67, 0x70, 0x2f, 0x3f, 0x83, 0x0b, 0x36, 0xc5, 0x7e, 0xe6, 0x73,
+	0xe7, 0x2c, 0xfb, 0xcf, 0x0a, 0x34, 0x9d, 0xbc, 0x35, 0xb4, 0x70, 0x2b, 0xfd, 0x63, 0xac, 0xf7,
+	0xcf, 0x03, 0xa8, 0x25, 0x82, 0xc6, 0x42, 0xe6, 0xcc, 0x74, 0x5b, 0x18, 0x96, 0x8a, 0x28, 0xff,
+	0x9f, 0x22, 0xa4, 0x4c, 0x1e, 0x8d, 0xa8, 0xc7, 0xc4, 0x2c, 0xd3, 0x70, 0x8e, 0xe5, 0x5a, 0xf4,
+	0x86, 0xb2, 0x80, 0x8e, 0x03, 0xcc, 0x34, 0x5c, 0x18, 0x64, 0x64, 0x9a, 0xa0, 0x5f, 0x50, 0x6f,
+	0x8e, 0xc9, 0x1e, 0x54, 0x58, 0x72, 0x9c, 0x26, 0x33, 0xa5, 0x56, 0xd5, 0xcd, 0x90, 0xec, 0xeb,
+	0xbc, 0x0d, 0x1c, 0x9e, 0x86, 0x42, 0x29, 0x65, 0xba, 0xcb, 0x46, 0xd2, 0x01, 0x2b, 0xc1, 0xd0,
+	0x67, 0xe1, 0x64, 0x18, 0xd2, 0x48, 0x13, 0x6b, 0x8a, 0xb8, 0x66, 0x27, 0x87, 0x40, 0x62, 0xf4,
+	0x90, 0xdd, 0x2c, 0xb1, 0x41, 0xb1, 0x3f, 0xe3, 0x21, 0xdf, 0xc0, 0x2e, 0x8d, 0xa2, 0x60, 0xb6,
+	0x44, 0xaf, 0x2b, 0xfa, 0xba, 0x63, 0xad, 0x51, 0x1b, 0x9f, 0x69, 0xd4, 0xa5, 0x36, 0xdc, 0x5e,
+	0x6d, 0xc3, 0x95, 0x36, 0x6e, 0xae, 0xb7, 0x71, 0xb1, 0x51, 0x77, 0x56, 0x1a, 0xf5, 0x05, 0xd4,
+	0xbc, 0x28, 0x1d, 0x25, 0x74, 0x82, 0x49, 0xcb, 0x6a, 0x97, 0x0f, 0xea, 0x47, 0x24, 0x3f, 0x50,
+	0x17, 0x3d, 0x1e, 0xfb, 0xf2, 0xa6, 0x66, 0xf7, 0x7b, 0x41, 0x25, 0xdf, 0x41, 0x5d, 0xe6, 0xe8,
+	0x9f, 0xbb, 0x54, 0x56, 0xb5, 0x7b, 0x47, 0x64, 0x91, 0x4c, 0xbe, 0xd7, 0x7b, 0xc6, 0x3c, 0x98,
+	0xdc, 0x11, 0xbc, 0xc4, 0x96, 0x2b, 0xf3, 0xe8, 0x94, 0x0a, 0x0c, 0x3d, 0x86, 0x49, 0xeb, 0xab,
+	0xbb, 0x56, 0x2e, 0x90, 0xed, 0xe7, 0x00, 0x0b, 0xc2, 0x5d, 0xe3, 0xc7, 0xcc, 0xc7, 0xcf, 0x4f,
+	0x50, 0xd1, 0xf3, 0xf0, 0x8b, 0x53, 0x99, 0x80, 0x19, 0xd2, 0x69, 0x3e, 0xb5, 0xd4, 0xb7, 0xb4,
+	0x51, 0xdf, 0x8f, 0xd5, 0x2d, 0xa9, 0xb9, 0xea, 0xdb, 0xee, 0xc1, 0x96, 0x13, 0xa4, 0x89, 0xf8,
+	0x8f, 0x54, 0x36, 0x34, 0xa6, 0xf4, 0xbd, 0x1c, 0xaa, 0xba, 0x73, 0x64, 0xca, 0x6d, 0x77, 0xc9,
+	0x66, 0xbf, 0x80, 0x46, 0xf1, 0xb2, 0xc9, 0xb2, 0xd5, 0x0d, 0xcd, 0xae, 0xb3, 0x06, 0x72, 0x7b,
+	0x18, 0xfa, 0xd9, 0x56, 0xe4, 0xa7, 0x1d, 0x40, 0xf9, 0x25, 0x1f, 0x93, 0xaf, 0xc1, 0x14, 0xb3,
+	0x08, 0x15, 0xbb, 0x79, 0xb4, 0x93, 0x4b, 0xf7, 0x92, 0x8f, 0x2f, 0x66, 0x11, 0xba, 0xca, 0x99,
+	0xbd, 0x5e, 0x02, 0xb3, 0x12, 0x1a, 0x6e, 0x0e, 0xc9, 0x63, 0xb5, 0x9a, 0x58, 0x7b, 0x61, 0x5e,
+	0xf2, 0xb1, 0x9c, 0x31, 0xe8, 0x6a, 0xb7, 0x8d, 0xb0, 0xeb, 0xe2, 0x94, 0xdf, 0x60, 0x3e, 0xba,
+	0xe5, 0xda, 0x8f, 0xd7, 0x07, 0xf7, 0x7c, 0xfb, 0x05, 0x0f, 0x39, 0x80, 0x4d, 0xf9, 0x98, 0xc8,
+	0xc9, 0x5d, 0xfe, 0xc2, 0x6b, 0xa3, 0x09, 0xb6, 0x03, 0x3b, 0xf9, 0x02, 0x03, 0xce, 0x03, 0xb9,
+	0xc8, 0x53, 0xd8, 0x8c, 0x38, 0x0f, 0x92, 0x96, 0xd1, 0x2e, 0x17, 0x27, 0x54, 0x91, 0x37, 0x4f,
+	0x22, 0x89, 0xf6, 0x18, 0x1a, 0x45, 0xa7, 0x54, 0x74, 0x12, 0xf3, 0x34, 0xca, 0x15, 0x55, 0x60,
+	0x69, 0x94, 0x95, 0x56, 0x46, 0x59, 0x1b, 0xea, 0x31, 0x0d, 0x27, 0x38, 0x88, 0xf1, 0x92, 0xbd,
+	0x57, 0xda, 0x34, 0xdc, 0xa2, 0xc9, 0xbe, 0x86, 0x9a, 0xec, 0xe0, 0x53, 0x36, 0x65, 0xe2, 0x0b,
+	0x0b, 0xec, 0x41, 0x45, 0x60, 0x48, 0x33, 0xcd, 0x4d, 0x37, 0x43, 0xfa, 0x06, 0xff, 0x96, 0x62,
+	0x22, 0xf2, 0x77, 0x6a, 0x8e, 0x65, 0xa6, 0x71, 0xe1, 0x81, 0xd2, 0xa0, 0xd3, 0x86, 0x4a, 0xd7,
+	0x13, 0x8c, 0x87, 0xa4, 0x0a, 0xe6, 0x19, 0x0f, 0xd1, 0xda, 0x20, 0x0d, 0xa8, 0x0e, 0x3d, 0x1a,
+	0xe0, 0x79, 0x2a, 0x2c, 0xa3, 0xf3, 0x64, 0xb1, 0xe5, 0x57, 0x2c, 0xf4, 0x49, 0x13, 0xe0, 0x14,
+	0xa9, 0x8f, 0xb1, 0x44, 0xd6, 0x06, 0xd9, 0x81, 0xba, 0x8b, 0x51, 0xc0, 0x3c, 0xaa, 0x0c, 0x46,
+	0xe7, 0xf9, 0xca, 0x63, 0x82, 0xa4, 0x02, 0xa5, 0xd1, 0xc0, 0xda, 0x20, 0x75, 0xd8, 0x3a, 0xbf,
+	0xbc, 0x0c, 0x58, 0x88, 0x96, 0x41, 0xb6, 0xa1, 0x76, 0xc1, 0xa7, 0xe3, 0x44, 0xc8, 0x45, 0x4b,
+	0x9d, 0x1f, 0x96, 0x9f, 0x6e, 0x94, 0x64, 0x37, 0x0d, 0x43, 0x16, 0x4e, 0xac, 0x0d, 0x42, 0xa0,
+	0xf9, 0x96, 0x32, 0x21, 0x58, 0x38, 0x71, 0x62, 0xa4, 0x42, 0x26, 0x90, 0x04, 0xd5, 0x37, 0xbe,
+	0x55, 0xea, 0xfc, 0x0a, 0x4d, 0xe7, 0x4a, 0x89, 0x88, 0x18, 0xcb, 0xf6, 0x94, 0xee, 0xae, 0xef,
+	0x9f, 0x71, 0x5f, 0x6e, 0xa9, 0x09, 0xa0, 0xb9, 0x0a, 0x1b, 0x12, 0x8f, 0x22, 0x9f, 0x0a, 0x8d,
+	0x4b, 0x32, 0x7f, 0xd7, 0xf7, 0x4f, 0x91, 0xc6, 0x21, 0xc6, 0xca, 0x56, 0x96, 0x05, 0x2a, 0x19,
+	0x64, 0x46, 0xcb, 0xec, 0x8c, 0xa0, 0x9a, 0xff, 0x1b, 0x91, 0x1a, 0x6c, 0xbe, 0xe1, 0x02, 0x63,
+	0xbd, 0xa7, 0x2c, 0xcc, 0x32, 0xc8, 0x2e, 0x6c, 0xf7, 0x43, 0x8f, 0x4f, 0x59, 0x38, 0xd1, 0xfe,
+	0x92, 0x34, 0x9d, 0xe0, 0x94, 0x8b, 0xb9, 0xa9, 0x2c, 0x43, 0xde, 0x32, 0x11, 0x62, 0x92, 0x58,
+	0x66, 0xe7, 0x39, 0xd4, 0x9d, 0x2b, 0xf4, 0xae, 0x07, 0x3c, 0x60, 0xde, 0x4c, 0x9e, 0xc2, 0xd0,
+	0xe9, 0x9e, 0x69, 0x5d, 0xbb, 0x83, 0x81, 0x7b, 0xfe, 0x73, 0xff, 0x75, 0xf7, 0xa2, 0x67, 0x19,
+	0x04, 0xa0, 0x32, 0x1a, 0xf6, 0x5e, 0xf5, 0x7e, 0xb1, 0x4a, 0x9d, 0x01, 0x34, 0xcf, 0x23, 0x8c,
+	0xa9, 0xe0, 0x4a, 0xe2, 0x34, 0x91, 0x49, 0x87, 0x23, 0xc7, 0xe9, 0x0d, 0x87, 0xba, 0xa8, 0x8b,
+	0xfe, 0xeb, 0xde, 0xf9, 0xe8, 0x42, 0xc7, 0x39, 0xdd, 0x33, 0xa7, 0x77, 0x6a, 0x95, 0x94, 0x66,
+	0xbd, 0xc1, 0x69, 0xd7, 0xe9, 0xe9, 0x3a, 0xdc, 0xd1, 0xd9, 0x59, 0xff, 0xec, 0x47, 0xcb, 0xec,
+	0x5c, 0xc0, 0x56, 0x76, 0xb1, 0xa5, 0x18, 0xcb, 0x17, 0xd2, 0xda, 0x20, 0x7b, 0x40, 0xb4, 0xf0,
+	0xc5, 0xf6, 0xd7, 0xc9, 0x8f, 0xa9, 0x77, 0x9d, 0x46, 0x7a, 0xab, 0x4e, 0x9a, 0x08, 0x3e, 0x1d,
+	0xca, 0xb9, 0xd2, 0x15, 0x96, 0xdf, 0x79, 0x06, 0xd5, 0xfc, 0xba, 0xcb, 0xe5, 0x74, 0x0a, 0x5f,
+	0x57, 0xf8, 0x96, 0xc7, 0xd7, 0xf2, 0x74, 0x55, 0x2b, 0x38, 0x7c, 0x1a, 0x05, 0x28, 0x7d, 0xa5,
+	0x63, 0xeb, 0xe3, 0x3f, 0xfb, 0xc6, 0x87, 0xdb, 0x7d, 0xe3, 0xe3, 0xed, 0xbe, 0xf1, 0xf7, 0xed,
+	0xbe, 0x31, 0xae, 0xa8, 0x5f, 0xe7, 0x67, 0xff, 0x0e, 0x00, 0xa7, 0x6e, 0x26, 0x1d, 0x81, 0x0b,
+	0x00, 0x00,
 }
 
 func (m *ResourceEpoch) Marshal() (dAtA []byte, err error) {
@@ -2127,6 +2213,47 @@ func (m *ResourcePool) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Group != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Group))
+	}
+	if m.Tenant != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Tenant))
+	}
+	if m.Requests != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Requests))
+	}
+	if m.Bytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Bytes))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeVarintMetapb(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -2495,6 +2622,30 @@ func (m *ResourcePool) Size() (n int) {
 	return n
 }
 
+func (m *RateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Group != 0 {
+		n += 1 + sovMetapb(uint64(m.Group))
+	}
+	if m.Tenant != 0 {
+		n += 1 + sovMetapb(uint64(m.Tenant))
+	}
+	if m.Requests != 0 {
+		n += 1 + sovMetapb(uint64(m.Requests))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovMetapb(uint64(m.Bytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovMetapb(x uint64) (n int) {
 	for {
 		n++
@@ -4544,6 +4695,137 @@ func (m *ResourcePool) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			m.Group = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Group |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tenant", wireType)
+			}
+			m.Tenant = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Tenant |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			m.Requests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Requests |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func skipMetapb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
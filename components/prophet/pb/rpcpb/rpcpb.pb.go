@@ -28,43 +28,59 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type Type int32
 
 const (
-	TypeRegisterContainer     Type = 0
-	TypeResourceHeartbeatReq  Type = 1
-	TypeResourceHeartbeatRsp  Type = 2
-	TypeContainerHeartbeatReq Type = 3
-	TypeContainerHeartbeatRsp Type = 4
-	TypePutContainerReq       Type = 5
-	TypePutContainerRsp       Type = 6
-	TypeGetContainerReq       Type = 7
-	TypeGetContainerRsp       Type = 8
-	TypeAllocIDReq            Type = 9
-	TypeAllocIDRsp            Type = 10
-	TypeAskSplitReq           Type = 11
-	TypeAskSplitRsp           Type = 12
-	TypeAskBatchSplitReq      Type = 13
-	TypeAskBatchSplitRsp      Type = 14
-	TypeReportSplitReq        Type = 15
-	TypeReportSplitRsp        Type = 16
-	TypeBatchReportSplitReq   Type = 17
-	TypeBatchReportSplitRsp   Type = 18
-	TypeCreateWatcherReq      Type = 19
-	TypeEventNotify           Type = 20
-	TypeCreateResourcesReq    Type = 21
-	TypeCreateResourcesRsp    Type = 22
-	TypeRemoveResourcesReq    Type = 23
-	TypeRemoveResourcesRsp    Type = 24
-	TypeCheckResourceStateReq Type = 25
-	TypeCheckResourceStateRsp Type = 26
-	TypePutPlacementRuleReq   Type = 27
-	TypePutPlacementRuleRsp   Type = 28
-	TypeGetAppliedRulesReq    Type = 29
-	TypeGetAppliedRulesRsp    Type = 30
-	TypeCreateJobReq          Type = 31
-	TypeCreateJobRsp          Type = 32
-	TypeRemoveJobReq          Type = 33
-	TypeRemoveJobRsp          Type = 34
-	TypeExecuteJobReq         Type = 35
-	TypeExecuteJobRsp         Type = 36
+	TypeRegisterContainer          Type = 0
+	TypeResourceHeartbeatReq       Type = 1
+	TypeResourceHeartbeatRsp       Type = 2
+	TypeContainerHeartbeatReq      Type = 3
+	TypeContainerHeartbeatRsp      Type = 4
+	TypePutContainerReq            Type = 5
+	TypePutContainerRsp            Type = 6
+	TypeGetContainerReq            Type = 7
+	TypeGetContainerRsp            Type = 8
+	TypeAllocIDReq                 Type = 9
+	TypeAllocIDRsp                 Type = 10
+	TypeAskSplitReq                Type = 11
+	TypeAskSplitRsp                Type = 12
+	TypeAskBatchSplitReq           Type = 13
+	TypeAskBatchSplitRsp           Type = 14
+	TypeReportSplitReq             Type = 15
+	TypeReportSplitRsp             Type = 16
+	TypeBatchReportSplitReq        Type = 17
+	TypeBatchReportSplitRsp        Type = 18
+	TypeCreateWatcherReq           Type = 19
+	TypeEventNotify                Type = 20
+	TypeCreateResourcesReq         Type = 21
+	TypeCreateResourcesRsp         Type = 22
+	TypeRemoveResourcesReq         Type = 23
+	TypeRemoveResourcesRsp         Type = 24
+	TypeCheckResourceStateReq      Type = 25
+	TypeCheckResourceStateRsp      Type = 26
+	TypePutPlacementRuleReq        Type = 27
+	TypePutPlacementRuleRsp        Type = 28
+	TypeGetAppliedRulesReq         Type = 29
+	TypeGetAppliedRulesRsp         Type = 30
+	TypeCreateJobReq               Type = 31
+	TypeCreateJobRsp               Type = 32
+	TypeRemoveJobReq               Type = 33
+	TypeRemoveJobRsp               Type = 34
+	TypeExecuteJobReq              Type = 35
+	TypeExecuteJobRsp              Type = 36
+	TypeTransferLeaderReq          Type = 37
+	TypeTransferLeaderRsp          Type = 38
+	TypeAllocTimestampReq          Type = 39
+	TypeAllocTimestampRsp          Type = 40
+	TypePutRateLimitReq            Type = 41
+	TypePutRateLimitRsp            Type = 42
+	TypeGetRateLimitsReq           Type = 43
+	TypeGetRateLimitsRsp           Type = 44
+	TypeSetWriteFreezeReq          Type = 45
+	TypeSetWriteFreezeRsp          Type = 46
+	TypeGetWriteFrozenGroupsReq    Type = 47
+	TypeGetWriteFrozenGroupsRsp    Type = 48
+	TypeDecommissionContainerReq   Type = 49
+	TypeDecommissionContainerRsp   Type = 50
+	TypeGetDecommissionProgressReq Type = 51
+	TypeGetDecommissionProgressRsp Type = 52
 )
 
 var Type_name = map[int32]string{
@@ -105,46 +121,78 @@ var Type_name = map[int32]string{
 	34: "TypeRemoveJobRsp",
 	35: "TypeExecuteJobReq",
 	36: "TypeExecuteJobRsp",
+	37: "TypeTransferLeaderReq",
+	38: "TypeTransferLeaderRsp",
+	39: "TypeAllocTimestampReq",
+	40: "TypeAllocTimestampRsp",
+	41: "TypePutRateLimitReq",
+	42: "TypePutRateLimitRsp",
+	43: "TypeGetRateLimitsReq",
+	44: "TypeGetRateLimitsRsp",
+	45: "TypeSetWriteFreezeReq",
+	46: "TypeSetWriteFreezeRsp",
+	47: "TypeGetWriteFrozenGroupsReq",
+	48: "TypeGetWriteFrozenGroupsRsp",
+	49: "TypeDecommissionContainerReq",
+	50: "TypeDecommissionContainerRsp",
+	51: "TypeGetDecommissionProgressReq",
+	52: "TypeGetDecommissionProgressRsp",
 }
 
 var Type_value = map[string]int32{
-	"TypeRegisterContainer":     0,
-	"TypeResourceHeartbeatReq":  1,
-	"TypeResourceHeartbeatRsp":  2,
-	"TypeContainerHeartbeatReq": 3,
-	"TypeContainerHeartbeatRsp": 4,
-	"TypePutContainerReq":       5,
-	"TypePutContainerRsp":       6,
-	"TypeGetContainerReq":       7,
-	"TypeGetContainerRsp":       8,
-	"TypeAllocIDReq":            9,
-	"TypeAllocIDRsp":            10,
-	"TypeAskSplitReq":           11,
-	"TypeAskSplitRsp":           12,
-	"TypeAskBatchSplitReq":      13,
-	"TypeAskBatchSplitRsp":      14,
-	"TypeReportSplitReq":        15,
-	"TypeReportSplitRsp":        16,
-	"TypeBatchReportSplitReq":   17,
-	"TypeBatchReportSplitRsp":   18,
-	"TypeCreateWatcherReq":      19,
-	"TypeEventNotify":           20,
-	"TypeCreateResourcesReq":    21,
-	"TypeCreateResourcesRsp":    22,
-	"TypeRemoveResourcesReq":    23,
-	"TypeRemoveResourcesRsp":    24,
-	"TypeCheckResourceStateReq": 25,
-	"TypeCheckResourceStateRsp": 26,
-	"TypePutPlacementRuleReq":   27,
-	"TypePutPlacementRuleRsp":   28,
-	"TypeGetAppliedRulesReq":    29,
-	"TypeGetAppliedRulesRsp":    30,
-	"TypeCreateJobReq":          31,
-	"TypeCreateJobRsp":          32,
-	"TypeRemoveJobReq":          33,
-	"TypeRemoveJobRsp":          34,
-	"TypeExecuteJobReq":         35,
-	"TypeExecuteJobRsp":         36,
+	"TypeRegisterContainer":          0,
+	"TypeResourceHeartbeatReq":       1,
+	"TypeResourceHeartbeatRsp":       2,
+	"TypeContainerHeartbeatReq":      3,
+	"TypeContainerHeartbeatRsp":      4,
+	"TypePutContainerReq":            5,
+	"TypePutContainerRsp":            6,
+	"TypeGetContainerReq":            7,
+	"TypeGetContainerRsp":            8,
+	"TypeAllocIDReq":                 9,
+	"TypeAllocIDRsp":                 10,
+	"TypeAskSplitReq":                11,
+	"TypeAskSplitRsp":                12,
+	"TypeAskBatchSplitReq":           13,
+	"TypeAskBatchSplitRsp":           14,
+	"TypeReportSplitReq":             15,
+	"TypeReportSplitRsp":             16,
+	"TypeBatchReportSplitReq":        17,
+	"TypeBatchReportSplitRsp":        18,
+	"TypeCreateWatcherReq":           19,
+	"TypeEventNotify":                20,
+	"TypeCreateResourcesReq":         21,
+	"TypeCreateResourcesRsp":         22,
+	"TypeRemoveResourcesReq":         23,
+	"TypeRemoveResourcesRsp":         24,
+	"TypeCheckResourceStateReq":      25,
+	"TypeCheckResourceStateRsp":      26,
+	"TypePutPlacementRuleReq":        27,
+	"TypePutPlacementRuleRsp":        28,
+	"TypeGetAppliedRulesReq":         29,
+	"TypeGetAppliedRulesRsp":         30,
+	"TypeCreateJobReq":               31,
+	"TypeCreateJobRsp":               32,
+	"TypeRemoveJobReq":               33,
+	"TypeRemoveJobRsp":               34,
+	"TypeExecuteJobReq":              35,
+	"TypeExecuteJobRsp":              36,
+	"TypeTransferLeaderReq":          37,
+	"TypeTransferLeaderRsp":          38,
+	"TypeAllocTimestampReq":          39,
+	"TypeAllocTimestampRsp":          40,
+	"TypePutRateLimitReq":            41,
+	"TypePutRateLimitRsp":            42,
+	"TypeGetRateLimitsReq":           43,
+	"TypeGetRateLimitsRsp":           44,
+	"TypeSetWriteFreezeReq":          45,
+	"TypeSetWriteFreezeRsp":          46,
+	"TypeGetWriteFrozenGroupsReq":    47,
+	"TypeGetWriteFrozenGroupsRsp":    48,
+	"TypeDecommissionContainerReq":   49,
+	"TypeDecommissionContainerRsp":   50,
+	"TypeGetDecommissionProgressReq": 51,
+	"TypeGetDecommissionProgressRsp": 52,
 }
 
 func (x Type) String() string {
@@ -167,6 +215,8 @@ const (
 	Follower PeerRoleType = 2
 	// Learner matches a learner.
 	Learner PeerRoleType = 3
+	// Witness matches a witness.
+	Witness PeerRoleType = 4
 )
 
 var PeerRoleType_name = map[int32]string{
@@ -174,6 +224,7 @@ var PeerRoleType_name = map[int32]string{
 	1: "Leader",
 	2: "Follower",
 	3: "Learner",
+	4: "Witness",
 }
 
 var PeerRoleType_value = map[string]int32{
@@ -181,6 +232,7 @@ var PeerRoleType_value = map[string]int32{
 	"Leader":   1,
 	"Follower": 2,
 	"Learner":  3,
+	"Witness":  4,
 }
 
 func (x PeerRoleType) String() string {
@@ -230,30 +282,36 @@ func (LabelConstraintOp) EnumDescriptor() ([]byte, []int) {
 
 // Request the prophet rpc request
 type Request struct {
-	ID                   uint64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ContainerID          uint64                `protobuf:"varint,2,opt,name=containerID,proto3" json:"containerID,omitempty"`
-	Type                 Type                  `protobuf:"varint,3,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
-	ResourceHeartbeat    ResourceHeartbeatReq  `protobuf:"bytes,4,opt,name=resourceHeartbeat,proto3" json:"resourceHeartbeat"`
-	ContainerHeartbeat   ContainerHeartbeatReq `protobuf:"bytes,5,opt,name=containerHeartbeat,proto3" json:"containerHeartbeat"`
-	PutContainer         PutContainerReq       `protobuf:"bytes,6,opt,name=putContainer,proto3" json:"putContainer"`
-	GetContainer         GetContainerReq       `protobuf:"bytes,7,opt,name=getContainer,proto3" json:"getContainer"`
-	AllocID              AllocIDReq            `protobuf:"bytes,8,opt,name=allocID,proto3" json:"allocID"`
-	AskSplit             AskSplitReq           `protobuf:"bytes,9,opt,name=askSplit,proto3" json:"askSplit"`
-	AskBatchSplit        AskBatchSplitReq      `protobuf:"bytes,10,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
-	ReportSplit          ReportSplitReq        `protobuf:"bytes,11,opt,name=reportSplit,proto3" json:"reportSplit"`
-	BatchReportSplit     BatchReportSplitReq   `protobuf:"bytes,12,opt,name=batchReportSplit,proto3" json:"batchReportSplit"`
-	CreateWatcher        CreateWatcherReq      `protobuf:"bytes,13,opt,name=createWatcher,proto3" json:"createWatcher"`
-	CreateResources      CreateResourcesReq    `protobuf:"bytes,14,opt,name=createResources,proto3" json:"createResources"`
-	RemoveResources      RemoveResourcesReq    `protobuf:"bytes,15,opt,name=removeResources,proto3" json:"removeResources"`
-	CheckResourceState   CheckResourceStateReq `protobuf:"bytes,16,opt,name=checkResourceState,proto3" json:"checkResourceState"`
-	PutPlacementRule     PutPlacementRuleReq   `protobuf:"bytes,17,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
-	GetAppliedRules      GetAppliedRulesReq    `protobuf:"bytes,18,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
-	CreateJob            CreateJobReq          `protobuf:"bytes,19,opt,name=createJob,proto3" json:"createJob"`
-	RemoveJob            RemoveJobReq          `protobuf:"bytes,20,opt,name=removeJob,proto3" json:"removeJob"`
-	ExecuteJob           ExecuteJobReq         `protobuf:"bytes,21,opt,name=executeJob,proto3" json:"executeJob"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	ID                      uint64                     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ContainerID             uint64                     `protobuf:"varint,2,opt,name=containerID,proto3" json:"containerID,omitempty"`
+	Type                    Type                       `protobuf:"varint,3,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
+	ResourceHeartbeat       ResourceHeartbeatReq       `protobuf:"bytes,4,opt,name=resourceHeartbeat,proto3" json:"resourceHeartbeat"`
+	ContainerHeartbeat      ContainerHeartbeatReq      `protobuf:"bytes,5,opt,name=containerHeartbeat,proto3" json:"containerHeartbeat"`
+	PutContainer            PutContainerReq            `protobuf:"bytes,6,opt,name=putContainer,proto3" json:"putContainer"`
+	GetContainer            GetContainerReq            `protobuf:"bytes,7,opt,name=getContainer,proto3" json:"getContainer"`
+	AllocID                 AllocIDReq                 `protobuf:"bytes,8,opt,name=allocID,proto3" json:"allocID"`
+	AskSplit                AskSplitReq                `protobuf:"bytes,9,opt,name=askSplit,proto3" json:"askSplit"`
+	AskBatchSplit           AskBatchSplitReq           `protobuf:"bytes,10,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
+	ReportSplit             ReportSplitReq             `protobuf:"bytes,11,opt,name=reportSplit,proto3" json:"reportSplit"`
+	BatchReportSplit        BatchReportSplitReq        `protobuf:"bytes,12,opt,name=batchReportSplit,proto3" json:"batchReportSplit"`
+	CreateWatcher           CreateWatcherReq           `protobuf:"bytes,13,opt,name=createWatcher,proto3" json:"createWatcher"`
+	CreateResources         CreateResourcesReq         `protobuf:"bytes,14,opt,name=createResources,proto3" json:"createResources"`
+	RemoveResources         RemoveResourcesReq         `protobuf:"bytes,15,opt,name=removeResources,proto3" json:"removeResources"`
+	CheckResourceState      CheckResourceStateReq      `protobuf:"bytes,16,opt,name=checkResourceState,proto3" json:"checkResourceState"`
+	PutPlacementRule        PutPlacementRuleReq        `protobuf:"bytes,17,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
+	GetAppliedRules         GetAppliedRulesReq         `protobuf:"bytes,18,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
+	CreateJob               CreateJobReq               `protobuf:"bytes,19,opt,name=createJob,proto3" json:"createJob"`
+	RemoveJob               RemoveJobReq               `protobuf:"bytes,20,opt,name=removeJob,proto3" json:"removeJob"`
+	ExecuteJob              ExecuteJobReq              `protobuf:"bytes,21,opt,name=executeJob,proto3" json:"executeJob"`
+	TransferLeader          TransferLeaderReq          `protobuf:"bytes,22,opt,name=transferLeader,proto3" json:"transferLeader"`
+	AllocTimestamp          AllocTimestampReq          `protobuf:"bytes,23,opt,name=allocTimestamp,proto3" json:"allocTimestamp"`
+	PutRateLimit            PutRateLimitReq            `protobuf:"bytes,24,opt,name=putRateLimit,proto3" json:"putRateLimit"`
+	SetWriteFreeze          SetWriteFreezeReq          `protobuf:"bytes,25,opt,name=setWriteFreeze,proto3" json:"setWriteFreeze"`
+	DecommissionContainer   DecommissionContainerReq   `protobuf:"bytes,26,opt,name=decommissionContainer,proto3" json:"decommissionContainer"`
+	GetDecommissionProgress GetDecommissionProgressReq `protobuf:"bytes,27,opt,name=getDecommissionProgress,proto3" json:"getDecommissionProgress"`
+	XXX_NoUnkeyedLiteral    struct{}                   `json:"-"`
+	XXX_unrecognized        []byte                     `json:"-"`
+	XXX_sizecache           int32                      `json:"-"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
@@ -436,33 +494,80 @@ func (m *Request) GetExecuteJob() ExecuteJobReq {
 	return ExecuteJobReq{}
 }
 
+func (m *Request) GetTransferLeader() TransferLeaderReq {
+	if m != nil {
+		return m.TransferLeader
+	}
+	return TransferLeaderReq{}
+}
+
+func (m *Request) GetAllocTimestamp() AllocTimestampReq {
+	if m != nil {
+		return m.AllocTimestamp
+	}
+	return AllocTimestampReq{}
+}
+
+func (m *Request) GetPutRateLimit() PutRateLimitReq {
+	if m != nil {
+		return m.PutRateLimit
+	}
+	return PutRateLimitReq{}
+}
+
+func (m *Request) GetSetWriteFreeze() SetWriteFreezeReq {
+	if m != nil {
+		return m.SetWriteFreeze
+	}
+	return SetWriteFreezeReq{}
+}
+
+func (m *Request) GetDecommissionContainer() DecommissionContainerReq {
+	if m != nil {
+		return m.DecommissionContainer
+	}
+	return DecommissionContainerReq{}
+}
+
+func (m *Request) GetGetDecommissionProgress() GetDecommissionProgressReq {
+	if m != nil {
+		return m.GetDecommissionProgress
+	}
+	return GetDecommissionProgressReq{}
+}
+
 // Response the prophet rpc response
 type Response struct {
-	ID                   uint64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type                 Type                  `protobuf:"varint,2,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
-	Error                string                `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	Leader               string                `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
-	ResourceHeartbeat    ResourceHeartbeatRsp  `protobuf:"bytes,5,opt,name=resourceHeartbeat,proto3" json:"resourceHeartbeat"`
-	ContainerHeartbeat   ContainerHeartbeatRsp `protobuf:"bytes,6,opt,name=containerHeartbeat,proto3" json:"containerHeartbeat"`
-	PutContainer         PutContainerRsp       `protobuf:"bytes,7,opt,name=putContainer,proto3" json:"putContainer"`
-	GetContainer         GetContainerRsp       `protobuf:"bytes,8,opt,name=getContainer,proto3" json:"getContainer"`
-	AllocID              AllocIDRsp            `protobuf:"bytes,9,opt,name=allocID,proto3" json:"allocID"`
-	AskSplit             AskSplitRsp           `protobuf:"bytes,10,opt,name=askSplit,proto3" json:"askSplit"`
-	AskBatchSplit        AskBatchSplitRsp      `protobuf:"bytes,11,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
-	ReportSplit          ReportSplitRsp        `protobuf:"bytes,12,opt,name=reportSplit,proto3" json:"reportSplit"`
-	BatchReportSplit     BatchReportSplitRsp   `protobuf:"bytes,13,opt,name=batchReportSplit,proto3" json:"batchReportSplit"`
-	Event                EventNotify           `protobuf:"bytes,14,opt,name=event,proto3" json:"event"`
-	CreateResources      CreateResourcesRsp    `protobuf:"bytes,15,opt,name=createResources,proto3" json:"createResources"`
-	RemoveResources      RemoveResourcesRsp    `protobuf:"bytes,16,opt,name=removeResources,proto3" json:"removeResources"`
-	CheckResourceState   CheckResourceStateRsp `protobuf:"bytes,17,opt,name=checkResourceState,proto3" json:"checkResourceState"`
-	PutPlacementRule     PutPlacementRuleRsp   `protobuf:"bytes,18,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
-	GetAppliedRules      GetAppliedRulesRsp    `protobuf:"bytes,19,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
-	CreateJob            CreateJobRsp          `protobuf:"bytes,20,opt,name=createJob,proto3" json:"createJob"`
-	RemoveJob            RemoveJobRsp          `protobuf:"bytes,21,opt,name=removeJob,proto3" json:"removeJob"`
-	ExecuteJob           ExecuteJobRsp         `protobuf:"bytes,22,opt,name=executeJob,proto3" json:"executeJob"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	ID                      uint64                     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                    Type                       `protobuf:"varint,2,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
+	Error                   string                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Leader                  string                     `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	ResourceHeartbeat       ResourceHeartbeatRsp       `protobuf:"bytes,5,opt,name=resourceHeartbeat,proto3" json:"resourceHeartbeat"`
+	ContainerHeartbeat      ContainerHeartbeatRsp      `protobuf:"bytes,6,opt,name=containerHeartbeat,proto3" json:"containerHeartbeat"`
+	PutContainer            PutContainerRsp            `protobuf:"bytes,7,opt,name=putContainer,proto3" json:"putContainer"`
+	GetContainer            GetContainerRsp            `protobuf:"bytes,8,opt,name=getContainer,proto3" json:"getContainer"`
+	AllocID                 AllocIDRsp                 `protobuf:"bytes,9,opt,name=allocID,proto3" json:"allocID"`
+	AskSplit                AskSplitRsp                `protobuf:"bytes,10,opt,name=askSplit,proto3" json:"askSplit"`
+	AskBatchSplit           AskBatchSplitRsp           `protobuf:"bytes,11,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
+	ReportSplit             ReportSplitRsp             `protobuf:"bytes,12,opt,name=reportSplit,proto3" json:"reportSplit"`
+	BatchReportSplit        BatchReportSplitRsp        `protobuf:"bytes,13,opt,name=batchReportSplit,proto3" json:"batchReportSplit"`
+	Event                   EventNotify                `protobuf:"bytes,14,opt,name=event,proto3" json:"event"`
+	CreateResources         CreateResourcesRsp         `protobuf:"bytes,15,opt,name=createResources,proto3" json:"createResources"`
+	RemoveResources         RemoveResourcesRsp         `protobuf:"bytes,16,opt,name=removeResources,proto3" json:"removeResources"`
+	CheckResourceState      CheckResourceStateRsp      `protobuf:"bytes,17,opt,name=checkResourceState,proto3" json:"checkResourceState"`
+	PutPlacementRule        PutPlacementRuleRsp        `protobuf:"bytes,18,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
+	GetAppliedRules         GetAppliedRulesRsp         `protobuf:"bytes,19,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
+	CreateJob               CreateJobRsp               `protobuf:"bytes,20,opt,name=createJob,proto3" json:"createJob"`
+	RemoveJob               RemoveJobRsp               `protobuf:"bytes,21,opt,name=removeJob,proto3" json:"removeJob"`
+	ExecuteJob              ExecuteJobRsp              `protobuf:"bytes,22,opt,name=executeJob,proto3" json:"executeJob"`
+	TransferLeader          TransferLeaderRsp          `protobuf:"bytes,23,opt,name=transferLeader,proto3" json:"transferLeader"`
+	AllocTimestamp          AllocTimestampRsp          `protobuf:"bytes,24,opt,name=allocTimestamp,proto3" json:"allocTimestamp"`
+	GetRateLimits           GetRateLimitsRsp           `protobuf:"bytes,25,opt,name=getRateLimits,proto3" json:"getRateLimits"`
+	GetWriteFrozenGroups    GetWriteFrozenGroupsRsp    `protobuf:"bytes,26,opt,name=getWriteFrozenGroups,proto3" json:"getWriteFrozenGroups"`
+	GetDecommissionProgress GetDecommissionProgressRsp `protobuf:"bytes,27,opt,name=getDecommissionProgress,proto3" json:"getDecommissionProgress"`
+	XXX_NoUnkeyedLiteral    struct{}                   `json:"-"`
+	XXX_unrecognized        []byte                     `json:"-"`
+	XXX_sizecache           int32                      `json:"-"`
 }
 
 func (m *Response) Reset()         { *m = Response{} }
@@ -652,6 +757,41 @@ func (m *Response) GetExecuteJob() ExecuteJobRsp {
 	return ExecuteJobRsp{}
 }
 
+func (m *Response) GetTransferLeader() TransferLeaderRsp {
+	if m != nil {
+		return m.TransferLeader
+	}
+	return TransferLeaderRsp{}
+}
+
+func (m *Response) GetAllocTimestamp() AllocTimestampRsp {
+	if m != nil {
+		return m.AllocTimestamp
+	}
+	return AllocTimestampRsp{}
+}
+
+func (m *Response) GetGetRateLimits() GetRateLimitsRsp {
+	if m != nil {
+		return m.GetRateLimits
+	}
+	return GetRateLimitsRsp{}
+}
+
+func (m *Response) GetGetWriteFrozenGroups() GetWriteFrozenGroupsRsp {
+	if m != nil {
+		return m.GetWriteFrozenGroups
+	}
+	return GetWriteFrozenGroupsRsp{}
+}
+
+func (m *Response) GetGetDecommissionProgress() GetDecommissionProgressRsp {
+	if m != nil {
+		return m.GetDecommissionProgress
+	}
+	return GetDecommissionProgressRsp{}
+}
+
 // ResourceHeartbeatReq resource heartbeat request
 type ResourceHeartbeatReq struct {
 	ContainerID uint64 `protobuf:"varint,1,opt,name=containerID,proto3" json:"containerID,omitempty"`
@@ -969,6 +1109,7 @@ var xxx_messageInfo_PutContainerRsp proto.InternalMessageInfo
 type ContainerHeartbeatReq struct {
 	Stats                metapb.ContainerStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats"`
 	Data                 []byte                `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	WriteFrozenGroups    []uint64              `protobuf:"varint,3,rep,packed,name=writeFrozenGroups,proto3" json:"writeFrozenGroups,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -1021,12 +1162,21 @@ func (m *ContainerHeartbeatReq) GetData() []byte {
 	return nil
 }
 
+func (m *ContainerHeartbeatReq) GetWriteFrozenGroups() []uint64 {
+	if m != nil {
+		return m.WriteFrozenGroups
+	}
+	return nil
+}
+
 // ContainerHeartbeatRsp container heartbeat response
 type ContainerHeartbeatRsp struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Data                 []byte             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	RateLimits           []metapb.RateLimit `protobuf:"bytes,2,rep,name=rateLimits,proto3" json:"rateLimits"`
+	WriteFrozenGroups    []uint64           `protobuf:"varint,3,rep,packed,name=writeFrozenGroups,proto3" json:"writeFrozenGroups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ContainerHeartbeatRsp) Reset()         { *m = ContainerHeartbeatRsp{} }
@@ -1069,6 +1219,20 @@ func (m *ContainerHeartbeatRsp) GetData() []byte {
 	return nil
 }
 
+func (m *ContainerHeartbeatRsp) GetRateLimits() []metapb.RateLimit {
+	if m != nil {
+		return m.RateLimits
+	}
+	return nil
+}
+
+func (m *ContainerHeartbeatRsp) GetWriteFrozenGroups() []uint64 {
+	if m != nil {
+		return m.WriteFrozenGroups
+	}
+	return nil
+}
+
 // GetContainerReq get container request
 type GetContainerReq struct {
 	ID                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1753,6 +1917,7 @@ func (m *CreateWatcherReq) GetFlag() uint32 {
 type CreateResourcesReq struct {
 	Resources            [][]byte `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
 	LeastPeers           []uint64 `protobuf:"varint,2,rep,packed,name=leastPeers,proto3" json:"leastPeers,omitempty"`
+	Scatter              bool     `protobuf:"varint,3,opt,name=scatter,proto3" json:"scatter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1805,6 +1970,13 @@ func (m *CreateResourcesReq) GetLeastPeers() []uint64 {
 	return nil
 }
 
+func (m *CreateResourcesReq) GetScatter() bool {
+	if m != nil {
+		return m.Scatter
+	}
+	return false
+}
+
 // CreateResourcesRsp create resources rsp
 type CreateResourcesRsp struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2493,6 +2665,102 @@ func (m *ExecuteJobRsp) GetData() []byte {
 	return nil
 }
 
+// TransferLeaderReq transfer the leader of the resource to the container req
+type TransferLeaderReq struct {
+	ResourceID           uint64   `protobuf:"varint,1,opt,name=resourceID,proto3" json:"resourceID,omitempty"`
+	ContainerID          uint64   `protobuf:"varint,2,opt,name=containerID,proto3" json:"containerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferLeaderReq) Reset()         { *m = TransferLeaderReq{} }
+func (m *TransferLeaderReq) String() string { return proto.CompactTextString(m) }
+func (*TransferLeaderReq) ProtoMessage()    {}
+func (*TransferLeaderReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{38}
+}
+func (m *TransferLeaderReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferLeaderReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferLeaderReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferLeaderReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeaderReq.Merge(m, src)
+}
+func (m *TransferLeaderReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferLeaderReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeaderReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeaderReq proto.InternalMessageInfo
+
+func (m *TransferLeaderReq) GetResourceID() uint64 {
+	if m != nil {
+		return m.ResourceID
+	}
+	return 0
+}
+
+func (m *TransferLeaderReq) GetContainerID() uint64 {
+	if m != nil {
+		return m.ContainerID
+	}
+	return 0
+}
+
+// TransferLeaderRsp transfer leader rsp
+type TransferLeaderRsp struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferLeaderRsp) Reset()         { *m = TransferLeaderRsp{} }
+func (m *TransferLeaderRsp) String() string { return proto.CompactTextString(m) }
+func (*TransferLeaderRsp) ProtoMessage()    {}
+func (*TransferLeaderRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{39}
+}
+func (m *TransferLeaderRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferLeaderRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferLeaderRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferLeaderRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeaderRsp.Merge(m, src)
+}
+func (m *TransferLeaderRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferLeaderRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeaderRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeaderRsp proto.InternalMessageInfo
+
 // EventNotify event notify
 type EventNotify struct {
 	Seq                  uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
@@ -2502,6 +2770,7 @@ type EventNotify struct {
 	ContainerEvent       *ContainerEventData    `protobuf:"bytes,5,opt,name=containerEvent,proto3" json:"containerEvent,omitempty"`
 	ResourceStatsEvent   *metapb.ResourceStats  `protobuf:"bytes,6,opt,name=resourceStatsEvent,proto3" json:"resourceStatsEvent,omitempty"`
 	ContainerStatsEvent  *metapb.ContainerStats `protobuf:"bytes,7,opt,name=containerStatsEvent,proto3" json:"containerStatsEvent,omitempty"`
+	WriteFrozenGroups    []uint64               `protobuf:"varint,8,rep,packed,name=writeFrozenGroups,proto3" json:"writeFrozenGroups,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
@@ -2511,7 +2780,7 @@ func (m *EventNotify) Reset()         { *m = EventNotify{} }
 func (m *EventNotify) String() string { return proto.CompactTextString(m) }
 func (*EventNotify) ProtoMessage()    {}
 func (*EventNotify) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{38}
+	return fileDescriptor_25e491924c678914, []int{40}
 }
 func (m *EventNotify) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2589,6 +2858,13 @@ func (m *EventNotify) GetContainerStatsEvent() *metapb.ContainerStats {
 	return nil
 }
 
+func (m *EventNotify) GetWriteFrozenGroups() []uint64 {
+	if m != nil {
+		return m.WriteFrozenGroups
+	}
+	return nil
+}
+
 // InitEventData init event data
 type InitEventData struct {
 	Resources            [][]byte `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
@@ -2603,7 +2879,7 @@ func (m *InitEventData) Reset()         { *m = InitEventData{} }
 func (m *InitEventData) String() string { return proto.CompactTextString(m) }
 func (*InitEventData) ProtoMessage()    {}
 func (*InitEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{39}
+	return fileDescriptor_25e491924c678914, []int{41}
 }
 func (m *InitEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2668,7 +2944,7 @@ func (m *ResourceEventData) Reset()         { *m = ResourceEventData{} }
 func (m *ResourceEventData) String() string { return proto.CompactTextString(m) }
 func (*ResourceEventData) ProtoMessage()    {}
 func (*ResourceEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{40}
+	return fileDescriptor_25e491924c678914, []int{42}
 }
 func (m *ResourceEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2737,7 +3013,7 @@ func (m *ContainerEventData) Reset()         { *m = ContainerEventData{} }
 func (m *ContainerEventData) String() string { return proto.CompactTextString(m) }
 func (*ContainerEventData) ProtoMessage()    {}
 func (*ContainerEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{41}
+	return fileDescriptor_25e491924c678914, []int{43}
 }
 func (m *ContainerEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2786,7 +3062,7 @@ func (m *ChangePeer) Reset()         { *m = ChangePeer{} }
 func (m *ChangePeer) String() string { return proto.CompactTextString(m) }
 func (*ChangePeer) ProtoMessage()    {}
 func (*ChangePeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{42}
+	return fileDescriptor_25e491924c678914, []int{44}
 }
 func (m *ChangePeer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2841,7 +3117,7 @@ func (m *TransferLeader) Reset()         { *m = TransferLeader{} }
 func (m *TransferLeader) String() string { return proto.CompactTextString(m) }
 func (*TransferLeader) ProtoMessage()    {}
 func (*TransferLeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{43}
+	return fileDescriptor_25e491924c678914, []int{45}
 }
 func (m *TransferLeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2890,7 +3166,7 @@ func (m *ChangePeerV2) Reset()         { *m = ChangePeerV2{} }
 func (m *ChangePeerV2) String() string { return proto.CompactTextString(m) }
 func (*ChangePeerV2) ProtoMessage()    {}
 func (*ChangePeerV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{44}
+	return fileDescriptor_25e491924c678914, []int{46}
 }
 func (m *ChangePeerV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2939,7 +3215,7 @@ func (m *Merge) Reset()         { *m = Merge{} }
 func (m *Merge) String() string { return proto.CompactTextString(m) }
 func (*Merge) ProtoMessage()    {}
 func (*Merge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{45}
+	return fileDescriptor_25e491924c678914, []int{47}
 }
 func (m *Merge) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2988,7 +3264,7 @@ func (m *SplitResource) Reset()         { *m = SplitResource{} }
 func (m *SplitResource) String() string { return proto.CompactTextString(m) }
 func (*SplitResource) ProtoMessage()    {}
 func (*SplitResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{46}
+	return fileDescriptor_25e491924c678914, []int{48}
 }
 func (m *SplitResource) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3045,7 +3321,7 @@ func (m *LabelConstraint) Reset()         { *m = LabelConstraint{} }
 func (m *LabelConstraint) String() string { return proto.CompactTextString(m) }
 func (*LabelConstraint) ProtoMessage()    {}
 func (*LabelConstraint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{47}
+	return fileDescriptor_25e491924c678914, []int{49}
 }
 func (m *LabelConstraint) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3126,7 +3402,7 @@ func (m *PlacementRule) Reset()         { *m = PlacementRule{} }
 func (m *PlacementRule) String() string { return proto.CompactTextString(m) }
 func (*PlacementRule) ProtoMessage()    {}
 func (*PlacementRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{48}
+	return fileDescriptor_25e491924c678914, []int{50}
 }
 func (m *PlacementRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3232,409 +3508,738 @@ func (m *PlacementRule) GetIsolationLevel() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterEnum("rpcpb.Type", Type_name, Type_value)
-	proto.RegisterEnum("rpcpb.PeerRoleType", PeerRoleType_name, PeerRoleType_value)
-	proto.RegisterEnum("rpcpb.LabelConstraintOp", LabelConstraintOp_name, LabelConstraintOp_value)
-	proto.RegisterType((*Request)(nil), "rpcpb.Request")
-	proto.RegisterType((*Response)(nil), "rpcpb.Response")
-	proto.RegisterType((*ResourceHeartbeatReq)(nil), "rpcpb.ResourceHeartbeatReq")
-	proto.RegisterType((*ResourceHeartbeatRsp)(nil), "rpcpb.ResourceHeartbeatRsp")
-	proto.RegisterType((*PutContainerReq)(nil), "rpcpb.PutContainerReq")
-	proto.RegisterType((*PutContainerRsp)(nil), "rpcpb.PutContainerRsp")
-	proto.RegisterType((*ContainerHeartbeatReq)(nil), "rpcpb.ContainerHeartbeatReq")
-	proto.RegisterType((*ContainerHeartbeatRsp)(nil), "rpcpb.ContainerHeartbeatRsp")
-	proto.RegisterType((*GetContainerReq)(nil), "rpcpb.GetContainerReq")
-	proto.RegisterType((*GetContainerRsp)(nil), "rpcpb.GetContainerRsp")
-	proto.RegisterType((*AllocIDReq)(nil), "rpcpb.AllocIDReq")
-	proto.RegisterType((*AllocIDRsp)(nil), "rpcpb.AllocIDRsp")
-	proto.RegisterType((*AskSplitReq)(nil), "rpcpb.AskSplitReq")
-	proto.RegisterType((*AskSplitRsp)(nil), "rpcpb.AskSplitRsp")
-	proto.RegisterType((*ReportSplitReq)(nil), "rpcpb.ReportSplitReq")
-	proto.RegisterType((*ReportSplitRsp)(nil), "rpcpb.ReportSplitRsp")
-	proto.RegisterType((*AskBatchSplitReq)(nil), "rpcpb.AskBatchSplitReq")
-	proto.RegisterType((*AskBatchSplitRsp)(nil), "rpcpb.AskBatchSplitRsp")
-	proto.RegisterType((*BatchReportSplitReq)(nil), "rpcpb.BatchReportSplitReq")
-	proto.RegisterType((*BatchReportSplitRsp)(nil), "rpcpb.BatchReportSplitRsp")
-	proto.RegisterType((*SplitID)(nil), "rpcpb.SplitID")
-	proto.RegisterType((*CreateWatcherReq)(nil), "rpcpb.CreateWatcherReq")
-	proto.RegisterType((*CreateResourcesReq)(nil), "rpcpb.CreateResourcesReq")
-	proto.RegisterType((*CreateResourcesRsp)(nil), "rpcpb.CreateResourcesRsp")
-	proto.RegisterType((*RemoveResourcesReq)(nil), "rpcpb.RemoveResourcesReq")
-	proto.RegisterType((*RemoveResourcesRsp)(nil), "rpcpb.RemoveResourcesRsp")
-	proto.RegisterType((*CheckResourceStateReq)(nil), "rpcpb.CheckResourceStateReq")
-	proto.RegisterType((*CheckResourceStateRsp)(nil), "rpcpb.CheckResourceStateRsp")
-	proto.RegisterType((*PutPlacementRuleReq)(nil), "rpcpb.PutPlacementRuleReq")
-	proto.RegisterType((*PutPlacementRuleRsp)(nil), "rpcpb.PutPlacementRuleRsp")
-	proto.RegisterType((*GetAppliedRulesReq)(nil), "rpcpb.GetAppliedRulesReq")
-	proto.RegisterType((*GetAppliedRulesRsp)(nil), "rpcpb.GetAppliedRulesRsp")
-	proto.RegisterType((*CreateJobReq)(nil), "rpcpb.CreateJobReq")
-	proto.RegisterType((*CreateJobRsp)(nil), "rpcpb.CreateJobRsp")
-	proto.RegisterType((*RemoveJobReq)(nil), "rpcpb.RemoveJobReq")
-	proto.RegisterType((*RemoveJobRsp)(nil), "rpcpb.RemoveJobRsp")
-	proto.RegisterType((*ExecuteJobReq)(nil), "rpcpb.ExecuteJobReq")
-	proto.RegisterType((*ExecuteJobRsp)(nil), "rpcpb.ExecuteJobRsp")
-	proto.RegisterType((*EventNotify)(nil), "rpcpb.EventNotify")
-	proto.RegisterType((*InitEventData)(nil), "rpcpb.InitEventData")
-	proto.RegisterType((*ResourceEventData)(nil), "rpcpb.ResourceEventData")
-	proto.RegisterType((*ContainerEventData)(nil), "rpcpb.ContainerEventData")
-	proto.RegisterType((*ChangePeer)(nil), "rpcpb.ChangePeer")
-	proto.RegisterType((*TransferLeader)(nil), "rpcpb.TransferLeader")
-	proto.RegisterType((*ChangePeerV2)(nil), "rpcpb.ChangePeerV2")
-	proto.RegisterType((*Merge)(nil), "rpcpb.Merge")
-	proto.RegisterType((*SplitResource)(nil), "rpcpb.SplitResource")
-	proto.RegisterType((*LabelConstraint)(nil), "rpcpb.LabelConstraint")
-	proto.RegisterType((*PlacementRule)(nil), "rpcpb.PlacementRule")
+// AllocTimestampReq alloc timestamp request
+type AllocTimestampReq struct {
+	// Count the number of the timestamps
+	Count                uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("rpcpb.proto", fileDescriptor_25e491924c678914) }
-
-var fileDescriptor_25e491924c678914 = []byte{
-	// 2369 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0x4b, 0x73, 0xdc, 0xc6,
-	0x11, 0xd6, 0xbe, 0x77, 0x7b, 0x1f, 0x1c, 0xce, 0x92, 0x14, 0x44, 0xc9, 0x24, 0x0d, 0xa9, 0x14,
-	0x46, 0x76, 0xc8, 0x88, 0x72, 0xec, 0x94, 0x2a, 0x4a, 0x4c, 0x8a, 0xb4, 0x45, 0x45, 0x91, 0x59,
-	0x90, 0x4a, 0x39, 0xa6, 0xb0, 0xbb, 0xa3, 0x25, 0x42, 0x10, 0x18, 0x61, 0x66, 0x25, 0xf1, 0x96,
-	0x9f, 0x94, 0x53, 0x8e, 0x39, 0xe5, 0xe0, 0xa3, 0x7f, 0x40, 0x4a, 0x95, 0xf0, 0x6f, 0xe4, 0x92,
-	0x9a, 0x07, 0x80, 0xc1, 0x63, 0x97, 0xf4, 0x89, 0x98, 0xee, 0xfe, 0xbe, 0x99, 0x69, 0x0c, 0xbe,
-	0xe9, 0x5e, 0x42, 0x37, 0xa2, 0x63, 0x3a, 0xda, 0xa1, 0x51, 0xc8, 0x43, 0xdc, 0x90, 0x83, 0xf5,
-	0x17, 0x53, 0x8f, 0x9f, 0xce, 0x46, 0x3b, 0xe3, 0xf0, 0x7c, 0xf7, 0xdc, 0xe5, 0x91, 0xf7, 0x31,
-	0x8c, 0xbc, 0xa9, 0x17, 0xe8, 0xc1, 0x78, 0x36, 0x22, 0xbb, 0xe3, 0xf0, 0x9c, 0x86, 0x01, 0x09,
-	0x38, 0xdb, 0xa5, 0x51, 0x48, 0x4f, 0x09, 0xdf, 0xa5, 0xa3, 0xdd, 0x73, 0xc2, 0xdd, 0xe4, 0x8f,
-	0x22, 0x5d, 0xff, 0x95, 0xc1, 0x36, 0x0d, 0xa7, 0xe1, 0xae, 0x34, 0x8f, 0x66, 0x6f, 0xe5, 0x48,
-	0x0e, 0xe4, 0x93, 0x0a, 0xb7, 0xff, 0x09, 0xd0, 0x72, 0xc8, 0xbb, 0x19, 0x61, 0x1c, 0xaf, 0x41,
-	0xd5, 0x9b, 0x58, 0x95, 0xad, 0xca, 0x76, 0xfd, 0xa0, 0x79, 0xf9, 0x69, 0xb3, 0x7a, 0x7c, 0xe8,
-	0x54, 0xbd, 0x09, 0xde, 0x82, 0xee, 0x38, 0x0c, 0xb8, 0xeb, 0x05, 0x24, 0x3a, 0x3e, 0xb4, 0xaa,
-	0x22, 0xc0, 0x31, 0x4d, 0x78, 0x13, 0xea, 0xfc, 0x82, 0x12, 0xab, 0xb6, 0x55, 0xd9, 0x1e, 0xec,
-	0x75, 0x77, 0xd4, 0x2e, 0x5f, 0x5f, 0x50, 0xe2, 0x48, 0x07, 0xfe, 0x01, 0x96, 0x23, 0xc2, 0xc2,
-	0x59, 0x34, 0x26, 0xcf, 0x88, 0x1b, 0xf1, 0x11, 0x71, 0xb9, 0x55, 0xdf, 0xaa, 0x6c, 0x77, 0xf7,
-	0x6e, 0xeb, 0x68, 0x27, 0xef, 0x77, 0xc8, 0xbb, 0x83, 0xfa, 0x8f, 0x9f, 0x36, 0x6f, 0x38, 0x45,
-	0x2c, 0x76, 0x00, 0x27, 0x0b, 0x48, 0x19, 0x1b, 0x92, 0xf1, 0x8e, 0x66, 0x7c, 0x5a, 0x08, 0x48,
-	0x29, 0x4b, 0xd0, 0xf8, 0x5b, 0xe8, 0xd1, 0x19, 0x4f, 0x50, 0x56, 0x53, 0xb2, 0xad, 0x69, 0xb6,
-	0x13, 0xc3, 0x95, 0xf2, 0x64, 0x10, 0x82, 0x61, 0x4a, 0x0c, 0x86, 0x56, 0x86, 0xe1, 0x7b, 0x52,
-	0xca, 0x60, 0x22, 0xf0, 0x43, 0x68, 0xb9, 0xbe, 0x1f, 0x8e, 0x8f, 0x0f, 0xad, 0xb6, 0x04, 0x2f,
-	0x6b, 0xf0, 0xbe, 0xb2, 0xa6, 0xb8, 0x38, 0x0e, 0x7f, 0x05, 0x6d, 0x97, 0x9d, 0xbd, 0xa2, 0xbe,
-	0xc7, 0xad, 0x8e, 0xc4, 0xe0, 0x18, 0xa3, 0xcd, 0x29, 0x28, 0x89, 0xc4, 0x4f, 0xa1, 0xef, 0xb2,
-	0xb3, 0x03, 0x97, 0x8f, 0x4f, 0x15, 0x14, 0x24, 0xf4, 0x66, 0x0a, 0x4d, 0x7d, 0x29, 0x3e, 0x8b,
-	0xc1, 0x4f, 0xa0, 0x1b, 0x11, 0x1a, 0x46, 0x5c, 0x51, 0x74, 0x25, 0xc5, 0x6a, 0xf2, 0x42, 0x13,
-	0x4f, 0x4a, 0x60, 0xc6, 0xe3, 0x17, 0x80, 0x46, 0x82, 0xcc, 0x88, 0xb4, 0x7a, 0x92, 0x63, 0x5d,
-	0x73, 0x1c, 0xe4, 0xdc, 0x29, 0x51, 0x01, 0x29, 0x76, 0x34, 0x8e, 0x88, 0xcb, 0xc9, 0x9f, 0x85,
-	0x87, 0x44, 0x56, 0x3f, 0xb3, 0xa3, 0xa7, 0xa6, 0xcf, 0xd8, 0x51, 0x06, 0x83, 0x8f, 0x61, 0x49,
-	0x19, 0xe2, 0xe3, 0xc8, 0xac, 0x81, 0xa4, 0xb9, 0x95, 0xa1, 0x49, 0xbc, 0x29, 0x51, 0x1e, 0x27,
-	0xa8, 0x22, 0x72, 0x1e, 0xbe, 0x37, 0xa8, 0x96, 0x32, 0x54, 0x4e, 0xd6, 0x6b, 0x50, 0xe5, 0x70,
-	0xf2, 0xb4, 0x9f, 0x92, 0xf1, 0x59, 0x6c, 0x79, 0xc5, 0x5d, 0x4e, 0x2c, 0x94, 0x3d, 0xed, 0x85,
-	0x00, 0xf3, 0xb4, 0x17, 0x9c, 0x22, 0xf9, 0x74, 0xc6, 0x4f, 0x7c, 0x77, 0x4c, 0xce, 0x49, 0xc0,
-	0x9d, 0x99, 0x4f, 0xac, 0xe5, 0x4c, 0xf2, 0x4f, 0x72, 0x6e, 0x23, 0xf9, 0x79, 0xa4, 0xd8, 0xec,
-	0x94, 0xf0, 0x7d, 0x4a, 0x7d, 0x8f, 0x4c, 0x84, 0x85, 0x59, 0x38, 0xb3, 0xd9, 0xef, 0xb3, 0x5e,
-	0x63, 0xb3, 0x39, 0x1c, 0xfe, 0x06, 0x3a, 0x2a, 0x95, 0xcf, 0xc3, 0x91, 0x35, 0x94, 0x24, 0xc3,
-	0x4c, 0xf2, 0x9f, 0x87, 0xa3, 0x14, 0x9e, 0xc6, 0x0a, 0xa0, 0x4a, 0x9c, 0x00, 0xae, 0x64, 0x80,
-	0x4e, 0x6c, 0x37, 0x80, 0x49, 0x2c, 0x7e, 0x0c, 0x40, 0x3e, 0x92, 0xf1, 0x4c, 0x4d, 0xb9, 0x2a,
-	0x91, 0x2b, 0x1a, 0x79, 0x94, 0x38, 0x52, 0xa8, 0x11, 0x6d, 0xff, 0x03, 0xa0, 0xed, 0x10, 0x46,
-	0xc3, 0x80, 0x91, 0xb9, 0x0a, 0x1a, 0xeb, 0x63, 0x75, 0x9e, 0x3e, 0xae, 0x40, 0x83, 0x44, 0x51,
-	0x18, 0x49, 0x05, 0xed, 0x38, 0x6a, 0x80, 0xd7, 0xa0, 0xe9, 0x13, 0x77, 0x42, 0x22, 0x29, 0x95,
-	0x1d, 0x47, 0x8f, 0xca, 0xd5, 0xb4, 0x71, 0x85, 0x9a, 0x32, 0xfa, 0x73, 0xd5, 0xb4, 0x79, 0x95,
-	0x9a, 0x26, 0x94, 0xd7, 0x51, 0xd3, 0xd6, 0x7c, 0x35, 0x4d, 0x78, 0x16, 0xab, 0x69, 0x7b, 0xbe,
-	0x9a, 0xa6, 0x0c, 0xf3, 0xd4, 0xb4, 0x53, 0xaa, 0xa6, 0x09, 0xae, 0x54, 0x4d, 0xa1, 0x5c, 0x4d,
-	0x13, 0xd0, 0x02, 0x35, 0xed, 0x2e, 0x50, 0xd3, 0x04, 0xbf, 0x58, 0x4d, 0x7b, 0x73, 0xd5, 0x34,
-	0x21, 0xb8, 0x52, 0x4d, 0xfb, 0x8b, 0xd5, 0x34, 0x21, 0x2a, 0xaa, 0xe9, 0x0e, 0x34, 0xc8, 0x7b,
-	0x12, 0x70, 0x2d, 0x7f, 0x71, 0x12, 0x8e, 0x84, 0xed, 0x65, 0xc8, 0xbd, 0xb7, 0x17, 0x1a, 0xaa,
-	0xc2, 0xca, 0x84, 0x73, 0x69, 0xa1, 0x70, 0x26, 0x73, 0x5f, 0x47, 0x38, 0xd1, 0x42, 0xe1, 0x4c,
-	0xa9, 0xae, 0x27, 0x9c, 0xcb, 0x57, 0x09, 0xa7, 0x71, 0xb0, 0xaf, 0x27, 0x9c, 0x78, 0xb1, 0x70,
-	0xa6, 0x79, 0xbe, 0x8e, 0x70, 0x0e, 0x17, 0x0a, 0x67, 0xba, 0xd9, 0x85, 0xc2, 0xb9, 0x32, 0x47,
-	0x38, 0x13, 0xf8, 0x3c, 0xe1, 0x5c, 0x9d, 0x23, 0x9c, 0x29, 0x70, 0x9e, 0x70, 0xae, 0xcd, 0x13,
-	0xce, 0x04, 0x6a, 0x0a, 0xe7, 0xdf, 0xab, 0xb0, 0x52, 0x56, 0xf3, 0xe5, 0xcb, 0xcd, 0x4a, 0xb1,
-	0xdc, 0x5c, 0x87, 0x76, 0xac, 0x61, 0x52, 0x52, 0x7b, 0x4e, 0x32, 0xc6, 0x18, 0xea, 0x9c, 0x44,
-	0xe7, 0x52, 0x48, 0xeb, 0x8e, 0x7c, 0xc6, 0xf7, 0x32, 0x3a, 0xda, 0xdd, 0xeb, 0xed, 0xe8, 0x92,
-	0xf9, 0x84, 0x90, 0x28, 0x51, 0xd5, 0xdf, 0x40, 0x67, 0x12, 0x7e, 0x08, 0x84, 0x8d, 0x59, 0x8d,
-	0xad, 0x9a, 0x94, 0x0b, 0x23, 0x50, 0xbc, 0x7d, 0x16, 0xe7, 0x20, 0x89, 0xc4, 0x5f, 0x43, 0x8f,
-	0x92, 0x60, 0xe2, 0x05, 0x53, 0x85, 0x6c, 0x4a, 0x64, 0x66, 0x8a, 0x44, 0xdd, 0x8c, 0x38, 0xfc,
-	0x10, 0x1a, 0x4c, 0x30, 0x6a, 0x61, 0x5c, 0x8d, 0x01, 0xe6, 0x61, 0x8b, 0xa7, 0x53, 0x91, 0xf6,
-	0xbf, 0x6b, 0x65, 0x29, 0x63, 0x14, 0x6f, 0x00, 0xc4, 0x09, 0x48, 0x32, 0x66, 0x58, 0xf0, 0x3e,
-	0xf4, 0xe3, 0xd1, 0x11, 0x0d, 0xc7, 0xa7, 0x32, 0x6b, 0x25, 0x73, 0x4a, 0x67, 0x2c, 0x4e, 0x19,
-	0x04, 0xfe, 0x12, 0x80, 0xbb, 0xd1, 0x94, 0x70, 0xb1, 0x7a, 0x99, 0xdd, 0x7c, 0x1e, 0x0d, 0x3f,
-	0x7e, 0x08, 0x30, 0x3e, 0x75, 0x83, 0x29, 0x91, 0xd1, 0xf5, 0x8c, 0xf6, 0x3e, 0x4d, 0x1c, 0x8e,
-	0x11, 0x84, 0x9f, 0xc0, 0x80, 0x47, 0x6e, 0xc0, 0xde, 0x92, 0xe8, 0x85, 0x7a, 0x59, 0x8d, 0x8c,
-	0x00, 0xbe, 0xce, 0x38, 0x9d, 0x5c, 0x30, 0xb6, 0xa1, 0x71, 0x4e, 0xa2, 0x29, 0xd1, 0xb7, 0x56,
-	0x4f, 0xa3, 0xfe, 0x24, 0x6c, 0x8e, 0x72, 0xe1, 0xc7, 0xd0, 0x67, 0xaa, 0x8a, 0xd4, 0x87, 0xa7,
-	0x95, 0x39, 0xb1, 0xaf, 0x4c, 0x9f, 0x93, 0x0d, 0xc5, 0xdf, 0x40, 0x2f, 0x5d, 0xec, 0x9b, 0x3d,
-	0x7d, 0x19, 0x0d, 0x0b, 0x7b, 0x7a, 0xb3, 0xe7, 0x64, 0x02, 0xf1, 0x36, 0x2c, 0x4d, 0x08, 0xe3,
-	0x61, 0x74, 0x71, 0xe8, 0x45, 0x64, 0xcc, 0xfd, 0x0b, 0x79, 0x17, 0xb5, 0x9d, 0xbc, 0xd9, 0xde,
-	0x85, 0xa5, 0x5c, 0x93, 0x81, 0xef, 0x40, 0x27, 0x39, 0xf8, 0xf2, 0xbd, 0xf6, 0x9c, 0xd4, 0x60,
-	0x2f, 0xe7, 0x00, 0x8c, 0xda, 0x7f, 0x81, 0xd5, 0xd2, 0xb6, 0x07, 0xef, 0xc5, 0xc7, 0xad, 0xa2,
-	0x6f, 0x51, 0xfd, 0xea, 0x92, 0xe8, 0xe2, 0x79, 0x13, 0xdf, 0xd2, 0xc4, 0xe5, 0xae, 0xfe, 0xc6,
-	0xe4, 0xb3, 0xfd, 0x45, 0xe9, 0x04, 0x8c, 0x26, 0xc1, 0x15, 0x23, 0xf8, 0x97, 0xb0, 0x94, 0x6b,
-	0x7a, 0xe6, 0x95, 0x48, 0xf6, 0xab, 0x5c, 0x68, 0x39, 0x23, 0xfe, 0x32, 0xde, 0x46, 0x75, 0xd1,
-	0x36, 0xe2, 0x0f, 0xa6, 0x07, 0x90, 0xf6, 0x4d, 0xf6, 0xbd, 0x74, 0xc4, 0xe8, 0xdc, 0x85, 0x7c,
-	0x0e, 0x5d, 0xa3, 0x6f, 0x2a, 0xdd, 0xd6, 0x13, 0x23, 0x84, 0x51, 0xbc, 0x03, 0x2d, 0x79, 0x56,
-	0xf4, 0xa7, 0xd7, 0xdd, 0x1b, 0x98, 0x07, 0xea, 0xf8, 0x30, 0x2e, 0x31, 0x74, 0x90, 0xfd, 0x18,
-	0x06, 0xd9, 0x96, 0x46, 0x4c, 0xe2, 0x93, 0xb7, 0x3c, 0x9e, 0x44, 0x3c, 0x8b, 0x92, 0x30, 0xf2,
-	0xa6, 0xa7, 0x5c, 0x67, 0x5f, 0x0d, 0x6c, 0x94, 0xc5, 0x32, 0x6a, 0xff, 0x0e, 0x50, 0xbe, 0x59,
-	0x2b, 0xcd, 0xdc, 0x0a, 0x34, 0xc6, 0xe1, 0x2c, 0x50, 0x7c, 0x7d, 0x47, 0x0d, 0xec, 0xc3, 0x3c,
-	0x9a, 0x51, 0xfc, 0x6b, 0x68, 0xeb, 0xa5, 0x8a, 0xd3, 0x52, 0x9b, 0xbb, 0xa1, 0x24, 0xca, 0x7e,
-	0x04, 0xc3, 0x92, 0x4e, 0x4d, 0x9c, 0xde, 0x28, 0xb9, 0xc2, 0x05, 0x53, 0xcf, 0x49, 0x0d, 0xf6,
-	0x6a, 0x09, 0x88, 0x51, 0xfb, 0x0f, 0xd0, 0xd2, 0xd3, 0x88, 0x25, 0x07, 0xe4, 0x43, 0xa2, 0x68,
-	0x6a, 0x20, 0xc4, 0x2e, 0x20, 0x1f, 0xc4, 0xd7, 0x25, 0x16, 0x58, 0xdd, 0xaa, 0x09, 0xb1, 0x4b,
-	0x2d, 0xf6, 0x7d, 0x40, 0xf9, 0x5e, 0x4f, 0x24, 0xe4, 0xad, 0xef, 0x4e, 0x25, 0x51, 0xdf, 0x91,
-	0xcf, 0xb6, 0x03, 0xb8, 0xd8, 0xcc, 0x2d, 0x5e, 0xb3, 0x98, 0xdb, 0x27, 0x2e, 0xe3, 0x4a, 0xea,
-	0xf5, 0xdc, 0xa9, 0xc5, 0x5e, 0x29, 0x72, 0x32, 0x6a, 0xef, 0x02, 0x2e, 0xf6, 0x7a, 0xf8, 0x16,
-	0xd4, 0xbc, 0x89, 0x9a, 0xa3, 0x7e, 0xd0, 0xba, 0xfc, 0xb4, 0x59, 0x3b, 0x3e, 0x64, 0x8e, 0xb0,
-	0x09, 0x9a, 0x62, 0x8d, 0x63, 0xef, 0xc1, 0x6a, 0x69, 0x93, 0x97, 0x32, 0x55, 0xb6, 0x7b, 0x39,
-	0xa6, 0x87, 0xa5, 0x18, 0x46, 0xb1, 0x05, 0x2d, 0x75, 0x8f, 0x4f, 0xd4, 0x0a, 0x9c, 0x78, 0x68,
-	0x1f, 0xc1, 0xb0, 0xa4, 0xf3, 0xc3, 0x3b, 0x50, 0x8f, 0x44, 0xa9, 0x53, 0xc9, 0x68, 0x66, 0x26,
-	0x4c, 0x9f, 0x0b, 0x19, 0x27, 0x5e, 0x6f, 0x49, 0x1d, 0x64, 0x7f, 0x05, 0xb8, 0xd8, 0x0a, 0x5e,
-	0x75, 0x81, 0xd9, 0xdf, 0x15, 0x51, 0xf2, 0xa0, 0x36, 0x22, 0x59, 0x31, 0xa9, 0x53, 0xba, 0x68,
-	0x4d, 0x2a, 0xd0, 0x7e, 0x04, 0x3d, 0xb3, 0x87, 0xc4, 0x77, 0xa1, 0xf6, 0xd7, 0x70, 0xa4, 0xf7,
-	0xd4, 0x8d, 0xc5, 0xe4, 0x79, 0x38, 0xd2, 0x30, 0xe1, 0xb5, 0x07, 0x26, 0x88, 0x51, 0x41, 0x62,
-	0xf6, 0x93, 0xd7, 0x26, 0x31, 0x6b, 0x29, 0xfb, 0x19, 0xf4, 0x33, 0xad, 0xe5, 0xb5, 0x58, 0x4a,
-	0x15, 0xf9, 0x6e, 0x86, 0x69, 0x8e, 0x12, 0xff, 0xaf, 0x0a, 0x5d, 0xa3, 0x76, 0xc7, 0x08, 0x6a,
-	0x8c, 0xbc, 0xd3, 0x99, 0x16, 0x8f, 0xb2, 0x70, 0x8a, 0x7b, 0xd4, 0xbe, 0x6e, 0x4b, 0xf7, 0xa0,
-	0xe3, 0x05, 0x1e, 0x97, 0x40, 0x7d, 0xe7, 0xc7, 0x49, 0x3e, 0x8e, 0xed, 0x87, 0x2e, 0x77, 0x9d,
-	0x34, 0x0c, 0xff, 0xde, 0xa8, 0x35, 0x24, 0x4e, 0xdd, 0xfe, 0x56, 0xae, 0x31, 0x4d, 0xb1, 0xd9,
-	0x70, 0xbc, 0x0f, 0x83, 0xe4, 0x86, 0x53, 0x04, 0x8d, 0x6c, 0x1f, 0x91, 0x71, 0x4a, 0x86, 0x1c,
-	0x00, 0x1f, 0x01, 0x8e, 0xcc, 0x2a, 0x4a, 0xd1, 0x34, 0x17, 0xd4, 0x59, 0x4e, 0x09, 0x00, 0x3f,
-	0x83, 0xe1, 0x38, 0x73, 0xad, 0x28, 0x9e, 0xd6, 0xc2, 0x9b, 0xa7, 0x0c, 0x62, 0x4f, 0xa1, 0x9f,
-	0xc9, 0xd7, 0x15, 0x2a, 0x63, 0x41, 0x4b, 0xd5, 0xa4, 0xb1, 0xc4, 0xc4, 0x43, 0xf1, 0x9d, 0x24,
-	0xfc, 0xcc, 0xaa, 0x49, 0xa0, 0x61, 0xb1, 0xdf, 0xc1, 0x72, 0x21, 0xc1, 0xa5, 0xb7, 0x41, 0xfa,
-	0xd3, 0x82, 0xfa, 0x39, 0x37, 0x2e, 0x82, 0x0d, 0x59, 0xa8, 0xc9, 0x2a, 0x25, 0x1e, 0x0a, 0x84,
-	0xea, 0x18, 0xe4, 0x0b, 0x6d, 0x3b, 0x7a, 0x64, 0x6f, 0x03, 0x2e, 0xbe, 0x92, 0xd2, 0x33, 0xe8,
-	0x03, 0xa4, 0x75, 0x12, 0xbe, 0x0f, 0x75, 0x4a, 0x74, 0x55, 0x53, 0x5e, 0x2f, 0x4b, 0x3f, 0xfe,
-	0x3a, 0x2e, 0x25, 0x5f, 0xa7, 0xbf, 0xa0, 0xa4, 0xc9, 0x4f, 0xf8, 0xe4, 0x8f, 0x29, 0x46, 0xa4,
-	0xfd, 0x5b, 0x18, 0x64, 0x4b, 0xc6, 0xeb, 0xce, 0x68, 0xef, 0x43, 0xcf, 0xac, 0xe7, 0xf0, 0x43,
-	0x68, 0x29, 0xde, 0x58, 0x68, 0x8a, 0x95, 0x6c, 0x7c, 0xc5, 0xeb, 0x38, 0x7b, 0x13, 0x1a, 0xb2,
-	0xf2, 0x14, 0x59, 0x53, 0x65, 0xb1, 0xce, 0x84, 0x1e, 0xd9, 0x27, 0xd0, 0xcf, 0x94, 0x9b, 0xf8,
-	0x0b, 0x68, 0xd2, 0xd0, 0xf7, 0xc6, 0x17, 0x32, 0x70, 0xb0, 0x37, 0x4c, 0xb7, 0x48, 0xc6, 0x67,
-	0x27, 0xd2, 0xe5, 0xe8, 0x10, 0x91, 0xdd, 0x33, 0x72, 0xa1, 0x4e, 0x47, 0xcf, 0x91, 0xcf, 0x36,
-	0x81, 0xa5, 0x17, 0xee, 0x88, 0xf8, 0x4f, 0xc3, 0x80, 0xf1, 0xc8, 0xf5, 0x02, 0x2e, 0x3e, 0xf2,
-	0x33, 0xa2, 0x08, 0x3b, 0x8e, 0x78, 0xc4, 0xdb, 0x50, 0x0d, 0xa9, 0x4e, 0x62, 0xfc, 0x45, 0xe6,
-	0x50, 0x3f, 0x50, 0xa7, 0x1a, 0x8a, 0xf2, 0xa8, 0xf9, 0xde, 0xf5, 0x67, 0x44, 0x9d, 0xb2, 0x8e,
-	0xa3, 0x47, 0xf6, 0xdf, 0x6a, 0xd0, 0xcf, 0x76, 0xb0, 0x69, 0x21, 0xd5, 0xc9, 0xfc, 0xe8, 0x65,
-	0x41, 0x6b, 0x1a, 0x85, 0x33, 0xaa, 0xff, 0x65, 0xd0, 0x71, 0xe2, 0xa1, 0xb8, 0xd7, 0xbd, 0x60,
-	0x42, 0x3e, 0xca, 0x23, 0xd6, 0x77, 0xd4, 0x40, 0x74, 0x75, 0xe1, 0x7b, 0x12, 0x45, 0xde, 0x24,
-	0x3e, 0x62, 0xc9, 0x58, 0xf8, 0x18, 0x77, 0x23, 0xfe, 0x47, 0x72, 0x21, 0xe5, 0xa0, 0xe7, 0x24,
-	0x63, 0xb1, 0x52, 0x12, 0x4c, 0x84, 0xa7, 0xa9, 0x52, 0xac, 0x46, 0xf8, 0x17, 0x50, 0x8f, 0x42,
-	0x5f, 0x15, 0xf9, 0x83, 0xa4, 0x52, 0x97, 0x7d, 0x47, 0xe8, 0x13, 0xf5, 0xe3, 0x9b, 0x08, 0x48,
-	0x2b, 0xa3, 0xb6, 0x51, 0x19, 0xe1, 0x67, 0x80, 0xfc, 0x6c, 0x66, 0x98, 0xd5, 0x91, 0xaf, 0x7f,
-	0xad, 0x3c, 0x71, 0x71, 0x8b, 0x9f, 0x47, 0xe1, 0xfb, 0x30, 0xf0, 0xc3, 0xb1, 0xcb, 0xbd, 0x30,
-	0x90, 0x10, 0x66, 0x81, 0x4c, 0x69, 0xce, 0x2a, 0xe2, 0x3c, 0x16, 0xfa, 0xca, 0x44, 0xde, 0x13,
-	0x5f, 0xfe, 0x8a, 0xd4, 0x71, 0x72, 0xd6, 0x07, 0xff, 0x6a, 0x41, 0x5d, 0x2c, 0x1f, 0xdf, 0x82,
-	0x55, 0xb9, 0x0d, 0x32, 0xf5, 0x18, 0x27, 0x51, 0xf2, 0x19, 0xa2, 0x1b, 0xf8, 0x0e, 0x58, 0xca,
-	0x55, 0x6c, 0xb0, 0x51, 0x65, 0xbe, 0x97, 0x51, 0x54, 0xc5, 0x9f, 0xc1, 0x2d, 0xe1, 0x2d, 0xed,
-	0x23, 0x50, 0x6d, 0x81, 0x9b, 0x51, 0x54, 0xc7, 0x37, 0x61, 0x28, 0xdc, 0xb9, 0x4e, 0x06, 0x35,
-	0x4a, 0x1d, 0x8c, 0xa2, 0x66, 0xec, 0xc8, 0x75, 0x0a, 0xa8, 0x55, 0xea, 0x60, 0x14, 0xb5, 0x31,
-	0x86, 0x81, 0x70, 0xa4, 0xb5, 0x3d, 0xea, 0xe4, 0x6d, 0x8c, 0x22, 0xc0, 0x43, 0x58, 0x92, 0xb6,
-	0xb4, 0x9e, 0x47, 0xdd, 0x82, 0x91, 0x51, 0xd4, 0xc3, 0x16, 0xac, 0x68, 0x63, 0xa6, 0x92, 0x46,
-	0xfd, 0x72, 0x0f, 0xa3, 0x68, 0x80, 0xd7, 0x00, 0xab, 0x2c, 0x9a, 0x45, 0x2f, 0x5a, 0x2a, 0xb3,
-	0x33, 0x8a, 0x10, 0xbe, 0x0d, 0x37, 0x85, 0xbd, 0xa4, 0x52, 0x46, 0xcb, 0x73, 0x9d, 0x8c, 0x22,
-	0x1c, 0xaf, 0x21, 0x5f, 0xd6, 0xa2, 0x61, 0xbc, 0x19, 0xe3, 0x6a, 0x47, 0x2b, 0x78, 0x1d, 0xd6,
-	0xd2, 0x70, 0xb3, 0xe6, 0x44, 0xab, 0xf3, 0x7c, 0x8c, 0xa2, 0xb5, 0xd8, 0x57, 0xac, 0x55, 0xd1,
-	0xcd, 0x79, 0x3e, 0x46, 0x91, 0x95, 0x9c, 0x88, 0xb2, 0xe2, 0x14, 0xdd, 0x5a, 0xe0, 0x66, 0x14,
-	0xad, 0xc7, 0x3b, 0x2f, 0xa9, 0x39, 0xd1, 0xed, 0xb9, 0x4e, 0x46, 0xd1, 0x9d, 0x78, 0x4d, 0xc5,
-	0x7a, 0x12, 0x7d, 0x36, 0xcf, 0xc7, 0x28, 0xda, 0xc0, 0x2b, 0x80, 0xd2, 0x1c, 0xa8, 0xf2, 0x0b,
-	0x6d, 0x16, 0xad, 0x8c, 0xa2, 0xad, 0xd8, 0x6a, 0x16, 0x7c, 0xe8, 0xf3, 0xa2, 0x95, 0x51, 0x64,
-	0xe3, 0x55, 0x58, 0x96, 0x2f, 0xc3, 0xac, 0xeb, 0xd0, 0xdd, 0x12, 0x33, 0xa3, 0xe8, 0xde, 0x83,
-	0x6f, 0xa1, 0x67, 0x8a, 0x11, 0xee, 0x40, 0xe3, 0x4d, 0xc8, 0xe5, 0xd7, 0x0b, 0xd0, 0x54, 0x77,
-	0x16, 0xaa, 0xe0, 0x1e, 0xb4, 0xbf, 0x0b, 0x7d, 0x3f, 0xfc, 0x40, 0x22, 0x54, 0xc5, 0x5d, 0x68,
-	0xbd, 0x20, 0x6e, 0x24, 0x3e, 0xf2, 0xda, 0x83, 0x7d, 0x58, 0x2e, 0x88, 0x37, 0x6e, 0x42, 0xf5,
-	0x38, 0x40, 0x37, 0x04, 0xdd, 0xcb, 0x90, 0x1f, 0x07, 0xa8, 0x22, 0xe8, 0x8e, 0x3e, 0x7a, 0x8c,
-	0x33, 0x54, 0xc5, 0x7d, 0xe8, 0xbc, 0x0c, 0xb9, 0x1e, 0xd6, 0x0e, 0xd0, 0x4f, 0xff, 0xdd, 0xb8,
-	0xf1, 0xe3, 0xe5, 0x46, 0xe5, 0xa7, 0xcb, 0x8d, 0xca, 0x7f, 0x2e, 0x37, 0x2a, 0xa3, 0xa6, 0xfc,
-	0xc7, 0xf0, 0xa3, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0x54, 0x9b, 0xd7, 0xd0, 0xab, 0x1e, 0x00,
-	0x00,
+func (m *AllocTimestampReq) Reset()         { *m = AllocTimestampReq{} }
+func (m *AllocTimestampReq) String() string { return proto.CompactTextString(m) }
+func (*AllocTimestampReq) ProtoMessage()    {}
+func (*AllocTimestampReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{51}
+}
+func (m *AllocTimestampReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AllocTimestampReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AllocTimestampReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AllocTimestampReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocTimestampReq.Merge(m, src)
+}
+func (m *AllocTimestampReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *AllocTimestampReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocTimestampReq.DiscardUnknown(m)
 }
 
-func (m *Request) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AllocTimestampReq proto.InternalMessageInfo
+
+func (m *AllocTimestampReq) GetCount() uint32 {
+	if m != nil {
+		return m.Count
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Request) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
+// AllocTimestampRsp alloc timestamp response
+type AllocTimestampRsp struct {
+	// Timestamp the max timestamp of the allocated timestamps, the allocated timestamps
+	// are (timestamp-count, timestamp]
+	Timestamp            uint64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllocTimestampRsp) Reset()         { *m = AllocTimestampRsp{} }
+func (m *AllocTimestampRsp) String() string { return proto.CompactTextString(m) }
+func (*AllocTimestampRsp) ProtoMessage()    {}
+func (*AllocTimestampRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{52}
+}
+func (m *AllocTimestampRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AllocTimestampRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AllocTimestampRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.ContainerID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
+}
+func (m *AllocTimestampRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocTimestampRsp.Merge(m, src)
+}
+func (m *AllocTimestampRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *AllocTimestampRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocTimestampRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocTimestampRsp proto.InternalMessageInfo
+
+func (m *AllocTimestampRsp) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
+	return 0
+}
+
+// PutRateLimitReq put rate limit request
+type PutRateLimitReq struct {
+	Limit                metapb.RateLimit `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PutRateLimitReq) Reset()         { *m = PutRateLimitReq{} }
+func (m *PutRateLimitReq) String() string { return proto.CompactTextString(m) }
+func (*PutRateLimitReq) ProtoMessage()    {}
+func (*PutRateLimitReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{53}
+}
+func (m *PutRateLimitReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PutRateLimitReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PutRateLimitReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceHeartbeat.Size()))
-	n1, err := m.ResourceHeartbeat.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *PutRateLimitReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutRateLimitReq.Merge(m, src)
+}
+func (m *PutRateLimitReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *PutRateLimitReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutRateLimitReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutRateLimitReq proto.InternalMessageInfo
+
+func (m *PutRateLimitReq) GetLimit() metapb.RateLimit {
+	if m != nil {
+		return m.Limit
 	}
-	i += n1
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerHeartbeat.Size()))
-	n2, err := m.ContainerHeartbeat.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return metapb.RateLimit{}
+}
+
+// GetRateLimitsRsp get rate limits response
+type GetRateLimitsRsp struct {
+	Limits               []metapb.RateLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetRateLimitsRsp) Reset()         { *m = GetRateLimitsRsp{} }
+func (m *GetRateLimitsRsp) String() string { return proto.CompactTextString(m) }
+func (*GetRateLimitsRsp) ProtoMessage()    {}
+func (*GetRateLimitsRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{54}
+}
+func (m *GetRateLimitsRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRateLimitsRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRateLimitsRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n2
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutContainer.Size()))
-	n3, err := m.PutContainer.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *GetRateLimitsRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRateLimitsRsp.Merge(m, src)
+}
+func (m *GetRateLimitsRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRateLimitsRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRateLimitsRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRateLimitsRsp proto.InternalMessageInfo
+
+func (m *GetRateLimitsRsp) GetLimits() []metapb.RateLimit {
+	if m != nil {
+		return m.Limits
 	}
-	i += n3
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetContainer.Size()))
-	n4, err := m.GetContainer.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// SetWriteFreezeReq set write freeze request
+type SetWriteFreezeReq struct {
+	Group                uint64   `protobuf:"varint,1,opt,name=group,proto3" json:"group,omitempty"`
+	Frozen               bool     `protobuf:"varint,2,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWriteFreezeReq) Reset()         { *m = SetWriteFreezeReq{} }
+func (m *SetWriteFreezeReq) String() string { return proto.CompactTextString(m) }
+func (*SetWriteFreezeReq) ProtoMessage()    {}
+func (*SetWriteFreezeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{55}
+}
+func (m *SetWriteFreezeReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetWriteFreezeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetWriteFreezeReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n4
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
-	n5, err := m.AllocID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *SetWriteFreezeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWriteFreezeReq.Merge(m, src)
+}
+func (m *SetWriteFreezeReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetWriteFreezeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWriteFreezeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWriteFreezeReq proto.InternalMessageInfo
+
+func (m *SetWriteFreezeReq) GetGroup() uint64 {
+	if m != nil {
+		return m.Group
 	}
-	i += n5
-	dAtA[i] = 0x4a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskSplit.Size()))
-	n6, err := m.AskSplit.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return 0
+}
+
+func (m *SetWriteFreezeReq) GetFrozen() bool {
+	if m != nil {
+		return m.Frozen
 	}
-	i += n6
-	dAtA[i] = 0x52
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
-	n7, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return false
+}
+
+// GetWriteFrozenGroupsRsp get write frozen groups response
+type GetWriteFrozenGroupsRsp struct {
+	Groups               []uint64 `protobuf:"varint,1,rep,packed,name=groups,proto3" json:"groups,omitempty"`
+	PendingContainers    []uint64 `protobuf:"varint,2,rep,packed,name=pendingContainers,proto3" json:"pendingContainers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWriteFrozenGroupsRsp) Reset()         { *m = GetWriteFrozenGroupsRsp{} }
+func (m *GetWriteFrozenGroupsRsp) String() string { return proto.CompactTextString(m) }
+func (*GetWriteFrozenGroupsRsp) ProtoMessage()    {}
+func (*GetWriteFrozenGroupsRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{56}
+}
+func (m *GetWriteFrozenGroupsRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetWriteFrozenGroupsRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetWriteFrozenGroupsRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n7
-	dAtA[i] = 0x5a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportSplit.Size()))
-	n8, err := m.ReportSplit.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *GetWriteFrozenGroupsRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWriteFrozenGroupsRsp.Merge(m, src)
+}
+func (m *GetWriteFrozenGroupsRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetWriteFrozenGroupsRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWriteFrozenGroupsRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWriteFrozenGroupsRsp proto.InternalMessageInfo
+
+func (m *GetWriteFrozenGroupsRsp) GetGroups() []uint64 {
+	if m != nil {
+		return m.Groups
 	}
-	i += n8
-	dAtA[i] = 0x62
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.BatchReportSplit.Size()))
-	n9, err := m.BatchReportSplit.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+func (m *GetWriteFrozenGroupsRsp) GetPendingContainers() []uint64 {
+	if m != nil {
+		return m.PendingContainers
 	}
-	i += n9
-	dAtA[i] = 0x6a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateWatcher.Size()))
-	n10, err := m.CreateWatcher.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// DecommissionContainerReq decommission container request
+type DecommissionContainerReq struct {
+	ContainerID          uint64   `protobuf:"varint,1,opt,name=containerID,proto3" json:"containerID,omitempty"`
+	Rate                 uint64   `protobuf:"varint,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecommissionContainerReq) Reset()         { *m = DecommissionContainerReq{} }
+func (m *DecommissionContainerReq) String() string { return proto.CompactTextString(m) }
+func (*DecommissionContainerReq) ProtoMessage()    {}
+func (*DecommissionContainerReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{57}
+}
+func (m *DecommissionContainerReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DecommissionContainerReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DecommissionContainerReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n10
-	dAtA[i] = 0x72
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateResources.Size()))
-	n11, err := m.CreateResources.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *DecommissionContainerReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecommissionContainerReq.Merge(m, src)
+}
+func (m *DecommissionContainerReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *DecommissionContainerReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecommissionContainerReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecommissionContainerReq proto.InternalMessageInfo
+
+func (m *DecommissionContainerReq) GetContainerID() uint64 {
+	if m != nil {
+		return m.ContainerID
 	}
-	i += n11
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveResources.Size()))
-	n12, err := m.RemoveResources.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n12
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckResourceState.Size()))
-	n13, err := m.CheckResourceState.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return 0
+}
+
+func (m *DecommissionContainerReq) GetRate() uint64 {
+	if m != nil {
+		return m.Rate
 	}
-	i += n13
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
-	n14, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return 0
+}
+
+// GetDecommissionProgressReq get decommission progress request
+type GetDecommissionProgressReq struct {
+	ContainerID          uint64   `protobuf:"varint,1,opt,name=containerID,proto3" json:"containerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDecommissionProgressReq) Reset()         { *m = GetDecommissionProgressReq{} }
+func (m *GetDecommissionProgressReq) String() string { return proto.CompactTextString(m) }
+func (*GetDecommissionProgressReq) ProtoMessage()    {}
+func (*GetDecommissionProgressReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{58}
+}
+func (m *GetDecommissionProgressReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetDecommissionProgressReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetDecommissionProgressReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n14
-	dAtA[i] = 0x92
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
-	n15, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *GetDecommissionProgressReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDecommissionProgressReq.Merge(m, src)
+}
+func (m *GetDecommissionProgressReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetDecommissionProgressReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDecommissionProgressReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDecommissionProgressReq proto.InternalMessageInfo
+
+func (m *GetDecommissionProgressReq) GetContainerID() uint64 {
+	if m != nil {
+		return m.ContainerID
 	}
-	i += n15
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
-	n16, err := m.CreateJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return 0
+}
+
+// GetDecommissionProgressRsp get decommission progress response
+type GetDecommissionProgressRsp struct {
+	State                metapb.ContainerState `protobuf:"varint,1,opt,name=state,proto3,enum=metapb.ContainerState" json:"state,omitempty"`
+	ResourceCount        uint64                `protobuf:"varint,2,opt,name=resourceCount,proto3" json:"resourceCount,omitempty"`
+	LeaderCount          uint64                `protobuf:"varint,3,opt,name=leaderCount,proto3" json:"leaderCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetDecommissionProgressRsp) Reset()         { *m = GetDecommissionProgressRsp{} }
+func (m *GetDecommissionProgressRsp) String() string { return proto.CompactTextString(m) }
+func (*GetDecommissionProgressRsp) ProtoMessage()    {}
+func (*GetDecommissionProgressRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{59}
+}
+func (m *GetDecommissionProgressRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetDecommissionProgressRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetDecommissionProgressRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n16
-	dAtA[i] = 0xa2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
-	n17, err := m.RemoveJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *GetDecommissionProgressRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDecommissionProgressRsp.Merge(m, src)
+}
+func (m *GetDecommissionProgressRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetDecommissionProgressRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDecommissionProgressRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDecommissionProgressRsp proto.InternalMessageInfo
+
+func (m *GetDecommissionProgressRsp) GetState() metapb.ContainerState {
+	if m != nil {
+		return m.State
 	}
-	i += n17
-	dAtA[i] = 0xaa
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
-	n18, err := m.ExecuteJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return metapb.ContainerState_UP
+}
+
+func (m *GetDecommissionProgressRsp) GetResourceCount() uint64 {
+	if m != nil {
+		return m.ResourceCount
 	}
-	i += n18
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return 0
+}
+
+func (m *GetDecommissionProgressRsp) GetLeaderCount() uint64 {
+	if m != nil {
+		return m.LeaderCount
 	}
-	return i, nil
+	return 0
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func init() {
+	proto.RegisterEnum("rpcpb.Type", Type_name, Type_value)
+	proto.RegisterEnum("rpcpb.PeerRoleType", PeerRoleType_name, PeerRoleType_value)
+	proto.RegisterEnum("rpcpb.LabelConstraintOp", LabelConstraintOp_name, LabelConstraintOp_value)
+	proto.RegisterType((*Request)(nil), "rpcpb.Request")
+	proto.RegisterType((*Response)(nil), "rpcpb.Response")
+	proto.RegisterType((*ResourceHeartbeatReq)(nil), "rpcpb.ResourceHeartbeatReq")
+	proto.RegisterType((*ResourceHeartbeatRsp)(nil), "rpcpb.ResourceHeartbeatRsp")
+	proto.RegisterType((*PutContainerReq)(nil), "rpcpb.PutContainerReq")
+	proto.RegisterType((*PutContainerRsp)(nil), "rpcpb.PutContainerRsp")
+	proto.RegisterType((*ContainerHeartbeatReq)(nil), "rpcpb.ContainerHeartbeatReq")
+	proto.RegisterType((*ContainerHeartbeatRsp)(nil), "rpcpb.ContainerHeartbeatRsp")
+	proto.RegisterType((*GetContainerReq)(nil), "rpcpb.GetContainerReq")
+	proto.RegisterType((*GetContainerRsp)(nil), "rpcpb.GetContainerRsp")
+	proto.RegisterType((*AllocIDReq)(nil), "rpcpb.AllocIDReq")
+	proto.RegisterType((*AllocIDRsp)(nil), "rpcpb.AllocIDRsp")
+	proto.RegisterType((*AskSplitReq)(nil), "rpcpb.AskSplitReq")
+	proto.RegisterType((*AskSplitRsp)(nil), "rpcpb.AskSplitRsp")
+	proto.RegisterType((*ReportSplitReq)(nil), "rpcpb.ReportSplitReq")
+	proto.RegisterType((*ReportSplitRsp)(nil), "rpcpb.ReportSplitRsp")
+	proto.RegisterType((*AskBatchSplitReq)(nil), "rpcpb.AskBatchSplitReq")
+	proto.RegisterType((*AskBatchSplitRsp)(nil), "rpcpb.AskBatchSplitRsp")
+	proto.RegisterType((*BatchReportSplitReq)(nil), "rpcpb.BatchReportSplitReq")
+	proto.RegisterType((*BatchReportSplitRsp)(nil), "rpcpb.BatchReportSplitRsp")
+	proto.RegisterType((*SplitID)(nil), "rpcpb.SplitID")
+	proto.RegisterType((*CreateWatcherReq)(nil), "rpcpb.CreateWatcherReq")
+	proto.RegisterType((*CreateResourcesReq)(nil), "rpcpb.CreateResourcesReq")
+	proto.RegisterType((*CreateResourcesRsp)(nil), "rpcpb.CreateResourcesRsp")
+	proto.RegisterType((*RemoveResourcesReq)(nil), "rpcpb.RemoveResourcesReq")
+	proto.RegisterType((*RemoveResourcesRsp)(nil), "rpcpb.RemoveResourcesRsp")
+	proto.RegisterType((*CheckResourceStateReq)(nil), "rpcpb.CheckResourceStateReq")
+	proto.RegisterType((*CheckResourceStateRsp)(nil), "rpcpb.CheckResourceStateRsp")
+	proto.RegisterType((*PutPlacementRuleReq)(nil), "rpcpb.PutPlacementRuleReq")
+	proto.RegisterType((*PutPlacementRuleRsp)(nil), "rpcpb.PutPlacementRuleRsp")
+	proto.RegisterType((*GetAppliedRulesReq)(nil), "rpcpb.GetAppliedRulesReq")
+	proto.RegisterType((*GetAppliedRulesRsp)(nil), "rpcpb.GetAppliedRulesRsp")
+	proto.RegisterType((*CreateJobReq)(nil), "rpcpb.CreateJobReq")
+	proto.RegisterType((*CreateJobRsp)(nil), "rpcpb.CreateJobRsp")
+	proto.RegisterType((*RemoveJobReq)(nil), "rpcpb.RemoveJobReq")
+	proto.RegisterType((*RemoveJobRsp)(nil), "rpcpb.RemoveJobRsp")
+	proto.RegisterType((*ExecuteJobReq)(nil), "rpcpb.ExecuteJobReq")
+	proto.RegisterType((*ExecuteJobRsp)(nil), "rpcpb.ExecuteJobRsp")
+	proto.RegisterType((*TransferLeaderReq)(nil), "rpcpb.TransferLeaderReq")
+	proto.RegisterType((*TransferLeaderRsp)(nil), "rpcpb.TransferLeaderRsp")
+	proto.RegisterType((*EventNotify)(nil), "rpcpb.EventNotify")
+	proto.RegisterType((*InitEventData)(nil), "rpcpb.InitEventData")
+	proto.RegisterType((*ResourceEventData)(nil), "rpcpb.ResourceEventData")
+	proto.RegisterType((*ContainerEventData)(nil), "rpcpb.ContainerEventData")
+	proto.RegisterType((*ChangePeer)(nil), "rpcpb.ChangePeer")
+	proto.RegisterType((*TransferLeader)(nil), "rpcpb.TransferLeader")
+	proto.RegisterType((*ChangePeerV2)(nil), "rpcpb.ChangePeerV2")
+	proto.RegisterType((*Merge)(nil), "rpcpb.Merge")
+	proto.RegisterType((*SplitResource)(nil), "rpcpb.SplitResource")
+	proto.RegisterType((*LabelConstraint)(nil), "rpcpb.LabelConstraint")
+	proto.RegisterType((*PlacementRule)(nil), "rpcpb.PlacementRule")
+	proto.RegisterType((*AllocTimestampReq)(nil), "rpcpb.AllocTimestampReq")
+	proto.RegisterType((*AllocTimestampRsp)(nil), "rpcpb.AllocTimestampRsp")
+	proto.RegisterType((*PutRateLimitReq)(nil), "rpcpb.PutRateLimitReq")
+	proto.RegisterType((*GetRateLimitsRsp)(nil), "rpcpb.GetRateLimitsRsp")
+	proto.RegisterType((*SetWriteFreezeReq)(nil), "rpcpb.SetWriteFreezeReq")
+	proto.RegisterType((*GetWriteFrozenGroupsRsp)(nil), "rpcpb.GetWriteFrozenGroupsRsp")
+	proto.RegisterType((*DecommissionContainerReq)(nil), "rpcpb.DecommissionContainerReq")
+	proto.RegisterType((*GetDecommissionProgressReq)(nil), "rpcpb.GetDecommissionProgressReq")
+	proto.RegisterType((*GetDecommissionProgressRsp)(nil), "rpcpb.GetDecommissionProgressRsp")
+}
+
+func init() { proto.RegisterFile("rpcpb.proto", fileDescriptor_25e491924c678914) }
+
+var fileDescriptor_25e491924c678914 = []byte{
+	// 2940 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x5a, 0xcb, 0x72, 0xdc, 0xc6,
+	0xd5, 0xd6, 0xdc, 0x67, 0xce, 0x5c, 0xd8, 0xd3, 0xbc, 0x81, 0x94, 0x4c, 0xd2, 0xb0, 0x7e, 0x99,
+	0x92, 0x65, 0xd2, 0xa4, 0xfc, 0xdb, 0x29, 0x55, 0xe4, 0x98, 0x22, 0x75, 0xa1, 0x23, 0xdb, 0x2c,
+	0xc8, 0x97, 0x45, 0x16, 0x29, 0x70, 0xa6, 0x35, 0x44, 0x34, 0x04, 0x9a, 0xe8, 0xa6, 0x24, 0x7a,
+	0xe5, 0x07, 0x48, 0x36, 0x59, 0xe6, 0x0d, 0xb2, 0xcb, 0x2a, 0xcf, 0xe0, 0xa5, 0x1f, 0x20, 0xe5,
+	0x4a, 0xf4, 0x24, 0xa9, 0xbe, 0x00, 0x68, 0xdc, 0x86, 0x74, 0x65, 0xa5, 0xe9, 0x73, 0xce, 0xf7,
+	0xa1, 0xd1, 0xe8, 0xee, 0xef, 0x9c, 0x43, 0x41, 0x37, 0xa4, 0x23, 0x7a, 0xbc, 0x45, 0xc3, 0x80,
+	0x07, 0xb8, 0x21, 0x07, 0xab, 0xcf, 0x26, 0x1e, 0x3f, 0x39, 0x3f, 0xde, 0x1a, 0x05, 0xa7, 0xdb,
+	0xa7, 0x2e, 0x0f, 0xbd, 0x37, 0x41, 0xe8, 0x4d, 0x3c, 0x5f, 0x0f, 0x46, 0xe7, 0xc7, 0x64, 0x7b,
+	0x14, 0x9c, 0xd2, 0xc0, 0x27, 0x3e, 0x67, 0xdb, 0x34, 0x0c, 0xe8, 0x09, 0xe1, 0xdb, 0xf4, 0x78,
+	0xfb, 0x94, 0x70, 0x37, 0xfe, 0x47, 0x91, 0xae, 0x7e, 0x68, 0xb0, 0x4d, 0x82, 0x49, 0xb0, 0x2d,
+	0xcd, 0xc7, 0xe7, 0x2f, 0xe4, 0x48, 0x0e, 0xe4, 0x2f, 0x15, 0x6e, 0xff, 0x38, 0x80, 0x96, 0x43,
+	0xce, 0xce, 0x09, 0xe3, 0x78, 0x09, 0xaa, 0xde, 0xd8, 0xaa, 0x6c, 0x54, 0x36, 0xeb, 0x0f, 0x9b,
+	0x6f, 0x7f, 0x59, 0xaf, 0x1e, 0x1e, 0x38, 0x55, 0x6f, 0x8c, 0x37, 0xa0, 0x3b, 0x0a, 0x7c, 0xee,
+	0x7a, 0x3e, 0x09, 0x0f, 0x0f, 0xac, 0xaa, 0x08, 0x70, 0x4c, 0x13, 0x5e, 0x87, 0x3a, 0xbf, 0xa0,
+	0xc4, 0xaa, 0x6d, 0x54, 0x36, 0x07, 0xbb, 0xdd, 0x2d, 0xf5, 0x96, 0xdf, 0x5c, 0x50, 0xe2, 0x48,
+	0x07, 0xfe, 0x1a, 0x86, 0x21, 0x61, 0xc1, 0x79, 0x38, 0x22, 0x4f, 0x89, 0x1b, 0xf2, 0x63, 0xe2,
+	0x72, 0xab, 0xbe, 0x51, 0xd9, 0xec, 0xee, 0x5e, 0xd7, 0xd1, 0x4e, 0xd6, 0xef, 0x90, 0xb3, 0x87,
+	0xf5, 0x9f, 0x7e, 0x59, 0xbf, 0xe6, 0xe4, 0xb1, 0xd8, 0x01, 0x1c, 0x4f, 0x20, 0x61, 0x6c, 0x48,
+	0xc6, 0x1b, 0x9a, 0x71, 0x3f, 0x17, 0x90, 0x50, 0x16, 0xa0, 0xf1, 0xe7, 0xd0, 0xa3, 0xe7, 0x3c,
+	0x46, 0x59, 0x4d, 0xc9, 0xb6, 0xa4, 0xd9, 0x8e, 0x0c, 0x57, 0xc2, 0x93, 0x42, 0x08, 0x86, 0x09,
+	0x31, 0x18, 0x5a, 0x29, 0x86, 0x27, 0xa4, 0x90, 0xc1, 0x44, 0xe0, 0x1d, 0x68, 0xb9, 0xd3, 0x69,
+	0x30, 0x3a, 0x3c, 0xb0, 0xda, 0x12, 0x3c, 0xd4, 0xe0, 0x3d, 0x65, 0x4d, 0x70, 0x51, 0x1c, 0xfe,
+	0x18, 0xda, 0x2e, 0x7b, 0xf9, 0x9c, 0x4e, 0x3d, 0x6e, 0x75, 0x24, 0x06, 0x47, 0x18, 0x6d, 0x4e,
+	0x40, 0x71, 0x24, 0xde, 0x87, 0xbe, 0xcb, 0x5e, 0x3e, 0x74, 0xf9, 0xe8, 0x44, 0x41, 0x41, 0x42,
+	0x97, 0x13, 0x68, 0xe2, 0x4b, 0xf0, 0x69, 0x0c, 0x7e, 0x00, 0xdd, 0x90, 0xd0, 0x20, 0xe4, 0x8a,
+	0xa2, 0x2b, 0x29, 0x16, 0xe3, 0x0f, 0x1a, 0x7b, 0x12, 0x02, 0x33, 0x1e, 0x3f, 0x03, 0x74, 0x2c,
+	0xc8, 0x8c, 0x48, 0xab, 0x27, 0x39, 0x56, 0x35, 0xc7, 0xc3, 0x8c, 0x3b, 0x21, 0xca, 0x21, 0xc5,
+	0x1b, 0x8d, 0x42, 0xe2, 0x72, 0xf2, 0xbd, 0xf0, 0x90, 0xd0, 0xea, 0xa7, 0xde, 0x68, 0xdf, 0xf4,
+	0x19, 0x6f, 0x94, 0xc2, 0xe0, 0x43, 0x98, 0x53, 0x86, 0x68, 0x3b, 0x32, 0x6b, 0x20, 0x69, 0x56,
+	0x52, 0x34, 0xb1, 0x37, 0x21, 0xca, 0xe2, 0x04, 0x55, 0x48, 0x4e, 0x83, 0x57, 0x06, 0xd5, 0x5c,
+	0x8a, 0xca, 0x49, 0x7b, 0x0d, 0xaa, 0x0c, 0x4e, 0xee, 0xf6, 0x13, 0x32, 0x7a, 0x19, 0x59, 0x9e,
+	0x73, 0x97, 0x13, 0x0b, 0xa5, 0x77, 0x7b, 0x2e, 0xc0, 0xdc, 0xed, 0x39, 0xa7, 0x58, 0x7c, 0x7a,
+	0xce, 0x8f, 0xa6, 0xee, 0x88, 0x9c, 0x12, 0x9f, 0x3b, 0xe7, 0x53, 0x62, 0x0d, 0x53, 0x8b, 0x7f,
+	0x94, 0x71, 0x1b, 0x8b, 0x9f, 0x45, 0x8a, 0x97, 0x9d, 0x10, 0xbe, 0x47, 0xe9, 0xd4, 0x23, 0x63,
+	0x61, 0x61, 0x16, 0x4e, 0xbd, 0xec, 0x93, 0xb4, 0xd7, 0x78, 0xd9, 0x0c, 0x0e, 0x7f, 0x0a, 0x1d,
+	0xb5, 0x94, 0x5f, 0x04, 0xc7, 0xd6, 0xbc, 0x24, 0x99, 0x4f, 0x2d, 0xfe, 0x17, 0xc1, 0x71, 0x02,
+	0x4f, 0x62, 0x05, 0x50, 0x2d, 0x9c, 0x00, 0x2e, 0xa4, 0x80, 0x4e, 0x64, 0x37, 0x80, 0x71, 0x2c,
+	0xbe, 0x0f, 0x40, 0xde, 0x90, 0xd1, 0xb9, 0x7a, 0xe4, 0xa2, 0x44, 0x2e, 0x68, 0xe4, 0xa3, 0xd8,
+	0x91, 0x40, 0x8d, 0x68, 0xfc, 0x18, 0x06, 0x3c, 0x74, 0x7d, 0xf6, 0x82, 0x84, 0xcf, 0x88, 0x3b,
+	0x26, 0xa1, 0xb5, 0x24, 0xf1, 0x56, 0x74, 0x09, 0xa6, 0x9c, 0x09, 0x47, 0x06, 0x25, 0x78, 0xe4,
+	0x81, 0xfe, 0xc6, 0x3b, 0x25, 0x8c, 0xbb, 0xa7, 0xd4, 0x5a, 0x4e, 0xf1, 0xec, 0xa5, 0x9c, 0x06,
+	0x4f, 0x1a, 0x85, 0xef, 0xcb, 0x4b, 0xcc, 0x71, 0x39, 0x79, 0xe6, 0x9d, 0x7a, 0xdc, 0xb2, 0xb2,
+	0x97, 0x58, 0xec, 0x72, 0xc8, 0x99, 0x93, 0x8a, 0xc5, 0x9f, 0xc3, 0x80, 0x11, 0xfe, 0x7d, 0xe8,
+	0x71, 0xf2, 0x38, 0x24, 0xe4, 0x07, 0x62, 0xad, 0xa4, 0xe6, 0xf0, 0x3c, 0xe5, 0x14, 0xf8, 0x4c,
+	0x3c, 0xfe, 0x16, 0x16, 0xc7, 0x64, 0x14, 0x9c, 0x9e, 0x7a, 0x8c, 0x79, 0x81, 0x9f, 0xdc, 0x84,
+	0xab, 0x92, 0x68, 0x5d, 0x13, 0x1d, 0x14, 0xc5, 0x08, 0xbe, 0x62, 0x34, 0xfe, 0x03, 0x2c, 0x4f,
+	0x08, 0x37, 0x51, 0x47, 0x61, 0x30, 0x09, 0x09, 0x63, 0xd6, 0x75, 0x49, 0xfc, 0x6e, 0xb2, 0xcb,
+	0x8a, 0xa2, 0x04, 0x75, 0x19, 0x83, 0xfd, 0xcf, 0x3e, 0xb4, 0x1d, 0xc2, 0x68, 0xe0, 0x33, 0x52,
+	0xaa, 0x81, 0x91, 0xc2, 0x55, 0xcb, 0x14, 0x6e, 0x01, 0x1a, 0x24, 0x0c, 0x83, 0x50, 0x6a, 0x60,
+	0xc7, 0x51, 0x03, 0xbc, 0x04, 0xcd, 0xa9, 0xda, 0x15, 0x75, 0x69, 0xd6, 0xa3, 0x62, 0x3d, 0x6c,
+	0x5c, 0xa2, 0x87, 0x8c, 0xfe, 0x5a, 0x3d, 0x6c, 0x5e, 0xa6, 0x87, 0x31, 0xe5, 0x55, 0xf4, 0xb0,
+	0x55, 0xae, 0x87, 0x31, 0xcf, 0x6c, 0x3d, 0x6c, 0x97, 0xeb, 0x61, 0xc2, 0x50, 0xa6, 0x87, 0x9d,
+	0x42, 0x3d, 0x8c, 0x71, 0x85, 0x7a, 0x08, 0xc5, 0x7a, 0x18, 0x83, 0x66, 0xe8, 0x61, 0x77, 0x86,
+	0x1e, 0xc6, 0xf8, 0xd9, 0x7a, 0xd8, 0x2b, 0xd5, 0xc3, 0x98, 0xe0, 0x52, 0x3d, 0xec, 0xcf, 0xd6,
+	0xc3, 0x98, 0x28, 0x87, 0xc4, 0x5b, 0xd0, 0x20, 0xaf, 0x88, 0xcf, 0xad, 0x41, 0x6a, 0x11, 0x1e,
+	0x09, 0xdb, 0x57, 0x01, 0xf7, 0x5e, 0x5c, 0x68, 0xa8, 0x0a, 0x2b, 0x92, 0xbe, 0xb9, 0x99, 0xd2,
+	0x17, 0x3f, 0xfb, 0x2a, 0xd2, 0x87, 0x66, 0x4a, 0x5f, 0x42, 0x75, 0x35, 0xe9, 0x1b, 0x5e, 0x26,
+	0x7d, 0xc6, 0xc6, 0xbe, 0x9a, 0xf4, 0xe1, 0xd9, 0xd2, 0x97, 0xac, 0xf3, 0x55, 0xa4, 0x6f, 0x7e,
+	0xa6, 0xf4, 0x25, 0x2f, 0x3b, 0x53, 0xfa, 0x16, 0x4a, 0xa4, 0x2f, 0x86, 0x97, 0x49, 0xdf, 0x62,
+	0x89, 0xf4, 0x25, 0xc0, 0x32, 0xe9, 0x5b, 0x2a, 0x93, 0xbe, 0x18, 0x3a, 0x5b, 0xfa, 0x96, 0x67,
+	0x49, 0x5f, 0xcc, 0x71, 0xb9, 0xf4, 0x59, 0xb3, 0xa4, 0x2f, 0xe1, 0x49, 0xa3, 0xf0, 0x03, 0xe8,
+	0x4f, 0x48, 0x22, 0x67, 0xcc, 0x5a, 0x49, 0x1d, 0xe1, 0x27, 0xa6, 0xcf, 0x61, 0xd4, 0x49, 0x47,
+	0x63, 0x07, 0x16, 0x26, 0xb1, 0x9a, 0x05, 0x3f, 0x10, 0xff, 0x49, 0x18, 0x9c, 0x53, 0xa6, 0xa5,
+	0x6b, 0x2d, 0x61, 0xc9, 0x85, 0x08, 0xb2, 0x42, 0xec, 0xff, 0x2e, 0x5c, 0x8c, 0x96, 0x0b, 0xd7,
+	0x3f, 0xaa, 0xb0, 0x50, 0x54, 0x35, 0x65, 0x0b, 0xb6, 0x4a, 0xbe, 0x60, 0x5b, 0x85, 0x76, 0xa4,
+	0x21, 0x52, 0xd2, 0x7a, 0x4e, 0x3c, 0xc6, 0x18, 0xea, 0x9c, 0x84, 0xa7, 0x52, 0xc8, 0xea, 0x8e,
+	0xfc, 0x8d, 0x6f, 0xa6, 0x74, 0xac, 0xbb, 0xdb, 0xdb, 0xd2, 0x45, 0xe7, 0x11, 0x21, 0x61, 0xac,
+	0x6a, 0xff, 0x0f, 0x9d, 0x71, 0xf0, 0xda, 0x17, 0x36, 0x66, 0x35, 0x36, 0x6a, 0xf2, 0xba, 0x36,
+	0x02, 0xc5, 0xe9, 0x63, 0xd1, 0x1e, 0x8c, 0x23, 0xf1, 0x27, 0xd0, 0xa3, 0xc4, 0x1f, 0x7b, 0xfe,
+	0x44, 0x21, 0x9b, 0x1b, 0xb5, 0xec, 0x23, 0x62, 0x75, 0x31, 0xe2, 0xf0, 0x0e, 0x34, 0x98, 0x60,
+	0xd4, 0xc2, 0xb4, 0x18, 0x01, 0xcc, 0xc3, 0x1e, 0x3d, 0x4e, 0x45, 0xda, 0xff, 0xaa, 0x15, 0x2d,
+	0x19, 0xa3, 0x78, 0x0d, 0x20, 0x5a, 0x80, 0x78, 0xc5, 0x0c, 0x0b, 0xde, 0x83, 0x7e, 0x34, 0x7a,
+	0x44, 0x83, 0xd1, 0x89, 0x55, 0x2d, 0x7e, 0xa6, 0x74, 0x46, 0xe2, 0x90, 0x42, 0xe0, 0xbb, 0x00,
+	0xdc, 0x0d, 0x27, 0x84, 0x8b, 0xd9, 0xcb, 0xd5, 0xcd, 0xae, 0xa3, 0xe1, 0xc7, 0x3b, 0x00, 0xa3,
+	0x13, 0xd7, 0x9f, 0x90, 0x23, 0x12, 0xaf, 0xfa, 0x30, 0xbe, 0xef, 0x22, 0x87, 0x63, 0x04, 0xe1,
+	0x07, 0xb9, 0xf3, 0xd8, 0x48, 0x09, 0x50, 0xe6, 0x3c, 0x66, 0x8f, 0xa1, 0x0d, 0x8d, 0x53, 0x12,
+	0x4e, 0x88, 0xce, 0x1a, 0x7a, 0x1a, 0xf5, 0xa5, 0xb0, 0x39, 0xca, 0x85, 0xef, 0x43, 0x9f, 0xa9,
+	0x3a, 0x4c, 0x6f, 0x9e, 0x56, 0xea, 0xc6, 0x78, 0x6e, 0xfa, 0x9c, 0x74, 0x28, 0xfe, 0x14, 0x7a,
+	0xc9, 0x64, 0xbf, 0xdb, 0xb5, 0xda, 0xa9, 0x6b, 0x6a, 0xdf, 0x70, 0x39, 0xa9, 0x40, 0xbc, 0x09,
+	0x73, 0x63, 0xc2, 0x78, 0x10, 0x5e, 0x1c, 0x78, 0x21, 0x19, 0xf1, 0xe9, 0x85, 0xcc, 0x05, 0xda,
+	0x4e, 0xd6, 0x6c, 0x6f, 0xc3, 0x5c, 0xa6, 0x4c, 0xc7, 0x37, 0xa0, 0x13, 0x6f, 0x7c, 0xf9, 0x5d,
+	0x7b, 0x4e, 0x62, 0xb0, 0x87, 0x19, 0x00, 0xa3, 0xf6, 0x5f, 0x2a, 0xb0, 0x58, 0xd8, 0x39, 0xc0,
+	0xbb, 0xd1, 0x7e, 0xab, 0xe8, 0x34, 0x46, 0x7f, 0xbb, 0x38, 0x3a, 0xbf, 0xe1, 0xc4, 0x61, 0x1a,
+	0xbb, 0xdc, 0xd5, 0x87, 0x4c, 0xfe, 0xc6, 0x77, 0x61, 0xf8, 0x3a, 0x77, 0xcb, 0xd4, 0x36, 0x6a,
+	0x9b, 0x75, 0x27, 0xef, 0xb0, 0xff, 0x5c, 0x3c, 0x1f, 0x46, 0x63, 0xee, 0x8a, 0xc1, 0xbd, 0x03,
+	0x10, 0x26, 0x17, 0x60, 0x35, 0x7d, 0x06, 0x93, 0xd4, 0xdf, 0x08, 0xfa, 0x95, 0xd3, 0xb9, 0x0d,
+	0x73, 0x99, 0x3e, 0x46, 0x59, 0xce, 0x6c, 0x3f, 0xcf, 0x84, 0x96, 0x4c, 0xf9, 0x6e, 0xb4, 0xac,
+	0xd5, 0x59, 0xcb, 0x1a, 0x9d, 0xe0, 0x1e, 0x40, 0xd2, 0x0a, 0xb1, 0x6f, 0x26, 0x23, 0x46, 0x4b,
+	0x27, 0xf2, 0x2e, 0x74, 0x8d, 0x56, 0x48, 0xd1, 0x24, 0xec, 0x07, 0x46, 0x08, 0xa3, 0x78, 0x0b,
+	0x5a, 0x72, 0xf3, 0xea, 0xbb, 0xa0, 0xbb, 0x3b, 0x30, 0x77, 0xf8, 0xe1, 0x41, 0x94, 0x73, 0xea,
+	0x20, 0xfb, 0x3e, 0x0c, 0xd2, 0x5d, 0x0a, 0xf1, 0x90, 0x29, 0x79, 0xc1, 0xa3, 0x87, 0x88, 0xdf,
+	0xa2, 0x46, 0x08, 0xbd, 0xc9, 0x09, 0xd7, 0xbb, 0x41, 0x0d, 0x6c, 0x94, 0xc6, 0x32, 0x6a, 0xff,
+	0x16, 0x50, 0xb6, 0xff, 0x52, 0xb8, 0x72, 0x0b, 0xd0, 0x18, 0x05, 0xe7, 0xbe, 0xe2, 0xeb, 0x3b,
+	0x6a, 0x60, 0x1f, 0x64, 0xd1, 0x8c, 0xe2, 0x8f, 0xa0, 0xad, 0xa7, 0x2a, 0x76, 0x6f, 0xad, 0xf4,
+	0x85, 0xe2, 0x28, 0xfb, 0x1e, 0xcc, 0x17, 0x34, 0x5f, 0xc4, 0x71, 0x0a, 0xe3, 0x9c, 0x4e, 0x30,
+	0xf5, 0x9c, 0xc4, 0x60, 0x2f, 0x16, 0x80, 0x18, 0xb5, 0x7f, 0x07, 0x2d, 0xfd, 0x18, 0x31, 0x65,
+	0x9f, 0xbc, 0x8e, 0xaf, 0x58, 0x35, 0x10, 0xb7, 0xaf, 0x4f, 0x5e, 0x8b, 0xe3, 0x7e, 0x78, 0xa0,
+	0x76, 0x6d, 0xdd, 0x31, 0x2c, 0xf6, 0x2d, 0x40, 0xd9, 0xf6, 0x8d, 0x58, 0x90, 0x17, 0x53, 0x77,
+	0x22, 0x89, 0xfa, 0x8e, 0xfc, 0x6d, 0x4f, 0x01, 0xe7, 0xfb, 0x33, 0xb3, 0xe7, 0x2c, 0x9e, 0x3d,
+	0x25, 0x2e, 0xe3, 0x4a, 0x7b, 0xf4, 0xb3, 0x13, 0x0b, 0xb6, 0xa0, 0xc5, 0x46, 0x2e, 0xe7, 0xfa,
+	0xce, 0x6e, 0x3b, 0xd1, 0xd0, 0x5e, 0xc8, 0x3f, 0x8d, 0x51, 0x7b, 0x1b, 0x70, 0xbe, 0xb1, 0x83,
+	0x57, 0xa0, 0xe6, 0x8d, 0xd5, 0xd3, 0xeb, 0x0f, 0x5b, 0x6f, 0x7f, 0x59, 0xaf, 0x1d, 0x1e, 0x30,
+	0x47, 0xd8, 0xec, 0x85, 0x3c, 0x80, 0x51, 0x7b, 0x17, 0x16, 0x0b, 0x3b, 0x3a, 0x09, 0x53, 0x65,
+	0xb3, 0x97, 0x61, 0xda, 0x29, 0xc4, 0x30, 0x2a, 0xde, 0x41, 0xa5, 0x7c, 0x63, 0x35, 0x03, 0x27,
+	0x1a, 0xda, 0x8f, 0x60, 0xbe, 0xa0, 0xcd, 0x83, 0xb7, 0xa0, 0x1e, 0x8a, 0xac, 0xb8, 0x92, 0xba,
+	0xde, 0x53, 0x61, 0x7a, 0xc7, 0xc8, 0x38, 0x7b, 0xb1, 0x80, 0x86, 0x51, 0xfb, 0x63, 0xc0, 0xf9,
+	0xbe, 0xcf, 0x65, 0x5a, 0x6b, 0x3f, 0xce, 0xa3, 0xe4, 0x16, 0x6e, 0x88, 0x47, 0x45, 0xfb, 0x77,
+	0xd6, 0x9c, 0x54, 0xa0, 0x7d, 0x0f, 0x7a, 0x66, 0xc3, 0x08, 0xbf, 0x07, 0xb5, 0x3f, 0x05, 0xc7,
+	0xfa, 0x9d, 0xba, 0xd1, 0x35, 0xf3, 0x45, 0x70, 0xac, 0x61, 0xc2, 0x6b, 0x0f, 0x4c, 0x10, 0xa3,
+	0x82, 0xc4, 0x6c, 0x1e, 0x5d, 0x99, 0xc4, 0x4c, 0xbb, 0xed, 0xa7, 0xd0, 0x4f, 0xf5, 0x91, 0xae,
+	0xc4, 0x52, 0xa4, 0x1d, 0xf6, 0x7b, 0x29, 0xa6, 0xe2, 0x1b, 0xd5, 0xfe, 0x16, 0x86, 0xb9, 0xb6,
+	0xd3, 0xa5, 0x19, 0xce, 0xa5, 0x5d, 0x7e, 0x7b, 0x3e, 0x47, 0xcb, 0xa8, 0xfd, 0xf7, 0x1a, 0x74,
+	0x8d, 0x92, 0x12, 0x23, 0xa8, 0x31, 0x72, 0xa6, 0xf9, 0xc5, 0x4f, 0x31, 0xc3, 0xb8, 0x75, 0xd2,
+	0xd7, 0xdd, 0x92, 0x5d, 0xe8, 0x78, 0xbe, 0xc7, 0x25, 0x50, 0xa7, 0x42, 0xd1, 0x07, 0x3d, 0x8c,
+	0xec, 0x07, 0x2e, 0x77, 0x9d, 0x24, 0x0c, 0x7f, 0x66, 0xa4, 0x60, 0x12, 0x57, 0x4f, 0x55, 0x09,
+	0x8e, 0xe9, 0x93, 0xd8, 0x74, 0x38, 0xde, 0x83, 0x41, 0xfc, 0x36, 0x8a, 0xa0, 0x91, 0x2e, 0x6f,
+	0x53, 0x4e, 0xc9, 0x90, 0x01, 0xe0, 0x47, 0x80, 0x43, 0x33, 0xb9, 0x54, 0x34, 0xcd, 0x19, 0xe9,
+	0xa7, 0x53, 0x00, 0xc0, 0x4f, 0x61, 0x7e, 0x94, 0x12, 0x37, 0xc5, 0xd3, 0x9a, 0xa9, 0x7f, 0x45,
+	0x90, 0x62, 0xed, 0x6e, 0x97, 0x69, 0xf7, 0x04, 0xfa, 0xa9, 0xd5, 0xbd, 0xe4, 0x66, 0xb4, 0xa0,
+	0xa5, 0x12, 0xfb, 0xe8, 0x5a, 0x8c, 0x86, 0x62, 0x2f, 0xc5, 0xb3, 0x51, 0xb9, 0x42, 0xcf, 0x31,
+	0x2c, 0xf6, 0x19, 0x0c, 0x73, 0x9f, 0xa3, 0x50, 0xc1, 0x92, 0xfe, 0x98, 0xda, 0x6f, 0x7a, 0x64,
+	0x5e, 0x58, 0xfa, 0xd2, 0xd5, 0x43, 0x81, 0x50, 0x65, 0xaf, 0xfc, 0xfc, 0x6d, 0x47, 0x8f, 0xec,
+	0x4d, 0xc0, 0xf9, 0x0f, 0x58, 0x78, 0x3a, 0xa6, 0x00, 0x49, 0xb2, 0x89, 0x6f, 0x41, 0x9d, 0x12,
+	0x9d, 0x1a, 0x16, 0x17, 0x1d, 0xd2, 0x8f, 0x3f, 0x89, 0xf2, 0xf1, 0x6f, 0x92, 0x36, 0x60, 0xf2,
+	0xa9, 0x62, 0x3e, 0xe1, 0x75, 0x8c, 0x48, 0xfb, 0x37, 0x30, 0x48, 0x1f, 0x9a, 0xab, 0x3e, 0xd1,
+	0xde, 0x83, 0x9e, 0x99, 0x14, 0x8b, 0x56, 0x98, 0xe2, 0x8d, 0xae, 0xc0, 0x7c, 0x39, 0x10, 0xa5,
+	0x25, 0x3a, 0xce, 0x5e, 0x87, 0x86, 0x4c, 0xdf, 0xc5, 0xaa, 0xa9, 0xda, 0x42, 0xaf, 0x84, 0x1e,
+	0xd9, 0x47, 0xd0, 0x4f, 0xe5, 0xec, 0xf8, 0x03, 0x68, 0xd2, 0x60, 0xea, 0x8d, 0x2e, 0x64, 0xe0,
+	0x60, 0x77, 0x3e, 0x79, 0x45, 0x32, 0x7a, 0x79, 0x24, 0x5d, 0x8e, 0x0e, 0x11, 0xab, 0xfb, 0x92,
+	0x5c, 0xa8, 0xdd, 0xd1, 0x73, 0xe4, 0x6f, 0x9b, 0xc0, 0xdc, 0x33, 0xf7, 0x98, 0x4c, 0xf7, 0x03,
+	0x9f, 0xf1, 0xd0, 0xf5, 0x7c, 0x2e, 0xae, 0x84, 0x97, 0x44, 0x11, 0x76, 0x1c, 0xf1, 0x13, 0x6f,
+	0x42, 0x35, 0xa0, 0x7a, 0x11, 0xa3, 0xf3, 0x9b, 0x41, 0x7d, 0x4d, 0x9d, 0x6a, 0x20, 0x52, 0xba,
+	0xe6, 0x2b, 0x77, 0x7a, 0x4e, 0xd4, 0x2e, 0xeb, 0x38, 0x7a, 0x64, 0xff, 0x58, 0x83, 0x7e, 0xba,
+	0x0d, 0x93, 0x24, 0x7f, 0x9d, 0x54, 0xe7, 0xd6, 0x82, 0xd6, 0x44, 0x6c, 0x7f, 0x7d, 0xa7, 0x75,
+	0x9c, 0x68, 0x28, 0x72, 0x11, 0xcf, 0x1f, 0x93, 0x37, 0x72, 0x8b, 0xf5, 0x1d, 0x35, 0x10, 0xa5,
+	0x71, 0xf0, 0x8a, 0x84, 0xa1, 0x37, 0x8e, 0xb6, 0x58, 0x3c, 0x16, 0x3e, 0xc6, 0xdd, 0x90, 0xff,
+	0x9e, 0x5c, 0xc8, 0xcb, 0xa3, 0xe7, 0xc4, 0x63, 0x31, 0x53, 0xe2, 0x8f, 0x85, 0xa7, 0xa9, 0x96,
+	0x58, 0x8d, 0xf0, 0xfb, 0x50, 0x0f, 0x83, 0xa9, 0xaa, 0x94, 0x06, 0x71, 0xb9, 0x23, 0x8b, 0xb7,
+	0x60, 0x4a, 0x54, 0x07, 0x59, 0x04, 0x24, 0xd9, 0x5c, 0xdb, 0xc8, 0xe6, 0xf0, 0x53, 0x40, 0xd3,
+	0xf4, 0xca, 0x30, 0xab, 0xb3, 0x51, 0x33, 0xda, 0xa8, 0x99, 0x85, 0x8b, 0xfa, 0x54, 0x59, 0x14,
+	0xbe, 0x05, 0x83, 0x69, 0x30, 0x72, 0xb9, 0x17, 0xf8, 0x12, 0xc2, 0x2c, 0x90, 0x4b, 0x9a, 0xb1,
+	0x8a, 0x38, 0x8f, 0x05, 0x53, 0x65, 0x22, 0xaf, 0xc8, 0x54, 0xb6, 0x42, 0x3b, 0x4e, 0xc6, 0x6a,
+	0xdf, 0x86, 0x61, 0xee, 0x8f, 0x12, 0xc9, 0x4b, 0x54, 0xcc, 0x94, 0x74, 0x27, 0x17, 0xca, 0xa8,
+	0xb8, 0x7c, 0x78, 0x34, 0xd6, 0x7a, 0x91, 0x18, 0xec, 0xfb, 0xb2, 0x32, 0x33, 0xff, 0x58, 0x81,
+	0xdf, 0x87, 0xc6, 0x54, 0xfc, 0xd6, 0x27, 0xa7, 0xa0, 0xac, 0x51, 0x7e, 0xfb, 0x01, 0xa0, 0x6c,
+	0xb3, 0x07, 0xdf, 0x86, 0xa6, 0x74, 0x26, 0x87, 0x27, 0x87, 0xd6, 0x01, 0xf6, 0x1e, 0x0c, 0x73,
+	0x7f, 0xe9, 0x10, 0x2f, 0x26, 0xf7, 0x4d, 0x94, 0xb8, 0xca, 0x81, 0xf8, 0xe8, 0x2f, 0xe4, 0x0d,
+	0x2b, 0xf7, 0x56, 0xdb, 0xd1, 0x23, 0xfb, 0x8f, 0xb0, 0x5c, 0xd2, 0x28, 0x12, 0x10, 0x89, 0xd5,
+	0xc9, 0xa0, 0xa3, 0x47, 0xe2, 0x2a, 0xd7, 0xdd, 0x8d, 0xfd, 0xe4, 0x6a, 0x55, 0xf7, 0x6e, 0xde,
+	0x61, 0x1f, 0x81, 0x55, 0xf6, 0x47, 0x94, 0x2b, 0xb4, 0x7f, 0x30, 0xd4, 0x43, 0x71, 0x85, 0xaa,
+	0x4b, 0x57, 0xfe, 0xb6, 0x3f, 0x83, 0xd5, 0xf2, 0xbf, 0x9e, 0x5c, 0xce, 0x69, 0xff, 0xb5, 0x52,
+	0x4e, 0xc0, 0x68, 0x54, 0xe5, 0x11, 0xab, 0x92, 0xb9, 0x3a, 0x4d, 0x55, 0x23, 0xaa, 0xca, 0x23,
+	0xf8, 0x66, 0xa2, 0xf5, 0xfb, 0x71, 0x85, 0x53, 0x77, 0xd2, 0x46, 0x31, 0x29, 0xa5, 0x17, 0x2a,
+	0x46, 0x35, 0xac, 0x4c, 0xd3, 0x9d, 0xbf, 0x75, 0xa1, 0x2e, 0x8e, 0x18, 0x5e, 0x81, 0x45, 0xf1,
+	0xaf, 0x43, 0x26, 0x1e, 0xe3, 0x24, 0x8c, 0x9f, 0x8a, 0xae, 0xe1, 0x1b, 0x60, 0x29, 0x57, 0xbe,
+	0x93, 0x86, 0x2a, 0xe5, 0x5e, 0x46, 0x51, 0x15, 0xbf, 0x03, 0x2b, 0xc2, 0x5b, 0xd8, 0x2f, 0x40,
+	0xb5, 0x19, 0x6e, 0x46, 0x51, 0x1d, 0x2f, 0xc3, 0xbc, 0x70, 0x67, 0x5a, 0x16, 0xa8, 0x51, 0xe8,
+	0x60, 0x14, 0x35, 0x23, 0x47, 0xa6, 0x02, 0x47, 0xad, 0x42, 0x07, 0xa3, 0xa8, 0x8d, 0x31, 0x0c,
+	0x84, 0x23, 0xa9, 0x99, 0x51, 0x27, 0x6b, 0x63, 0x14, 0x01, 0x9e, 0x87, 0x39, 0x69, 0x4b, 0xea,
+	0x64, 0xd4, 0xcd, 0x19, 0x19, 0x45, 0x3d, 0x6c, 0xc1, 0x82, 0x36, 0xa6, 0x2a, 0x54, 0xd4, 0x2f,
+	0xf6, 0x30, 0x8a, 0x06, 0x78, 0x09, 0xb0, 0x5a, 0x45, 0xb3, 0x98, 0x44, 0x73, 0x45, 0x76, 0x46,
+	0x11, 0xc2, 0xd7, 0x61, 0x59, 0xd8, 0x0b, 0x2a, 0x50, 0x34, 0x2c, 0x75, 0x32, 0x8a, 0x70, 0x34,
+	0x87, 0x6c, 0xb9, 0x88, 0xe6, 0xa3, 0x97, 0x31, 0x92, 0x55, 0xb4, 0x80, 0x57, 0x61, 0x29, 0x09,
+	0x37, 0x2b, 0x36, 0xb4, 0x58, 0xe6, 0x63, 0x14, 0x2d, 0x45, 0xbe, 0x7c, 0xa5, 0x87, 0x96, 0xcb,
+	0x7c, 0x8c, 0x22, 0x2b, 0xde, 0x11, 0x45, 0xa5, 0x1d, 0x5a, 0x99, 0xe1, 0x66, 0x14, 0xad, 0x46,
+	0x6f, 0x5e, 0x50, 0xb1, 0xa1, 0xeb, 0xa5, 0x4e, 0x46, 0xd1, 0x8d, 0x68, 0x4e, 0xf9, 0x6a, 0x0c,
+	0xbd, 0x53, 0xe6, 0x63, 0x14, 0xad, 0xe1, 0x05, 0x40, 0xc9, 0x1a, 0xa8, 0xe2, 0x05, 0xad, 0xe7,
+	0xad, 0x8c, 0xa2, 0x8d, 0xc8, 0x6a, 0x96, 0x4b, 0xe8, 0xdd, 0xbc, 0x95, 0x51, 0x64, 0xe3, 0x45,
+	0x18, 0xca, 0x8f, 0x61, 0x56, 0x45, 0xe8, 0xbd, 0x02, 0x33, 0xa3, 0xe8, 0x66, 0x74, 0x7a, 0x73,
+	0x45, 0x0d, 0xfa, 0xbf, 0x12, 0x17, 0xa3, 0xe8, 0x56, 0xe4, 0xca, 0x89, 0x14, 0x7a, 0xbf, 0xc4,
+	0xc5, 0x28, 0xda, 0x34, 0xce, 0x9e, 0x29, 0x3e, 0xe8, 0x76, 0xa1, 0x83, 0x51, 0x74, 0x27, 0xda,
+	0x72, 0x69, 0xc9, 0x21, 0x67, 0xe8, 0x83, 0x62, 0x0f, 0xa3, 0xe8, 0x6e, 0x34, 0x81, 0x9c, 0xce,
+	0xa0, 0x0f, 0x4b, 0x5c, 0x8c, 0xa2, 0x2d, 0xbc, 0x0e, 0xd7, 0x35, 0x5f, 0x5e, 0x5a, 0xc8, 0x19,
+	0xda, 0x9e, 0x19, 0xc0, 0x28, 0xfa, 0x08, 0x6f, 0xc0, 0x0d, 0x11, 0x50, 0xa6, 0x1d, 0x68, 0x67,
+	0x76, 0x04, 0xa3, 0x68, 0x17, 0xdb, 0xb0, 0xa6, 0x1f, 0x52, 0xa2, 0x16, 0xe8, 0xde, 0x65, 0x31,
+	0x8c, 0xa2, 0x8f, 0xef, 0x7c, 0x09, 0x3d, 0x33, 0x0d, 0xc2, 0x1d, 0x68, 0x7c, 0x17, 0x70, 0x79,
+	0x27, 0x03, 0x34, 0xd5, 0x97, 0x44, 0x15, 0xdc, 0x83, 0xf6, 0xe3, 0x60, 0x3a, 0x0d, 0x5e, 0x93,
+	0x10, 0x55, 0x71, 0x17, 0x5a, 0xcf, 0x88, 0x1b, 0x8a, 0xab, 0xbb, 0x26, 0x06, 0xdf, 0x7b, 0xdc,
+	0x27, 0x8c, 0xa1, 0xfa, 0x9d, 0x3d, 0x18, 0xe6, 0x72, 0x48, 0xdc, 0x84, 0xea, 0xa1, 0x8f, 0xae,
+	0x09, 0xee, 0xaf, 0x02, 0x7e, 0xe8, 0xa3, 0x8a, 0xe0, 0x7e, 0xf4, 0xc6, 0x63, 0x9c, 0xa1, 0x2a,
+	0xee, 0x43, 0xe7, 0xab, 0x80, 0xeb, 0x61, 0xed, 0x21, 0xfa, 0xf9, 0x3f, 0x6b, 0xd7, 0x7e, 0x7a,
+	0xbb, 0x56, 0xf9, 0xf9, 0xed, 0x5a, 0xe5, 0xdf, 0x6f, 0xd7, 0x2a, 0xc7, 0x4d, 0xf9, 0xdf, 0xe4,
+	0xee, 0xfd, 0x77, 0x00, 0x28, 0x44, 0xc8, 0x2c, 0xb9, 0x27, 0x00, 0x00,
+}
+
+func (m *Request) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3644,7 +4249,7 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -3654,188 +4259,239 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
-	if m.Type != 0 {
+	if m.ContainerID != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
-	}
-	if len(m.Error) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Error)))
-		i += copy(dAtA[i:], m.Error)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
 	}
-	if len(m.Leader) > 0 {
-		dAtA[i] = 0x22
+	if m.Type != 0 {
+		dAtA[i] = 0x18
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Leader)))
-		i += copy(dAtA[i:], m.Leader)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
 	}
-	dAtA[i] = 0x2a
+	dAtA[i] = 0x22
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceHeartbeat.Size()))
-	n19, err := m.ResourceHeartbeat.MarshalTo(dAtA[i:])
+	n1, err := m.ResourceHeartbeat.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n19
-	dAtA[i] = 0x32
+	i += n1
+	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerHeartbeat.Size()))
-	n20, err := m.ContainerHeartbeat.MarshalTo(dAtA[i:])
+	n2, err := m.ContainerHeartbeat.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n20
-	dAtA[i] = 0x3a
+	i += n2
+	dAtA[i] = 0x32
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutContainer.Size()))
-	n21, err := m.PutContainer.MarshalTo(dAtA[i:])
+	n3, err := m.PutContainer.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
-	dAtA[i] = 0x42
+	i += n3
+	dAtA[i] = 0x3a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetContainer.Size()))
-	n22, err := m.GetContainer.MarshalTo(dAtA[i:])
+	n4, err := m.GetContainer.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
-	dAtA[i] = 0x4a
+	i += n4
+	dAtA[i] = 0x42
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
-	n23, err := m.AllocID.MarshalTo(dAtA[i:])
+	n5, err := m.AllocID.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
-	dAtA[i] = 0x52
+	i += n5
+	dAtA[i] = 0x4a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskSplit.Size()))
-	n24, err := m.AskSplit.MarshalTo(dAtA[i:])
+	n6, err := m.AskSplit.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
-	dAtA[i] = 0x5a
+	i += n6
+	dAtA[i] = 0x52
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
-	n25, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
+	n7, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n25
-	dAtA[i] = 0x62
+	i += n7
+	dAtA[i] = 0x5a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportSplit.Size()))
-	n26, err := m.ReportSplit.MarshalTo(dAtA[i:])
+	n8, err := m.ReportSplit.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n26
-	dAtA[i] = 0x6a
+	i += n8
+	dAtA[i] = 0x62
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.BatchReportSplit.Size()))
-	n27, err := m.BatchReportSplit.MarshalTo(dAtA[i:])
+	n9, err := m.BatchReportSplit.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n27
+	i += n9
+	dAtA[i] = 0x6a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateWatcher.Size()))
+	n10, err := m.CreateWatcher.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n10
 	dAtA[i] = 0x72
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Event.Size()))
-	n28, err := m.Event.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateResources.Size()))
+	n11, err := m.CreateResources.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n28
+	i += n11
 	dAtA[i] = 0x7a
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateResources.Size()))
-	n29, err := m.CreateResources.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveResources.Size()))
+	n12, err := m.RemoveResources.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n29
+	i += n12
 	dAtA[i] = 0x82
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveResources.Size()))
-	n30, err := m.RemoveResources.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckResourceState.Size()))
+	n13, err := m.CheckResourceState.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n30
+	i += n13
 	dAtA[i] = 0x8a
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckResourceState.Size()))
-	n31, err := m.CheckResourceState.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
+	n14, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n31
+	i += n14
 	dAtA[i] = 0x92
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
-	n32, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
+	n15, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n32
+	i += n15
 	dAtA[i] = 0x9a
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
-	n33, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
+	n16, err := m.CreateJob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n33
+	i += n16
 	dAtA[i] = 0xa2
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
-	n34, err := m.CreateJob.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
+	n17, err := m.RemoveJob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n34
+	i += n17
 	dAtA[i] = 0xaa
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
-	n35, err := m.RemoveJob.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
+	n18, err := m.ExecuteJob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n35
+	i += n18
 	dAtA[i] = 0xb2
 	i++
 	dAtA[i] = 0x1
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
-	n36, err := m.ExecuteJob.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
+	n19, err := m.TransferLeader.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n36
+	i += n19
+	dAtA[i] = 0xba
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocTimestamp.Size()))
+	n191, err := m.AllocTimestamp.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n191
+	dAtA[i] = 0xc2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutRateLimit.Size()))
+	n192, err := m.PutRateLimit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n192
+	dAtA[i] = 0xca
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.SetWriteFreeze.Size()))
+	n193, err := m.SetWriteFreeze.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n193
+	dAtA[i] = 0xd2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.DecommissionContainer.Size()))
+	n194, err := m.DecommissionContainer.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n194
+	dAtA[i] = 0xda
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDecommissionProgress.Size()))
+	n195, err := m.GetDecommissionProgress.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n195
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ResourceHeartbeatReq) Marshal() (dAtA []byte, err error) {
+func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3845,162 +4501,413 @@ func (m *ResourceHeartbeatReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResourceHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ContainerID != 0 {
+	if m.ID != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
-	if len(m.Resource) > 0 {
-		dAtA[i] = 0x12
+	if m.Type != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Resource)))
-		i += copy(dAtA[i:], m.Resource)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
 	}
-	if m.Term != 0 {
-		dAtA[i] = 0x18
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Term))
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
 	}
-	if m.Leader != nil {
+	if len(m.Leader) > 0 {
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Leader.Size()))
-		n37, err := m.Leader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n37
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Leader)))
+		i += copy(dAtA[i:], m.Leader)
 	}
-	if len(m.DownPeers) > 0 {
-		for _, msg := range m.DownPeers {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceHeartbeat.Size()))
+	n20, err := m.ResourceHeartbeat.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.PendingPeers) > 0 {
-		for _, msg := range m.PendingPeers {
-			dAtA[i] = 0x32
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	i += n20
+	dAtA[i] = 0x32
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerHeartbeat.Size()))
+	n21, err := m.ContainerHeartbeat.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n21
 	dAtA[i] = 0x3a
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-	n38, err := m.Stats.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutContainer.Size()))
+	n22, err := m.PutContainer.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n38
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
-}
-
-func (m *ResourceHeartbeatRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	i += n22
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetContainer.Size()))
+	n23, err := m.GetContainer.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ResourceHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ResourceID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceID))
+	i += n23
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
+	n24, err := m.AllocID.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	dAtA[i] = 0x12
+	i += n24
+	dAtA[i] = 0x52
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceEpoch.Size()))
-	n39, err := m.ResourceEpoch.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskSplit.Size()))
+	n25, err := m.AskSplit.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n39
-	if m.TargetPeer != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TargetPeer.Size()))
-		n40, err := m.TargetPeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n40
+	i += n25
+	dAtA[i] = 0x5a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
+	n26, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.ChangePeer != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n41, err := m.ChangePeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n41
+	i += n26
+	dAtA[i] = 0x62
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportSplit.Size()))
+	n27, err := m.ReportSplit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n42, err := m.TransferLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n42
+	i += n27
+	dAtA[i] = 0x6a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.BatchReportSplit.Size()))
+	n28, err := m.BatchReportSplit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.Merge != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Merge.Size()))
-		n43, err := m.Merge.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n43
+	i += n28
+	dAtA[i] = 0x72
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Event.Size()))
+	n29, err := m.Event.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.SplitResource != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.SplitResource.Size()))
-		n44, err := m.SplitResource.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n44
+	i += n29
+	dAtA[i] = 0x7a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateResources.Size()))
+	n30, err := m.CreateResources.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n30
+	dAtA[i] = 0x82
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveResources.Size()))
+	n31, err := m.RemoveResources.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n31
+	dAtA[i] = 0x8a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckResourceState.Size()))
+	n32, err := m.CheckResourceState.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n32
+	dAtA[i] = 0x92
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
+	n33, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n33
+	dAtA[i] = 0x9a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
+	n34, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n34
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
+	n35, err := m.CreateJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n35
+	dAtA[i] = 0xaa
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
+	n36, err := m.RemoveJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n36
+	dAtA[i] = 0xb2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
+	n37, err := m.ExecuteJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n37
+	dAtA[i] = 0xba
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
+	n38, err := m.TransferLeader.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n38
+	dAtA[i] = 0xc2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocTimestamp.Size()))
+	n381, err := m.AllocTimestamp.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n381
+	dAtA[i] = 0xca
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetRateLimits.Size()))
+	n382, err := m.GetRateLimits.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n382
+	dAtA[i] = 0xd2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetWriteFrozenGroups.Size()))
+	n385, err := m.GetWriteFrozenGroups.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n385
+	dAtA[i] = 0xda
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDecommissionProgress.Size()))
+	n388, err := m.GetDecommissionProgress.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n388
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResourceHeartbeatReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ContainerID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
+	}
+	if len(m.Resource) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Resource)))
+		i += copy(dAtA[i:], m.Resource)
+	}
+	if m.Term != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Term))
+	}
+	if m.Leader != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Leader.Size()))
+		n39, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	if len(m.DownPeers) > 0 {
+		for _, msg := range m.DownPeers {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.PendingPeers) > 0 {
+		for _, msg := range m.PendingPeers {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
+	n40, err := m.Stats.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n40
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResourceHeartbeatRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ResourceID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceID))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceEpoch.Size()))
+	n41, err := m.ResourceEpoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n41
+	if m.TargetPeer != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TargetPeer.Size()))
+		n42, err := m.TargetPeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n42
+	}
+	if m.ChangePeer != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangePeer.Size()))
+		n43, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
+	}
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n44, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
+	}
+	if m.Merge != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Merge.Size()))
+		n45, err := m.Merge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
+	}
+	if m.SplitResource != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.SplitResource.Size()))
+		n46, err := m.SplitResource.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
 	}
 	if m.ChangePeerV2 != nil {
 		dAtA[i] = 0x42
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangePeerV2.Size()))
-		n45, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
+		n47, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n45
+		i += n47
 	}
 	if m.DestoryDirectly {
 		dAtA[i] = 0x48
@@ -4084,17 +4991,34 @@ func (m *ContainerHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-	n46, err := m.Stats.MarshalTo(dAtA[i:])
+	n48, err := m.Stats.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n46
+	i += n48
 	if len(m.Data) > 0 {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
 		i += copy(dAtA[i:], m.Data)
 	}
+	if len(m.WriteFrozenGroups) > 0 {
+		dAtA901 := make([]byte, len(m.WriteFrozenGroups)*10)
+		var j901 int
+		for _, num := range m.WriteFrozenGroups {
+			for num >= 1<<7 {
+				dAtA901[j901] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j901++
+			}
+			dAtA901[j901] = uint8(num)
+			j901++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j901))
+		i += copy(dAtA[i:], dAtA901[:j901])
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4122,7 +5046,36 @@ func (m *ContainerHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
 		i += copy(dAtA[i:], m.Data)
 	}
-	if m.XXX_unrecognized != nil {
+	if len(m.RateLimits) > 0 {
+		for _, msg := range m.RateLimits {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.WriteFrozenGroups) > 0 {
+		dAtA387 := make([]byte, len(m.WriteFrozenGroups)*10)
+		var j386 int
+		for _, num := range m.WriteFrozenGroups {
+			for num >= 1<<7 {
+				dAtA387[j386] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j386++
+			}
+			dAtA387[j386] = uint8(num)
+			j386++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j386))
+		i += copy(dAtA[i:], dAtA387[:j386])
+	}
+	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
@@ -4179,11 +5132,11 @@ func (m *GetContainerRsp) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-		n47, err := m.Stats.MarshalTo(dAtA[i:])
+		n49, err := m.Stats.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n49
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4283,11 +5236,11 @@ func (m *AskSplitRsp) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.SplitID.Size()))
-	n48, err := m.SplitID.MarshalTo(dAtA[i:])
+	n50, err := m.SplitID.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n48
+	i += n50
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4484,21 +5437,21 @@ func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.NewID))
 	}
 	if len(m.NewPeerIDs) > 0 {
-		dAtA50 := make([]byte, len(m.NewPeerIDs)*10)
-		var j49 int
+		dAtA52 := make([]byte, len(m.NewPeerIDs)*10)
+		var j51 int
 		for _, num := range m.NewPeerIDs {
 			for num >= 1<<7 {
-				dAtA50[j49] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA52[j51] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j49++
+				j51++
 			}
-			dAtA50[j49] = uint8(num)
-			j49++
+			dAtA52[j51] = uint8(num)
+			j51++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j49))
-		i += copy(dAtA[i:], dAtA50[:j49])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j51))
+		i += copy(dAtA[i:], dAtA52[:j51])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4556,21 +5509,31 @@ func (m *CreateResourcesReq) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.LeastPeers) > 0 {
-		dAtA52 := make([]byte, len(m.LeastPeers)*10)
-		var j51 int
+		dAtA54 := make([]byte, len(m.LeastPeers)*10)
+		var j53 int
 		for _, num := range m.LeastPeers {
 			for num >= 1<<7 {
-				dAtA52[j51] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA54[j53] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j51++
+				j53++
 			}
-			dAtA52[j51] = uint8(num)
-			j51++
+			dAtA54[j53] = uint8(num)
+			j53++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j51))
-		i += copy(dAtA[i:], dAtA52[:j51])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j53))
+		i += copy(dAtA[i:], dAtA54[:j53])
+	}
+	if m.Scatter {
+		dAtA[i] = 0x18
+		i++
+		if m.Scatter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4615,21 +5578,21 @@ func (m *RemoveResourcesReq) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.IDs) > 0 {
-		dAtA54 := make([]byte, len(m.IDs)*10)
-		var j53 int
+		dAtA56 := make([]byte, len(m.IDs)*10)
+		var j55 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA54[j53] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA56[j55] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j53++
+				j55++
 			}
-			dAtA54[j53] = uint8(num)
-			j53++
+			dAtA56[j55] = uint8(num)
+			j55++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j53))
-		i += copy(dAtA[i:], dAtA54[:j53])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j55))
+		i += copy(dAtA[i:], dAtA56[:j55])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4701,21 +5664,21 @@ func (m *CheckResourceStateRsp) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Removed) > 0 {
-		dAtA56 := make([]byte, len(m.Removed)*10)
-		var j55 int
+		dAtA58 := make([]byte, len(m.Removed)*10)
+		var j57 int
 		for _, num := range m.Removed {
 			for num >= 1<<7 {
-				dAtA56[j55] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j55++
+				j57++
 			}
-			dAtA56[j55] = uint8(num)
-			j55++
+			dAtA58[j57] = uint8(num)
+			j57++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j55))
-		i += copy(dAtA[i:], dAtA56[:j55])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j57))
+		i += copy(dAtA[i:], dAtA58[:j57])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4741,11 +5704,11 @@ func (m *PutPlacementRuleReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
-	n57, err := m.Rule.MarshalTo(dAtA[i:])
+	n59, err := m.Rule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n57
+	i += n59
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4850,11 +5813,11 @@ func (m *CreateJobReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n58, err := m.Job.MarshalTo(dAtA[i:])
+	n60, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n58
+	i += n60
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4900,11 +5863,11 @@ func (m *RemoveJobReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n59, err := m.Job.MarshalTo(dAtA[i:])
+	n61, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n59
+	i += n61
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4950,11 +5913,11 @@ func (m *ExecuteJobReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n60, err := m.Job.MarshalTo(dAtA[i:])
+	n62, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n60
+	i += n62
 	if len(m.Data) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -4994,6 +5957,58 @@ func (m *ExecuteJobRsp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *TransferLeaderReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeaderReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ResourceID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceID))
+	}
+	if m.ContainerID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TransferLeaderRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeaderRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *EventNotify) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5023,51 +6038,68 @@ func (m *EventNotify) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.InitEvent.Size()))
-		n61, err := m.InitEvent.MarshalTo(dAtA[i:])
+		n63, err := m.InitEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
+		i += n63
 	}
 	if m.ResourceEvent != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceEvent.Size()))
-		n62, err := m.ResourceEvent.MarshalTo(dAtA[i:])
+		n64, err := m.ResourceEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
+		i += n64
 	}
 	if m.ContainerEvent != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerEvent.Size()))
-		n63, err := m.ContainerEvent.MarshalTo(dAtA[i:])
+		n65, err := m.ContainerEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n63
+		i += n65
 	}
 	if m.ResourceStatsEvent != nil {
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceStatsEvent.Size()))
-		n64, err := m.ResourceStatsEvent.MarshalTo(dAtA[i:])
+		n66, err := m.ResourceStatsEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n64
+		i += n66
 	}
 	if m.ContainerStatsEvent != nil {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerStatsEvent.Size()))
-		n65, err := m.ContainerStatsEvent.MarshalTo(dAtA[i:])
+		n67, err := m.ContainerStatsEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n65
+		i += n67
+	}
+	if len(m.WriteFrozenGroups) > 0 {
+		dAtA903 := make([]byte, len(m.WriteFrozenGroups)*10)
+		var j903 int
+		for _, num := range m.WriteFrozenGroups {
+			for num >= 1<<7 {
+				dAtA903[j903] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j903++
+			}
+			dAtA903[j903] = uint8(num)
+			j903++
+		}
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j903))
+		i += copy(dAtA[i:], dAtA903[:j903])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5099,21 +6131,21 @@ func (m *InitEventData) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Leaders) > 0 {
-		dAtA67 := make([]byte, len(m.Leaders)*10)
-		var j66 int
+		dAtA69 := make([]byte, len(m.Leaders)*10)
+		var j68 int
 		for _, num := range m.Leaders {
 			for num >= 1<<7 {
-				dAtA67[j66] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA69[j68] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j66++
+				j68++
 			}
-			dAtA67[j66] = uint8(num)
-			j66++
+			dAtA69[j68] = uint8(num)
+			j68++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j66))
-		i += copy(dAtA[i:], dAtA67[:j66])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j68))
+		i += copy(dAtA[i:], dAtA69[:j68])
 	}
 	if len(m.Containers) > 0 {
 		for _, b := range m.Containers {
@@ -5226,11 +6258,11 @@ func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Peer.Size()))
-	n68, err := m.Peer.MarshalTo(dAtA[i:])
+	n70, err := m.Peer.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n68
+	i += n70
 	if m.ChangeType != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -5260,11 +6292,11 @@ func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Peer.Size()))
-	n69, err := m.Peer.MarshalTo(dAtA[i:])
+	n71, err := m.Peer.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n69
+	i += n71
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5515,328 +6547,383 @@ func (m *PlacementRule) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintRpcpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *AllocTimestampReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *AllocTimestampReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
-	}
-	if m.ContainerID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ContainerID))
-	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
+	if m.Count != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
 	}
-	l = m.ResourceHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.ContainerHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.PutContainer.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.GetContainer.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AllocID.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AskSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AskBatchSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.ReportSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.BatchReportSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateWatcher.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateResources.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.RemoveResources.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CheckResourceState.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.PutPlacementRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.GetAppliedRules.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CreateJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.RemoveJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.ExecuteJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Response) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AllocTimestampRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AllocTimestampRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp))
 	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	return i, nil
+}
+
+func (m *PutRateLimitReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *PutRateLimitReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Limit.Size()))
+	n1, err := m.Limit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	l = m.ResourceHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.ContainerHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.PutContainer.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.GetContainer.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AllocID.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AskSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AskBatchSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.ReportSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.BatchReportSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.Event.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateResources.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.RemoveResources.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CheckResourceState.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.PutPlacementRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.GetAppliedRules.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CreateJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.RemoveJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.ExecuteJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
+	i += n1
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ResourceHeartbeatReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetRateLimitsRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRateLimitsRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ContainerID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ContainerID))
-	}
-	l = len(m.Resource)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Term != 0 {
-		n += 1 + sovRpcpb(uint64(m.Term))
-	}
-	if m.Leader != nil {
-		l = m.Leader.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if len(m.DownPeers) > 0 {
-		for _, e := range m.DownPeers {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
-	if len(m.PendingPeers) > 0 {
-		for _, e := range m.PendingPeers {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Limits) > 0 {
+		for _, msg := range m.Limits {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	l = m.Stats.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ResourceHeartbeatRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetWriteFreezeReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetWriteFreezeReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ResourceID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ResourceID))
-	}
-	l = m.ResourceEpoch.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.TargetPeer != nil {
-		l = m.TargetPeer.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.ChangePeer != nil {
-		l = m.ChangePeer.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.TransferLeader != nil {
-		l = m.TransferLeader.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Merge != nil {
-		l = m.Merge.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.SplitResource != nil {
-		l = m.SplitResource.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.ChangePeerV2 != nil {
-		l = m.ChangePeerV2.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Group != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Group))
 	}
-	if m.DestoryDirectly {
-		n += 2
+	if m.Frozen {
+		dAtA[i] = 0x10
+		i++
+		if m.Frozen {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutContainerReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetWriteFrozenGroupsRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetWriteFrozenGroupsRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Container)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Groups) > 0 {
+		dAtA384 := make([]byte, len(m.Groups)*10)
+		var j383 int
+		for _, num := range m.Groups {
+			for num >= 1<<7 {
+				dAtA384[j383] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j383++
+			}
+			dAtA384[j383] = uint8(num)
+			j383++
+		}
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j383))
+		i += copy(dAtA[i:], dAtA384[:j383])
+	}
+	if len(m.PendingContainers) > 0 {
+		dAtA902 := make([]byte, len(m.PendingContainers)*10)
+		var j902 int
+		for _, num := range m.PendingContainers {
+			for num >= 1<<7 {
+				dAtA902[j902] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j902++
+			}
+			dAtA902[j902] = uint8(num)
+			j902++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j902))
+		i += copy(dAtA[i:], dAtA902[:j902])
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutContainerRsp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *DecommissionContainerReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ContainerHeartbeatReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *DecommissionContainerReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Stats.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.ContainerID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
+	}
+	if m.Rate != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Rate))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ContainerHeartbeatRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetDecommissionProgressReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDecommissionProgressReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.ContainerID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ContainerID))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetContainerReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetDecommissionProgressRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDecommissionProgressRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.State))
+	}
+	if m.ResourceCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ResourceCount))
+	}
+	if m.LeaderCount != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.LeaderCount))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetContainerRsp) Size() (n int) {
+func encodeVarintRpcpb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Request) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Stats != nil {
-		l = m.Stats.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.ContainerID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ContainerID))
 	}
-	return n
-}
-
-func (m *AllocIDReq) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
 	}
-	var l int
-	_ = l
+	l = m.ResourceHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.ContainerHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.PutContainer.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.GetContainer.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AllocID.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AskSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AskBatchSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.ReportSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.BatchReportSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateWatcher.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateResources.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.RemoveResources.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CheckResourceState.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutPlacementRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetAppliedRules.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CreateJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.RemoveJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ExecuteJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.TransferLeader.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.AllocTimestamp.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutRateLimit.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.SetWriteFreeze.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.DecommissionContainer.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetDecommissionProgress.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *AllocIDRsp) Size() (n int) {
+func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -5845,53 +6932,160 @@ func (m *AllocIDRsp) Size() (n int) {
 	if m.ID != 0 {
 		n += 1 + sovRpcpb(uint64(m.ID))
 	}
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = m.ResourceHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.ContainerHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.PutContainer.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.GetContainer.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AllocID.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AskSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AskBatchSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.ReportSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.BatchReportSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.Event.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateResources.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.RemoveResources.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CheckResourceState.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutPlacementRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetAppliedRules.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CreateJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.RemoveJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ExecuteJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.TransferLeader.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.AllocTimestamp.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetRateLimits.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetWriteFrozenGroups.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetDecommissionProgress.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *AskSplitReq) Size() (n int) {
+func (m *ResourceHeartbeatReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Data)
+	if m.ContainerID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ContainerID))
+	}
+	l = len(m.Resource)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
+	if m.Term != 0 {
+		n += 1 + sovRpcpb(uint64(m.Term))
+	}
+	if m.Leader != nil {
+		l = m.Leader.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if len(m.DownPeers) > 0 {
+		for _, e := range m.DownPeers {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if len(m.PendingPeers) > 0 {
+		for _, e := range m.PendingPeers {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	l = m.Stats.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *AskSplitRsp) Size() (n int) {
+func (m *ResourceHeartbeatRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.SplitID.Size()
+	if m.ResourceID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ResourceID))
+	}
+	l = m.ResourceEpoch.Size()
 	n += 1 + l + sovRpcpb(uint64(l))
+	if m.TargetPeer != nil {
+		l = m.TargetPeer.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ChangePeer != nil {
+		l = m.ChangePeer.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.TransferLeader != nil {
+		l = m.TransferLeader.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Merge != nil {
+		l = m.Merge.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.SplitResource != nil {
+		l = m.SplitResource.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ChangePeerV2 != nil {
+		l = m.ChangePeerV2.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.DestoryDirectly {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ReportSplitReq) Size() (n int) {
+func (m *PutContainerReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Left)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	l = len(m.Right)
+	l = len(m.Container)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
@@ -5901,7 +7095,7 @@ func (m *ReportSplitReq) Size() (n int) {
 	return n
 }
 
-func (m *ReportSplitRsp) Size() (n int) {
+func (m *PutContainerRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -5913,18 +7107,24 @@ func (m *ReportSplitRsp) Size() (n int) {
 	return n
 }
 
-func (m *AskBatchSplitReq) Size() (n int) {
+func (m *ContainerHeartbeatReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Stats.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.Count != 0 {
-		n += 1 + sovRpcpb(uint64(m.Count))
+	if len(m.WriteFrozenGroups) > 0 {
+		l = 0
+		for _, e := range m.WriteFrozenGroups {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5932,35 +7132,43 @@ func (m *AskBatchSplitReq) Size() (n int) {
 	return n
 }
 
-func (m *AskBatchSplitRsp) Size() (n int) {
+func (m *ContainerHeartbeatRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.SplitIDs) > 0 {
-		for _, e := range m.SplitIDs {
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if len(m.RateLimits) > 0 {
+		for _, e := range m.RateLimits {
 			l = e.Size()
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
+	if len(m.WriteFrozenGroups) > 0 {
+		l = 0
+		for _, e := range m.WriteFrozenGroups {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *BatchReportSplitReq) Size() (n int) {
+func (m *GetContainerReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Resources) > 0 {
-		for _, b := range m.Resources {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5968,7 +7176,171 @@ func (m *BatchReportSplitReq) Size() (n int) {
 	return n
 }
 
-func (m *BatchReportSplitRsp) Size() (n int) {
+func (m *GetContainerRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AllocIDReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AllocIDRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AskSplitReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AskSplitRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SplitID.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReportSplitReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Left)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Right)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReportSplitRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AskBatchSplitReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovRpcpb(uint64(m.Count))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AskBatchSplitRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SplitIDs) > 0 {
+		for _, e := range m.SplitIDs {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchReportSplitReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Resources) > 0 {
+		for _, b := range m.Resources {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchReportSplitRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -6036,6 +7408,9 @@ func (m *CreateResourcesReq) Size() (n int) {
 		}
 		n += 1 + sovRpcpb(uint64(l)) + l
 	}
+	if m.Scatter {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6265,6 +7640,36 @@ func (m *ExecuteJobRsp) Size() (n int) {
 	return n
 }
 
+func (m *TransferLeaderReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResourceID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ResourceID))
+	}
+	if m.ContainerID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ContainerID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferLeaderRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *EventNotify) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6297,6 +7702,13 @@ func (m *EventNotify) Size() (n int) {
 		l = m.ContainerStatsEvent.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
+	if len(m.WriteFrozenGroups) > 0 {
+		l = 0
+		for _, e := range m.WriteFrozenGroups {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6532,30 +7944,1093 @@ func (m *PlacementRule) Size() (n int) {
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	l = len(m.IsolationLevel)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
+	l = len(m.IsolationLevel)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AllocTimestampReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Count != 0 {
+		n += 1 + sovRpcpb(uint64(m.Count))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AllocTimestampRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		n += 1 + sovRpcpb(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutRateLimitReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Limit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetRateLimitsRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Limits) > 0 {
+		for _, e := range m.Limits {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetWriteFreezeReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Group != 0 {
+		n += 1 + sovRpcpb(uint64(m.Group))
+	}
+	if m.Frozen {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetWriteFrozenGroupsRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Groups) > 0 {
+		l = 0
+		for _, e := range m.Groups {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
+	if len(m.PendingContainers) > 0 {
+		l = 0
+		for _, e := range m.PendingContainers {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DecommissionContainerReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ContainerID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ContainerID))
+	}
+	if m.Rate != 0 {
+		n += 1 + sovRpcpb(uint64(m.Rate))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetDecommissionProgressReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ContainerID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ContainerID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetDecommissionProgressRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovRpcpb(uint64(m.State))
+	}
+	if m.ResourceCount != 0 {
+		n += 1 + sovRpcpb(uint64(m.ResourceCount))
+	}
+	if m.LeaderCount != 0 {
+		n += 1 + sovRpcpb(uint64(m.LeaderCount))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovRpcpb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRpcpb(x uint64) (n int) {
+	return sovRpcpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
+			}
+			m.ContainerID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContainerID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceHeartbeat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ResourceHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerHeartbeat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ContainerHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutContainer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetContainer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AskSplit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AskSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReportSplit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchReportSplit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BatchReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateWatcher", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateWatcher.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckResourceState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CheckResourceState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllocTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AllocTimestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutRateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutRateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetWriteFreeze", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SetWriteFreeze.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DecommissionContainer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.DecommissionContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetDecommissionProgress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetDecommissionProgress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func sovRpcpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozRpcpb(x uint64) (n int) {
-	return sovRpcpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6578,10 +9053,10 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6605,9 +9080,9 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			m.ContainerID = 0
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -6617,16 +9092,16 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ContainerID |= uint64(b&0x7F) << shift
+				m.Type |= Type(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -6636,16 +9111,29 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= Type(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceHeartbeat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -6655,28 +9143,27 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ResourceHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerHeartbeat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceHeartbeat", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6703,13 +9190,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ContainerHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ResourceHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutContainer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerHeartbeat", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6736,13 +9223,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ContainerHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetContainer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PutContainer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6769,13 +9256,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PutContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GetContainer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6802,13 +9289,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.GetContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6835,13 +9322,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AskSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AskSplit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6868,13 +9355,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AskSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReportSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6901,13 +9388,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BatchReportSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReportSplit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6934,13 +9421,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.BatchReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateWatcher", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchReportSplit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6967,13 +9454,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateWatcher.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BatchReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateResources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7000,13 +9487,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveResources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateResources", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7033,13 +9520,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CreateResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckResourceState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveResources", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7066,13 +9553,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CheckResourceState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.RemoveResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckResourceState", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7099,13 +9586,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CheckResourceState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 18:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7132,13 +9619,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7165,13 +9652,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7198,13 +9685,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 21:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7222,116 +9709,24 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 			}
 			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Response) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+				return ErrInvalidLengthRpcpb
 			}
-		case 3:
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -7341,29 +9736,30 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 23:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -7373,27 +9769,28 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 24:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceHeartbeat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AllocTimestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7420,13 +9817,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ResourceHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AllocTimestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 25:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerHeartbeat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GetRateLimits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7453,13 +9850,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ContainerHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.GetRateLimits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 26:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutContainer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GetWriteFrozenGroups", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7486,13 +9883,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.GetWriteFrozenGroups.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 27:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetContainer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GetDecommissionProgress", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7519,15 +9916,88 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetContainer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.GetDecommissionProgress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResourceHeartbeatReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceHeartbeatReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
+			}
+			m.ContainerID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContainerID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -7537,28 +10007,48 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Resource = append(m.Resource[:0], dAtA[iNdEx:postIndex]...)
+			if m.Resource == nil {
+				m.Resource = []byte{}
 			}
 			iNdEx = postIndex
-		case 10:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7585,13 +10075,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AskSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DownPeers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7618,13 +10111,14 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.DownPeers = append(m.DownPeers, metapb.PeerStats{})
+			if err := m.DownPeers[len(m.DownPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 12:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReportSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7651,13 +10145,14 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.PendingPeers = append(m.PendingPeers, metapb.Peer{})
+			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 13:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BatchReportSplit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7684,15 +10179,69 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.BatchReportSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 14:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceHeartbeatRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			m.ResourceID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -7702,28 +10251,14 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ResourceID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 15:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateResources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceEpoch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7750,13 +10285,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ResourceEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 16:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveResources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetPeer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7783,13 +10318,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.TargetPeer == nil {
+				m.TargetPeer = &metapb.Peer{}
+			}
+			if err := m.TargetPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 17:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckResourceState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7816,13 +10354,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CheckResourceState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ChangePeer == nil {
+				m.ChangePeer = &ChangePeer{}
+			}
+			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 18:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7849,13 +10390,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeader{}
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 19:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7882,13 +10426,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Merge == nil {
+				m.Merge = &Merge{}
+			}
+			if err := m.Merge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 20:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitResource", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7915,13 +10462,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.SplitResource == nil {
+				m.SplitResource = &SplitResource{}
+			}
+			if err := m.SplitResource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 21:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7948,15 +10498,18 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ChangePeerV2 == nil {
+				m.ChangePeerV2 = &ChangePeerV2{}
+			}
+			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 22:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DestoryDirectly", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -7966,25 +10519,12 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.DestoryDirectly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -8010,7 +10550,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResourceHeartbeatReq) Unmarshal(dAtA []byte) error {
+func (m *PutContainerReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8030,162 +10570,20 @@ func (m *ResourceHeartbeatReq) Unmarshal(dAtA []byte) error {
 				break
 			}
 		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResourceHeartbeatReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResourceHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
-			}
-			m.ContainerID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ContainerID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Resource = append(m.Resource[:0], dAtA[iNdEx:postIndex]...)
-			if m.Resource == nil {
-				m.Resource = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
-			}
-			m.Term = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Term |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Peer{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DownPeers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DownPeers = append(m.DownPeers, metapb.PeerStats{})
-			if err := m.DownPeers[len(m.DownPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutContainerReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutContainerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Container", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8195,59 +10593,80 @@ func (m *ResourceHeartbeatReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PendingPeers = append(m.PendingPeers, metapb.Peer{})
-			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Container = append(m.Container[:0], dAtA[iNdEx:postIndex]...)
+			if m.Container == nil {
+				m.Container = []byte{}
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutContainerRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutContainerRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutContainerRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -8273,7 +10692,7 @@ func (m *ResourceHeartbeatReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
+func (m *ContainerHeartbeatReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8296,17 +10715,17 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResourceHeartbeatRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContainerHeartbeatReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResourceHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContainerHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
-			m.ResourceID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8316,16 +10735,30 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ResourceID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceEpoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8335,30 +10768,161 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ResourceEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.WriteFrozenGroups = append(m.WriteFrozenGroups, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.WriteFrozenGroups) == 0 {
+					m.WriteFrozenGroups = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.WriteFrozenGroups = append(m.WriteFrozenGroups, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteFrozenGroups", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContainerHeartbeatRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContainerHeartbeatRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContainerHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetPeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8368,31 +10932,29 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TargetPeer == nil {
-				m.TargetPeer = &metapb.Peer{}
-			}
-			if err := m.TargetPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8419,54 +10981,146 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeer == nil {
-				m.ChangePeer = &ChangePeer{}
-			}
-			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.RateLimits = append(m.RateLimits, metapb.RateLimit{})
+			if err := m.RateLimits[len(m.RateLimits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.WriteFrozenGroups = append(m.WriteFrozenGroups, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.WriteFrozenGroups) == 0 {
+					m.WriteFrozenGroups = make([]uint64, 0, elementCount)
 				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.WriteFrozenGroups = append(m.WriteFrozenGroups, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteFrozenGroups", wireType)
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeader{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetContainerReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetContainerReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetContainerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8476,33 +11130,70 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Merge == nil {
-				m.Merge = &Merge{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetContainerRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.Merge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 7:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetContainerRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetContainerRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitResource", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8512,31 +11203,29 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SplitResource == nil {
-				m.SplitResource = &SplitResource{}
-			}
-			if err := m.SplitResource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8563,18 +11252,126 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeerV2 == nil {
-				m.ChangePeerV2 = &ChangePeerV2{}
+			if m.Stats == nil {
+				m.Stats = &metapb.ContainerStats{}
 			}
-			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllocIDReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllocIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllocIDRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllocIDRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DestoryDirectly", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var v int
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8584,12 +11381,11 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.DestoryDirectly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -8615,7 +11411,7 @@ func (m *ResourceHeartbeatRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutContainerReq) Unmarshal(dAtA []byte) error {
+func (m *AskSplitReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8638,15 +11434,15 @@ func (m *PutContainerReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutContainerReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskSplitReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutContainerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Container", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -8673,9 +11469,9 @@ func (m *PutContainerReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Container = append(m.Container[:0], dAtA[iNdEx:postIndex]...)
-			if m.Container == nil {
-				m.Container = []byte{}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -8703,7 +11499,7 @@ func (m *PutContainerReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutContainerRsp) Unmarshal(dAtA []byte) error {
+func (m *AskSplitRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8726,12 +11522,45 @@ func (m *PutContainerRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutContainerRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskSplitRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutContainerRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SplitID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -8757,7 +11586,7 @@ func (m *PutContainerRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContainerHeartbeatReq) Unmarshal(dAtA []byte) error {
+func (m *ReportSplitReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8780,17 +11609,17 @@ func (m *ContainerHeartbeatReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContainerHeartbeatReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportSplitReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContainerHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -8800,28 +11629,29 @@ func (m *ContainerHeartbeatReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Left = append(m.Left[:0], dAtA[iNdEx:postIndex]...)
+			if m.Left == nil {
+				m.Left = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -8848,9 +11678,9 @@ func (m *ContainerHeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Right = append(m.Right[:0], dAtA[iNdEx:postIndex]...)
+			if m.Right == nil {
+				m.Right = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -8878,7 +11708,61 @@ func (m *ContainerHeartbeatReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContainerHeartbeatRsp) Unmarshal(dAtA []byte) error {
+func (m *ReportSplitRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReportSplitRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReportSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8901,10 +11785,10 @@ func (m *ContainerHeartbeatRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContainerHeartbeatRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskBatchSplitReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContainerHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskBatchSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8941,6 +11825,25 @@ func (m *ContainerHeartbeatRsp) Unmarshal(dAtA []byte) error {
 				m.Data = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -8966,7 +11869,7 @@ func (m *ContainerHeartbeatRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetContainerReq) Unmarshal(dAtA []byte) error {
+func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8989,17 +11892,17 @@ func (m *GetContainerReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetContainerReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskBatchSplitRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetContainerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskBatchSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitIDs", wireType)
 			}
-			m.ID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -9009,11 +11912,26 @@ func (m *GetContainerReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SplitIDs = append(m.SplitIDs, SplitID{})
+			if err := m.SplitIDs[len(m.SplitIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -9039,7 +11957,7 @@ func (m *GetContainerReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetContainerRsp) Unmarshal(dAtA []byte) error {
+func (m *BatchReportSplitReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9062,15 +11980,15 @@ func (m *GetContainerRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetContainerRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchReportSplitReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetContainerRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchReportSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -9097,46 +12015,8 @@ func (m *GetContainerRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Stats == nil {
-				m.Stats = &metapb.ContainerStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Resources = append(m.Resources, make([]byte, postIndex-iNdEx))
+			copy(m.Resources[len(m.Resources)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9163,7 +12043,7 @@ func (m *GetContainerRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
+func (m *BatchReportSplitRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9186,10 +12066,10 @@ func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AllocIDReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchReportSplitRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AllocIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchReportSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -9217,7 +12097,7 @@ func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
+func (m *SplitID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9240,17 +12120,17 @@ func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AllocIDRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AllocIDRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewID", wireType)
 			}
-			m.ID = 0
+			m.NewID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -9260,99 +12140,87 @@ func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				m.NewID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AskSplitReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AskSplitReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.NewPeerIDs = append(m.NewPeerIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.NewPeerIDs) == 0 {
+					m.NewPeerIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPeerIDs = append(m.NewPeerIDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIDs", wireType)
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -9378,7 +12246,7 @@ func (m *AskSplitReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskSplitRsp) Unmarshal(dAtA []byte) error {
+func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9401,17 +12269,17 @@ func (m *AskSplitRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskSplitRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateWatcherReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateWatcherReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
 			}
-			var msglen int
+			m.Flag = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -9421,25 +12289,11 @@ func (m *AskSplitRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Flag |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.SplitID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -9465,7 +12319,7 @@ func (m *AskSplitRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportSplitReq) Unmarshal(dAtA []byte) error {
+func (m *CreateResourcesReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9488,15 +12342,15 @@ func (m *ReportSplitReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportSplitReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateResourcesReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateResourcesReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -9523,16 +12377,90 @@ func (m *ReportSplitReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Left = append(m.Left[:0], dAtA[iNdEx:postIndex]...)
-			if m.Left == nil {
-				m.Left = []byte{}
-			}
+			m.Resources = append(m.Resources, make([]byte, postIndex-iNdEx))
+			copy(m.Resources[len(m.Resources)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.LeastPeers = append(m.LeastPeers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.LeastPeers) == 0 {
+					m.LeastPeers = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.LeastPeers = append(m.LeastPeers, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeastPeers", wireType)
 			}
-			var byteLen int
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scatter", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -9542,26 +12470,12 @@ func (m *ReportSplitReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Right = append(m.Right[:0], dAtA[iNdEx:postIndex]...)
-			if m.Right == nil {
-				m.Right = []byte{}
-			}
-			iNdEx = postIndex
+			m.Scatter = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -9587,7 +12501,7 @@ func (m *ReportSplitReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportSplitRsp) Unmarshal(dAtA []byte) error {
+func (m *CreateResourcesRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9610,10 +12524,10 @@ func (m *ReportSplitRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportSplitRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateResourcesRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateResourcesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -9641,7 +12555,7 @@ func (m *ReportSplitRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
+func (m *RemoveResourcesReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9664,64 +12578,87 @@ func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskBatchSplitReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveResourcesReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskBatchSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveResourcesReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				m.IDs = append(m.IDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
 				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
-			}
-			m.Count = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				elementCount = count
+				if elementCount != 0 && len(m.IDs) == 0 {
+					m.IDs = make([]uint64, 0, elementCount)
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Count |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.IDs = append(m.IDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
 		default:
 			iNdEx = preIndex
@@ -9748,7 +12685,7 @@ func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
+func (m *RemoveResourcesRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9771,46 +12708,12 @@ func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskBatchSplitRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveResourcesRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskBatchSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveResourcesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitIDs", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SplitIDs = append(m.SplitIDs, SplitID{})
-			if err := m.SplitIDs[len(m.SplitIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -9836,7 +12739,7 @@ func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BatchReportSplitReq) Unmarshal(dAtA []byte) error {
+func (m *CheckResourceStateReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9859,15 +12762,15 @@ func (m *BatchReportSplitReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BatchReportSplitReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckResourceStateReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchReportSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CheckResourceStateReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -9894,63 +12797,11 @@ func (m *BatchReportSplitReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Resources = append(m.Resources, make([]byte, postIndex-iNdEx))
-			copy(m.Resources[len(m.Resources)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *BatchReportSplitRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.IDs = append(m.IDs[:0], dAtA[iNdEx:postIndex]...)
+			if m.IDs == nil {
+				m.IDs = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BatchReportSplitRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchReportSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -9976,7 +12827,7 @@ func (m *BatchReportSplitRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SplitID) Unmarshal(dAtA []byte) error {
+func (m *CheckResourceStateRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9999,32 +12850,13 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckResourceStateRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CheckResourceStateRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewID", wireType)
-			}
-			m.NewID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NewID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType == 0 {
 				var v uint64
 				for shift := uint(0); ; shift += 7 {
@@ -10041,7 +12873,7 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 						break
 					}
 				}
-				m.NewPeerIDs = append(m.NewPeerIDs, v)
+				m.Removed = append(m.Removed, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -10076,8 +12908,8 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.NewPeerIDs) == 0 {
-					m.NewPeerIDs = make([]uint64, 0, elementCount)
+				if elementCount != 0 && len(m.Removed) == 0 {
+					m.Removed = make([]uint64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
 					var v uint64
@@ -10095,11 +12927,98 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 							break
 						}
 					}
-					m.NewPeerIDs = append(m.NewPeerIDs, v)
+					m.Removed = append(m.Removed, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutPlacementRuleReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutPlacementRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10125,7 +13044,7 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
+func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10148,31 +13067,12 @@ func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateWatcherReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutPlacementRuleRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateWatcherReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutPlacementRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
-			}
-			m.Flag = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Flag |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10198,7 +13098,7 @@ func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateResourcesReq) Unmarshal(dAtA []byte) error {
+func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10221,17 +13121,17 @@ func (m *CreateResourcesReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateResourcesReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAppliedRulesReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateResourcesReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAppliedRulesReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
 			}
-			var byteLen int
+			m.ResourceID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -10241,100 +13141,11 @@ func (m *CreateResourcesReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.ResourceID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Resources = append(m.Resources, make([]byte, postIndex-iNdEx))
-			copy(m.Resources[len(m.Resources)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.LeastPeers = append(m.LeastPeers, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.LeastPeers) == 0 {
-					m.LeastPeers = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.LeastPeers = append(m.LeastPeers, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeastPeers", wireType)
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10360,7 +13171,7 @@ func (m *CreateResourcesReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateResourcesRsp) Unmarshal(dAtA []byte) error {
+func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10383,12 +13194,46 @@ func (m *CreateResourcesRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateResourcesRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAppliedRulesRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateResourcesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAppliedRulesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rules = append(m.Rules, PlacementRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10414,7 +13259,7 @@ func (m *CreateResourcesRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveResourcesReq) Unmarshal(dAtA []byte) error {
+func (m *CreateJobReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10437,88 +13282,45 @@ func (m *RemoveResourcesReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveResourcesReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateJobReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveResourcesReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.IDs = append(m.IDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.IDs) == 0 {
-					m.IDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.IDs = append(m.IDs, v)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10544,7 +13346,7 @@ func (m *RemoveResourcesReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveResourcesRsp) Unmarshal(dAtA []byte) error {
+func (m *CreateJobRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10567,10 +13369,10 @@ func (m *RemoveResourcesRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveResourcesRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateJobRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveResourcesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -10598,7 +13400,7 @@ func (m *RemoveResourcesRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckResourceStateReq) Unmarshal(dAtA []byte) error {
+func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10621,17 +13423,17 @@ func (m *CheckResourceStateReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckResourceStateReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveJobReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckResourceStateReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -10641,24 +13443,23 @@ func (m *CheckResourceStateReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IDs = append(m.IDs[:0], dAtA[iNdEx:postIndex]...)
-			if m.IDs == nil {
-				m.IDs = []byte{}
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -10686,7 +13487,7 @@ func (m *CheckResourceStateReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckResourceStateRsp) Unmarshal(dAtA []byte) error {
+func (m *RemoveJobRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10709,88 +13510,133 @@ func (m *CheckResourceStateRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckResourceStateRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveJobRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckResourceStateRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecuteJobReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecuteJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				m.Removed = append(m.Removed, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Removed) == 0 {
-					m.Removed = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Removed = append(m.Removed, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10816,7 +13662,7 @@ func (m *CheckResourceStateRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
+func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10839,17 +13685,17 @@ func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutPlacementRuleReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExecuteJobRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutPlacementRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExecuteJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -10859,23 +13705,24 @@ func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -10903,7 +13750,7 @@ func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
+func (m *TransferLeaderReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10926,12 +13773,50 @@ func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutPlacementRuleRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLeaderReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutPlacementRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLeaderReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			m.ResourceID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResourceID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
+			}
+			m.ContainerID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContainerID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -10957,7 +13842,7 @@ func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
+func (m *TransferLeaderRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10980,31 +13865,12 @@ func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetAppliedRulesReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLeaderRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAppliedRulesReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLeaderRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
-			}
-			m.ResourceID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ResourceID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -11030,7 +13896,7 @@ func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
+func (m *EventNotify) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11053,15 +13919,53 @@ func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetAppliedRulesRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventNotify: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAppliedRulesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventNotify: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InitEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11088,68 +13992,88 @@ func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Rules = append(m.Rules, PlacementRule{})
-			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.InitEvent == nil {
+				m.InitEvent = &InitEventData{}
+			}
+			if err := m.InitEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceEvent", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateJobReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			if m.ResourceEvent == nil {
+				m.ResourceEvent = &ResourceEventData{}
 			}
-			if iNdEx >= l {
+			if err := m.ResourceEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.ContainerEvent == nil {
+				m.ContainerEvent = &ContainerEventData{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateJobReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.ContainerEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceStatsEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11176,64 +14100,125 @@ func (m *CreateJobReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ResourceStatsEvent == nil {
+				m.ResourceStatsEvent = &metapb.ResourceStats{}
+			}
+			if err := m.ResourceStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerStatsEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateJobRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			if m.ContainerStatsEvent == nil {
+				m.ContainerStatsEvent = &metapb.ContainerStats{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if err := m.ContainerStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			iNdEx = postIndex
+		case 8:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.WriteFrozenGroups = append(m.WriteFrozenGroups, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.WriteFrozenGroups) == 0 {
+					m.WriteFrozenGroups = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.WriteFrozenGroups = append(m.WriteFrozenGroups, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteFrozenGroups", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateJobRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -11259,7 +14244,7 @@ func (m *CreateJobRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
+func (m *InitEventData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11282,17 +14267,17 @@ func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveJobReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: InitEventData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InitEventData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11302,79 +14287,132 @@ func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Resources = append(m.Resources, make([]byte, postIndex-iNdEx))
+			copy(m.Resources[len(m.Resources)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Leaders = append(m.Leaders, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Leaders) == 0 {
+					m.Leaders = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Leaders = append(m.Leaders, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaders", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Containers", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RemoveJobRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveJobRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Containers = append(m.Containers, make([]byte, postIndex-iNdEx))
+			copy(m.Containers[len(m.Containers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -11400,7 +14438,7 @@ func (m *RemoveJobRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
+func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11423,17 +14461,17 @@ func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExecuteJobReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResourceEventData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExecuteJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResourceEventData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11443,30 +14481,31 @@ func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			var byteLen int
+			m.Leader = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11476,26 +14515,51 @@ func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Leader |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Removed = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Create", wireType)
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.Create = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -11521,7 +14585,7 @@ func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
+func (m *ContainerEventData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11544,10 +14608,10 @@ func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExecuteJobRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContainerEventData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExecuteJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContainerEventData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11609,7 +14673,7 @@ func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EventNotify) Unmarshal(dAtA []byte) error {
+func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11632,17 +14696,17 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EventNotify: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EventNotify: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
-			m.Seq = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11652,16 +14716,30 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Seq |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
 			}
-			m.Type = 0
+			m.ChangeType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11671,14 +14749,68 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= uint32(b&0x7F) << shift
+				m.ChangeType |= metapb.ChangePeerType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11705,16 +14837,67 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InitEvent == nil {
-				m.InitEvent = &InitEventData{}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if err := m.InitEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 4:
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangePeerV2) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangePeerV2: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangePeerV2: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11741,18 +14924,70 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ResourceEvent == nil {
-				m.ResourceEvent = &ResourceEventData{}
-			}
-			if err := m.ResourceEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Changes = append(m.Changes, ChangePeer{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Merge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Merge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Merge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11762,33 +14997,85 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ContainerEvent == nil {
-				m.ContainerEvent = &ContainerEventData{}
+			m.Target = append(m.Target[:0], dAtA[iNdEx:postIndex]...)
+			if m.Target == nil {
+				m.Target = []byte{}
 			}
-			if err := m.ContainerEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceStatsEvent", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			var msglen int
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitResource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitResource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitResource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			m.Policy = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11798,33 +15085,16 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Policy |= metapb.CheckPolicy(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ResourceStatsEvent == nil {
-				m.ResourceStatsEvent = &metapb.ResourceStats{}
-			}
-			if err := m.ResourceStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerStatsEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11834,27 +15104,23 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ContainerStatsEvent == nil {
-				m.ContainerStatsEvent = &metapb.ContainerStats{}
-			}
-			if err := m.ContainerStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11881,7 +15147,7 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InitEventData) Unmarshal(dAtA []byte) error {
+func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11904,17 +15170,17 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InitEventData: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelConstraint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InitEventData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -11924,105 +15190,48 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Resources = append(m.Resources, make([]byte, postIndex-iNdEx))
-			copy(m.Resources[len(m.Resources)-1], dAtA[iNdEx:postIndex])
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Leaders = append(m.Leaders, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Leaders) == 0 {
-					m.Leaders = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Leaders = append(m.Leaders, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= LabelConstraintOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leaders", wireType)
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Containers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12032,23 +15241,23 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Containers = append(m.Containers, make([]byte, postIndex-iNdEx))
-			copy(m.Containers[len(m.Containers)-1], dAtA[iNdEx:postIndex])
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12075,7 +15284,7 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
+func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12098,17 +15307,17 @@ func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResourceEventData: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementRule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResourceEventData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementRule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12118,31 +15327,29 @@ func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
 			}
-			m.Leader = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12152,16 +15359,29 @@ func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Leader |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var v int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12171,15 +15391,14 @@ func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Index |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Create", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Override", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -12196,66 +15415,118 @@ func (m *ResourceEventData) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Create = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			m.Override = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ContainerEventData) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.StartKey == nil {
+				m.StartKey = []byte{}
 			}
-			if iNdEx >= l {
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EndKey == nil {
+				m.EndKey = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ContainerEventData: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContainerEventData: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= PeerRoleType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelConstraints", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12265,85 +15536,31 @@ func (m *ContainerEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
+			m.LabelConstraints = append(m.LabelConstraints, LabelConstraint{})
+			if err := m.LabelConstraints[len(m.LabelConstraints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ChangePeer) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LocationLabels", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12353,30 +15570,29 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LocationLabels = append(m.LocationLabels, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsolationLevel", wireType)
 			}
-			m.ChangeType = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12386,11 +15602,24 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChangeType |= metapb.ChangePeerType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IsolationLevel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -12416,7 +15645,7 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeader) Unmarshal(dAtA []byte) error {
+func (m *AllocTimestampReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12439,17 +15668,17 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: AllocTimestampReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AllocTimestampReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var msglen int
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12459,25 +15688,11 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Count |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -12503,7 +15718,7 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangePeerV2) Unmarshal(dAtA []byte) error {
+func (m *AllocTimestampRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12526,17 +15741,17 @@ func (m *ChangePeerV2) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeerV2: wiretype end group for non-group")
+			return fmt.Errorf("proto: AllocTimestampRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeerV2: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AllocTimestampRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
-			var msglen int
+			m.Timestamp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12546,26 +15761,11 @@ func (m *ChangePeerV2) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Timestamp |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Changes = append(m.Changes, ChangePeer{})
-			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -12591,7 +15791,7 @@ func (m *ChangePeerV2) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Merge) Unmarshal(dAtA []byte) error {
+func (m *PutRateLimitReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12614,17 +15814,17 @@ func (m *Merge) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Merge: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutRateLimitReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Merge: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutRateLimitReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12634,24 +15834,23 @@ func (m *Merge) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Target = append(m.Target[:0], dAtA[iNdEx:postIndex]...)
-			if m.Target == nil {
-				m.Target = []byte{}
+			if err := m.Limit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -12679,7 +15878,7 @@ func (m *Merge) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SplitResource) Unmarshal(dAtA []byte) error {
+func (m *GetRateLimitsRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12702,36 +15901,17 @@ func (m *SplitResource) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SplitResource: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRateLimitsRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitResource: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRateLimitsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
-			}
-			m.Policy = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Policy |= metapb.CheckPolicy(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12741,23 +15921,25 @@ func (m *SplitResource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
-			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
+			m.Limits = append(m.Limits, metapb.RateLimit{})
+			if err := m.Limits[len(m.Limits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12784,72 +15966,41 @@ func (m *SplitResource) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
+
+func (m *SetWriteFreezeReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
 		preIndex := iNdEx
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelConstraint: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetWriteFreezeReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetWriteFreezeReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			m.Op = 0
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12859,16 +16010,16 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Op |= LabelConstraintOp(b&0x7F) << shift
+				m.Group |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Frozen", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -12878,24 +16029,12 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
+			m.Frozen = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -12921,7 +16060,7 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementRule) Unmarshal(dAtA []byte) error {
+func (m *GetWriteFrozenGroupsRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12944,81 +16083,224 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementRule: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetWriteFrozenGroupsRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementRule: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetWriteFrozenGroupsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.Groups = append(m.Groups, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
+				elementCount = count
+				if elementCount != 0 && len(m.Groups) == 0 {
+					m.Groups = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Groups = append(m.Groups, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.PendingContainers = append(m.PendingContainers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.PendingContainers) == 0 {
+					m.PendingContainers = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.PendingContainers = append(m.PendingContainers, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingContainers", wireType)
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *DecommissionContainerReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GroupID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DecommissionContainerReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DecommissionContainerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
 			}
-			m.Index = 0
+			m.ContainerID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13028,16 +16310,16 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint32(b&0x7F) << shift
+				m.ContainerID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Override", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rate", wireType)
 			}
-			var v int
+			m.Rate = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13047,51 +16329,70 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Rate |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Override = bool(v != 0)
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.StartKey == nil {
-				m.StartKey = []byte{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetDecommissionProgressReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var byteLen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetDecommissionProgressReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetDecommissionProgressReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerID", wireType)
+			}
+			m.ContainerID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13101,69 +16402,70 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.ContainerID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EndKey == nil {
-				m.EndKey = []byte{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetDecommissionProgressRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Role = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Role |= PeerRoleType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetDecommissionProgressRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetDecommissionProgressRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
-			}
-			m.Count = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Count |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelConstraints", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var msglen int
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13173,31 +16475,16 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.State |= metapb.ContainerState(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LabelConstraints = append(m.LabelConstraints, LabelConstraint{})
-			if err := m.LabelConstraints[len(m.LabelConstraints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LocationLabels", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceCount", wireType)
 			}
-			var stringLen uint64
+			m.ResourceCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13207,29 +16494,16 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ResourceCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LocationLabels = append(m.LocationLabels, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsolationLevel", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderCount", wireType)
 			}
-			var stringLen uint64
+			m.LeaderCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13239,24 +16513,11 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.LeaderCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.IsolationLevel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -13282,6 +16543,7 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
 func skipRpcpb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
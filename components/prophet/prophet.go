@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"net/http"
 	"sync"
 	"time"
 
@@ -32,7 +33,9 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/hbstream"
 	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/tso"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/embed"
@@ -86,12 +89,20 @@ type defaultProphet struct {
 	basicCluster *core.BasicCluster
 	cluster      *cluster.RaftCluster
 
+	// timestamp oracle
+	tso       *tso.Allocator
+	tsoCancel context.CancelFunc
+
 	// rpc
 	hbStreams  *hbstream.HeartbeatStreams
 	trans      goetty.NetApplication
+	tls        *tlsutil.TLS
 	client     Client
 	clientOnce sync.Once
 
+	// http admin api
+	httpServer *http.Server
+
 	// job task ctx
 	jobMu struct {
 		sync.RWMutex
@@ -131,8 +142,14 @@ func NewProphet(cfg *config.Config) Prophet {
 		util.GetLogger().Fatalf("create elector failed with %+v", err)
 	}
 
+	tls, err := tlsutil.New(cfg.TLS)
+	if err != nil {
+		util.GetLogger().Fatalf("create tls failed with %+v", err)
+	}
+
 	p := &defaultProphet{}
 	p.cfg = cfg
+	p.tls = tls
 	p.persistOptions = config.NewPersistOptions(cfg)
 	p.ctx = ctx
 	p.cancel = cancel
@@ -154,12 +171,14 @@ func (p *defaultProphet) Start() {
 	p.storage = storage.NewStorage(rootPath,
 		storage.NewEtcdKV(rootPath, p.elector.Client(), p.member.GetLeadership()),
 		p.cfg.Adapter)
+	p.tso = tso.NewAllocator(p.storage)
 	p.basicCluster = core.NewBasicCluster(p.cfg.Adapter.NewResource)
 	p.cluster = cluster.NewRaftCluster(p.ctx, rootPath, p.clusterID, p.elector.Client(), p.cfg.Adapter, p.cfg.ResourceStateChangedHandler)
 	p.hbStreams = hbstream.NewHeartbeatStreams(p.ctx, p.clusterID, p.cluster)
 
 	p.startSystemMonitor(context.Background())
 	p.startListen()
+	p.startHTTPServer()
 	p.startLeaderLoop()
 }
 
@@ -168,6 +187,7 @@ func (p *defaultProphet) Stop() {
 		p.client.Close()
 	}
 	p.trans.Stop()
+	p.stopHTTPServer()
 	p.runner.Stop()
 	p.member.Stop()
 	p.cancel()
@@ -175,6 +195,7 @@ func (p *defaultProphet) Stop() {
 	if p.etcd != nil {
 		p.etcd.Close()
 	}
+	p.tls.Close()
 }
 
 func (p *defaultProphet) GetStorage() storage.Storage {
@@ -175,6 +175,54 @@ func (p *defaultProphet) handleRPCRequest(rs goetty.IOSession, data interface{},
 		if err != nil {
 			resp.Error = err.Error()
 		}
+	case rpcpb.TypeTransferLeaderReq:
+		resp.Type = rpcpb.TypeTransferLeaderRsp
+		err := p.handleTransferLeader(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeAllocTimestampReq:
+		resp.Type = rpcpb.TypeAllocTimestampRsp
+		err := p.handleAllocTimestamp(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypePutRateLimitReq:
+		resp.Type = rpcpb.TypePutRateLimitRsp
+		err := p.handlePutRateLimit(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeGetRateLimitsReq:
+		resp.Type = rpcpb.TypeGetRateLimitsRsp
+		err := p.handleGetRateLimits(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeSetWriteFreezeReq:
+		resp.Type = rpcpb.TypeSetWriteFreezeRsp
+		err := p.handleSetWriteFreeze(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeGetWriteFrozenGroupsReq:
+		resp.Type = rpcpb.TypeGetWriteFrozenGroupsRsp
+		err := p.handleGetWriteFrozenGroups(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeDecommissionContainerReq:
+		resp.Type = rpcpb.TypeDecommissionContainerRsp
+		err := p.handleDecommissionContainer(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeGetDecommissionProgressReq:
+		resp.Type = rpcpb.TypeGetDecommissionProgressRsp
+		err := p.handleGetDecommissionProgress(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
 	default:
 		return fmt.Errorf("type %s not support", req.Type.String())
 	}
@@ -248,6 +296,7 @@ func (p *defaultProphet) handleContainerHeartbeat(rc *cluster.RaftCluster, req *
 	if err != nil {
 		return err
 	}
+	rc.HandleContainerWriteFrozenGroups(req.ContainerHeartbeat.Stats.ContainerID, req.ContainerHeartbeat.WriteFrozenGroups)
 
 	if p.cfg.ContainerHeartbeatDataProcessor != nil {
 		data, err := p.cfg.ContainerHeartbeatDataProcessor.HandleHeartbeatReq(req.ContainerHeartbeat.Stats.ContainerID,
@@ -258,6 +307,8 @@ func (p *defaultProphet) handleContainerHeartbeat(rc *cluster.RaftCluster, req *
 		resp.ContainerHeartbeat.Data = data
 	}
 
+	resp.ContainerHeartbeat.RateLimits = rc.GetRateLimits()
+	resp.ContainerHeartbeat.WriteFrozenGroups = rc.GetWriteFrozenGroups()
 	return nil
 }
 
@@ -369,6 +420,68 @@ func (p *defaultProphet) handleGetAppliedRule(rc *cluster.RaftCluster, req *rpcp
 	return nil
 }
 
+func (p *defaultProphet) handleTransferLeader(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	rsp, err := rc.HandleTransferLeader(req)
+	if err != nil {
+		return err
+	}
+
+	resp.TransferLeader = *rsp
+	return nil
+}
+
+func (p *defaultProphet) handleAllocTimestamp(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	ts, err := p.tso.Generate(req.AllocTimestamp.Count)
+	if err != nil {
+		return err
+	}
+
+	resp.AllocTimestamp.Timestamp = ts
+	return nil
+}
+
+func (p *defaultProphet) handlePutRateLimit(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	return rc.HandlePutRateLimit(req)
+}
+
+func (p *defaultProphet) handleGetRateLimits(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	rsp, err := rc.HandleGetRateLimits(req)
+	if err != nil {
+		return err
+	}
+
+	resp.GetRateLimits = *rsp
+	return nil
+}
+
+func (p *defaultProphet) handleSetWriteFreeze(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	return rc.HandleSetWriteFreeze(req)
+}
+
+func (p *defaultProphet) handleGetWriteFrozenGroups(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	rsp, err := rc.HandleGetWriteFrozenGroups(req)
+	if err != nil {
+		return err
+	}
+
+	resp.GetWriteFrozenGroups = *rsp
+	return nil
+}
+
+func (p *defaultProphet) handleDecommissionContainer(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	return rc.HandleDecommissionContainer(req)
+}
+
+func (p *defaultProphet) handleGetDecommissionProgress(rc *cluster.RaftCluster, req *rpcpb.Request, resp *rpcpb.Response) error {
+	rsp, err := rc.HandleGetDecommissionProgress(req)
+	if err != nil {
+		return err
+	}
+
+	resp.GetDecommissionProgress = *rsp
+	return nil
+}
+
 // checkContainer returns an error response if the store exists and is in tombstone state.
 // It returns nil if it can't get the store.
 func checkContainer(rc *cluster.RaftCluster, storeID uint64) error {
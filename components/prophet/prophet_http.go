@@ -0,0 +1,459 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prophet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/cluster"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/placement"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+)
+
+const (
+	// HTTPAPIPrefix the prefix of the http admin api
+	HTTPAPIPrefix = "/prophet/api/v1"
+)
+
+// ClusterInfo the cluster info of the http admin api
+type ClusterInfo struct {
+	ID         uint64 `json:"id"`
+	Leader     string `json:"leader"`
+	LeaderAddr string `json:"leader_addr"`
+	Containers int    `json:"containers"`
+	Resources  int    `json:"resources"`
+}
+
+// ContainerInfo the container info of the http admin api
+type ContainerInfo struct {
+	ID            uint64        `json:"id"`
+	Addr          string        `json:"addr"`
+	ShardAddr     string        `json:"shard_addr"`
+	Labels        []metapb.Pair `json:"labels,omitempty"`
+	State         string        `json:"state"`
+	Version       string        `json:"version"`
+	GitHash       string        `json:"git_hash"`
+	LastHeartbeat time.Time     `json:"last_heartbeat"`
+	ResourceCount int           `json:"resource_count"`
+	LeaderCount   int           `json:"leader_count"`
+}
+
+// ResourceInfo the resource info of the http admin api
+type ResourceInfo struct {
+	ID              uint64               `json:"id"`
+	Group           uint64               `json:"group"`
+	StartKey        string               `json:"start_key"`
+	EndKey          string               `json:"end_key"`
+	Epoch           metapb.ResourceEpoch `json:"epoch"`
+	State           string               `json:"state"`
+	Peers           []metapb.Peer        `json:"peers"`
+	Leader          *metapb.Peer         `json:"leader,omitempty"`
+	DownPeers       []metapb.PeerStats   `json:"down_peers,omitempty"`
+	PendingPeers    []metapb.Peer        `json:"pending_peers,omitempty"`
+	ApproximateSize int64                `json:"approximate_size"`
+	ApproximateKeys int64                `json:"approximate_keys"`
+}
+
+// LeaderInfo the leader of the resource of the http admin api
+type LeaderInfo struct {
+	ResourceID uint64      `json:"resource_id"`
+	Leader     metapb.Peer `json:"leader"`
+}
+
+// OperatorInfo the pending operator info of the http admin api
+type OperatorInfo struct {
+	ResourceID uint64    `json:"resource_id"`
+	Desc       string    `json:"desc"`
+	Kind       string    `json:"kind"`
+	Status     string    `json:"status"`
+	CreateAt   time.Time `json:"create_at"`
+	Detail     string    `json:"detail"`
+}
+
+// SchedulerInfo the scheduler info of the http admin api
+type SchedulerInfo struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+// AddSchedulerRequest the request to add a scheduler by the http admin api
+type AddSchedulerRequest struct {
+	Type string   `json:"type"`
+	Args []string `json:"args,omitempty"`
+}
+
+// PauseSchedulerRequest the request to pause or resume a scheduler by the http admin api,
+// the scheduler will be paused in delay seconds, 0 means resume the scheduler.
+type PauseSchedulerRequest struct {
+	Delay int64 `json:"delay"`
+}
+
+// HTTPError the error response of the http admin api
+type HTTPError struct {
+	Error string `json:"error"`
+}
+
+func (p *defaultProphet) startHTTPServer() {
+	if p.cfg.HTTPAddr == "" {
+		return
+	}
+
+	l, err := net.Listen("tcp", p.cfg.HTTPAddr)
+	if err != nil {
+		util.GetLogger().Fatalf("start http server failed with %+v", err)
+	}
+	// the http admin api serves by https with the tls config of the rpc if it's enabled
+	l = p.tls.NewListener(l)
+
+	p.httpServer = &http.Server{Handler: p.newHTTPHandler()}
+	go func() {
+		if err := p.httpServer.Serve(l); err != nil && err != http.ErrServerClosed {
+			util.GetLogger().Errorf("http server stopped with %+v", err)
+		}
+	}()
+	util.GetLogger().Infof("http admin api serves at %s, tls=<%t>", p.cfg.HTTPAddr, p.tls != nil)
+}
+
+func (p *defaultProphet) stopHTTPServer() {
+	if p.httpServer != nil {
+		p.httpServer.Close()
+	}
+}
+
+func (p *defaultProphet) newHTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HTTPAPIPrefix+"/cluster", p.leaderHandler(p.handleHTTPGetCluster, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/containers", p.leaderHandler(p.handleHTTPGetContainers, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/containers/", p.leaderHandler(p.handleHTTPGetContainer, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/resources", p.leaderHandler(p.handleHTTPGetResources, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/resources/", p.leaderHandler(p.handleHTTPGetResource, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/leaders", p.leaderHandler(p.handleHTTPGetLeaders, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/operators", p.leaderHandler(p.handleHTTPGetOperators, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/rules", p.leaderHandler(p.handleHTTPGetRules, http.MethodGet))
+	mux.HandleFunc(HTTPAPIPrefix+"/schedulers", p.leaderHandler(p.handleHTTPSchedulers, http.MethodGet, http.MethodPost))
+	mux.HandleFunc(HTTPAPIPrefix+"/schedulers/", p.leaderHandler(p.handleHTTPScheduler, http.MethodPost, http.MethodDelete))
+	mux.HandleFunc(HTTPAPIPrefix+"/scheduler-config/", p.leaderHandler(p.handleHTTPSchedulerConfig))
+	return mux
+}
+
+// leaderHandler returns a http handler which only serves on the prophet leader with the allowed methods,
+// all methods are allowed if no method specified.
+func (p *defaultProphet) leaderHandler(fn func(*cluster.RaftCluster, http.ResponseWriter, *http.Request), methods ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(methods) > 0 {
+			allowed := false
+			for _, method := range methods {
+				if r.Method == method {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				writeHTTPError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+				return
+			}
+		}
+
+		rc := p.GetRaftCluster()
+		if rc == nil || !p.member.IsLeader() {
+			writeHTTPError(w, http.StatusServiceUnavailable, fmt.Errorf("%s, current leader is %+v",
+				util.ErrNotLeader,
+				p.member.GetLeader()))
+			return
+		}
+
+		fn(rc, w, r)
+	}
+}
+
+func (p *defaultProphet) handleHTTPGetCluster(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	info := ClusterInfo{
+		ID:         p.clusterID,
+		Containers: rc.GetContainerCount(),
+		Resources:  rc.GetResourceCount(),
+	}
+	if leader := p.member.GetLeader(); leader != nil {
+		info.Leader = leader.Name
+		info.LeaderAddr = leader.Addr
+	}
+	writeHTTPJSON(w, http.StatusOK, info)
+}
+
+func (p *defaultProphet) handleHTTPGetContainers(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	containers := rc.GetContainers()
+	infos := make([]ContainerInfo, 0, len(containers))
+	for _, c := range containers {
+		infos = append(infos, newContainerInfo(rc, c))
+	}
+	writeHTTPJSON(w, http.StatusOK, infos)
+}
+
+func (p *defaultProphet) handleHTTPGetContainer(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	id, err := parseHTTPPathID(r.URL.Path, HTTPAPIPrefix+"/containers/")
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	c := rc.GetContainer(id)
+	if c == nil {
+		writeHTTPError(w, http.StatusNotFound, fmt.Errorf("container %d not found", id))
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newContainerInfo(rc, c))
+}
+
+func (p *defaultProphet) handleHTTPGetResources(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	var resources []*core.CachedResource
+	if value := r.URL.Query().Get("container"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+		resources = rc.GetContainerResources(id)
+	} else {
+		resources = rc.GetResources()
+	}
+
+	infos := make([]ResourceInfo, 0, len(resources))
+	for _, res := range resources {
+		infos = append(infos, newResourceInfo(res))
+	}
+	writeHTTPJSON(w, http.StatusOK, infos)
+}
+
+func (p *defaultProphet) handleHTTPGetResource(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	id, err := parseHTTPPathID(r.URL.Path, HTTPAPIPrefix+"/resources/")
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res := rc.GetResource(id)
+	if res == nil {
+		writeHTTPError(w, http.StatusNotFound, fmt.Errorf("resource %d not found", id))
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newResourceInfo(res))
+}
+
+func (p *defaultProphet) handleHTTPGetLeaders(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	var leaders []LeaderInfo
+	for _, res := range rc.GetResources() {
+		if leader := res.GetLeader(); leader != nil && leader.ID > 0 {
+			leaders = append(leaders, LeaderInfo{ResourceID: res.Meta.ID(), Leader: *leader})
+		}
+	}
+	writeHTTPJSON(w, http.StatusOK, leaders)
+}
+
+func (p *defaultProphet) handleHTTPGetOperators(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	ops := rc.GetOperatorController().GetOperators()
+	infos := make([]OperatorInfo, 0, len(ops))
+	for _, op := range ops {
+		infos = append(infos, OperatorInfo{
+			ResourceID: op.ResourceID(),
+			Desc:       op.Desc(),
+			Kind:       op.Kind().String(),
+			Status:     operator.OpStatusToString(op.Status()),
+			CreateAt:   op.GetCreateTime(),
+			Detail:     op.String(),
+		})
+	}
+	writeHTTPJSON(w, http.StatusOK, infos)
+}
+
+func (p *defaultProphet) handleHTTPGetRules(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	var rules []*placement.Rule
+	if group := r.URL.Query().Get("group"); group != "" {
+		rules = rc.GetRuleManager().GetRulesByGroup(group)
+	} else {
+		rules = rc.GetRuleManager().GetAllRules()
+	}
+	writeHTTPJSON(w, http.StatusOK, rules)
+}
+
+func (p *defaultProphet) handleHTTPSchedulers(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		names := rc.GetSchedulers()
+		infos := make([]SchedulerInfo, 0, len(names))
+		for _, name := range names {
+			paused, err := rc.IsSchedulerPaused(name)
+			if err != nil {
+				writeHTTPError(w, http.StatusInternalServerError, err)
+				return
+			}
+			infos = append(infos, SchedulerInfo{Name: name, Paused: paused})
+		}
+		writeHTTPJSON(w, http.StatusOK, infos)
+		return
+	}
+
+	req := AddSchedulerRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s, err := schedule.CreateScheduler(req.Type, rc.GetOperatorController(), rc.GetStorage(), schedule.ConfigSliceDecoder(req.Type, req.Args))
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := rc.AddScheduler(s, req.Args...); err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := rc.GetOpts().Persist(rc.GetStorage()); err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	util.GetLogger().Infof("scheduler %s added by http admin api, args %+v",
+		s.GetName(),
+		req.Args)
+	writeHTTPJSON(w, http.StatusOK, SchedulerInfo{Name: s.GetName()})
+}
+
+func (p *defaultProphet) handleHTTPScheduler(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, HTTPAPIPrefix+"/schedulers/")
+	if name == "" {
+		writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("missing scheduler name"))
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := rc.RemoveScheduler(name); err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		util.GetLogger().Infof("scheduler %s removed by http admin api", name)
+		writeHTTPJSON(w, http.StatusOK, SchedulerInfo{Name: name})
+		return
+	}
+
+	req := PauseSchedulerRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := rc.PauseOrResumeScheduler(name, req.Delay); err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	util.GetLogger().Infof("scheduler %s paused by http admin api, delay %d seconds",
+		name,
+		req.Delay)
+	writeHTTPJSON(w, http.StatusOK, SchedulerInfo{Name: name, Paused: req.Delay > 0})
+}
+
+func (p *defaultProphet) handleHTTPSchedulerConfig(rc *cluster.RaftCluster, w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, HTTPAPIPrefix+"/scheduler-config/")
+	name := strings.SplitN(path, "/", 2)[0]
+
+	h, ok := rc.GetSchedulerHandlers()[name]
+	if !ok {
+		writeHTTPError(w, http.StatusNotFound, fmt.Errorf("scheduler %s not found", name))
+		return
+	}
+	http.StripPrefix(HTTPAPIPrefix+"/scheduler-config/"+name, h).ServeHTTP(w, r)
+}
+
+func newContainerInfo(rc *cluster.RaftCluster, c *core.CachedContainer) ContainerInfo {
+	version, githash := c.Meta.Version()
+	info := ContainerInfo{
+		ID:            c.Meta.ID(),
+		Addr:          c.Meta.Addr(),
+		ShardAddr:     c.Meta.ShardAddr(),
+		Labels:        c.Meta.Labels(),
+		State:         c.GetState().String(),
+		Version:       version,
+		GitHash:       githash,
+		LastHeartbeat: c.GetLastHeartbeatTS(),
+	}
+
+	for _, res := range rc.GetContainerResources(info.ID) {
+		info.ResourceCount++
+		if leader := res.GetLeader(); leader != nil && leader.ContainerID == info.ID {
+			info.LeaderCount++
+		}
+	}
+	return info
+}
+
+func newResourceInfo(res *core.CachedResource) ResourceInfo {
+	start, end := res.Meta.Range()
+	info := ResourceInfo{
+		ID:              res.Meta.ID(),
+		Group:           res.Meta.Group(),
+		StartKey:        hex.EncodeToString(start),
+		EndKey:          hex.EncodeToString(end),
+		Epoch:           res.Meta.Epoch(),
+		State:           res.Meta.State().String(),
+		Peers:           res.Meta.Peers(),
+		DownPeers:       res.GetDownPeers(),
+		PendingPeers:    res.GetPendingPeers(),
+		ApproximateSize: res.GetApproximateSize(),
+		ApproximateKeys: res.GetApproximateKeys(),
+	}
+	if leader := res.GetLeader(); leader != nil && leader.ID > 0 {
+		info.Leader = leader
+	}
+	return info
+}
+
+func parseHTTPPathID(path, prefix string) (uint64, error) {
+	value := strings.TrimPrefix(path, prefix)
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %s", value)
+	}
+	return id, nil
+}
+
+func writeHTTPJSON(w http.ResponseWriter, code int, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func writeHTTPError(w http.ResponseWriter, code int, err error) {
+	data, _ := json.Marshal(HTTPError{Error: err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prophet
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPAdminAPI(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.PutContainer(newTestContainerMeta(1)))
+	_, err := c.ContainerHeartbeat(newTestContainerHeartbeat(1, 1))
+	assert.NoError(t, err)
+
+	peer := metapb.Peer{ID: 1, ContainerID: 1}
+	assert.NoError(t, c.ResourceHeartbeat(newTestResourceMeta(2, peer), rpcpb.ResourceHeartbeatReq{
+		ContainerID: 1,
+		Leader:      &peer}))
+	for p.(*defaultProphet).GetRaftCluster().GetResource(2) == nil {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	h := p.(*defaultProphet).newHTTPHandler()
+	do := func(method, path string, body interface{}, value interface{}) int {
+		var data []byte
+		if body != nil {
+			data, err = json.Marshal(body)
+			assert.NoError(t, err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, HTTPAPIPrefix+path, bytes.NewReader(data)))
+		if value != nil && w.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), value))
+		}
+		return w.Code
+	}
+
+	cluster := ClusterInfo{}
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/cluster", nil, &cluster))
+	assert.Equal(t, p.GetClusterID(), cluster.ID)
+	assert.Equal(t, 1, cluster.Containers)
+	assert.Equal(t, 1, cluster.Resources)
+
+	var containers []ContainerInfo
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/containers", nil, &containers))
+	assert.Equal(t, 1, len(containers))
+	assert.Equal(t, uint64(1), containers[0].ID)
+	assert.Equal(t, 1, containers[0].LeaderCount)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/containers/2", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/containers/abc", nil, nil))
+
+	var resources []ResourceInfo
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/resources?container=1", nil, &resources))
+	assert.Equal(t, 1, len(resources))
+	assert.Equal(t, uint64(2), resources[0].ID)
+	res := ResourceInfo{}
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/resources/2", nil, &res))
+	assert.Equal(t, peer, *res.Leader)
+
+	var leaders []LeaderInfo
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/leaders", nil, &leaders))
+	assert.Equal(t, []LeaderInfo{{ResourceID: 2, Leader: peer}}, leaders)
+
+	var operators []OperatorInfo
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/operators", nil, &operators))
+
+	var rules []json.RawMessage
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/rules", nil, &rules))
+	assert.Equal(t, 1, len(rules))
+
+	var schedulers []SchedulerInfo
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/schedulers", nil, &schedulers))
+	n := len(schedulers)
+
+	s := SchedulerInfo{}
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/schedulers", AddSchedulerRequest{Type: "shuffle-leader"}, &s))
+	assert.Equal(t, "shuffle-leader-scheduler", s.Name)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/schedulers", AddSchedulerRequest{Type: "unknown"}, nil))
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/schedulers/"+s.Name, PauseSchedulerRequest{Delay: 60}, nil))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/schedulers", nil, &schedulers))
+	assert.Equal(t, n+1, len(schedulers))
+	for _, v := range schedulers {
+		assert.Equal(t, v.Name == s.Name, v.Paused)
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/schedulers/"+s.Name, nil, nil))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/schedulers", nil, &schedulers))
+	assert.Equal(t, n, len(schedulers))
+	assert.Equal(t, http.StatusMethodNotAllowed, do(http.MethodPut, "/schedulers", nil, nil))
+}
@@ -14,6 +14,8 @@
 package prophet
 
 import (
+	"context"
+
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
 )
 
@@ -32,6 +34,7 @@ func (p *defaultProphet) enableLeader() error {
 
 	p.initClient()
 	p.createEventNotifer()
+	p.startTSO()
 	p.notifyElectionComplete()
 	p.startJobs()
 	p.startCustom()
@@ -45,6 +48,7 @@ func (p *defaultProphet) disableLeader() error {
 	p.initClient()
 	p.stopRaftCluster()
 	p.stopEventNotifer()
+	p.stopTSO()
 	p.notifyElectionComplete()
 	p.stopJobs()
 	p.stopCustom()
@@ -82,10 +86,32 @@ func (p *defaultProphet) stopEventNotifer() {
 	}
 }
 
+func (p *defaultProphet) startTSO() {
+	p.stopTSO()
+	ctx, cancel := context.WithCancel(p.ctx)
+	stoppedC := make(chan struct{})
+	p.tsoCancel = func() {
+		cancel()
+		<-stoppedC
+	}
+	go func() {
+		defer close(stoppedC)
+		p.tso.Run(ctx)
+	}()
+}
+
+func (p *defaultProphet) stopTSO() {
+	if p.tsoCancel != nil {
+		p.tsoCancel()
+		p.tsoCancel = nil
+	}
+}
+
 func (p *defaultProphet) initClient() {
 	p.clientOnce.Do(func() {
 		p.client = NewClient(p.cfg.Adapter,
 			WithRPCTimeout(p.cfg.RPCTimeout.Duration),
-			WithLeaderGetter(p.GetLeader))
+			WithLeaderGetter(p.GetLeader),
+			WithTLS(p.tls))
 	})
 }
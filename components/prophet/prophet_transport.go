@@ -23,7 +23,7 @@ import (
 
 func (p *defaultProphet) startListen() {
 	encoder, decoder := codec.NewServerCodec(10 * buf.MB)
-	app, err := goetty.NewTCPApplication(p.cfg.RPCAddr,
+	app, err := p.tls.NewApplication(p.cfg.RPCAddr,
 		p.handleRPCRequest,
 		goetty.WithAppSessionOptions(goetty.WithCodec(encoder, decoder),
 			goetty.WithEnableAsyncWrite(16),
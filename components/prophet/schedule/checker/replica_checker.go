@@ -19,7 +19,9 @@ import (
 
 	"github.com/matrixorigin/matrixcube/components/prophet/config"
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/filter"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
@@ -169,6 +171,10 @@ func (r *ReplicaChecker) checkOfflinePeer(res *core.CachedResource) *operator.Op
 			continue
 		}
 
+		if op := transferLeaderFromOfflineContainer(replicaCheckerName, r.cluster, res, containerID); op != nil {
+			checkerCounter.WithLabelValues("replica_checker", "transfer-offline-leader").Inc()
+			return op
+		}
 		return r.fixPeer(res, containerID, offlineStatus)
 	}
 
@@ -194,7 +200,7 @@ func (r *ReplicaChecker) checkMakeUpReplica(res *core.CachedResource) *operator.
 		r.resourceWaitingList.Put(res.Meta.ID(), nil)
 		return nil
 	}
-	newPeer := metapb.Peer{ContainerID: target}
+	newPeer := metapb.Peer{ContainerID: target, Role: r.makeUpPeerRole(res)}
 	op, err := operator.CreateAddPeerOperator("make-up-replica", r.cluster, res, newPeer, operator.OpReplica)
 	if err != nil {
 		util.GetLogger().Debugf("create make-up-replica operator failed with %+v", err)
@@ -250,7 +256,8 @@ func (r *ReplicaChecker) checkLocationReplacement(res *core.CachedResource) *ope
 		return nil
 	}
 
-	newPeer := metapb.Peer{ContainerID: newContainer}
+	old, _ := res.GetContainerPeer(oldContainer)
+	newPeer := metapb.Peer{ContainerID: newContainer, Role: replacePeerRole(old)}
 	op, err := operator.CreateMovePeerOperator("move-to-better-location", r.cluster, res, operator.OpReplica, oldContainer, newPeer)
 	if err != nil {
 		checkerCounter.WithLabelValues("replica_checker", "create-operator-fail").Inc()
@@ -282,7 +289,8 @@ func (r *ReplicaChecker) fixPeer(res *core.CachedResource, containerID uint64, s
 			res.Meta.ID())
 		return nil
 	}
-	newPeer := metapb.Peer{ContainerID: target}
+	old, _ := res.GetContainerPeer(containerID)
+	newPeer := metapb.Peer{ContainerID: target, Role: replacePeerRole(old)}
 	replace := fmt.Sprintf("replace-%s-replica", status)
 	op, err := operator.CreateMovePeerOperator(replace, r.cluster, res, operator.OpReplica, containerID, newPeer)
 	if err != nil {
@@ -293,6 +301,63 @@ func (r *ReplicaChecker) fixPeer(res *core.CachedResource, containerID uint64, s
 	return op
 }
 
+// transferLeaderFromOfflineContainer returns an operator to transfer the leader to a follower if
+// the leader of the resource is on the offline container, so the leaders are drained from the
+// container before the peers are moved away. Returns nil if there is no suitable follower.
+func transferLeaderFromOfflineContainer(scope string, cluster opt.Cluster, res *core.CachedResource, containerID uint64) *operator.Operator {
+	if res.GetLeader().GetContainerID() != containerID {
+		return nil
+	}
+	source := cluster.GetContainer(containerID)
+	if source == nil {
+		return nil
+	}
+
+	filters := []filter.Filter{&filter.ContainerStateFilter{ActionScope: scope, TransferLeader: true}}
+	if leaderFilter := filter.NewPlacementLeaderSafeguard(scope, cluster, res, source, cluster.GetResourceFactory()); leaderFilter != nil {
+		filters = append(filters, leaderFilter)
+	}
+	target := filter.NewCandidates(cluster.GetFollowerContainers(res)).
+		FilterTarget(cluster.GetOpts(), filters...).
+		RandomPick()
+	if target == nil {
+		return nil
+	}
+
+	op, err := operator.CreateTransferLeaderOperator("transfer-offline-leader", cluster, res, containerID, target.Meta.ID(), operator.OpLeader)
+	if err != nil {
+		util.GetLogger().Debugf("resource %d create transfer offline leader operator failed with %+v",
+			res.Meta.ID(),
+			err)
+		return nil
+	}
+	return op
+}
+
+// makeUpPeerRole returns the role of the peer to make up, the voters are added first
+// until the resource has enough voters to hold the data, and then the witnesses.
+func (r *ReplicaChecker) makeUpPeerRole(res *core.CachedResource) metapb.PeerRole {
+	voters := 0
+	for _, peer := range res.Meta.Peers() {
+		if !metadata.IsWitness(peer) {
+			voters++
+		}
+	}
+	if voters < r.opts.GetMaxReplicas()-r.opts.GetWitnessReplicas() {
+		return metapb.PeerRole_Voter
+	}
+	return metapb.PeerRole_Witness
+}
+
+// replacePeerRole returns the role of the peer which replaces the old one, the
+// witness can only be replaced by a witness.
+func replacePeerRole(old metapb.Peer) metapb.PeerRole {
+	if metadata.IsWitness(old) {
+		return metapb.PeerRole_Witness
+	}
+	return metapb.PeerRole_Voter
+}
+
 func (r *ReplicaChecker) strategy(res *core.CachedResource) *ReplicaStrategy {
 	return &ReplicaStrategy{
 		checkerName:    replicaCheckerName,
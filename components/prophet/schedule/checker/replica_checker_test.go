@@ -163,12 +163,19 @@ func TestReplaceOfflinePeer(t *testing.T) {
 	}
 	r := core.NewCachedResource(&metadata.TestResource{ResID: 2, ResPeers: peers}, &peers[0])
 	s.cluster.PutResource(r)
+	// the leader is transferred away from the offline container first
 	op := s.rc.Check(r)
 	assert.NotNil(t, op)
+	assert.Equal(t, 1, op.Len())
 	assert.Equal(t, uint64(3), op.Step(0).(operator.TransferLeader).ToContainer)
-	assert.Equal(t, uint64(4), op.Step(1).(operator.AddLearner).ToContainer)
-	assert.Equal(t, uint64(4), op.Step(2).(operator.PromoteLearner).ToContainer)
-	assert.Equal(t, uint64(1), op.Step(3).(operator.RemovePeer).FromContainer)
+
+	r = r.Clone(core.WithLeader(&peers[2]))
+	s.cluster.PutResource(r)
+	op = s.rc.Check(r)
+	assert.NotNil(t, op)
+	assert.Equal(t, uint64(4), op.Step(0).(operator.AddLearner).ToContainer)
+	assert.Equal(t, uint64(4), op.Step(1).(operator.PromoteLearner).ToContainer)
+	assert.Equal(t, uint64(1), op.Step(2).(operator.RemovePeer).FromContainer)
 }
 
 func TestOfflineWithOneReplica(t *testing.T) {
@@ -214,6 +221,49 @@ func TestFillReplicas(t *testing.T) {
 	assert.Equal(t, rc.cluster.GetOpts().GetMaxReplicas(), len(res.Meta.Peers()))
 }
 
+func TestWitnessReplica(t *testing.T) {
+	opt := config.NewTestOptions()
+	opt.SetWitnessReplicas(1)
+	tc := mockcluster.NewCluster(opt)
+	rc := NewReplicaChecker(tc, cache.NewDefaultCache(10))
+
+	tc.AddResourceContainer(1, 1)
+	tc.AddResourceContainer(2, 1)
+	tc.AddResourceContainer(3, 1)
+	tc.AddResourceContainer(4, 1)
+	tc.AddLeaderResource(1, 1)
+
+	// the voters are added first
+	res := tc.GetResource(1)
+	op := rc.Check(res)
+	assert.NotNil(t, op)
+	assert.Equal(t, "make-up-replica", op.Desc())
+	voter := op.Step(0).(operator.AddLearner)
+	res = res.Clone(core.WithAddPeer(metapb.Peer{ID: voter.PeerID, ContainerID: voter.ToContainer, Role: metapb.PeerRole_Voter}))
+	tc.PutResource(res)
+
+	// and then the witness
+	op = rc.Check(res)
+	assert.NotNil(t, op)
+	assert.Equal(t, "make-up-replica", op.Desc())
+	assert.Equal(t, 1, op.Len())
+	witness := op.Step(0).(operator.AddWitness)
+	assert.NotEqual(t, uint64(1), witness.ToContainer)
+	assert.NotEqual(t, voter.ToContainer, witness.ToContainer)
+
+	res = res.Clone(core.WithAddPeer(metapb.Peer{ID: witness.PeerID, ContainerID: witness.ToContainer, Role: metapb.PeerRole_Witness}))
+	tc.PutResource(res)
+	assert.Nil(t, rc.Check(res))
+
+	// the offline witness is replaced by a witness
+	tc.SetContainerOffline(witness.ToContainer)
+	op = rc.Check(res)
+	assert.NotNil(t, op)
+	assert.Equal(t, "replace-offline-replica", op.Desc())
+	assert.Equal(t, 9-voter.ToContainer-witness.ToContainer, op.Step(0).(operator.AddWitness).ToContainer)
+	assert.Equal(t, witness.ToContainer, op.Step(1).(operator.RemovePeer).FromContainer)
+}
+
 func TestDownPeer(t *testing.T) {
 	s := &testReplicaChecker{}
 	s.setup()
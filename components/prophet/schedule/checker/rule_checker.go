@@ -79,12 +79,14 @@ func (c *RuleChecker) FillReplicas(res *core.CachedResource, leastPeers int) err
 			switch rf.Rule.Role {
 			case placement.Voter, placement.Follower, placement.Leader:
 				p.Role = metapb.PeerRole_Voter
+			case placement.Witness:
+				p.Role = metapb.PeerRole_Witness
 			default:
 				p.Role = metapb.PeerRole_Learner
 			}
 
 			peers := res.Meta.Peers()
-			peers = append(peers, metapb.Peer{ContainerID: container})
+			peers = append(peers, p)
 			res.Meta.SetPeers(peers)
 		}
 	}
@@ -161,6 +163,10 @@ func (c *RuleChecker) fixRulePeer(res *core.CachedResource, fit *placement.Resou
 			return c.replaceRulePeer(res, rf, peer, downStatus)
 		}
 		if c.isOfflinePeer(res, peer) {
+			if op := transferLeaderFromOfflineContainer(c.name, c.cluster, res, peer.ContainerID); op != nil {
+				checkerCounter.WithLabelValues("rule_checker", "transfer-offline-leader").Inc()
+				return op, nil
+			}
 			checkerCounter.WithLabelValues("rule_checker", "replace-offline").Inc()
 			return c.replaceRulePeer(res, rf, peer, offlineStatus)
 		}
@@ -233,7 +239,7 @@ func (c *RuleChecker) fixLooseMatchPeer(res *core.CachedResource, fit *placement
 }
 
 func (c *RuleChecker) allowLeader(fit *placement.ResourceFit, peer metapb.Peer) bool {
-	if metadata.IsLearner(peer) {
+	if metadata.IsLearner(peer) || metadata.IsWitness(peer) {
 		return false
 	}
 	s := c.cluster.GetContainer(peer.ContainerID)
@@ -166,6 +166,33 @@ func TestFixPeer(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestFixOfflineLeader(t *testing.T) {
+	s := &testRuleChecker{}
+	s.setup()
+
+	s.cluster.AddLeaderContainer(1, 1)
+	s.cluster.AddLeaderContainer(2, 1)
+	s.cluster.AddLeaderContainer(3, 1)
+	s.cluster.AddLeaderContainer(4, 1)
+	s.cluster.AddLeaderResourceWithRange(1, "", "", 1, 2, 3)
+	s.cluster.SetContainerOffline(1)
+
+	// the leader is transferred away from the offline container before the peer is replaced
+	op := s.rc.Check(s.cluster.GetResource(1))
+	assert.NotNil(t, op)
+	assert.Equal(t, "transfer-offline-leader", op.Desc())
+	assert.Equal(t, 1, op.Len())
+	assert.NotEqual(t, uint64(1), op.Step(0).(operator.TransferLeader).ToContainer)
+
+	r := s.cluster.GetResource(1)
+	p, _ := r.GetContainerPeer(op.Step(0).(operator.TransferLeader).ToContainer)
+	s.cluster.PutResource(r.Clone(core.WithLeader(&p)))
+	op = s.rc.Check(s.cluster.GetResource(1))
+	assert.NotNil(t, op)
+	assert.Equal(t, "replace-rule-offline-peer", op.Desc())
+	assert.Equal(t, uint64(4), op.Step(0).(operator.AddLearner).ToContainer)
+}
+
 func TestFixOrphanPeers(t *testing.T) {
 	s := &testRuleChecker{}
 	s.setup()
@@ -237,6 +237,8 @@ func (b *Builder) SetLeader(containerID uint64) *Builder {
 		b.err = fmt.Errorf("cannot transfer leader to %d: not found", containerID)
 	} else if metadata.IsLearner(peer) {
 		b.err = fmt.Errorf("cannot transfer leader to %d: not voter", containerID)
+	} else if metadata.IsWitness(peer) {
+		b.err = fmt.Errorf("cannot transfer leader to %d: witness", containerID)
 	} else if _, ok := b.unhealthyPeers[containerID]; ok {
 		b.err = fmt.Errorf("cannot transfer leader to %d: unhealthy", containerID)
 	} else {
@@ -287,7 +289,7 @@ func (b *Builder) SetExpectedRoles(roles map[uint64]placement.PeerRoleType) *Bui
 			leaderCount++
 		case placement.Voter:
 			voterCount++
-		case placement.Follower, placement.Learner:
+		case placement.Follower, placement.Learner, placement.Witness:
 			if b.targetLeaderContainerID == id {
 				b.targetLeaderContainerID = 0
 			}
@@ -347,7 +349,7 @@ func (b *Builder) prepareBuild() (string, error) {
 
 	voterCount := 0
 	for _, peer := range b.targetPeers {
-		if !metadata.IsLearner(peer) {
+		if !metadata.IsLearner(peer) && !metadata.IsWitness(peer) {
 			voterCount++
 		}
 	}
@@ -374,6 +376,12 @@ func (b *Builder) prepareBuild() (string, error) {
 			}
 		}
 
+		// The witness only holds the raft log, it can not be changed to a
+		// normal peer in place, and vice versa.
+		if metadata.IsWitness(o) != metadata.IsWitness(n) {
+			return "", fmt.Errorf("cannot change the witness role of peer %d in container %d", o.ID, o.ContainerID)
+		}
+
 		if metadata.IsLearner(o) {
 			if !metadata.IsLearner(n) {
 				// learner -> voter
@@ -412,8 +420,8 @@ func (b *Builder) prepareBuild() (string, error) {
 		}
 	}
 
-	// If the target leader does not exist or is a Learner, the target is cancelled.
-	if peer, ok := b.targetPeers[b.targetLeaderContainerID]; !ok || metadata.IsLearner(peer) {
+	// If the target leader does not exist or is a Learner or Witness, the target is cancelled.
+	if peer, ok := b.targetPeers[b.targetLeaderContainerID]; !ok || metadata.IsLearner(peer) || metadata.IsWitness(peer) {
 		b.targetLeaderContainerID = 0
 	}
 
@@ -465,7 +473,7 @@ func (b *Builder) buildStepsWithJointConsensus(kind OpKind) (OpKind, error) {
 	// Add all the peers as Learner first. Split `Add Voter` to `Add Learner + Promote`
 	for _, add := range b.toAdd.IDs() {
 		peer := b.toAdd[add]
-		if !metadata.IsLearner(peer) {
+		if !metadata.IsLearner(peer) && !metadata.IsWitness(peer) {
 			b.execAddPeer(metapb.Peer{
 				ID:          peer.ID,
 				ContainerID: peer.ContainerID,
@@ -483,10 +491,10 @@ func (b *Builder) buildStepsWithJointConsensus(kind OpKind) (OpKind, error) {
 		return kind, errors.New("no valid leader")
 	}
 
-	// Split `Remove Voter` to `Demote + Remove Learner`
+	// Split `Remove Voter` to `Demote + Remove Learner`, the witness is removed directly.
 	for _, remove := range b.toRemove.IDs() {
 		peer := b.toRemove[remove]
-		if !metadata.IsLearner(peer) {
+		if !metadata.IsLearner(peer) && !metadata.IsWitness(peer) {
 			b.toDemote.Set(metapb.Peer{
 				ID:          peer.ID,
 				ContainerID: peer.ContainerID,
@@ -652,12 +660,14 @@ func (b *Builder) execDemoteFollower(peer metapb.Peer) {
 }
 
 func (b *Builder) execAddPeer(peer metapb.Peer) {
-	if b.lightWeight {
+	if metadata.IsWitness(peer) {
+		b.steps = append(b.steps, AddWitness{ToContainer: peer.ContainerID, PeerID: peer.ID})
+	} else if b.lightWeight {
 		b.steps = append(b.steps, AddLightLearner{ToContainer: peer.ContainerID, PeerID: peer.ID})
 	} else {
 		b.steps = append(b.steps, AddLearner{ToContainer: peer.ContainerID, PeerID: peer.ID})
 	}
-	if !metadata.IsLearner(peer) {
+	if !metadata.IsLearner(peer) && !metadata.IsWitness(peer) {
 		b.steps = append(b.steps, PromoteLearner{ToContainer: peer.ContainerID, PeerID: peer.ID})
 	}
 	b.currentPeers.Set(peer)
@@ -707,7 +717,7 @@ func (b *Builder) execChangePeerV2(needEnter bool, needTransferLeader bool) {
 func (b *Builder) allowLeader(peer metapb.Peer, ignoreClusterLimit bool) bool {
 	// these peer roles are not allowed to become leader.
 	switch peer.Role {
-	case metapb.PeerRole_Learner, metapb.PeerRole_DemotingVoter:
+	case metapb.PeerRole_Learner, metapb.PeerRole_DemotingVoter, metapb.PeerRole_Witness:
 		return false
 	}
 
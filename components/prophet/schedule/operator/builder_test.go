@@ -416,6 +416,45 @@ func TestBuild(t *testing.T) {
 				RemovePeer{FromContainer: 2},
 			},
 		},
+		{ // add witness
+			false, false,
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2}},
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2}, {ContainerID: 3, Role: metapb.PeerRole_Witness}},
+			OpResource,
+			[]OpStep{
+				AddWitness{ToContainer: 3},
+			},
+		},
+		{ // use joint consensus: replace voter with witness
+			true, true,
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2}, {ID: 3, ContainerID: 3}},
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2}, {ContainerID: 4, Role: metapb.PeerRole_Witness}},
+			OpResource,
+			[]OpStep{
+				AddWitness{ToContainer: 4},
+				ChangePeerV2Enter{
+					DemoteVoters: []DemoteVoter{{ToContainer: 3}},
+				},
+				ChangePeerV2Leave{
+					DemoteVoters: []DemoteVoter{{ToContainer: 3}},
+				},
+				RemovePeer{FromContainer: 3},
+			},
+		},
+		{ // witness can not be changed to voter in place
+			false, false,
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2}, {ID: 3, ContainerID: 3, Role: metapb.PeerRole_Witness}},
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2}, {ID: 3, ContainerID: 3}},
+			0,
+			[]OpStep{},
+		},
+		{ // witness can not be the leader
+			false, false,
+			[]metapb.Peer{{ID: 1, ContainerID: 1}, {ID: 2, ContainerID: 2, Role: metapb.PeerRole_Witness}},
+			[]metapb.Peer{{ID: 2, ContainerID: 2, Role: metapb.PeerRole_Witness}, {ID: 1, ContainerID: 1}},
+			0,
+			[]OpStep{},
+		},
 	}
 
 	for _, tc := range cases {
@@ -451,6 +490,8 @@ func TestBuild(t *testing.T) {
 				assert.Equal(t, step.ToContainer, tc.steps[i].(AddLearner).ToContainer)
 			case AddLightLearner:
 				assert.Equal(t, step.ToContainer, tc.steps[i].(AddLightLearner).ToContainer)
+			case AddWitness:
+				assert.Equal(t, step.ToContainer, tc.steps[i].(AddWitness).ToContainer)
 			case PromoteLearner:
 				assert.Equal(t, step.ToContainer, tc.steps[i].(PromoteLearner).ToContainer)
 			case DemoteFollower:
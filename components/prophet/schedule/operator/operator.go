@@ -318,6 +318,8 @@ func (o *Operator) History() []OpHistory {
 			addPeerContainers = append(addPeerContainers, s.ToContainer)
 		case AddLightLearner:
 			addPeerContainers = append(addPeerContainers, s.ToContainer)
+		case AddWitness:
+			addPeerContainers = append(addPeerContainers, s.ToContainer)
 		case RemovePeer:
 			removePeerContainers = append(removePeerContainers, s.FromContainer)
 		}
@@ -181,6 +181,58 @@ func (al AddLearner) Influence(opInfluence OpInfluence, res *core.CachedResource
 	to.AdjustStepCost(limit.AddPeer, size)
 }
 
+// AddWitness is an OpStep that adds a resource witness peer.
+type AddWitness struct {
+	ToContainer, PeerID uint64
+}
+
+// ConfVerChanged returns the delta value for version increased by this step.
+func (aw AddWitness) ConfVerChanged(res *core.CachedResource) uint64 {
+	peer, _ := res.GetContainerVoter(aw.ToContainer)
+	return typeutil.BoolToUint64(peer.ID == aw.PeerID)
+}
+
+func (aw AddWitness) String() string {
+	return fmt.Sprintf("add witness peer %v on container %v", aw.PeerID, aw.ToContainer)
+}
+
+// IsFinish checks if current step is finished.
+func (aw AddWitness) IsFinish(res *core.CachedResource) bool {
+	if peer, ok := res.GetContainerVoter(aw.ToContainer); ok {
+		if peer.ID != aw.PeerID || !metadata.IsWitness(peer) {
+			util.GetLogger().Warningf("%s obtain unexpected peer %+v", aw.String(), peer)
+			return false
+		}
+		_, ok := res.GetPendingVoter(peer.ID)
+		return !ok
+	}
+	return false
+}
+
+// CheckSafety checks if the step meets the safety properties.
+func (aw AddWitness) CheckSafety(res *core.CachedResource) error {
+	peer, ok := res.GetContainerPeer(aw.ToContainer)
+	if !ok {
+		return nil
+	}
+	if peer.ID != aw.PeerID {
+		return fmt.Errorf("peer %d has already existed in container %d, the operator is trying to add peer %d on the same container", peer.ID, aw.ToContainer, aw.PeerID)
+	}
+	if !metadata.IsWitness(peer) {
+		return errors.New("peer already exists and is not a witness")
+	}
+	return nil
+}
+
+// Influence calculates the container difference that current step makes.
+func (aw AddWitness) Influence(opInfluence OpInfluence, res *core.CachedResource) {
+	to := opInfluence.GetContainerInfluence(aw.ToContainer)
+
+	// the witness only holds the raft log, no data will be sent to it.
+	to.ResourceCount++
+	to.AdjustStepCost(limit.AddPeer, 0)
+}
+
 // PromoteLearner is an OpStep that promotes a resource learner peer to normal voter.
 type PromoteLearner struct {
 	ToContainer, PeerID uint64
@@ -670,6 +670,21 @@ func (oc *OperatorController) SendScheduleCommand(res *core.CachedResource, step
 				},
 			},
 		}
+	case operator.AddWitness:
+		if _, ok := res.GetContainerPeer(st.ToContainer); ok {
+			// The newly added peer is pending.
+			return
+		}
+		cmd = &rpcpb.ResourceHeartbeatRsp{
+			ChangePeer: &rpcpb.ChangePeer{
+				ChangeType: metapb.ChangePeerType_AddNode,
+				Peer: metapb.Peer{
+					ID:          st.PeerID,
+					ContainerID: st.ToContainer,
+					Role:        metapb.PeerRole_Witness,
+				},
+			},
+		}
 	case operator.AddLightLearner:
 		if _, ok := res.GetContainerPeer(st.ToContainer); ok {
 			// The newly added peer is pending.
@@ -272,13 +272,15 @@ type fitPeer struct {
 func (p *fitPeer) matchRoleStrict(role PeerRoleType) bool {
 	switch role {
 	case Voter: // Voter matches either Leader or Follower.
-		return !metadata.IsLearner(p.Peer)
+		return !metadata.IsLearner(p.Peer) && !metadata.IsWitness(p.Peer)
 	case Leader:
 		return p.isLeader
 	case Follower:
-		return !metadata.IsLearner(p.Peer) && !p.isLeader
+		return !metadata.IsLearner(p.Peer) && !metadata.IsWitness(p.Peer) && !p.isLeader
 	case Learner:
 		return metadata.IsLearner(p.Peer)
+	case Witness:
+		return metadata.IsWitness(p.Peer)
 	}
 	return false
 }
@@ -286,7 +288,11 @@ func (p *fitPeer) matchRoleStrict(role PeerRoleType) bool {
 func (p *fitPeer) matchRoleLoose(role PeerRoleType) bool {
 	// non-learner cannot become learner. All other roles can migrate to
 	// others by scheduling. For example, Leader->Follower, Learner->Leader
-	// are possible, but Voter->Learner is impossible.
+	// are possible, but Voter->Learner is impossible. The witness holds no
+	// data, so it only matches the witness role.
+	if metadata.IsWitness(p.Peer) || role == Witness {
+		return role == Witness && metadata.IsWitness(p.Peer)
+	}
 	return role != Learner || metadata.IsLearner(p.Peer)
 }
 
@@ -131,6 +131,10 @@ func TestFitresource(t *testing.T) {
 		{"1111_learner,1112,1113", []string{"2/voter//"}, "1112,1113"},
 		{"1111_learner,1112,1113", []string{"3/voter//"}, "1111,1112,1113"},
 		{"1111,1112_learner,1121_learner,1122_learner,1131_learner,1132,1141,1142", []string{"3/follower//zone,rack,host"}, "1111,1132,1141"},
+		// test witness
+		{"1111,1112,1113_witness", []string{"2/voter//", "1/witness//"}, "1111,1112/1113"},
+		{"1111,1112,1113_witness", []string{"3/voter//"}, "1111,1112/1113"},
+		{"1111,1112,1113", []string{"1/witness//"}, "/1111,1112,1113"},
 		// test 2 rule
 		{"1111,1112,1113,1114", []string{"3/voter//", "1/voter/id=id1/"}, "1112,1113,1114/1111"},
 		{"1111,2211,3111,3112", []string{"3/voter//zone", "1/voter/rack=rack2/"}, "1111,2211,3111//3112"},
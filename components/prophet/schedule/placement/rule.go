@@ -35,6 +35,8 @@ const (
 	Follower PeerRoleType = "follower"
 	// Learner matches a learner.
 	Learner PeerRoleType = "learner"
+	// Witness matches a witness.
+	Witness PeerRoleType = "witness"
 )
 
 func getPeerRoleTypeFromRPC(tpe rpcpb.PeerRoleType) PeerRoleType {
@@ -47,18 +49,23 @@ func getPeerRoleTypeFromRPC(tpe rpcpb.PeerRoleType) PeerRoleType {
 		return Follower
 	case rpcpb.Learner:
 		return Learner
+	case rpcpb.Witness:
+		return Witness
 	}
 	return Voter
 }
 
 func validateRole(s PeerRoleType) bool {
-	return s == Voter || s == Leader || s == Follower || s == Learner
+	return s == Voter || s == Leader || s == Follower || s == Learner || s == Witness
 }
 
 // MetaPeerRole converts placement.PeerRoleType to metapb.PeerRole.
 func (s PeerRoleType) MetaPeerRole() metapb.PeerRole {
-	if s == Learner {
+	switch s {
+	case Learner:
 		return metapb.PeerRole_Learner
+	case Witness:
+		return metapb.PeerRole_Witness
 	}
 	return metapb.PeerRole_Voter
 }
@@ -74,6 +81,8 @@ func (s PeerRoleType) RPCPeerRole() rpcpb.PeerRoleType {
 		return rpcpb.Follower
 	case Learner:
 		return rpcpb.Learner
+	case Witness:
+		return rpcpb.Witness
 	}
 	return rpcpb.Voter
 }
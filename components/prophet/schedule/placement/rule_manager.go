@@ -58,8 +58,8 @@ func NewRuleManager(storage storage.Storage, containerSetInformer core.Container
 }
 
 // Initialize loads rules from storage. If Placement Rules feature is never enabled, it creates default rule that is
-// compatible with previous configuration.
-func (m *RuleManager) Initialize(maxReplica int, locationLabels []string) error {
+// compatible with previous configuration. The witness replicas are placed by a separate witness rule.
+func (m *RuleManager) Initialize(maxReplica, witnessReplica int, locationLabels []string) error {
 	m.Lock()
 	defer m.Unlock()
 	if m.initialized {
@@ -78,13 +78,28 @@ func (m *RuleManager) Initialize(maxReplica int, locationLabels []string) error
 			GroupID:        "prophet",
 			ID:             "default",
 			Role:           Voter,
-			Count:          maxReplica,
+			Count:          maxReplica - witnessReplica,
 			LocationLabels: locationLabels,
 		}
 		if err := m.storage.PutRule(defaultRule.StoreKey(), defaultRule); err != nil {
 			return err
 		}
 		m.ruleConfig.setRule(defaultRule)
+
+		if witnessReplica > 0 {
+			witnessRule := &Rule{
+				GroupID:        "prophet",
+				ID:             "witness",
+				Index:          1,
+				Role:           Witness,
+				Count:          witnessReplica,
+				LocationLabels: locationLabels,
+			}
+			if err := m.storage.PutRule(witnessRule.StoreKey(), witnessRule); err != nil {
+				return err
+			}
+			m.ruleConfig.setRule(witnessRule)
+		}
 	}
 	m.ruleConfig.adjust()
 	ruleList, err := buildRuleList(m.ruleConfig)
@@ -35,7 +35,7 @@ func (s *testManager) setup(t *testing.T) {
 	s.storage = storage.NewTestStorage()
 	var err error
 	s.manager = NewRuleManager(s.storage, nil)
-	err = s.manager.Initialize(3, []string{"zone", "rack", "host"})
+	err = s.manager.Initialize(3, 0, []string{"zone", "rack", "host"})
 	assert.NoError(t, err)
 }
 
@@ -62,6 +62,18 @@ func TestDefault(t *testing.T) {
 	assert.True(t, reflect.DeepEqual([]string{"zone", "rack", "host"}, rules[0].LocationLabels))
 }
 
+func TestDefaultWithWitness(t *testing.T) {
+	m := NewRuleManager(storage.NewTestStorage(), nil)
+	assert.NoError(t, m.Initialize(3, 1, []string{"zone"}))
+
+	rules := m.GetAllRules()
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, Voter, m.GetRule("prophet", "default").Role)
+	assert.Equal(t, 2, m.GetRule("prophet", "default").Count)
+	assert.Equal(t, Witness, m.GetRule("prophet", "witness").Role)
+	assert.Equal(t, 1, m.GetRule("prophet", "witness").Count)
+}
+
 func TestApplyRule(t *testing.T) {
 	s := &testManager{}
 	s.setup(t)
@@ -160,7 +172,7 @@ func TestSaveLoad(t *testing.T) {
 	}
 
 	m2 := NewRuleManager(s.storage, nil)
-	err := m2.Initialize(3, []string{"no", "labels"})
+	err := m2.Initialize(3, 0, []string{"no", "labels"})
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(m2.GetAllRules()))
 	assert.True(t, reflect.DeepEqual(rules[0], m2.GetRule("prophet", "default")))
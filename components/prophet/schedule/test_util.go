@@ -78,6 +78,16 @@ func ApplyOperatorStep(resource *core.CachedResource, op *operator.Operator) *co
 				Role:        metapb.PeerRole_Learner,
 			}
 			resource = resource.Clone(core.WithAddPeer(peer))
+		case operator.AddWitness:
+			if _, ok := resource.GetContainerPeer(s.ToContainer); ok {
+				panic("Add witness that exists")
+			}
+			peer := metapb.Peer{
+				ID:          s.PeerID,
+				ContainerID: s.ToContainer,
+				Role:        metapb.PeerRole_Witness,
+			}
+			resource = resource.Clone(core.WithAddPeer(peer))
 		case operator.PromoteLearner:
 			if _, ok := resource.GetContainerLearner(s.ToContainer); !ok {
 				panic("Promote peer that doesn't exist")
@@ -0,0 +1,235 @@
+// Copyright 2020 PingCAP, Inc.
+// Modifications copyright (C) 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"errors"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/filter"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+)
+
+const (
+	// EvictSlowStoreName is evict slow store scheduler name.
+	EvictSlowStoreName = "evict-slow-store-scheduler"
+	// EvictSlowStoreType is evict slow store scheduler type.
+	EvictSlowStoreType = "evict-slow-store"
+
+	slowStoreEvictThreshold   = 100
+	slowStoreRecoverThreshold = 1
+)
+
+func init() {
+	schedule.RegisterSliceDecoderBuilder(EvictSlowStoreType, func(args []string) schedule.ConfigDecoder {
+		return func(v interface{}) error {
+			if _, ok := v.(*evictSlowStoreSchedulerConfig); !ok {
+				return errors.New("scheduler error configuration")
+			}
+			return nil
+		}
+	})
+
+	schedule.RegisterScheduler(EvictSlowStoreType, func(opController *schedule.OperatorController, storage storage.Storage, decoder schedule.ConfigDecoder) (schedule.Scheduler, error) {
+		conf := &evictSlowStoreSchedulerConfig{storage: storage, EvictedContainers: make([]uint64, 0)}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		return newEvictSlowStoreScheduler(opController, conf), nil
+	})
+}
+
+type evictSlowStoreSchedulerConfig struct {
+	storage           storage.Storage
+	EvictedContainers []uint64 `json:"evict-containers"`
+}
+
+func (conf *evictSlowStoreSchedulerConfig) Persist() error {
+	data, err := schedule.EncodeConfig(conf)
+	if err != nil {
+		return err
+	}
+	return conf.storage.SaveScheduleConfig(EvictSlowStoreName, data)
+}
+
+func (conf *evictSlowStoreSchedulerConfig) getEvictedContainer() uint64 {
+	if len(conf.EvictedContainers) == 0 {
+		return 0
+	}
+	return conf.EvictedContainers[0]
+}
+
+func (conf *evictSlowStoreSchedulerConfig) setEvictedContainer(id uint64) error {
+	conf.EvictedContainers = []uint64{id}
+	return conf.Persist()
+}
+
+func (conf *evictSlowStoreSchedulerConfig) clearEvictedContainer() error {
+	conf.EvictedContainers = []uint64{}
+	return conf.Persist()
+}
+
+type evictSlowStoreScheduler struct {
+	*BaseScheduler
+	conf *evictSlowStoreSchedulerConfig
+}
+
+// newEvictSlowStoreScheduler creates a scheduler that transfers all leaders out of the
+// container whose slow score reported by the heartbeat is too high. Only one container
+// is evicted at a time, and the leader transfer is resumed after the container recovered.
+func newEvictSlowStoreScheduler(opController *schedule.OperatorController, conf *evictSlowStoreSchedulerConfig) schedule.Scheduler {
+	return &evictSlowStoreScheduler{
+		BaseScheduler: NewBaseScheduler(opController),
+		conf:          conf,
+	}
+}
+
+func (s *evictSlowStoreScheduler) GetName() string {
+	return EvictSlowStoreName
+}
+
+func (s *evictSlowStoreScheduler) GetType() string {
+	return EvictSlowStoreType
+}
+
+func (s *evictSlowStoreScheduler) EncodeConfig() ([]byte, error) {
+	return schedule.EncodeConfig(s.conf)
+}
+
+func (s *evictSlowStoreScheduler) Prepare(cluster opt.Cluster) error {
+	if id := s.conf.getEvictedContainer(); id != 0 {
+		return cluster.PauseLeaderTransfer(id)
+	}
+	return nil
+}
+
+func (s *evictSlowStoreScheduler) Cleanup(cluster opt.Cluster) {
+	if id := s.conf.getEvictedContainer(); id != 0 {
+		s.recover(cluster, id)
+	}
+}
+
+func (s *evictSlowStoreScheduler) IsScheduleAllowed(cluster opt.Cluster) bool {
+	if s.conf.getEvictedContainer() == 0 {
+		return true
+	}
+
+	allowed := s.OpController.OperatorCount(operator.OpLeader) < cluster.GetOpts().GetLeaderScheduleLimit()
+	if !allowed {
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpLeader.String()).Inc()
+	}
+	return allowed
+}
+
+func (s *evictSlowStoreScheduler) Schedule(cluster opt.Cluster) []*operator.Operator {
+	schedulerCounter.WithLabelValues(s.GetName(), "schedule").Inc()
+
+	if id := s.conf.getEvictedContainer(); id != 0 {
+		container := cluster.GetContainer(id)
+		if container == nil || container.IsTombstone() {
+			// the container is removed, stop evicting
+			s.recover(cluster, id)
+			return nil
+		}
+
+		if container.GetSlowScore() <= slowStoreRecoverThreshold {
+			util.GetLogger().Infof("slow container %d recovered, stop evicting leaders", id)
+			s.recover(cluster, id)
+			return nil
+		}
+		return s.evictLeaders(cluster, id)
+	}
+
+	var slowContainers []*core.CachedContainer
+	for _, container := range cluster.GetContainers() {
+		if container.IsUp() && container.GetSlowScore() >= slowStoreEvictThreshold {
+			slowContainers = append(slowContainers, container)
+		}
+	}
+
+	// more than one slow container may be caused by the cluster wide issues, and
+	// evicting the leaders cannot help.
+	if len(slowContainers) != 1 {
+		if len(slowContainers) > 1 {
+			schedulerCounter.WithLabelValues(s.GetName(), "multi-slow-container").Inc()
+		}
+		return nil
+	}
+
+	id := slowContainers[0].Meta.ID()
+	if err := cluster.PauseLeaderTransfer(id); err != nil {
+		util.GetLogger().Errorf("pause leader transfer of slow container %d failed with %+v",
+			id, err)
+		return nil
+	}
+	if err := s.conf.setEvictedContainer(id); err != nil {
+		util.GetLogger().Errorf("persist evicted slow container %d failed with %+v",
+			id, err)
+		cluster.ResumeLeaderTransfer(id)
+		return nil
+	}
+
+	util.GetLogger().Infof("container %d is slow with score %d, start evicting leaders",
+		id, slowContainers[0].GetSlowScore())
+	return s.evictLeaders(cluster, id)
+}
+
+func (s *evictSlowStoreScheduler) recover(cluster opt.Cluster, id uint64) {
+	if err := s.conf.clearEvictedContainer(); err != nil {
+		util.GetLogger().Errorf("persist recovered slow container %d failed with %+v",
+			id, err)
+		return
+	}
+	cluster.ResumeLeaderTransfer(id)
+}
+
+func (s *evictSlowStoreScheduler) evictLeaders(cluster opt.Cluster, id uint64) []*operator.Operator {
+	var ops []*operator.Operator
+	resIDs := make(map[uint64]struct{})
+	ranges := []core.KeyRange{core.NewKeyRange("", "")}
+	for i := 0; i < EvictLeaderBatchSize; i++ {
+		res := cluster.RandLeaderResource(id, ranges, opt.HealthResource(cluster))
+		if res == nil {
+			schedulerCounter.WithLabelValues(s.GetName(), "no-leader").Inc()
+			break
+		}
+		if _, ok := resIDs[res.Meta.ID()]; ok {
+			continue
+		}
+
+		target := filter.NewCandidates(cluster.GetFollowerContainers(res)).
+			FilterTarget(cluster.GetOpts(), &filter.ContainerStateFilter{ActionScope: EvictSlowStoreName, TransferLeader: true}).
+			RandomPick()
+		if target == nil {
+			schedulerCounter.WithLabelValues(s.GetName(), "no-target-container").Inc()
+			continue
+		}
+		op, err := operator.CreateTransferLeaderOperator(EvictSlowStoreType, cluster, res, id, target.Meta.ID(), operator.OpLeader)
+		if err != nil {
+			util.GetLogger().Debugf("create evict slow store operator failed with %+v",
+				err)
+			continue
+		}
+		op.SetPriorityLevel(core.HighPriority)
+		op.Counters = append(op.Counters, schedulerCounter.WithLabelValues(s.GetName(), "new-operator"))
+		resIDs[res.Meta.ID()] = struct{}{}
+		ops = append(ops, op)
+	}
+	return ops
+}
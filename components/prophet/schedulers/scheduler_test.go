@@ -182,6 +182,57 @@ func TestEvictLeader(t *testing.T) {
 	testutil.CheckTransferLeader(t, op[0], operator.OpLeader, 1, 2)
 }
 
+func TestEvictSlowStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+
+	// Add containers 1, 2, 3
+	tc.AddLeaderContainer(1, 0)
+	tc.AddLeaderContainer(2, 0)
+	tc.AddLeaderContainer(3, 0)
+	// Add resources 1, 2, 3 with leaders in containers 1, 2, 3
+	tc.AddLeaderResource(1, 1, 2)
+	tc.AddLeaderResource(2, 2, 1)
+	tc.AddLeaderResource(3, 3, 1)
+
+	s := storage.NewTestStorage()
+	es, err := schedule.CreateScheduler(EvictSlowStoreType, schedule.NewOperatorController(ctx, tc, nil), s, schedule.ConfigSliceDecoder(EvictSlowStoreType, nil))
+	assert.NoError(t, err)
+	assert.True(t, es.IsScheduleAllowed(tc))
+	assert.Empty(t, es.Schedule(tc))
+
+	// more than one slow container
+	tc.SetContainerSlowScore(1, slowStoreEvictThreshold)
+	tc.SetContainerSlowScore(2, slowStoreEvictThreshold)
+	assert.Empty(t, es.Schedule(tc))
+
+	tc.SetContainerSlowScore(2, slowStoreRecoverThreshold)
+	op := es.Schedule(tc)
+	testutil.CheckTransferLeader(t, op[0], operator.OpLeader, 1, 2)
+	assert.Equal(t, []uint64{1}, es.(*evictSlowStoreScheduler).conf.EvictedContainers)
+	assert.False(t, tc.GetContainer(1).AllowLeaderTransfer())
+
+	// the evicted container is persisted
+	names, values, err := s.LoadAllScheduleConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{EvictSlowStoreName}, names)
+	conf := &evictSlowStoreSchedulerConfig{}
+	assert.NoError(t, schedule.DecodeConfig([]byte(values[0]), conf))
+	assert.Equal(t, []uint64{1}, conf.EvictedContainers)
+
+	// keep evicting until recovered
+	tc.SetContainerSlowScore(1, slowStoreEvictThreshold/2)
+	op = es.Schedule(tc)
+	testutil.CheckTransferLeader(t, op[0], operator.OpLeader, 1, 2)
+
+	tc.SetContainerSlowScore(1, slowStoreRecoverThreshold)
+	assert.Empty(t, es.Schedule(tc))
+	assert.Empty(t, es.(*evictSlowStoreScheduler).conf.EvictedContainers)
+	assert.True(t, tc.GetContainer(1).AllowLeaderTransfer())
+}
+
 func TestShuffleresource(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -35,7 +35,7 @@ func (s *testResourceStatistics) setup(t *testing.T) {
 	s.storage = storage.NewTestStorage()
 	var err error
 	s.manager = placement.NewRuleManager(s.storage, nil)
-	err = s.manager.Initialize(3, []string{"zone", "rack", "host"})
+	err = s.manager.Initialize(3, 0, []string{"zone", "rack", "host"})
 	assert.NoError(t, err)
 }
 
@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fagongzi/util/format"
 	"github.com/fagongzi/util/protoc"
@@ -128,6 +129,34 @@ type ClusterStorage interface {
 	PutBootstrapped(container metadata.Container, resources ...metadata.Resource) (bool, error)
 }
 
+// TimestampStorage timestamp storage
+type TimestampStorage interface {
+	// PutTimestamp puts the max timestamp that the tso can allocate
+	PutTimestamp(time.Time) error
+	// GetTimestamp returns the max timestamp that the tso can allocate, returns zero time if not exists
+	GetTimestamp() (time.Time, error)
+}
+
+// RateLimitStorage rate limit storage
+type RateLimitStorage interface {
+	// PutRateLimit puts the rate limit of the tenant in the group
+	PutRateLimit(metapb.RateLimit) error
+	// RemoveRateLimit removes the rate limit of the tenant in the group
+	RemoveRateLimit(group, tenant uint64) error
+	// LoadRateLimits load all rate limits
+	LoadRateLimits(limit int64, do func(metapb.RateLimit)) error
+}
+
+// WriteFreezeStorage write frozen groups storage
+type WriteFreezeStorage interface {
+	// PutWriteFrozenGroup marks the group as write frozen
+	PutWriteFrozenGroup(group uint64) error
+	// RemoveWriteFrozenGroup removes the write frozen mark of the group
+	RemoveWriteFrozenGroup(group uint64) error
+	// LoadWriteFrozenGroups load all write frozen groups
+	LoadWriteFrozenGroups(limit int64, do func(uint64)) error
+}
+
 // Storage meta storage
 type Storage interface {
 	JobStorage
@@ -138,6 +167,9 @@ type Storage interface {
 	ResourceStorage
 	ContainerStorage
 	ClusterStorage
+	TimestampStorage
+	RateLimitStorage
+	WriteFreezeStorage
 
 	// KV return KV storage
 	KV() KV
@@ -158,6 +190,9 @@ type storage struct {
 	jobPath                  string
 	jobDataPath              string
 	customDataPath           string
+	timestampPath            string
+	rateLimitPath            string
+	writeFrozenGroupPath     string
 }
 
 // NewTestStorage create test storage
@@ -182,6 +217,9 @@ func NewStorage(rootPath string, kv KV, adapter metadata.Adapter) Storage {
 		jobPath:                  fmt.Sprintf("%s/jobs", rootPath),
 		jobDataPath:              fmt.Sprintf("%s/job-data", rootPath),
 		customDataPath:           fmt.Sprintf("%s/custom", rootPath),
+		timestampPath:            fmt.Sprintf("%s/timestamp", rootPath),
+		rateLimitPath:            fmt.Sprintf("%s/rate-limits", rootPath),
+		writeFrozenGroupPath:     fmt.Sprintf("%s/write-frozen-groups", rootPath),
 	}
 }
 
@@ -523,6 +561,67 @@ func (s *storage) AlreadyBootstrapped() (bool, error) {
 	return v != "", nil
 }
 
+func (s *storage) PutTimestamp(ts time.Time) error {
+	return s.kv.Save(s.timestampPath, string(format.Uint64ToBytes(uint64(ts.UnixNano()))))
+}
+
+func (s *storage) GetTimestamp() (time.Time, error) {
+	v, err := s.kv.Load(s.timestampPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	nanos, err := format.BytesToUint64([]byte(v))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(nanos)), nil
+}
+
+func (s *storage) PutRateLimit(limit metapb.RateLimit) error {
+	return s.kv.Save(s.rateLimitKey(limit.Group, limit.Tenant),
+		string(protoc.MustMarshal(&limit)))
+}
+
+func (s *storage) RemoveRateLimit(group, tenant uint64) error {
+	return s.kv.Remove(s.rateLimitKey(group, tenant))
+}
+
+func (s *storage) LoadRateLimits(limit int64, fn func(metapb.RateLimit)) error {
+	return s.LoadRangeByPrefix(limit, s.rateLimitPath+"/", func(k, v string) error {
+		rl := metapb.RateLimit{}
+		protoc.MustUnmarshal(&rl, []byte(v))
+		fn(rl)
+		return nil
+	})
+}
+
+func (s *storage) rateLimitKey(group, tenant uint64) string {
+	return path.Join(s.rateLimitPath, fmt.Sprintf("%020d-%020d", group, tenant))
+}
+
+func (s *storage) PutWriteFrozenGroup(group uint64) error {
+	return s.kv.Save(s.getKey(group, s.writeFrozenGroupPath), string(format.Uint64ToBytes(group)))
+}
+
+func (s *storage) RemoveWriteFrozenGroup(group uint64) error {
+	return s.kv.Remove(s.getKey(group, s.writeFrozenGroupPath))
+}
+
+func (s *storage) LoadWriteFrozenGroups(limit int64, fn func(uint64)) error {
+	return s.LoadRangeByPrefix(limit, s.writeFrozenGroupPath+"/", func(k, v string) error {
+		group, err := format.BytesToUint64([]byte(v))
+		if err != nil {
+			return err
+		}
+		fn(group)
+		return nil
+	})
+}
+
 func (s *storage) getKey(id uint64, base string) string {
 	return path.Join(base, fmt.Sprintf("%020d", id))
 }
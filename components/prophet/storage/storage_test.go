@@ -293,3 +293,46 @@ func TestBatchPutCustomData(t *testing.T) {
 		assert.Equal(t, data[i], loadedValues[i])
 	}
 }
+
+func TestPutAndRemoveAndLoadRateLimits(t *testing.T) {
+	storage := NewTestStorage()
+	assert.NoError(t, storage.PutRateLimit(metapb.RateLimit{Group: 1, Tenant: 1, Requests: 100}))
+	assert.NoError(t, storage.PutRateLimit(metapb.RateLimit{Group: 1, Tenant: 2, Bytes: 1024}))
+	assert.NoError(t, storage.PutRateLimit(metapb.RateLimit{Group: 1, Tenant: 1, Requests: 200}))
+
+	var loadedValues []metapb.RateLimit
+	assert.NoError(t, storage.LoadRateLimits(10, func(limit metapb.RateLimit) {
+		loadedValues = append(loadedValues, limit)
+	}))
+	assert.Equal(t, 2, len(loadedValues))
+	assert.Equal(t, uint64(200), loadedValues[0].Requests)
+	assert.Equal(t, uint64(1024), loadedValues[1].Bytes)
+
+	assert.NoError(t, storage.RemoveRateLimit(1, 1))
+	loadedValues = loadedValues[:0]
+	assert.NoError(t, storage.LoadRateLimits(10, func(limit metapb.RateLimit) {
+		loadedValues = append(loadedValues, limit)
+	}))
+	assert.Equal(t, 1, len(loadedValues))
+	assert.Equal(t, uint64(2), loadedValues[0].Tenant)
+}
+
+func TestPutAndRemoveAndLoadWriteFrozenGroups(t *testing.T) {
+	storage := NewTestStorage()
+	assert.NoError(t, storage.PutWriteFrozenGroup(2))
+	assert.NoError(t, storage.PutWriteFrozenGroup(1))
+	assert.NoError(t, storage.PutWriteFrozenGroup(2))
+
+	var loadedValues []uint64
+	assert.NoError(t, storage.LoadWriteFrozenGroups(10, func(group uint64) {
+		loadedValues = append(loadedValues, group)
+	}))
+	assert.Equal(t, []uint64{1, 2}, loadedValues)
+
+	assert.NoError(t, storage.RemoveWriteFrozenGroup(1))
+	loadedValues = loadedValues[:0]
+	assert.NoError(t, storage.LoadWriteFrozenGroups(10, func(group uint64) {
+		loadedValues = append(loadedValues, group)
+	}))
+	assert.Equal(t, []uint64{2}, loadedValues)
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+)
+
+const (
+	logicalBits = 18
+	maxLogical  = int64(1 << logicalBits)
+
+	// updateInterval the interval to advance the physical time
+	updateInterval = time.Millisecond * 50
+	// saveWindow the timestamps within the window can be allocated without saving to the storage
+	saveWindow = time.Second * 3
+	// guard the min step of the physical time
+	guard = time.Millisecond
+)
+
+var (
+	// ErrNotReady the tso is not initialized, the leader is waiting past the timestamps allocated
+	// by the previous leader
+	ErrNotReady = errors.New("tso is not ready")
+	// ErrLogicalOverflow too many timestamps are allocated in the same physical time, retry later
+	ErrLogicalOverflow = errors.New("tso logical overflow")
+)
+
+// Compose returns the timestamp composed by the physical time in milliseconds and the logical counter
+func Compose(physical time.Time, logical int64) uint64 {
+	ms := physical.UnixNano() / int64(time.Millisecond)
+	return uint64(ms)<<logicalBits | uint64(logical)
+}
+
+// Parse returns the physical time and the logical counter of the timestamp
+func Parse(ts uint64) (time.Time, int64) {
+	ms := int64(ts >> logicalBits)
+	return time.Unix(0, ms*int64(time.Millisecond)), int64(ts & uint64(maxLogical-1))
+}
+
+// Allocator the timestamp oracle on the prophet leader. It allocates the hybrid timestamps composed
+// by the physical time and the logical counter. The max physical time is saved to the storage in
+// advance, and the new leader starts after the saved time, so the timestamps allocated by all the
+// leaders are increasing. All the times are wall clock times without the monotonic clock reading,
+// because the timestamps are composed by the wall clock and compared across the leaders.
+type Allocator struct {
+	sync.Mutex
+
+	storage   storage.TimestampStorage
+	now       func() time.Time
+	physical  time.Time
+	logical   int64
+	lastSaved time.Time
+}
+
+// NewAllocator returns a tso allocator
+func NewAllocator(storage storage.TimestampStorage) *Allocator {
+	return &Allocator{storage: storage, now: time.Now}
+}
+
+// wallNow returns the current wall clock time, the monotonic clock reading is stripped
+func (a *Allocator) wallNow() time.Time {
+	return a.now().Round(0)
+}
+
+// Run initializes the allocator and advances the physical time until the ctx done, it should be
+// called after become the prophet leader.
+func (a *Allocator) Run(ctx context.Context) {
+	defer a.reset()
+
+	for {
+		err := a.initialize(ctx)
+		if err == nil {
+			break
+		}
+
+		util.GetLogger().Errorf("initialize tso failed with %+v, retry later", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+
+	ticker := time.NewTicker(updateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.update(); err != nil {
+				util.GetLogger().Errorf("update tso failed with %+v", err)
+			}
+		}
+	}
+}
+
+// Generate allocates count timestamps, returns the max one. The allocated timestamps
+// are (ts-count, ts].
+func (a *Allocator) Generate(count uint32) (uint64, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.physical.IsZero() {
+		return 0, ErrNotReady
+	}
+
+	if count == 0 {
+		count = 1
+	}
+	if a.logical+int64(count) >= maxLogical {
+		return 0, ErrLogicalOverflow
+	}
+
+	a.logical += int64(count)
+	return Compose(a.physical, a.logical), nil
+}
+
+func (a *Allocator) initialize(ctx context.Context) error {
+	last, err := a.storage.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	// the previous leader may allocate the timestamps up to the saved time, wait past it
+	last = last.Round(0)
+	if wait := last.Sub(a.wallNow()); wait >= 0 {
+		util.GetLogger().Infof("tso wait %s past the saved timestamp %s", wait, last)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait + guard):
+		}
+	}
+
+	// the wall clock may go backwards during the wait
+	now := a.wallNow()
+	if now.Sub(last) < guard {
+		now = last.Add(guard)
+	}
+	save := now.Add(saveWindow)
+	if err := a.storage.PutTimestamp(save); err != nil {
+		return err
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	a.physical = now
+	a.logical = 0
+	a.lastSaved = save
+	util.GetLogger().Infof("tso initialized at %s", now)
+	return nil
+}
+
+func (a *Allocator) update() error {
+	a.Lock()
+	defer a.Unlock()
+
+	// the physical time never goes backwards even if the wall clock does
+	now := a.wallNow()
+	next := a.physical
+	if now.Sub(a.physical) >= guard {
+		next = now
+	} else if a.logical > maxLogical/2 {
+		// the clock is not advanced, use the next physical time to allocate more timestamps
+		next = a.physical.Add(guard)
+	} else {
+		return nil
+	}
+
+	if a.lastSaved.Sub(next) <= guard {
+		save := next.Add(saveWindow)
+		if err := a.storage.PutTimestamp(save); err != nil {
+			return err
+		}
+		a.lastSaved = save
+	}
+
+	a.physical = next
+	a.logical = 0
+	return nil
+}
+
+func (a *Allocator) reset() {
+	a.Lock()
+	defer a.Unlock()
+	a.physical = time.Time{}
+	a.logical = 0
+	a.lastSaved = time.Time{}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeAndParse(t *testing.T) {
+	now := time.Unix(0, int64(time.Millisecond)*1000)
+	physical, logical := Parse(Compose(now, 10))
+	assert.Equal(t, now, physical)
+	assert.Equal(t, int64(10), logical)
+}
+
+func TestGenerate(t *testing.T) {
+	s := storage.NewTestStorage()
+	a := NewAllocator(s)
+	_, err := a.Generate(1)
+	assert.Equal(t, ErrNotReady, err)
+
+	assert.NoError(t, a.initialize(context.Background()))
+	saved, err := s.GetTimestamp()
+	assert.NoError(t, err)
+	assert.True(t, saved.After(a.physical))
+
+	ts1, err := a.Generate(1)
+	assert.NoError(t, err)
+	ts2, err := a.Generate(10)
+	assert.NoError(t, err)
+	assert.Equal(t, ts1+10, ts2)
+
+	_, err = a.Generate(uint32(maxLogical))
+	assert.Equal(t, ErrLogicalOverflow, err)
+
+	a.reset()
+	_, err = a.Generate(1)
+	assert.Equal(t, ErrNotReady, err)
+}
+
+func TestUpdate(t *testing.T) {
+	s := storage.NewTestStorage()
+	a := NewAllocator(s)
+	assert.NoError(t, a.initialize(context.Background()))
+
+	ts1, err := a.Generate(uint32(maxLogical/2 + 1))
+	assert.NoError(t, err)
+	// the physical time is advanced even if the clock is not, the logical is exhausted
+	assert.NoError(t, a.update())
+	ts2, err := a.Generate(1)
+	assert.NoError(t, err)
+	assert.True(t, ts2 > ts1)
+
+	// the window is saved before the physical time reaches the saved time
+	a.Lock()
+	a.lastSaved = a.physical.Add(guard)
+	a.Unlock()
+	time.Sleep(guard * 2)
+	assert.NoError(t, a.update())
+	saved, err := s.GetTimestamp()
+	assert.NoError(t, err)
+	assert.Equal(t, a.physical.Add(saveWindow).UnixNano(), saved.UnixNano())
+}
+
+func TestUpdateWithBackwardClock(t *testing.T) {
+	s := storage.NewTestStorage()
+	a := NewAllocator(s)
+	assert.NoError(t, a.initialize(context.Background()))
+	physical := a.physical
+	ts1, err := a.Generate(1)
+	assert.NoError(t, err)
+
+	// the wall clock goes backwards
+	a.now = func() time.Time { return physical.Add(-time.Second) }
+	assert.NoError(t, a.update())
+	assert.Equal(t, physical, a.physical)
+	ts2, err := a.Generate(1)
+	assert.NoError(t, err)
+	assert.True(t, ts2 > ts1)
+
+	// the physical time is advanced by the guard if the logical is exhausted
+	_, err = a.Generate(uint32(maxLogical / 2))
+	assert.NoError(t, err)
+	assert.NoError(t, a.update())
+	assert.Equal(t, physical.Add(guard), a.physical)
+	ts3, err := a.Generate(1)
+	assert.NoError(t, err)
+	assert.True(t, ts3 > ts2)
+}
+
+func TestInitializeWithBackwardClock(t *testing.T) {
+	s := storage.NewTestStorage()
+	saved := time.Now().Add(time.Millisecond * 200)
+	assert.NoError(t, s.PutTimestamp(saved))
+
+	// the wall clock is behind the saved time after the wait
+	a := NewAllocator(s)
+	a.now = func() time.Time { return saved.Add(-time.Second) }
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	assert.NoError(t, a.initialize(ctx))
+	ts, err := a.Generate(1)
+	assert.NoError(t, err)
+	assert.True(t, ts > Compose(saved, maxLogical-1))
+}
+
+func TestInitializeAfterSaved(t *testing.T) {
+	s := storage.NewTestStorage()
+	saved := time.Now().Add(time.Millisecond * 200)
+	assert.NoError(t, s.PutTimestamp(saved))
+
+	a := NewAllocator(s)
+	assert.NoError(t, a.initialize(context.Background()))
+	ts, err := a.Generate(1)
+	assert.NoError(t, err)
+	assert.True(t, ts > Compose(saved, maxLogical-1))
+
+	// the initialization is canceled
+	a = NewAllocator(s)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, a.initialize(ctx))
+}
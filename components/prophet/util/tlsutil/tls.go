@@ -0,0 +1,293 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+)
+
+var (
+	defaultReloadInterval = time.Second * 10
+	defaultDialTimeout    = time.Second * 10
+	// tokenSize the size of the token to authenticate the connections to the local tunnels
+	tokenSize = 32
+)
+
+// Config the tls config of the listeners and the dialers, all the listeners require tls and
+// all the dialers use tls if the CertFile is set.
+type Config struct {
+	// CertFile the certificate file, it's used by both the listeners and the dialers
+	CertFile string `toml:"cert-file"`
+	// KeyFile the private key file of the certificate
+	KeyFile string `toml:"key-file"`
+	// CAFile the CA file to verify the certificates of the remote side
+	CAFile string `toml:"ca-file"`
+	// RequireClientCert the listeners require and verify the certificates of the clients
+	RequireClientCert bool `toml:"require-client-cert"`
+	// ReloadInterval the interval to check whether the files changed, the new certificates are
+	// used by the new connections.
+	ReloadInterval typeutil.Duration `toml:"reload-interval"`
+}
+
+// Enabled returns true if the tls is enabled
+func (c Config) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// TLS creates the tls listeners and dialers by the config, and reloads the files if changed.
+// A nil TLS means the tls is disabled, the plain tcp listeners and dialers are used.
+type TLS struct {
+	cfg   Config
+	token []byte
+
+	mu struct {
+		sync.RWMutex
+
+		cert      *tls.Certificate
+		pool      *x509.CertPool
+		modTimes  [3]time.Time
+		lastCheck time.Time
+		tunnels   map[string]*tunnel
+		closed    bool
+	}
+}
+
+// New returns the TLS by the config, returns nil if the tls is disabled
+func New(cfg Config) (*TLS, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	if cfg.KeyFile == "" || cfg.CAFile == "" {
+		return nil, errors.New("tls requires the cert-file, key-file and ca-file")
+	}
+
+	if cfg.ReloadInterval.Duration == 0 {
+		cfg.ReloadInterval.Duration = defaultReloadInterval
+	}
+
+	t := &TLS{cfg: cfg, token: make([]byte, tokenSize)}
+	if _, err := rand.Read(t.token); err != nil {
+		return nil, err
+	}
+	t.mu.tunnels = make(map[string]*tunnel)
+	if err := t.reload(true); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// NewApplication returns the goetty application listen at the addr, the connections of the
+// application use tls if it's enabled.
+func (t *TLS) NewApplication(addr string, handleFunc func(goetty.IOSession, interface{}, uint64) error,
+	opts ...goetty.AppOption) (goetty.NetApplication, error) {
+	if t == nil {
+		return goetty.NewTCPApplication(addr, handleFunc, opts...)
+	}
+
+	l, err := net.Listen("tcp4", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return goetty.NewApplication(t.NewListener(l), handleFunc, opts...)
+}
+
+// NewListener returns the listener accepting the connections of l by tls if it's enabled, the
+// certificates of the clients are required if the RequireClientCert is set.
+func (t *TLS) NewListener(l net.Listener) net.Listener {
+	if t == nil {
+		return l
+	}
+
+	return tls.NewListener(l, &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return t.serverConfig()
+		},
+	})
+}
+
+// DialAddr returns the address for the goetty session to connect to the addr. The goetty session
+// only dials the plain tcp address, so a local tunnel is started to forward the connections to the
+// addr by tls if it's enabled. The tunnel only forwards the connections of the sessions created with
+// the SessionOptions.
+func (t *TLS) DialAddr(addr string) (string, error) {
+	if t == nil {
+		return addr, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.mu.closed {
+		return "", errors.New("tls is closed")
+	}
+
+	if tn, ok := t.mu.tunnels[addr]; ok {
+		return tn.addr(), nil
+	}
+
+	tn, err := newTunnel(t, addr)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.tunnels[addr] = tn
+	return tn.addr(), nil
+}
+
+// SessionOptions returns the options of the goetty sessions connecting to the address returned by
+// DialAddr, the sessions send the token of the tls once connected, and the local tunnels reject the
+// connections without the token.
+func (t *TLS) SessionOptions() []goetty.Option {
+	if t == nil {
+		return nil
+	}
+
+	return []goetty.Option{goetty.WithConnOptionFunc(t.sendToken)}
+}
+
+func (t *TLS) sendToken(conn net.Conn) {
+	conn.SetWriteDeadline(time.Now().Add(defaultDialTimeout))
+	defer conn.SetWriteDeadline(time.Time{})
+
+	// the tunnel closes the connection if the token is not received
+	if _, err := conn.Write(t.token); err != nil {
+		util.GetLogger().Errorf("send token to tunnel %s failed with %+v", conn.RemoteAddr(), err)
+	}
+}
+
+// Close closes all the local tunnels
+func (t *TLS) Close() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.mu.closed = true
+	for _, tn := range t.mu.tunnels {
+		tn.close()
+	}
+	t.mu.tunnels = nil
+}
+
+func (t *TLS) serverConfig() (*tls.Config, error) {
+	cert, pool, err := t.current()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if t.cfg.RequireClientCert {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+func (t *TLS) clientConfig(addr string) (*tls.Config, error) {
+	cert, pool, err := t.current()
+	if err != nil {
+		return nil, err
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		RootCAs:      pool,
+		ServerName:   host,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// current returns the current certificate and CA pool, the files are reloaded if changed
+func (t *TLS) current() (*tls.Certificate, *x509.CertPool, error) {
+	t.mu.RLock()
+	check := time.Since(t.mu.lastCheck) >= t.cfg.ReloadInterval.Duration
+	t.mu.RUnlock()
+
+	if check {
+		if err := t.reload(false); err != nil {
+			// keep the old certificates, the files may be in writing
+			util.GetLogger().Errorf("reload tls files failed with %+v", err)
+		}
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.mu.cert, t.mu.pool, nil
+}
+
+func (t *TLS) reload(force bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.mu.lastCheck = time.Now()
+	var modTimes [3]time.Time
+	for i, file := range []string{t.cfg.CertFile, t.cfg.KeyFile, t.cfg.CAFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	if !force && modTimes == t.mu.modTimes {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(t.cfg.CertFile, t.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	ca, err := ioutil.ReadFile(t.cfg.CAFile)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("no valid certificate in ca file %s", t.cfg.CAFile)
+	}
+
+	t.mu.cert = &cert
+	t.mu.pool = pool
+	t.mu.modTimes = modTimes
+	if !force {
+		util.GetLogger().Infof("tls files reloaded")
+	}
+	return nil
+}
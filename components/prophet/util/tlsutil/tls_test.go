@@ -0,0 +1,186 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/goetty"
+	"github.com/fagongzi/goetty/codec/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabled(t *testing.T) {
+	tt, err := New(Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, tt)
+
+	addr, err := tt.DialAddr("127.0.0.1:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:1", addr)
+	tt.Close()
+
+	_, err = New(Config{CertFile: "cert"})
+	assert.Error(t, err)
+}
+
+func TestTLSApplication(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := createTestConfig(t, dir)
+	cfg.RequireClientCert = true
+	server, err := New(cfg)
+	assert.NoError(t, err)
+	client, err := New(cfg)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	addr := fmt.Sprintf("127.0.0.1:%d", freePort(t))
+	encoder, decoder := simple.NewStringCodec()
+	app, err := server.NewApplication(addr, func(s goetty.IOSession, msg interface{}, _ uint64) error {
+		return s.WriteAndFlush(msg)
+	}, goetty.WithAppSessionOptions(goetty.WithCodec(encoder, decoder)))
+	assert.NoError(t, err)
+	assert.NoError(t, app.Start())
+	defer app.Stop()
+
+	// the plain tcp client is rejected
+	conn, err := net.Dial("tcp", addr)
+	assert.NoError(t, err)
+	conn.Write([]byte("hello"))
+	conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	_, err = conn.Read(make([]byte, 16))
+	assert.Error(t, err)
+	conn.Close()
+
+	dialAddr, err := client.DialAddr(addr)
+	assert.NoError(t, err)
+	assert.NotEqual(t, addr, dialAddr)
+
+	// the connection to the tunnel without the token is rejected
+	conn, err = net.Dial("tcp", dialAddr)
+	assert.NoError(t, err)
+	conn.Write(make([]byte, tokenSize))
+	conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	_, err = conn.Read(make([]byte, 16))
+	assert.Error(t, err)
+	conn.Close()
+
+	opts := append([]goetty.Option{goetty.WithCodec(encoder, decoder)}, client.SessionOptions()...)
+	s := goetty.NewIOSession(opts...)
+	ok, err := s.Connect(dialAddr, time.Second*5)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	defer s.Close()
+
+	assert.NoError(t, s.WriteAndFlush("hello"))
+	reply, err := s.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", reply)
+}
+
+func TestTLSListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := createTestConfig(t, dir)
+	cfg.RequireClientCert = true
+	tt, err := New(cfg)
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})}
+	go server.Serve(tt.NewListener(l))
+	defer server.Close()
+	addr := l.Addr().String()
+
+	get := func(scheme string, config *tls.Config) (int, error) {
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: config}, Timeout: time.Second * 5}
+		resp, err := c.Get(fmt.Sprintf("%s://%s", scheme, addr))
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	// the plain http client is rejected
+	code, err := get("http", nil)
+	assert.True(t, err != nil || code != http.StatusOK)
+
+	// the client without the certificate is rejected
+	config, err := tt.clientConfig(addr)
+	assert.NoError(t, err)
+	_, err = get("https", &tls.Config{RootCAs: config.RootCAs, ServerName: config.ServerName})
+	assert.Error(t, err)
+
+	code, err = get("https", config)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	// the listener is not changed if the tls is disabled
+	var disabled *TLS
+	assert.Equal(t, l, disabled.NewListener(l))
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := createTestConfig(t, dir)
+	cfg.ReloadInterval.Duration = time.Millisecond
+	tt, err := New(cfg)
+	assert.NoError(t, err)
+
+	old, _, _ := tt.current()
+	time.Sleep(time.Millisecond * 10)
+	createTestConfig(t, dir)
+	// the mod time of the files may be the same as before in some file systems
+	now := time.Now().Add(time.Second)
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.CAFile} {
+		assert.NoError(t, os.Chtimes(file, now, now))
+	}
+
+	time.Sleep(time.Millisecond * 10)
+	cert, _, _ := tt.current()
+	assert.NotEqual(t, old.Certificate[0], cert.Certificate[0])
+}
+
+func createTestConfig(t *testing.T, dir string) Config {
+	cfg, err := CreateTestFiles(dir)
+	assert.NoError(t, err)
+	return cfg
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
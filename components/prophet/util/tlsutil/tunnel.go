@@ -0,0 +1,158 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+)
+
+// tunnel listens at a local address, and forwards the accepted connections to the remote
+// address by tls. The local address is reachable by all the local processes, so only the
+// connections sending the token of the TLS first are forwarded.
+type tunnel struct {
+	t      *TLS
+	remote string
+	l      net.Listener
+
+	mu struct {
+		sync.Mutex
+
+		conns  map[net.Conn]struct{}
+		closed bool
+	}
+}
+
+func newTunnel(t *TLS, remote string) (*tunnel, error) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	tn := &tunnel{t: t, remote: remote, l: l}
+	tn.mu.conns = make(map[net.Conn]struct{})
+	go tn.accept()
+	return tn, nil
+}
+
+func (tn *tunnel) addr() string {
+	return tn.l.Addr().String()
+}
+
+func (tn *tunnel) close() {
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+
+	tn.mu.closed = true
+	tn.l.Close()
+	for conn := range tn.mu.conns {
+		conn.Close()
+	}
+}
+
+func (tn *tunnel) accept() {
+	for {
+		conn, err := tn.l.Accept()
+		if err != nil {
+			return
+		}
+
+		go tn.forward(conn)
+	}
+}
+
+func (tn *tunnel) forward(conn net.Conn) {
+	if !tn.authenticate(conn) {
+		util.GetLogger().Errorf("reject the connection from %s to tunnel %s without the valid token",
+			conn.RemoteAddr(),
+			tn.remote)
+		conn.Close()
+		return
+	}
+
+	cfg, err := tn.t.clientConfig(tn.remote)
+	if err != nil {
+		util.GetLogger().Errorf("create tls config to %s failed with %+v", tn.remote, err)
+		conn.Close()
+		return
+	}
+
+	remote, err := tls.DialWithDialer(&net.Dialer{Timeout: defaultDialTimeout}, "tcp", tn.remote, cfg)
+	if err != nil {
+		util.GetLogger().Errorf("tls dial to %s failed with %+v", tn.remote, err)
+		conn.Close()
+		return
+	}
+
+	if !tn.add(conn, remote) {
+		conn.Close()
+		remote.Close()
+		return
+	}
+	defer tn.remove(conn, remote)
+
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go pipe(remote, conn)
+	go pipe(conn, remote)
+
+	// close both sides if any side closed
+	<-done
+	conn.Close()
+	remote.Close()
+	<-done
+}
+
+func (tn *tunnel) authenticate(conn net.Conn) bool {
+	conn.SetReadDeadline(time.Now().Add(defaultDialTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	token := make([]byte, tokenSize)
+	if _, err := io.ReadFull(conn, token); err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(token, tn.t.token) == 1
+}
+
+func (tn *tunnel) add(conns ...net.Conn) bool {
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+
+	if tn.mu.closed {
+		return false
+	}
+
+	for _, conn := range conns {
+		tn.mu.conns[conn] = struct{}{}
+	}
+	return true
+}
+
+func (tn *tunnel) remove(conns ...net.Conn) {
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+
+	for _, conn := range conns {
+		delete(tn.mu.conns, conn)
+	}
+}
@@ -47,7 +47,7 @@ func newWatcher(flag uint32, client *asyncClient) Watcher {
 		flag:   flag,
 		client: client,
 		eventC: make(chan rpcpb.EventNotify, 128),
-		conn:   createConn(),
+		conn:   createConn(client.opts.tls),
 	}
 
 	go w.watchDog()
@@ -18,9 +18,12 @@ import (
 	"path"
 	"time"
 
+	"github.com/matrixorigin/matrixcube/auth"
 	"github.com/matrixorigin/matrixcube/aware"
+	"github.com/matrixorigin/matrixcube/backup"
 	pconfig "github.com/matrixorigin/matrixcube/components/prophet/config"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
@@ -46,14 +49,18 @@ var (
 	defaultCompactDuration                 = time.Second * 30
 	defaultShardSplitCheckDuration         = time.Second * 30
 	defaultShardStateCheckDuration         = time.Second * 60
+	defaultConsistencyCheckDuration        = time.Hour
 	defaultMaxEntryBytes                   = 10 * mb
 	defaultShardCapacityBytes       uint64 = uint64(96 * mb)
 	defaultMaxAllowTransferLag      uint64 = 2
+	defaultMaxLearnerReadLag        uint64 = 1024
 	defaultCompactThreshold         uint64 = 256
+	defaultSlowApplyQueueThreshold  uint64 = 1024
 	defaultRaftTickDuration                = time.Second
 	defaultMaxPeerDownTime                 = time.Minute * 30
 	defaultShardHeartbeatDuration          = time.Second * 2
 	defaultStoreHeartbeatDuration          = time.Second * 10
+	defaultSlowLatencyThreshold            = time.Millisecond * 500
 	defaultMaxInflightMsgs                 = 8
 	defaultDataPath                        = "/tmp/matrixcube"
 	defaultSnapshotDirName                 = "snapshots"
@@ -81,6 +88,12 @@ type Config struct {
 	Raft RaftConfig `toml:"raft"`
 	// Worker worker config
 	Worker WorkerConfig `toml:"worker"`
+	// TLS the tls config of the raft transport and the client rpc, the prophet
+	// uses the same config if its own tls config is not set
+	TLS tlsutil.Config `toml:"tls"`
+	// InternalToken the token of the requests created by the stores themselves, e.g. the requests
+	// of the jobs. It's required if the Customize.CustomAuthenticator is set.
+	InternalToken string `toml:"internal-token"`
 	// Prophet prophet config
 	Prophet pconfig.Config `toml:"prophet"`
 	// Storage config
@@ -132,6 +145,9 @@ func (c *Config) Adjust() {
 	(&c.Raft).adjust(uint64(c.Replication.ShardCapacityBytes))
 	c.Prophet.DataDir = path.Join(c.DataPath, defaultProphetDirName)
 	c.Prophet.ContainerHeartbeatDataProcessor = c.Customize.CustomStoreHeartbeatDataProcessor
+	if !c.Prophet.TLS.Enabled() {
+		c.Prophet.TLS = c.TLS
+	}
 	(&c.Prophet).Adjust(nil, false)
 	(&c.Worker).adjust()
 
@@ -162,6 +178,18 @@ func (c *Config) validate() {
 	if c.Storage.ForeachDataStorageFunc == nil {
 		log.Panicf("missing Config.Storage.ForeachDataStorageFunc")
 	}
+
+	if !c.Metric.ValidMode() {
+		log.Panicf("invalid Config.Metric.Mode %s, must be push, pull or both", c.Metric.Mode)
+	}
+
+	if c.Metric.EnablePull() && c.Metric.ListenAddr == "" {
+		log.Panicf("missing Config.Metric.ListenAddr in %s mode", c.Metric.Mode)
+	}
+
+	if c.Customize.CustomAuthenticator != nil && c.InternalToken == "" {
+		log.Panicf("missing Config.InternalToken with Config.Customize.CustomAuthenticator")
+	}
 }
 
 // SnapshotDir returns snapshot dir
@@ -171,15 +199,23 @@ func (c *Config) SnapshotDir() string {
 
 // ReplicationConfig replication config
 type ReplicationConfig struct {
-	MaxPeerDownTime         typeutil.Duration `toml:"max-peer-down-time"`
-	ShardHeartbeatDuration  typeutil.Duration `toml:"shard-heartbeat-duration"`
-	StoreHeartbeatDuration  typeutil.Duration `toml:"store-heartbeat-duration"`
-	ShardSplitCheckDuration typeutil.Duration `toml:"shard-split-check-duration"`
-	ShardStateCheckDuration typeutil.Duration `toml:"shard-state-check-duration"`
-	DisableShardSplit       bool              `toml:"disable-shard-split"`
-	AllowRemoveLeader       bool              `toml:"allow-remove-leader"`
-	ShardCapacityBytes      typeutil.ByteSize `toml:"shard-capacity-bytes"`
-	ShardSplitCheckBytes    typeutil.ByteSize `toml:"shard-split-check-bytes"`
+	MaxPeerDownTime          typeutil.Duration `toml:"max-peer-down-time"`
+	ShardHeartbeatDuration   typeutil.Duration `toml:"shard-heartbeat-duration"`
+	StoreHeartbeatDuration   typeutil.Duration `toml:"store-heartbeat-duration"`
+	ShardSplitCheckDuration  typeutil.Duration `toml:"shard-split-check-duration"`
+	ShardStateCheckDuration  typeutil.Duration `toml:"shard-state-check-duration"`
+	ConsistencyCheckDuration typeutil.Duration `toml:"consistency-check-duration"`
+	DisableConsistencyCheck  bool              `toml:"disable-consistency-check"`
+	DisableShardSplit        bool              `toml:"disable-shard-split"`
+	AllowRemoveLeader        bool              `toml:"allow-remove-leader"`
+	ShardCapacityBytes       typeutil.ByteSize `toml:"shard-capacity-bytes"`
+	ShardSplitCheckBytes     typeutil.ByteSize `toml:"shard-split-check-bytes"`
+	// SlowStoreLatencyThreshold the raft log append or apply slower than this is counted
+	// as a slow operation when computing the slow score of the store.
+	SlowStoreLatencyThreshold typeutil.Duration `toml:"slow-store-latency-threshold"`
+	// SlowStoreApplyQueueThreshold the store is considered slow if the number of the pending
+	// apply jobs is not less than this.
+	SlowStoreApplyQueueThreshold uint64 `toml:"slow-store-apply-queue-threshold"`
 }
 
 func (c *ReplicationConfig) adjust() {
@@ -203,6 +239,10 @@ func (c *ReplicationConfig) adjust() {
 		c.ShardStateCheckDuration.Duration = defaultShardStateCheckDuration
 	}
 
+	if c.ConsistencyCheckDuration.Duration == 0 {
+		c.ConsistencyCheckDuration.Duration = defaultConsistencyCheckDuration
+	}
+
 	if c.ShardCapacityBytes == 0 {
 		c.ShardCapacityBytes = typeutil.ByteSize(defaultShardCapacityBytes)
 	}
@@ -210,6 +250,14 @@ func (c *ReplicationConfig) adjust() {
 	if c.ShardSplitCheckBytes == 0 {
 		c.ShardSplitCheckBytes = c.ShardCapacityBytes * 80 / 100
 	}
+
+	if c.SlowStoreLatencyThreshold.Duration == 0 {
+		c.SlowStoreLatencyThreshold.Duration = defaultSlowLatencyThreshold
+	}
+
+	if c.SlowStoreApplyQueueThreshold == 0 {
+		c.SlowStoreApplyQueueThreshold = defaultSlowApplyQueueThreshold
+	}
 }
 
 // SnapshotConfig snapshot config
@@ -274,6 +322,20 @@ type RaftConfig struct {
 	MaxEntryBytes typeutil.ByteSize `toml:"max-entry-bytes"`
 	// SendRaftBatchSize raft message sender count
 	SendRaftBatchSize uint64 `toml:"send-raft-batch-size"`
+	// MaxLearnerReadLag a learner serves the read requests which allow follower read locally
+	// if its applied index is behind the committed index at most MaxLearnerReadLag entries.
+	MaxLearnerReadLag uint64 `toml:"max-learner-read-lag"`
+	// MaxLearnerReadStaleness a learner serves the read requests which allow follower read locally
+	// only if it received the message from the leader within MaxLearnerReadStaleness, a partitioned
+	// learner stops serving the reads. Default is the election timeout.
+	MaxLearnerReadStaleness typeutil.Duration `toml:"max-learner-read-staleness"`
+	// EnableLeaseRead the leader serves the reads locally within the leader lease, the lease
+	// is derived from the election timeout and the last heartbeat acked by the quorum. The
+	// reads fall back to ReadIndex if the lease is invalid.
+	EnableLeaseRead bool `toml:"enable-lease-read"`
+	// LeaseMaxClockDrift the max clock drift between the leader and the followers during the
+	// lease, the lease is shortened by it. Default is one tick interval.
+	LeaseMaxClockDrift typeutil.Duration `toml:"lease-max-clock-drift"`
 	// RaftLog raft log 配置
 	RaftLog RaftLogConfig `toml:"raft-log"`
 }
@@ -313,6 +375,18 @@ func (c *RaftConfig) adjust(shardCapacityBytes uint64) {
 		c.MaxEntryBytes = typeutil.ByteSize(defaultMaxEntryBytes)
 	}
 
+	if c.MaxLearnerReadLag == 0 {
+		c.MaxLearnerReadLag = defaultMaxLearnerReadLag
+	}
+
+	if c.MaxLearnerReadStaleness.Duration == 0 {
+		c.MaxLearnerReadStaleness.Duration = c.GetElectionTimeoutDuration()
+	}
+
+	if c.LeaseMaxClockDrift.Duration == 0 {
+		c.LeaseMaxClockDrift.Duration = c.TickInterval.Duration
+	}
+
 	(&c.RaftLog).adjust(shardCapacityBytes)
 }
 
@@ -326,6 +400,9 @@ type RaftLogConfig struct {
 	ForceCompactCount     uint64
 	ForceCompactBytes     uint64
 	CompactProtectLag     uint64
+	// RetainCount the number of the applied entries kept by the raft log compaction, the change
+	// subscribers can resume from the retained entries.
+	RetainCount uint64 `toml:"retain-count"`
 }
 
 func (c *RaftLogConfig) adjust(shardCapacityBytes uint64) {
@@ -358,9 +435,13 @@ func (c *RaftLogConfig) adjust(shardCapacityBytes uint64) {
 type StorageConfig struct {
 	// MetaStorage used to store raft, shards and store's metadata
 	MetaStorage storage.MetadataStorage
+	// RaftLogStorage used to store the raft log entries, the raft log entries are stored
+	// in the MetaStorage if it's nil.
+	RaftLogStorage storage.RaftLogStorage
 	// DataStorageFactory is a storage factory  to store application's data
 	DataStorageFactory func(group uint64, shardID uint64) storage.DataStorage
-	// DataMoveFunc move data from a storage to others
+	// DataMoveFunc move data from a storage to others, it will be called after the shard
+	// splited or merged into the target shard
 	DataMoveFunc func(bhmetapb.Shard, []bhmetapb.Shard) error
 	// ForeachDataStorageFunc do in every storage
 	ForeachDataStorageFunc func(cb func(storage.DataStorage))
@@ -396,6 +477,18 @@ type CustomizeConfig struct {
 	// CustomShardPoolShardFactory is factory create a shard used by shard pool, `start, end and unique` is created by
 	// `ShardPool` based on `offsetInPool`, these can be modified, provided that the only non-conflict.
 	CustomShardPoolShardFactory func(g uint64, start, end []byte, unique string, offsetInPool uint64) bhmetapb.Shard
+	// CustomBackupStorageFactory is factory create a backup.Storage to save the backup files at the path, the backup
+	// files are saved in the local dir of the path if it's nil.
+	CustomBackupStorageFactory func(path string) (backup.Storage, error)
+	// CustomInitShardsRestoreFunc is called with the init shards provided by CustomInitShardsFactory, which the ids
+	// are allocated, before the store bootstraps the cluster with them. It restores the data of the init shards.
+	CustomInitShardsRestoreFunc func(shards []bhmetapb.Shard) error
+	// CustomAuthenticator validates the tokens of the requests received by the client rpc and the application,
+	// all the requests are allowed if it's nil.
+	CustomAuthenticator auth.Authenticator
+	// CustomAuthorizer checks the permissions of the authenticated users, all the requests of the authenticated
+	// users are allowed if it's nil.
+	CustomAuthorizer auth.Authorizer
 }
 
 // GetLabels returns lables
@@ -17,12 +17,45 @@ import (
 	"os"
 )
 
+const (
+	// ModePush push metrics to the prometheus pushgateway
+	ModePush = "push"
+	// ModePull serve metrics on the `/metrics` http listener for prometheus pull
+	ModePull = "pull"
+	// ModeBoth push and pull
+	ModeBoth = "both"
+)
+
 // Cfg metric cfg
 type Cfg struct {
+	// Mode push, pull or both, default is push
+	Mode     string `toml:"mode"`
 	Addr     string `toml:"addr"`
 	Interval int    `toml:"interval"`
 	Job      string `toml:"job"`
 	Instance string `toml:"instance"`
+	// ListenAddr the http listener address which serves `/metrics` in pull mode
+	ListenAddr string `toml:"listen-addr"`
+}
+
+// ValidMode returns true if the mode is empty or one of push, pull and both
+func (c Cfg) ValidMode() bool {
+	switch c.Mode {
+	case "", ModePush, ModePull, ModeBoth:
+		return true
+	}
+	return false
+}
+
+// EnablePush returns true if the metrics need to be pushed to the pushgateway, the
+// pushgateway address must be configured
+func (c Cfg) EnablePush() bool {
+	return c.Addr != "" && (c.Mode == "" || c.Mode == ModePush || c.Mode == ModeBoth)
+}
+
+// EnablePull returns true if the metrics need to be served for prometheus pull
+func (c Cfg) EnablePull() bool {
+	return c.Mode == ModePull || c.Mode == ModeBoth
 }
 
 func (c Cfg) instance() string {
@@ -31,11 +31,16 @@ func init() {
 	registry.MustRegister(batchGauge)
 	registry.MustRegister(storeStorageGauge)
 	registry.MustRegister(shardCountGauge)
+	registry.MustRegister(snapshotTransferGauge)
 
 	registry.MustRegister(raftReadyCounter)
 	registry.MustRegister(raftMsgsCounter)
 	registry.MustRegister(raftCommandCounter)
 	registry.MustRegister(raftAdminCommandCounter)
+	registry.MustRegister(snapshotTransferCounter)
+	registry.MustRegister(snapshotTransferBytesCounter)
+	registry.MustRegister(rateLimitCounter)
+	registry.MustRegister(rateLimitBytesCounter)
 
 	registry.MustRegister(raftLogLagHistogram)
 	registry.MustRegister(raftLogAppendDurationHistogram)
@@ -44,4 +49,5 @@ func init() {
 	registry.MustRegister(snapshotSizeHistogram)
 	registry.MustRegister(snapshotBuildingDurationHistogram)
 	registry.MustRegister(snapshotSendingDurationHistogram)
+	registry.MustRegister(followerReadIndexWaitDurationHistogram)
 }
@@ -49,6 +49,38 @@ var (
 			Name:      "command_admin_total",
 			Help:      "Total number of admin commands processed.",
 		}, []string{"type", "status"})
+
+	snapshotTransferCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "snapshot_transfer_total",
+			Help:      "Total number of snapshot transfer events.",
+		}, []string{"type"})
+
+	snapshotTransferBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "snapshot_transfer_bytes_total",
+			Help:      "Total bytes of snapshot data transferred.",
+		}, []string{"type"})
+
+	rateLimitCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "rate_limit_requests_total",
+			Help:      "Total number of requests checked by the rate limits.",
+		}, []string{"group", "tenant", "result"})
+
+	rateLimitBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "rate_limit_bytes_total",
+			Help:      "Total bytes of written requests admitted by the rate limits.",
+		}, []string{"group", "tenant"})
 )
 
 // IncComandCount inc the command received
@@ -126,6 +158,21 @@ func AddRaftProposalReadIndexCount(value uint64) {
 	raftMsgsCounter.WithLabelValues("read-index").Add(float64(value))
 }
 
+// AddRaftProposalReadLearnerCount add read on learner
+func AddRaftProposalReadLearnerCount(value uint64) {
+	raftMsgsCounter.WithLabelValues("read-learner").Add(float64(value))
+}
+
+// AddRaftProposalReadLeaseCount add read with the leader lease
+func AddRaftProposalReadLeaseCount(value uint64) {
+	raftMsgsCounter.WithLabelValues("read-lease").Add(float64(value))
+}
+
+// AddRaftProposalFollowerReadIndexCount add read index issued by the follower
+func AddRaftProposalFollowerReadIndexCount(value uint64) {
+	raftMsgsCounter.WithLabelValues("read-index-follower").Add(float64(value))
+}
+
 // AddRaftProposalNormalCount add normal
 func AddRaftProposalNormalCount(value uint64) {
 	raftMsgsCounter.WithLabelValues("normal").Add(float64(value))
@@ -166,6 +213,37 @@ func AddRaftAdminCommandSplitSucceedCount(value uint64) {
 	raftAdminCommandCounter.WithLabelValues("split", "succeed").Add(float64(value))
 }
 
+// AddRaftAdminCommandMergeCount admin command of merge shard
+func AddRaftAdminCommandMergeCount(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("merge", "total").Add(float64(value))
+}
+
+// AddRaftAdminCommandMergeSucceedCount admin command of merge shard succeed
+func AddRaftAdminCommandMergeSucceedCount(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("merge", "succeed").Add(float64(value))
+}
+
+// AddRaftAdminCommandMergeRollbackCount admin command of merge shard rollback
+func AddRaftAdminCommandMergeRollbackCount(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("merge", "rollback").Add(float64(value))
+}
+
+// AddRaftAdminCommandConsistencyCheckCount admin command of consistency check
+func AddRaftAdminCommandConsistencyCheckCount(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("consistency", "total").Add(float64(value))
+}
+
+// AddRaftAdminCommandConsistencyCheckSucceedCount admin command of consistency check succeed
+func AddRaftAdminCommandConsistencyCheckSucceedCount(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("consistency", "succeed").Add(float64(value))
+}
+
+// AddRaftAdminCommandConsistencyCheckFailedCount admin command of consistency check failed, the
+// data of the replicas are inconsistent
+func AddRaftAdminCommandConsistencyCheckFailedCount(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("consistency", "failed").Add(float64(value))
+}
+
 // AddRaftAdminCommandCompactCount admin command of compact raft log
 func AddRaftAdminCommandCompactCount(value uint64) {
 	raftAdminCommandCounter.WithLabelValues("compact", "succeed").Add(float64(value))
@@ -175,3 +253,46 @@ func AddRaftAdminCommandCompactCount(value uint64) {
 func AddRaftAdminCommandCompactSucceedCount(value uint64) {
 	raftAdminCommandCounter.WithLabelValues("compact", "succeed").Add(float64(value))
 }
+
+// AddSnapshotSentBytes add the bytes of the sent snapshot chunks
+func AddSnapshotSentBytes(value uint64) {
+	snapshotTransferBytesCounter.WithLabelValues("sent").Add(float64(value))
+}
+
+// AddSnapshotReceivedBytes add the bytes of the received snapshot chunks
+func AddSnapshotReceivedBytes(value uint64) {
+	snapshotTransferBytesCounter.WithLabelValues("received").Add(float64(value))
+}
+
+// AddSnapshotResumedCount add the snapshot transfers resumed from the received offset
+func AddSnapshotResumedCount(value uint64) {
+	snapshotTransferCounter.WithLabelValues("resumed").Add(float64(value))
+}
+
+// AddSnapshotReceivedCount add the snapshots received completely
+func AddSnapshotReceivedCount(value uint64) {
+	snapshotTransferCounter.WithLabelValues("received").Add(float64(value))
+}
+
+// AddSnapshotChunkCheckSumFailedCount add the received snapshot chunks with mismatched checksum
+func AddSnapshotChunkCheckSumFailedCount(value uint64) {
+	snapshotTransferCounter.WithLabelValues("chunk-checksum-failed").Add(float64(value))
+}
+
+// AddSnapshotCheckSumFailedCount add the received snapshot files with mismatched checksum
+func AddSnapshotCheckSumFailedCount(value uint64) {
+	snapshotTransferCounter.WithLabelValues("checksum-failed").Add(float64(value))
+}
+
+// AddRateLimitAdmittedCount add the request admitted by the rate limit of the tenant in the group
+func AddRateLimitAdmittedCount(group, tenant string, bytes uint64) {
+	rateLimitCounter.WithLabelValues(group, tenant, "admitted").Inc()
+	if bytes > 0 {
+		rateLimitBytesCounter.WithLabelValues(group, tenant).Add(float64(bytes))
+	}
+}
+
+// AddRateLimitRejectedCount add the request rejected by the rate limit of the tenant in the group
+func AddRateLimitRejectedCount(group, tenant string) {
+	rateLimitCounter.WithLabelValues(group, tenant, "rejected").Inc()
+}
@@ -49,6 +49,14 @@ var (
 			Name:      "store_storage_bytes",
 			Help:      "Size of raftstore storage.",
 		}, []string{"type"})
+
+	snapshotTransferGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "snapshot_transfer_pending_bytes",
+			Help:      "Bytes of the snapshots waiting to be transferred.",
+		}, []string{"type"})
 )
 
 // SetRaftMsgQueueMetric set send raft message queue size
@@ -102,3 +110,9 @@ func SetStorageOnStore(total uint64, free uint64) {
 	storeStorageGauge.WithLabelValues("total").Set(float64(total))
 	storeStorageGauge.WithLabelValues("free").Set(float64(free))
 }
+
+// AddSnapshotSendingPendingBytes add the bytes of the snapshots waiting to be sent, use the
+// negative value to sub the sent bytes
+func AddSnapshotSendingPendingBytes(value int64) {
+	snapshotTransferGauge.WithLabelValues("sending").Add(float64(value))
+}
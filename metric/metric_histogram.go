@@ -73,6 +73,15 @@ var (
 			Help:      "Bucketed histogram of server send snapshots duration.",
 		})
 
+	followerReadIndexWaitDurationHistogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "follower_read_index_wait_duration_seconds",
+			Help:      "Bucketed histogram of follower waiting the read index and the apply duration.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2.0, 20),
+		})
+
 	raftLogLagHistogram = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "matrixcube",
@@ -113,6 +122,12 @@ func ObserveRaftLogApplyDuration(start time.Time) {
 	raftLogApplyDurationHistogram.Observe(time.Now().Sub(start).Seconds())
 }
 
+// ObserveFollowerReadIndexWaitDuration observe seconds of the follower waiting the read index
+// and the apply
+func ObserveFollowerReadIndexWaitDuration(start time.Time) {
+	followerReadIndexWaitDurationHistogram.Observe(time.Now().Sub(start).Seconds())
+}
+
 // ObserveRaftLogLag observe raft log lag
 func ObserveRaftLogLag(size uint64) {
 	raftLogLagHistogram.Observe(float64(size))
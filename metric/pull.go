@@ -0,0 +1,58 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// PullPath the http path of the prometheus pull
+	PullPath = "/metrics"
+)
+
+// Handler returns the http handler which serves the cube metrics and the prophet
+// metrics registered on the prometheus default registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(prometheus.Gatherers{registry, prometheus.DefaultGatherer}, promhttp.HandlerOpts{})
+}
+
+// StartPull start a http listener to serve the metrics for prometheus pull, the returned
+// server is used to stop the listener.
+func StartPull(cfg Cfg) (*http.Server, error) {
+	logger.Infof("start serve metric at %s%s for prometheus pull",
+		cfg.ListenAddr,
+		PullPath)
+
+	l, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(PullPath, Handler())
+	s := &http.Server{Handler: mux}
+	go func() {
+		if err := s.Serve(l); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("serve metric at %s failed with %+v",
+				cfg.ListenAddr,
+				err)
+		}
+	}()
+	return s, nil
+}
@@ -24,17 +24,18 @@ var (
 	logger = log.NewLoggerWithPrefix("[matrixcube-metric]")
 )
 
-// StartPush start push metric
-func StartPush(cfg Cfg) {
+// StartPush start push metric, the returned func is used to stop the push
+func StartPush(cfg Cfg) func() {
+	if cfg.Interval == 0 || cfg.Addr == "" || cfg.Job == "" {
+		return func() {}
+	}
+
 	logger.Infof("start push job %s metric to prometheus pushgateway %s, interval %d seconds",
 		cfg.Job,
 		cfg.Addr,
 		cfg.Interval)
 
-	if cfg.Interval == 0 || cfg.Addr == "" || cfg.Job == "" {
-		return
-	}
-
+	stopC := make(chan struct{})
 	pusher := push.New(cfg.Addr, cfg.Job).
 		Gatherer(registry).
 		Grouping("instance", cfg.instance())
@@ -44,6 +45,8 @@ func StartPush(cfg Cfg) {
 
 		for {
 			select {
+			case <-stopC:
+				return
 			case <-timer.C:
 				err := pusher.Push()
 				if err != nil {
@@ -54,4 +57,7 @@ func StartPush(cfg Cfg) {
 			}
 		}
 	}()
+	return func() {
+		close(stopC)
+	}
 }
@@ -0,0 +1,64 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartPushWithoutAddr(t *testing.T) {
+	cfg := Cfg{Interval: 1, Job: "test"}
+	assert.False(t, cfg.EnablePush())
+
+	before := runtime.NumGoroutine()
+	stop := StartPush(cfg)
+	assert.Equal(t, before, runtime.NumGoroutine())
+	stop()
+}
+
+func TestStopPush(t *testing.T) {
+	var pushed uint64
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddUint64(&pushed, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	cfg := Cfg{Addr: gateway.URL, Interval: 1, Job: "test", Instance: "test"}
+	assert.True(t, cfg.EnablePush())
+
+	stop := StartPush(cfg)
+	timeout := time.After(time.Second * 10)
+	for atomic.LoadUint64(&pushed) == 0 {
+		select {
+		case <-timeout:
+			assert.FailNow(t, "wait push timeout")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	stop()
+	// the push in flight when stopping may still be received
+	time.Sleep(time.Millisecond * 100)
+	n := atomic.LoadUint64(&pushed)
+	time.Sleep(time.Duration(cfg.Interval) * time.Second * 3)
+	assert.Equal(t, n, atomic.LoadUint64(&pushed))
+}
@@ -770,6 +770,174 @@ func (m *ShardsPoolAllocCmd) GetPurpose() []byte {
 	return nil
 }
 
+// BackupJob the job to backup the cluster
+type BackupJob struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FrozenGroups         []uint64 `protobuf:"varint,2,rep,packed,name=frozenGroups,proto3" json:"frozenGroups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupJob) Reset()         { *m = BackupJob{} }
+func (m *BackupJob) String() string { return proto.CompactTextString(m) }
+func (*BackupJob) ProtoMessage()    {}
+func (*BackupJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75f1d28c03f69d97, []int{10}
+}
+func (m *BackupJob) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BackupJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BackupJob.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BackupJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupJob.Merge(m, src)
+}
+func (m *BackupJob) XXX_Size() int {
+	return m.Size()
+}
+func (m *BackupJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupJob proto.InternalMessageInfo
+
+func (m *BackupJob) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *BackupJob) GetFrozenGroups() []uint64 {
+	if m != nil {
+		return m.FrozenGroups
+	}
+	return nil
+}
+
+// ShardBackup the backup of a shard at the applied index
+type ShardBackup struct {
+	Shard                Shard    `protobuf:"bytes,1,opt,name=shard,proto3" json:"shard"`
+	Index                uint64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	File                 string   `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShardBackup) Reset()         { *m = ShardBackup{} }
+func (m *ShardBackup) String() string { return proto.CompactTextString(m) }
+func (*ShardBackup) ProtoMessage()    {}
+func (*ShardBackup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75f1d28c03f69d97, []int{11}
+}
+func (m *ShardBackup) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardBackup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardBackup.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardBackup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardBackup.Merge(m, src)
+}
+func (m *ShardBackup) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardBackup) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardBackup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardBackup proto.InternalMessageInfo
+
+func (m *ShardBackup) GetShard() Shard {
+	if m != nil {
+		return m.Shard
+	}
+	return Shard{}
+}
+
+func (m *ShardBackup) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ShardBackup) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+// BackupMeta the metadata of the cluster backup
+type BackupMeta struct {
+	Shards               []ShardBackup `protobuf:"bytes,1,rep,name=shards,proto3" json:"shards"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BackupMeta) Reset()         { *m = BackupMeta{} }
+func (m *BackupMeta) String() string { return proto.CompactTextString(m) }
+func (*BackupMeta) ProtoMessage()    {}
+func (*BackupMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75f1d28c03f69d97, []int{12}
+}
+func (m *BackupMeta) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BackupMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BackupMeta.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BackupMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupMeta.Merge(m, src)
+}
+func (m *BackupMeta) XXX_Size() int {
+	return m.Size()
+}
+func (m *BackupMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupMeta proto.InternalMessageInfo
+
+func (m *BackupMeta) GetShards() []ShardBackup {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("bhmetapb.ShardsPoolCmdType", ShardsPoolCmdType_name, ShardsPoolCmdType_value)
 	proto.RegisterType((*StoreIdent)(nil), "bhmetapb.StoreIdent")
@@ -783,68 +951,77 @@ func init() {
 	proto.RegisterType((*ShardsPoolCmd)(nil), "bhmetapb.ShardsPoolCmd")
 	proto.RegisterType((*ShardsPoolCreateCmd)(nil), "bhmetapb.ShardsPoolCreateCmd")
 	proto.RegisterType((*ShardsPoolAllocCmd)(nil), "bhmetapb.ShardsPoolAllocCmd")
+	proto.RegisterType((*BackupJob)(nil), "bhmetapb.BackupJob")
+	proto.RegisterType((*ShardBackup)(nil), "bhmetapb.ShardBackup")
+	proto.RegisterType((*BackupMeta)(nil), "bhmetapb.BackupMeta")
 }
 
 func init() { proto.RegisterFile("bhmetapb.proto", fileDescriptor_75f1d28c03f69d97) }
 
 var fileDescriptor_75f1d28c03f69d97 = []byte{
-	// 888 bytes of a gzipped FileDescriptorProto
+	// 984 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x0e, 0xf5, 0x67, 0x6b, 0xe4, 0x38, 0xce, 0xba, 0x31, 0x16, 0xae, 0x2b, 0x0b, 0x3c, 0xb1,
-	0x69, 0x2a, 0xb5, 0x6a, 0x03, 0x14, 0xbd, 0xd9, 0x52, 0xd0, 0x18, 0x68, 0x51, 0x63, 0x9d, 0x17,
-	0x58, 0x92, 0x23, 0x69, 0x51, 0x8a, 0xcb, 0xec, 0x2e, 0x03, 0xeb, 0x09, 0x7a, 0xef, 0x6b, 0xf4,
-	0x45, 0xd2, 0x5b, 0x6e, 0xbd, 0x05, 0xad, 0x9f, 0x24, 0xd8, 0x25, 0x29, 0x51, 0xb6, 0x72, 0x11,
-	0xe6, 0x9b, 0xf9, 0x66, 0x96, 0xf3, 0xcd, 0xee, 0x08, 0x0e, 0xc3, 0xc5, 0x12, 0x0d, 0xcf, 0xc2,
-	0x61, 0xa6, 0xa4, 0x91, 0x64, 0xbf, 0xc2, 0xa7, 0xdf, 0xce, 0x85, 0x59, 0xe4, 0xe1, 0x30, 0x92,
-	0xcb, 0xd1, 0x5c, 0xce, 0xe5, 0xc8, 0x11, 0xc2, 0x7c, 0xe6, 0x90, 0x03, 0xce, 0x2a, 0x12, 0x4f,
-	0x7f, 0xad, 0xd1, 0x97, 0xdc, 0x28, 0x71, 0x2b, 0x95, 0x98, 0x8b, 0xb4, 0x04, 0x51, 0x1e, 0xe2,
-	0x28, 0x92, 0xcb, 0x4c, 0xa6, 0x98, 0x1a, 0x6d, 0x8b, 0x65, 0x0b, 0x34, 0xa3, 0x2c, 0x1c, 0x15,
-	0xe7, 0x8d, 0xea, 0x9f, 0xe1, 0x4f, 0x01, 0x6e, 0x8c, 0x54, 0x78, 0x15, 0x63, 0x6a, 0xc8, 0x19,
-	0x74, 0xa3, 0x24, 0xd7, 0x06, 0xd5, 0xd5, 0x94, 0x7a, 0x03, 0x2f, 0x68, 0xb1, 0x8d, 0x83, 0x50,
-	0xd8, 0xd3, 0x8e, 0x3b, 0xa5, 0x0d, 0x17, 0xab, 0xa0, 0x3f, 0x81, 0xbd, 0x49, 0x41, 0x23, 0x27,
-	0xd0, 0x10, 0x71, 0x91, 0x7b, 0xd9, 0xb9, 0xfb, 0x78, 0xde, 0xb8, 0x9a, 0xb2, 0x86, 0x88, 0xc9,
-	0x00, 0x7a, 0x4b, 0x7e, 0xcb, 0x30, 0x4b, 0x44, 0xc4, 0xb5, 0x2b, 0xf0, 0x98, 0xd5, 0x5d, 0xfe,
-	0xbf, 0x0d, 0x68, 0xdf, 0x2c, 0xb8, 0x8a, 0x3f, 0x5b, 0xe3, 0x0b, 0x68, 0x6b, 0xc3, 0x95, 0x71,
-	0xd9, 0x07, 0xac, 0x00, 0xe4, 0x08, 0x9a, 0x98, 0xc6, 0xb4, 0xe9, 0x7c, 0xd6, 0x24, 0xdf, 0x43,
-	0x1b, 0x33, 0x19, 0x2d, 0x68, 0x6b, 0xe0, 0x05, 0xbd, 0xf1, 0xb3, 0x61, 0xd9, 0x32, 0x43, 0x2d,
-	0x73, 0x15, 0xe1, 0x2b, 0x1b, 0xbc, 0x6c, 0xbd, 0xff, 0x78, 0xfe, 0x88, 0x15, 0x4c, 0xf2, 0x8d,
-	0x2b, 0x6d, 0x90, 0xb6, 0x07, 0x5e, 0x70, 0xf8, 0x30, 0xe5, 0xc6, 0x06, 0x59, 0xc1, 0x21, 0x01,
-	0xb4, 0x33, 0x44, 0xa5, 0x69, 0x67, 0xd0, 0x0c, 0x7a, 0xe3, 0x83, 0x8a, 0x7c, 0x8d, 0xa8, 0xaa,
-	0xb2, 0x8e, 0x40, 0x7c, 0x38, 0x88, 0x85, 0xe6, 0x61, 0x82, 0x37, 0x59, 0x22, 0x0c, 0xdd, 0x1b,
-	0x78, 0xc1, 0x3e, 0xdb, 0xf2, 0xd9, 0xae, 0xe6, 0x4a, 0xe6, 0x19, 0xdd, 0x77, 0xa2, 0x16, 0x80,
-	0x9c, 0x40, 0x27, 0x4f, 0xc5, 0xdb, 0x1c, 0x29, 0x0c, 0xbc, 0xa0, 0xcb, 0x4a, 0x44, 0xfa, 0x00,
-	0x2a, 0x4f, 0xf0, 0x17, 0x4b, 0xd2, 0xb4, 0x37, 0x68, 0x06, 0x5d, 0x56, 0xf3, 0x10, 0x02, 0xad,
-	0x98, 0x1b, 0x4e, 0x0f, 0x9c, 0x1c, 0xce, 0xf6, 0xff, 0x6c, 0x42, 0xdb, 0x4d, 0xf9, 0xb3, 0xca,
-	0x9e, 0xc2, 0xbe, 0xe2, 0x33, 0x73, 0x11, 0xc7, 0xca, 0x89, 0xdb, 0x65, 0x6b, 0x6c, 0x4f, 0x8c,
-	0x12, 0x81, 0x69, 0x11, 0x6d, 0xba, 0x68, 0xcd, 0x43, 0x9e, 0x43, 0x27, 0xe1, 0x21, 0x26, 0x9a,
-	0xb6, 0xee, 0xc9, 0xc1, 0x45, 0x25, 0x47, 0xc9, 0x20, 0x2f, 0xb6, 0x65, 0x3e, 0xa9, 0xa8, 0x13,
-	0x99, 0x1a, 0x2e, 0x52, 0x54, 0x5b, 0x3a, 0x9f, 0x41, 0xd7, 0x8d, 0xf8, 0x8d, 0x58, 0x22, 0xed,
-	0x0c, 0xbc, 0xa0, 0xc9, 0x36, 0x0e, 0xf2, 0x02, 0x9e, 0x26, 0x5c, 0x9b, 0xd7, 0xc8, 0x95, 0x09,
-	0x91, 0x17, 0xac, 0x3d, 0xc7, 0x7a, 0x18, 0xb0, 0x97, 0xf7, 0x1d, 0x2a, 0x2d, 0x64, 0xea, 0x74,
-	0xee, 0xb2, 0x0a, 0xda, 0xc8, 0x5c, 0x98, 0xd7, 0x5c, 0x2f, 0x68, 0xb7, 0x88, 0x94, 0xd0, 0x76,
-	0x1e, 0x63, 0x96, 0xc8, 0xd5, 0x35, 0x37, 0x8b, 0x72, 0x0e, 0x35, 0x0f, 0xf9, 0x0e, 0x8e, 0xb3,
-	0xc5, 0x4a, 0x8b, 0x88, 0x27, 0xc9, 0x6a, 0x8a, 0xda, 0x28, 0xb9, 0xc2, 0x98, 0xf6, 0xdc, 0x90,
-	0x77, 0x85, 0xfc, 0xbf, 0x3c, 0x00, 0x77, 0xc7, 0xf5, 0xb5, 0x94, 0x09, 0x79, 0x09, 0xed, 0x4c,
-	0xca, 0x44, 0x53, 0xcf, 0x29, 0x77, 0x3e, 0x5c, 0x2f, 0x89, 0x0d, 0x69, 0x68, 0x7f, 0xf4, 0xab,
-	0xd4, 0xa8, 0x15, 0x2b, 0xd8, 0xa7, 0xbf, 0x01, 0x6c, 0x9c, 0xf6, 0xfe, 0xff, 0x81, 0xab, 0xf2,
-	0xb9, 0x5a, 0x93, 0x7c, 0x0d, 0xed, 0x77, 0x3c, 0xc9, 0xd1, 0x8d, 0xb2, 0x37, 0x3e, 0xbe, 0x57,
-	0xd6, 0xe6, 0xb2, 0x82, 0xf1, 0x73, 0xe3, 0x27, 0xcf, 0xff, 0xc7, 0x83, 0xee, 0x3a, 0x60, 0xaf,
-	0x42, 0xc4, 0x33, 0x1e, 0x09, 0x53, 0xd5, 0x5c, 0x63, 0xfb, 0x88, 0x15, 0x4f, 0xe7, 0x78, 0xad,
-	0x70, 0x26, 0x6e, 0xcb, 0x67, 0x58, 0x77, 0x91, 0x4b, 0x78, 0xc2, 0x93, 0x44, 0x46, 0xdc, 0x60,
-	0x5c, 0xf4, 0x40, 0x9b, 0xae, 0x37, 0xba, 0xf9, 0x88, 0x8b, 0x2d, 0x02, 0xbb, 0x9f, 0x60, 0x1b,
-	0xd2, 0xf8, 0xd6, 0x3d, 0xde, 0x16, 0xb3, 0x26, 0x09, 0x6a, 0x55, 0x7f, 0x9f, 0xcd, 0x34, 0x1a,
-	0x77, 0x81, 0x5a, 0xec, 0xbe, 0xdb, 0x9f, 0xc1, 0xe1, 0x76, 0x79, 0xb7, 0xb5, 0xac, 0xb1, 0xde,
-	0x68, 0x15, 0xb4, 0xdd, 0xac, 0xd3, 0x2f, 0x4c, 0xb9, 0xd3, 0xea, 0x2e, 0x9b, 0x9b, 0xe5, 0x2a,
-	0x93, 0x1a, 0xcb, 0xf5, 0x52, 0x41, 0xff, 0x6f, 0x0f, 0x1e, 0x6f, 0x66, 0x34, 0x59, 0xc6, 0x64,
-	0x04, 0x2d, 0xb3, 0xca, 0xd0, 0x1d, 0x72, 0x38, 0xfe, 0x72, 0xd7, 0x28, 0x27, 0xcb, 0xf8, 0xcd,
-	0x2a, 0x43, 0xe6, 0x88, 0xe4, 0x25, 0x74, 0x22, 0x85, 0xf6, 0x31, 0x14, 0x63, 0xfa, 0x6a, 0x67,
-	0x8a, 0x63, 0x4c, 0x96, 0x31, 0x2b, 0xc9, 0x64, 0x0c, 0x6d, 0xf7, 0x89, 0xee, 0x8b, 0x7a, 0xe3,
-	0xb3, 0x5d, 0x59, 0x4e, 0x02, 0x9b, 0x54, 0x50, 0xfd, 0x67, 0x70, 0xbc, 0xa3, 0xa4, 0x3f, 0x05,
-	0xf2, 0x30, 0x67, 0xb3, 0x8f, 0xbc, 0xfa, 0x3e, 0xaa, 0x49, 0xd1, 0xd8, 0x92, 0xe2, 0xf9, 0x8f,
-	0xf0, 0xf4, 0x41, 0x8b, 0xe4, 0x09, 0xf4, 0x8a, 0x73, 0x5c, 0xe8, 0xe8, 0x11, 0x39, 0x04, 0x70,
-	0x27, 0x14, 0xd8, 0xbb, 0x3c, 0xfa, 0xf0, 0x7f, 0xdf, 0x7b, 0x7f, 0xd7, 0xf7, 0x3e, 0xdc, 0xf5,
-	0xbd, 0xff, 0xee, 0xfa, 0x5e, 0xd8, 0x71, 0xff, 0x48, 0x3f, 0x7c, 0x0a, 0x00, 0x00, 0xff, 0xff,
-	0xd0, 0xa0, 0x57, 0xc3, 0x2a, 0x07, 0x00, 0x00,
+	0x10, 0x0e, 0xf5, 0x67, 0x6b, 0xe4, 0xd8, 0xce, 0xba, 0x36, 0x08, 0xd7, 0xb5, 0x05, 0x9e, 0xd4,
+	0x34, 0xb5, 0x5a, 0xa5, 0x01, 0x8a, 0xde, 0x6c, 0x29, 0x68, 0x5c, 0x34, 0xa8, 0xb1, 0xce, 0x0b,
+	0xac, 0xc8, 0x91, 0x44, 0x84, 0xe2, 0x32, 0xbb, 0xcb, 0xc0, 0xea, 0x0b, 0xf4, 0xde, 0xd7, 0xe8,
+	0x8b, 0xa4, 0xb7, 0xdc, 0x7a, 0x0b, 0x5a, 0x3f, 0x49, 0xb1, 0xb3, 0xa4, 0x44, 0xc9, 0xce, 0x45,
+	0xd8, 0x6f, 0xe6, 0x9b, 0xe1, 0xce, 0x37, 0x3b, 0x23, 0xd8, 0x1d, 0xcf, 0xe6, 0x68, 0x44, 0x36,
+	0x3e, 0xcf, 0x94, 0x34, 0x92, 0x6d, 0x97, 0xf8, 0xf8, 0xdb, 0x69, 0x6c, 0x66, 0xf9, 0xf8, 0x3c,
+	0x94, 0xf3, 0xfe, 0x54, 0x4e, 0x65, 0x9f, 0x08, 0xe3, 0x7c, 0x42, 0x88, 0x00, 0x9d, 0x5c, 0xe0,
+	0xf1, 0xaf, 0x15, 0xfa, 0x5c, 0x18, 0x15, 0xdf, 0x4a, 0x15, 0x4f, 0xe3, 0xb4, 0x00, 0x61, 0x3e,
+	0xc6, 0x7e, 0x28, 0xe7, 0x99, 0x4c, 0x31, 0x35, 0xda, 0x26, 0xcb, 0x66, 0x68, 0xfa, 0xd9, 0xb8,
+	0xef, 0xbe, 0xd7, 0xaf, 0x5e, 0x23, 0x18, 0x01, 0xdc, 0x18, 0xa9, 0xf0, 0x2a, 0xc2, 0xd4, 0xb0,
+	0x13, 0x68, 0x87, 0x49, 0xae, 0x0d, 0xaa, 0xab, 0x91, 0xef, 0x75, 0xbd, 0x5e, 0x83, 0xaf, 0x0c,
+	0xcc, 0x87, 0x2d, 0x4d, 0xdc, 0x91, 0x5f, 0x23, 0x5f, 0x09, 0x83, 0x21, 0x6c, 0x0d, 0x1d, 0x8d,
+	0x1d, 0x41, 0x2d, 0x8e, 0x5c, 0xec, 0x65, 0xeb, 0xee, 0xd3, 0x59, 0xed, 0x6a, 0xc4, 0x6b, 0x71,
+	0xc4, 0xba, 0xd0, 0x99, 0x8b, 0x5b, 0x8e, 0x59, 0x12, 0x87, 0x42, 0x53, 0x82, 0xc7, 0xbc, 0x6a,
+	0x0a, 0xfe, 0xa9, 0x41, 0xf3, 0x66, 0x26, 0x54, 0xf4, 0xd9, 0x1c, 0x5f, 0x40, 0x53, 0x1b, 0xa1,
+	0x0c, 0x45, 0xef, 0x70, 0x07, 0xd8, 0x3e, 0xd4, 0x31, 0x8d, 0xfc, 0x3a, 0xd9, 0xec, 0x91, 0x7d,
+	0x0f, 0x4d, 0xcc, 0x64, 0x38, 0xf3, 0x1b, 0x5d, 0xaf, 0xd7, 0x19, 0x1c, 0x9e, 0x17, 0x25, 0x73,
+	0xd4, 0x32, 0x57, 0x21, 0xbe, 0xb4, 0xce, 0xcb, 0xc6, 0x87, 0x4f, 0x67, 0x8f, 0xb8, 0x63, 0xb2,
+	0x6f, 0x28, 0xb5, 0x41, 0xbf, 0xd9, 0xf5, 0x7a, 0xbb, 0xf7, 0x43, 0x6e, 0xac, 0x93, 0x3b, 0x0e,
+	0xeb, 0x41, 0x33, 0x43, 0x54, 0xda, 0x6f, 0x75, 0xeb, 0xbd, 0xce, 0x60, 0xa7, 0x24, 0x5f, 0x23,
+	0xaa, 0x32, 0x2d, 0x11, 0x58, 0x00, 0x3b, 0x51, 0xac, 0xc5, 0x38, 0xc1, 0x9b, 0x2c, 0x89, 0x8d,
+	0xbf, 0xd5, 0xf5, 0x7a, 0xdb, 0x7c, 0xcd, 0x66, 0xab, 0x9a, 0x2a, 0x99, 0x67, 0xfe, 0x36, 0x89,
+	0xea, 0x00, 0x3b, 0x82, 0x56, 0x9e, 0xc6, 0xef, 0x72, 0xf4, 0xa1, 0xeb, 0xf5, 0xda, 0xbc, 0x40,
+	0xec, 0x14, 0x40, 0xe5, 0x09, 0xfe, 0x6c, 0x49, 0xda, 0xef, 0x74, 0xeb, 0xbd, 0x36, 0xaf, 0x58,
+	0x18, 0x83, 0x46, 0x24, 0x8c, 0xf0, 0x77, 0x48, 0x0e, 0x3a, 0x07, 0x7f, 0xd4, 0xa1, 0x49, 0x5d,
+	0xfe, 0xac, 0xb2, 0xc7, 0xb0, 0xad, 0xc4, 0xc4, 0x5c, 0x44, 0x91, 0x22, 0x71, 0xdb, 0x7c, 0x89,
+	0xed, 0x17, 0xc3, 0x24, 0xc6, 0xd4, 0x79, 0xeb, 0xe4, 0xad, 0x58, 0xd8, 0x53, 0x68, 0x25, 0x62,
+	0x8c, 0x89, 0xf6, 0x1b, 0x1b, 0x72, 0x88, 0xb8, 0x94, 0xa3, 0x60, 0xb0, 0x67, 0xeb, 0x32, 0x1f,
+	0x95, 0xd4, 0xa1, 0x4c, 0x8d, 0x88, 0x53, 0x54, 0x6b, 0x3a, 0x9f, 0x40, 0x9b, 0x5a, 0xfc, 0x26,
+	0x9e, 0xa3, 0xdf, 0xea, 0x7a, 0xbd, 0x3a, 0x5f, 0x19, 0xd8, 0x33, 0x78, 0x92, 0x08, 0x6d, 0x5e,
+	0xa1, 0x50, 0x66, 0x8c, 0xc2, 0xb1, 0xb6, 0x88, 0x75, 0xdf, 0x61, 0x1f, 0xef, 0x7b, 0x54, 0x3a,
+	0x96, 0x29, 0xe9, 0xdc, 0xe6, 0x25, 0xb4, 0x9e, 0x69, 0x6c, 0x5e, 0x09, 0x3d, 0xf3, 0xdb, 0xce,
+	0x53, 0x40, 0x5b, 0x79, 0x84, 0x59, 0x22, 0x17, 0xd7, 0xc2, 0xcc, 0x8a, 0x3e, 0x54, 0x2c, 0xec,
+	0x3b, 0x38, 0xc8, 0x66, 0x0b, 0x1d, 0x87, 0x22, 0x49, 0x16, 0x23, 0xd4, 0x46, 0xc9, 0x05, 0x46,
+	0x7e, 0x87, 0x9a, 0xfc, 0x90, 0x2b, 0xf8, 0xd3, 0x03, 0xa0, 0x37, 0xae, 0xaf, 0xa5, 0x4c, 0xd8,
+	0x0b, 0x68, 0x66, 0x52, 0x26, 0xda, 0xf7, 0x48, 0xb9, 0xb3, 0xf3, 0xe5, 0x92, 0x58, 0x91, 0xce,
+	0xed, 0x8f, 0x7e, 0x99, 0x1a, 0xb5, 0xe0, 0x8e, 0x7d, 0xfc, 0x1a, 0x60, 0x65, 0xb4, 0xef, 0xff,
+	0x2d, 0x2e, 0x8a, 0x71, 0xb5, 0x47, 0xf6, 0x35, 0x34, 0xdf, 0x8b, 0x24, 0x47, 0x6a, 0x65, 0x67,
+	0x70, 0xb0, 0x91, 0xd6, 0xc6, 0x72, 0xc7, 0xf8, 0xa9, 0xf6, 0xa3, 0x17, 0xfc, 0xed, 0x41, 0x7b,
+	0xe9, 0xb0, 0x4f, 0x21, 0x14, 0x99, 0x08, 0x63, 0x53, 0xe6, 0x5c, 0x62, 0x3b, 0xc4, 0x4a, 0xa4,
+	0x53, 0xbc, 0x56, 0x38, 0x89, 0x6f, 0x8b, 0x31, 0xac, 0x9a, 0xd8, 0x25, 0xec, 0x89, 0x24, 0x91,
+	0xa1, 0x30, 0x18, 0xb9, 0x1a, 0xfc, 0x3a, 0xd5, 0xe6, 0xaf, 0x2e, 0x71, 0xb1, 0x46, 0xe0, 0x9b,
+	0x01, 0xb6, 0x20, 0x8d, 0xef, 0x68, 0x78, 0x1b, 0xdc, 0x1e, 0x59, 0xaf, 0x92, 0xf5, 0xb7, 0xc9,
+	0x44, 0xa3, 0xa1, 0x07, 0xd4, 0xe0, 0x9b, 0xe6, 0x60, 0x02, 0xbb, 0xeb, 0xe9, 0x69, 0x6b, 0xd9,
+	0xc3, 0x72, 0xa3, 0x95, 0xd0, 0x56, 0xb3, 0x0c, 0xbf, 0x30, 0xc5, 0x4e, 0xab, 0x9a, 0x6c, 0x6c,
+	0x96, 0xab, 0x4c, 0x6a, 0x2c, 0xd6, 0x4b, 0x09, 0x83, 0xbf, 0x3c, 0x78, 0xbc, 0xea, 0xd1, 0x70,
+	0x1e, 0xb1, 0x3e, 0x34, 0xcc, 0x22, 0x43, 0xfa, 0xc8, 0xee, 0xe0, 0xcb, 0x87, 0x5a, 0x39, 0x9c,
+	0x47, 0x6f, 0x16, 0x19, 0x72, 0x22, 0xb2, 0x17, 0xd0, 0x0a, 0x15, 0xda, 0x61, 0x70, 0x6d, 0xfa,
+	0xea, 0xc1, 0x10, 0x62, 0x0c, 0xe7, 0x11, 0x2f, 0xc8, 0x6c, 0x00, 0x4d, 0xba, 0x22, 0xdd, 0xa8,
+	0x33, 0x38, 0x79, 0x28, 0x8a, 0x24, 0xb0, 0x41, 0x8e, 0x1a, 0x1c, 0xc2, 0xc1, 0x03, 0x29, 0x83,
+	0x11, 0xb0, 0xfb, 0x31, 0xab, 0x7d, 0xe4, 0x55, 0xf7, 0x51, 0x45, 0x8a, 0xda, 0xba, 0x14, 0x43,
+	0x68, 0x5f, 0x8a, 0xf0, 0x6d, 0x9e, 0xfd, 0x22, 0xc7, 0x76, 0xfd, 0x64, 0x76, 0x58, 0x3c, 0x1a,
+	0x16, 0x3a, 0xdb, 0x25, 0x38, 0x51, 0xf2, 0x77, 0x4c, 0x8b, 0xa5, 0x55, 0xeb, 0xd6, 0x7b, 0x0d,
+	0xbe, 0x66, 0x0b, 0x22, 0xe8, 0xd0, 0x55, 0x5c, 0x26, 0x5a, 0xc7, 0x16, 0x52, 0x9e, 0xce, 0x60,
+	0x6f, 0xa3, 0xc8, 0x72, 0xc9, 0x12, 0xc7, 0x5e, 0x38, 0x4e, 0x23, 0xbc, 0x2d, 0x3a, 0xe8, 0x80,
+	0xbd, 0xc9, 0x24, 0x4e, 0xb0, 0x58, 0x58, 0x74, 0x0e, 0x2e, 0x00, 0xdc, 0x07, 0x5e, 0xa3, 0x11,
+	0xec, 0x39, 0xb4, 0xb4, 0x7b, 0xa2, 0x6e, 0xfc, 0x0e, 0x37, 0xbf, 0x42, 0xd4, 0x72, 0x83, 0x39,
+	0xea, 0xd3, 0x1f, 0xe0, 0xc9, 0xbd, 0x86, 0xb2, 0x3d, 0xe8, 0x38, 0x55, 0xc9, 0xb5, 0xff, 0x88,
+	0xed, 0x02, 0x90, 0x9e, 0x0e, 0x7b, 0x97, 0xfb, 0x1f, 0xff, 0x3b, 0xf5, 0x3e, 0xdc, 0x9d, 0x7a,
+	0x1f, 0xef, 0x4e, 0xbd, 0x7f, 0xef, 0x4e, 0xbd, 0x71, 0x8b, 0xfe, 0x7f, 0x9f, 0xff, 0x3f, 0x00,
+	0x95, 0xbc, 0xed, 0x08, 0x18, 0x08, 0x00, 0x00,
 }
 
 func (m *StoreIdent) Marshal() (dAtA []byte, err error) {
@@ -1345,6 +1522,123 @@ func (m *ShardsPoolAllocCmd) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *BackupJob) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupJob) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintBhmetapb(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.FrozenGroups) > 0 {
+		dAtA1 := make([]byte, len(m.FrozenGroups)*10)
+		var j1 int
+		for _, num := range m.FrozenGroups {
+			for num >= 1<<7 {
+				dAtA1[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA1[j1] = uint8(num)
+			j1++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintBhmetapb(dAtA, i, uint64(j1))
+		i += copy(dAtA[i:], dAtA1[:j1])
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ShardBackup) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardBackup) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintBhmetapb(dAtA, i, uint64(m.Shard.Size()))
+	n5, err := m.Shard.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintBhmetapb(dAtA, i, uint64(m.Index))
+	}
+	if len(m.File) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintBhmetapb(dAtA, i, uint64(len(m.File)))
+		i += copy(dAtA[i:], m.File)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BackupMeta) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupMeta) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Shards) > 0 {
+		for _, msg := range m.Shards {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintBhmetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeVarintBhmetapb(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1629,6 +1923,68 @@ func (m *ShardsPoolAllocCmd) Size() (n int) {
 	return n
 }
 
+func (m *BackupJob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovBhmetapb(uint64(l))
+	}
+	if len(m.FrozenGroups) > 0 {
+		l = 0
+		for _, e := range m.FrozenGroups {
+			l += sovBhmetapb(uint64(e))
+		}
+		n += 1 + sovBhmetapb(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardBackup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Shard.Size()
+	n += 1 + l + sovBhmetapb(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovBhmetapb(uint64(m.Index))
+	}
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovBhmetapb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BackupMeta) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
+			l = e.Size()
+			n += 1 + l + sovBhmetapb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovBhmetapb(x uint64) (n int) {
 	for {
 		n++
@@ -3314,6 +3670,395 @@ func (m *ShardsPoolAllocCmd) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *BackupJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBhmetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhmetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowBhmetapb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.FrozenGroups = append(m.FrozenGroups, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowBhmetapb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthBhmetapb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthBhmetapb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.FrozenGroups) == 0 {
+					m.FrozenGroups = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowBhmetapb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.FrozenGroups = append(m.FrozenGroups, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrozenGroups", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBhmetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardBackup) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBhmetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardBackup: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardBackup: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhmetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhmetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhmetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.File = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBhmetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BackupMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBhmetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhmetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shards = append(m.Shards, ShardBackup{})
+			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBhmetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBhmetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func skipBhmetapb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
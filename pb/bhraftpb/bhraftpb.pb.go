@@ -33,18 +33,21 @@ const (
 	PeerState_Normal    PeerState = 0
 	PeerState_Applying  PeerState = 1
 	PeerState_Tombstone PeerState = 2
+	PeerState_Merging   PeerState = 3
 )
 
 var PeerState_name = map[int32]string{
 	0: "Normal",
 	1: "Applying",
 	2: "Tombstone",
+	3: "Merging",
 }
 
 var PeerState_value = map[string]int32{
 	"Normal":    0,
 	"Applying":  1,
 	"Tombstone": 2,
+	"Merging":   3,
 }
 
 func (x PeerState) String() string {
@@ -195,6 +198,7 @@ func (m *RaftMessage) GetRuleGroups() []string {
 type ShardLocalState struct {
 	State                PeerState      `protobuf:"varint,1,opt,name=state,proto3,enum=bhraftpb.PeerState" json:"state,omitempty"`
 	Shard                bhmetapb.Shard `protobuf:"bytes,2,opt,name=shard,proto3" json:"shard"`
+	MergeState           *MergeState    `protobuf:"bytes,3,opt,name=mergeState,proto3" json:"mergeState,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -247,6 +251,77 @@ func (m *ShardLocalState) GetShard() bhmetapb.Shard {
 	return bhmetapb.Shard{}
 }
 
+func (m *ShardLocalState) GetMergeState() *MergeState {
+	if m != nil {
+		return m.MergeState
+	}
+	return nil
+}
+
+// MergeState the merge state of the source shard
+type MergeState struct {
+	MinIndex             uint64         `protobuf:"varint,1,opt,name=minIndex,proto3" json:"minIndex,omitempty"`
+	Commit               uint64         `protobuf:"varint,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Target               bhmetapb.Shard `protobuf:"bytes,3,opt,name=target,proto3" json:"target"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MergeState) Reset()         { *m = MergeState{} }
+func (m *MergeState) String() string { return proto.CompactTextString(m) }
+func (*MergeState) ProtoMessage()    {}
+func (*MergeState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b31c127a72499666, []int{2}
+}
+func (m *MergeState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MergeState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MergeState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MergeState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeState.Merge(m, src)
+}
+func (m *MergeState) XXX_Size() int {
+	return m.Size()
+}
+func (m *MergeState) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeState proto.InternalMessageInfo
+
+func (m *MergeState) GetMinIndex() uint64 {
+	if m != nil {
+		return m.MinIndex
+	}
+	return 0
+}
+
+func (m *MergeState) GetCommit() uint64 {
+	if m != nil {
+		return m.Commit
+	}
+	return 0
+}
+
+func (m *MergeState) GetTarget() bhmetapb.Shard {
+	if m != nil {
+		return m.Target
+	}
+	return bhmetapb.Shard{}
+}
+
 // RaftLocalState raft local state about raft log
 type RaftLocalState struct {
 	HardState            raftpb.HardState `protobuf:"bytes,1,opt,name=hardState,proto3" json:"hardState"`
@@ -260,7 +335,7 @@ func (m *RaftLocalState) Reset()         { *m = RaftLocalState{} }
 func (m *RaftLocalState) String() string { return proto.CompactTextString(m) }
 func (*RaftLocalState) ProtoMessage()    {}
 func (*RaftLocalState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b31c127a72499666, []int{2}
+	return fileDescriptor_b31c127a72499666, []int{3}
 }
 func (m *RaftLocalState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -316,7 +391,7 @@ func (m *RaftTruncatedState) Reset()         { *m = RaftTruncatedState{} }
 func (m *RaftTruncatedState) String() string { return proto.CompactTextString(m) }
 func (*RaftTruncatedState) ProtoMessage()    {}
 func (*RaftTruncatedState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b31c127a72499666, []int{3}
+	return fileDescriptor_b31c127a72499666, []int{4}
 }
 func (m *RaftTruncatedState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -372,7 +447,7 @@ func (m *RaftApplyState) Reset()         { *m = RaftApplyState{} }
 func (m *RaftApplyState) String() string { return proto.CompactTextString(m) }
 func (*RaftApplyState) ProtoMessage()    {}
 func (*RaftApplyState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b31c127a72499666, []int{4}
+	return fileDescriptor_b31c127a72499666, []int{5}
 }
 func (m *RaftApplyState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -431,7 +506,7 @@ func (m *SnapshotMessageHeader) Reset()         { *m = SnapshotMessageHeader{} }
 func (m *SnapshotMessageHeader) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMessageHeader) ProtoMessage()    {}
 func (*SnapshotMessageHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b31c127a72499666, []int{5}
+	return fileDescriptor_b31c127a72499666, []int{6}
 }
 func (m *SnapshotMessageHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -497,22 +572,29 @@ func (m *SnapshotMessageHeader) GetIndex() uint64 {
 
 // SnapshotMessage snapshot message
 type SnapshotMessage struct {
-	Header               SnapshotMessageHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Data                 []byte                `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	First                bool                  `protobuf:"varint,3,opt,name=first,proto3" json:"first,omitempty"`
-	Last                 bool                  `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`
-	FileSize             uint64                `protobuf:"varint,5,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
-	CheckSum             uint64                `protobuf:"varint,6,opt,name=checkSum,proto3" json:"checkSum,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Header   SnapshotMessageHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Data     []byte                `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	First    bool                  `protobuf:"varint,3,opt,name=first,proto3" json:"first,omitempty"`
+	Last     bool                  `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`
+	FileSize uint64                `protobuf:"varint,5,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+	CheckSum uint64                `protobuf:"varint,6,opt,name=checkSum,proto3" json:"checkSum,omitempty"`
+	// offset the offset of the data in the snapshot file
+	Offset uint64 `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	// chunkCheckSum the crc32 checksum of the data
+	ChunkCheckSum uint32 `protobuf:"varint,8,opt,name=chunkCheckSum,proto3" json:"chunkCheckSum,omitempty"`
+	// probe the sender asks the receiver how many bytes of the snapshot file are received,
+	// and the receiver responds the probe message with the received bytes in the offset.
+	Probe                bool     `protobuf:"varint,9,opt,name=probe,proto3" json:"probe,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SnapshotMessage) Reset()         { *m = SnapshotMessage{} }
 func (m *SnapshotMessage) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMessage) ProtoMessage()    {}
 func (*SnapshotMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b31c127a72499666, []int{6}
+	return fileDescriptor_b31c127a72499666, []int{7}
 }
 func (m *SnapshotMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -583,10 +665,32 @@ func (m *SnapshotMessage) GetCheckSum() uint64 {
 	return 0
 }
 
+func (m *SnapshotMessage) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SnapshotMessage) GetChunkCheckSum() uint32 {
+	if m != nil {
+		return m.ChunkCheckSum
+	}
+	return 0
+}
+
+func (m *SnapshotMessage) GetProbe() bool {
+	if m != nil {
+		return m.Probe
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("bhraftpb.PeerState", PeerState_name, PeerState_value)
 	proto.RegisterType((*RaftMessage)(nil), "bhraftpb.RaftMessage")
 	proto.RegisterType((*ShardLocalState)(nil), "bhraftpb.ShardLocalState")
+	proto.RegisterType((*MergeState)(nil), "bhraftpb.MergeState")
 	proto.RegisterType((*RaftLocalState)(nil), "bhraftpb.RaftLocalState")
 	proto.RegisterType((*RaftTruncatedState)(nil), "bhraftpb.RaftTruncatedState")
 	proto.RegisterType((*RaftApplyState)(nil), "bhraftpb.RaftApplyState")
@@ -597,54 +701,60 @@ func init() {
 func init() { proto.RegisterFile("bhraftpb.proto", fileDescriptor_b31c127a72499666) }
 
 var fileDescriptor_b31c127a72499666 = []byte{
-	// 739 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5d, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0xf3, 0xd7, 0x64, 0x12, 0xd2, 0xb0, 0xb4, 0xc8, 0xaa, 0xaa, 0x34, 0xf2, 0x03, 0x4a,
-	0x41, 0xc4, 0x52, 0x0b, 0x4f, 0xa8, 0x48, 0x54, 0x20, 0x5a, 0x54, 0x10, 0x72, 0x7a, 0x81, 0xb5,
-	0xbd, 0xb1, 0x57, 0xd8, 0x5e, 0xb3, 0x5e, 0xa3, 0xc2, 0x13, 0x17, 0xe1, 0x22, 0x1c, 0x00, 0xf5,
-	0xb1, 0x27, 0x40, 0xd0, 0x93, 0xa0, 0x5d, 0xaf, 0x1d, 0xa7, 0xfc, 0xf5, 0xc9, 0x3b, 0xb3, 0xdf,
-	0xcc, 0x7c, 0xf3, 0xed, 0x8c, 0x61, 0xe8, 0x86, 0x1c, 0x2f, 0x44, 0xea, 0xce, 0x52, 0xce, 0x04,
-	0x43, 0xdd, 0xd2, 0xde, 0x3e, 0x0c, 0xa8, 0x08, 0x73, 0x77, 0xe6, 0xb1, 0xd8, 0x8e, 0xb1, 0xe0,
-	0xf4, 0x9c, 0x71, 0x1a, 0xd0, 0x44, 0x1b, 0x5e, 0xee, 0x12, 0x3b, 0x75, 0x6d, 0x37, 0x8c, 0x89,
-	0xc0, 0xb5, 0x43, 0x91, 0x68, 0xfb, 0xf4, 0x06, 0xe1, 0x1e, 0x8b, 0x53, 0x96, 0x90, 0x44, 0x64,
-	0x76, 0xca, 0x59, 0x1a, 0x12, 0x21, 0x33, 0xea, 0x7c, 0x2b, 0xd9, 0x1e, 0xd6, 0xb2, 0x05, 0x2c,
-	0x60, 0xb6, 0x72, 0xbb, 0xf9, 0x42, 0x59, 0xca, 0x50, 0x27, 0x0d, 0xdf, 0x0b, 0xd8, 0x8c, 0x08,
-	0xcf, 0x9f, 0x51, 0x66, 0xcb, 0xaf, 0x2d, 0x7b, 0xb2, 0x3f, 0x1c, 0xd8, 0x45, 0x6f, 0xea, 0x53,
-	0x40, 0xad, 0x2f, 0x4d, 0xe8, 0x3b, 0x78, 0x21, 0x5e, 0x93, 0x2c, 0xc3, 0x01, 0x41, 0x26, 0xac,
-	0x67, 0x21, 0xe6, 0xfe, 0xc9, 0x73, 0xd3, 0x98, 0x18, 0xd3, 0x96, 0x53, 0x9a, 0x68, 0x13, 0xda,
-	0x01, 0x67, 0x79, 0x6a, 0x36, 0x94, 0xbf, 0x30, 0xd0, 0x3d, 0x68, 0x2d, 0x38, 0x8b, 0xcd, 0xe6,
-	0xc4, 0x98, 0xf6, 0xf7, 0x07, 0x33, 0x4d, 0xfb, 0x2d, 0x21, 0xfc, 0xa8, 0x75, 0xf1, 0x7d, 0x77,
-	0xcd, 0x51, 0xf7, 0xc8, 0x82, 0x86, 0x60, 0x66, 0xeb, 0xaf, 0xa8, 0x86, 0x60, 0xc8, 0x86, 0xf5,
-	0xb8, 0xa0, 0x61, 0xb6, 0x15, 0x70, 0x63, 0xa6, 0x1f, 0x47, 0xb3, 0xd3, 0xd8, 0x12, 0x85, 0x9e,
-	0x00, 0x28, 0x76, 0x2f, 0x52, 0xe6, 0x85, 0x66, 0x47, 0xc5, 0x6c, 0x95, 0xc9, 0x1d, 0x92, 0xb1,
-	0x9c, 0x7b, 0x44, 0x5d, 0xea, 0xc8, 0x1a, 0x1c, 0x4d, 0xa0, 0x4f, 0xb3, 0x33, 0x16, 0xbb, 0x99,
-	0x60, 0x09, 0x31, 0xd7, 0x27, 0xc6, 0xb4, 0xeb, 0xd4, 0x5d, 0xb2, 0xe3, 0x4c, 0x60, 0x2e, 0xcc,
-	0xee, 0xc4, 0x98, 0x0e, 0x9c, 0xc2, 0x40, 0x23, 0x68, 0x92, 0xc4, 0x37, 0x7b, 0xca, 0x27, 0x8f,
-	0xc8, 0x82, 0x81, 0x4f, 0x33, 0xec, 0x46, 0x64, 0x9e, 0x46, 0x54, 0x98, 0xa0, 0x52, 0xad, 0xf8,
-	0xd0, 0x5d, 0xe8, 0xe4, 0x09, 0x7d, 0x9f, 0x13, 0xb3, 0x3f, 0x31, 0xa6, 0x3d, 0x47, 0x5b, 0x68,
-	0x0c, 0xc0, 0xf3, 0x88, 0xbc, 0x94, 0x62, 0x66, 0xe6, 0x60, 0xd2, 0x9c, 0xf6, 0x9c, 0x9a, 0xc7,
-	0xa2, 0xb0, 0x31, 0x97, 0x9c, 0x4f, 0x99, 0x87, 0xa3, 0xb9, 0xc0, 0x82, 0xa0, 0x3d, 0x45, 0x4b,
-	0x10, 0xf5, 0x40, 0xc3, 0xfd, 0x3b, 0xb3, 0x6a, 0x86, 0xa5, 0x9e, 0x0a, 0xe3, 0x14, 0x08, 0xf4,
-	0x00, 0xda, 0xaa, 0x63, 0xf5, 0x66, 0x52, 0xcf, 0x6a, 0x4a, 0x55, 0x52, 0xad, 0x4a, 0x81, 0xb1,
-	0x08, 0x0c, 0xe5, 0x24, 0xd4, 0x2a, 0x3d, 0x86, 0x9e, 0xbc, 0x99, 0x57, 0xd5, 0xfa, 0xfb, 0xb7,
-	0xcb, 0x27, 0x39, 0x2e, 0x2f, 0x74, 0x92, 0x25, 0x12, 0xed, 0x40, 0x2f, 0xc2, 0x99, 0x38, 0x49,
-	0x7c, 0x72, 0xae, 0xa7, 0x65, 0xe9, 0xb0, 0x9e, 0x02, 0x92, 0x65, 0xce, 0x78, 0x9e, 0x78, 0x58,
-	0x10, 0x1d, 0xb3, 0x09, 0x6d, 0xaa, 0xf0, 0xc5, 0xd4, 0x15, 0x06, 0x42, 0xd0, 0x12, 0x84, 0xc7,
-	0x3a, 0x89, 0x3a, 0x5b, 0x9f, 0x8d, 0x82, 0xe7, 0xb3, 0x34, 0x8d, 0x3e, 0x16, 0xc1, 0x16, 0x0c,
-	0x70, 0x9a, 0x46, 0x94, 0xf8, 0x27, 0xb5, 0x1c, 0x2b, 0x3e, 0xf4, 0x0a, 0x86, 0x62, 0xa5, 0xa4,
-	0xd6, 0x64, 0x67, 0x29, 0xdf, 0xef, 0xb4, 0x74, 0x6f, 0xd7, 0x22, 0xad, 0xaf, 0x06, 0x6c, 0xcd,
-	0x13, 0x9c, 0x66, 0x21, 0x2b, 0x17, 0xe7, 0x98, 0x60, 0x9f, 0xf0, 0xa5, 0xe0, 0xc6, 0xff, 0x05,
-	0xaf, 0x76, 0xa7, 0x71, 0xa3, 0xdd, 0x69, 0xfe, 0x73, 0x77, 0x4a, 0xa5, 0x5a, 0x4b, 0xa5, 0x96,
-	0x9a, 0xb6, 0x6b, 0x9a, 0x5a, 0xdf, 0x0c, 0xd8, 0xb8, 0x46, 0x1e, 0x1d, 0x42, 0x27, 0x54, 0x0d,
-	0x68, 0xde, 0xbb, 0x4b, 0x51, 0xfe, 0xd8, 0xa7, 0x2e, 0xac, 0x83, 0x64, 0x71, 0x1f, 0x0b, 0xac,
-	0x1a, 0x19, 0x38, 0xea, 0x2c, 0x8b, 0x2f, 0x28, 0xcf, 0x84, 0xe2, 0xdd, 0x75, 0x0a, 0x43, 0x22,
-	0xe5, 0x24, 0x28, 0x9a, 0x5d, 0x47, 0x9d, 0xd1, 0x36, 0x74, 0x17, 0x34, 0x22, 0x73, 0xfa, 0x89,
-	0x68, 0xa6, 0x95, 0x2d, 0xef, 0xbc, 0x90, 0x78, 0xef, 0xe6, 0x79, 0xac, 0xf6, 0xbb, 0xe5, 0x54,
-	0xf6, 0xfd, 0x47, 0xd0, 0xab, 0x06, 0x1e, 0x01, 0x74, 0xde, 0x30, 0x1e, 0xe3, 0x68, 0xb4, 0x86,
-	0x06, 0xd0, 0x55, 0xc3, 0x41, 0x93, 0x60, 0x64, 0xa0, 0x5b, 0xd0, 0xab, 0x56, 0x7a, 0xd4, 0x38,
-	0x1a, 0x5d, 0xfe, 0x1c, 0x1b, 0x17, 0x57, 0x63, 0xe3, 0xf2, 0x6a, 0x6c, 0xfc, 0xb8, 0x1a, 0x1b,
-	0x6e, 0x47, 0xfd, 0x09, 0x0f, 0x7e, 0x05, 0x00, 0x00, 0xff, 0xff, 0xe4, 0x1f, 0xa2, 0x59, 0x0c,
-	0x06, 0x00, 0x00,
+	// 842 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0xae, 0xf3, 0xb7, 0xc9, 0x49, 0x76, 0x37, 0x0c, 0x5b, 0x64, 0xad, 0xaa, 0x34, 0xb2, 0x10,
+	0x4a, 0x41, 0x8d, 0xa5, 0x2d, 0x5c, 0xa1, 0x22, 0xb5, 0x80, 0x68, 0x51, 0x8b, 0xd0, 0xa4, 0x2f,
+	0x30, 0x76, 0x4e, 0xec, 0x51, 0x6d, 0x8f, 0x19, 0x8f, 0x51, 0xe1, 0x8a, 0xe7, 0x40, 0x42, 0xbc,
+	0x07, 0x4f, 0xd0, 0xcb, 0x3e, 0x01, 0x82, 0x7d, 0x12, 0x34, 0x3f, 0xfe, 0x49, 0x81, 0x6a, 0xaf,
+	0x32, 0xdf, 0x99, 0xef, 0x1c, 0x7f, 0xe7, 0xcc, 0x37, 0x13, 0x38, 0x8b, 0x52, 0xc9, 0x0e, 0xaa,
+	0x8c, 0xb6, 0xa5, 0x14, 0x4a, 0x90, 0x69, 0x83, 0x2f, 0x1f, 0x26, 0x5c, 0xa5, 0x75, 0xb4, 0x8d,
+	0x45, 0x1e, 0xe6, 0x4c, 0x49, 0xfe, 0x4a, 0x48, 0x9e, 0xf0, 0xc2, 0x81, 0xb8, 0x8e, 0x30, 0x2c,
+	0xa3, 0x30, 0x4a, 0x73, 0x54, 0xac, 0xb7, 0xb0, 0x85, 0x2e, 0x9f, 0xdd, 0x20, 0x3d, 0x16, 0x79,
+	0x29, 0x0a, 0x2c, 0x54, 0x15, 0x96, 0x52, 0x94, 0x29, 0x2a, 0x5d, 0xd1, 0xd5, 0x3b, 0xaa, 0x76,
+	0xbf, 0x57, 0x2d, 0x11, 0x89, 0x08, 0x4d, 0x38, 0xaa, 0x0f, 0x06, 0x19, 0x60, 0x56, 0x8e, 0x7e,
+	0x2f, 0x11, 0x5b, 0x54, 0xf1, 0x7e, 0xcb, 0x45, 0xa8, 0x7f, 0x43, 0xdd, 0x53, 0xf8, 0xe3, 0x83,
+	0xd0, 0xf6, 0x66, 0x7e, 0x2c, 0x35, 0xf8, 0x6d, 0x08, 0x73, 0xca, 0x0e, 0xea, 0x39, 0x56, 0x15,
+	0x4b, 0x90, 0xf8, 0x70, 0x52, 0xa5, 0x4c, 0xee, 0x9f, 0x7e, 0xe5, 0x7b, 0x6b, 0x6f, 0x33, 0xa2,
+	0x0d, 0x24, 0x17, 0x30, 0x4e, 0xa4, 0xa8, 0x4b, 0x7f, 0x60, 0xe2, 0x16, 0x90, 0x8f, 0x60, 0x74,
+	0x90, 0x22, 0xf7, 0x87, 0x6b, 0x6f, 0x33, 0xbf, 0x5a, 0x6c, 0x9d, 0xec, 0xef, 0x11, 0xe5, 0xe3,
+	0xd1, 0xeb, 0x3f, 0xef, 0xde, 0xa2, 0x66, 0x9f, 0x04, 0x30, 0x50, 0xc2, 0x1f, 0xfd, 0x2f, 0x6b,
+	0xa0, 0x04, 0x09, 0xe1, 0x24, 0xb7, 0x32, 0xfc, 0xb1, 0x21, 0x9e, 0x6f, 0xdd, 0xe1, 0x38, 0x75,
+	0x8e, 0xdb, 0xb0, 0xc8, 0xe7, 0x00, 0x46, 0xdd, 0xd7, 0xa5, 0x88, 0x53, 0x7f, 0x62, 0x72, 0x6e,
+	0x37, 0xc5, 0x29, 0x56, 0xa2, 0x96, 0x31, 0x9a, 0x4d, 0x97, 0xd9, 0xa3, 0x93, 0x35, 0xcc, 0x79,
+	0xf5, 0x42, 0xe4, 0x51, 0xa5, 0x44, 0x81, 0xfe, 0xc9, 0xda, 0xdb, 0x4c, 0x69, 0x3f, 0xa4, 0x3b,
+	0xae, 0x14, 0x93, 0xca, 0x9f, 0xae, 0xbd, 0xcd, 0x82, 0x5a, 0x40, 0x96, 0x30, 0xc4, 0x62, 0xef,
+	0xcf, 0x4c, 0x4c, 0x2f, 0x49, 0x00, 0x8b, 0x3d, 0xaf, 0x58, 0x94, 0xe1, 0xae, 0xcc, 0xb8, 0xf2,
+	0xc1, 0x94, 0x3a, 0x8a, 0x91, 0x0f, 0x60, 0x52, 0x17, 0xfc, 0x87, 0x1a, 0xfd, 0xf9, 0xda, 0xdb,
+	0xcc, 0xa8, 0x43, 0x64, 0x05, 0x20, 0xeb, 0x0c, 0xbf, 0xd1, 0xc3, 0xac, 0xfc, 0xc5, 0x7a, 0xb8,
+	0x99, 0xd1, 0x5e, 0x24, 0xf8, 0xdd, 0x83, 0xf3, 0x9d, 0x16, 0xfd, 0x4c, 0xc4, 0x2c, 0xdb, 0x29,
+	0xa6, 0x90, 0xdc, 0x33, 0xba, 0x14, 0x9a, 0x13, 0x3a, 0xbb, 0x7a, 0x7f, 0xdb, 0x9a, 0x58, 0x0f,
+	0xd4, 0x70, 0xa8, 0x65, 0x90, 0x4f, 0x60, 0x6c, 0x5a, 0xf6, 0x07, 0x6e, 0xa0, 0xad, 0x4d, 0x4d,
+	0x51, 0x37, 0x16, 0xcb, 0x21, 0x9f, 0x02, 0xe4, 0x28, 0x13, 0x34, 0x15, 0xdc, 0x89, 0x5e, 0x74,
+	0xc5, 0x9f, 0xb7, 0x7b, 0xb4, 0xc7, 0x0b, 0x04, 0x40, 0xb7, 0x43, 0x2e, 0x61, 0x9a, 0xf3, 0xe2,
+	0x69, 0xb1, 0xc7, 0x57, 0xce, 0x40, 0x2d, 0xd6, 0x33, 0x88, 0x45, 0x9e, 0x73, 0xe5, 0x2c, 0xe4,
+	0x10, 0xb9, 0x0f, 0x13, 0xc5, 0x64, 0x82, 0xca, 0x1f, 0xbe, 0x4b, 0xa5, 0x23, 0x05, 0x08, 0x67,
+	0xda, 0xb1, 0xbd, 0x81, 0x7c, 0x06, 0x33, 0xcd, 0xdb, 0xb5, 0x43, 0x99, 0x5f, 0xbd, 0xd7, 0x58,
+	0xe7, 0x49, 0xb3, 0xe1, 0xaa, 0x74, 0x4c, 0x72, 0x07, 0x66, 0x19, 0xab, 0x94, 0x15, 0x6b, 0x25,
+	0x75, 0x81, 0xe0, 0x0b, 0x20, 0xfa, 0x33, 0x2f, 0x64, 0x5d, 0xc4, 0x4c, 0xa1, 0xcb, 0xb9, 0x80,
+	0x31, 0xef, 0x35, 0x67, 0x01, 0x21, 0x30, 0x52, 0x28, 0x73, 0x57, 0xc4, 0xac, 0x83, 0x5f, 0x3c,
+	0xab, 0xf3, 0x51, 0x59, 0x66, 0x3f, 0xd9, 0xe4, 0x00, 0x16, 0xac, 0x2c, 0x33, 0x8e, 0xfb, 0xfe,
+	0x80, 0x8e, 0x62, 0xe4, 0x5b, 0x38, 0x53, 0x47, 0x9f, 0x74, 0x47, 0x77, 0xa7, 0x3b, 0x88, 0x7f,
+	0xcb, 0x72, 0xbd, 0xbd, 0x95, 0x19, 0xfc, 0xe1, 0xc1, 0xed, 0x5d, 0xc1, 0xca, 0x2a, 0x15, 0xcd,
+	0x05, 0x7f, 0x82, 0x6c, 0x8f, 0xb2, 0xf3, 0x85, 0x77, 0x03, 0x5f, 0x34, 0x77, 0x7c, 0x70, 0xa3,
+	0x3b, 0x3e, 0x7c, 0xe7, 0x1d, 0x6f, 0x26, 0x35, 0xea, 0x26, 0xd5, 0xcd, 0x74, 0xdc, 0x9b, 0x69,
+	0xf0, 0xeb, 0x00, 0xce, 0xdf, 0x12, 0x4f, 0x1e, 0xc2, 0x24, 0x35, 0x0d, 0x38, 0xdd, 0x77, 0xbb,
+	0xa1, 0xfc, 0x67, 0x9f, 0x8d, 0x73, 0x6c, 0x92, 0xfe, 0xf8, 0x9e, 0x29, 0x66, 0x1a, 0x59, 0x50,
+	0xb3, 0xd6, 0x1f, 0x3f, 0x70, 0x59, 0x59, 0xef, 0x4d, 0xa9, 0x05, 0x9a, 0xa9, 0x9d, 0x60, 0x64,
+	0x4e, 0xa9, 0x59, 0x6b, 0x6b, 0x1f, 0x78, 0x86, 0x3b, 0xfe, 0x33, 0x3a, 0xa5, 0x2d, 0xd6, 0x7b,
+	0x71, 0x8a, 0xf1, 0xcb, 0x5d, 0x9d, 0x9b, 0x77, 0x68, 0x44, 0x5b, 0xac, 0x6d, 0x2f, 0x0e, 0x87,
+	0x0a, 0x95, 0x79, 0x63, 0x46, 0xd4, 0x21, 0xf2, 0x21, 0x9c, 0xc6, 0x69, 0x5d, 0xbc, 0xfc, 0xb2,
+	0x49, 0xd4, 0xcf, 0xcc, 0x29, 0x3d, 0x0e, 0x6a, 0x7d, 0xa5, 0x14, 0x11, 0x9a, 0x07, 0x67, 0x4a,
+	0x2d, 0xf8, 0xf8, 0x11, 0xcc, 0xda, 0xbb, 0x4e, 0x00, 0x26, 0xdf, 0x09, 0x99, 0xb3, 0x6c, 0x79,
+	0x8b, 0x2c, 0x60, 0x6a, 0x0c, 0xc7, 0x8b, 0x64, 0xe9, 0x91, 0x53, 0x98, 0xb5, 0xcf, 0xd9, 0x72,
+	0x40, 0xe6, 0x70, 0xa2, 0xaf, 0xaa, 0xde, 0x1b, 0x3e, 0x5e, 0xbe, 0xf9, 0x7b, 0xe5, 0xbd, 0xbe,
+	0x5e, 0x79, 0x6f, 0xae, 0x57, 0xde, 0x5f, 0xd7, 0x2b, 0x2f, 0x9a, 0x98, 0xbf, 0x84, 0x07, 0xff,
+	0x0c, 0x00, 0x36, 0xd1, 0x5e, 0xb5, 0x15, 0x07, 0x00, 0x00,
 }
 
 func (m *RaftMessage) Marshal() (dAtA []byte, err error) {
@@ -791,6 +901,55 @@ func (m *ShardLocalState) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n5
+	if m.MergeState != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintBhraftpb(dAtA, i, uint64(m.MergeState.Size()))
+		n6, err := m.MergeState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *MergeState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MergeState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MinIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintBhraftpb(dAtA, i, uint64(m.MinIndex))
+	}
+	if m.Commit != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintBhraftpb(dAtA, i, uint64(m.Commit))
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintBhraftpb(dAtA, i, uint64(m.Target.Size()))
+	n7, err := m.Target.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -815,11 +974,11 @@ func (m *RaftLocalState) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintBhraftpb(dAtA, i, uint64(m.HardState.Size()))
-	n6, err := m.HardState.MarshalTo(dAtA[i:])
+	n8, err := m.HardState.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n8
 	if m.LastIndex != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -885,11 +1044,11 @@ func (m *RaftApplyState) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintBhraftpb(dAtA, i, uint64(m.TruncatedState.Size()))
-	n7, err := m.TruncatedState.MarshalTo(dAtA[i:])
+	n9, err := m.TruncatedState.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n9
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -914,27 +1073,27 @@ func (m *SnapshotMessageHeader) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintBhraftpb(dAtA, i, uint64(m.Shard.Size()))
-	n8, err := m.Shard.MarshalTo(dAtA[i:])
+	n10, err := m.Shard.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n10
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintBhraftpb(dAtA, i, uint64(m.From.Size()))
-	n9, err := m.From.MarshalTo(dAtA[i:])
+	n11, err := m.From.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n11
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintBhraftpb(dAtA, i, uint64(m.To.Size()))
-	n10, err := m.To.MarshalTo(dAtA[i:])
+	n12, err := m.To.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
+	i += n12
 	if m.Term != 0 {
 		dAtA[i] = 0x20
 		i++
@@ -969,11 +1128,11 @@ func (m *SnapshotMessage) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintBhraftpb(dAtA, i, uint64(m.Header.Size()))
-	n11, err := m.Header.MarshalTo(dAtA[i:])
+	n13, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n11
+	i += n13
 	if len(m.Data) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -1010,6 +1169,26 @@ func (m *SnapshotMessage) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintBhraftpb(dAtA, i, uint64(m.CheckSum))
 	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintBhraftpb(dAtA, i, uint64(m.Offset))
+	}
+	if m.ChunkCheckSum != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintBhraftpb(dAtA, i, uint64(m.ChunkCheckSum))
+	}
+	if m.Probe {
+		dAtA[i] = 0x48
+		i++
+		if m.Probe {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1086,6 +1265,30 @@ func (m *ShardLocalState) Size() (n int) {
 	}
 	l = m.Shard.Size()
 	n += 1 + l + sovBhraftpb(uint64(l))
+	if m.MergeState != nil {
+		l = m.MergeState.Size()
+		n += 1 + l + sovBhraftpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MergeState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinIndex != 0 {
+		n += 1 + sovBhraftpb(uint64(m.MinIndex))
+	}
+	if m.Commit != 0 {
+		n += 1 + sovBhraftpb(uint64(m.Commit))
+	}
+	l = m.Target.Size()
+	n += 1 + l + sovBhraftpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1192,6 +1395,15 @@ func (m *SnapshotMessage) Size() (n int) {
 	if m.CheckSum != 0 {
 		n += 1 + sovBhraftpb(uint64(m.CheckSum))
 	}
+	if m.Offset != 0 {
+		n += 1 + sovBhraftpb(uint64(m.Offset))
+	}
+	if m.ChunkCheckSum != 0 {
+		n += 1 + sovBhraftpb(uint64(m.ChunkCheckSum))
+	}
+	if m.Probe {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1688,6 +1900,167 @@ func (m *ShardLocalState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MergeState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBhraftpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBhraftpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MergeState == nil {
+				m.MergeState = &MergeState{}
+			}
+			if err := m.MergeState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBhraftpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBhraftpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBhraftpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MergeState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBhraftpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MergeState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MergeState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinIndex", wireType)
+			}
+			m.MinIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			m.Commit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Commit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBhraftpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBhraftpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBhraftpb(dAtA[iNdEx:])
@@ -2382,6 +2755,64 @@ func (m *SnapshotMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkCheckSum", wireType)
+			}
+			m.ChunkCheckSum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkCheckSum |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probe", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBhraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Probe = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBhraftpb(dAtA[iNdEx:])
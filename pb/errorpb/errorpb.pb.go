@@ -425,6 +425,110 @@ func (m *RaftEntryTooLarge) GetEntrySize() uint64 {
 	return 0
 }
 
+// PermissionDenied the request is rejected by the authenticator or the authorizer
+type PermissionDenied struct {
+	Group                uint64   `protobuf:"varint,1,opt,name=group,proto3" json:"group,omitempty"`
+	CustemType           uint64   `protobuf:"varint,2,opt,name=custemType,proto3" json:"custemType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PermissionDenied) Reset()         { *m = PermissionDenied{} }
+func (m *PermissionDenied) String() string { return proto.CompactTextString(m) }
+func (*PermissionDenied) ProtoMessage()    {}
+func (*PermissionDenied) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{8}
+}
+func (m *PermissionDenied) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PermissionDenied) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PermissionDenied.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PermissionDenied) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermissionDenied.Merge(m, src)
+}
+func (m *PermissionDenied) XXX_Size() int {
+	return m.Size()
+}
+func (m *PermissionDenied) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermissionDenied.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PermissionDenied proto.InternalMessageInfo
+
+func (m *PermissionDenied) GetGroup() uint64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *PermissionDenied) GetCustemType() uint64 {
+	if m != nil {
+		return m.CustemType
+	}
+	return 0
+}
+
+// WriteFrozen the write request is rejected because the group is write frozen
+type WriteFrozen struct {
+	Group                uint64   `protobuf:"varint,1,opt,name=group,proto3" json:"group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteFrozen) Reset()         { *m = WriteFrozen{} }
+func (m *WriteFrozen) String() string { return proto.CompactTextString(m) }
+func (*WriteFrozen) ProtoMessage()    {}
+func (*WriteFrozen) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{9}
+}
+func (m *WriteFrozen) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WriteFrozen) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WriteFrozen.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WriteFrozen) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteFrozen.Merge(m, src)
+}
+func (m *WriteFrozen) XXX_Size() int {
+	return m.Size()
+}
+func (m *WriteFrozen) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteFrozen.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteFrozen proto.InternalMessageInfo
+
+func (m *WriteFrozen) GetGroup() uint64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
 // Error is a raft error
 type Error struct {
 	Message              string             `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -436,6 +540,8 @@ type Error struct {
 	StaleCommand         *StaleCommand      `protobuf:"bytes,7,opt,name=staleCommand,proto3" json:"staleCommand,omitempty"`
 	StoreNotMatch        *StoreNotMatch     `protobuf:"bytes,8,opt,name=storeNotMatch,proto3" json:"storeNotMatch,omitempty"`
 	RaftEntryTooLarge    *RaftEntryTooLarge `protobuf:"bytes,9,opt,name=raftEntryTooLarge,proto3" json:"raftEntryTooLarge,omitempty"`
+	PermissionDenied     *PermissionDenied  `protobuf:"bytes,10,opt,name=permissionDenied,proto3" json:"permissionDenied,omitempty"`
+	WriteFrozen          *WriteFrozen       `protobuf:"bytes,11,opt,name=writeFrozen,proto3" json:"writeFrozen,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -445,7 +551,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_390aa86757fd1154, []int{8}
+	return fileDescriptor_390aa86757fd1154, []int{10}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -537,6 +643,20 @@ func (m *Error) GetRaftEntryTooLarge() *RaftEntryTooLarge {
 	return nil
 }
 
+func (m *Error) GetPermissionDenied() *PermissionDenied {
+	if m != nil {
+		return m.PermissionDenied
+	}
+	return nil
+}
+
+func (m *Error) GetWriteFrozen() *WriteFrozen {
+	if m != nil {
+		return m.WriteFrozen
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NotLeader)(nil), "errorpb.NotLeader")
 	proto.RegisterType((*StoreNotMatch)(nil), "errorpb.StoreNotMatch")
@@ -546,47 +666,55 @@ func init() {
 	proto.RegisterType((*ServerIsBusy)(nil), "errorpb.ServerIsBusy")
 	proto.RegisterType((*StaleCommand)(nil), "errorpb.StaleCommand")
 	proto.RegisterType((*RaftEntryTooLarge)(nil), "errorpb.RaftEntryTooLarge")
+	proto.RegisterType((*PermissionDenied)(nil), "errorpb.PermissionDenied")
+	proto.RegisterType((*WriteFrozen)(nil), "errorpb.WriteFrozen")
 	proto.RegisterType((*Error)(nil), "errorpb.Error")
 }
 
 func init() { proto.RegisterFile("errorpb.proto", fileDescriptor_390aa86757fd1154) }
 
 var fileDescriptor_390aa86757fd1154 = []byte{
-	// 543 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5f, 0x8f, 0xd2, 0x4e,
-	0x14, 0xdd, 0xee, 0xf2, 0xe7, 0xd7, 0x0b, 0xfc, 0x76, 0xb7, 0xfe, 0x49, 0x43, 0x0c, 0x92, 0x3e,
-	0xa1, 0x89, 0x60, 0xe0, 0xc9, 0x44, 0x1f, 0x44, 0x31, 0x92, 0x45, 0xa2, 0x83, 0x5f, 0x60, 0xda,
-	0xde, 0x6d, 0x89, 0xdb, 0x99, 0x66, 0x66, 0x50, 0xf1, 0xd1, 0x4f, 0xb7, 0x8f, 0xfb, 0x09, 0x8c,
-	0xf2, 0x49, 0x4c, 0x87, 0x52, 0xa6, 0x6c, 0x34, 0xbe, 0xcd, 0x99, 0x7b, 0xce, 0xb9, 0x9d, 0x7b,
-	0x6e, 0xa1, 0x85, 0x42, 0x70, 0x91, 0xfa, 0xfd, 0x54, 0x70, 0xc5, 0x9d, 0x7a, 0x0e, 0xdb, 0x2f,
-	0xa2, 0xa5, 0x8a, 0x57, 0x7e, 0x3f, 0xe0, 0xc9, 0x20, 0xa1, 0x4a, 0x2c, 0xbf, 0x72, 0xb1, 0x8c,
-	0x96, 0x2c, 0x07, 0xc1, 0xca, 0xc7, 0x41, 0xea, 0x0f, 0xfc, 0x38, 0x41, 0x45, 0x8d, 0xc3, 0xd6,
-	0xa7, 0x3d, 0xfb, 0x07, 0x79, 0xc0, 0x93, 0x94, 0x33, 0x64, 0x4a, 0x0e, 0x52, 0xc1, 0xd3, 0x18,
-	0x55, 0xe6, 0x98, 0xfb, 0x95, 0xdc, 0x9e, 0x18, 0x6e, 0x11, 0x8f, 0xf8, 0x40, 0x5f, 0xfb, 0xab,
-	0x4b, 0x8d, 0x34, 0xd0, 0xa7, 0x2d, 0xdd, 0xfb, 0x00, 0xf6, 0x9c, 0xab, 0x19, 0xd2, 0x10, 0x85,
-	0xe3, 0x42, 0x5d, 0xc6, 0x54, 0x84, 0xd3, 0xd7, 0xae, 0xd5, 0xb5, 0x7a, 0x15, 0xb2, 0x83, 0xce,
-	0x63, 0xa8, 0x5d, 0x69, 0x8e, 0x7b, 0xdc, 0xb5, 0x7a, 0x8d, 0x61, 0xb3, 0x9f, 0x37, 0x7d, 0x8f,
-	0x28, 0xc6, 0x95, 0xeb, 0x1f, 0x0f, 0x8f, 0x48, 0xce, 0xf0, 0x4e, 0xa1, 0xb5, 0x50, 0x5c, 0xe0,
-	0x9c, 0xab, 0x77, 0x54, 0x05, 0xb1, 0xf7, 0x08, 0x5a, 0x8b, 0xcc, 0x67, 0xce, 0xd5, 0x1b, 0xbe,
-	0x62, 0xe1, 0x9f, 0xfb, 0x78, 0x01, 0xb4, 0x2e, 0x70, 0x3d, 0xe7, 0x6a, 0xca, 0xb4, 0xc4, 0x39,
-	0x83, 0x93, 0x4f, 0xb8, 0xd6, 0xb4, 0x26, 0xc9, 0x8e, 0xa6, 0xf8, 0xb8, 0xfc, 0x91, 0x77, 0xa1,
-	0x2a, 0x15, 0x15, 0xca, 0x3d, 0xd1, 0xec, 0x2d, 0xc8, 0x1c, 0x90, 0x85, 0x6e, 0x65, 0xeb, 0x80,
-	0x2c, 0xf4, 0x5e, 0x02, 0x2c, 0x14, 0xbd, 0xc2, 0x49, 0xca, 0x83, 0xd8, 0x19, 0x81, 0xcd, 0xf0,
-	0x8b, 0xee, 0x26, 0x5d, 0xab, 0x7b, 0xd2, 0x6b, 0x0c, 0x4f, 0xfb, 0x45, 0x44, 0xfa, 0x3e, 0x7f,
-	0xe0, 0x9e, 0xe7, 0xfd, 0x0f, 0xcd, 0x05, 0x8a, 0xcf, 0x28, 0xa6, 0x72, 0xbc, 0x92, 0x6b, 0x8d,
-	0x33, 0xcb, 0x57, 0x3c, 0x49, 0x28, 0x0b, 0xbd, 0x0b, 0x38, 0x27, 0xf4, 0x52, 0x4d, 0x98, 0x12,
-	0xeb, 0x8f, 0x9c, 0xcf, 0xa8, 0x88, 0xf0, 0x2f, 0xe3, 0x7d, 0x00, 0x36, 0x66, 0xd4, 0xc5, 0xf2,
-	0x1b, 0xe6, 0xaf, 0xda, 0x5f, 0x78, 0xdf, 0x2b, 0x50, 0x9d, 0x64, 0xbb, 0x96, 0x39, 0x24, 0x28,
-	0x25, 0x8d, 0x50, 0x3b, 0xd8, 0x64, 0x07, 0x9d, 0xa7, 0x60, 0xb3, 0x5d, 0x8e, 0x79, 0x46, 0x4e,
-	0x7f, 0xb7, 0xaf, 0x45, 0xc2, 0x64, 0x4f, 0x72, 0x9e, 0x43, 0x4b, 0x9a, 0xa9, 0xe8, 0xa9, 0x35,
-	0x86, 0xf7, 0x0b, 0x55, 0x29, 0x33, 0x52, 0x26, 0x67, 0xea, 0x52, 0x50, 0x7a, 0xbe, 0xa6, 0xba,
-	0x54, 0x25, 0x07, 0xa9, 0x8e, 0x00, 0x64, 0x91, 0x80, 0x5b, 0xd5, 0xd2, 0x3b, 0xfb, 0xc6, 0x45,
-	0x89, 0x18, 0x34, 0xe7, 0x19, 0x34, 0xa5, 0x31, 0x73, 0xb7, 0xa6, 0x65, 0xf7, 0xf6, 0x32, 0xa3,
-	0x48, 0x4a, 0x54, 0x2d, 0x35, 0xe2, 0x71, 0xeb, 0x87, 0x52, 0xa3, 0x48, 0x4a, 0x54, 0x3d, 0x26,
-	0x73, 0x9b, 0xdd, 0xff, 0x0e, 0xc7, 0x64, 0x56, 0x49, 0x99, 0xec, 0xbc, 0x85, 0x73, 0x71, 0xb8,
-	0x07, 0xae, 0xad, 0x1d, 0xda, 0x85, 0xc3, 0xad, 0x4d, 0x21, 0xb7, 0x45, 0xe3, 0xb3, 0x9b, 0x5f,
-	0x9d, 0xa3, 0xeb, 0x4d, 0xc7, 0xba, 0xd9, 0x74, 0xac, 0x9f, 0x9b, 0x8e, 0xe5, 0xd7, 0xf4, 0x1f,
-	0x3c, 0xfa, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xa0, 0xe6, 0x28, 0x53, 0x97, 0x04, 0x00, 0x00,
+	// 631 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x5f, 0x4f, 0xdb, 0x3a,
+	0x18, 0xc6, 0x29, 0xb4, 0x70, 0xfa, 0xb6, 0x3d, 0x14, 0x1f, 0xce, 0x91, 0x0f, 0x9a, 0x3a, 0x94,
+	0xdd, 0xb0, 0x49, 0x6b, 0x27, 0x90, 0x26, 0x4d, 0x62, 0x17, 0x63, 0x14, 0x81, 0x60, 0x15, 0x73,
+	0x91, 0x76, 0x9d, 0x3f, 0x2f, 0x69, 0x34, 0x62, 0x47, 0xb6, 0x33, 0x56, 0xee, 0xf6, 0xed, 0xb8,
+	0xe4, 0x13, 0x4c, 0x1b, 0x9f, 0x64, 0x8a, 0x9b, 0xa6, 0x4e, 0xd8, 0xa6, 0xdd, 0xf9, 0xf5, 0xfb,
+	0x3c, 0x3f, 0xc7, 0xf6, 0xe3, 0x40, 0x07, 0xa5, 0x14, 0x32, 0xf1, 0xfa, 0x89, 0x14, 0x5a, 0x90,
+	0xb5, 0xbc, 0xdc, 0x7a, 0x1d, 0x46, 0x7a, 0x92, 0x7a, 0x7d, 0x5f, 0xc4, 0x83, 0xd8, 0xd5, 0x32,
+	0xfa, 0x2c, 0x64, 0x14, 0x46, 0x3c, 0x2f, 0xfc, 0xd4, 0xc3, 0x41, 0xe2, 0x0d, 0xbc, 0x49, 0x8c,
+	0xda, 0xb5, 0x06, 0x33, 0xce, 0xd6, 0xd9, 0x1f, 0xd8, 0x7d, 0x11, 0x27, 0x82, 0x23, 0xd7, 0x6a,
+	0x90, 0x48, 0x91, 0x4c, 0x50, 0x67, 0xc4, 0x9c, 0x57, 0xa2, 0x3d, 0xb7, 0x68, 0xa1, 0x08, 0xc5,
+	0xc0, 0x4c, 0x7b, 0xe9, 0xa5, 0xa9, 0x4c, 0x61, 0x46, 0x33, 0xb9, 0xf3, 0x1e, 0x9a, 0x23, 0xa1,
+	0xcf, 0xd0, 0x0d, 0x50, 0x12, 0x0a, 0x6b, 0x6a, 0xe2, 0xca, 0xe0, 0xe4, 0x90, 0xd6, 0xb6, 0x6b,
+	0x3b, 0x75, 0x36, 0x2f, 0xc9, 0x33, 0x58, 0xbd, 0x32, 0x1a, 0xba, 0xbc, 0x5d, 0xdb, 0x69, 0xed,
+	0xb6, 0xfb, 0xf9, 0xa2, 0xe7, 0x88, 0xf2, 0xa0, 0x7e, 0xfb, 0xf5, 0xf1, 0x12, 0xcb, 0x15, 0xce,
+	0x3a, 0x74, 0xc6, 0x5a, 0x48, 0x1c, 0x09, 0xfd, 0xce, 0xd5, 0xfe, 0xc4, 0x79, 0x0a, 0x9d, 0x71,
+	0xc6, 0x19, 0x09, 0x7d, 0x24, 0x52, 0x1e, 0xfc, 0x7a, 0x1d, 0xc7, 0x87, 0xce, 0x29, 0x4e, 0x47,
+	0x42, 0x9f, 0x70, 0x63, 0x21, 0x5d, 0x58, 0xf9, 0x88, 0x53, 0x23, 0x6b, 0xb3, 0x6c, 0x68, 0x9b,
+	0x97, 0xcb, 0x1f, 0xb9, 0x09, 0x0d, 0xa5, 0x5d, 0xa9, 0xe9, 0x8a, 0x51, 0xcf, 0x8a, 0x8c, 0x80,
+	0x3c, 0xa0, 0xf5, 0x19, 0x01, 0x79, 0xe0, 0xbc, 0x01, 0x18, 0x6b, 0xf7, 0x0a, 0x87, 0x89, 0xf0,
+	0x27, 0x64, 0x0f, 0x9a, 0x1c, 0xaf, 0xcd, 0x6a, 0x8a, 0xd6, 0xb6, 0x57, 0x76, 0x5a, 0xbb, 0xeb,
+	0xfd, 0xe2, 0x8a, 0xcc, 0x7c, 0xbe, 0xc1, 0x85, 0xce, 0xf9, 0x1b, 0xda, 0x63, 0x94, 0x9f, 0x50,
+	0x9e, 0xa8, 0x83, 0x54, 0x4d, 0x4d, 0x9d, 0x21, 0xdf, 0x8a, 0x38, 0x76, 0x79, 0xe0, 0x9c, 0xc2,
+	0x06, 0x73, 0x2f, 0xf5, 0x90, 0x6b, 0x39, 0xbd, 0x10, 0xe2, 0xcc, 0x95, 0x21, 0xfe, 0xe6, 0x78,
+	0x1f, 0x41, 0x13, 0x33, 0xe9, 0x38, 0xba, 0xc1, 0x7c, 0x57, 0x8b, 0x09, 0xe7, 0x18, 0xba, 0xe7,
+	0x28, 0xe3, 0x48, 0xa9, 0x48, 0xf0, 0x43, 0xe4, 0x11, 0x06, 0xd9, 0x5e, 0x43, 0x29, 0xd2, 0x24,
+	0x27, 0xcd, 0x0a, 0xd2, 0x03, 0xf0, 0x53, 0xa5, 0x31, 0xbe, 0x98, 0x26, 0x73, 0x90, 0x35, 0xe3,
+	0x3c, 0x81, 0xd6, 0x07, 0x19, 0x69, 0x3c, 0x92, 0xe2, 0x06, 0xf9, 0xcf, 0x21, 0xce, 0x97, 0x06,
+	0x34, 0x86, 0x59, 0xb4, 0xb3, 0x0f, 0x8e, 0x51, 0x29, 0x37, 0x44, 0xa3, 0x68, 0xb2, 0x79, 0x49,
+	0x5e, 0x40, 0x93, 0xcf, 0x63, 0x93, 0x47, 0x82, 0xf4, 0xe7, 0xcf, 0xa3, 0x08, 0x14, 0x5b, 0x88,
+	0xc8, 0x3e, 0x74, 0x94, 0x1d, 0x02, 0x73, 0x49, 0xad, 0xdd, 0xff, 0x0a, 0x57, 0x29, 0x22, 0xac,
+	0x2c, 0x26, 0xfb, 0x95, 0x5c, 0xd0, 0x7a, 0xc5, 0x5d, 0xea, 0xb2, 0x4a, 0x88, 0xf6, 0x00, 0x54,
+	0x71, 0xe1, 0xb4, 0x61, 0xac, 0xff, 0x2c, 0x16, 0x2e, 0x5a, 0xcc, 0x92, 0x91, 0x57, 0xd0, 0x56,
+	0xd6, 0x15, 0xd3, 0x55, 0x63, 0xfb, 0x77, 0x61, 0xb3, 0x9a, 0xac, 0x24, 0x35, 0x56, 0x2b, 0x0d,
+	0x74, 0xad, 0x6a, 0xb5, 0x9a, 0xac, 0x24, 0x35, 0xc7, 0x64, 0x3f, 0x1e, 0xfa, 0x57, 0xf5, 0x98,
+	0xec, 0x2e, 0x2b, 0x8b, 0xc9, 0x31, 0x6c, 0xc8, 0x6a, 0xec, 0x68, 0xd3, 0x10, 0xb6, 0x0a, 0xc2,
+	0x83, 0x60, 0xb2, 0x87, 0x26, 0x32, 0x84, 0x6e, 0x52, 0xc9, 0x1c, 0x05, 0x03, 0xfa, 0xbf, 0x00,
+	0x55, 0x43, 0xc9, 0x1e, 0x58, 0xc8, 0x4b, 0x68, 0x5d, 0x2f, 0x02, 0x47, 0x5b, 0x86, 0xb0, 0x59,
+	0x10, 0xac, 0x30, 0x32, 0x5b, 0x78, 0xd0, 0xbd, 0xfb, 0xde, 0x5b, 0xba, 0xbd, 0xef, 0xd5, 0xee,
+	0xee, 0x7b, 0xb5, 0x6f, 0xf7, 0xbd, 0x9a, 0xb7, 0x6a, 0xfe, 0x57, 0x7b, 0x3f, 0x06, 0x00, 0x09,
+	0x91, 0xff, 0xfc, 0x85, 0x05, 0x00, 0x00,
 }
 
 func (m *NotLeader) Marshal() (dAtA []byte, err error) {
@@ -820,6 +948,63 @@ func (m *RaftEntryTooLarge) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PermissionDenied) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PermissionDenied) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Group != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.Group))
+	}
+	if m.CustemType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.CustemType))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteFrozen) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteFrozen) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Group != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.Group))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *Error) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -921,6 +1106,26 @@ func (m *Error) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n9
 	}
+	if m.PermissionDenied != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.PermissionDenied.Size()))
+		n10, err := m.PermissionDenied.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.WriteFrozen != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.WriteFrozen.Size()))
+		n11, err := m.WriteFrozen.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1067,6 +1272,39 @@ func (m *RaftEntryTooLarge) Size() (n int) {
 	return n
 }
 
+func (m *PermissionDenied) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Group != 0 {
+		n += 1 + sovErrorpb(uint64(m.Group))
+	}
+	if m.CustemType != 0 {
+		n += 1 + sovErrorpb(uint64(m.CustemType))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteFrozen) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Group != 0 {
+		n += 1 + sovErrorpb(uint64(m.Group))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *Error) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1109,6 +1347,14 @@ func (m *Error) Size() (n int) {
 		l = m.RaftEntryTooLarge.Size()
 		n += 1 + l + sovErrorpb(uint64(l))
 	}
+	if m.PermissionDenied != nil {
+		l = m.PermissionDenied.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.WriteFrozen != nil {
+		l = m.WriteFrozen.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1824,6 +2070,173 @@ func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PermissionDenied) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PermissionDenied: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PermissionDenied: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			m.Group = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Group |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CustemType", wireType)
+			}
+			m.CustemType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CustemType |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *WriteFrozen) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteFrozen: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteFrozen: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			m.Group = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Group |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func (m *Error) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2173,6 +2586,78 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PermissionDenied", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PermissionDenied == nil {
+				m.PermissionDenied = &PermissionDenied{}
+			}
+			if err := m.PermissionDenied.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteFrozen", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.WriteFrozen == nil {
+				m.WriteFrozen = &WriteFrozen{}
+			}
+			if err := m.WriteFrozen.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
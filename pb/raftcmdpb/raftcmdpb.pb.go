@@ -13,6 +13,7 @@ import (
 	metapb "github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	bhmetapb "github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	errorpb "github.com/matrixorigin/matrixcube/pb/errorpb"
+	raftpb "go.etcd.io/etcd/raft/v3/raftpb"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -34,6 +35,7 @@ const (
 	CMDType_Snap      CMDType = 2
 	CMDType_Write     CMDType = 3
 	CMDType_Read      CMDType = 4
+	CMDType_Admin     CMDType = 5
 )
 
 var CMDType_name = map[int32]string{
@@ -42,6 +44,7 @@ var CMDType_name = map[int32]string{
 	2: "Snap",
 	3: "Write",
 	4: "Read",
+	5: "Admin",
 }
 
 var CMDType_value = map[string]int32{
@@ -50,6 +53,7 @@ var CMDType_value = map[string]int32{
 	"Snap":      2,
 	"Write":     3,
 	"Read":      4,
+	"Admin":     5,
 }
 
 func (x CMDType) String() string {
@@ -72,17 +76,27 @@ const (
 	AdminCmdType_VerifyHash     AdminCmdType = 5
 	AdminCmdType_BatchSplit     AdminCmdType = 6
 	AdminCmdType_ChangePeerV2   AdminCmdType = 7
+	AdminCmdType_PrepareMerge   AdminCmdType = 8
+	AdminCmdType_CommitMerge    AdminCmdType = 9
+	AdminCmdType_RollbackMerge  AdminCmdType = 10
+	AdminCmdType_Ingest         AdminCmdType = 11
+	AdminCmdType_Backup         AdminCmdType = 12
 )
 
 var AdminCmdType_name = map[int32]string{
-	0: "InvalidAdmin",
-	1: "ChangePeer",
-	2: "CompactLog",
-	3: "TransferLeader",
-	4: "ComputeHash",
-	5: "VerifyHash",
-	6: "BatchSplit",
-	7: "ChangePeerV2",
+	0:  "InvalidAdmin",
+	1:  "ChangePeer",
+	2:  "CompactLog",
+	3:  "TransferLeader",
+	4:  "ComputeHash",
+	5:  "VerifyHash",
+	6:  "BatchSplit",
+	7:  "ChangePeerV2",
+	8:  "PrepareMerge",
+	9:  "CommitMerge",
+	10: "RollbackMerge",
+	11: "Ingest",
+	12: "Backup",
 }
 
 var AdminCmdType_value = map[string]int32{
@@ -94,6 +108,11 @@ var AdminCmdType_value = map[string]int32{
 	"VerifyHash":     5,
 	"BatchSplit":     6,
 	"ChangePeerV2":   7,
+	"PrepareMerge":   8,
+	"CommitMerge":    9,
+	"RollbackMerge":  10,
+	"Ingest":         11,
+	"Backup":         12,
 }
 
 func (x AdminCmdType) String() string {
@@ -106,15 +125,18 @@ func (AdminCmdType) EnumDescriptor() ([]byte, []int) {
 
 // RaftRequestHeader raft request header, it contains the shard's metadata
 type RaftRequestHeader struct {
-	ID                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ShardID              uint64               `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
-	Peer                 metapb.Peer          `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer"`
-	Epoch                metapb.ResourceEpoch `protobuf:"bytes,5,opt,name=epoch,proto3" json:"epoch"`
-	Term                 uint64               `protobuf:"varint,6,opt,name=term,proto3" json:"term,omitempty"`
-	IgnoreEpochCheck     bool                 `protobuf:"varint,7,opt,name=ignoreEpochCheck,proto3" json:"ignoreEpochCheck,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	ID               []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShardID          uint64               `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Peer             metapb.Peer          `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer"`
+	Epoch            metapb.ResourceEpoch `protobuf:"bytes,5,opt,name=epoch,proto3" json:"epoch"`
+	Term             uint64               `protobuf:"varint,6,opt,name=term,proto3" json:"term,omitempty"`
+	IgnoreEpochCheck bool                 `protobuf:"varint,7,opt,name=ignoreEpochCheck,proto3" json:"ignoreEpochCheck,omitempty"`
+	// proposedAt the unix time in seconds when the leader proposed the request, the TTLs of
+	// the writes are counted from it, so all the replicas have the same expiration time.
+	ProposedAt           int64    `protobuf:"varint,8,opt,name=proposedAt,proto3" json:"proposedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RaftRequestHeader) Reset()         { *m = RaftRequestHeader{} }
@@ -192,6 +214,13 @@ func (m *RaftRequestHeader) GetIgnoreEpochCheck() bool {
 	return false
 }
 
+func (m *RaftRequestHeader) GetProposedAt() int64 {
+	if m != nil {
+		return m.ProposedAt
+	}
+	return 0
+}
+
 type RaftResponseHeader struct {
 	ID                   []byte        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Error                errorpb.Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error"`
@@ -392,6 +421,12 @@ type AdminRequest struct {
 	VerifyHash           *VerifyHashRequest     `protobuf:"bytes,5,opt,name=verifyHash,proto3" json:"verifyHash,omitempty"`
 	Splits               *BatchSplitRequest     `protobuf:"bytes,6,opt,name=splits,proto3" json:"splits,omitempty"`
 	ChangePeerV2         *ChangePeerV2Request   `protobuf:"bytes,7,opt,name=changePeerV2,proto3" json:"changePeerV2,omitempty"`
+	PrepareMerge         *PrepareMergeRequest   `protobuf:"bytes,8,opt,name=prepareMerge,proto3" json:"prepareMerge,omitempty"`
+	CommitMerge          *CommitMergeRequest    `protobuf:"bytes,9,opt,name=commitMerge,proto3" json:"commitMerge,omitempty"`
+	RollbackMerge        *RollbackMergeRequest  `protobuf:"bytes,10,opt,name=rollbackMerge,proto3" json:"rollbackMerge,omitempty"`
+	ComputeHash          *ComputeHashRequest    `protobuf:"bytes,11,opt,name=computeHash,proto3" json:"computeHash,omitempty"`
+	Ingest               *IngestRequest         `protobuf:"bytes,12,opt,name=ingest,proto3" json:"ingest,omitempty"`
+	Backup               *BackupRequest         `protobuf:"bytes,13,opt,name=backup,proto3" json:"backup,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
@@ -479,6 +514,48 @@ func (m *AdminRequest) GetChangePeerV2() *ChangePeerV2Request {
 	return nil
 }
 
+func (m *AdminRequest) GetPrepareMerge() *PrepareMergeRequest {
+	if m != nil {
+		return m.PrepareMerge
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetCommitMerge() *CommitMergeRequest {
+	if m != nil {
+		return m.CommitMerge
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetRollbackMerge() *RollbackMergeRequest {
+	if m != nil {
+		return m.RollbackMerge
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetComputeHash() *ComputeHashRequest {
+	if m != nil {
+		return m.ComputeHash
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetIngest() *IngestRequest {
+	if m != nil {
+		return m.Ingest
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetBackup() *BackupRequest {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
 // AdminResponse admin response
 type AdminResponse struct {
 	CmdType              AdminCmdType            `protobuf:"varint,1,opt,name=cmdType,proto3,enum=raftcmdpb.AdminCmdType" json:"cmdType,omitempty"`
@@ -488,6 +565,12 @@ type AdminResponse struct {
 	VerifyHash           *VerifyHashResponse     `protobuf:"bytes,5,opt,name=verifyHash,proto3" json:"verifyHash,omitempty"`
 	Splits               *BatchSplitResponse     `protobuf:"bytes,9,opt,name=splits,proto3" json:"splits,omitempty"`
 	ChangePeerV2         *ChangePeerV2Response   `protobuf:"bytes,10,opt,name=changePeerV2,proto3" json:"changePeerV2,omitempty"`
+	PrepareMerge         *PrepareMergeResponse   `protobuf:"bytes,11,opt,name=prepareMerge,proto3" json:"prepareMerge,omitempty"`
+	CommitMerge          *CommitMergeResponse    `protobuf:"bytes,12,opt,name=commitMerge,proto3" json:"commitMerge,omitempty"`
+	RollbackMerge        *RollbackMergeResponse  `protobuf:"bytes,13,opt,name=rollbackMerge,proto3" json:"rollbackMerge,omitempty"`
+	ComputeHash          *ComputeHashResponse    `protobuf:"bytes,14,opt,name=computeHash,proto3" json:"computeHash,omitempty"`
+	Ingest               *IngestResponse         `protobuf:"bytes,15,opt,name=ingest,proto3" json:"ingest,omitempty"`
+	Backup               *BackupResponse         `protobuf:"bytes,16,opt,name=backup,proto3" json:"backup,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
 	XXX_sizecache        int32                   `json:"-"`
@@ -575,21 +658,68 @@ func (m *AdminResponse) GetChangePeerV2() *ChangePeerV2Response {
 	return nil
 }
 
+func (m *AdminResponse) GetPrepareMerge() *PrepareMergeResponse {
+	if m != nil {
+		return m.PrepareMerge
+	}
+	return nil
+}
+
+func (m *AdminResponse) GetCommitMerge() *CommitMergeResponse {
+	if m != nil {
+		return m.CommitMerge
+	}
+	return nil
+}
+
+func (m *AdminResponse) GetRollbackMerge() *RollbackMergeResponse {
+	if m != nil {
+		return m.RollbackMerge
+	}
+	return nil
+}
+
+func (m *AdminResponse) GetComputeHash() *ComputeHashResponse {
+	if m != nil {
+		return m.ComputeHash
+	}
+	return nil
+}
+
+func (m *AdminResponse) GetIngest() *IngestResponse {
+	if m != nil {
+		return m.Ingest
+	}
+	return nil
+}
+
+func (m *AdminResponse) GetBackup() *BackupResponse {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
 // Request request
 type Request struct {
-	ID                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Group                uint64   `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
-	Type                 CMDType  `protobuf:"varint,3,opt,name=type,proto3,enum=raftcmdpb.CMDType" json:"type,omitempty"`
-	CustemType           uint64   `protobuf:"varint,4,opt,name=custemType,proto3" json:"custemType,omitempty"`
-	Key                  []byte   `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
-	Cmd                  []byte   `protobuf:"bytes,6,opt,name=cmd,proto3" json:"cmd,omitempty"`
-	SID                  int64    `protobuf:"varint,7,opt,name=sid,proto3" json:"sid,omitempty"`
-	PID                  int64    `protobuf:"varint,8,opt,name=pid,proto3" json:"pid,omitempty"`
-	StopAt               int64    `protobuf:"varint,9,opt,name=stopAt,proto3" json:"stopAt,omitempty"`
-	ToShard              uint64   `protobuf:"varint,10,opt,name=toShard,proto3" json:"toShard,omitempty"`
-	AllowFollower        bool     `protobuf:"varint,11,opt,name=allowFollower,proto3" json:"allowFollower,omitempty"`
-	LastBroadcast        bool     `protobuf:"varint,12,opt,name=lastBroadcast,proto3" json:"lastBroadcast,omitempty"`
-	IgnoreEpochCheck     bool     `protobuf:"varint,13,opt,name=ignoreEpochCheck,proto3" json:"ignoreEpochCheck,omitempty"`
+	ID               []byte  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Group            uint64  `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
+	Type             CMDType `protobuf:"varint,3,opt,name=type,proto3,enum=raftcmdpb.CMDType" json:"type,omitempty"`
+	CustemType       uint64  `protobuf:"varint,4,opt,name=custemType,proto3" json:"custemType,omitempty"`
+	Key              []byte  `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	Cmd              []byte  `protobuf:"bytes,6,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	SID              int64   `protobuf:"varint,7,opt,name=sid,proto3" json:"sid,omitempty"`
+	PID              int64   `protobuf:"varint,8,opt,name=pid,proto3" json:"pid,omitempty"`
+	StopAt           int64   `protobuf:"varint,9,opt,name=stopAt,proto3" json:"stopAt,omitempty"`
+	ToShard          uint64  `protobuf:"varint,10,opt,name=toShard,proto3" json:"toShard,omitempty"`
+	AllowFollower    bool    `protobuf:"varint,11,opt,name=allowFollower,proto3" json:"allowFollower,omitempty"`
+	LastBroadcast    bool    `protobuf:"varint,12,opt,name=lastBroadcast,proto3" json:"lastBroadcast,omitempty"`
+	IgnoreEpochCheck bool    `protobuf:"varint,13,opt,name=ignoreEpochCheck,proto3" json:"ignoreEpochCheck,omitempty"`
+	Token            string  `protobuf:"bytes,14,opt,name=token,proto3" json:"token,omitempty"`
+	Tenant           uint64  `protobuf:"varint,15,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// followerReadIndex the read is served by a follower with the ReadIndex forwarded to the
+	// leader, it's linearizable
+	FollowerReadIndex    bool     `protobuf:"varint,16,opt,name=followerReadIndex,proto3" json:"followerReadIndex,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -719,6 +849,27 @@ func (m *Request) GetIgnoreEpochCheck() bool {
 	return false
 }
 
+func (m *Request) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Request) GetTenant() uint64 {
+	if m != nil {
+		return m.Tenant
+	}
+	return 0
+}
+
+func (m *Request) GetFollowerReadIndex() bool {
+	if m != nil {
+		return m.FollowerReadIndex
+	}
+	return false
+}
+
 // Response response
 type Response struct {
 	ID                   []byte        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1118,6 +1269,84 @@ func (m *TransferLeaderResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TransferLeaderResponse proto.InternalMessageInfo
 
+type ComputeHashRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ComputeHashRequest) Reset()         { *m = ComputeHashRequest{} }
+func (m *ComputeHashRequest) String() string { return proto.CompactTextString(m) }
+func (*ComputeHashRequest) ProtoMessage()    {}
+func (*ComputeHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{14}
+}
+func (m *ComputeHashRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ComputeHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ComputeHashRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ComputeHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeHashRequest.Merge(m, src)
+}
+func (m *ComputeHashRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ComputeHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeHashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeHashRequest proto.InternalMessageInfo
+
+type ComputeHashResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ComputeHashResponse) Reset()         { *m = ComputeHashResponse{} }
+func (m *ComputeHashResponse) String() string { return proto.CompactTextString(m) }
+func (*ComputeHashResponse) ProtoMessage()    {}
+func (*ComputeHashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{15}
+}
+func (m *ComputeHashResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ComputeHashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ComputeHashResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ComputeHashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeHashResponse.Merge(m, src)
+}
+func (m *ComputeHashResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ComputeHashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeHashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeHashResponse proto.InternalMessageInfo
+
 type VerifyHashRequest struct {
 	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -1131,7 +1360,7 @@ func (m *VerifyHashRequest) Reset()         { *m = VerifyHashRequest{} }
 func (m *VerifyHashRequest) String() string { return proto.CompactTextString(m) }
 func (*VerifyHashRequest) ProtoMessage()    {}
 func (*VerifyHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{14}
+	return fileDescriptor_c4d8ad5550754569, []int{16}
 }
 func (m *VerifyHashRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1191,7 +1420,7 @@ func (m *VerifyHashResponse) Reset()         { *m = VerifyHashResponse{} }
 func (m *VerifyHashResponse) String() string { return proto.CompactTextString(m) }
 func (*VerifyHashResponse) ProtoMessage()    {}
 func (*VerifyHashResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{15}
+	return fileDescriptor_c4d8ad5550754569, []int{17}
 }
 func (m *VerifyHashResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1239,7 +1468,7 @@ func (m *SplitRequest) Reset()         { *m = SplitRequest{} }
 func (m *SplitRequest) String() string { return proto.CompactTextString(m) }
 func (*SplitRequest) ProtoMessage()    {}
 func (*SplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{16}
+	return fileDescriptor_c4d8ad5550754569, []int{18}
 }
 func (m *SplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1303,7 +1532,7 @@ func (m *BatchSplitRequest) Reset()         { *m = BatchSplitRequest{} }
 func (m *BatchSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*BatchSplitRequest) ProtoMessage()    {}
 func (*BatchSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{17}
+	return fileDescriptor_c4d8ad5550754569, []int{19}
 }
 func (m *BatchSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1357,7 +1586,7 @@ func (m *BatchSplitResponse) Reset()         { *m = BatchSplitResponse{} }
 func (m *BatchSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*BatchSplitResponse) ProtoMessage()    {}
 func (*BatchSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{18}
+	return fileDescriptor_c4d8ad5550754569, []int{20}
 }
 func (m *BatchSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1404,7 +1633,7 @@ func (m *ChangePeerV2Request) Reset()         { *m = ChangePeerV2Request{} }
 func (m *ChangePeerV2Request) String() string { return proto.CompactTextString(m) }
 func (*ChangePeerV2Request) ProtoMessage()    {}
 func (*ChangePeerV2Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{19}
+	return fileDescriptor_c4d8ad5550754569, []int{21}
 }
 func (m *ChangePeerV2Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1451,7 +1680,7 @@ func (m *ChangePeerV2Response) Reset()         { *m = ChangePeerV2Response{} }
 func (m *ChangePeerV2Response) String() string { return proto.CompactTextString(m) }
 func (*ChangePeerV2Response) ProtoMessage()    {}
 func (*ChangePeerV2Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c4d8ad5550754569, []int{20}
+	return fileDescriptor_c4d8ad5550754569, []int{22}
 }
 func (m *ChangePeerV2Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1487,507 +1716,683 @@ func (m *ChangePeerV2Response) GetShard() *bhmetapb.Shard {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("raftcmdpb.CMDType", CMDType_name, CMDType_value)
-	proto.RegisterEnum("raftcmdpb.AdminCmdType", AdminCmdType_name, AdminCmdType_value)
-	proto.RegisterType((*RaftRequestHeader)(nil), "raftcmdpb.RaftRequestHeader")
-	proto.RegisterType((*RaftResponseHeader)(nil), "raftcmdpb.RaftResponseHeader")
-	proto.RegisterType((*RaftCMDRequest)(nil), "raftcmdpb.RaftCMDRequest")
-	proto.RegisterType((*RaftCMDResponse)(nil), "raftcmdpb.RaftCMDResponse")
-	proto.RegisterType((*AdminRequest)(nil), "raftcmdpb.AdminRequest")
-	proto.RegisterType((*AdminResponse)(nil), "raftcmdpb.AdminResponse")
-	proto.RegisterType((*Request)(nil), "raftcmdpb.Request")
-	proto.RegisterType((*Response)(nil), "raftcmdpb.Response")
-	proto.RegisterType((*ChangePeerRequest)(nil), "raftcmdpb.ChangePeerRequest")
-	proto.RegisterType((*ChangePeerResponse)(nil), "raftcmdpb.ChangePeerResponse")
-	proto.RegisterType((*CompactLogRequest)(nil), "raftcmdpb.CompactLogRequest")
-	proto.RegisterType((*CompactLogResponse)(nil), "raftcmdpb.CompactLogResponse")
-	proto.RegisterType((*TransferLeaderRequest)(nil), "raftcmdpb.TransferLeaderRequest")
-	proto.RegisterType((*TransferLeaderResponse)(nil), "raftcmdpb.TransferLeaderResponse")
-	proto.RegisterType((*VerifyHashRequest)(nil), "raftcmdpb.VerifyHashRequest")
-	proto.RegisterType((*VerifyHashResponse)(nil), "raftcmdpb.VerifyHashResponse")
-	proto.RegisterType((*SplitRequest)(nil), "raftcmdpb.SplitRequest")
-	proto.RegisterType((*BatchSplitRequest)(nil), "raftcmdpb.BatchSplitRequest")
-	proto.RegisterType((*BatchSplitResponse)(nil), "raftcmdpb.BatchSplitResponse")
-	proto.RegisterType((*ChangePeerV2Request)(nil), "raftcmdpb.ChangePeerV2Request")
-	proto.RegisterType((*ChangePeerV2Response)(nil), "raftcmdpb.ChangePeerV2Response")
+// PrepareMergeRequest prepare to merge the current shard into the target shard.
+// After the request applied, the source shard will not accept any write requests.
+type PrepareMergeRequest struct {
+	// MinIndex the min matched raft log index of all source shard's peers
+	MinIndex             uint64         `protobuf:"varint,1,opt,name=minIndex,proto3" json:"minIndex,omitempty"`
+	Target               bhmetapb.Shard `protobuf:"bytes,2,opt,name=target,proto3" json:"target"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func init() { proto.RegisterFile("raftcmdpb.proto", fileDescriptor_c4d8ad5550754569) }
-
-var fileDescriptor_c4d8ad5550754569 = []byte{
-	// 1380 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x0e, 0x45, 0xfd, 0x79, 0x44, 0xdb, 0xf4, 0xc6, 0x71, 0xd9, 0xa0, 0xb6, 0x55, 0xa2, 0x2d,
-	0x0c, 0xb7, 0xb1, 0x11, 0x35, 0x6d, 0x51, 0x24, 0x6e, 0x61, 0x49, 0x29, 0x22, 0x34, 0x01, 0x02,
-	0x2a, 0x48, 0xd0, 0x23, 0x45, 0xae, 0x25, 0x36, 0x12, 0xc9, 0x2e, 0x57, 0x4e, 0xdc, 0x6b, 0x1f,
-	0xa2, 0x8f, 0x93, 0x5b, 0x90, 0x4b, 0x81, 0x3c, 0x81, 0x91, 0xfa, 0x49, 0x8a, 0xfd, 0x21, 0xb9,
-	0x34, 0x25, 0x3b, 0xe8, 0xc5, 0xe2, 0xfc, 0xed, 0xce, 0xcc, 0x37, 0x3b, 0x33, 0x86, 0x75, 0xe2,
-	0x9e, 0x50, 0x6f, 0xe6, 0xc7, 0xa3, 0x83, 0x98, 0x44, 0x34, 0x42, 0x2b, 0x19, 0xe3, 0xf6, 0xd1,
-	0x38, 0xa0, 0x93, 0xf9, 0xe8, 0xc0, 0x8b, 0x66, 0x87, 0x33, 0x97, 0x92, 0xe0, 0x75, 0x44, 0x82,
-	0x71, 0x10, 0x4a, 0xc2, 0x9b, 0x8f, 0xf0, 0x61, 0x3c, 0x3a, 0x1c, 0x4d, 0x66, 0x98, 0xba, 0xca,
-	0x87, 0x38, 0xe9, 0xf6, 0xfd, 0x8f, 0x33, 0xc7, 0x84, 0x44, 0x24, 0xff, 0x95, 0xc6, 0x8f, 0x3f,
-	0xc2, 0xd8, 0x8b, 0x66, 0x71, 0x14, 0xe2, 0x90, 0x26, 0x87, 0x31, 0x89, 0xe2, 0x09, 0xa6, 0xec,
-	0x3c, 0xe9, 0x4c, 0xc1, 0x95, 0x3b, 0xca, 0x69, 0xe3, 0x68, 0x1c, 0x1d, 0x72, 0xf6, 0x68, 0x7e,
-	0xc2, 0x29, 0x4e, 0xf0, 0x2f, 0xa1, 0x6e, 0x7f, 0xd0, 0x60, 0xc3, 0x71, 0x4f, 0xa8, 0x83, 0xff,
-	0x98, 0xe3, 0x84, 0x3e, 0xc2, 0xae, 0x8f, 0x09, 0xda, 0x82, 0x4a, 0xe0, 0x5b, 0x5a, 0x5b, 0xdb,
-	0x33, 0xba, 0xf5, 0x8b, 0xf3, 0xdd, 0xca, 0xa0, 0xef, 0x54, 0x02, 0x1f, 0x59, 0xd0, 0x48, 0x26,
-	0x2e, 0xf1, 0x07, 0x7d, 0xab, 0xd2, 0xd6, 0xf6, 0xaa, 0x4e, 0x4a, 0xa2, 0xaf, 0xa0, 0x1a, 0x63,
-	0x4c, 0x2c, 0xbd, 0xad, 0xed, 0xb5, 0x3a, 0xc6, 0x81, 0xf4, 0xe9, 0x29, 0xc6, 0xa4, 0x5b, 0x7d,
-	0x77, 0xbe, 0x7b, 0xc3, 0xe1, 0x72, 0x74, 0x17, 0x6a, 0x38, 0x8e, 0xbc, 0x89, 0x55, 0xe3, 0x8a,
-	0xb7, 0x52, 0x45, 0x07, 0x27, 0xd1, 0x9c, 0x78, 0xf8, 0x21, 0x13, 0x4a, 0x0b, 0xa1, 0x89, 0x10,
-	0x54, 0x29, 0x26, 0x33, 0xab, 0xce, 0x6f, 0xe4, 0xdf, 0x68, 0x1f, 0xcc, 0x60, 0x1c, 0x46, 0x44,
-	0xe8, 0xf7, 0x26, 0xd8, 0x7b, 0x69, 0x35, 0xda, 0xda, 0x5e, 0xd3, 0x29, 0xf1, 0xed, 0x3f, 0x01,
-	0x89, 0x08, 0x93, 0x38, 0x0a, 0x13, 0x7c, 0x4d, 0x88, 0xfb, 0x50, 0xe3, 0xf0, 0xf0, 0x00, 0x5b,
-	0x9d, 0xb5, 0x83, 0x14, 0xac, 0x87, 0xec, 0x37, 0xf3, 0x8c, 0x11, 0xa8, 0x0d, 0x2d, 0x6f, 0x4e,
-	0x08, 0x0e, 0xe9, 0x33, 0xe6, 0xa0, 0xce, 0x1d, 0x54, 0x59, 0xf6, 0x1b, 0x0d, 0xd6, 0xd8, 0xe5,
-	0xbd, 0x27, 0x7d, 0x99, 0x61, 0x74, 0x0f, 0xea, 0x13, 0xee, 0x02, 0xbf, 0xbc, 0xd5, 0xf9, 0xec,
-	0x20, 0xaf, 0xcb, 0x12, 0x12, 0x8e, 0xd4, 0x45, 0xf7, 0xa0, 0x49, 0x84, 0x20, 0xb1, 0x2a, 0x6d,
-	0x7d, 0xaf, 0xd5, 0x41, 0xaa, 0x9d, 0x10, 0x71, 0xef, 0x34, 0x27, 0xd3, 0x44, 0xc7, 0x60, 0xb8,
-	0xfe, 0x2c, 0x08, 0xa5, 0x5c, 0xa2, 0xf3, 0x89, 0x62, 0x79, 0xac, 0x88, 0xa5, 0x79, 0xc1, 0xc4,
-	0xfe, 0x47, 0x83, 0xf5, 0x2c, 0x02, 0x91, 0x41, 0x74, 0xff, 0x52, 0x08, 0xdb, 0xa5, 0x10, 0xd4,
-	0x54, 0xcb, 0x63, 0xd3, 0x48, 0x7e, 0x80, 0x15, 0x22, 0xe5, 0x69, 0x28, 0x37, 0x0b, 0xa1, 0x08,
-	0x99, 0xb4, 0xca, 0x75, 0x51, 0x1f, 0x56, 0xa5, 0x67, 0x82, 0x23, 0xa3, 0xb1, 0xca, 0xd1, 0x14,
-	0x4e, 0x28, 0x1a, 0xd9, 0x6f, 0x74, 0x30, 0xd4, 0xa0, 0xd1, 0x5d, 0x68, 0x78, 0x33, 0xff, 0xd9,
-	0x59, 0x8c, 0x79, 0x34, 0x6b, 0xe5, 0xf4, 0xf4, 0x84, 0xd8, 0x49, 0xf5, 0xd0, 0x03, 0x00, 0x6f,
-	0xe2, 0x86, 0x63, 0xcc, 0xca, 0x5b, 0x16, 0x8a, 0x0a, 0x63, 0x2f, 0x13, 0xca, 0x4b, 0x1c, 0x45,
-	0x9f, 0x5b, 0x47, 0xb3, 0xd8, 0xf5, 0xe8, 0xe3, 0x68, 0x2c, 0x83, 0x28, 0x58, 0x67, 0xc2, 0xdc,
-	0x3a, 0x63, 0xa1, 0x47, 0xb0, 0x46, 0x89, 0x1b, 0x26, 0x27, 0x98, 0x3c, 0x16, 0x18, 0x54, 0xf9,
-	0x09, 0x6d, 0xe5, 0x84, 0x67, 0x05, 0x85, 0xf4, 0x94, 0x4b, 0x76, 0xcc, 0x8f, 0x53, 0x4c, 0x82,
-	0x93, 0xb3, 0x47, 0x6e, 0x92, 0xbe, 0x47, 0xd5, 0x8f, 0xe7, 0x99, 0x30, 0xf3, 0x23, 0xd7, 0x67,
-	0x65, 0x9c, 0xc4, 0xd3, 0x80, 0x26, 0xfc, 0x5d, 0x16, 0x2d, 0xbb, 0x2e, 0xf5, 0x26, 0x43, 0x26,
-	0x4d, 0x2d, 0xa5, 0x2e, 0xea, 0x82, 0x91, 0x67, 0xe2, 0x79, 0x87, 0xbf, 0xd9, 0x56, 0x67, 0x67,
-	0x61, 0xee, 0x9e, 0x77, 0x52, 0xeb, 0x82, 0x8d, 0xfd, 0x56, 0x87, 0xd5, 0x02, 0xd0, 0xff, 0x07,
-	0xc2, 0xa3, 0x05, 0x10, 0x6e, 0x2f, 0x81, 0x50, 0xdc, 0x52, 0xc0, 0xf0, 0x68, 0x01, 0x86, 0xdb,
-	0x4b, 0x30, 0xcc, 0xcc, 0x73, 0x10, 0x07, 0x4b, 0x40, 0xfc, 0xfc, 0x0a, 0x10, 0xe5, 0x31, 0x97,
-	0x51, 0x3c, 0x5a, 0x80, 0xe2, 0xf6, 0x12, 0x14, 0x53, 0x4f, 0x14, 0x18, 0xbf, 0xcb, 0x60, 0x5c,
-	0x29, 0x99, 0xaa, 0x30, 0x4a, 0xd3, 0x14, 0xc7, 0xde, 0x25, 0x1c, 0x81, 0x1b, 0xef, 0x2e, 0xc5,
-	0x51, 0x9a, 0x17, 0x81, 0xfc, 0x4b, 0x87, 0x46, 0xfa, 0x0a, 0x97, 0xb5, 0xe3, 0x4d, 0xa8, 0x8d,
-	0x49, 0x34, 0x8f, 0xe5, 0xbc, 0x11, 0x04, 0x9b, 0x36, 0x94, 0xa1, 0xad, 0x73, 0xb4, 0xd5, 0x4e,
-	0xd8, 0x7b, 0xd2, 0xe7, 0x40, 0x73, 0x39, 0xda, 0x01, 0xf0, 0xe6, 0x09, 0xc5, 0x33, 0x5e, 0x1b,
-	0x55, 0x7e, 0x84, 0xc2, 0x41, 0x26, 0xe8, 0x2f, 0xf1, 0x19, 0xcf, 0x9a, 0xe1, 0xb0, 0x4f, 0xc6,
-	0xf1, 0x66, 0x3e, 0xaf, 0x69, 0xc3, 0x61, 0x9f, 0xe8, 0x53, 0xd0, 0x93, 0xc0, 0xe7, 0x95, 0xaa,
-	0x77, 0x1b, 0x17, 0xe7, 0xbb, 0xfa, 0x70, 0xd0, 0x77, 0x18, 0x8f, 0x89, 0xe2, 0xc0, 0xb7, 0x9a,
-	0xb9, 0xe8, 0x29, 0x13, 0xc5, 0x81, 0x8f, 0xb6, 0xa0, 0x9e, 0xd0, 0x28, 0x3e, 0xa6, 0x3c, 0xaf,
-	0xba, 0x23, 0x29, 0x36, 0x41, 0x69, 0x34, 0x64, 0x43, 0x93, 0xe7, 0xac, 0xea, 0xa4, 0x24, 0xfa,
-	0x02, 0x56, 0xdd, 0xe9, 0x34, 0x7a, 0xf5, 0x4b, 0xc4, 0xfe, 0x62, 0x62, 0xb5, 0xf8, 0x3c, 0x2b,
-	0x32, 0x99, 0xd6, 0xd4, 0x4d, 0x68, 0x97, 0x44, 0xae, 0xef, 0xb9, 0x09, 0xb5, 0x0c, 0xa1, 0x55,
-	0x60, 0x2e, 0x1c, 0x8f, 0xab, 0x4b, 0xc6, 0xe3, 0xdb, 0x0a, 0x34, 0xb3, 0x97, 0xb4, 0x0c, 0x86,
-	0x34, 0xe1, 0x95, 0x6b, 0x12, 0xbe, 0x09, 0xb5, 0x53, 0x77, 0x3a, 0x17, 0xc8, 0x18, 0x8e, 0x20,
-	0xd0, 0x4f, 0xb0, 0x2a, 0x56, 0x9a, 0x74, 0x0e, 0x89, 0x6a, 0x5f, 0x3e, 0xc1, 0x8a, 0xea, 0x29,
-	0x04, 0xb5, 0xe5, 0x10, 0xd4, 0x17, 0x40, 0x90, 0x4d, 0xf2, 0xc6, 0xf5, 0x93, 0xfc, 0x1b, 0xd8,
-	0xf0, 0xa2, 0x90, 0x06, 0xe1, 0x1c, 0xe7, 0xa9, 0x6d, 0xf2, 0x8c, 0x95, 0x05, 0x2c, 0xca, 0x84,
-	0xba, 0x53, 0xcc, 0xb1, 0x6d, 0x3a, 0x82, 0xb0, 0x13, 0xd8, 0x28, 0x35, 0x7e, 0xf4, 0x7d, 0xda,
-	0x67, 0x94, 0xee, 0xb4, 0x95, 0x2e, 0x3d, 0xb9, 0x3a, 0x4f, 0xa1, 0xa2, 0x99, 0xed, 0x53, 0x95,
-	0xab, 0xf7, 0x29, 0xfb, 0x18, 0x50, 0xb9, 0x55, 0xa1, 0xaf, 0xa1, 0xc6, 0x17, 0x33, 0x39, 0x9f,
-	0xd7, 0x0f, 0xb2, 0x7d, 0x95, 0xd7, 0x5a, 0x1a, 0x3b, 0xd7, 0xb1, 0x7f, 0x83, 0x8d, 0xd2, 0xc8,
-	0x41, 0x36, 0x18, 0xb2, 0x5f, 0x0d, 0x42, 0x1f, 0xbf, 0xe6, 0x07, 0x55, 0x9d, 0x02, 0x8f, 0xaf,
-	0x3f, 0x82, 0xe6, 0xeb, 0x4f, 0x45, 0xae, 0x3f, 0x39, 0xcb, 0xde, 0x04, 0x54, 0xee, 0x84, 0xf6,
-	0xcf, 0x70, 0x6b, 0xe1, 0x84, 0xca, 0x82, 0xd6, 0xae, 0x09, 0xda, 0x82, 0xad, 0xc5, 0xdd, 0xd1,
-	0x7e, 0x01, 0x1b, 0xa5, 0xb1, 0xc5, 0xe0, 0x0a, 0x94, 0x20, 0x04, 0xc1, 0xd6, 0xca, 0x09, 0x6b,
-	0x99, 0x15, 0x5e, 0xa9, 0xfc, 0x9b, 0xbd, 0x4e, 0x86, 0x36, 0x7e, 0x4d, 0x65, 0x01, 0xa7, 0x24,
-	0x8b, 0xa4, 0xdc, 0x49, 0xed, 0xdf, 0xc1, 0x50, 0xc7, 0x1c, 0xba, 0x0d, 0x4d, 0xde, 0x20, 0x7f,
-	0xc5, 0x67, 0xe2, 0x11, 0x39, 0x19, 0xcd, 0x7a, 0x51, 0x88, 0x5f, 0x0d, 0x0b, 0xeb, 0xb3, 0xc2,
-	0x91, 0x72, 0x16, 0xeb, 0xa0, 0x9f, 0x58, 0x7a, 0x5b, 0x97, 0x72, 0xc9, 0xb1, 0x63, 0xd8, 0x28,
-	0xcd, 0x55, 0xf4, 0xa3, 0xb2, 0x16, 0x6a, 0x7c, 0x97, 0x52, 0x47, 0x9f, 0xaa, 0x2a, 0x13, 0x98,
-	0xef, 0x86, 0x6d, 0x68, 0x91, 0x60, 0x3c, 0xa1, 0x7d, 0x4c, 0x82, 0x53, 0xf1, 0xb2, 0x9b, 0x8e,
-	0xca, 0xb2, 0x7b, 0x80, 0xca, 0x23, 0x00, 0xdd, 0x81, 0x3a, 0xaf, 0x9b, 0xf4, 0xc2, 0x25, 0xc5,
-	0x25, 0x95, 0xec, 0x21, 0xdc, 0x5c, 0x30, 0xd2, 0xd1, 0x03, 0x68, 0x88, 0x6a, 0x4f, 0x8f, 0xb9,
-	0x72, 0x7f, 0x92, 0x67, 0xa6, 0x26, 0xf6, 0x11, 0x6c, 0x2e, 0x9a, 0x2f, 0xe8, 0xcb, 0xab, 0xeb,
-	0x5e, 0x56, 0xfc, 0x7e, 0x1f, 0x1a, 0xb2, 0x6d, 0xa1, 0x16, 0x34, 0x06, 0xe1, 0xa9, 0x3b, 0x0d,
-	0x7c, 0xf3, 0x06, 0x5a, 0x85, 0x15, 0xb6, 0xbe, 0xf2, 0xfe, 0x60, 0x6a, 0xa8, 0x09, 0xd5, 0x61,
-	0xe8, 0xc6, 0x66, 0x05, 0xad, 0x40, 0xed, 0x05, 0x09, 0x28, 0x36, 0x75, 0xc6, 0x74, 0xb0, 0xeb,
-	0x9b, 0xd5, 0xfd, 0xbf, 0x35, 0xb9, 0x49, 0xca, 0xe5, 0x02, 0x99, 0x60, 0xc8, 0xb3, 0x38, 0xdb,
-	0xbc, 0x81, 0xd6, 0x00, 0x72, 0x3f, 0x4d, 0x8d, 0xd3, 0xd9, 0x7b, 0x30, 0x2b, 0x08, 0xc1, 0x5a,
-	0xb1, 0x90, 0x4d, 0x1d, 0xad, 0x43, 0x8b, 0xe9, 0xcc, 0x29, 0x66, 0xa5, 0x66, 0x56, 0x99, 0x51,
-	0x5e, 0x7a, 0x66, 0x8d, 0xd1, 0x39, 0x2c, 0x66, 0x9d, 0x5d, 0xab, 0x26, 0xc3, 0x6c, 0x74, 0xcd,
-	0xf7, 0xff, 0xee, 0x68, 0xef, 0x2e, 0x76, 0xb4, 0xf7, 0x17, 0x3b, 0xda, 0x87, 0x8b, 0x1d, 0x6d,
-	0x54, 0xe7, 0xff, 0xed, 0x7d, 0xfb, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x1f, 0xfe, 0xa5, 0x8f,
-	0x04, 0x0f, 0x00, 0x00,
+func (m *PrepareMergeRequest) Reset()         { *m = PrepareMergeRequest{} }
+func (m *PrepareMergeRequest) String() string { return proto.CompactTextString(m) }
+func (*PrepareMergeRequest) ProtoMessage()    {}
+func (*PrepareMergeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{23}
 }
-
-func (m *RaftRequestHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *PrepareMergeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrepareMergeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrepareMergeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *PrepareMergeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareMergeRequest.Merge(m, src)
+}
+func (m *PrepareMergeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrepareMergeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareMergeRequest.DiscardUnknown(m)
 }
 
-func (m *RaftRequestHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
-	}
-	if m.ShardID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ShardID))
-	}
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Peer.Size()))
-	n1, err := m.Peer.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n1
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Epoch.Size()))
-	n2, err := m.Epoch.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n2
-	if m.Term != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Term))
-	}
-	if m.IgnoreEpochCheck {
-		dAtA[i] = 0x38
-		i++
-		if m.IgnoreEpochCheck {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+var xxx_messageInfo_PrepareMergeRequest proto.InternalMessageInfo
+
+func (m *PrepareMergeRequest) GetMinIndex() uint64 {
+	if m != nil {
+		return m.MinIndex
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return 0
+}
+
+func (m *PrepareMergeRequest) GetTarget() bhmetapb.Shard {
+	if m != nil {
+		return m.Target
 	}
-	return i, nil
+	return bhmetapb.Shard{}
 }
 
-func (m *RaftResponseHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type PrepareMergeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RaftResponseHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Error.Size()))
-	n3, err := m.Error.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n3
-	if m.CurrentTerm != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CurrentTerm))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *PrepareMergeResponse) Reset()         { *m = PrepareMergeResponse{} }
+func (m *PrepareMergeResponse) String() string { return proto.CompactTextString(m) }
+func (*PrepareMergeResponse) ProtoMessage()    {}
+func (*PrepareMergeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{24}
+}
+func (m *PrepareMergeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrepareMergeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrepareMergeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *PrepareMergeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareMergeResponse.Merge(m, src)
+}
+func (m *PrepareMergeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrepareMergeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareMergeResponse.DiscardUnknown(m)
 }
 
-func (m *RaftCMDRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+var xxx_messageInfo_PrepareMergeResponse proto.InternalMessageInfo
+
+// CommitMergeRequest commit the merge on the target shard. It contains the
+// raft logs of source shard in (MinIndex, Commit], so the source shard's peers
+// on the target shard's stores can catch up before merged.
+type CommitMergeRequest struct {
+	Source               bhmetapb.Shard `protobuf:"bytes,1,opt,name=source,proto3" json:"source"`
+	Commit               uint64         `protobuf:"varint,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Entries              []raftpb.Entry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *RaftCMDRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Header.Size()))
-		n4, err := m.Header.MarshalTo(dAtA[i:])
+func (m *CommitMergeRequest) Reset()         { *m = CommitMergeRequest{} }
+func (m *CommitMergeRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitMergeRequest) ProtoMessage()    {}
+func (*CommitMergeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{25}
+}
+func (m *CommitMergeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitMergeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitMergeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
-		}
-		i += n4
-	}
-	if len(m.Requests) > 0 {
-		for _, msg := range m.Requests {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.AdminRequest != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.AdminRequest.Size()))
-		n5, err := m.AdminRequest.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+}
+func (m *CommitMergeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitMergeRequest.Merge(m, src)
+}
+func (m *CommitMergeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitMergeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitMergeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitMergeRequest proto.InternalMessageInfo
+
+func (m *CommitMergeRequest) GetSource() bhmetapb.Shard {
+	if m != nil {
+		return m.Source
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return bhmetapb.Shard{}
+}
+
+func (m *CommitMergeRequest) GetCommit() uint64 {
+	if m != nil {
+		return m.Commit
 	}
-	return i, nil
+	return 0
 }
 
-func (m *RaftCMDResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CommitMergeRequest) GetEntries() []raftpb.Entry {
+	if m != nil {
+		return m.Entries
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *RaftCMDResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Header.Size()))
-		n6, err := m.Header.MarshalTo(dAtA[i:])
+type CommitMergeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitMergeResponse) Reset()         { *m = CommitMergeResponse{} }
+func (m *CommitMergeResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitMergeResponse) ProtoMessage()    {}
+func (*CommitMergeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{26}
+}
+func (m *CommitMergeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitMergeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitMergeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
-		}
-		i += n6
-	}
-	if len(m.Responses) > 0 {
-		for _, msg := range m.Responses {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.AdminResponse != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.AdminResponse.Size()))
-		n7, err := m.AdminResponse.MarshalTo(dAtA[i:])
+}
+func (m *CommitMergeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitMergeResponse.Merge(m, src)
+}
+func (m *CommitMergeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitMergeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitMergeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitMergeResponse proto.InternalMessageInfo
+
+// RollbackMergeRequest rollback the merge, the source shard will back to normal
+type RollbackMergeRequest struct {
+	Commit               uint64   `protobuf:"varint,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackMergeRequest) Reset()         { *m = RollbackMergeRequest{} }
+func (m *RollbackMergeRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackMergeRequest) ProtoMessage()    {}
+func (*RollbackMergeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{27}
+}
+func (m *RollbackMergeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackMergeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackMergeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n7
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *RollbackMergeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackMergeRequest.Merge(m, src)
+}
+func (m *RollbackMergeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackMergeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackMergeRequest.DiscardUnknown(m)
 }
 
-func (m *AdminRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RollbackMergeRequest proto.InternalMessageInfo
+
+func (m *RollbackMergeRequest) GetCommit() uint64 {
+	if m != nil {
+		return m.Commit
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *AdminRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.CmdType != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CmdType))
-	}
-	if m.ChangePeer != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n8, err := m.ChangePeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
-	}
-	if m.CompactLog != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactLog.Size()))
-		n9, err := m.CompactLog.MarshalTo(dAtA[i:])
+type RollbackMergeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackMergeResponse) Reset()         { *m = RollbackMergeResponse{} }
+func (m *RollbackMergeResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackMergeResponse) ProtoMessage()    {}
+func (*RollbackMergeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{28}
+}
+func (m *RollbackMergeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackMergeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackMergeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n9
+		return b[:n], nil
 	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n10, err := m.TransferLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
-	}
-	if m.VerifyHash != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.VerifyHash.Size()))
-		n11, err := m.VerifyHash.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
-	}
-	if m.Splits != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Splits.Size()))
-		n12, err := m.Splits.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
-	}
-	if m.ChangePeerV2 != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeerV2.Size()))
-		n13, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
+}
+func (m *RollbackMergeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackMergeResponse.Merge(m, src)
+}
+func (m *RollbackMergeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackMergeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackMergeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackMergeResponse proto.InternalMessageInfo
+
+// IngestRequest ingests the file built by the bulk load into the shard, the file is
+// dropped if the epoch of the shard is changed after the file built.
+type IngestRequest struct {
+	ID    uint64               `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Epoch metapb.ResourceEpoch `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch"`
+	// discard the file is removed from all the replicas without ingested
+	Discard              bool     `protobuf:"varint,3,opt,name=discard,proto3" json:"discard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IngestRequest) Reset()         { *m = IngestRequest{} }
+func (m *IngestRequest) String() string { return proto.CompactTextString(m) }
+func (*IngestRequest) ProtoMessage()    {}
+func (*IngestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{29}
+}
+func (m *IngestRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IngestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IngestRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n13
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *IngestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestRequest.Merge(m, src)
+}
+func (m *IngestRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *IngestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngestRequest proto.InternalMessageInfo
+
+func (m *IngestRequest) GetID() uint64 {
+	if m != nil {
+		return m.ID
 	}
-	return i, nil
+	return 0
 }
 
-func (m *AdminResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *IngestRequest) GetEpoch() metapb.ResourceEpoch {
+	if m != nil {
+		return m.Epoch
 	}
-	return dAtA[:n], nil
+	return metapb.ResourceEpoch{}
 }
 
-func (m *AdminResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.CmdType != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CmdType))
+func (m *IngestRequest) GetDiscard() bool {
+	if m != nil {
+		return m.Discard
 	}
-	if m.ChangePeer != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n14, err := m.ChangePeer.MarshalTo(dAtA[i:])
+	return false
+}
+
+type IngestResponse struct {
+	StaleEpoch           bool     `protobuf:"varint,1,opt,name=staleEpoch,proto3" json:"staleEpoch,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IngestResponse) Reset()         { *m = IngestResponse{} }
+func (m *IngestResponse) String() string { return proto.CompactTextString(m) }
+func (*IngestResponse) ProtoMessage()    {}
+func (*IngestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{30}
+}
+func (m *IngestResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IngestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IngestResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n14
+		return b[:n], nil
 	}
-	if m.CompactLog != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactLog.Size()))
-		n15, err := m.CompactLog.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n15
+}
+func (m *IngestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestResponse.Merge(m, src)
+}
+func (m *IngestResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *IngestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngestResponse proto.InternalMessageInfo
+
+func (m *IngestResponse) GetStaleEpoch() bool {
+	if m != nil {
+		return m.StaleEpoch
 	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n16, err := m.TransferLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n16
+	return false
+}
+
+func (m *IngestResponse) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	if m.VerifyHash != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.VerifyHash.Size()))
-		n17, err := m.VerifyHash.MarshalTo(dAtA[i:])
+	return ""
+}
+
+// BackupRequest backups the shard at the applied index of the request
+type BackupRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{31}
+}
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n17
+		return b[:n], nil
 	}
-	if m.Splits != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Splits.Size()))
-		n18, err := m.Splits.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n18
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+func (m *BackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	if m.ChangePeerV2 != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeerV2.Size()))
-		n19, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
+	return ""
+}
+
+type BackupResponse struct {
+	Backup               bhmetapb.ShardBackup `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup"`
+	Error                string               `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4d8ad5550754569, []int{32}
+}
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n19
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func (m *BackupResponse) GetBackup() bhmetapb.ShardBackup {
+	if m != nil {
+		return m.Backup
 	}
-	return i, nil
+	return bhmetapb.ShardBackup{}
 }
 
-func (m *Request) Marshal() (dAtA []byte, err error) {
+func (m *BackupResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("raftcmdpb.CMDType", CMDType_name, CMDType_value)
+	proto.RegisterEnum("raftcmdpb.AdminCmdType", AdminCmdType_name, AdminCmdType_value)
+	proto.RegisterType((*RaftRequestHeader)(nil), "raftcmdpb.RaftRequestHeader")
+	proto.RegisterType((*RaftResponseHeader)(nil), "raftcmdpb.RaftResponseHeader")
+	proto.RegisterType((*RaftCMDRequest)(nil), "raftcmdpb.RaftCMDRequest")
+	proto.RegisterType((*RaftCMDResponse)(nil), "raftcmdpb.RaftCMDResponse")
+	proto.RegisterType((*AdminRequest)(nil), "raftcmdpb.AdminRequest")
+	proto.RegisterType((*AdminResponse)(nil), "raftcmdpb.AdminResponse")
+	proto.RegisterType((*Request)(nil), "raftcmdpb.Request")
+	proto.RegisterType((*Response)(nil), "raftcmdpb.Response")
+	proto.RegisterType((*ChangePeerRequest)(nil), "raftcmdpb.ChangePeerRequest")
+	proto.RegisterType((*ChangePeerResponse)(nil), "raftcmdpb.ChangePeerResponse")
+	proto.RegisterType((*CompactLogRequest)(nil), "raftcmdpb.CompactLogRequest")
+	proto.RegisterType((*CompactLogResponse)(nil), "raftcmdpb.CompactLogResponse")
+	proto.RegisterType((*TransferLeaderRequest)(nil), "raftcmdpb.TransferLeaderRequest")
+	proto.RegisterType((*TransferLeaderResponse)(nil), "raftcmdpb.TransferLeaderResponse")
+	proto.RegisterType((*ComputeHashRequest)(nil), "raftcmdpb.ComputeHashRequest")
+	proto.RegisterType((*ComputeHashResponse)(nil), "raftcmdpb.ComputeHashResponse")
+	proto.RegisterType((*VerifyHashRequest)(nil), "raftcmdpb.VerifyHashRequest")
+	proto.RegisterType((*VerifyHashResponse)(nil), "raftcmdpb.VerifyHashResponse")
+	proto.RegisterType((*SplitRequest)(nil), "raftcmdpb.SplitRequest")
+	proto.RegisterType((*BatchSplitRequest)(nil), "raftcmdpb.BatchSplitRequest")
+	proto.RegisterType((*BatchSplitResponse)(nil), "raftcmdpb.BatchSplitResponse")
+	proto.RegisterType((*ChangePeerV2Request)(nil), "raftcmdpb.ChangePeerV2Request")
+	proto.RegisterType((*ChangePeerV2Response)(nil), "raftcmdpb.ChangePeerV2Response")
+	proto.RegisterType((*PrepareMergeRequest)(nil), "raftcmdpb.PrepareMergeRequest")
+	proto.RegisterType((*PrepareMergeResponse)(nil), "raftcmdpb.PrepareMergeResponse")
+	proto.RegisterType((*CommitMergeRequest)(nil), "raftcmdpb.CommitMergeRequest")
+	proto.RegisterType((*CommitMergeResponse)(nil), "raftcmdpb.CommitMergeResponse")
+	proto.RegisterType((*RollbackMergeRequest)(nil), "raftcmdpb.RollbackMergeRequest")
+	proto.RegisterType((*RollbackMergeResponse)(nil), "raftcmdpb.RollbackMergeResponse")
+	proto.RegisterType((*IngestRequest)(nil), "raftcmdpb.IngestRequest")
+	proto.RegisterType((*IngestResponse)(nil), "raftcmdpb.IngestResponse")
+	proto.RegisterType((*BackupRequest)(nil), "raftcmdpb.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "raftcmdpb.BackupResponse")
+}
+
+func init() { proto.RegisterFile("raftcmdpb.proto", fileDescriptor_c4d8ad5550754569) }
+
+var fileDescriptor_c4d8ad5550754569 = []byte{
+	// 1901 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x5b, 0x6f, 0xdb, 0xc8,
+	0x15, 0x0e, 0x45, 0x5d, 0xa8, 0xa3, 0x8b, 0xe9, 0x89, 0xe3, 0xe5, 0x06, 0x8d, 0xa3, 0xb2, 0x17,
+	0xb8, 0xe9, 0x46, 0x6e, 0x9c, 0x6d, 0x8b, 0x62, 0xd7, 0xdd, 0xb5, 0xe5, 0x04, 0x31, 0x9a, 0xa0,
+	0x01, 0x1d, 0x64, 0x51, 0xf4, 0xa5, 0x14, 0x39, 0x96, 0xd8, 0x48, 0x24, 0x3b, 0x1c, 0x79, 0xe3,
+	0x3e, 0xf5, 0xb9, 0xbf, 0xa6, 0x7f, 0xa0, 0xe8, 0x5b, 0xb1, 0x2f, 0x05, 0xf6, 0x17, 0xa4, 0xad,
+	0x81, 0xfe, 0x8f, 0x62, 0x6e, 0xe4, 0x50, 0xa4, 0x9c, 0x60, 0x5f, 0x24, 0x9e, 0x2b, 0xcf, 0x99,
+	0x73, 0xbe, 0x99, 0x33, 0x84, 0x2d, 0xe2, 0x5f, 0xd0, 0x60, 0x19, 0xa6, 0xd3, 0x71, 0x4a, 0x12,
+	0x9a, 0xa0, 0x6e, 0xce, 0xb8, 0x7b, 0x34, 0x8b, 0xe8, 0x7c, 0x35, 0x1d, 0x07, 0xc9, 0xf2, 0x60,
+	0xe9, 0x53, 0x12, 0xbd, 0x4d, 0x48, 0x34, 0x8b, 0x62, 0x49, 0x04, 0xab, 0x29, 0x3e, 0x48, 0xa7,
+	0x07, 0xd3, 0xf9, 0x12, 0x53, 0x5f, 0x7b, 0x10, 0x9e, 0xee, 0x7e, 0xf6, 0x61, 0xe6, 0x98, 0x90,
+	0x84, 0x14, 0xff, 0xd2, 0xf8, 0xf9, 0x07, 0x18, 0x07, 0xc9, 0x32, 0x4d, 0x62, 0x1c, 0xd3, 0xec,
+	0x20, 0x25, 0x49, 0x3a, 0xc7, 0x94, 0xf9, 0x93, 0xc1, 0x94, 0x42, 0x79, 0xa8, 0x79, 0x9b, 0x25,
+	0xb3, 0xe4, 0x80, 0xb3, 0xa7, 0xab, 0x0b, 0x4e, 0x71, 0x82, 0x3f, 0x49, 0xf5, 0x9f, 0xcc, 0x92,
+	0x31, 0xa6, 0x41, 0x38, 0x8e, 0x92, 0x03, 0xf6, 0x7f, 0xc0, 0xd6, 0xe4, 0xe0, 0xf2, 0x31, 0xff,
+	0x4f, 0xa7, 0xfc, 0x4f, 0xa8, 0xba, 0x7f, 0x69, 0xc0, 0xb6, 0xe7, 0x5f, 0x50, 0x0f, 0xff, 0x69,
+	0x85, 0x33, 0xfa, 0x0c, 0xfb, 0x21, 0x26, 0x68, 0x17, 0x1a, 0x51, 0xe8, 0x18, 0x23, 0x63, 0xbf,
+	0x7f, 0xd2, 0xbe, 0x7e, 0x77, 0xbf, 0x71, 0x76, 0xea, 0x35, 0xa2, 0x10, 0x39, 0xd0, 0xc9, 0xe6,
+	0x3e, 0x09, 0xcf, 0x4e, 0x9d, 0xc6, 0xc8, 0xd8, 0x6f, 0x7a, 0x8a, 0x44, 0x3f, 0x86, 0x66, 0x8a,
+	0x31, 0x71, 0xcc, 0x91, 0xb1, 0xdf, 0x3b, 0xec, 0x8f, 0x65, 0xf8, 0x2f, 0x31, 0x26, 0x27, 0xcd,
+	0x6f, 0xde, 0xdd, 0xbf, 0xe5, 0x71, 0x39, 0x7a, 0x04, 0x2d, 0x9c, 0x26, 0xc1, 0xdc, 0x69, 0x71,
+	0xc5, 0x3b, 0x4a, 0xd1, 0xc3, 0x59, 0xb2, 0x22, 0x01, 0x7e, 0xc2, 0x84, 0xd2, 0x42, 0x68, 0x22,
+	0x04, 0x4d, 0x8a, 0xc9, 0xd2, 0x69, 0xf3, 0x37, 0xf2, 0x67, 0xf4, 0x00, 0xec, 0x68, 0x16, 0x27,
+	0x44, 0xe8, 0x4f, 0xe6, 0x38, 0x78, 0xe3, 0x74, 0x46, 0xc6, 0xbe, 0xe5, 0x55, 0xf8, 0x68, 0x0f,
+	0x80, 0x2d, 0x6f, 0x92, 0xe1, 0xf0, 0x98, 0x3a, 0xd6, 0xc8, 0xd8, 0x37, 0x3d, 0x8d, 0xe3, 0xfe,
+	0x19, 0x90, 0x58, 0x81, 0x2c, 0x4d, 0xe2, 0x0c, 0xbf, 0x67, 0x09, 0x1e, 0x40, 0x8b, 0x57, 0x9a,
+	0x2f, 0x40, 0xef, 0x70, 0x38, 0x56, 0x75, 0x7f, 0xc2, 0xfe, 0xf3, 0xc8, 0x19, 0x81, 0x46, 0xd0,
+	0x0b, 0x56, 0x84, 0xe0, 0x98, 0xbe, 0x62, 0x09, 0x98, 0x3c, 0x01, 0x9d, 0xe5, 0xfe, 0xc3, 0x80,
+	0x21, 0x7b, 0xf9, 0xe4, 0xc5, 0xa9, 0xac, 0x00, 0xfa, 0x14, 0xda, 0x73, 0x1e, 0x02, 0x7f, 0x79,
+	0xef, 0xf0, 0x7b, 0xe3, 0xa2, 0xc5, 0x2b, 0x95, 0xf2, 0xa4, 0x2e, 0xfa, 0x14, 0x2c, 0x22, 0x04,
+	0x99, 0xd3, 0x18, 0x99, 0xfb, 0xbd, 0x43, 0xa4, 0xdb, 0x09, 0x11, 0x8f, 0xce, 0xf0, 0x72, 0x4d,
+	0x74, 0x0c, 0x7d, 0x3f, 0x5c, 0x46, 0xb1, 0x94, 0xcb, 0xea, 0x7d, 0xa4, 0x59, 0x1e, 0x6b, 0x62,
+	0x69, 0x5e, 0x32, 0x71, 0xff, 0x65, 0xc0, 0x56, 0x9e, 0x81, 0x58, 0x41, 0xf4, 0xd9, 0x5a, 0x0a,
+	0xf7, 0x2a, 0x29, 0xe8, 0x4b, 0x2d, 0xdd, 0xaa, 0x4c, 0x7e, 0x09, 0x5d, 0x22, 0xe5, 0x2a, 0x95,
+	0xdb, 0xa5, 0x54, 0x84, 0x4c, 0x5a, 0x15, 0xba, 0xe8, 0x14, 0x06, 0x32, 0x32, 0xc1, 0x91, 0xd9,
+	0x38, 0xd5, 0x6c, 0x4a, 0x1e, 0xca, 0x46, 0xee, 0xdf, 0xda, 0xd0, 0xd7, 0x93, 0x46, 0x8f, 0xa0,
+	0x13, 0x2c, 0xc3, 0x57, 0x57, 0x29, 0xe6, 0xd9, 0x0c, 0xab, 0xcb, 0x33, 0x11, 0x62, 0x4f, 0xe9,
+	0xa1, 0xcf, 0x01, 0x82, 0xb9, 0x1f, 0xcf, 0x30, 0x6b, 0x7f, 0xa7, 0x51, 0x29, 0xe3, 0x24, 0x17,
+	0xca, 0x97, 0x78, 0x9a, 0x3e, 0xb7, 0x4e, 0x96, 0xa9, 0x1f, 0xd0, 0xe7, 0xc9, 0xcc, 0x31, 0xab,
+	0xd6, 0xb9, 0xb0, 0xb0, 0xce, 0x59, 0xe8, 0x19, 0x0c, 0x29, 0xf1, 0xe3, 0xec, 0x02, 0x93, 0xe7,
+	0xa2, 0x06, 0x4d, 0xee, 0x61, 0xa4, 0x79, 0x78, 0x55, 0x52, 0x50, 0x5e, 0xd6, 0xec, 0x58, 0x1c,
+	0x97, 0x98, 0x44, 0x17, 0x57, 0xcf, 0xfc, 0x4c, 0xe1, 0x55, 0x8f, 0xe3, 0x75, 0x2e, 0xcc, 0xe3,
+	0x28, 0xf4, 0x59, 0x1b, 0x67, 0xe9, 0x22, 0xa2, 0x99, 0xd3, 0xae, 0x58, 0x9e, 0xf8, 0x34, 0x98,
+	0x9f, 0x33, 0xa9, 0xb2, 0x94, 0xba, 0xe8, 0x04, 0xfa, 0xc5, 0x4a, 0xbc, 0x3e, 0xe4, 0x98, 0xee,
+	0x1d, 0xee, 0xd5, 0xae, 0xdd, 0xeb, 0x43, 0x65, 0x5d, 0xb2, 0x61, 0x3e, 0x52, 0x82, 0x53, 0x9f,
+	0xe0, 0x17, 0x98, 0xcc, 0xb0, 0x63, 0x55, 0x7c, 0xbc, 0xd4, 0xc4, 0xb9, 0x0f, 0xdd, 0x06, 0x7d,
+	0x01, 0xbd, 0x20, 0x59, 0x2e, 0x23, 0x2a, 0x5c, 0x74, 0x2b, 0x6d, 0x3c, 0x29, 0xa4, 0xca, 0x83,
+	0x6e, 0x81, 0x9e, 0xc0, 0x80, 0x24, 0x8b, 0xc5, 0xd4, 0x0f, 0xde, 0x08, 0x17, 0xc0, 0x5d, 0xdc,
+	0xd7, 0x3b, 0x59, 0x97, 0x2b, 0x27, 0x65, 0x2b, 0x19, 0x47, 0xba, 0xa2, 0x98, 0x17, 0xa1, 0x57,
+	0x17, 0x87, 0x92, 0xea, 0x71, 0x28, 0x1e, 0xfa, 0x19, 0xb4, 0xa3, 0x78, 0xc6, 0xb0, 0xdd, 0xaf,
+	0xa0, 0xe1, 0x8c, 0x0b, 0xf2, 0x12, 0x08, 0x3d, 0x66, 0xc1, 0xde, 0xbf, 0x4a, 0x9d, 0x41, 0xc5,
+	0xe2, 0x84, 0x0b, 0x72, 0x0b, 0xa1, 0xe7, 0xfe, 0xbd, 0x0d, 0x83, 0x12, 0xb2, 0xbe, 0x0b, 0x66,
+	0x8e, 0x6a, 0x30, 0x73, 0x6f, 0x03, 0x66, 0xc4, 0x5b, 0x4a, 0xa0, 0x39, 0xaa, 0x01, 0xcd, 0xbd,
+	0x0d, 0xa0, 0xc9, 0xcd, 0x73, 0x1e, 0x3a, 0xdb, 0x80, 0x9a, 0xef, 0xdf, 0x80, 0x1a, 0xe9, 0x66,
+	0x1d, 0x36, 0x47, 0x35, 0xb0, 0xb9, 0xb7, 0x01, 0x36, 0x2a, 0x92, 0xc2, 0x00, 0xfd, 0x3c, 0xc7,
+	0x4d, 0xb5, 0xe9, 0x74, 0xdc, 0x48, 0x53, 0x05, 0x9c, 0xc9, 0x1a, 0x70, 0xaa, 0xed, 0x56, 0x06,
+	0x8e, 0x34, 0x2f, 0x23, 0x67, 0xb2, 0x86, 0x9c, 0x5e, 0xc5, 0x49, 0x19, 0x39, 0xca, 0x49, 0x09,
+	0x3a, 0x5f, 0x96, 0xa1, 0xd3, 0xaf, 0x22, 0x58, 0x87, 0x8e, 0x74, 0x51, 0xc2, 0xce, 0xd3, 0x75,
+	0xec, 0x0c, 0x2a, 0x3b, 0xd8, 0x1a, 0x76, 0xa4, 0x97, 0x35, 0xf0, 0x7c, 0x59, 0x06, 0xcf, 0xb0,
+	0x2e, 0x92, 0x02, 0x3c, 0x5a, 0x24, 0x8a, 0x89, 0x1e, 0xe5, 0xe8, 0xd9, 0xe2, 0xc6, 0x1f, 0xd7,
+	0xa0, 0x47, 0x15, 0x42, 0xc2, 0xe7, 0x51, 0x0e, 0x1f, 0xbb, 0x62, 0xa2, 0xe0, 0xa3, 0x4c, 0x24,
+	0x7e, 0xfe, 0x6d, 0x42, 0x47, 0x9d, 0x36, 0x9b, 0xc6, 0x8e, 0x1d, 0x68, 0xcd, 0x48, 0xb2, 0x4a,
+	0xe5, 0xdc, 0x25, 0x08, 0x36, 0x75, 0x51, 0x06, 0x32, 0x93, 0x83, 0x4c, 0x3f, 0xf1, 0x27, 0x2f,
+	0x4e, 0x39, 0xbe, 0xb8, 0x9c, 0x8d, 0x40, 0xc1, 0x2a, 0xa3, 0x78, 0xc9, 0x21, 0xd9, 0xe4, 0x2e,
+	0x34, 0x0e, 0xb2, 0xc1, 0x7c, 0x83, 0xaf, 0x78, 0xb3, 0xf6, 0x3d, 0xf6, 0xc8, 0x38, 0xc1, 0x32,
+	0xe4, 0x7b, 0x77, 0xdf, 0x63, 0x8f, 0xe8, 0x63, 0x30, 0xb3, 0x28, 0xe4, 0x3b, 0xb2, 0x79, 0xd2,
+	0xb9, 0x7e, 0x77, 0xdf, 0x3c, 0x3f, 0x3b, 0xf5, 0x18, 0x8f, 0x89, 0xd2, 0x28, 0x74, 0xac, 0x42,
+	0xf4, 0x92, 0x89, 0xd2, 0x28, 0x44, 0xbb, 0xd0, 0xce, 0x68, 0x92, 0x1e, 0x53, 0xde, 0xce, 0xa6,
+	0x27, 0x29, 0x36, 0x49, 0xd2, 0xe4, 0x9c, 0x0d, 0x8f, 0xbc, 0x55, 0x9b, 0x9e, 0x22, 0xd1, 0x0f,
+	0x61, 0xe0, 0x2f, 0x16, 0xc9, 0xd7, 0x4f, 0x13, 0xf6, 0x8b, 0x09, 0xef, 0x42, 0xcb, 0x2b, 0x33,
+	0x99, 0xd6, 0xc2, 0xcf, 0xe8, 0x09, 0x49, 0xfc, 0x30, 0xf0, 0xe5, 0xf6, 0x66, 0x79, 0x65, 0x66,
+	0xed, 0x98, 0x38, 0xd8, 0x30, 0x26, 0xee, 0x40, 0x8b, 0x26, 0x6f, 0x70, 0xcc, 0xfb, 0xa4, 0xeb,
+	0x09, 0x82, 0xc5, 0x4f, 0x71, 0xec, 0xc7, 0xa2, 0x03, 0x9a, 0x9e, 0xa4, 0xd0, 0x27, 0xb0, 0x7d,
+	0x21, 0x63, 0xf1, 0xb0, 0x1f, 0x9e, 0xc5, 0x21, 0x7e, 0xcb, 0x2b, 0x6e, 0x79, 0x55, 0x81, 0xfb,
+	0xcf, 0x06, 0x58, 0xf9, 0xe6, 0xb8, 0xa9, 0xc4, 0xaa, 0x98, 0x8d, 0xf7, 0x14, 0x73, 0x07, 0x5a,
+	0x97, 0xfe, 0x62, 0x25, 0xaa, 0xde, 0xf7, 0x04, 0x81, 0x7e, 0x0d, 0x03, 0x71, 0xc3, 0x50, 0xb3,
+	0x9c, 0xd8, 0xc0, 0x36, 0x4f, 0x81, 0x65, 0x75, 0x55, 0xde, 0xd6, 0xe6, 0xf2, 0xb6, 0x6b, 0xca,
+	0x9b, 0x4f, 0xc3, 0x9d, 0xf7, 0x4f, 0xc3, 0x9f, 0xc0, 0x76, 0x90, 0xc4, 0x34, 0x8a, 0x57, 0xb8,
+	0x28, 0x9b, 0x25, 0x96, 0xac, 0x22, 0x60, 0x59, 0x66, 0xd4, 0x5f, 0x88, 0xb3, 0xd7, 0xf2, 0x04,
+	0xe1, 0x66, 0xb0, 0x5d, 0x19, 0x9e, 0xd0, 0x2f, 0xd4, 0xd1, 0xa1, 0x1d, 0x38, 0xbb, 0xea, 0x62,
+	0x51, 0xa8, 0xf3, 0x25, 0xd4, 0x34, 0xf3, 0x3b, 0x4b, 0xe3, 0xe6, 0x3b, 0x8b, 0x7b, 0x0c, 0xa8,
+	0x7a, 0xfa, 0xa0, 0x9f, 0x42, 0x8b, 0x5f, 0x7e, 0xe4, 0x8c, 0xbb, 0x35, 0xce, 0xaf, 0x8f, 0xbc,
+	0x8f, 0x55, 0xee, 0x5c, 0xc7, 0xfd, 0x1d, 0x6c, 0x57, 0xc6, 0x36, 0xe4, 0x42, 0x5f, 0x1e, 0x41,
+	0xa2, 0x7d, 0x0c, 0xde, 0x61, 0x25, 0x1e, 0xbf, 0x42, 0x08, 0x9a, 0x5f, 0x21, 0x1a, 0xf2, 0x0a,
+	0x51, 0xb0, 0xdc, 0x1d, 0x40, 0xd5, 0xc3, 0xcd, 0xfd, 0x02, 0xee, 0xd4, 0x4e, 0x79, 0x79, 0xd2,
+	0xc6, 0x7b, 0x92, 0x76, 0x60, 0xb7, 0xfe, 0xc0, 0x53, 0x2f, 0x2c, 0x4f, 0x1d, 0xee, 0x1d, 0xb8,
+	0x5d, 0xb3, 0x9d, 0xba, 0x5f, 0xc1, 0x76, 0x65, 0x4e, 0x64, 0xb5, 0x8d, 0xb4, 0x8c, 0x05, 0xc1,
+	0xee, 0x79, 0x73, 0xb6, 0x4f, 0x37, 0x78, 0x5b, 0xf3, 0x67, 0xb6, 0x4d, 0xb0, 0xd6, 0xc0, 0x6f,
+	0xa9, 0xec, 0x76, 0x45, 0xb2, 0x28, 0xaa, 0x27, 0xa9, 0xfb, 0x47, 0xe8, 0xeb, 0x73, 0x25, 0xba,
+	0x0b, 0x16, 0x3f, 0x20, 0x7f, 0x83, 0xaf, 0x04, 0xe2, 0xbc, 0x9c, 0x66, 0x9b, 0x62, 0x8c, 0xbf,
+	0x3e, 0x2f, 0xdd, 0x67, 0x35, 0x8e, 0x94, 0xb3, 0x85, 0x39, 0x3b, 0xcd, 0x1c, 0x73, 0x64, 0x4a,
+	0xb9, 0xe4, 0xb8, 0x29, 0x6c, 0x57, 0x06, 0x59, 0xf4, 0x2b, 0xed, 0x1e, 0x66, 0xf0, 0xcb, 0x8b,
+	0x3e, 0xfa, 0xe8, 0xaa, 0x72, 0xb5, 0x73, 0x75, 0x56, 0x6a, 0x12, 0xcd, 0xe6, 0xf4, 0x14, 0x93,
+	0xe8, 0x52, 0x6c, 0x03, 0x96, 0xa7, 0xb3, 0xdc, 0x09, 0xa0, 0xea, 0x08, 0x80, 0x1e, 0x42, 0x9b,
+	0x37, 0x99, 0x7a, 0xe1, 0x86, 0x4e, 0x94, 0x4a, 0xee, 0x39, 0xdc, 0xae, 0x99, 0xa1, 0xd1, 0xe7,
+	0xd0, 0x11, 0xd0, 0x50, 0x6e, 0x6e, 0xbc, 0xb0, 0x48, 0x9f, 0xca, 0xc4, 0x3d, 0x82, 0x9d, 0xba,
+	0xf9, 0x02, 0xfd, 0xe8, 0x66, 0x90, 0x28, 0x78, 0xfc, 0x01, 0x6e, 0xd7, 0xcc, 0xe4, 0xac, 0x7a,
+	0xcb, 0x28, 0xd6, 0xc1, 0x91, 0xd3, 0x2c, 0x6b, 0xea, 0x93, 0x19, 0xa6, 0x4e, 0xa3, 0xd6, 0xb5,
+	0xca, 0x5a, 0x28, 0xb9, 0xbb, 0xb0, 0x53, 0x37, 0xbb, 0xb8, 0x7f, 0x35, 0x78, 0x37, 0xaf, 0xcd,
+	0xf2, 0x7c, 0x4d, 0xf9, 0xf7, 0x88, 0x9b, 0xd1, 0x2d, 0x95, 0xd8, 0x29, 0x21, 0x06, 0x18, 0xd9,
+	0x46, 0x92, 0x42, 0x0f, 0xa1, 0x83, 0x63, 0x4a, 0x22, 0x2c, 0xfa, 0xa7, 0x77, 0x38, 0x18, 0x8b,
+	0x4f, 0x30, 0xe3, 0x27, 0x31, 0x25, 0x57, 0x6a, 0x15, 0xa5, 0x8e, 0xc4, 0xd0, 0xfa, 0x70, 0xe4,
+	0x8e, 0x61, 0xa7, 0xee, 0xae, 0xa0, 0xbd, 0xd5, 0xd0, 0xdf, 0xea, 0x7e, 0x04, 0x77, 0x6a, 0xe7,
+	0x23, 0x97, 0xc2, 0xa0, 0x34, 0xf3, 0x6b, 0x47, 0x51, 0xb3, 0x74, 0x14, 0xe5, 0x5f, 0x69, 0x1a,
+	0x1f, 0xfc, 0x95, 0xc6, 0x81, 0x4e, 0x18, 0x65, 0x01, 0xab, 0xb5, 0xc9, 0x3b, 0x57, 0x91, 0xee,
+	0x53, 0x18, 0x96, 0x67, 0x25, 0x86, 0x2c, 0xbe, 0x9d, 0x73, 0x37, 0xfc, 0xf5, 0x96, 0xa7, 0x71,
+	0xd8, 0xfe, 0x50, 0x7c, 0x63, 0xe9, 0xca, 0xf3, 0xc3, 0xfd, 0x01, 0x0c, 0x4a, 0xf7, 0x0f, 0xb6,
+	0x61, 0xa4, 0x3e, 0x15, 0x0e, 0xba, 0x1e, 0x7f, 0x76, 0x7f, 0x0f, 0xc3, 0xf2, 0x94, 0x85, 0x1e,
+	0xe7, 0x03, 0x99, 0x21, 0x93, 0x59, 0x2b, 0x25, 0x17, 0xaa, 0x82, 0x0a, 0xd5, 0xfa, 0x08, 0x1e,
+	0xfc, 0x16, 0x3a, 0xf2, 0x28, 0x46, 0x3d, 0xe8, 0x9c, 0xc5, 0x97, 0xfe, 0x22, 0x0a, 0xed, 0x5b,
+	0x68, 0x00, 0x5d, 0xf6, 0x59, 0x83, 0x9f, 0x79, 0xb6, 0x81, 0x2c, 0x68, 0x9e, 0xc7, 0x7e, 0x6a,
+	0x37, 0x50, 0x17, 0x5a, 0x5f, 0x91, 0x88, 0x62, 0xdb, 0x64, 0x4c, 0x36, 0x0f, 0xd8, 0x4d, 0xc6,
+	0xe4, 0x57, 0x20, 0xbb, 0xf5, 0xe0, 0x7f, 0x06, 0xf4, 0xf5, 0xeb, 0x10, 0xb2, 0xa1, 0x2f, 0xdd,
+	0x0a, 0x95, 0x5b, 0x68, 0x08, 0x50, 0x20, 0xcb, 0x36, 0x38, 0x9d, 0x6f, 0xf7, 0x76, 0x03, 0x21,
+	0x18, 0x96, 0xf7, 0x69, 0xdb, 0x44, 0x5b, 0xd0, 0xd3, 0xf6, 0x62, 0xbb, 0xc9, 0x8c, 0x8a, 0xcd,
+	0xd2, 0x6e, 0x31, 0xba, 0xd8, 0x48, 0xec, 0x36, 0x7b, 0xad, 0x0e, 0x5f, 0xbb, 0xc3, 0x38, 0x3a,
+	0x5e, 0x6c, 0x4b, 0x3a, 0x55, 0xcd, 0x69, 0x77, 0xd1, 0x36, 0x0c, 0x4a, 0x6d, 0x66, 0x03, 0x02,
+	0x68, 0x8b, 0x52, 0xdb, 0x3d, 0xf6, 0x2c, 0x96, 0xd6, 0xee, 0x9f, 0xd8, 0xdf, 0xfe, 0x77, 0xcf,
+	0xf8, 0xe6, 0x7a, 0xcf, 0xf8, 0xf6, 0x7a, 0xcf, 0xf8, 0xcf, 0xf5, 0x9e, 0x31, 0x6d, 0xf3, 0xcf,
+	0x8f, 0x8f, 0xff, 0x3f, 0x00, 0x34, 0xa9, 0xe0, 0xe4, 0xc0, 0x15, 0x00, 0x00,
+}
+
+func (m *RaftRequestHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1997,7 +2402,7 @@ func (m *Request) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+func (m *RaftRequestHeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -2008,82 +2413,46 @@ func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.ID)))
 		i += copy(dAtA[i:], m.ID)
 	}
-	if m.Group != 0 {
+	if m.ShardID != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Group))
-	}
-	if m.Type != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Type))
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ShardID))
 	}
-	if m.CustemType != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CustemType))
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Peer.Size()))
+	n1, err := m.Peer.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.Key) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+	i += n1
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Epoch.Size()))
+	n2, err := m.Epoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.Cmd) > 0 {
-		dAtA[i] = 0x32
+	i += n2
+	if m.Term != 0 {
+		dAtA[i] = 0x30
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Cmd)))
-		i += copy(dAtA[i:], m.Cmd)
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Term))
 	}
-	if m.SID != 0 {
+	if m.IgnoreEpochCheck {
 		dAtA[i] = 0x38
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.SID))
-	}
-	if m.PID != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.PID))
-	}
-	if m.StopAt != 0 {
-		dAtA[i] = 0x48
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.StopAt))
-	}
-	if m.ToShard != 0 {
-		dAtA[i] = 0x50
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ToShard))
-	}
-	if m.AllowFollower {
-		dAtA[i] = 0x58
-		i++
-		if m.AllowFollower {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.LastBroadcast {
-		dAtA[i] = 0x60
-		i++
-		if m.LastBroadcast {
+		if m.IgnoreEpochCheck {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
-	if m.IgnoreEpochCheck {
-		dAtA[i] = 0x68
-		i++
-		if m.IgnoreEpochCheck {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+	if m.ProposedAt != 0 {
+		dAtA[i] = 0x40
 		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ProposedAt))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2091,7 +2460,7 @@ func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *RaftResponseHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2101,7 +2470,7 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *RaftResponseHeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -2112,64 +2481,18 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.ID)))
 		i += copy(dAtA[i:], m.ID)
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Type))
-	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
-	}
-	if m.OriginRequest != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.OriginRequest.Size()))
-		n20, err := m.OriginRequest.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n20
-	}
-	if m.SID != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.SID))
-	}
-	if m.PID != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.PID))
-	}
-	dAtA[i] = 0x3a
+	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Error.Size()))
-	n21, err := m.Error.MarshalTo(dAtA[i:])
+	n3, err := m.Error.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
-	if m.ContinueBroadcast {
-		dAtA[i] = 0x40
-		i++
-		if m.ContinueBroadcast {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.Stale {
-		dAtA[i] = 0x48
-		i++
-		if m.Stale {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+	i += n3
+	if m.CurrentTerm != 0 {
+		dAtA[i] = 0x18
 		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CurrentTerm))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2177,7 +2500,7 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ChangePeerRequest) Marshal() (dAtA []byte, err error) {
+func (m *RaftCMDRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2187,31 +2510,50 @@ func (m *ChangePeerRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChangePeerRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RaftCMDRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ChangeType != 0 {
-		dAtA[i] = 0x8
+	if m.Header != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangeType))
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Header.Size()))
+		n4, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Peer.Size()))
-	n22, err := m.Peer.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.AdminRequest != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.AdminRequest.Size()))
+		n5, err := m.AdminRequest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
 	}
-	i += n22
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ChangePeerResponse) Marshal() (dAtA []byte, err error) {
+func (m *RaftCMDResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2221,26 +2563,50 @@ func (m *ChangePeerResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChangePeerResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *RaftCMDResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Shard.Size()))
-	n23, err := m.Shard.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Header.Size()))
+		n6, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if len(m.Responses) > 0 {
+		for _, msg := range m.Responses {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.AdminResponse != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.AdminResponse.Size()))
+		n7, err := m.AdminResponse.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	i += n23
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CompactLogRequest) Marshal() (dAtA []byte, err error) {
+func (m *AdminRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2250,99 +2616,143 @@ func (m *CompactLogRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CompactLogRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *AdminRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.CompactIndex != 0 {
+	if m.CmdType != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactIndex))
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CmdType))
 	}
-	if m.CompactTerm != 0 {
-		dAtA[i] = 0x10
+	if m.ChangePeer != nil {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactTerm))
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeer.Size()))
+		n8, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.CompactLog != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactLog.Size()))
+		n9, err := m.CompactLog.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
-	return i, nil
-}
-
-func (m *CompactLogResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n10, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CompactLogResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.VerifyHash != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.VerifyHash.Size()))
+		n11, err := m.VerifyHash.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
 	}
-	return i, nil
-}
-
-func (m *TransferLeaderRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.Splits != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Splits.Size()))
+		n12, err := m.Splits.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
 	}
-	return dAtA[:n], nil
-}
-
-func (m *TransferLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Peer.Size()))
-	n24, err := m.Peer.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.ChangePeerV2 != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeerV2.Size()))
+		n13, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
 	}
-	i += n24
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.PrepareMerge != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.PrepareMerge.Size()))
+		n14, err := m.PrepareMerge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
 	}
-	return i, nil
-}
-
-func (m *TransferLeaderResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.CommitMerge != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CommitMerge.Size()))
+		n15, err := m.CommitMerge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.RollbackMerge != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.RollbackMerge.Size()))
+		n16, err := m.RollbackMerge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.ComputeHash != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ComputeHash.Size()))
+		n17, err := m.ComputeHash.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.Ingest != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Ingest.Size()))
+		n18, err := m.Ingest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.Backup != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Backup.Size()))
+		n19, err := m.Backup.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
 	}
-	return dAtA[:n], nil
-}
-
-func (m *TransferLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *VerifyHashRequest) Marshal() (dAtA []byte, err error) {
+func (m *AdminResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2352,56 +2762,145 @@ func (m *VerifyHashRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *VerifyHashRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *AdminResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Index != 0 {
+	if m.CmdType != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Index))
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CmdType))
 	}
-	if len(m.Hash) > 0 {
+	if m.ChangePeer != nil {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeer.Size()))
+		n20, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
 	}
-	if len(m.Context) > 0 {
+	if m.CompactLog != nil {
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Context)))
-		i += copy(dAtA[i:], m.Context)
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactLog.Size()))
+		n21, err := m.CompactLog.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n22, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
 	}
-	return i, nil
-}
-
-func (m *VerifyHashResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.VerifyHash != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.VerifyHash.Size()))
+		n23, err := m.VerifyHash.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.Splits != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Splits.Size()))
+		n24, err := m.Splits.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.ChangePeerV2 != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangePeerV2.Size()))
+		n25, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	if m.PrepareMerge != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.PrepareMerge.Size()))
+		n26, err := m.PrepareMerge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	if m.CommitMerge != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CommitMerge.Size()))
+		n27, err := m.CommitMerge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	if m.RollbackMerge != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.RollbackMerge.Size()))
+		n28, err := m.RollbackMerge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	if m.ComputeHash != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ComputeHash.Size()))
+		n29, err := m.ComputeHash.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
+	}
+	if m.Ingest != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Ingest.Size()))
+		n30, err := m.Ingest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	if m.Backup != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Backup.Size()))
+		n31, err := m.Backup.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n31
 	}
-	return dAtA[:n], nil
-}
-
-func (m *VerifyHashResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *SplitRequest) Marshal() (dAtA []byte, err error) {
+func (m *Request) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2411,38 +2910,116 @@ func (m *SplitRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SplitRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.SplitKey) > 0 {
+	if len(m.ID) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.SplitKey)))
-		i += copy(dAtA[i:], m.SplitKey)
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
-	if m.NewShardID != 0 {
+	if m.Group != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.NewShardID))
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Group))
 	}
-	if len(m.NewPeerIDs) > 0 {
-		dAtA26 := make([]byte, len(m.NewPeerIDs)*10)
-		var j25 int
-		for _, num := range m.NewPeerIDs {
-			for num >= 1<<7 {
-				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j25++
-			}
-			dAtA26[j25] = uint8(num)
-			j25++
-		}
-		dAtA[i] = 0x1a
+	if m.Type != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Type))
+	}
+	if m.CustemType != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CustemType))
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Cmd) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Cmd)))
+		i += copy(dAtA[i:], m.Cmd)
+	}
+	if m.SID != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.SID))
+	}
+	if m.PID != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.PID))
+	}
+	if m.StopAt != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.StopAt))
+	}
+	if m.ToShard != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ToShard))
+	}
+	if m.AllowFollower {
+		dAtA[i] = 0x58
+		i++
+		if m.AllowFollower {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.LastBroadcast {
+		dAtA[i] = 0x60
+		i++
+		if m.LastBroadcast {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.IgnoreEpochCheck {
+		dAtA[i] = 0x68
+		i++
+		if m.IgnoreEpochCheck {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Token) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	if m.Tenant != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Tenant))
+	}
+	if m.FollowerReadIndex {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.FollowerReadIndex {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(j25))
-		i += copy(dAtA[i:], dAtA26[:j25])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2450,7 +3027,7 @@ func (m *SplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *BatchSplitRequest) Marshal() (dAtA []byte, err error) {
+func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2460,27 +3037,70 @@ func (m *BatchSplitRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Requests) > 0 {
-		for _, msg := range m.Requests {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
-	if m.RightDerive {
+	if m.Type != 0 {
 		dAtA[i] = 0x10
 		i++
-		if m.RightDerive {
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.OriginRequest != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.OriginRequest.Size()))
+		n32, err := m.OriginRequest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n32
+	}
+	if m.SID != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.SID))
+	}
+	if m.PID != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.PID))
+	}
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Error.Size()))
+	n33, err := m.Error.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n33
+	if m.ContinueBroadcast {
+		dAtA[i] = 0x40
+		i++
+		if m.ContinueBroadcast {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Stale {
+		dAtA[i] = 0x48
+		i++
+		if m.Stale {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
@@ -2493,7 +3113,7 @@ func (m *BatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *BatchSplitResponse) Marshal() (dAtA []byte, err error) {
+func (m *ChangePeerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2503,30 +3123,31 @@ func (m *BatchSplitResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChangePeerRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Shards) > 0 {
-		for _, msg := range m.Shards {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ChangeType))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Peer.Size()))
+	n34, err := m.Peer.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n34
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ChangePeerV2Request) Marshal() (dAtA []byte, err error) {
+func (m *ChangePeerResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2536,30 +3157,26 @@ func (m *ChangePeerV2Request) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChangePeerV2Request) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChangePeerResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Changes) > 0 {
-		for _, msg := range m.Changes {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Shard.Size()))
+	n35, err := m.Shard.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n35
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ChangePeerV2Response) Marshal() (dAtA []byte, err error) {
+func (m *CompactLogRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2569,20 +3186,20 @@ func (m *ChangePeerV2Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChangePeerV2Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *CompactLogRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Shard != nil {
-		dAtA[i] = 0xa
+	if m.CompactIndex != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Shard.Size()))
-		n27, err := m.Shard.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n27
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactIndex))
+	}
+	if m.CompactTerm != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.CompactTerm))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2590,488 +3207,713 @@ func (m *ChangePeerV2Response) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintRaftcmdpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CompactLogResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *RaftRequestHeader) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *CompactLogResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.ShardID != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.ShardID))
-	}
-	l = m.Peer.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
-	l = m.Epoch.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
-	if m.Term != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.Term))
-	}
-	if m.IgnoreEpochCheck {
-		n += 2
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftResponseHeader) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TransferLeaderRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	l = m.Error.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
-	if m.CurrentTerm != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.CurrentTerm))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Peer.Size()))
+	n36, err := m.Peer.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n36
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftCMDRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TransferLeaderResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovRaftcmdpb(uint64(l))
-		}
-	}
-	if m.AdminRequest != nil {
-		l = m.AdminRequest.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftCMDResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ComputeHashRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ComputeHashRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if len(m.Responses) > 0 {
-		for _, e := range m.Responses {
-			l = e.Size()
-			n += 1 + l + sovRaftcmdpb(uint64(l))
-		}
-	}
-	if m.AdminResponse != nil {
-		l = m.AdminResponse.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AdminRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ComputeHashResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ComputeHashResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.CmdType != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.CmdType))
-	}
-	if m.ChangePeer != nil {
-		l = m.ChangePeer.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.CompactLog != nil {
-		l = m.CompactLog.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.TransferLeader != nil {
-		l = m.TransferLeader.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.VerifyHash != nil {
-		l = m.VerifyHash.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.Splits != nil {
-		l = m.Splits.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.ChangePeerV2 != nil {
-		l = m.ChangePeerV2.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AdminResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *VerifyHashRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyHashRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.CmdType != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.CmdType))
-	}
-	if m.ChangePeer != nil {
-		l = m.ChangePeer.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.CompactLog != nil {
-		l = m.CompactLog.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Index))
 	}
-	if m.TransferLeader != nil {
-		l = m.TransferLeader.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
 	}
-	if m.VerifyHash != nil {
-		l = m.VerifyHash.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	if len(m.Context) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Context)))
+		i += copy(dAtA[i:], m.Context)
 	}
-	if m.Splits != nil {
-		l = m.Splits.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ChangePeerV2 != nil {
-		l = m.ChangePeerV2.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	return i, nil
+}
+
+func (m *VerifyHashResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyHashResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	if len(m.SplitKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.SplitKey)))
+		i += copy(dAtA[i:], m.SplitKey)
 	}
-	if m.Group != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.Group))
+	if m.NewShardID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.NewShardID))
 	}
-	if m.Type != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.Type))
+	if len(m.NewPeerIDs) > 0 {
+		dAtA38 := make([]byte, len(m.NewPeerIDs)*10)
+		var j37 int
+		for _, num := range m.NewPeerIDs {
+			for num >= 1<<7 {
+				dAtA38[j37] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j37++
+			}
+			dAtA38[j37] = uint8(num)
+			j37++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(j37))
+		i += copy(dAtA[i:], dAtA38[:j37])
 	}
-	if m.CustemType != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.CustemType))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	l = len(m.Cmd)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.SID != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.SID))
-	}
-	if m.PID != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.PID))
-	}
-	if m.StopAt != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.StopAt))
-	}
-	if m.ToShard != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.ToShard))
-	}
-	if m.AllowFollower {
-		n += 2
-	}
-	if m.LastBroadcast {
-		n += 2
-	}
-	if m.IgnoreEpochCheck {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return i, nil
 }
 
-func (m *Response) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BatchSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.Type != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.Type))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.OriginRequest != nil {
-		l = m.OriginRequest.Size()
-		n += 1 + l + sovRaftcmdpb(uint64(l))
-	}
-	if m.SID != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.SID))
-	}
-	if m.PID != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.PID))
-	}
-	l = m.Error.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
-	if m.ContinueBroadcast {
-		n += 2
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.Stale {
-		n += 2
+	if m.RightDerive {
+		dAtA[i] = 0x10
+		i++
+		if m.RightDerive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ChangePeerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BatchSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ChangeType != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.ChangeType))
+	if len(m.Shards) > 0 {
+		for _, msg := range m.Shards {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	l = m.Peer.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ChangePeerResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ChangePeerV2Request) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ChangePeerV2Request) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Shard.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CompactLogRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ChangePeerV2Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ChangePeerV2Response) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.CompactIndex != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.CompactIndex))
-	}
-	if m.CompactTerm != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.CompactTerm))
+	if m.Shard != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Shard.Size()))
+		n39, err := m.Shard.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CompactLogResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PrepareMergeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PrepareMergeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if m.MinIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.MinIndex))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Target.Size()))
+	n40, err := m.Target.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n40
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *TransferLeaderRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PrepareMergeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PrepareMergeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Peer.Size()
-	n += 1 + l + sovRaftcmdpb(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *TransferLeaderResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CommitMergeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitMergeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Source.Size()))
+	n41, err := m.Source.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return n
+	i += n41
+	if m.Commit != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Commit))
+	}
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintRaftcmdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
 }
 
-func (m *VerifyHashRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CommitMergeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitMergeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Index != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.Index))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	return i, nil
+}
+
+func (m *RollbackMergeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Context)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *RollbackMergeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Commit != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Commit))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *VerifyHashResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RollbackMergeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackMergeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *SplitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *IngestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.SplitKey)
-	if l > 0 {
-		n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.ID))
 	}
-	if m.NewShardID != 0 {
-		n += 1 + sovRaftcmdpb(uint64(m.NewShardID))
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Epoch.Size()))
+	n42, err := m.Epoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.NewPeerIDs) > 0 {
-		l = 0
-		for _, e := range m.NewPeerIDs {
-			l += sovRaftcmdpb(uint64(e))
+	i += n42
+	if m.Discard {
+		dAtA[i] = 0x18
+		i++
+		if m.Discard {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		n += 1 + sovRaftcmdpb(uint64(l)) + l
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *BatchSplitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *IngestResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.StaleEpoch {
+		dAtA[i] = 0x8
+		i++
+		if m.StaleEpoch {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
 	}
-	if m.RightDerive {
-		n += 2
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *BatchSplitResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BackupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Shards) > 0 {
-		for _, e := range m.Shards {
-			l = e.Size()
-			n += 1 + l + sovRaftcmdpb(uint64(l))
-		}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ChangePeerV2Request) Size() (n int) {
+func (m *BackupResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRaftcmdpb(dAtA, i, uint64(m.Backup.Size()))
+	n43, err := m.Backup.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n43
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftcmdpb(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintRaftcmdpb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *RaftRequestHeader) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Changes) > 0 {
-		for _, e := range m.Changes {
-			l = e.Size()
-			n += 1 + l + sovRaftcmdpb(uint64(l))
-		}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.ShardID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.ShardID))
+	}
+	l = m.Peer.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	l = m.Epoch.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.Term != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Term))
+	}
+	if m.IgnoreEpochCheck {
+		n += 2
+	}
+	if m.ProposedAt != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.ProposedAt))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -3079,36 +3921,1929 @@ func (m *ChangePeerV2Request) Size() (n int) {
 	return n
 }
 
-func (m *ChangePeerV2Response) Size() (n int) {
+func (m *RaftResponseHeader) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Shard != nil {
-		l = m.Shard.Size()
+	l = len(m.ID)
+	if l > 0 {
 		n += 1 + l + sovRaftcmdpb(uint64(l))
 	}
+	l = m.Error.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.CurrentTerm != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.CurrentTerm))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func sovRaftcmdpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *RaftCMDRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovRaftcmdpb(uint64(l))
+		}
+	}
+	if m.AdminRequest != nil {
+		l = m.AdminRequest.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftCMDResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovRaftcmdpb(uint64(l))
+		}
+	}
+	if m.AdminResponse != nil {
+		l = m.AdminResponse.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AdminRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CmdType != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.CmdType))
+	}
+	if m.ChangePeer != nil {
+		l = m.ChangePeer.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.CompactLog != nil {
+		l = m.CompactLog.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.TransferLeader != nil {
+		l = m.TransferLeader.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.VerifyHash != nil {
+		l = m.VerifyHash.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Splits != nil {
+		l = m.Splits.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.ChangePeerV2 != nil {
+		l = m.ChangePeerV2.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.PrepareMerge != nil {
+		l = m.PrepareMerge.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.CommitMerge != nil {
+		l = m.CommitMerge.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.RollbackMerge != nil {
+		l = m.RollbackMerge.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.ComputeHash != nil {
+		l = m.ComputeHash.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Ingest != nil {
+		l = m.Ingest.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Backup != nil {
+		l = m.Backup.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AdminResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CmdType != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.CmdType))
+	}
+	if m.ChangePeer != nil {
+		l = m.ChangePeer.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.CompactLog != nil {
+		l = m.CompactLog.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.TransferLeader != nil {
+		l = m.TransferLeader.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.VerifyHash != nil {
+		l = m.VerifyHash.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Splits != nil {
+		l = m.Splits.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.ChangePeerV2 != nil {
+		l = m.ChangePeerV2.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.PrepareMerge != nil {
+		l = m.PrepareMerge.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.CommitMerge != nil {
+		l = m.CommitMerge.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.RollbackMerge != nil {
+		l = m.RollbackMerge.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.ComputeHash != nil {
+		l = m.ComputeHash.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Ingest != nil {
+		l = m.Ingest.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Backup != nil {
+		l = m.Backup.Size()
+		n += 2 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Group != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Group))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Type))
+	}
+	if m.CustemType != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.CustemType))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	l = len(m.Cmd)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.SID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.SID))
+	}
+	if m.PID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.PID))
+	}
+	if m.StopAt != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.StopAt))
+	}
+	if m.ToShard != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.ToShard))
+	}
+	if m.AllowFollower {
+		n += 2
+	}
+	if m.LastBroadcast {
+		n += 2
+	}
+	if m.IgnoreEpochCheck {
+		n += 2
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Tenant != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Tenant))
+	}
+	if m.FollowerReadIndex {
+		n += 3
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Type))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.OriginRequest != nil {
+		l = m.OriginRequest.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.SID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.SID))
+	}
+	if m.PID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.PID))
+	}
+	l = m.Error.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.ContinueBroadcast {
+		n += 2
+	}
+	if m.Stale {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChangePeerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangeType != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.ChangeType))
+	}
+	l = m.Peer.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChangePeerResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Shard.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CompactLogRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactIndex != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.CompactIndex))
+	}
+	if m.CompactTerm != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.CompactTerm))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CompactLogResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferLeaderRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Peer.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferLeaderResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ComputeHashRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ComputeHashResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerifyHashRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Index))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	l = len(m.Context)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerifyHashResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SplitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SplitKey)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.NewShardID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.NewShardID))
+	}
+	if len(m.NewPeerIDs) > 0 {
+		l = 0
+		for _, e := range m.NewPeerIDs {
+			l += sovRaftcmdpb(uint64(e))
+		}
+		n += 1 + sovRaftcmdpb(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchSplitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovRaftcmdpb(uint64(l))
+		}
+	}
+	if m.RightDerive {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchSplitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
+			l = e.Size()
+			n += 1 + l + sovRaftcmdpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChangePeerV2Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovRaftcmdpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChangePeerV2Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Shard != nil {
+		l = m.Shard.Size()
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PrepareMergeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinIndex != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.MinIndex))
+	}
+	l = m.Target.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PrepareMergeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitMergeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Source.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.Commit != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Commit))
+	}
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovRaftcmdpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitMergeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackMergeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.Commit))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackMergeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IngestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRaftcmdpb(uint64(m.ID))
+	}
+	l = m.Epoch.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	if m.Discard {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IngestResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StaleEpoch {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BackupRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BackupResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Backup.Size()
+	n += 1 + l + sovRaftcmdpb(uint64(l))
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovRaftcmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovRaftcmdpb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRaftcmdpb(x uint64) (n int) {
+	return sovRaftcmdpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftRequestHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftRequestHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreEpochCheck", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IgnoreEpochCheck = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposedAt", wireType)
+			}
+			m.ProposedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftResponseHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftResponseHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftResponseHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentTerm", wireType)
+			}
+			m.CurrentTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CurrentTerm |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftCMDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftCMDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftCMDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RaftRequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &Request{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdminRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AdminRequest == nil {
+				m.AdminRequest = &AdminRequest{}
+			}
+			if err := m.AdminRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftCMDResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftCMDResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftCMDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RaftResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &Response{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdminResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AdminResponse == nil {
+				m.AdminResponse = &AdminResponse{}
+			}
+			if err := m.AdminResponse.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AdminRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdminRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdminRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CmdType", wireType)
+			}
+			m.CmdType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CmdType |= AdminCmdType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangePeer == nil {
+				m.ChangePeer = &ChangePeerRequest{}
+			}
+			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CompactLog == nil {
+				m.CompactLog = &CompactLogRequest{}
+			}
+			if err := m.CompactLog.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeaderRequest{}
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyHash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.VerifyHash == nil {
+				m.VerifyHash = &VerifyHashRequest{}
+			}
+			if err := m.VerifyHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Splits == nil {
+				m.Splits = &BatchSplitRequest{}
+			}
+			if err := m.Splits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangePeerV2 == nil {
+				m.ChangePeerV2 = &ChangePeerV2Request{}
+			}
+			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareMerge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PrepareMerge == nil {
+				m.PrepareMerge = &PrepareMergeRequest{}
+			}
+			if err := m.PrepareMerge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitMerge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CommitMerge == nil {
+				m.CommitMerge = &CommitMergeRequest{}
+			}
+			if err := m.CommitMerge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollbackMerge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RollbackMerge == nil {
+				m.RollbackMerge = &RollbackMergeRequest{}
+			}
+			if err := m.RollbackMerge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ComputeHash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ComputeHash == nil {
+				m.ComputeHash = &ComputeHashRequest{}
+			}
+			if err := m.ComputeHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingest == nil {
+				m.Ingest = &IngestRequest{}
+			}
+			if err := m.Ingest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Backup == nil {
+				m.Backup = &BackupRequest{}
+			}
+			if err := m.Backup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
-func sozRaftcmdpb(x uint64) (n int) {
-	return sovRaftcmdpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
+func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3118,30 +5853,229 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowRaftcmdpb
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdminResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdminResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CmdType", wireType)
+			}
+			m.CmdType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CmdType |= AdminCmdType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangePeer == nil {
+				m.ChangePeer = &ChangePeerResponse{}
+			}
+			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CompactLog == nil {
+				m.CompactLog = &CompactLogResponse{}
+			}
+			if err := m.CompactLog.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeaderResponse{}
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyHash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.VerifyHash == nil {
+				m.VerifyHash = &VerifyHashResponse{}
+			}
+			if err := m.VerifyHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Splits == nil {
+				m.Splits = &BatchSplitResponse{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RaftRequestHeader: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftRequestHeader: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.Splits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3151,31 +6085,33 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			if m.ChangePeerV2 == nil {
+				m.ChangePeerV2 = &ChangePeerV2Response{}
+			}
+			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareMerge", wireType)
 			}
-			m.ShardID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3185,14 +6121,31 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PrepareMerge == nil {
+				m.PrepareMerge = &PrepareMergeResponse{}
+			}
+			if err := m.PrepareMerge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitMerge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3219,13 +6172,16 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.CommitMerge == nil {
+				m.CommitMerge = &CommitMergeResponse{}
+			}
+			if err := m.CommitMerge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RollbackMerge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3252,15 +6208,18 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.RollbackMerge == nil {
+				m.RollbackMerge = &RollbackMergeResponse{}
+			}
+			if err := m.RollbackMerge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ComputeHash", wireType)
 			}
-			m.Term = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3270,16 +6229,33 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreEpochCheck", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ComputeHash == nil {
+				m.ComputeHash = &ComputeHashResponse{}
+			}
+			if err := m.ComputeHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingest", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3289,12 +6265,64 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IgnoreEpochCheck = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingest == nil {
+				m.Ingest = &IngestResponse{}
+			}
+			if err := m.Ingest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Backup == nil {
+				m.Backup = &BackupResponse{}
+			}
+			if err := m.Backup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -3320,7 +6348,7 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftResponseHeader) Unmarshal(dAtA []byte) error {
+func (m *Request) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3339,19 +6367,110 @@ func (m *RaftResponseHeader) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RaftResponseHeader: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftResponseHeader: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			m.Group = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Group |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= CMDType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CustemType", wireType)
+			}
+			m.CustemType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CustemType |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3378,16 +6497,16 @@ func (m *RaftResponseHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3397,30 +6516,31 @@ func (m *RaftResponseHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Cmd = append(m.Cmd[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cmd == nil {
+				m.Cmd = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CurrentTerm", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
 			}
-			m.CurrentTerm = 0
+			m.SID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3430,70 +6550,73 @@ func (m *RaftResponseHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CurrentTerm |= uint64(b&0x7F) << shift
+				m.SID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRaftcmdpb
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
 			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRaftcmdpb
+			m.PID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StopAt", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RaftCMDRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRaftcmdpb
+			m.StopAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StopAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToShard", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ToShard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ToShard |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RaftCMDRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftCMDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowFollower", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3503,33 +6626,57 @@ func (m *RaftCMDRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
+			m.AllowFollower = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBroadcast", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if m.Header == nil {
-				m.Header = &RaftRequestHeader{}
+			m.LastBroadcast = bool(v != 0)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreEpochCheck", wireType)
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 2:
+			m.IgnoreEpochCheck = bool(v != 0)
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3539,31 +6686,29 @@ func (m *RaftCMDRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Requests = append(m.Requests, &Request{})
-			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AdminRequest", wireType)
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tenant", wireType)
 			}
-			var msglen int
+			m.Tenant = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3573,28 +6718,31 @@ func (m *RaftCMDRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Tenant |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.AdminRequest == nil {
-				m.AdminRequest = &AdminRequest{}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FollowerReadIndex", wireType)
 			}
-			if err := m.AdminRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.FollowerReadIndex = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -3620,7 +6768,7 @@ func (m *RaftCMDRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftCMDResponse) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3643,17 +6791,17 @@ func (m *RaftCMDResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftCMDResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftCMDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3663,33 +6811,50 @@ func (m *RaftCMDResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RaftResponseHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= CMDType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3699,29 +6864,29 @@ func (m *RaftCMDResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Responses = append(m.Responses, &Response{})
-			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AdminResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginRequest", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3748,72 +6913,37 @@ func (m *RaftCMDResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AdminResponse == nil {
-				m.AdminResponse = &AdminResponse{}
+			if m.OriginRequest == nil {
+				m.OriginRequest = &Request{}
 			}
-			if err := m.AdminResponse.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.OriginRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AdminRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRaftcmdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.SID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AdminRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AdminRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CmdType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
 			}
-			m.CmdType = 0
+			m.PID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3823,14 +6953,14 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CmdType |= AdminCmdType(b&0x7F) << shift
+				m.PID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3857,18 +6987,15 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeer == nil {
-				m.ChangePeer = &ChangePeerRequest{}
-			}
-			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompactLog", wireType)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinueBroadcast", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3878,33 +7005,17 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.CompactLog == nil {
-				m.CompactLog = &CompactLogRequest{}
-			}
-			if err := m.CompactLog.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			m.ContinueBroadcast = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3914,33 +7025,71 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.Stale = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeaderRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangePeerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VerifyHash", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangePeerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangePeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			}
+			m.ChangeType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -3950,31 +7099,14 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ChangeType |= metapb.ChangePeerType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.VerifyHash == nil {
-				m.VerifyHash = &VerifyHashRequest{}
-			}
-			if err := m.VerifyHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4001,16 +7133,67 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Splits == nil {
-				m.Splits = &BatchSplitRequest{}
-			}
-			if err := m.Splits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangePeerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangePeerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangePeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4037,10 +7220,7 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeerV2 == nil {
-				m.ChangePeerV2 = &ChangePeerV2Request{}
-			}
-			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4069,7 +7249,7 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AdminResponse) Unmarshal(dAtA []byte) error {
+func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4092,17 +7272,17 @@ func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AdminResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactLogRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AdminResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CmdType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactIndex", wireType)
 			}
-			m.CmdType = 0
+			m.CompactIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -4112,16 +7292,16 @@ func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CmdType |= AdminCmdType(b&0x7F) << shift
+				m.CompactIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactTerm", wireType)
 			}
-			var msglen int
+			m.CompactTerm = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -4131,103 +7311,122 @@ func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.CompactTerm |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ChangePeer == nil {
-				m.ChangePeer = &ChangePeerResponse{}
-			}
-			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompactLog", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CompactLog == nil {
-				m.CompactLog = &CompactLogResponse{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-			if err := m.CompactLog.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactLogResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactLogResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeaderResponse{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 5:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeaderRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeaderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VerifyHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4254,85 +7453,118 @@ func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.VerifyHash == nil {
-				m.VerifyHash = &VerifyHashResponse{}
-			}
-			if err := m.VerifyHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Splits == nil {
-				m.Splits = &BatchSplitResponse{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-			if err := m.Splits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeaderResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeaderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeerV2 == nil {
-				m.ChangePeerV2 = &ChangePeerV2Response{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ComputeHashRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ComputeHashRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ComputeHashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -4358,7 +7590,7 @@ func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *ComputeHashResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4381,89 +7613,71 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: ComputeHashResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ComputeHashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
-			}
-			m.Group = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Group |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= CMDType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyHashRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyHashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CustemType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.CustemType = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -4473,14 +7687,14 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CustemType |= uint64(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4507,14 +7721,14 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4541,147 +7755,11 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Cmd = append(m.Cmd[:0], dAtA[iNdEx:postIndex]...)
-			if m.Cmd == nil {
-				m.Cmd = []byte{}
+			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
+			if m.Context == nil {
+				m.Context = []byte{}
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
-			}
-			m.SID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
-			}
-			m.PID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StopAt", wireType)
-			}
-			m.StopAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StopAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToShard", wireType)
-			}
-			m.ToShard = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ToShard |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllowFollower", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.AllowFollower = bool(v != 0)
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBroadcast", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.LastBroadcast = bool(v != 0)
-		case 13:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreEpochCheck", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.IgnoreEpochCheck = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -4707,7 +7785,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *VerifyHashResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4730,104 +7808,71 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyHashResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyHashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= CMDType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
 			}
-			iNdEx = postIndex
-		case 4:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginRequest", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitKey", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -4837,52 +7882,31 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OriginRequest == nil {
-				m.OriginRequest = &Request{}
-			}
-			if err := m.OriginRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.SplitKey = append(m.SplitKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.SplitKey == nil {
+				m.SplitKey = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
-			}
-			m.SID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewShardID", wireType)
 			}
-			m.PID = 0
+			m.NewShardID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -4892,84 +7916,87 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PID |= int64(b&0x7F) << shift
+				m.NewShardID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRaftcmdpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				m.NewPeerIDs = append(m.NewPeerIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRaftcmdpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				if packedLen < 0 {
+					return ErrInvalidLengthRaftcmdpb
 				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContinueBroadcast", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRaftcmdpb
 				}
-				if iNdEx >= l {
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.ContinueBroadcast = bool(v != 0)
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				elementCount = count
+				if elementCount != 0 && len(m.NewPeerIDs) == 0 {
+					m.NewPeerIDs = make([]uint64, 0, elementCount)
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRaftcmdpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPeerIDs = append(m.NewPeerIDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIDs", wireType)
 			}
-			m.Stale = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -4995,7 +8022,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangePeerRequest) Unmarshal(dAtA []byte) error {
+func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5018,34 +8045,15 @@ func (m *ChangePeerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchSplitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
-			}
-			m.ChangeType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ChangeType |= metapb.ChangePeerType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5072,10 +8080,31 @@ func (m *ChangePeerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Requests = append(m.Requests, SplitRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RightDerive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RightDerive = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -5101,7 +8130,7 @@ func (m *ChangePeerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangePeerResponse) Unmarshal(dAtA []byte) error {
+func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5124,15 +8153,15 @@ func (m *ChangePeerResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeerResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchSplitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5159,7 +8188,8 @@ func (m *ChangePeerResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Shards = append(m.Shards, bhmetapb.Shard{})
+			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5188,7 +8218,7 @@ func (m *ChangePeerResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
+func (m *ChangePeerV2Request) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5211,17 +8241,17 @@ func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompactLogRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChangePeerV2Request: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompactLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChangePeerV2Request: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompactIndex", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
 			}
-			m.CompactIndex = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -5231,30 +8261,26 @@ func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CompactIndex |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompactTerm", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
 			}
-			m.CompactTerm = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CompactTerm |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, ChangePeerRequest{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -5280,7 +8306,7 @@ func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
+func (m *ChangePeerV2Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5303,12 +8329,48 @@ func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompactLogResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChangePeerV2Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompactLogResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChangePeerV2Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Shard == nil {
+				m.Shard = &bhmetapb.Shard{}
+			}
+			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -5334,7 +8396,7 @@ func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
+func (m *PrepareMergeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5357,15 +8419,34 @@ func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeaderRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrepareMergeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeaderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrepareMergeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinIndex", wireType)
+			}
+			m.MinIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5392,7 +8473,7 @@ func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5421,7 +8502,7 @@ func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
+func (m *PrepareMergeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5444,10 +8525,10 @@ func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeaderResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrepareMergeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeaderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrepareMergeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -5475,7 +8556,7 @@ func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
+func (m *CommitMergeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5498,17 +8579,17 @@ func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyHashRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommitMergeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyHashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommitMergeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
-			m.Index = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -5518,16 +8599,30 @@ func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Source.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
-			var byteLen int
+			m.Commit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -5537,31 +8632,16 @@ func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Commit |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
-			}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -5571,24 +8651,24 @@ func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
-			if m.Context == nil {
-				m.Context = []byte{}
+			m.Entries = append(m.Entries, raftpb.Entry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -5616,7 +8696,61 @@ func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VerifyHashResponse) Unmarshal(dAtA []byte) error {
+func (m *CommitMergeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftcmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitMergeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitMergeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollbackMergeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5639,12 +8773,31 @@ func (m *VerifyHashResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyHashResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RollbackMergeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyHashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RollbackMergeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			m.Commit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Commit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -5670,7 +8823,7 @@ func (m *VerifyHashResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SplitRequest) Unmarshal(dAtA []byte) error {
+func (m *RollbackMergeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5693,141 +8846,12 @@ func (m *SplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RollbackMergeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RollbackMergeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftcmdpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SplitKey = append(m.SplitKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.SplitKey == nil {
-				m.SplitKey = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewShardID", wireType)
-			}
-			m.NewShardID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftcmdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NewShardID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRaftcmdpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.NewPeerIDs = append(m.NewPeerIDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRaftcmdpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRaftcmdpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRaftcmdpb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.NewPeerIDs) == 0 {
-					m.NewPeerIDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRaftcmdpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.NewPeerIDs = append(m.NewPeerIDs, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIDs", wireType)
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -5853,7 +8877,7 @@ func (m *SplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
+func (m *IngestRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5876,15 +8900,34 @@ func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BatchSplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: IngestRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IngestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5911,14 +8954,13 @@ func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Requests = append(m.Requests, SplitRequest{})
-			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RightDerive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Discard", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -5935,7 +8977,7 @@ func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.RightDerive = bool(v != 0)
+			m.Discard = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -5961,7 +9003,7 @@ func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
+func (m *IngestResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5984,17 +9026,37 @@ func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BatchSplitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: IngestResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IngestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StaleEpoch", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StaleEpoch = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -6004,25 +9066,23 @@ func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Shards = append(m.Shards, bhmetapb.Shard{})
-			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6049,7 +9109,7 @@ func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangePeerV2Request) Unmarshal(dAtA []byte) error {
+func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6072,17 +9132,17 @@ func (m *ChangePeerV2Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeerV2Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: BackupRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeerV2Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BackupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftcmdpb
@@ -6092,25 +9152,23 @@ func (m *ChangePeerV2Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftcmdpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Changes = append(m.Changes, ChangePeerRequest{})
-			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6137,7 +9195,7 @@ func (m *ChangePeerV2Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangePeerV2Response) Unmarshal(dAtA []byte) error {
+func (m *BackupResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6160,15 +9218,15 @@ func (m *ChangePeerV2Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeerV2Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: BackupResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeerV2Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BackupResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Backup", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6195,13 +9253,42 @@ func (m *ChangePeerV2Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Shard == nil {
-				m.Shard = &bhmetapb.Shard{}
-			}
-			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Backup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftcmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftcmdpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftcmdpb(dAtA[iNdEx:])
@@ -0,0 +1,101 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/auth"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCWithAuth(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.InternalToken = "internal"
+			cfg.Customize.CustomAuthenticator = auth.NewTokenAuthenticator(map[string]string{
+				"reader": "reader",
+			})
+			cfg.Customize.CustomAuthorizer = auth.NewPermissionAuthorizer(map[string][]auth.Permission{
+				"reader": {{Group: 0, CustemTypes: []uint64{2}}},
+			})
+		}))
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	// the requests forwarded by the stores carry the internal token
+	for i := 0; i < 3; i++ {
+		kv := c.CreateTestKVClient(i)
+		assert.NoError(t, kv.Set("key", "OK", testWaitTimeout))
+		kv.Close()
+	}
+
+	s := c.GetShardLeaderStore(c.GetShardByIndex(0, 0).ID)
+	conn := goetty.NewIOSession(goetty.WithCodec(s.CreateRPCCliendSideCodec()))
+	ok, err := conn.Connect(s.GetConfig().ClientAddr, time.Second*5)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	defer conn.Close()
+
+	send := func(req *raftcmdpb.Request) *raftcmdpb.Response {
+		assert.NoError(t, conn.WriteAndFlush(req))
+		value, err := conn.Read()
+		assert.NoError(t, err)
+		return value.(*raftcmdpb.Response)
+	}
+
+	rsp := send(createTestWriteReq("w1", "key", "value"))
+	assert.NotNil(t, rsp.Error.PermissionDenied)
+	assert.Equal(t, uint64(1), rsp.Error.PermissionDenied.CustemType)
+
+	req := createTestWriteReq("w2", "key", "value")
+	req.Token = "reader"
+	rsp = send(req)
+	assert.NotNil(t, rsp.Error.PermissionDenied)
+
+	req = createTestReadReq("r1", "key")
+	req.Token = "reader"
+	rsp = send(req)
+	assert.Nil(t, rsp.Error.PermissionDenied)
+	assert.Equal(t, "OK", string(rsp.Value))
+
+	// the tokens are not written into the raft log
+	id := c.GetShardByIndex(0, 0).ID
+	c.EveryStore(func(i int, s Store) {
+		pr := s.(*store).getPR(id, false)
+		if pr == nil {
+			return
+		}
+		first, err := pr.ps.FirstIndex()
+		assert.NoError(t, err)
+		last, err := pr.ps.LastIndex()
+		assert.NoError(t, err)
+		entries, err := pr.ps.Entries(first, last+1, math.MaxUint64)
+		assert.NoError(t, err)
+		for _, entry := range entries {
+			assert.False(t, bytes.Contains(entry.Data, []byte("internal")))
+			assert.False(t, bytes.Contains(entry.Data, []byte("reader")))
+		}
+	})
+}
@@ -0,0 +1,661 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/fagongzi/util/uuid"
+	"github.com/matrixorigin/matrixcube/backup"
+	pconfig "github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	pstorage "github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+const (
+	// backupMetaFile the file of the backup meta, it's saved after all the shards backuped
+	backupMetaFile = "backupmeta"
+	// backupShardTimeout the timeout to backup a shard
+	backupShardTimeout = time.Minute
+	// backupUploadTimeout the timeout to wait for the backup files of all the shards uploaded
+	backupUploadTimeout = time.Minute * 10
+)
+
+var (
+	errBackupNotCompleted  = errors.New("the backup is not completed")
+	errBackupUploadTimeout = errors.New("wait for the backup files uploaded timeout")
+)
+
+// Backup starts a job in the prophet to backup all the shards of the cluster to the path, the
+// backup is completed once LoadBackupMeta returns the meta of the backup. The job freezes the
+// writes of all the groups, takes the view of every shard at its applied index, and unfreezes
+// the writes, so the backup contains all the writes accepted before the freeze and none after.
+// The backup files are uploaded asynchronously after the writes unfrozen. Only one backup can
+// run at the same time, the backup is ignored if there is a running one.
+func (s *store) Backup(path string) error {
+	return s.pd.GetClient().CreateJob(metapb.Job{Type: metapb.JobType_Backup, Content: protoc.MustMarshal(&bhmetapb.BackupJob{
+		Path: path,
+	})})
+}
+
+// LoadBackupMeta returns the meta of the backup at the path, returns nil if the backup is not
+// completed.
+func LoadBackupMeta(cfg *config.Config, path string) (*bhmetapb.BackupMeta, error) {
+	bs, err := newBackupStorage(cfg, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := bs.Exists(backupMetaFile)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	fs := backupFS(cfg)
+	tmp, err := backupTmpDir(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.RemoveAll(tmp)
+
+	file := fs.PathJoin(tmp, backupMetaFile)
+	if err := bs.Get(backupMetaFile, file); err != nil {
+		return nil, err
+	}
+
+	f, err := fs.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(f); err != nil {
+		return nil, err
+	}
+
+	meta := &bhmetapb.BackupMeta{}
+	protoc.MustUnmarshal(meta, buf.Bytes())
+	return meta, nil
+}
+
+// RestoreFromBackup sets the config to bootstrap a new cluster with the shards in the backup at
+// the path. It needs to be called with the config of every store of the new cluster before the
+// store created, because the new cluster is bootstrapped by any of the stores, but only the store
+// bootstrapping the cluster restores the data, and the other replicas are created by the raft
+// snapshots. The shards are created with the new ids and peers allocated by the new cluster, the
+// ranges and the versions of the epochs in the backup are kept.
+func RestoreFromBackup(cfg *config.Config, path string) error {
+	meta, err := LoadBackupMeta(cfg, path)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		return errBackupNotCompleted
+	}
+
+	var shards []bhmetapb.Shard
+	for _, b := range meta.Shards {
+		shards = append(shards, bhmetapb.Shard{
+			Start:        b.Shard.Start,
+			End:          b.Shard.End,
+			Epoch:        metapb.ResourceEpoch{Version: b.Shard.Epoch.Version},
+			Group:        b.Shard.Group,
+			Unique:       b.Shard.Unique,
+			RuleGroups:   b.Shard.RuleGroups,
+			Data:         b.Shard.Data,
+			DisableSplit: b.Shard.DisableSplit,
+		})
+	}
+
+	cfg.Customize.CustomInitShardsFactory = func() []bhmetapb.Shard {
+		return shards
+	}
+	cfg.Customize.CustomInitShardsRestoreFunc = func(shards []bhmetapb.Shard) error {
+		return restoreShards(cfg, path, meta, shards)
+	}
+	return nil
+}
+
+// restoreShards restores the data of the shards in the backup to the init shards with the ids
+// allocated, the init shards are in the same order as the backup.
+func restoreShards(cfg *config.Config, path string, meta *bhmetapb.BackupMeta, shards []bhmetapb.Shard) error {
+	bs, err := newBackupStorage(cfg, path)
+	if err != nil {
+		return err
+	}
+
+	fs := backupFS(cfg)
+	tmp, err := backupTmpDir(cfg)
+	if err != nil {
+		return err
+	}
+	defer fs.RemoveAll(tmp)
+
+	for i, b := range meta.Shards {
+		file := fs.PathJoin(tmp, b.File)
+		if err := bs.Get(b.File, file); err != nil {
+			return err
+		}
+
+		if err := util.UnGZIP(fs, file, tmp); err != nil {
+			return err
+		}
+
+		dir := strings.TrimSuffix(file, ".gz")
+		shard := shards[i]
+		db := cfg.Storage.DataStorageFactory(shard.Group, shard.ID)
+		if err := applyShardSnapshot(cfg, db, dir, shard); err != nil {
+			return err
+		}
+
+		fs.RemoveAll(file)
+		fs.RemoveAll(dir)
+		logger.Infof("shard %d restored from the backup of shard %d at index %d",
+			shard.ID,
+			b.Shard.ID,
+			b.Index)
+	}
+	return nil
+}
+
+func newBackupStorage(cfg *config.Config, path string) (backup.Storage, error) {
+	if cfg.Customize.CustomBackupStorageFactory != nil {
+		return cfg.Customize.CustomBackupStorageFactory(path)
+	}
+
+	return backup.NewLocalStorage(backupFS(cfg), path)
+}
+
+func backupFS(cfg *config.Config) vfs.FS {
+	if cfg.FS == nil {
+		return vfs.Default
+	}
+	return cfg.FS
+}
+
+func backupTmpDir(cfg *config.Config) (string, error) {
+	fs := backupFS(cfg)
+	dir := fs.PathJoin(cfg.SnapshotDir(), fmt.Sprintf("backup-%d", time.Now().UnixNano()))
+	return dir, fs.MkdirAll(dir, 0750)
+}
+
+// startBackupShard takes the view of the shard's data at the applied index, and uploads the
+// snapshot of the view to the backup storage at the path by the backup worker.
+func (s *store) startBackupShard(path string, shard bhmetapb.Shard, index uint64) (bhmetapb.ShardBackup, error) {
+	name := fmt.Sprintf("shard-%d-%d", shard.ID, index)
+	backup := bhmetapb.ShardBackup{Shard: shard, Index: index, File: fmt.Sprintf("%s.gz", name)}
+	bs, err := newBackupStorage(s.cfg, path)
+	if err != nil {
+		return backup, err
+	}
+
+	fs := s.cfg.FS
+	dir := fs.PathJoin(s.cfg.SnapshotDir(), name)
+	gzFile := fmt.Sprintf("%s.gz", dir)
+	fs.RemoveAll(dir)
+
+	// the custom snapshot data has no view, it's created in the apply path with the storage's
+	// snapshot, so as the storage which can't take a view.
+	var create func(path string) error
+	db := s.DataStorageByGroup(shard.Group, shard.ID)
+	if ss, ok := db.(storage.SnapshotableStorage); ok && s.cfg.Customize.CustomSnapshotDataCreateFuncFactory == nil {
+		create, err = ss.SnapshotView(encStartKey(&shard), encEndKey(&shard))
+	} else {
+		err = createShardSnapshot(s.cfg, db, dir, shard)
+	}
+	if err != nil {
+		fs.RemoveAll(dir)
+		return backup, err
+	}
+
+	err = s.addBackupJob(func() error {
+		defer fs.RemoveAll(dir)
+		defer fs.RemoveAll(gzFile)
+
+		err := s.uploadShardBackup(bs, create, dir, backup.File)
+		if err != nil {
+			logger.Errorf("shard %d upload backup at index %d failed with %+v",
+				shard.ID,
+				index,
+				err)
+		}
+		return err
+	})
+	if err != nil && create != nil {
+		// release the view
+		create(dir)
+	}
+	return backup, err
+}
+
+func (s *store) uploadShardBackup(bs backup.Storage, create func(path string) error, dir, file string) error {
+	if create != nil {
+		if err := create(dir); err != nil {
+			return err
+		}
+	}
+
+	if err := util.GZIP(s.cfg.FS, dir); err != nil {
+		return err
+	}
+	return bs.Put(file, fmt.Sprintf("%s.gz", dir))
+}
+
+type backupResult struct {
+	backup bhmetapb.ShardBackup
+	err    error
+}
+
+// backupJob is the processor of the backup job. It runs on the prophet leader, freezes the writes
+// of all the groups, and backups the shards of all the groups by the Backup admin request. The
+// backup is taken again if the shards of a group don't cover the whole range, e.g. the shards
+// are split during the backup, or the backup files are not uploaded. The groups frozen by the job
+// are saved in the job data, so the job unfreezes them on the new prophet leader.
+type backupJob struct {
+	s        *store
+	pendings sync.Map // request id -> chan backupResult
+
+	mu struct {
+		sync.Mutex
+
+		cancel context.CancelFunc
+		proxy  *shardsProxy
+	}
+}
+
+func newBackupJob(s *store) *backupJob {
+	j := &backupJob{s: s}
+	s.cfg.Prophet.RegisterJobProcessor(metapb.JobType_Backup, j)
+	return j
+}
+
+func (j *backupJob) Start(job metapb.Job, store pstorage.JobStorage, aware pconfig.ResourcesAware) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j.mu.cancel = cancel
+	go j.run(ctx, job, store, aware)
+}
+
+func (j *backupJob) Stop(job metapb.Job, store pstorage.JobStorage, aware pconfig.ResourcesAware) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.cancel != nil {
+		j.mu.cancel()
+		j.mu.cancel = nil
+	}
+}
+
+func (j *backupJob) Remove(job metapb.Job, store pstorage.JobStorage, aware pconfig.ResourcesAware) {
+	j.Stop(job, store, aware)
+}
+
+func (j *backupJob) Execute(data []byte, store pstorage.JobStorage, aware pconfig.ResourcesAware) ([]byte, error) {
+	return nil, errors.New("backup job has nothing to execute")
+}
+
+func (j *backupJob) stop() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.cancel != nil {
+		j.mu.cancel()
+		j.mu.cancel = nil
+	}
+	if j.mu.proxy != nil {
+		j.mu.proxy.stop()
+		j.mu.proxy = nil
+	}
+}
+
+func (j *backupJob) run(ctx context.Context, job metapb.Job, store pstorage.JobStorage, aware pconfig.ResourcesAware) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-j.s.pdStartedC:
+	}
+
+	content := &bhmetapb.BackupJob{}
+	protoc.MustUnmarshal(content, job.Content)
+	logger.Infof("backup to %s started", content.Path)
+
+	var meta *bhmetapb.BackupMeta
+	for {
+		var err error
+		meta, err = j.backup(ctx, content.Path, job, store, aware)
+		if err == nil {
+			break
+		}
+
+		logger.Errorf("backup to %s failed with %+v, retry later",
+			content.Path,
+			err)
+		if !j.wait(ctx) {
+			return
+		}
+	}
+
+	for {
+		err := j.saveMeta(content.Path, meta)
+		if err == nil {
+			err = j.s.pd.GetClient().RemoveJob(job)
+		}
+		if err == nil {
+			break
+		}
+
+		logger.Errorf("complete backup to %s failed with %+v",
+			content.Path,
+			err)
+		if !j.wait(ctx) {
+			return
+		}
+	}
+
+	logger.Infof("backup to %s completed, %d shards backuped",
+		content.Path,
+		len(meta.Shards))
+}
+
+// backup takes the backup of all the shards with the writes frozen, and waits for the backup
+// files uploaded.
+func (j *backupJob) backup(ctx context.Context, path string, job metapb.Job, store pstorage.JobStorage,
+	aware pconfig.ResourcesAware) (*bhmetapb.BackupMeta, error) {
+	meta, err := j.backupFrozen(ctx, path, job, store, aware)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := newBackupStorage(j.s.cfg, path)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.After(backupUploadTimeout)
+	for _, b := range meta.Shards {
+		for {
+			ok, err := bs.Exists(b.File)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-timeout:
+				return nil, errBackupUploadTimeout
+			case <-time.After(RetryInterval):
+			}
+		}
+	}
+	return meta, nil
+}
+
+// backupFrozen freezes the writes of the groups, takes the backups of all the shards and unfreezes
+// the groups frozen by the job.
+func (j *backupJob) backupFrozen(ctx context.Context, path string, job metapb.Job, store pstorage.JobStorage,
+	aware pconfig.ResourcesAware) (*bhmetapb.BackupMeta, error) {
+	// unfreeze the groups frozen by the job on the previous prophet leader
+	if err := j.unfreeze(job, store); err != nil {
+		return nil, err
+	}
+
+	client := j.s.pd.GetClient()
+	frozen, err := client.GetWriteFrozenGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &bhmetapb.BackupJob{Path: path}
+	for g := uint64(0); g < j.s.cfg.ShardGroups; g++ {
+		if !containsGroup(frozen, g) {
+			data.FrozenGroups = append(data.FrozenGroups, g)
+		}
+	}
+	if err := store.PutJobData(job, protoc.MustMarshal(data)); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := j.unfreeze(job, store); err != nil {
+			logger.Errorf("unfreeze the groups frozen by the backup to %s failed with %+v, retry later",
+				path,
+				err)
+		}
+	}()
+
+	for _, g := range data.FrozenGroups {
+		if err := client.SetWriteFreeze(g, true); err != nil {
+			return nil, err
+		}
+	}
+
+	meta := &bhmetapb.BackupMeta{}
+	for g := uint64(0); g < j.s.cfg.ShardGroups; g++ {
+		if err := j.backupGroup(ctx, g, path, meta, aware); err != nil {
+			return nil, err
+		}
+	}
+	return meta, nil
+}
+
+// unfreeze unfreezes the groups frozen by the job
+func (j *backupJob) unfreeze(job metapb.Job, store pstorage.JobStorage) error {
+	value, err := store.GetJobData(job)
+	if err != nil || len(value) == 0 {
+		return err
+	}
+
+	data := &bhmetapb.BackupJob{}
+	protoc.MustUnmarshal(data, value)
+	for _, g := range data.FrozenGroups {
+		if err := j.s.pd.GetClient().SetWriteFreeze(g, false); err != nil {
+			return err
+		}
+	}
+
+	data.FrozenGroups = nil
+	return store.PutJobData(job, protoc.MustMarshal(data))
+}
+
+// backupGroup backups all the shards of the group, returns error if the shards backuped don't
+// cover the whole range of the group.
+func (j *backupJob) backupGroup(ctx context.Context, group uint64, path string, meta *bhmetapb.BackupMeta,
+	aware pconfig.ResourcesAware) error {
+	var resources []metadata.Resource
+	aware.ForeachResources(group, func(res metadata.Resource) {
+		if res.State() == metapb.ResourceState_Running {
+			resources = append(resources, res)
+		}
+	})
+
+	var backups []bhmetapb.ShardBackup
+	for _, res := range resources {
+		start, _ := res.Range()
+		b, err := j.backupShard(ctx, path, group, res.ID(), start)
+		if err != nil {
+			return fmt.Errorf("shard %d backup failed with %+v", res.ID(), err)
+		}
+		backups = append(backups, b)
+	}
+
+	sort.Slice(backups, func(i, k int) bool {
+		return bytes.Compare(backups[i].Shard.Start, backups[k].Shard.Start) < 0
+	})
+	var end []byte
+	for idx, b := range backups {
+		if !bytes.Equal(b.Shard.Start, end) || (idx > 0 && len(end) == 0) {
+			return fmt.Errorf("the shards of group %d changed during the backup", group)
+		}
+		end = b.Shard.End
+	}
+	if len(backups) > 0 && len(end) > 0 {
+		return fmt.Errorf("the shards of group %d changed during the backup", group)
+	}
+
+	meta.Shards = append(meta.Shards, backups...)
+	return nil
+}
+
+// backupShard sends the Backup admin request to the leader of the shard, and waits for the
+// backup of the shard.
+func (j *backupJob) backupShard(ctx context.Context, path string, group, shardID uint64, start []byte) (bhmetapb.ShardBackup, error) {
+	admin := &raftcmdpb.AdminRequest{
+		CmdType: raftcmdpb.AdminCmdType_Backup,
+		Backup:  &raftcmdpb.BackupRequest{Path: path},
+	}
+
+	req := pb.AcquireRequest()
+	req.ID = uuid.NewV4().Bytes()
+	req.Group = group
+	req.Key = start
+	req.ToShard = shardID
+	req.Type = raftcmdpb.CMDType_Admin
+	req.Cmd = protoc.MustMarshal(admin)
+	req.StopAt = time.Now().Add(backupShardTimeout).Unix()
+
+	id := string(req.ID)
+	c := make(chan backupResult, 1)
+	j.pendings.Store(id, c)
+	defer j.pendings.Delete(id)
+
+	proxy := j.getProxy()
+	if err := proxy.DispatchTo(req, shardID, proxy.Router().LeaderPeerStore(shardID).ClientAddr); err != nil {
+		return bhmetapb.ShardBackup{}, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return bhmetapb.ShardBackup{}, ctx.Err()
+	case result := <-c:
+		return result.backup, result.err
+	case <-time.After(backupShardTimeout):
+		return bhmetapb.ShardBackup{}, ErrTimeout
+	}
+}
+
+func (j *backupJob) getProxy() *shardsProxy {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.proxy == nil {
+		j.mu.proxy = newRPCShardsProxy(j.s, j.done, j.errorDone)
+	}
+	return j.mu.proxy
+}
+
+func (j *backupJob) done(rsp *raftcmdpb.Response) {
+	result := backupResult{}
+	admin := &raftcmdpb.AdminResponse{}
+	if err := admin.Unmarshal(rsp.Value); err != nil {
+		result.err = err
+	} else if admin.Backup == nil {
+		result.err = fmt.Errorf("unexpected backup response %+v", admin)
+	} else if admin.Backup.Error != "" {
+		result.err = errors.New(admin.Backup.Error)
+	} else {
+		result.backup = admin.Backup.Backup
+	}
+
+	j.notify(rsp.ID, result)
+}
+
+func (j *backupJob) errorDone(req *raftcmdpb.Request, err error) {
+	if req != nil {
+		j.notify(req.ID, backupResult{err: err})
+	}
+}
+
+func (j *backupJob) notify(id []byte, result backupResult) {
+	if c, ok := j.pendings.Load(string(id)); ok {
+		select {
+		case c.(chan backupResult) <- result:
+		default:
+		}
+	}
+}
+
+func (j *backupJob) saveMeta(path string, meta *bhmetapb.BackupMeta) error {
+	bs, err := newBackupStorage(j.s.cfg, path)
+	if err != nil {
+		return err
+	}
+
+	fs := j.s.cfg.FS
+	tmp, err := backupTmpDir(j.s.cfg)
+	if err != nil {
+		return err
+	}
+	defer fs.RemoveAll(tmp)
+
+	file := fs.PathJoin(tmp, backupMetaFile)
+	f, err := fs.Create(file)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(protoc.MustMarshal(meta))
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	return bs.Put(backupMetaFile, file)
+}
+
+// wait waits for the retry interval, returns false if the job is stopped
+func (j *backupJob) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(RetryInterval):
+		return true
+	}
+}
+
+func containsGroup(groups []uint64, group uint64) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
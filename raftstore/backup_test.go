@@ -0,0 +1,87 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, kv.Set(fmt.Sprintf("key-%d", i), "OK", testWaitTimeout))
+	}
+	kv.Close()
+
+	path := fmt.Sprintf("%s/backup-%d", util.GetTestDir(), time.Now().Nanosecond())
+	s := c.GetStore(0)
+	meta, err := LoadBackupMeta(s.GetConfig(), path)
+	assert.NoError(t, err)
+	assert.Nil(t, meta)
+
+	assert.NoError(t, s.Backup(path))
+	timeout := time.After(testWaitTimeout)
+	for meta == nil {
+		select {
+		case <-timeout:
+			assert.FailNow(t, "backup timeout")
+		default:
+			meta, err = LoadBackupMeta(s.GetConfig(), path)
+			assert.NoError(t, err)
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+	assert.Equal(t, 1, len(meta.Shards))
+	assert.Equal(t, c.GetShardByIndex(0, 0).ID, meta.Shards[0].Shard.ID)
+	// the writes are unfrozen after the backup
+	groups, err := c.GetProphet().GetClient().GetWriteFrozenGroups()
+	assert.NoError(t, err)
+	assert.Empty(t, groups)
+
+	// the test is already parallel, create the cluster without NewTestClusterStore
+	nc := &testRaftCluster{t: t}
+	nc.reset(true, GetCMDTestClusterHandler,
+		WithTestClusterStoreFactory(func(node int, cfg *config.Config) Store {
+			assert.NoError(t, RestoreFromBackup(cfg, path))
+			return NewStore(cfg)
+		}))
+	defer nc.Stop()
+
+	nc.Start()
+	nc.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv = nc.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		v, err := kv.Get(fmt.Sprintf("key-%d", i), testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, "OK", v)
+	}
+
+	assert.Equal(t, errBackupNotCompleted, RestoreFromBackup(s.GetConfig(), fmt.Sprintf("%s/not-exist", path)))
+}
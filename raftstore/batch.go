@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/fagongzi/goetty/buf"
 	"github.com/fagongzi/util/uuid"
@@ -40,33 +41,91 @@ type readIndexQueue struct {
 	shardID     uint64
 	reads       []cmd
 	readyToRead int
+	// followerReads the reads with the ReadIndex forwarded to the leader by the follower, the
+	// leader may drop the ReadIndex, so they are not in order and may expire.
+	followerReads []cmd
 }
 
 func (q *readIndexQueue) reset() {
 	q.reads = q.reads[:0]
 	q.readyToRead = 0
+	q.followerReads = q.followerReads[:0]
 }
 
 func (q *readIndexQueue) push(c cmd) {
+	if c.followerRead {
+		q.followerReads = append(q.followerReads, c)
+		return
+	}
+
 	q.reads = append(q.reads, c)
 }
 
+func (q *readIndexQueue) foreach(fn func(c cmd)) {
+	for _, c := range q.reads {
+		fn(c)
+	}
+	for _, c := range q.followerReads {
+		fn(c)
+	}
+}
+
 func (q *readIndexQueue) ready(state raft.ReadState) {
-	if !bytes.Equal(state.RequestCtx, q.reads[q.readyToRead].getUUID()) {
-		logger.Fatalf("shard %d apply read failed, uuid not match",
-			q.shardID)
+	for idx := range q.followerReads {
+		if bytes.Equal(state.RequestCtx, q.followerReads[idx].getUUID()) {
+			q.followerReads[idx].readIndexCommittedIndex = state.Index
+			return
+		}
 	}
 
 	for idx := range q.reads {
 		if bytes.Equal(state.RequestCtx, q.reads[idx].getUUID()) {
+			if idx != q.readyToRead {
+				logger.Fatalf("shard %d apply read failed, uuid not match",
+					q.shardID)
+			}
+
 			q.reads[idx].readIndexCommittedIndex = state.Index
 			q.readyToRead++
 			return
 		}
 	}
+
+	// The ReadIndex forwarded by the follower may be responded after it was expired.
+	logger.Debugf("shard %d read state %+v not found in pending reads",
+		q.shardID,
+		state)
+}
+
+// removeExpired responds the follower reads which are not ready after the timeout with the
+// stale command error, the ReadIndex may be dropped by the leader.
+func (q *readIndexQueue) removeExpired(now time.Time, timeout time.Duration, term uint64) {
+	newCmds := q.followerReads[:0]
+	for _, c := range q.followerReads {
+		if c.readIndexCommittedIndex == 0 && now.Sub(c.readIndexAt) > timeout {
+			c.resp(errorStaleCMDResp(c.getUUID(), term))
+		} else {
+			newCmds = append(newCmds, c)
+		}
+	}
+
+	q.followerReads = newCmds
 }
 
 func (q *readIndexQueue) doReadLEAppliedIndex(appliedIndex uint64, pr *peerReplica) {
+	if len(q.followerReads) > 0 {
+		newCmds := q.followerReads[:0]
+		for _, c := range q.followerReads {
+			if c.readIndexCommittedIndex > 0 && c.readIndexCommittedIndex <= appliedIndex {
+				metric.ObserveFollowerReadIndexWaitDuration(c.readIndexAt)
+				pr.doExecReadCmd(c)
+			} else {
+				newCmds = append(newCmds, c)
+			}
+		}
+		q.followerReads = newCmds
+	}
+
 	if len(q.reads) == 0 || q.readyToRead <= 0 {
 		return
 	}
@@ -88,6 +147,8 @@ type reqCtx struct {
 	admin *raftcmdpb.AdminRequest
 	req   *raftcmdpb.Request
 	cb    func(*raftcmdpb.RaftCMDResponse)
+	// ingestChecked the ingest file is held by all the replicas
+	ingestChecked bool
 }
 
 type proposeBatch struct {
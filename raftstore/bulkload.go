@@ -0,0 +1,348 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/fagongzi/util/uuid"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/transport"
+)
+
+var (
+	errUnsortedIngestKeys  = errors.New("the keys of the bulk load are not in ascending order")
+	errUnsupportedAdmin    = errors.New("unsupported admin request")
+	errIngestFileNotFound  = errors.New("ingest file not found")
+	errProbeNotSupported   = errors.New("the snapshot manager or the transport not support snapshot probes")
+	errBulkLoadUnsupported = errors.New("bulk load unsupported, the snapshot manager not implement snapshot.IngestManager")
+)
+
+// IngestFile is the file built by the bulk load for a shard. It's ingested into the shard by
+// the Ingest admin request, and dropped if the epoch of the shard is changed after the file built.
+type IngestFile struct {
+	// ID the unique id of the file
+	ID uint64
+	// Shard the shard when the file built
+	Shard bhmetapb.Shard
+}
+
+func (f IngestFile) snapshotMessage() *bhraftpb.SnapshotMessage {
+	msg := &bhraftpb.SnapshotMessage{}
+	msg.Header.Shard = f.Shard
+	msg.Header.Index = f.ID
+	return msg
+}
+
+// NewIngestRequest returns the request to ingest the file, the request is routed to the
+// leader of the shard of the file. The response value is the marshaled AdminResponse.
+func NewIngestRequest(file IngestFile) *raftcmdpb.Request {
+	return newIngestRequest(file, false)
+}
+
+// NewDiscardIngestRequest returns the request to remove the file from all the replicas of the
+// shard without ingested, it's used to clean the files of the aborted bulk load.
+func NewDiscardIngestRequest(file IngestFile) *raftcmdpb.Request {
+	return newIngestRequest(file, true)
+}
+
+func newIngestRequest(file IngestFile, discard bool) *raftcmdpb.Request {
+	admin := &raftcmdpb.AdminRequest{
+		CmdType: raftcmdpb.AdminCmdType_Ingest,
+		Ingest: &raftcmdpb.IngestRequest{
+			ID:      file.ID,
+			Epoch:   file.Shard.Epoch,
+			Discard: discard,
+		},
+	}
+
+	req := pb.AcquireRequest()
+	req.ID = uuid.NewV4().Bytes()
+	req.Group = file.Shard.Group
+	req.Key = file.Shard.Start
+	req.ToShard = file.Shard.ID
+	req.Type = raftcmdpb.CMDType_Admin
+	req.Cmd = protoc.MustMarshal(admin)
+	return req
+}
+
+// CreateIngestFiles splits the key-value pairs of the bulk load by the shards of the group in
+// the router, builds a file for each shard and sends it to all the peers of the shard. The
+// keys must be returned by next in ascending order, and the returned slices must not be
+// modified later. The files need to be ingested by the requests returned by NewIngestRequest.
+// The files returned with the error are sent to some of the peers, and need to be discarded
+// by the requests returned by NewDiscardIngestRequest.
+func (s *store) CreateIngestFiles(group uint64, next func() ([]byte, []byte, bool)) ([]IngestFile, error) {
+	mgr, ok := s.snapshotManager.(snapshot.IngestManager)
+	if !ok {
+		return nil, errBulkLoadUnsupported
+	}
+
+	var shards []bhmetapb.Shard
+	s.router.ForeachShards(group, func(shard *bhmetapb.Shard) bool {
+		shards = append(shards, *shard)
+		return true
+	})
+	sort.Slice(shards, func(i, j int) bool {
+		return bytes.Compare(shards[i].Start, shards[j].Start) < 0
+	})
+
+	var files []IngestFile
+	it := &ingestIterator{next: next}
+	idx := 0
+	for it.peek() {
+		for idx < len(shards) &&
+			len(shards[idx].End) > 0 &&
+			bytes.Compare(it.key, shards[idx].End) >= 0 {
+			idx++
+		}
+		if idx == len(shards) ||
+			bytes.Compare(it.key, shards[idx].Start) < 0 {
+			return files, fmt.Errorf("%w, group %d key %+v", errKeyNotInShard, group, it.key)
+		}
+
+		file, err := s.createIngestFile(mgr, shards[idx], it)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, file)
+		if err := s.sendIngestFile(file); err != nil {
+			return files, err
+		}
+	}
+
+	if it.err != nil {
+		return files, it.err
+	}
+	return files, nil
+}
+
+func (s *store) createIngestFile(mgr snapshot.IngestManager, shard bhmetapb.Shard, it *ingestIterator) (IngestFile, error) {
+	file := IngestFile{ID: s.MustAllocID(), Shard: shard}
+	msg := file.snapshotMessage()
+	err := mgr.CreateIngest(msg, it.nextInShard(shard))
+	if err == nil {
+		err = it.err
+	}
+	if err != nil {
+		s.snapshotManager.CleanSnap(msg)
+		return file, err
+	}
+
+	logger.Infof("shard %d ingest file %d created, epoch=<%s>",
+		shard.ID,
+		file.ID,
+		shard.Epoch.String())
+	return file, nil
+}
+
+// sendIngestFile sends the file to all the peers of the shard except the witnesses, the local
+// file is removed after sent if the local store has no peer of the shard which holds the data.
+func (s *store) sendIngestFile(file IngestFile) error {
+	msg := file.snapshotMessage()
+	local := false
+	var err error
+	for _, p := range file.Shard.Peers {
+		if p.Role == metapb.PeerRole_Witness {
+			continue
+		}
+		if p.ContainerID == s.Meta().ID {
+			local = true
+			continue
+		}
+
+		msg.Header.To = p
+		if err = s.trans.SendSnapshot(msg); err != nil {
+			break
+		}
+	}
+
+	if !local {
+		s.snapshotManager.CleanSnap(msg)
+	}
+	return err
+}
+
+// checkIngestFile checks all the peers of the shard except the witnesses hold the complete ingest
+// file, the remote peers are probed the same as sending the snapshots. It returns the bytes of
+// the file.
+func (s *store) checkIngestFile(file IngestFile) (uint64, error) {
+	msg := file.snapshotMessage()
+	if !s.snapshotManager.Exists(msg) {
+		return 0, fmt.Errorf("%w, shard %d file %d", errIngestFileNotFound, file.Shard.ID, file.ID)
+	}
+
+	local, ok := s.snapshotManager.(snapshot.SnapshotProber)
+	if !ok {
+		return 0, errProbeNotSupported
+	}
+
+	size := local.ReceivedSnapBytes(msg)
+	prober, ok := s.trans.(transport.SnapshotProber)
+	for _, p := range file.Shard.Peers {
+		if p.Role == metapb.PeerRole_Witness || p.ContainerID == s.Meta().ID {
+			continue
+		}
+		if !ok {
+			return 0, errProbeNotSupported
+		}
+
+		msg.Header.To = p
+		received, err := prober.ProbeSnapshot(msg)
+		if err != nil {
+			return 0, err
+		}
+		if received != size {
+			return 0, fmt.Errorf("%w, shard %d file %d, store %d received %d bytes of %d",
+				errIngestFileNotFound,
+				file.Shard.ID,
+				file.ID,
+				p.ContainerID,
+				received,
+				size)
+		}
+	}
+	return size, nil
+}
+
+// checkIngestFile proposes the ingest after all the replicas are checked holding the ingest file
+// in the ingest worker, otherwise the ingest is rejected, the replica missing the file would
+// diverge from the others once the ingest applied. The bytes of the file are limited by the
+// rate limits of the group. The ingest with a stale epoch is proposed without the check, it's
+// skipped by all the replicas.
+func (pr *peerReplica) checkIngestFile(req reqCtx) {
+	req.ingestChecked = true
+	shard := pr.ps.shard
+	ingest := req.admin.Ingest
+	if ingest.Epoch.Version != shard.Epoch.Version ||
+		ingest.Epoch.ConfVer != shard.Epoch.ConfVer {
+		pr.batch.push(shard.Group, req)
+		return
+	}
+
+	file := IngestFile{ID: ingest.ID, Shard: shard}
+	err := pr.store.addIngestJob(func() error {
+		size, err := pr.store.checkIngestFile(file)
+		if err != nil {
+			logger.Errorf("shard %d reject ingest %d, check file failed with %+v",
+				shard.ID,
+				file.ID,
+				err)
+			req.cb(newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_Ingest,
+				&raftcmdpb.IngestResponse{Error: err.Error()}))
+			return nil
+		}
+		if !pr.store.limiter.allowIngest(shard.Group, size) {
+			respServerIsBusy(nil, req.cb)
+			return nil
+		}
+
+		if err := pr.addRequest(req); err != nil {
+			req.cb(errorOtherCMDResp(err))
+		}
+		return nil
+	})
+	if err != nil {
+		req.cb(errorOtherCMDResp(err))
+	}
+}
+
+// receivedSnapBytes returns the bytes of the snapshot file received, 0 if the snapshot manager
+// doesn't count the bytes.
+func (s *store) receivedSnapBytes(msg *bhraftpb.SnapshotMessage) uint64 {
+	if prober, ok := s.snapshotManager.(snapshot.SnapshotProber); ok {
+		return prober.ReceivedSnapBytes(msg)
+	}
+	return 0
+}
+
+func isIngest(admin *raftcmdpb.AdminRequest) bool {
+	return admin != nil && admin.CmdType == raftcmdpb.AdminCmdType_Ingest
+}
+
+// ingestIterator splits the key-value pairs of the bulk load by the shards
+type ingestIterator struct {
+	next   func() ([]byte, []byte, bool)
+	key    []byte
+	value  []byte
+	last   []byte
+	loaded bool
+	err    error
+}
+
+// peek loads the next key-value pair if the current one is consumed, returns false if there
+// is no more key-value pairs or the keys are not in ascending order.
+func (it *ingestIterator) peek() bool {
+	if it.loaded || it.err != nil {
+		return it.loaded
+	}
+
+	key, value, ok := it.next()
+	if !ok {
+		return false
+	}
+	if it.last != nil && bytes.Compare(key, it.last) <= 0 {
+		it.err = errUnsortedIngestKeys
+		return false
+	}
+
+	it.key = key
+	it.value = value
+	it.loaded = true
+	return true
+}
+
+// nextInShard returns the func to consume the key-value pairs in the shard, the keys are
+// encoded to the data keys.
+func (it *ingestIterator) nextInShard(shard bhmetapb.Shard) func() ([]byte, []byte, bool) {
+	return func() ([]byte, []byte, bool) {
+		if !it.peek() ||
+			(len(shard.End) > 0 && bytes.Compare(it.key, shard.End) >= 0) {
+			return nil, nil, false
+		}
+
+		it.loaded = false
+		it.last = it.key
+		return EncodeDataKey(shard.Group, it.key), it.value, true
+	}
+}
+
+// adminRespCB returns the cb of the admin request sent by the client, the admin response is
+// returned as the value of the response.
+func adminRespCB(req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDResponse)) func(*raftcmdpb.RaftCMDResponse) {
+	return func(resp *raftcmdpb.RaftCMDResponse) {
+		rsp := pb.AcquireResponse()
+		rsp.ID = req.ID
+		rsp.SID = req.SID
+		rsp.PID = req.PID
+		if resp.Header != nil {
+			rsp.OriginRequest = req
+		} else {
+			rsp.Type = raftcmdpb.CMDType_Admin
+			rsp.Value = protoc.MustMarshal(resp.AdminResponse)
+		}
+
+		resp.Responses = append(resp.Responses, rsp)
+		cb(resp)
+	}
+}
@@ -0,0 +1,492 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+const (
+	changeEventBufferSize = 1024
+	changeReplayMaxBytes  = 4 * 1024 * 1024
+)
+
+var (
+	// ErrLogCompacted the raft log to resume the change events from is compacted
+	ErrLogCompacted = errors.New("raft log compacted")
+	// ErrShardRemoved the subscribed shard is removed from the store
+	ErrShardRemoved = errors.New("shard removed from the store")
+	// ErrSubscriptionClosed the subscription is closed by the subscriber or the store is stopped
+	ErrSubscriptionClosed = errors.New("subscription closed")
+)
+
+// ChangeEventType the type of the change event
+type ChangeEventType int
+
+const (
+	// WriteEvent a write request is applied to the shard
+	WriteEvent = ChangeEventType(0)
+	// SplitEvent the shard is split, the subscription follows the new shards from their
+	// first raft log entry
+	SplitEvent = ChangeEventType(1)
+)
+
+// ChangeEvent is a change applied to the shard. The events of a shard are ordered by the raft
+// log index, the write requests in the same raft entry have the same index.
+type ChangeEvent struct {
+	Type    ChangeEventType
+	ShardID uint64
+	Index   uint64
+	// CustemType, Key and Cmd of the applied write request, the key is the original key
+	CustemType uint64
+	Key        []byte
+	Cmd        []byte
+	// Shards the new shards created by the split
+	Shards []bhmetapb.Shard
+}
+
+// ChangeSubscription receives the change events of the subscribed shard and the shards split
+// from it. The replicas apply the same raft log, so the events are not affected by the leader
+// change. The subscriber should record the last index of each shard, and resubscribe from the
+// next index on the other stores if the subscription is closed by ErrShardRemoved.
+type ChangeSubscription struct {
+	hub    *cdcHub
+	c      chan ChangeEvent
+	stopC  chan struct{}
+	closed bool
+	err    error
+	shards []uint64
+}
+
+// Events returns the channel of the change events
+func (sub *ChangeSubscription) Events() <-chan ChangeEvent {
+	return sub.c
+}
+
+// Done returns a channel which is closed when the subscription is closed
+func (sub *ChangeSubscription) Done() <-chan struct{} {
+	return sub.stopC
+}
+
+// Err returns the reason why the subscription is closed
+func (sub *ChangeSubscription) Err() error {
+	sub.hub.Lock()
+	defer sub.hub.Unlock()
+	return sub.err
+}
+
+// Close closes the subscription
+func (sub *ChangeSubscription) Close() {
+	sub.hub.Lock()
+	defer sub.hub.Unlock()
+	sub.hub.closeLocked(sub, ErrSubscriptionClosed)
+}
+
+func (s *store) SubscribeChanges(shardID uint64, fromIndex uint64) (*ChangeSubscription, error) {
+	pr := s.getPR(shardID, false)
+	if pr == nil {
+		return nil, errShardNotFound
+	}
+	// the witness does not apply the write requests
+	if pr.isWitness() {
+		return nil, errWitnessRead
+	}
+
+	return s.cdc.subscribe(shardID, fromIndex)
+}
+
+// cdcHub publishes the applied changes to the subscriptions. A cursor of the subscription
+// receives the events from the apply delegate if it's live, otherwise it replays the events
+// from the raft log until it catches up the applied index. The live cursor falls back to
+// replay if the subscriber is too slow to receive the events.
+type cdcHub struct {
+	sync.Mutex
+
+	store   *store
+	cursors int64
+	shards  map[uint64]*cdcShard
+}
+
+type cdcShard struct {
+	applied uint64
+	cursors []*cdcCursor
+}
+
+type cdcCursor struct {
+	sub     *ChangeSubscription
+	shardID uint64
+	next    uint64
+	// offset the number of the events at the next index already received
+	offset int
+	live   bool
+}
+
+func newCDCHub(s *store) *cdcHub {
+	return &cdcHub{
+		store:  s,
+		shards: make(map[uint64]*cdcShard),
+	}
+}
+
+func (h *cdcHub) active() bool {
+	return atomic.LoadInt64(&h.cursors) > 0
+}
+
+func (h *cdcHub) subscribe(shardID uint64, fromIndex uint64) (*ChangeSubscription, error) {
+	if fromIndex > 0 {
+		if _, err := h.store.RaftLogStorage().Term(shardID, fromIndex); err == raft.ErrUnavailable {
+			applied, err := h.loadAppliedIndex(shardID)
+			if err != nil {
+				return nil, err
+			}
+			if fromIndex <= applied {
+				return nil, ErrLogCompacted
+			}
+		}
+	}
+
+	sub := &ChangeSubscription{
+		hub:   h,
+		c:     make(chan ChangeEvent, changeEventBufferSize),
+		stopC: make(chan struct{}),
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	if err := h.attachLocked(sub, shardID, fromIndex); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// attachLocked adds a cursor of the shard to the subscription, the cursor starts from the next
+// applied entry if fromIndex is 0.
+func (h *cdcHub) attachLocked(sub *ChangeSubscription, shardID uint64, fromIndex uint64) error {
+	if sub.closed {
+		return sub.err
+	}
+	for _, id := range sub.shards {
+		if id == shardID {
+			return nil
+		}
+	}
+
+	st, ok := h.shards[shardID]
+	if !ok {
+		// the apply delegate persists the apply state before publishing, the entries applied
+		// after the state loaded are published to the shard.
+		applied, err := h.loadAppliedIndex(shardID)
+		if err != nil {
+			return err
+		}
+		st = &cdcShard{applied: applied}
+		h.shards[shardID] = st
+	}
+
+	if fromIndex == 0 {
+		fromIndex = st.applied + 1
+	}
+	c := &cdcCursor{
+		sub:     sub,
+		shardID: shardID,
+		next:    fromIndex,
+		live:    fromIndex > st.applied,
+	}
+	st.cursors = append(st.cursors, c)
+	sub.shards = append(sub.shards, shardID)
+	atomic.AddInt64(&h.cursors, 1)
+
+	if !c.live {
+		go h.replay(c)
+	}
+	return nil
+}
+
+// publish publishes the changes of the applied entry to the live cursors of the shard
+func (h *cdcHub) publish(shardID uint64, index uint64, events []ChangeEvent, result *execResult) {
+	if !h.active() {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	st, ok := h.shards[shardID]
+	if !ok {
+		return
+	}
+	st.applied = index
+
+	if result != nil && result.splitResult != nil {
+		events = append(events, ChangeEvent{
+			Type:    SplitEvent,
+			ShardID: shardID,
+			Index:   index,
+			Shards:  result.splitResult.shards,
+		})
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	for _, c := range st.cursors {
+		if !c.live || c.next > index {
+			continue
+		}
+
+		for _, e := range events[c.offset:] {
+			select {
+			case c.sub.c <- e:
+				c.offset++
+				continue
+			default:
+			}
+
+			// the subscriber is too slow, replay the rest events from the raft log
+			c.live = false
+			go h.replay(c)
+			break
+		}
+
+		if c.live {
+			c.next = index + 1
+			c.offset = 0
+			if result != nil && result.splitResult != nil {
+				h.followSplitLocked(c.sub, result.splitResult.shards)
+			}
+		}
+	}
+}
+
+// replay sends the events of the cursor from the raft log until the cursor catches up the
+// applied index of the shard.
+func (h *cdcHub) replay(c *cdcCursor) {
+	for {
+		h.Lock()
+		if c.sub.closed {
+			h.Unlock()
+			return
+		}
+		applied := h.shards[c.shardID].applied
+		if c.next > applied {
+			c.live = true
+			h.Unlock()
+			return
+		}
+		next := c.next
+		h.Unlock()
+
+		entries, err := h.store.RaftLogStorage().Entries(c.shardID, next, applied+1, changeReplayMaxBytes)
+		if err != nil {
+			if err == raft.ErrUnavailable {
+				err = ErrLogCompacted
+			}
+			h.Lock()
+			h.closeLocked(c.sub, err)
+			h.Unlock()
+			return
+		}
+
+		for _, entry := range entries {
+			events, err := h.decode(c, &entry)
+			if err != nil {
+				h.Lock()
+				h.closeLocked(c.sub, err)
+				h.Unlock()
+				return
+			}
+
+			h.Lock()
+			offset := c.offset
+			h.Unlock()
+			for _, e := range events[offset:] {
+				select {
+				case c.sub.c <- e:
+				case <-c.sub.stopC:
+					return
+				}
+			}
+
+			h.Lock()
+			c.next = entry.Index + 1
+			c.offset = 0
+			for _, e := range events {
+				if e.Type == SplitEvent {
+					h.followSplitLocked(c.sub, e.Shards)
+				}
+			}
+			h.Unlock()
+		}
+	}
+}
+
+// decode returns the events of the entry as the apply delegate does, the entries rejected by
+// the apply delegate are marked in the metadata storage with the apply state.
+func (h *cdcHub) decode(c *cdcCursor, entry *raftpb.Entry) ([]ChangeEvent, error) {
+	if entry.Type != raftpb.EntryNormal || len(entry.Data) == 0 {
+		return nil, nil
+	}
+
+	skipped, err := h.store.MetadataStorage().Get(getSkippedKey(c.shardID, entry.Index))
+	if err != nil {
+		logger.Errorf("shard %d load the skipped entry %d failed with %+v",
+			c.shardID,
+			entry.Index,
+			err)
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		return nil, nil
+	}
+
+	req := &raftcmdpb.RaftCMDRequest{}
+	protoc.MustUnmarshal(req, entry.Data)
+	if req.Header == nil {
+		return nil, nil
+	}
+
+	if req.AdminRequest != nil {
+		if req.AdminRequest.CmdType != raftcmdpb.AdminCmdType_BatchSplit {
+			return nil, nil
+		}
+
+		// the new shards are created on the store if the split succeeded
+		var shards []bhmetapb.Shard
+		for _, split := range req.AdminRequest.Splits.Requests {
+			state, err := h.loadShardLocalState(split.NewShardID)
+			if err != nil {
+				return nil, err
+			}
+			if state == nil {
+				return nil, nil
+			}
+			shards = append(shards, state.Shard)
+		}
+
+		return []ChangeEvent{{
+			Type:    SplitEvent,
+			ShardID: c.shardID,
+			Index:   entry.Index,
+			Shards:  shards,
+		}}, nil
+	}
+
+	events := make([]ChangeEvent, 0, len(req.Requests))
+	for _, r := range req.Requests {
+		events = append(events, ChangeEvent{
+			Type:       WriteEvent,
+			ShardID:    c.shardID,
+			Index:      entry.Index,
+			CustemType: r.CustemType,
+			Key:        DecodeDataKey(r.Key),
+			Cmd:        r.Cmd,
+		})
+	}
+	return events, nil
+}
+
+func (h *cdcHub) followSplitLocked(sub *ChangeSubscription, shards []bhmetapb.Shard) {
+	for _, shard := range shards {
+		if err := h.attachLocked(sub, shard.ID, raftInitLogIndex+1); err != nil {
+			logger.Errorf("shard %d follow the change events failed with %+v",
+				shard.ID,
+				err)
+		}
+	}
+}
+
+// remove closes the subscriptions of the shard removed from the store
+func (h *cdcHub) remove(shardID uint64) {
+	if !h.active() {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	if st, ok := h.shards[shardID]; ok {
+		for _, c := range append([]*cdcCursor(nil), st.cursors...) {
+			h.closeLocked(c.sub, ErrShardRemoved)
+		}
+	}
+}
+
+func (h *cdcHub) closeAll() {
+	h.Lock()
+	defer h.Unlock()
+	for _, st := range h.shards {
+		for _, c := range append([]*cdcCursor(nil), st.cursors...) {
+			h.closeLocked(c.sub, ErrSubscriptionClosed)
+		}
+	}
+}
+
+func (h *cdcHub) closeLocked(sub *ChangeSubscription, err error) {
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	sub.err = err
+	close(sub.stopC)
+
+	for _, id := range sub.shards {
+		st, ok := h.shards[id]
+		if !ok {
+			continue
+		}
+
+		cursors := st.cursors[:0]
+		for _, c := range st.cursors {
+			if c.sub != sub {
+				cursors = append(cursors, c)
+			}
+		}
+		st.cursors = cursors
+		atomic.AddInt64(&h.cursors, -1)
+		if len(st.cursors) == 0 {
+			delete(h.shards, id)
+		}
+	}
+}
+
+func (h *cdcHub) loadAppliedIndex(shardID uint64) (uint64, error) {
+	value, err := h.store.MetadataStorage().Get(getRaftApplyStateKey(shardID))
+	if err != nil || len(value) == 0 {
+		return 0, err
+	}
+
+	state := bhraftpb.RaftApplyState{}
+	protoc.MustUnmarshal(&state, value)
+	return state.AppliedIndex, nil
+}
+
+func (h *cdcHub) loadShardLocalState(shardID uint64) (*bhraftpb.ShardLocalState, error) {
+	value, err := h.store.MetadataStorage().Get(getShardLocalStateKey(shardID))
+	if err != nil || len(value) == 0 {
+		return nil, err
+	}
+
+	state := &bhraftpb.ShardLocalState{}
+	protoc.MustUnmarshal(state, value)
+	return state, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeChanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewSingleTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, kv.Set(fmt.Sprintf("key-%d", i), "value", testWaitTimeout))
+	}
+
+	s := c.GetStore(0)
+	shard := c.GetShardByIndex(0, 0)
+	_, err := s.SubscribeChanges(shard.ID+1000, 0)
+	assert.Equal(t, errShardNotFound, err)
+
+	// the applied changes are replayed from the raft log
+	sub, err := s.SubscribeChanges(shard.ID, raftInitLogIndex+1)
+	assert.NoError(t, err)
+	var last uint64
+	for i := 0; i < 3; i++ {
+		e := waitChangeEvent(t, sub)
+		assert.Equal(t, WriteEvent, e.Type)
+		assert.Equal(t, shard.ID, e.ShardID)
+		assert.Equal(t, fmt.Sprintf("key-%d", i), string(e.Key))
+		assert.Equal(t, "value", string(e.Cmd))
+		assert.True(t, e.Index > last)
+		last = e.Index
+	}
+
+	// the new changes are published after the replay
+	live, err := s.SubscribeChanges(shard.ID, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, kv.Set("key-3", "value", testWaitTimeout))
+	e := waitChangeEvent(t, sub)
+	assert.Equal(t, "key-3", string(e.Key))
+	assert.True(t, e.Index > last)
+	assert.Equal(t, e, waitChangeEvent(t, live))
+
+	sub.Close()
+	<-sub.Done()
+	assert.Equal(t, ErrSubscriptionClosed, sub.Err())
+	live.Close()
+}
+
+func TestSubscribeChangesSkipRejected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewSingleTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, kv.Set(fmt.Sprintf("key-%d", i), "value", testWaitTimeout))
+	}
+
+	s := c.GetStore(0)
+	shard := c.GetShardByIndex(0, 0)
+	sub, err := s.SubscribeChanges(shard.ID, raftInitLogIndex+1)
+	assert.NoError(t, err)
+	var indexes []uint64
+	for i := 0; i < 3; i++ {
+		indexes = append(indexes, waitChangeEvent(t, sub).Index)
+	}
+	sub.Close()
+
+	// the entry rejected by the apply is not replayed
+	assert.NoError(t, s.MetadataStorage().Set(getSkippedKey(shard.ID, indexes[1]), skippedValue))
+	sub, err = s.SubscribeChanges(shard.ID, raftInitLogIndex+1)
+	assert.NoError(t, err)
+	defer sub.Close()
+	assert.Equal(t, "key-0", string(waitChangeEvent(t, sub).Key))
+	assert.Equal(t, "key-2", string(waitChangeEvent(t, sub).Key))
+}
+
+func TestSubscribeChangesFollowSplit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewSingleTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Replication.ShardCapacityBytes = typeutil.ByteSize(20)
+		cfg.Replication.ShardSplitCheckBytes = typeutil.ByteSize(10)
+	}))
+	defer c.Stop()
+
+	c.Start()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+
+	shard := c.GetShardByIndex(0, 0)
+	sub, err := c.GetStore(0).SubscribeChanges(shard.ID, 0)
+	assert.NoError(t, err)
+	defer sub.Close()
+
+	c.Set(0, EncodeDataKey(0, []byte("key1")), []byte("value11"))
+	c.Set(0, EncodeDataKey(0, []byte("key2")), []byte("value22"))
+	c.Set(0, EncodeDataKey(0, []byte("key3")), []byte("value33"))
+	c.WaitShardByCountPerNode(3, testWaitTimeout)
+
+	e := waitChangeEvent(t, sub)
+	assert.Equal(t, SplitEvent, e.Type)
+	assert.Equal(t, shard.ID, e.ShardID)
+	assert.Equal(t, 2, len(e.Shards))
+
+	// the subscription follows the new shards
+	hub := c.GetStore(0).(*store).cdc
+	hub.Lock()
+	for _, shard := range e.Shards {
+		_, ok := hub.shards[shard.ID]
+		assert.True(t, ok)
+	}
+	hub.Unlock()
+}
+
+func waitChangeEvent(t *testing.T, sub *ChangeSubscription) ChangeEvent {
+	select {
+	case e := <-sub.Events():
+		return e
+	case <-sub.Done():
+		assert.FailNowf(t, "", "subscription closed with %+v", sub.Err())
+	case <-time.After(testWaitTimeout):
+		assert.FailNow(t, "wait change event timeout")
+	}
+	return ChangeEvent{}
+}
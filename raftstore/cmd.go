@@ -14,6 +14,8 @@
 package raftstore
 
 import (
+	"time"
+
 	"github.com/fagongzi/util/uuid"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb"
@@ -25,9 +27,13 @@ type cmd struct {
 	req                     *raftcmdpb.RaftCMDRequest
 	cb                      func(*raftcmdpb.RaftCMDResponse)
 	readIndexCommittedIndex uint64
-	term                    uint64
-	tp                      int
-	size                    int
+	// readIndexAt the time of the ReadIndex issued
+	readIndexAt time.Time
+	// followerRead the ReadIndex is issued by a follower
+	followerRead bool
+	term         uint64
+	tp           int
+	size         int
 }
 
 func (c *cmd) isFull(n, max int) bool {
@@ -35,7 +41,10 @@ func (c *cmd) isFull(n, max int) bool {
 }
 
 func (c *cmd) canAppend(req *raftcmdpb.Request) bool {
-	return c.req.Header.IgnoreEpochCheck == req.IgnoreEpochCheck
+	return c.req.Header.IgnoreEpochCheck == req.IgnoreEpochCheck &&
+		(len(c.req.Requests) == 0 ||
+			(c.req.Requests[0].AllowFollower == req.AllowFollower &&
+				c.req.Requests[0].FollowerReadIndex == req.FollowerReadIndex))
 }
 
 func newCMD(req *raftcmdpb.RaftCMDRequest, cb func(*raftcmdpb.RaftCMDResponse), tp int, size int) cmd {
@@ -72,10 +81,34 @@ func respWithRetry(req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDResponse))
 }
 
 func respStoreNotMatch(err error, req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDResponse)) {
-	rsp := errorPbResp(&errorpb.Error{
+	respError(&errorpb.Error{
 		Message:       err.Error(),
 		StoreNotMatch: storeNotMatch,
-	}, uuid.NewV4().Bytes(), 0)
+	}, req, cb)
+}
+
+func respServerIsBusy(req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDResponse)) {
+	respError(&errorpb.Error{
+		Message:      errServerIsBusy.Error(),
+		ServerIsBusy: serverIsBusy,
+	}, req, cb)
+}
+
+func respWriteFrozen(group uint64, req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDResponse)) {
+	respError(&errorpb.Error{
+		Message:     errWriteFrozen.Error(),
+		WriteFrozen: &errorpb.WriteFrozen{Group: group},
+	}, req, cb)
+}
+
+// respError responds the request with the error before proposing, the admin requests have no
+// request, the response is built by the callback.
+func respError(err *errorpb.Error, req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDResponse)) {
+	rsp := errorPbResp(err, uuid.NewV4().Bytes(), 0)
+	if req == nil {
+		cb(rsp)
+		return
+	}
 
 	resp := pb.AcquireResponse()
 	resp.ID = req.ID
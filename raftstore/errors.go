@@ -32,9 +32,14 @@ var (
 	errLargeRaftEntrySize = errors.New("raft entry is too large")
 	errKeyNotInShard      = errors.New("key not in shard")
 	errStoreNotMatch      = errors.New("store not match")
+	errIngestNotSupported = errors.New("data storage can not ingest files")
+	errServerIsBusy       = errors.New("server is busy")
+	errWriteFrozen        = errors.New("group write is frozen")
+	errWitnessRead        = errors.New("witness can not serve read")
 
 	infoStaleCMD  = new(errorpb.StaleCommand)
 	storeNotMatch = new(errorpb.StoreNotMatch)
+	serverIsBusy  = new(errorpb.ServerIsBusy)
 )
 
 func buildTerm(term uint64, resp *raftcmdpb.RaftCMDResponse) {
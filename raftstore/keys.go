@@ -31,8 +31,12 @@ const (
 	raftLogSuffix    = 0x01
 	raftStateSuffix  = 0x02
 	applyStateSuffix = 0x03
+	// skippedSuffix marks the raft log entry rejected by the apply, e.g. the epoch is stale
+	skippedSuffix = 0x04
 )
 
+var skippedValue = []byte{0x01}
+
 // local is in (0x01, 0x02);
 var (
 	localPrefix byte = 0x01
@@ -81,6 +85,11 @@ func GetMinKey() []byte {
 	return minKey
 }
 
+// GetDataKeyRange return the key range [start, end) of all the data keys
+func GetDataKeyRange() ([]byte, []byte) {
+	return []byte{dataPrefix}, []byte{dataPrefix + 1}
+}
+
 func decodeMetaKey(key []byte) (uint64, byte, error) {
 	prefixLen := len(metaPrefixKey)
 	keyLen := len(key)
@@ -143,6 +152,14 @@ func getRaftLogKey(shardID uint64, logIndex uint64) []byte {
 	return getIDKey(shardID, raftLogSuffix, 8, logIndex)
 }
 
+func getRaftLogPrefix(shardID uint64) []byte {
+	return getIDKey(shardID, raftLogSuffix, 0, 0)
+}
+
+func getSkippedKey(shardID uint64, logIndex uint64) []byte {
+	return getIDKey(shardID, skippedSuffix, 8, logIndex)
+}
+
 func getRaftLogIndex(key []byte) (uint64, error) {
 	expectKeyLen := len(raftPrefixKey) + 8*2 + 1
 	if len(key) != expectKeyLen {
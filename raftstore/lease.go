@@ -0,0 +1,126 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"time"
+
+	"go.etcd.io/etcd/raft/v3/quorum"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// leaderLease the lease of the leader. With check quorum enabled, a follower rejects the
+// votes within the election timeout after it received the heartbeat from the leader, so no
+// new leader can be elected before the send time of the heartbeat acked by a quorum plus
+// the election timeout. The leader serves the reads locally within the lease.
+type leaderLease struct {
+	duration time.Duration
+	// pending peer id -> the send time of the earliest heartbeat not acked yet
+	pending map[uint64]time.Time
+	// acked peer id -> the send time of the heartbeat acked by the peer
+	acked map[uint64]time.Time
+}
+
+// newLeaderLease returns the leader lease, the lease is the election timeout minus one tick for
+// the tick phase of the followers and the max clock drift between the leader and the followers.
+func newLeaderLease(electionTimeoutTicks int, tickInterval time.Duration, maxClockDrift time.Duration) *leaderLease {
+	return &leaderLease{
+		duration: time.Duration(electionTimeoutTicks-1)*tickInterval - maxClockDrift,
+		pending:  make(map[uint64]time.Time),
+		acked:    make(map[uint64]time.Time),
+	}
+}
+
+func (l *leaderLease) reset() {
+	for id := range l.pending {
+		delete(l.pending, id)
+	}
+	for id := range l.acked {
+		delete(l.acked, id)
+	}
+}
+
+func (l *leaderLease) onSend(msg raftpb.Message, now time.Time) {
+	if msg.Type != raftpb.MsgHeartbeat {
+		return
+	}
+
+	// the response may ack any heartbeat sent before, keep the earliest one
+	if _, ok := l.pending[msg.To]; !ok {
+		l.pending[msg.To] = now
+	}
+}
+
+func (l *leaderLease) onResp(msg raftpb.Message) {
+	if msg.Type != raftpb.MsgHeartbeatResp {
+		return
+	}
+
+	if sendAt, ok := l.pending[msg.From]; ok {
+		l.acked[msg.From] = sendAt
+		delete(l.pending, msg.From)
+	}
+}
+
+// inLease returns true if the lease derived from the heartbeats acked by the quorum of the
+// voters is valid at now. The send times keep the monotonic clock reading, so the lease is
+// not affected by the wall clock changes.
+func (l *leaderLease) inLease(voters quorum.JointConfig, self uint64, now time.Time) bool {
+	if l.duration <= 0 {
+		return false
+	}
+
+	start, ok := l.quorumAcked(voters[0], self, now)
+	if !ok {
+		return false
+	}
+	// the outgoing voters of the joint config need the quorum too
+	if len(voters[1]) > 0 {
+		outgoing, ok := l.quorumAcked(voters[1], self, now)
+		if !ok {
+			return false
+		}
+		if outgoing.Before(start) {
+			start = outgoing
+		}
+	}
+
+	return now.Before(start.Add(l.duration))
+}
+
+// quorumAcked returns the latest send time of the heartbeats acked by the quorum of the
+// voters, the leader itself acks at now.
+func (l *leaderLease) quorumAcked(voters quorum.MajorityConfig, self uint64, now time.Time) (time.Time, bool) {
+	if len(voters) == 0 {
+		return now, true
+	}
+
+	var acked []time.Time
+	for id := range voters {
+		if id == self {
+			acked = append(acked, now)
+		} else if sendAt, ok := l.acked[id]; ok {
+			acked = append(acked, sendAt)
+		}
+	}
+
+	quorum := len(voters)/2 + 1
+	if len(acked) < quorum {
+		return time.Time{}, false
+	}
+
+	sort.Slice(acked, func(i, j int) bool { return acked[i].After(acked[j]) })
+	return acked[quorum-1], true
+}
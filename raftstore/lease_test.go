@@ -0,0 +1,67 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft/v3/quorum"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestLeaderLease(t *testing.T) {
+	l := newLeaderLease(10, time.Second, time.Second)
+	voters := quorum.JointConfig{quorum.MajorityConfig{1: {}, 2: {}, 3: {}}}
+	now := time.Now()
+
+	assert.False(t, l.inLease(voters, 1, now))
+
+	// the response acks the earliest heartbeat
+	l.onSend(raftpb.Message{Type: raftpb.MsgHeartbeat, To: 2}, now)
+	l.onSend(raftpb.Message{Type: raftpb.MsgHeartbeat, To: 2}, now.Add(time.Second))
+	l.onResp(raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: 2})
+	assert.True(t, l.inLease(voters, 1, now.Add(time.Second*7)))
+	assert.False(t, l.inLease(voters, 1, now.Add(time.Second*8)))
+
+	// the response without pending heartbeat is ignored
+	l.onResp(raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: 3})
+	assert.False(t, l.inLease(voters, 1, now.Add(time.Second*8)))
+
+	l.onSend(raftpb.Message{Type: raftpb.MsgHeartbeat, To: 3}, now.Add(time.Second*2))
+	l.onResp(raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: 3})
+	assert.True(t, l.inLease(voters, 1, now.Add(time.Second*9)))
+
+	// both the incoming and outgoing voters need the quorum
+	joint := quorum.JointConfig{voters[0], quorum.MajorityConfig{1: {}, 4: {}, 5: {}}}
+	assert.False(t, l.inLease(joint, 1, now))
+
+	// the leader is the only voter
+	assert.True(t, l.inLease(quorum.JointConfig{quorum.MajorityConfig{1: {}}}, 1, now))
+
+	l.reset()
+	assert.False(t, l.inLease(voters, 1, now))
+}
+
+func TestLeaderLeaseWithClockDrift(t *testing.T) {
+	l := newLeaderLease(10, time.Second, time.Second*3)
+	voters := quorum.JointConfig{quorum.MajorityConfig{1: {}, 2: {}, 3: {}}}
+	now := time.Now()
+
+	l.onSend(raftpb.Message{Type: raftpb.MsgHeartbeat, To: 2}, now)
+	l.onResp(raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: 2})
+	assert.True(t, l.inLease(voters, 1, now.Add(time.Second*5)))
+	assert.False(t, l.inLease(voters, 1, now.Add(time.Second*6)))
+}
@@ -125,11 +125,15 @@ func (m *raftMessageMetrics) flush() {
 }
 
 type raftProposeMetrics struct {
-	readLocal      uint64
-	readIndex      uint64
-	normal         uint64
-	transferLeader uint64
-	confChange     uint64
+	readLocal   uint64
+	readIndex   uint64
+	readLearner uint64
+	readLease   uint64
+	// followerReadIndex the ReadIndex issued by the follower
+	followerReadIndex uint64
+	normal            uint64
+	transferLeader    uint64
+	confChange        uint64
 }
 
 func (m *raftProposeMetrics) flush() {
@@ -143,6 +147,21 @@ func (m *raftProposeMetrics) flush() {
 		m.readIndex = 0
 	}
 
+	if m.readLearner > 0 {
+		metric.AddRaftProposalReadLearnerCount(m.readLearner)
+		m.readLearner = 0
+	}
+
+	if m.readLease > 0 {
+		metric.AddRaftProposalReadLeaseCount(m.readLease)
+		m.readLease = 0
+	}
+
+	if m.followerReadIndex > 0 {
+		metric.AddRaftProposalFollowerReadIndexCount(m.followerReadIndex)
+		m.followerReadIndex = 0
+	}
+
 	if m.normal > 0 {
 		metric.AddRaftProposalNormalCount(m.normal)
 		m.normal = 0
@@ -160,17 +179,23 @@ func (m *raftProposeMetrics) flush() {
 }
 
 type raftAdminMetrics struct {
-	confChange uint64
-	split      uint64
-	compact    uint64
+	confChange  uint64
+	split       uint64
+	merge       uint64
+	compact     uint64
+	consistency uint64
 
 	confChangeReject uint64
 
-	confChangeSucceed uint64
-	addPeerSucceed    uint64
-	removePeerSucceed uint64
-	splitSucceed      uint64
-	compactSucceed    uint64
+	confChangeSucceed  uint64
+	addPeerSucceed     uint64
+	removePeerSucceed  uint64
+	splitSucceed       uint64
+	mergeSucceed       uint64
+	mergeRollback      uint64
+	compactSucceed     uint64
+	consistencySucceed uint64
+	consistencyFailed  uint64
 }
 
 func (m *raftAdminMetrics) incBy(by raftAdminMetrics) {
@@ -181,8 +206,14 @@ func (m *raftAdminMetrics) incBy(by raftAdminMetrics) {
 	m.removePeerSucceed += by.removePeerSucceed
 	m.split += by.split
 	m.splitSucceed += by.splitSucceed
+	m.merge += by.merge
+	m.mergeSucceed += by.mergeSucceed
+	m.mergeRollback += by.mergeRollback
 	m.compact += by.compact
 	m.compactSucceed += by.compactSucceed
+	m.consistency += by.consistency
+	m.consistencySucceed += by.consistencySucceed
+	m.consistencyFailed += by.consistencyFailed
 }
 
 func (m *raftAdminMetrics) flush() {
@@ -208,6 +239,19 @@ func (m *raftAdminMetrics) flush() {
 		m.splitSucceed = 0
 	}
 
+	if m.merge > 0 {
+		metric.AddRaftAdminCommandMergeCount(m.merge)
+		m.merge = 0
+	}
+	if m.mergeSucceed > 0 {
+		metric.AddRaftAdminCommandMergeSucceedCount(m.mergeSucceed)
+		m.mergeSucceed = 0
+	}
+	if m.mergeRollback > 0 {
+		metric.AddRaftAdminCommandMergeRollbackCount(m.mergeRollback)
+		m.mergeRollback = 0
+	}
+
 	if m.compact > 0 {
 		metric.AddRaftAdminCommandCompactCount(m.compact)
 		m.compact = 0
@@ -216,4 +260,17 @@ func (m *raftAdminMetrics) flush() {
 		metric.AddRaftAdminCommandCompactSucceedCount(m.compactSucceed)
 		m.compactSucceed = 0
 	}
+
+	if m.consistency > 0 {
+		metric.AddRaftAdminCommandConsistencyCheckCount(m.consistency)
+		m.consistency = 0
+	}
+	if m.consistencySucceed > 0 {
+		metric.AddRaftAdminCommandConsistencyCheckSucceedCount(m.consistencySucceed)
+		m.consistencySucceed = 0
+	}
+	if m.consistencyFailed > 0 {
+		metric.AddRaftAdminCommandConsistencyCheckFailedCount(m.consistencyFailed)
+		m.consistencyFailed = 0
+	}
 }
@@ -33,6 +33,7 @@ import (
 )
 
 func (s *store) doDestroy(shardID uint64, tombstone bool, why string) {
+	s.cdc.remove(shardID)
 	if value, ok := s.delegates.Load(shardID); ok {
 		s.delegates.Delete(shardID)
 		delegate := value.(*applyDelegate)
@@ -48,48 +49,33 @@ func (s *store) doDestroy(shardID uint64, tombstone bool, why string) {
 	}
 }
 
-func (pr *peerReplica) doCompactRaftLog(shardID, startIndex, endIndex uint64) error {
-	firstIndex := startIndex
-
-	if firstIndex == 0 {
-		startKey := getRaftLogKey(shardID, 0)
-		firstIndex = endIndex
-		key, _, err := pr.store.MetadataStorage().Seek(startKey)
-		if err != nil {
-			return err
-		}
+func (s *store) doDestroyMerged(shardID uint64, result *mergeResult) {
+	s.cdc.remove(shardID)
+	if value, ok := s.delegates.Load(shardID); ok {
+		s.delegates.Delete(shardID)
+		delegate := value.(*applyDelegate)
+		delegate.destroy()
+	}
 
-		if len(key) > 0 {
-			firstIndex, err = getRaftLogIndex(key)
-			if err != nil {
-				return err
-			}
-		}
+	pr := s.getPR(shardID, false)
+	if pr != nil {
+		pr.mustDestroyMerged(result)
 	}
+}
 
-	if firstIndex >= endIndex {
+func (pr *peerReplica) doCompactRaftLog(shardID, startIndex, endIndex uint64) error {
+	if startIndex > 0 && startIndex >= endIndex {
 		logger.Infof("shard %d no need to gc raft log",
 			shardID)
 		return nil
 	}
 
-	wb := util.NewWriteBatch()
-	for index := firstIndex; index < endIndex; index++ {
-		key := getRaftLogKey(shardID, index)
-		err := wb.Delete(key)
-		if err != nil {
-			return err
-		}
-	}
-
-	err := pr.store.MetadataStorage().Write(wb, false)
-	if err == nil {
-		logger.Debugf("shard %d raft log gc complete, entriesCount=<%d>",
-			shardID,
-			(endIndex - startIndex))
+	err := pr.store.MetadataStorage().RangeDelete(getSkippedKey(shardID, 0), getSkippedKey(shardID, endIndex))
+	if err != nil {
+		return err
 	}
 
-	return err
+	return pr.store.RaftLogStorage().Compact(shardID, endIndex)
 }
 
 func (pr *peerReplica) doApplyingSnapshotJob() error {
@@ -110,12 +96,15 @@ func (pr *peerReplica) doApplyingSnapshotJob() error {
 		return err
 	}
 
-	err = pr.ps.applySnapshot(pr.ps.applySnapJob)
-	if err != nil {
-		logger.Errorf("shard %d apply snap snapshot failed with %+v",
-			pr.shardID,
-			err)
-		return err
+	// the witness only applies the snapshot metadata
+	if !pr.isWitness() {
+		err = pr.ps.applySnapshot(pr.ps.applySnapJob)
+		if err != nil {
+			logger.Errorf("shard %d apply snap snapshot failed with %+v",
+				pr.shardID,
+				err)
+			return err
+		}
 	}
 
 	wb := util.NewWriteBatch()
@@ -156,6 +145,10 @@ func (pr *peerReplica) doApplyCommittedEntries(shardID uint64, term uint64, comm
 
 	delegate := value.(*applyDelegate)
 	delegate.term = term
+	if delegate.isApplyPaused() {
+		delegate.pauseEntries(commitedEntries)
+		return nil
+	}
 	delegate.applyCommittedEntries(commitedEntries)
 
 	if delegate.isPendingRemove() {
@@ -182,8 +175,12 @@ type execResult struct {
 	adminType    raftcmdpb.AdminCmdType
 	changePeer   *changePeer
 	splitResult  *splitResult
+	mergeResult  *mergeResult
+	hashResult   *hashResult
 	raftGCResult *raftGCResult
 	needSyncData bool
+	// ingested is the ingest file which is removed after the apply state is durable
+	ingested *bhraftpb.SnapshotMessage
 }
 
 type changePeer struct {
@@ -198,6 +195,22 @@ type splitResult struct {
 	shards  []bhmetapb.Shard
 }
 
+type mergeResult struct {
+	// shard is the source shard for PrepareMerge and RollbackMerge,
+	// and the target shard for CommitMerge
+	shard      bhmetapb.Shard
+	source     bhmetapb.Shard
+	mergeState *bhraftpb.MergeState
+}
+
+// hashResult is the hash of the shard's data at the index, the hash is computed by compute
+// out of the apply path if it is not set.
+type hashResult struct {
+	index   uint64
+	hash    []byte
+	compute func() ([]byte, error)
+}
+
 type raftGCResult struct {
 	state      bhraftpb.RaftTruncatedState
 	firstIndex uint64
@@ -216,6 +229,9 @@ type applyContext struct {
 	offset     int
 	batchSize  int
 	metrics    applyMetrics
+	changes    []ChangeEvent
+	// proposed is true if the command is proposed by the current replica
+	proposed bool
 }
 
 func newApplyContext(pr *peerReplica) *applyContext {
@@ -241,6 +257,8 @@ func (ctx *applyContext) reset() {
 	ctx.offset = 0
 	ctx.batchSize = 0
 	ctx.metrics = applyMetrics{}
+	ctx.changes = ctx.changes[:0]
+	ctx.proposed = false
 }
 
 func (ctx *applyContext) WriteBatch() *util.WriteBatch {
@@ -288,6 +306,15 @@ type applyDelegate struct {
 	pendingCMDs          []cmd
 	pendingChangePeerCMD cmd
 	ctx                  *applyContext
+	// mergeState is not nil if the shard is merging into other shard
+	mergeState *bhraftpb.MergeState
+	// pausedEntries the committed entries not applied until the merge source shard peer
+	// on another apply worker catches up
+	pausedEntries []raftpb.Entry
+	// caughtUpSource the merge source shard peer caught up during the pause
+	caughtUpSource *mergeResult
+	// catchingUp is not nil if the shard peer is catching up the logs as a merge source
+	catchingUp *mergeCatchUp
 
 	// sync data after exec admin requests.
 	// Before restart we applied index is `100`, If `Customize.CustomAdjustInitAppliedIndexFactory` is set,
@@ -370,6 +397,13 @@ func (d *applyDelegate) notifyShardRemoved(c cmd) {
 }
 
 func (d *applyDelegate) applyCommittedEntries(commitedEntries []raftpb.Entry) {
+	d.applyEntries(commitedEntries, true)
+}
+
+// applyEntries apply the entries, if notify is false, the apply results will not be
+// sent to the peer replica. It's used to catch up the logs of the merge source shard,
+// the raft node of the source shard may not known these logs are committed.
+func (d *applyDelegate) applyEntries(commitedEntries []raftpb.Entry, notify bool) {
 	if len(commitedEntries) <= 0 {
 		return
 	}
@@ -377,7 +411,7 @@ func (d *applyDelegate) applyCommittedEntries(commitedEntries []raftpb.Entry) {
 	start := time.Now()
 	req := pb.AcquireRaftCMDRequest()
 
-	for _, entry := range commitedEntries {
+	for idx, entry := range commitedEntries {
 		if d.isPendingRemove() {
 			// This peer is about to be destroyed, skip everything.
 			break
@@ -401,6 +435,13 @@ func (d *applyDelegate) applyCommittedEntries(commitedEntries []raftpb.Entry) {
 		d.ctx.index = entry.Index
 		d.ctx.term = entry.Term
 
+		if entry.Type == raftpb.EntryNormal && len(entry.Data) > 0 {
+			protoc.MustUnmarshal(req, entry.Data)
+			if d.pauseApplyForMergeSource(req, commitedEntries[idx:]) {
+				break
+			}
+		}
+
 		var result *execResult
 
 		switch entry.Type {
@@ -412,6 +453,8 @@ func (d *applyDelegate) applyCommittedEntries(commitedEntries []raftpb.Entry) {
 			result = d.applyConfChange(&entry)
 		}
 
+		d.store.cdc.publish(d.shard.ID, entry.Index, d.ctx.changes, result)
+
 		asyncResult := asyncApplyResult{}
 		asyncResult.shardID = d.shard.ID
 		asyncResult.appliedIndexTerm = d.appliedIndexTerm
@@ -422,6 +465,10 @@ func (d *applyDelegate) applyCommittedEntries(commitedEntries []raftpb.Entry) {
 			asyncResult.metrics = d.ctx.metrics
 		}
 
+		if !notify {
+			continue
+		}
+
 		pr := d.store.getPR(d.shard.ID, false)
 		if pr != nil {
 			pr.addApplyResult(asyncResult)
@@ -432,11 +479,12 @@ func (d *applyDelegate) applyCommittedEntries(commitedEntries []raftpb.Entry) {
 	pb.ReleaseRaftCMDRequest(req)
 
 	metric.ObserveRaftLogApplyDuration(start)
+	d.store.slowScore.observeApply(start)
 }
 
 func (d *applyDelegate) applyEntry(entry *raftpb.Entry) *execResult {
+	// the request of the entry is unmarshaled by applyEntries
 	if len(entry.Data) > 0 {
-		protoc.MustUnmarshal(d.ctx.req, entry.Data)
 		return d.doApplyRaftCMD()
 	}
 
@@ -505,6 +553,7 @@ func (d *applyDelegate) doApplyRaftCMD() *execResult {
 	}
 
 	c, ok := d.findCB(d.ctx)
+	d.ctx.proposed = ok
 	if d.isPendingRemove() {
 		logger.Fatalf("shard %d apply raft comand can not pending remove",
 			d.shard.ID)
@@ -516,15 +565,20 @@ func (d *applyDelegate) doApplyRaftCMD() *execResult {
 	var writeBytes uint64
 	var diffBytes int64
 
+	skipped := false
 	if !d.checkEpoch(d.ctx.req) {
 		resp = errorStaleEpochResp(d.ctx.req.Header.ID, d.term, d.shard)
+		skipped = true
 	} else {
 		if d.ctx.req.AdminRequest != nil {
 			resp, result, err = d.execAdminRequest(d.ctx)
 			if err != nil {
 				resp = errorStaleEpochResp(d.ctx.req.Header.ID, d.term, d.shard)
+				skipped = true
 			}
-		} else {
+		} else if !d.isWitness() {
+			// the witness only persists the raft log, the write requests are not applied
+			d.ctx.dataWB.TTLStart = d.ctx.req.Header.ProposedAt
 			writeBytes, diffBytes, resp = d.execWriteRequest(d.ctx)
 		}
 	}
@@ -548,6 +602,10 @@ func (d *applyDelegate) doApplyRaftCMD() *execResult {
 	}
 
 	d.ctx.applyState.AppliedIndex = d.ctx.index
+	// the change events replayed from the raft log skip the entries rejected by the apply
+	if skipped {
+		d.ctx.raftWB.Set(getSkippedKey(d.shard.ID, d.ctx.index), skippedValue)
+	}
 	if !d.isPendingRemove() {
 		if sc, ok := d.store.cfg.Test.Shards[d.shard.ID]; !ok || !sc.SkipSaveRaftApplyState {
 			d.ctx.raftWB.Set(getRaftApplyStateKey(d.shard.ID), protoc.MustMarshal(&d.ctx.applyState))
@@ -578,6 +636,15 @@ func (d *applyDelegate) doApplyRaftCMD() *execResult {
 			err)
 	}
 
+	if result != nil && result.ingested != nil {
+		if err := d.store.snapshotManager.CleanSnap(result.ingested); err != nil {
+			logger.Errorf("shard %d clean ingest file %d failed with %+v",
+				d.shard.ID,
+				result.ingested.Header.Index,
+				err)
+		}
+	}
+
 	d.applyState = d.ctx.applyState
 	d.term = d.ctx.term
 
@@ -610,6 +677,15 @@ func (d *applyDelegate) setPendingRemove() {
 	d.pendingRemove = true
 }
 
+func (d *applyDelegate) isWitness() bool {
+	for _, p := range d.shard.Peers {
+		if p.ID == d.peerID {
+			return p.Role == metapb.PeerRole_Witness
+		}
+	}
+	return false
+}
+
 func (d *applyDelegate) isPendingRemove() bool {
 	return d.pendingRemove
 }
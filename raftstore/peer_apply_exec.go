@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/fagongzi/util/collection/deque"
 	"github.com/fagongzi/util/protoc"
@@ -27,10 +28,31 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/storage"
+	"go.etcd.io/etcd/raft/v3/raftpb"
 )
 
 func (d *applyDelegate) execAdminRequest(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
 	cmdType := ctx.req.AdminRequest.CmdType
+	// the witness holds no data, skip the admin requests which read or write the data
+	if d.isWitness() {
+		switch cmdType {
+		case raftcmdpb.AdminCmdType_ComputeHash,
+			raftcmdpb.AdminCmdType_VerifyHash,
+			raftcmdpb.AdminCmdType_Backup:
+			return nil, nil, nil
+		case raftcmdpb.AdminCmdType_Ingest:
+			// the ingest file is never sent to the witness, but the file received as a
+			// voter before it became a witness is removed
+			msg := &bhraftpb.SnapshotMessage{}
+			msg.Header.Shard = d.shard
+			msg.Header.Index = ctx.req.AdminRequest.Ingest.ID
+			d.store.snapshotManager.CleanSnap(msg)
+			return nil, nil, nil
+		}
+	}
+
 	switch cmdType {
 	case raftcmdpb.AdminCmdType_ChangePeer:
 		resp, result, err := d.doExecChangePeer(ctx)
@@ -52,6 +74,28 @@ func (d *applyDelegate) execAdminRequest(ctx *applyContext) (*raftcmdpb.RaftCMDR
 		return resp, result, err
 	case raftcmdpb.AdminCmdType_CompactLog:
 		return d.doExecCompactRaftLog(ctx)
+	case raftcmdpb.AdminCmdType_PrepareMerge:
+		resp, result, err := d.doExecPrepareMerge(ctx)
+		if result != nil {
+			result.needSyncData = true
+		}
+		return resp, result, err
+	case raftcmdpb.AdminCmdType_CommitMerge:
+		resp, result, err := d.doExecCommitMerge(ctx)
+		if result != nil {
+			result.needSyncData = true
+		}
+		return resp, result, err
+	case raftcmdpb.AdminCmdType_RollbackMerge:
+		return d.doExecRollbackMerge(ctx)
+	case raftcmdpb.AdminCmdType_ComputeHash:
+		return d.doExecComputeHash(ctx)
+	case raftcmdpb.AdminCmdType_VerifyHash:
+		return d.doExecVerifyHash(ctx)
+	case raftcmdpb.AdminCmdType_Ingest:
+		return d.doExecIngest(ctx)
+	case raftcmdpb.AdminCmdType_Backup:
+		return d.doExecBackup(ctx)
 	}
 
 	return nil, nil, nil
@@ -217,7 +261,10 @@ func (d *applyDelegate) applyConfChangeByKind(kind confChangeKind, changes []raf
 
 		if exist_peer == nil && change_type == metapb.ChangePeerType_AddNode {
 			if kind == simpleKind {
-				peer.Role = metapb.PeerRole_Voter
+				// the witness is added by the simple conf change with the witness role
+				if peer.Role != metapb.PeerRole_Witness {
+					peer.Role = metapb.PeerRole_Voter
+				}
 			} else if kind == enterJointKind {
 				peer.Role = metapb.PeerRole_IncomingVoter
 			}
@@ -237,6 +284,8 @@ func (d *applyDelegate) applyConfChangeByKind(kind confChangeKind, changes []raf
 
 			// Add peer with different id to the same store
 			if exist_id != incoming_id ||
+				// The witness can not change the role
+				role == metapb.PeerRole_Witness ||
 				// The peer is already the requested role
 				(role == metapb.PeerRole_Voter && change_type == metapb.ChangePeerType_AddNode) ||
 				(role == metapb.PeerRole_Learner && change_type == metapb.ChangePeerType_AddLearnerNode) {
@@ -261,7 +310,8 @@ func (d *applyDelegate) applyConfChangeByKind(kind confChangeKind, changes []raf
 			}
 		} else if exist_peer != nil && change_type == metapb.ChangePeerType_RemoveNode {
 			// Remove node
-			if kind == enterJointKind && exist_peer.Role == metapb.PeerRole_Voter {
+			if kind == enterJointKind &&
+				(exist_peer.Role == metapb.PeerRole_Voter || exist_peer.Role == metapb.PeerRole_Witness) {
 				return res, fmt.Errorf("can't remove voter peer %+v directly",
 					peer)
 			}
@@ -429,6 +479,484 @@ func (d *applyDelegate) doExecSplit(ctx *applyContext) (*raftcmdpb.RaftCMDRespon
 	return rsp, result, nil
 }
 
+func (d *applyDelegate) doExecPrepareMerge(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	ctx.metrics.admin.merge++
+	req := ctx.req.AdminRequest.PrepareMerge
+	logger.Infof("shard %d do apply prepare merge into shard %d at epoch %+v, min index %d",
+		d.shard.ID,
+		req.Target.ID,
+		d.shard.Epoch,
+		req.MinIndex)
+
+	if d.mergeState != nil {
+		return nil, nil, fmt.Errorf("shard %d is already merging into shard %d",
+			d.shard.ID,
+			d.mergeState.Target.ID)
+	}
+
+	err := checkMergeTarget(d.shard, req.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := bhmetapb.Shard{}
+	protoc.MustUnmarshal(&res, protoc.MustMarshal(&d.shard))
+	res.Epoch.Version++
+	res.Epoch.ConfVer++
+
+	state := &bhraftpb.MergeState{
+		MinIndex: req.MinIndex,
+		Commit:   ctx.index,
+		Target:   req.Target,
+	}
+
+	d.shard = res
+	d.mergeState = state
+	d.store.updateMergeState(d.shard, bhraftpb.PeerState_Merging, state, ctx.raftWB)
+
+	rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_PrepareMerge, &raftcmdpb.PrepareMergeResponse{})
+	result := &execResult{
+		adminType: raftcmdpb.AdminCmdType_PrepareMerge,
+		mergeResult: &mergeResult{
+			shard:      d.shard,
+			mergeState: state,
+		},
+	}
+
+	return rsp, result, nil
+}
+
+func (d *applyDelegate) doExecCommitMerge(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	req := ctx.req.AdminRequest.CommitMerge
+	logger.Infof("shard %d do apply commit merge from shard %d at epoch %+v, commit %d",
+		d.shard.ID,
+		req.Source.ID,
+		d.shard.Epoch,
+		req.Commit)
+
+	// the source shard peer is caught up before the log applied, see pauseApplyForMergeSource
+	if d.caughtUpSource == nil || d.caughtUpSource.source.ID != req.Source.ID {
+		logger.Fatalf("shard %d commit merge failed, source shard %d is not caught up",
+			d.shard.ID,
+			req.Source.ID)
+	}
+	source, state := d.caughtUpSource.source, d.caughtUpSource.mergeState
+	d.caughtUpSource = nil
+	if state == nil || state.Target.ID != d.shard.ID {
+		logger.Fatalf("shard %d commit merge failed, source shard %d is not merging into current shard, merge state %+v",
+			d.shard.ID,
+			source.ID,
+			state)
+	}
+
+	res := bhmetapb.Shard{}
+	protoc.MustUnmarshal(&res, protoc.MustMarshal(&d.shard))
+	if bytes.Equal(source.End, res.Start) {
+		res.Start = source.Start
+	} else {
+		res.End = source.End
+	}
+	if source.Epoch.Version > res.Epoch.Version {
+		res.Epoch.Version = source.Epoch.Version
+	}
+	res.Epoch.Version++
+
+	mergeState := &bhraftpb.MergeState{
+		MinIndex: state.MinIndex,
+		Commit:   state.Commit,
+		Target:   res,
+	}
+
+	d.shard = res
+	d.store.updatePeerState(d.shard, bhraftpb.PeerState_Normal, ctx.raftWB)
+	d.store.updateMergeState(source, bhraftpb.PeerState_Tombstone, mergeState, ctx.raftWB)
+
+	if d.store.cfg.Storage.DataMoveFunc != nil {
+		err := d.store.cfg.Storage.DataMoveFunc(source, []bhmetapb.Shard{res})
+		if err != nil {
+			logger.Fatalf("shard %d commit apply merge result, move data failed with %+v",
+				d.shard.ID,
+				err)
+		}
+	}
+
+	ctx.metrics.admin.mergeSucceed++
+	rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_CommitMerge, &raftcmdpb.CommitMergeResponse{})
+	result := &execResult{
+		adminType: raftcmdpb.AdminCmdType_CommitMerge,
+		mergeResult: &mergeResult{
+			shard:      d.shard,
+			source:     source,
+			mergeState: mergeState,
+		},
+	}
+
+	return rsp, result, nil
+}
+
+// pauseApplyForMergeSource pauses the apply before the entry if it commits the merge of a
+// source shard peer not caught up yet. The source shard peer catches up the logs in its own
+// apply worker, then the paused entries are applied in the apply worker of the current shard,
+// so neither of the apply workers waits for the other one. The entries may be the logs caught
+// up by another merge if the current shard is also merging, the catch up of the current shard
+// is completed after resumed.
+func (d *applyDelegate) pauseApplyForMergeSource(req *raftcmdpb.RaftCMDRequest, entries []raftpb.Entry) bool {
+	if req.AdminRequest == nil ||
+		req.AdminRequest.CmdType != raftcmdpb.AdminCmdType_CommitMerge ||
+		!d.checkEpoch(req) {
+		return false
+	}
+
+	// the request is reused by the following entries
+	merge := *req.AdminRequest.CommitMerge
+	if d.caughtUpSource != nil && d.caughtUpSource.source.ID == merge.Source.ID {
+		return false
+	}
+
+	pr := d.store.getPR(merge.Source.ID, false)
+	if pr == nil {
+		logger.Fatalf("shard %d commit merge failed, missing source shard %d",
+			d.shard.ID,
+			merge.Source.ID)
+	}
+
+	logger.Infof("shard %d pause apply at index %d until merge source shard %d catches up",
+		d.shard.ID,
+		entries[0].Index,
+		merge.Source.ID)
+
+	d.pausedEntries = append([]raftpb.Entry(nil), entries...)
+	target := d.shard.ID
+	worker := d.ctx.pr.applyWorker
+	err := d.store.addApplyJob(pr.applyWorker, "catchUpMergeSource", func() error {
+		d.store.catchUpMergeSource(target, &merge, func(source bhmetapb.Shard, state *bhraftpb.MergeState) {
+			err := d.store.addApplyJob(worker, "resumeApply", func() error {
+				d.caughtUpSource = &mergeResult{source: source, mergeState: state}
+				d.resumeApply()
+				return nil
+			}, nil)
+			if err != nil {
+				logger.Errorf("shard %d add resume apply job failed with %+v",
+					target,
+					err)
+			}
+		})
+		return nil
+	}, nil)
+	if err != nil {
+		logger.Fatalf("shard %d add catch up merge source shard %d job failed with %+v",
+			d.shard.ID,
+			merge.Source.ID,
+			err)
+	}
+
+	return true
+}
+
+// resumeApply applies the entries paused by pauseApplyForMergeSource and the entries
+// committed during the pause.
+func (d *applyDelegate) resumeApply() {
+	// the shard peer is destroyed during the pause
+	if value, ok := d.store.delegates.Load(d.shard.ID); !ok || value.(*applyDelegate) != d {
+		d.pausedEntries = nil
+		return
+	}
+
+	logger.Infof("shard %d resume apply with %d paused entries",
+		d.shard.ID,
+		len(d.pausedEntries))
+
+	entries := d.pausedEntries
+	d.pausedEntries = nil
+	if d.catchingUp != nil {
+		d.resumeCatchUp(entries)
+		return
+	}
+
+	d.applyCommittedEntries(entries)
+	if d.isPendingRemove() {
+		d.destroy()
+		d.store.delegates.Delete(d.shard.ID)
+	}
+}
+
+// pauseEntries appends the committed entries to the paused entries, the entries already
+// paused are skipped, they may be loaded by the catch up before committed by the raft node.
+func (d *applyDelegate) pauseEntries(entries []raftpb.Entry) {
+	last := d.pausedEntries[len(d.pausedEntries)-1].Index
+	for _, entry := range entries {
+		if entry.Index > last {
+			d.pausedEntries = append(d.pausedEntries, entry)
+		}
+	}
+}
+
+func (d *applyDelegate) isApplyPaused() bool {
+	return len(d.pausedEntries) > 0
+}
+
+// mergeCatchUp is the catch up of the merge source shard peer in progress
+type mergeCatchUp struct {
+	commit uint64
+	done   func()
+}
+
+// catchUpMergeSource catches up the source shard peer of the merge into the target shard,
+// it must run in the apply worker of the source shard peer. The done is called in the apply
+// worker of the source shard peer once the source shard peer applied all the logs before the
+// CommitMerge log, it's called later if the catch up is paused by another merge.
+func (s *store) catchUpMergeSource(target uint64, req *raftcmdpb.CommitMergeRequest,
+	done func(bhmetapb.Shard, *bhraftpb.MergeState)) {
+	value, ok := s.delegates.Load(req.Source.ID)
+	if !ok {
+		logger.Fatalf("shard %d commit merge failed, missing source shard %d",
+			target,
+			req.Source.ID)
+	}
+
+	delegate := value.(*applyDelegate)
+	delegate.catchUpLogs(req.Commit, req.Entries, func() {
+		// the source shard will be destroyed, skip all following logs
+		delegate.setPendingRemove()
+		done(delegate.shard, delegate.mergeState)
+	})
+}
+
+func (d *applyDelegate) catchUpLogs(commit uint64, entries []raftpb.Entry, done func()) {
+	if d.catchingUp != nil {
+		logger.Fatalf("shard %d catch up logs to %d failed, already catching up to %d",
+			d.shard.ID,
+			commit,
+			d.catchingUp.commit)
+	}
+
+	// the logs are caught up after the paused entries
+	from := d.applyState.AppliedIndex + 1
+	if d.isApplyPaused() {
+		from = d.pausedEntries[len(d.pausedEntries)-1].Index + 1
+	}
+
+	var logs []raftpb.Entry
+	for index := from; index <= commit; index++ {
+		if len(entries) > 0 && index >= entries[0].Index {
+			logs = append(logs, entries[index-entries[0].Index:]...)
+			break
+		}
+
+		entry, err := d.ps.loadLogEntry(index)
+		if err != nil {
+			logger.Fatalf("shard %d catch up logs failed with %+v",
+				d.shard.ID,
+				err)
+		}
+		logs = append(logs, entry)
+	}
+
+	d.catchingUp = &mergeCatchUp{commit: commit, done: done}
+	if d.isApplyPaused() {
+		d.pauseEntries(logs)
+		return
+	}
+
+	d.applyEntries(logs, false)
+	if !d.isApplyPaused() {
+		d.completeCatchUp()
+	}
+}
+
+// resumeCatchUp applies the logs before the commit of the catch up paused by another merge
+func (d *applyDelegate) resumeCatchUp(entries []raftpb.Entry) {
+	var logs []raftpb.Entry
+	for _, entry := range entries {
+		if entry.Index <= d.catchingUp.commit {
+			logs = append(logs, entry)
+		}
+	}
+
+	d.applyEntries(logs, false)
+	if !d.isApplyPaused() {
+		d.completeCatchUp()
+	}
+}
+
+func (d *applyDelegate) completeCatchUp() {
+	if d.applyState.AppliedIndex < d.catchingUp.commit {
+		logger.Fatalf("shard %d catch up logs failed, applied %d, commit %d",
+			d.shard.ID,
+			d.applyState.AppliedIndex,
+			d.catchingUp.commit)
+	}
+
+	done := d.catchingUp.done
+	d.catchingUp = nil
+	done()
+}
+
+func (d *applyDelegate) doExecRollbackMerge(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	req := ctx.req.AdminRequest.RollbackMerge
+	logger.Infof("shard %d do apply rollback merge at epoch %+v, commit %d",
+		d.shard.ID,
+		d.shard.Epoch,
+		req.Commit)
+
+	if d.mergeState == nil || d.mergeState.Commit != req.Commit {
+		return nil, nil, fmt.Errorf("shard %d rollback merge with unmatched merge state %+v",
+			d.shard.ID,
+			d.mergeState)
+	}
+
+	res := bhmetapb.Shard{}
+	protoc.MustUnmarshal(&res, protoc.MustMarshal(&d.shard))
+	res.Epoch.Version++
+
+	d.shard = res
+	d.mergeState = nil
+	d.store.updatePeerState(d.shard, bhraftpb.PeerState_Normal, ctx.raftWB)
+
+	ctx.metrics.admin.mergeRollback++
+	rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_RollbackMerge, &raftcmdpb.RollbackMergeResponse{})
+	result := &execResult{
+		adminType: raftcmdpb.AdminCmdType_RollbackMerge,
+		mergeResult: &mergeResult{
+			shard: d.shard,
+		},
+	}
+
+	return rsp, result, nil
+}
+
+func (d *applyDelegate) doExecComputeHash(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	hs, ok := d.store.DataStorageByGroup(d.shard.Group, d.shard.ID).(storage.HashableStorage)
+	if !ok {
+		return nil, nil, fmt.Errorf("shard %d data storage can not compute hash",
+			d.shard.ID)
+	}
+
+	// all the replicas take the snapshot at the same index and exclude the key-value pairs
+	// expired at the proposal time, the hash is computed out of the apply path.
+	at := ctx.req.Header.ProposedAt
+	if at == 0 {
+		at = time.Now().Unix()
+	}
+	compute, err := hs.HashSnapshot(encStartKey(&d.shard), encEndKey(&d.shard), at)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_ComputeHash, &raftcmdpb.ComputeHashResponse{})
+	result := &execResult{
+		adminType: raftcmdpb.AdminCmdType_ComputeHash,
+		hashResult: &hashResult{
+			index:   ctx.index,
+			compute: compute,
+		},
+	}
+
+	return rsp, result, nil
+}
+
+func (d *applyDelegate) doExecVerifyHash(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	req := ctx.req.AdminRequest.VerifyHash
+	rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_VerifyHash, &raftcmdpb.VerifyHashResponse{})
+
+	// the leader's hash is checked with the local hash after the local hash computed
+	result := &execResult{
+		adminType: raftcmdpb.AdminCmdType_VerifyHash,
+		hashResult: &hashResult{
+			index: req.Index,
+			hash:  req.Hash,
+		},
+	}
+	return rsp, result, nil
+}
+
+// doExecIngest ingests the file built by the bulk load. All the replicas have received the file
+// before the Ingest proposed, and the file is dropped if the shard is split, merged or its peers
+// are changed after the file built, the epoch in the request is checked because the epoch in the
+// header is the one when the request proposed. The file is also dropped by the discard request
+// if the bulk load is aborted.
+func (d *applyDelegate) doExecIngest(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	req := ctx.req.AdminRequest.Ingest
+	msg := &bhraftpb.SnapshotMessage{}
+	msg.Header.Shard = d.shard
+	msg.Header.Index = req.ID
+
+	current := d.shard.Epoch
+	stale := req.Epoch.Version != current.Version ||
+		req.Epoch.ConfVer != current.ConfVer
+	if stale || req.Discard {
+		logger.Infof("shard %d skip ingest %d, discard=<%t>, epoch=<%s>, current=<%s>",
+			d.shard.ID,
+			req.ID,
+			req.Discard,
+			req.Epoch.String(),
+			current.String())
+
+		if err := d.store.snapshotManager.CleanSnap(msg); err != nil {
+			logger.Errorf("shard %d clean ingest file %d failed with %+v",
+				d.shard.ID,
+				req.ID,
+				err)
+		}
+		rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_Ingest, &raftcmdpb.IngestResponse{StaleEpoch: stale})
+		return rsp, nil, nil
+	}
+
+	// the file is checked held by all the replicas before proposed, the replica failed to
+	// ingest it can't skip the entry, otherwise its data diverges from the others.
+	mgr, ok := d.store.snapshotManager.(snapshot.IngestManager)
+	if !ok {
+		logger.Fatalf("shard %d ingest %d failed with %+v",
+			d.shard.ID,
+			req.ID,
+			errBulkLoadUnsupported)
+	}
+	size := d.store.receivedSnapBytes(msg)
+	if err := mgr.Ingest(msg); err != nil {
+		logger.Fatalf("shard %d ingest %d failed with %+v",
+			d.shard.ID,
+			req.ID,
+			err)
+	}
+
+	logger.Infof("shard %d ingest %d completed, size=<%d>",
+		d.shard.ID,
+		req.ID,
+		size)
+
+	// the ingested data is counted by the compressed size of the file, it's enough to trigger
+	// the split check.
+	ctx.metrics.writtenBytes += size
+	ctx.metrics.sizeDiffHint += size
+	rsp := newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_Ingest, &raftcmdpb.IngestResponse{})
+	result := &execResult{
+		adminType:    raftcmdpb.AdminCmdType_Ingest,
+		needSyncData: true,
+		ingested:     msg,
+	}
+	return rsp, result, nil
+}
+
+// doExecBackup takes the view of the shard's data at the applied index, all the writes before
+// the index are applied and none after it. The view is only taken by the replica which proposed
+// the request, and is uploaded out of the apply path.
+func (d *applyDelegate) doExecBackup(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
+	rsp := &raftcmdpb.BackupResponse{}
+	if ctx.proposed {
+		backup, err := d.store.startBackupShard(ctx.req.AdminRequest.Backup.Path, d.shard, ctx.index)
+		if err != nil {
+			logger.Errorf("shard %d backup at index %d failed with %+v",
+				d.shard.ID,
+				ctx.index,
+				err)
+			rsp.Error = err.Error()
+		}
+		rsp.Backup = backup
+	}
+
+	return newAdminRaftCMDResponse(raftcmdpb.AdminCmdType_Backup, rsp), nil, nil
+}
+
 func (d *applyDelegate) doExecCompactRaftLog(ctx *applyContext) (*raftcmdpb.RaftCMDResponse, *execResult, error) {
 	ctx.metrics.admin.compact++
 
@@ -486,6 +1014,17 @@ func (d *applyDelegate) execWriteRequest(ctx *applyContext) (uint64, int64, *raf
 			resp.Responses = append(resp.Responses, rsp)
 			writeBytes += written
 			diffBytes += diff
+
+			if d.store.cdc.active() {
+				ctx.changes = append(ctx.changes, ChangeEvent{
+					Type:       WriteEvent,
+					ShardID:    d.shard.ID,
+					Index:      ctx.index,
+					CustemType: req.CustemType,
+					Key:        append([]byte(nil), DecodeDataKey(req.Key)...),
+					Cmd:        append([]byte(nil), req.Cmd...),
+				})
+			}
 		} else {
 			logger.Fatalf("%s missing write handle func for type %d, registers %+v",
 				hex.EncodeToString(req.ID),
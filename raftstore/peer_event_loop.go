@@ -14,13 +14,18 @@
 package raftstore
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util"
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/raftpb"
@@ -29,6 +34,13 @@ import (
 
 const (
 	readyBatch = 1024
+	// maxPrepareMergeRetries the times to retry the merge not ready to prepare, e.g. the new
+	// leader doesn't know the matched index of the followers yet.
+	maxPrepareMergeRetries = 30
+)
+
+var (
+	errMergeNotReady = errors.New("merge not ready")
 )
 
 type action struct {
@@ -36,16 +48,24 @@ type action struct {
 	splitKeys  [][]byte
 	splitIDs   []rpcpb.SplitID
 	epoch      metapb.ResourceEpoch
+	target     bhmetapb.Shard
+	hash       hashResult
+	// retries the times the action is retried
+	retries int
 }
 
 type actionType int
 
 const (
-	checkCompactAction = actionType(0)
-	doCampaignAction   = actionType(1)
-	checkSplitAction   = actionType(2)
-	doSplitAction      = actionType(3)
-	heartbeatAction    = actionType(4)
+	checkCompactAction     = actionType(0)
+	doCampaignAction       = actionType(1)
+	checkSplitAction       = actionType(2)
+	doSplitAction          = actionType(3)
+	heartbeatAction        = actionType(4)
+	prepareMergeAction     = actionType(5)
+	checkMergeAction       = actionType(6)
+	checkConsistencyAction = actionType(7)
+	hashComputedAction     = actionType(8)
 )
 
 func (pr *peerReplica) addRequest(req reqCtx) error {
@@ -164,12 +184,12 @@ func (pr *peerReplica) handleEvent() bool {
 			}
 
 			// resp all pending requests in batch and queue
-			for _, c := range pr.pendingReads.reads {
+			pr.pendingReads.foreach(func(c cmd) {
 				for _, req := range c.req.Requests {
 					req.Key = DecodeDataKey(req.Key)
 					respStoreNotMatch(errStoreNotMatch, req, c.cb)
 				}
-			}
+			})
 			pr.pendingReads.reset()
 
 			requests := pr.requests.Dispose()
@@ -238,6 +258,14 @@ func (pr *peerReplica) handleAction(items []interface{}) {
 			}
 		case heartbeatAction:
 			pr.doHeartbeat()
+		case prepareMergeAction:
+			pr.doPrepareMerge(a.target, a.epoch, a.retries)
+		case checkMergeAction:
+			pr.doCheckMerge(a.retries > 0)
+		case checkConsistencyAction:
+			pr.doCheckConsistency()
+		case hashComputedAction:
+			pr.doHashComputed(a.hash)
 		}
 	}
 
@@ -259,8 +287,19 @@ func (pr *peerReplica) handleStep(items []interface{}) {
 
 	for i := int64(0); i < n; i++ {
 		msg := items[i].(raftpb.Message)
+		// the witness never becomes the leader
+		if msg.Type == raftpb.MsgTimeoutNow && pr.isWitness() {
+			logger.Infof("shard %d witness peer %d ignore timeout now message",
+				pr.shardID,
+				pr.peer.ID)
+			continue
+		}
+
 		if pr.isLeader() && msg.From != 0 {
 			pr.peerHeartbeatsMap.Store(msg.From, time.Now())
+			if msg.Term == pr.getCurrentTerm() {
+				pr.lease.onResp(msg)
+			}
 		}
 
 		err := pr.rn.Step(msg)
@@ -270,6 +309,10 @@ func (pr *peerReplica) handleStep(items []interface{}) {
 				err)
 		}
 
+		if msg.From != 0 && msg.From == pr.getLeaderPeerID() {
+			pr.lastLeaderContact = time.Now()
+		}
+
 		if logger.DebugEnabled() {
 			if len(msg.Entries) > 0 {
 				logger.Debugf("shard %d step raft", pr.shardID)
@@ -299,11 +342,24 @@ func (pr *peerReplica) handleTick(items []interface{}) {
 			return
 		}
 
+		// the witness never starts an election, but its election clock must keep
+		// moving, otherwise it rejects all votes as in lease once it has seen a
+		// leader and the shard cannot elect a new leader after the leader is down.
+		witness := pr.isWitness()
 		for i := int64(0); i < n; i++ {
-			if !pr.stopRaftTick {
+			if pr.stopRaftTick {
+				continue
+			}
+			if witness {
+				pr.rn.TickQuiesced()
+			} else {
 				pr.rn.Tick()
 			}
 		}
+
+		pr.pendingReads.removeExpired(time.Now(),
+			pr.store.cfg.Raft.GetElectionTimeoutDuration(),
+			pr.getCurrentTerm())
 	}
 }
 
@@ -394,6 +450,219 @@ func (pr *peerReplica) doSplit(splitKeys [][]byte, splitIDs []rpcpb.SplitID, epo
 	pr.onAdmin(req)
 }
 
+func (pr *peerReplica) doPrepareMerge(target bhmetapb.Shard, epoch metapb.ResourceEpoch, retries int) {
+	if !pr.isLeader() {
+		return
+	}
+
+	current := pr.ps.shard
+	if current.Epoch.Version != epoch.Version ||
+		current.Epoch.ConfVer != epoch.ConfVer {
+		logger.Infof("shard %d epoch changed, skip merge, current=<%+v> merge=<%+v>",
+			pr.shardID,
+			current.Epoch,
+			epoch)
+		return
+	}
+
+	if pr.ps.mergeState != nil {
+		logger.Infof("shard %d is already merging into shard %d",
+			pr.shardID,
+			pr.ps.mergeState.Target.ID)
+		return
+	}
+
+	minIndex, err := pr.checkPrepareMerge(target)
+	if err != nil {
+		logger.Errorf("shard %d can not merge into shard %d, retries %d, %+v",
+			pr.shardID,
+			target.ID,
+			retries,
+			err)
+		// the merge is retried by the current leader, the prophet may not send the merge
+		// again before the merge operator timeout.
+		if errors.Is(err, errMergeNotReady) && retries < maxPrepareMergeRetries {
+			pr.addActionLater(action{
+				actionType: prepareMergeAction,
+				target:     target,
+				epoch:      epoch,
+				retries:    retries + 1,
+			})
+		}
+		return
+	}
+
+	pr.onAdmin(&raftcmdpb.AdminRequest{
+		CmdType: raftcmdpb.AdminCmdType_PrepareMerge,
+		PrepareMerge: &raftcmdpb.PrepareMergeRequest{
+			MinIndex: minIndex,
+			Target:   target,
+		},
+	})
+}
+
+// checkPrepareMerge returns the min matched index of all peers, the logs after the
+// min index will be sent to the target shard to make the source peers catch up.
+func (pr *peerReplica) checkPrepareMerge(target bhmetapb.Shard) (uint64, error) {
+	err := checkMergeTarget(pr.ps.shard, target)
+	if err != nil {
+		return 0, err
+	}
+
+	// the local target peer's epoch must be the same as the epoch from prophet,
+	// otherwise the target shard has changed after the merge operator created.
+	local := pr.store.getPR(target.ID, false)
+	if local == nil {
+		return 0, fmt.Errorf("%w, target shard %d not found on current store", errMergeNotReady, target.ID)
+	}
+	if isEpochStale(target.Epoch, local.ps.shard.Epoch) ||
+		isEpochStale(local.ps.shard.Epoch, target.Epoch) {
+		return 0, fmt.Errorf("target shard %d epoch not match, current=<%+v> merge=<%+v>",
+			target.ID,
+			local.ps.shard.Epoch,
+			target.Epoch)
+	}
+
+	if pr.rn.PendingConfIndex() > pr.ps.getAppliedIndex() {
+		return 0, fmt.Errorf("%w, there is a pending conf change", errMergeNotReady)
+	}
+
+	minIndex := uint64(0)
+	for id, p := range pr.rn.Status().Progress {
+		if p.Match == 0 {
+			return 0, fmt.Errorf("%w, peer %d matched index is unknown", errMergeNotReady, id)
+		}
+
+		if minIndex == 0 || p.Match < minIndex {
+			minIndex = p.Match
+		}
+	}
+
+	if minIndex <= pr.ps.getTruncatedIndex() {
+		return 0, fmt.Errorf("%w, peers are lagging, min matched index %d, truncated index %d",
+			errMergeNotReady,
+			minIndex,
+			pr.ps.getTruncatedIndex())
+	}
+
+	return minIndex, nil
+}
+
+// doCheckMerge check the merge state, every source peer will try to commit the merge
+// by the target peer on the same store, and only the target leader can propose the
+// CommitMerge. If the target shard has changed after PrepareMerge, the source leader
+// will rollback the merge. The check is repeated until the merge is committed or rolled
+// back, the target leader may move to the other stores, and the proposals may be dropped.
+func (pr *peerReplica) doCheckMerge(scheduled bool) {
+	if scheduled {
+		pr.checkMergeScheduled = false
+	}
+
+	state := pr.ps.mergeState
+	if state == nil {
+		return
+	}
+
+	target := pr.store.getPR(state.Target.ID, false)
+	if target == nil {
+		logger.Infof("shard %d merge target shard %d not found on current store, check later",
+			pr.shardID,
+			state.Target.ID)
+		pr.scheduleCheckMerge()
+		return
+	}
+
+	current := target.ps.shard
+	if current.Epoch.Version != state.Target.Epoch.Version ||
+		current.Epoch.ConfVer != state.Target.Epoch.ConfVer {
+		// merge committed, current peer will be destroyed later
+		if containsShard(current, pr.ps.shard) {
+			return
+		}
+		defer pr.scheduleCheckMerge()
+
+		logger.Infof("shard %d merge target shard %d epoch changed, current=<%+v> merge=<%+v>",
+			pr.shardID,
+			current.ID,
+			current.Epoch,
+			state.Target.Epoch)
+		pr.doRollbackMerge()
+		return
+	}
+
+	defer pr.scheduleCheckMerge()
+	if !target.isLeader() {
+		return
+	}
+
+	entries, err := pr.ps.Entries(state.MinIndex+1, state.Commit+1, math.MaxUint64)
+	if err != nil {
+		logger.Errorf("shard %d load merge logs in (%d, %d] failed with %+v",
+			pr.shardID,
+			state.MinIndex,
+			state.Commit,
+			err)
+		if err == raft.ErrCompacted {
+			pr.doRollbackMerge()
+		}
+		return
+	}
+
+	target.onAdmin(&raftcmdpb.AdminRequest{
+		CmdType: raftcmdpb.AdminCmdType_CommitMerge,
+		CommitMerge: &raftcmdpb.CommitMergeRequest{
+			Source:  pr.ps.shard,
+			Commit:  state.Commit,
+			Entries: entries,
+		},
+	})
+}
+
+// scheduleCheckMerge checks the merge again after an election timeout
+func (pr *peerReplica) scheduleCheckMerge() {
+	if pr.checkMergeScheduled {
+		return
+	}
+
+	pr.checkMergeScheduled = true
+	pr.addActionLater(action{actionType: checkMergeAction, retries: 1})
+}
+
+// addActionLater adds the action after an election timeout
+func (pr *peerReplica) addActionLater(act action) {
+	util.DefaultTimeoutWheel().Schedule(pr.store.cfg.Raft.GetElectionTimeoutDuration(), func(interface{}) {
+		pr.addAction(act)
+	}, nil)
+}
+
+func (pr *peerReplica) doRollbackMerge() {
+	if !pr.isLeader() {
+		return
+	}
+
+	pr.onAdmin(&raftcmdpb.AdminRequest{
+		CmdType: raftcmdpb.AdminCmdType_RollbackMerge,
+		RollbackMerge: &raftcmdpb.RollbackMergeRequest{
+			Commit: pr.ps.mergeState.Commit,
+		},
+	})
+}
+
+func (pr *peerReplica) doCheckConsistency() {
+	if !pr.isLeader() {
+		return
+	}
+
+	if _, ok := pr.store.DataStorageByGroup(pr.ps.shard.Group, pr.shardID).(storage.HashableStorage); !ok {
+		return
+	}
+
+	pr.onAdmin(&raftcmdpb.AdminRequest{
+		CmdType:     raftcmdpb.AdminCmdType_ComputeHash,
+		ComputeHash: &raftcmdpb.ComputeHashRequest{},
+	})
+}
+
 func (pr *peerReplica) doCheckCompact() {
 	// Leader will replicate the compact log command to followers,
 	// If we use current replicated_index (like 10) as the compact index,
@@ -471,6 +740,16 @@ func (pr *peerReplica) doCheckCompact() {
 		}
 	}
 
+	// keep the applied entries for the change subscribers to resume from
+	if retain := pr.store.cfg.Raft.RaftLog.RetainCount; retain > 0 {
+		if appliedIdx <= retain {
+			return
+		}
+		if compactIdx > appliedIdx-retain {
+			compactIdx = appliedIdx - retain
+		}
+	}
+
 	compactIdx--
 
 	if pr.store.cfg.Customize.CustomAdjustCompactFuncFactory != nil {
@@ -0,0 +1,108 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	witnessTestCluster = WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Prophet.Replication.WitnessReplicas = 1
+	})
+)
+
+// waitTestWitness waits the shard has a witness and all the other peers are voters,
+// returns the store of the witness.
+func waitTestWitness(t *testing.T, c TestRaftCluster, id uint64) Store {
+	timeoutC := time.After(testWaitTimeout)
+	for {
+		var witness Store
+		voters := 0
+		c.EveryStore(func(i int, s Store) {
+			pr := s.(*store).getPR(id, false)
+			if pr == nil {
+				return
+			}
+			if pr.isWitness() {
+				witness = s
+			} else if !pr.isLearner() {
+				voters++
+			}
+		})
+		if witness != nil && voters == 2 {
+			return witness
+		}
+
+		select {
+		case <-timeoutC:
+			assert.FailNow(t, "wait the witness timeout")
+		default:
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
+func TestWitnessElection(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, witnessTestCluster, GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	id := c.GetShardByIndex(0, 0).ID
+	witness := waitTestWitness(t, c, id)
+	leader := c.GetShardLeaderStore(id)
+	assert.NotNil(t, leader)
+	assert.NotEqual(t, witness.Meta().ID, leader.Meta().ID)
+
+	resps, err := sendTestReqs(leader, testWaitTimeout, nil, nil, createTestWriteReq("w1", "key1", "1"))
+	assert.NoError(t, err)
+	assert.Nil(t, resps["w1"].Header)
+
+	// the witness has seen the leader, it must still vote for the full replica
+	// after the leader is down.
+	leader.Stop()
+	var newLeader Store
+	timeoutC := time.After(testWaitTimeout)
+	for newLeader == nil {
+		c.EveryStore(func(i int, s Store) {
+			if s.Meta().ID == leader.Meta().ID {
+				return
+			}
+			if pr := s.(*store).getPR(id, false); pr != nil && pr.isLeader() {
+				newLeader = s
+			}
+		})
+
+		select {
+		case <-timeoutC:
+			assert.FailNow(t, "wait the new leader timeout")
+		default:
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+	assert.NotEqual(t, witness.Meta().ID, newLeader.Meta().ID)
+
+	resps, err = sendTestReqs(newLeader, testWaitTimeout, nil, nil, createTestReadReq("r1", "key1"))
+	assert.NoError(t, err)
+	assert.Nil(t, resps["r1"].Header)
+	assert.Equal(t, "1", string(resps["r1"].Responses[0].Value))
+}
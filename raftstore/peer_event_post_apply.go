@@ -14,6 +14,8 @@
 package raftstore
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -92,6 +94,16 @@ func (pr *peerReplica) doPostApplyResult(result asyncApplyResult) {
 		pr.doApplySplit(result.result.splitResult)
 	case raftcmdpb.AdminCmdType_CompactLog:
 		pr.doApplyCompactRaftLog(result.result.raftGCResult)
+	case raftcmdpb.AdminCmdType_PrepareMerge:
+		pr.doApplyPrepareMerge(result.result.mergeResult)
+	case raftcmdpb.AdminCmdType_CommitMerge:
+		pr.doApplyCommitMerge(result.result.mergeResult)
+	case raftcmdpb.AdminCmdType_RollbackMerge:
+		pr.doApplyRollbackMerge(result.result.mergeResult)
+	case raftcmdpb.AdminCmdType_ComputeHash:
+		pr.doApplyComputeHash(result.result.hashResult)
+	case raftcmdpb.AdminCmdType_VerifyHash:
+		pr.doApplyVerifyHash(result.result.hashResult)
 	}
 }
 
@@ -274,6 +286,122 @@ func (pr *peerReplica) doApplySplit(result *splitResult) {
 		result.shards)
 }
 
+func (pr *peerReplica) doApplyPrepareMerge(result *mergeResult) {
+	logger.Infof("shard %d update to %+v by post apply prepare merge, merging into shard %d",
+		pr.shardID,
+		result.shard,
+		result.mergeState.Target.ID)
+
+	pr.ps.shard = result.shard
+	pr.ps.mergeState = result.mergeState
+	pr.store.updateShardKeyRange(result.shard)
+
+	if pr.isLeader() {
+		pr.addAction(action{actionType: heartbeatAction})
+	}
+	pr.addAction(action{actionType: checkMergeAction})
+}
+
+func (pr *peerReplica) doApplyCommitMerge(result *mergeResult) {
+	logger.Infof("shard %d update to %+v by post apply commit merge, shard %d merged",
+		pr.shardID,
+		result.shard,
+		result.source.ID)
+
+	pr.ps.shard = result.shard
+	pr.store.updateShardKeyRange(result.shard)
+	err := pr.store.startDestroyMergedJob(result)
+	if err != nil && !pr.store.isStopped() {
+		logger.Fatalf("shard %d add destroy merged shard %d job failed with %+v",
+			pr.shardID,
+			result.source.ID,
+			err)
+	}
+
+	pr.sizeDiffHint = uint64(pr.store.cfg.Replication.ShardSplitCheckBytes)
+	if pr.isLeader() {
+		pr.addAction(action{actionType: heartbeatAction})
+	}
+
+	if pr.store.aware != nil {
+		pr.store.aware.Merged(pr.ps.shard)
+	}
+}
+
+func (pr *peerReplica) doApplyRollbackMerge(result *mergeResult) {
+	logger.Infof("shard %d update to %+v by post apply rollback merge",
+		pr.shardID,
+		result.shard)
+
+	pr.ps.shard = result.shard
+	pr.ps.mergeState = nil
+	pr.store.updateShardKeyRange(result.shard)
+
+	if pr.isLeader() {
+		pr.addAction(action{actionType: heartbeatAction})
+	}
+}
+
+func (pr *peerReplica) doApplyComputeHash(result *hashResult) {
+	err := pr.startComputeHashJob(result.index, result.compute)
+	if err != nil {
+		logger.Errorf("shard %d add compute hash job failed with %+v",
+			pr.shardID,
+			err)
+	}
+}
+
+func (pr *peerReplica) doApplyVerifyHash(result *hashResult) {
+	if pr.lastHash.index == result.index {
+		pr.checkHash(*result)
+		return
+	}
+
+	// the local hash is computing, check it after computed. The hash may be not computed at all,
+	// e.g. the replica is restarted or applied a snapshot after the ComputeHash, it is skipped.
+	pr.expectedHash = *result
+}
+
+// doHashComputed handles the local hash computed by the compute hash job, the leader proposes
+// a VerifyHash with its hash to check the other replicas.
+func (pr *peerReplica) doHashComputed(result hashResult) {
+	pr.lastHash = result
+	if pr.isLeader() {
+		pr.onAdmin(&raftcmdpb.AdminRequest{
+			CmdType: raftcmdpb.AdminCmdType_VerifyHash,
+			VerifyHash: &raftcmdpb.VerifyHashRequest{
+				Index: result.index,
+				Hash:  result.hash,
+			},
+		})
+	}
+
+	if pr.expectedHash.index == result.index {
+		pr.checkHash(pr.expectedHash)
+	}
+}
+
+// checkHash checks the local hash with the leader's hash at the same index
+func (pr *peerReplica) checkHash(leader hashResult) {
+	pr.expectedHash = hashResult{}
+	pr.metrics.admin.consistency++
+	if bytes.Equal(pr.lastHash.hash, leader.hash) {
+		pr.metrics.admin.consistencySucceed++
+		return
+	}
+
+	pr.metrics.admin.consistencyFailed++
+	logger.Errorf("shard %d peer %d data is inconsistent with leader at index %d, hash %s, leader hash %s",
+		pr.shardID,
+		pr.peer.ID,
+		leader.index,
+		hex.EncodeToString(pr.lastHash.hash),
+		hex.EncodeToString(leader.hash))
+	if pr.store.aware != nil {
+		pr.store.aware.ConsistencyCheckFailed(pr.ps.shard, leader.index)
+	}
+}
+
 func (pr *peerReplica) doApplyCompactRaftLog(result *raftGCResult) {
 	total := pr.ps.lastReadyIndex - result.firstIndex
 	remain := pr.ps.lastReadyIndex - result.state.Index - 1
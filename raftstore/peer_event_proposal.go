@@ -17,9 +17,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
 	"go.etcd.io/etcd/raft/v3/raftpb"
@@ -54,6 +56,8 @@ const (
 	proposeNormal         = requestPolicy(2)
 	proposeTransferLeader = requestPolicy(3)
 	proposeChange         = requestPolicy(4)
+	readLearner           = requestPolicy(5)
+	readLease             = requestPolicy(6)
 )
 
 func (pr *peerReplica) handleRequest(items []interface{}) {
@@ -70,7 +74,17 @@ func (pr *peerReplica) handleRequest(items []interface{}) {
 
 		for i := int64(0); i < n; i++ {
 			req := items[i].(reqCtx)
+			if pr.store.freezer.frozen(pr.ps.shard.Group, req) {
+				respWriteFrozen(pr.ps.shard.Group, req.req, req.cb)
+				continue
+			}
+
 			if req.req != nil {
+				if !pr.store.limiter.allow(pr.ps.shard.Group, req.req) {
+					respServerIsBusy(req.req, req.cb)
+					continue
+				}
+
 				if h, ok := pr.store.localHandlers[req.req.CustemType]; ok {
 					rsp, err := h(pr.ps.shard, req.req)
 					if err != nil {
@@ -82,6 +96,11 @@ func (pr *peerReplica) handleRequest(items []interface{}) {
 				}
 			}
 
+			if isIngest(req.admin) && !req.admin.Ingest.Discard && !req.ingestChecked {
+				pr.checkIngestFile(req)
+				continue
+			}
+
 			if logger.DebugEnabled() && req.req != nil {
 				logger.Debugf("%s push to proposal batch", hex.EncodeToString(req.req.ID))
 			}
@@ -112,6 +131,15 @@ func (pr *peerReplica) propose(c cmd) {
 		return
 	}
 
+	// the shard is merging, only read requests and the requests to
+	// rollback merge can be accepted.
+	if pr.ps.mergeState != nil && !isAllowedWhenMerging(c) {
+		logger.Infof("shard %d is merging, skip the proposal",
+			pr.shardID)
+		c.resp(errorStaleCMDResp(c.getUUID(), pr.getCurrentTerm()))
+		return
+	}
+
 	// after propose, raft need to send message to peers
 	defer pr.addEvent()
 
@@ -134,6 +162,12 @@ func (pr *peerReplica) propose(c cmd) {
 		pr.execReadIndex(c)
 	case readLocal:
 		pr.doExecReadCmd(c)
+	case readLearner:
+		pr.metrics.propose.readLearner++
+		pr.doExecReadCmd(c)
+	case readLease:
+		pr.metrics.propose.readLease++
+		pr.doExecReadCmd(c)
 	case proposeNormal:
 		doPropose = pr.proposeNormal(c)
 	case proposeTransferLeader:
@@ -156,6 +190,11 @@ func (pr *peerReplica) propose(c cmd) {
 
 func (pr *peerReplica) execReadIndex(c cmd) {
 	if !pr.isLeader() {
+		if allowFollowerReadIndex(c.req) && pr.getLeaderPeerID() != 0 {
+			pr.execFollowerReadIndex(c)
+			return
+		}
+
 		target, _ := pr.store.getPeer(pr.getLeaderPeerID())
 		c.respNotLeader(pr.shardID, target)
 		return
@@ -180,6 +219,18 @@ func (pr *peerReplica) execReadIndex(c cmd) {
 	pr.metrics.propose.readIndex++
 }
 
+// execFollowerReadIndex the follower asks the leader for the committed index with the
+// ReadIndex message, and executes the read after the applied index catches up. The read
+// is responded with the stale command error if the leader drops the ReadIndex message.
+func (pr *peerReplica) execFollowerReadIndex(c cmd) {
+	pr.rn.ReadIndex(c.getUUID())
+
+	c.readIndexAt = time.Now()
+	c.followerRead = true
+	pr.pendingReads.push(c)
+	pr.metrics.propose.followerReadIndex++
+}
+
 func (pr *peerReplica) proposeNormal(c cmd) bool {
 	if !pr.isLeader() {
 		target, _ := pr.store.getPeer(pr.getLeaderPeerID())
@@ -187,6 +238,9 @@ func (pr *peerReplica) proposeNormal(c cmd) bool {
 		return false
 	}
 
+	// the TTLs of the writes are counted from the proposal time of the leader, so all the
+	// replicas have the same expiration time
+	c.req.Header.ProposedAt = time.Now().Unix()
 	data := protoc.MustMarshal(c.req)
 	size := len(data)
 	metric.ObserveProposalBytes(int64(size))
@@ -333,6 +387,10 @@ func (pr *peerReplica) doTransferLeader(peer metapb.Peer) {
 		pr.shardID,
 		peer.ID)
 
+	// The transferee campaigns without the lease check of the followers, so the lease is
+	// invalid from now on.
+	pr.lease.reset()
+
 	// Broadcast heartbeat to make sure followers commit the entries immediately.
 	// It's only necessary to ping the target peer, but ping all for simplicity.
 	pr.rn.Ping()
@@ -342,6 +400,11 @@ func (pr *peerReplica) doTransferLeader(peer metapb.Peer) {
 }
 
 func (pr *peerReplica) isTransferLeaderAllowed(newLeaderPeer metapb.Peer) bool {
+	// the witness holds no data, it can not be the leader
+	if p, ok := pr.getPeer(newLeaderPeer.ID); ok && p.Role == metapb.PeerRole_Witness {
+		return false
+	}
+
 	status := pr.rn.Status()
 	if _, ok := status.Progress[newLeaderPeer.ID]; !ok {
 		return false
@@ -407,13 +470,14 @@ func (pr *peerReplica) checkConfChange(changes []raftcmdpb.ChangePeerRequest, cc
 	currentVoter := currentProgress.Config.Voters.IDs()
 	for _, cp := range changes {
 		if cp.ChangeType == metapb.ChangePeerType_RemoveNode &&
-			cp.Peer.Role == metapb.PeerRole_Voter &&
+			(cp.Peer.Role == metapb.PeerRole_Voter || cp.Peer.Role == metapb.PeerRole_Witness) &&
 			kind != simpleKind {
 			return fmt.Errorf("invalid conf change request %+v, can not remove voter directly", cp)
 		}
 
 		if !(cp.ChangeType == metapb.ChangePeerType_RemoveNode ||
 			(cp.ChangeType == metapb.ChangePeerType_AddNode && cp.Peer.Role == metapb.PeerRole_Voter) ||
+			(cp.ChangeType == metapb.ChangePeerType_AddNode && cp.Peer.Role == metapb.PeerRole_Witness && kind == simpleKind) ||
 			(cp.ChangeType == metapb.ChangePeerType_AddLearnerNode && cp.Peer.Role == metapb.PeerRole_Learner)) {
 			return fmt.Errorf("invalid conf change request %+v", cp)
 		}
@@ -509,10 +573,109 @@ func (pr *peerReplica) getHandlePolicy(req *raftcmdpb.RaftCMDRequest) (requestPo
 		return proposeNormal, nil
 	}
 
+	// the witness has no data, the read must be served by the other peers
+	if pr.isWitness() {
+		return readIndex, errWitnessRead
+	}
+
 	if pr.store.cfg.Customize.CustomCanReadLocalFunc != nil &&
 		pr.store.cfg.Customize.CustomCanReadLocalFunc(pr.ps.shard) {
 		return readLocal, nil
 	}
 
+	if pr.canReadOnLearner(req) {
+		return readLearner, nil
+	}
+
+	if pr.store.cfg.Raft.EnableLeaseRead && pr.inLease() {
+		return readLease, nil
+	}
+
 	return readIndex, nil
 }
+
+// inLease returns true if the current peer is the leader with a valid lease, and the leader
+// has applied the entries of the current term which means all entries committed by the
+// previous leaders are applied.
+func (pr *peerReplica) inLease() bool {
+	if !pr.isLeader() {
+		return false
+	}
+
+	status := pr.rn.Status()
+	if status.LeadTransferee != 0 ||
+		pr.ps.appliedIndexTerm != status.Term {
+		return false
+	}
+
+	return pr.lease.inLease(status.Config.Voters, pr.peer.ID, time.Now())
+}
+
+// canReadOnLearner returns true if all the requests allow follower read and the current peer
+// is an initialized learner which is not too stale, the applied index is behind the committed
+// index at most `MaxLearnerReadLag` entries, and the last message from the leader is received
+// within `MaxLearnerReadStaleness`.
+func (pr *peerReplica) canReadOnLearner(req *raftcmdpb.RaftCMDRequest) bool {
+	if !allowFollowerRead(req) {
+		return false
+	}
+
+	// the learner created by the raft message has no data before the snapshot applied
+	if !pr.isLearner() ||
+		!pr.ps.isInitialized() ||
+		pr.getLeaderPeerID() == 0 {
+		return false
+	}
+
+	return isLearnerReadFresh(&pr.store.cfg.Raft, pr.lastLeaderContact, time.Now(),
+		pr.rn.BasicStatus().Commit, pr.ps.getAppliedIndex())
+}
+
+// isLearnerReadFresh returns true if the learner received the message from the leader recently
+// and the applied index is close to the committed index. The committed index of a partitioned
+// learner is stale too, so the lag of the entries is not enough to bound the staleness.
+func isLearnerReadFresh(cfg *config.RaftConfig, lastLeaderContact, now time.Time, committed, applied uint64) bool {
+	if now.Sub(lastLeaderContact) > cfg.MaxLearnerReadStaleness.Duration {
+		return false
+	}
+
+	return committed <= applied+cfg.MaxLearnerReadLag
+}
+
+func allowFollowerRead(req *raftcmdpb.RaftCMDRequest) bool {
+	if len(req.Requests) == 0 {
+		return false
+	}
+
+	for _, r := range req.Requests {
+		if !r.AllowFollower {
+			return false
+		}
+	}
+
+	return true
+}
+
+func allowFollowerReadIndex(req *raftcmdpb.RaftCMDRequest) bool {
+	if len(req.Requests) == 0 {
+		return false
+	}
+
+	for _, r := range req.Requests {
+		if !r.FollowerReadIndex {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAllowedWhenMerging(c cmd) bool {
+	if c.tp == read {
+		return true
+	}
+
+	return c.req.AdminRequest != nil &&
+		(c.req.AdminRequest.CmdType == raftcmdpb.AdminCmdType_RollbackMerge ||
+			c.req.AdminRequest.CmdType == raftcmdpb.AdminCmdType_TransferLeader)
+}
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/matrixorigin/matrixcube/command"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft/v3"
 )
 
 func TestIssue90(t *testing.T) {
@@ -73,3 +75,126 @@ func TestIssue90(t *testing.T) {
 	assert.Nil(t, resps["r2"].Header)
 	assert.Equal(t, "2", string(resps["r2"].Responses[0].Value))
 }
+
+func TestFollowerReadIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	id := c.GetShardByIndex(0, 0).ID
+	leader := c.GetShardLeaderStore(id)
+	assert.NotNil(t, leader)
+
+	var follower Store
+	c.EveryStore(func(i int, s Store) {
+		if s.Meta().ID != leader.Meta().ID {
+			follower = s
+		}
+	})
+
+	// wait the learner promoted to the voter, the learner serves the stale reads
+	timeoutC := time.After(testWaitTimeout)
+	for follower.(*store).getPR(id, false).isLearner() {
+		select {
+		case <-timeoutC:
+			assert.FailNow(t, "wait the follower promoted timeout")
+		default:
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+
+	resps, err := sendTestReqs(leader, testWaitTimeout, nil, nil, createTestWriteReq("w1", "key1", "1"))
+	assert.NoError(t, err)
+	assert.Nil(t, resps["w1"].Header)
+
+	// the follower reads the value written by the leader after the applied index catches up
+	r1 := createTestReadReq("r1", "key1")
+	r1.FollowerReadIndex = true
+	r2 := createTestReadReq("r2", "key1")
+	// the stale read is only served by the learner
+	r3 := createTestReadReq("r3", "key1")
+	r3.AllowFollower = true
+	resps, err = sendTestReqs(follower, testWaitTimeout, nil, nil, r1, r2, r3)
+	assert.NoError(t, err)
+	assert.Nil(t, resps["r1"].Header)
+	assert.Equal(t, "1", string(resps["r1"].Responses[0].Value))
+	assert.NotNil(t, resps["r2"].Header)
+	assert.NotNil(t, resps["r2"].Header.Error.NotLeader)
+	assert.NotNil(t, resps["r3"].Header)
+	assert.NotNil(t, resps["r3"].Header.Error.NotLeader)
+}
+
+func TestWitnessRead(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, witnessTestCluster, GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	id := c.GetShardByIndex(0, 0).ID
+	witness := waitTestWitness(t, c, id)
+
+	// the witness has no data to serve the read
+	r1 := createTestReadReq("r1", "key1")
+	r1.FollowerReadIndex = true
+	resps, err := sendTestReqs(witness, testWaitTimeout, nil, nil, r1)
+	assert.NoError(t, err)
+	assert.NotNil(t, resps["r1"].Header)
+	assert.Equal(t, errWitnessRead.Error(), resps["r1"].Header.Error.Message)
+}
+
+func TestReadIndexQueueFollowerReads(t *testing.T) {
+	var stale []string
+	newTestCMD := func(id string, followerRead bool) cmd {
+		req := pb.AcquireRaftCMDRequest()
+		req.Header = &raftcmdpb.RaftRequestHeader{ID: []byte(id)}
+		r := createTestReadReq(id, "key")
+		r.Key = EncodeDataKey(0, r.Key)
+		req.Requests = append(req.Requests, r)
+		c := newCMD(req, func(resp *raftcmdpb.RaftCMDResponse) {
+			if resp.Header != nil && resp.Header.Error.StaleCommand != nil {
+				stale = append(stale, id)
+			}
+		}, read, 0)
+		c.followerRead = followerRead
+		c.readIndexAt = time.Now()
+		return c
+	}
+
+	q := &readIndexQueue{shardID: 1}
+	q.push(newTestCMD("l1", false))
+	q.push(newTestCMD("f1", true))
+	q.push(newTestCMD("f2", true))
+	q.push(newTestCMD("l2", false))
+	assert.Equal(t, 2, len(q.reads))
+	assert.Equal(t, 2, len(q.followerReads))
+
+	// the follower reads are ready out of order, and the expired responses are ignored
+	q.ready(raft.ReadState{Index: 10, RequestCtx: []byte("f2")})
+	q.ready(raft.ReadState{Index: 10, RequestCtx: []byte("f0")})
+	q.ready(raft.ReadState{Index: 10, RequestCtx: []byte("l1")})
+	assert.Equal(t, 1, q.readyToRead)
+
+	// only the follower reads expire
+	q.removeExpired(time.Now().Add(time.Minute), time.Second, 1)
+	assert.Equal(t, []string{"f1"}, stale)
+	assert.Equal(t, 2, len(q.reads))
+	assert.Equal(t, 1, len(q.followerReads))
+	assert.Equal(t, "f2", string(q.followerReads[0].getUUID()))
+}
+
+func TestIsLearnerReadFresh(t *testing.T) {
+	cfg := &config.RaftConfig{MaxLearnerReadLag: 10}
+	cfg.MaxLearnerReadStaleness.Duration = time.Second * 10
+	now := time.Now()
+
+	assert.True(t, isLearnerReadFresh(cfg, now.Add(-time.Second), now, 20, 10))
+	assert.False(t, isLearnerReadFresh(cfg, now.Add(-time.Second), now, 21, 10))
+	// the learner is partitioned from the leader
+	assert.False(t, isLearnerReadFresh(cfg, now.Add(-time.Second*11), now, 10, 10))
+	assert.False(t, isLearnerReadFresh(cfg, time.Time{}, now, 10, 10))
+}
@@ -97,7 +97,8 @@ func (pr *peerReplica) handleReady() {
 			pr.stopRaftTick = true
 		}
 
-		if !pr.store.snapshotManager.Exists(ctx.snap) {
+		// the witness receives no snapshot data
+		if !pr.isWitness() && !pr.store.snapshotManager.Exists(ctx.snap) {
 			logger.Infof("shard %d peer %d receiving snapshot, skip further handling",
 				pr.shardID,
 				pr.peer.ID)
@@ -126,6 +127,7 @@ func (pr *peerReplica) handleRaftReadyAppend(ctx *readyContext, rd *raft.Ready)
 
 	// If we become leader, send heartbeat to pd
 	if rd.SoftState != nil {
+		pr.lease.reset()
 		if rd.SoftState.RaftState == raft.StateLeader {
 			logger.Infof("shard %d peer %d ********become leader now********",
 				pr.shardID,
@@ -162,6 +164,16 @@ func (pr *peerReplica) handleRaftReadyAppend(ctx *readyContext, rd *raft.Ready)
 	pr.doSaveRaftState(ctx)
 	pr.doSaveApplyState(ctx)
 
+	// the appended entries must be durable before the raft state
+	if !pr.store.cfg.Raft.RaftLog.DisableSync {
+		err := pr.store.RaftLogStorage().Sync()
+		if err != nil {
+			logger.Fatalf("shard %d sync raft log failed with %+v",
+				pr.shardID,
+				err)
+		}
+	}
+
 	err := pr.store.MetadataStorage().Write(ctx.wb, !pr.store.cfg.Raft.RaftLog.DisableSync)
 	if err != nil {
 		logger.Fatalf("shard %d handle raft ready failure, errors\n %+v",
@@ -169,7 +181,22 @@ func (pr *peerReplica) handleRaftReadyAppend(ctx *readyContext, rd *raft.Ready)
 			err)
 	}
 
+	// the entries before the snapshot are useless once the snapshot state is durable, the
+	// entries in the MetadataStorage are already cleared by the ready write batch
+	if !raft.IsEmptySnap(rd.Snapshot) {
+		if _, ok := pr.store.RaftLogStorage().(*metaRaftLogStorage); !ok {
+			err := pr.store.RaftLogStorage().Compact(pr.shardID, rd.Snapshot.Metadata.Index+1)
+			if err != nil {
+				logger.Fatalf("shard %d compact raft log to snapshot %d failed with %+v",
+					pr.shardID,
+					rd.Snapshot.Metadata.Index,
+					err)
+			}
+		}
+	}
+
 	metric.ObserveRaftLogAppendDuration(start)
+	pr.store.slowScore.observeAppend(start)
 }
 
 func (pr *peerReplica) handleAppendSnapshot(ctx *readyContext, rd *raft.Ready) {
@@ -260,32 +287,22 @@ func (pr *peerReplica) doAppendEntries(ctx *readyContext, entries []raftpb.Entry
 		return nil
 	}
 
-	prevLastIndex := ctx.raftState.LastIndex
 	lastIndex := entries[c-1].Index
 	lastTerm := entries[c-1].Term
 
-	for _, e := range entries {
-		d := protoc.MustMarshal(&e)
-		err := ctx.wb.Set(getRaftLogKey(pr.shardID, e.Index), d)
-		if err != nil {
-			logger.Fatalf("shard %d append entry <%s> failed with %+v",
-				pr.shardID,
-				e.String(),
-				err)
-			return err
-		}
+	// the entries saved in the MetadataStorage are written by the ready write batch, after the
+	// raft keys cleared by the snapshot
+	var err error
+	if s, ok := pr.store.RaftLogStorage().(*metaRaftLogStorage); ok {
+		err = s.appendTo(ctx.wb, pr.shardID, entries, ctx.raftState.LastIndex)
+	} else {
+		err = pr.store.RaftLogStorage().Append(pr.shardID, entries)
 	}
-
-	// Delete any previously appended log entries which never committed.
-	for index := lastIndex + 1; index < prevLastIndex+1; index++ {
-		err := ctx.wb.Delete(getRaftLogKey(pr.shardID, index))
-		if err != nil {
-			logger.Fatalf("shard %d delete any previously appended log entries %d failed with %+v",
-				pr.shardID,
-				index,
-				err)
-			return err
-		}
+	if err != nil {
+		logger.Fatalf("shard %d append entries failed with %+v",
+			pr.shardID,
+			err)
+		return err
 	}
 
 	ctx.raftState.LastIndex = lastIndex
@@ -492,6 +509,13 @@ func (pr *peerReplica) sendRaftMsg(msg raftpb.Message) error {
 		sendMsg.End = pr.ps.shard.End
 	}
 
+	// the heartbeats sent during transferring leader can't extend the lease, because the
+	// transferee campaigns without the lease check of the followers.
+	if pr.store.cfg.Raft.EnableLeaseRead &&
+		pr.rn.BasicStatus().LeadTransferee == 0 {
+		pr.lease.onSend(msg, time.Now())
+	}
+
 	sendMsg.Message = msg
 	pr.store.trans.Send(sendMsg)
 
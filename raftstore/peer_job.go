@@ -36,9 +36,14 @@ func (pr *peerReplica) startApplyingSnapJob() {
 }
 
 func (pr *peerReplica) startApplyCommittedEntriesJob(shardID uint64, term uint64, commitedEntries []raftpb.Entry) error {
+	pr.store.slowScore.incApplyQueue()
 	err := pr.store.addApplyJob(pr.applyWorker, "doApplyCommittedEntries", func() error {
+		pr.store.slowScore.decApplyQueue()
 		return pr.doApplyCommittedEntries(shardID, term, commitedEntries)
 	}, nil)
+	if err != nil {
+		pr.store.slowScore.decApplyQueue()
+	}
 	return err
 }
 
@@ -63,6 +68,19 @@ func (s *store) startDestroyJob(shardID uint64, peer metapb.Peer, why string) er
 	return nil
 }
 
+func (s *store) startDestroyMergedJob(result *mergeResult) error {
+	pr := s.getPR(result.source.ID, false)
+	if pr != nil {
+		err := s.addApplyJob(pr.applyWorker, "doDestroyMerged", func() error {
+			s.doDestroyMerged(result.source.ID, result)
+			return nil
+		}, nil)
+		return err
+	}
+
+	return nil
+}
+
 func (pr *peerReplica) startProposeJob(c cmd, isConfChange bool) error {
 	err := pr.store.addApplyJob(pr.applyWorker, "doPropose", func() error {
 		return pr.doPropose(c, isConfChange)
@@ -88,6 +106,22 @@ func (pr *peerReplica) startSplitCheckJob() error {
 	return err
 }
 
+func (pr *peerReplica) startComputeHashJob(index uint64, compute func() ([]byte, error)) error {
+	return pr.store.addHashJob(func() error {
+		hash, err := compute()
+		if err != nil {
+			logger.Errorf("shard %d compute hash at index %d failed with %+v",
+				pr.shardID,
+				index,
+				err)
+			return err
+		}
+
+		pr.addAction(action{actionType: hashComputedAction, hash: hashResult{index: index, hash: hash}})
+		return nil
+	})
+}
+
 func (ps *peerStorage) cancelApplyingSnapJob() bool {
 	ps.applySnapJobLock.RLock()
 	if ps.applySnapJob == nil {
@@ -33,6 +33,7 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/snapshot"
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util"
 	"go.etcd.io/etcd/raft/v3"
@@ -117,16 +118,19 @@ type peerReplica struct {
 
 	batch        *proposeBatch
 	pendingReads *readIndexQueue
-	ctx          context.Context
-	cancel       context.CancelFunc
-	items        []interface{}
-	events       *task.RingBuffer
-	ticks        *task.Queue
-	steps        *task.Queue
-	reports      *task.Queue
-	applyResults *task.Queue
-	requests     *task.Queue
-	actions      *task.Queue
+	lease        *leaderLease
+	// lastLeaderContact the time of the last message received from the leader
+	lastLeaderContact time.Time
+	ctx               context.Context
+	cancel            context.CancelFunc
+	items             []interface{}
+	events            *task.RingBuffer
+	ticks             *task.Queue
+	steps             *task.Queue
+	reports           *task.Queue
+	applyResults      *task.Queue
+	requests          *task.Queue
+	actions           *task.Queue
 
 	writtenKeys     uint64
 	writtenBytes    uint64
@@ -138,6 +142,12 @@ type peerReplica struct {
 	// TODO: setting on split check
 	approximateSize uint64
 	approximateKeys uint64
+	// lastHash is the local hash computed by the last ComputeHash, expectedHash is the leader's
+	// hash received before the local hash computed.
+	lastHash     hashResult
+	expectedHash hashResult
+	// checkMergeScheduled the merge check is scheduled, see scheduleCheckMerge
+	checkMergeScheduled bool
 
 	metrics  localMetrics
 	stopOnce sync.Once
@@ -224,6 +234,9 @@ func (pr *peerReplica) start() {
 	pr.pendingReads = &readIndexQueue{
 		shardID: pr.ps.shard.ID,
 	}
+	pr.lease = newLeaderLease(pr.store.cfg.Raft.ElectionTimeoutTicks,
+		pr.store.cfg.Raft.TickInterval.Duration,
+		pr.store.cfg.Raft.LeaseMaxClockDrift.Duration)
 
 	pr.ctx, pr.cancel = context.WithCancel(context.Background())
 	pr.items = make([]interface{}, readyBatch)
@@ -303,6 +316,7 @@ func (pr *peerReplica) registerDelegate() {
 		applyState:       pr.ps.raftApplyState,
 		appliedIndexTerm: pr.ps.appliedIndexTerm,
 		ctx:              newApplyContext(pr),
+		mergeState:       pr.ps.mergeState,
 		syncData: pr.store.cfg.Customize.CustomAdjustInitAppliedIndexFactory != nil &&
 			pr.store.cfg.Customize.CustomAdjustInitAppliedIndexFactory(pr.ps.shard.Group) != nil,
 	}
@@ -324,6 +338,7 @@ func (pr *peerReplica) registerDelegate() {
 			old.term = delegate.term
 			old.applyState = delegate.applyState
 			old.appliedIndexTerm = delegate.appliedIndexTerm
+			old.mergeState = delegate.mergeState
 			old.clearAllCommandsAsStale()
 			return nil
 		}, nil)
@@ -370,6 +385,16 @@ func (pr *peerReplica) isLeader() bool {
 	return pr.getLeaderPeerID() == pr.peer.ID
 }
 
+func (pr *peerReplica) isLearner() bool {
+	for _, p := range pr.ps.shard.Peers {
+		if p.ID == pr.peer.ID {
+			return p.Role == metapb.PeerRole_Learner
+		}
+	}
+
+	return false
+}
+
 func (pr *peerReplica) getLeaderPeerID() uint64 {
 	return atomic.LoadUint64(&pr.leaderID)
 }
@@ -383,7 +408,23 @@ func (pr *peerReplica) notifyWorker() {
 	pr.store.workReady.notify(pr.ps.shard.Group, pr.eventWorker)
 }
 
+func (pr *peerReplica) isWitness() bool {
+	for _, p := range pr.ps.shard.Peers {
+		if p.ID == pr.peer.ID {
+			return p.Role == metapb.PeerRole_Witness
+		}
+	}
+
+	// the shard metadata is not received yet if the peer is created by the raft message
+	return pr.peer.Role == metapb.PeerRole_Witness
+}
+
 func (pr *peerReplica) maybeCampaign() (bool, error) {
+	if pr.isWitness() {
+		// The witness never becomes the leader.
+		return false, nil
+	}
+
 	if len(pr.ps.shard.Peers) <= 1 {
 		// The peer campaigned when it was created, no need to do it again.
 		return false, nil
@@ -398,9 +439,19 @@ func (pr *peerReplica) maybeCampaign() (bool, error) {
 }
 
 func (pr *peerReplica) mustDestroy(why string) {
+	pr.doMustDestroy(why, nil)
+}
+
+// mustDestroyMerged destroy the peer which was merged into the target shard. The data
+// of the merged shard is owned by the target shard, so only the metadata will be removed.
+func (pr *peerReplica) mustDestroyMerged(merged *mergeResult) {
+	pr.doMustDestroy(fmt.Sprintf("merged into shard %d", merged.shard.ID), merged)
+}
+
+func (pr *peerReplica) doMustDestroy(why string, merged *mergeResult) {
 	if pr.ps.isApplyingSnapshot() {
 		util.DefaultTimeoutWheel().Schedule(time.Second*30, func(interface{}) {
-			pr.mustDestroy(why)
+			pr.doMustDestroy(why, merged)
 		}, nil)
 		logger.Infof("shard %d peer %d  is applying snapshot, retry destory later",
 			pr.shardID,
@@ -424,7 +475,11 @@ func (pr *peerReplica) mustDestroy(why string) {
 
 	wb := util.NewWriteBatch()
 	pr.store.clearMeta(pr.shardID, wb)
-	pr.store.updatePeerState(pr.ps.shard, bhraftpb.PeerState_Tombstone, wb)
+	if merged != nil {
+		pr.store.updateMergeState(merged.source, bhraftpb.PeerState_Tombstone, merged.mergeState, wb)
+	} else {
+		pr.store.updatePeerState(pr.ps.shard, bhraftpb.PeerState_Tombstone, wb)
+	}
 	err := pr.store.MetadataStorage().Write(wb, false)
 	if err != nil {
 		logger.Fatal("shard %d do destroy failed with %+v",
@@ -432,7 +487,14 @@ func (pr *peerReplica) mustDestroy(why string) {
 			err)
 	}
 
-	if pr.ps.isInitialized() {
+	err = pr.store.RaftLogStorage().RemoveShard(pr.shardID)
+	if err != nil {
+		logger.Fatalf("shard %d remove raft log failed with %+v",
+			pr.shardID,
+			err)
+	}
+
+	if merged == nil && pr.ps.isInitialized() {
 		err := pr.store.startClearDataJob(pr.ps.shard)
 		if err != nil {
 			logger.Fatal("shard %d do destroy failed with %+v",
@@ -443,7 +505,7 @@ func (pr *peerReplica) mustDestroy(why string) {
 
 	pr.cancel()
 
-	if pr.ps.isInitialized() && !pr.store.removeShardKeyRange(pr.ps.shard) {
+	if merged == nil && pr.ps.isInitialized() && !pr.store.removeShardKeyRange(pr.ps.shard) {
 		logger.Warningf("shard %d remove key range failed",
 			pr.shardID)
 	}
@@ -457,6 +519,32 @@ func (pr *peerReplica) onReq(req *raftcmdpb.Request, cb func(*raftcmdpb.RaftCMDR
 	metric.IncComandCount(hack.SliceToString(format.UInt64ToString(req.CustemType)))
 
 	r := reqCtx{}
+	if req.Type == raftcmdpb.CMDType_Admin {
+		// only the admin requests which have no side effects on the raft group are allowed
+		// to be sent by the client
+		admin := &raftcmdpb.AdminRequest{}
+		if err := admin.Unmarshal(req.Cmd); err != nil {
+			return err
+		}
+		if admin.CmdType != raftcmdpb.AdminCmdType_Ingest &&
+			admin.CmdType != raftcmdpb.AdminCmdType_Backup {
+			return errUnsupportedAdmin
+		}
+
+		if admin.CmdType == raftcmdpb.AdminCmdType_Ingest && admin.Ingest == nil {
+			return errUnsupportedAdmin
+		}
+		if isIngest(admin) && !admin.Ingest.Discard {
+			if _, ok := pr.store.snapshotManager.(snapshot.IngestManager); !ok {
+				return errBulkLoadUnsupported
+			}
+		}
+
+		r.admin = admin
+		r.cb = adminRespCB(req, cb)
+		return pr.addRequest(r)
+	}
+
 	r.req = req
 	r.cb = cb
 	return pr.addRequest(r)
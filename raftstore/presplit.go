@@ -0,0 +1,123 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+)
+
+const (
+	// maxCreateShardsBatch the max number of shards created by prophet in a request
+	maxCreateShardsBatch = 4
+)
+
+var (
+	errUnsortedSplitKeys = errors.New("the split keys are not in ascending order")
+	errShardGroupCreated = errors.New("the group already has shards")
+)
+
+// CreateShardGroup creates the shards of the new group pre-split by the split keys, the shards cover
+// the whole key range of the group. Once the shards are created, prophet scatters their peers and
+// leaders across the stores, so the load of the group is spread from the beginning instead of
+// waiting for the splits and the balance schedulers. The group must be one of the configured
+// groups and has no shards yet, otherwise the new shards would overlap the existing ones.
+//
+// The shards are named by the unique and their index, and prophet skips the shards already created
+// with the same name, so a failed call can be retried with the same unique and split keys, the
+// shards created by the failed call don't fail the retry.
+func (s *store) CreateShardGroup(group uint64, unique string, splitKeys [][]byte) error {
+	if group >= s.cfg.ShardGroups {
+		return fmt.Errorf("invalid group %d, the store has %d groups", group, s.cfg.ShardGroups)
+	}
+
+	shards, err := newPreSplitShards(group, unique, splitKeys)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{}, len(shards))
+	for _, shard := range shards {
+		names[shard.Unique] = struct{}{}
+	}
+	isPreSplit := func(shard bhmetapb.Shard) bool {
+		_, ok := names[shard.Unique]
+		return ok
+	}
+
+	// the router is updated asynchronously by the prophet events, so the local shards are
+	// also checked
+	created := false
+	s.router.ForeachShards(group, func(shard *bhmetapb.Shard) bool {
+		created = !isPreSplit(*shard)
+		return !created
+	})
+	s.foreachPR(func(pr *peerReplica) bool {
+		created = created || (pr.ps.shard.Group == group && !isPreSplit(pr.ps.shard))
+		return !created
+	})
+	if created {
+		return errShardGroupCreated
+	}
+
+	for start := 0; start < len(shards); start += maxCreateShardsBatch {
+		end := start + maxCreateShardsBatch
+		if end > len(shards) {
+			end = len(shards)
+		}
+
+		var resources []metadata.Resource
+		for _, shard := range shards[start:end] {
+			resources = append(resources, NewResourceAdapterWithShard(shard))
+		}
+		if err := s.pd.GetClient().AsyncAddAndScatterResources(resources...); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("group %d created with %d pre-split shards", group, len(shards))
+	return nil
+}
+
+func newPreSplitShards(group uint64, unique string, splitKeys [][]byte) ([]bhmetapb.Shard, error) {
+	for idx, key := range splitKeys {
+		if len(key) == 0 {
+			return nil, fmt.Errorf("the split key %d is empty", idx)
+		}
+		if idx > 0 && bytes.Compare(splitKeys[idx-1], key) >= 0 {
+			return nil, errUnsortedSplitKeys
+		}
+	}
+
+	shards := make([]bhmetapb.Shard, 0, len(splitKeys)+1)
+	var start []byte
+	for idx := 0; idx <= len(splitKeys); idx++ {
+		var end []byte
+		if idx < len(splitKeys) {
+			end = splitKeys[idx]
+		}
+		shards = append(shards, bhmetapb.Shard{
+			Group:  group,
+			Start:  start,
+			End:    end,
+			Unique: fmt.Sprintf("%s-%d", unique, idx),
+		})
+		start = end
+	}
+	return shards, nil
+}
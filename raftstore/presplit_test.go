@@ -0,0 +1,105 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPreSplitShards(t *testing.T) {
+	shards, err := newPreSplitShards(1, "t1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []bhmetapb.Shard{{Group: 1, Unique: "t1-0"}}, shards)
+
+	shards, err = newPreSplitShards(1, "t1", [][]byte{[]byte("b"), []byte("c")})
+	assert.NoError(t, err)
+	assert.Equal(t, []bhmetapb.Shard{
+		{Group: 1, End: []byte("b"), Unique: "t1-0"},
+		{Group: 1, Start: []byte("b"), End: []byte("c"), Unique: "t1-1"},
+		{Group: 1, Start: []byte("c"), Unique: "t1-2"},
+	}, shards)
+
+	_, err = newPreSplitShards(1, "t1", [][]byte{[]byte("c"), []byte("b")})
+	assert.Equal(t, errUnsortedSplitKeys, err)
+	_, err = newPreSplitShards(1, "t1", [][]byte{[]byte("b"), []byte("b")})
+	assert.Equal(t, errUnsortedSplitKeys, err)
+	_, err = newPreSplitShards(1, "t1", [][]byte{nil})
+	assert.Error(t, err)
+}
+
+func TestCreateShardGroup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(i int, cfg *config.Config) {
+		cfg.ShardGroups = 2
+		cfg.Prophet.Replication.Groups = []uint64{0, 1}
+	}))
+	defer c.Stop()
+
+	c.Start()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+
+	splitKeys := [][]byte{[]byte("b"), []byte("c"), []byte("d"), []byte("e"), []byte("f")}
+	assert.NoError(t, c.GetStore(0).CreateShardGroup(1, "t1", splitKeys))
+	c.WaitShardByCountPerNode(7, testWaitTimeout)
+
+	// the group already has shards
+	assert.Equal(t, errShardGroupCreated, c.GetStore(0).CreateShardGroup(1, "t2", splitKeys))
+	assert.Equal(t, errShardGroupCreated, c.GetStore(0).CreateShardGroup(0, "t2", splitKeys))
+	// the group is out of range
+	assert.Error(t, c.GetStore(0).CreateShardGroup(2, "t2", splitKeys))
+	time.Sleep(time.Second)
+	c.CheckShardCount(7)
+
+	// the retry with the same unique and split keys is a no-op
+	assert.NoError(t, c.GetStore(0).CreateShardGroup(1, "t1", splitKeys))
+	time.Sleep(time.Second)
+	c.CheckShardCount(7)
+}
+
+func TestCreateShardGroupRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(i int, cfg *config.Config) {
+		cfg.ShardGroups = 2
+		cfg.Prophet.Replication.Groups = []uint64{0, 1}
+	}))
+	defer c.Stop()
+
+	c.Start()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+
+	// only the first batch is created, as the call failed after that
+	splitKeys := [][]byte{[]byte("b"), []byte("c"), []byte("d"), []byte("e"), []byte("f")}
+	shards, err := newPreSplitShards(1, "t1", splitKeys)
+	assert.NoError(t, err)
+	var resources []metadata.Resource
+	for _, shard := range shards[:maxCreateShardsBatch] {
+		resources = append(resources, NewResourceAdapterWithShard(shard))
+	}
+	s := c.GetStore(0).(*store)
+	assert.NoError(t, s.pd.GetClient().AsyncAddAndScatterResources(resources...))
+	c.WaitShardByCountPerNode(1+maxCreateShardsBatch, testWaitTimeout)
+
+	assert.Equal(t, errShardGroupCreated, c.GetStore(0).CreateShardGroup(1, "t2", splitKeys))
+	assert.NoError(t, c.GetStore(0).CreateShardGroup(1, "t1", splitKeys))
+	c.WaitShardByCountPerNode(7, testWaitTimeout)
+	time.Sleep(time.Second)
+	c.CheckShardCount(7)
+}
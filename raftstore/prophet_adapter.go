@@ -238,7 +238,8 @@ func (ca *containerAdapter) Clone() metadata.Container {
 type prophetAdapter struct {
 }
 
-func newProphetAdapter() metadata.Adapter {
+// NewProphetAdapter returns a prophet metadata adapter, which use shard as resource and store as container
+func NewProphetAdapter() metadata.Adapter {
 	return &prophetAdapter{}
 }
 
@@ -335,8 +336,9 @@ func (s *store) doStoreHeartbeat(last time.Time) {
 		stats.ReadBytes += st.ReadBytes
 	})
 
-	// TODO: is busy
-	stats.IsBusy = false
+	score, latencies := s.slowScore.tick()
+	stats.IsBusy = score >= busySlowScore
+	stats.OpLatencies = latencies
 	stats.Interval = &metapb.TimeInterval{
 		Start: uint64(last.Unix()),
 		End:   uint64(time.Now().Unix()),
@@ -347,11 +349,19 @@ func (s *store) doStoreHeartbeat(last time.Time) {
 		data = s.cfg.Customize.CustomStoreHeartbeatDataProcessor.CollectData()
 	}
 
-	rsp, err := s.pd.GetClient().ContainerHeartbeat(rpcpb.ContainerHeartbeatReq{Stats: stats, Data: data})
+	rsp, err := s.pd.GetClient().ContainerHeartbeat(rpcpb.ContainerHeartbeatReq{
+		Stats:             stats,
+		Data:              data,
+		WriteFrozenGroups: s.freezer.frozenGroups(),
+	})
 	if err != nil {
 		logger.Errorf("send store heartbeat failed with %+v", err)
 		return
 	}
+	s.limiter.update(rsp.RateLimits)
+	if s.freezer.update(rsp.WriteFrozenGroups) {
+		s.triggerStoreHeartbeat()
+	}
 	if s.cfg.Customize.CustomStoreHeartbeatDataProcessor != nil {
 		err := s.cfg.Customize.CustomStoreHeartbeatDataProcessor.HandleHeartbeatRsp(rsp.Data)
 		if err != nil {
@@ -402,6 +412,17 @@ func (s *store) doResourceHeartbeatRsp(rsp rpcpb.ResourceHeartbeatRsp) {
 		pr.onAdmin(newChangePeerV2AdminReq(rsp))
 	} else if rsp.TransferLeader != nil {
 		pr.onAdmin(newTransferLeaderAdminReq(rsp))
+	} else if rsp.Merge != nil {
+		target := bhmetapb.Shard{}
+		protoc.MustUnmarshal(&target, rsp.Merge.Target)
+		logger.Infof("shard-%d merge into shard %d",
+			rsp.ResourceID,
+			target.ID)
+		pr.addAction(action{
+			epoch:      rsp.ResourceEpoch,
+			actionType: prepareMergeAction,
+			target:     target,
+		})
 	} else if rsp.SplitResource != nil {
 		// currently, pd only support use keys to splits
 		switch rsp.SplitResource.Policy {
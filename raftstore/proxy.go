@@ -17,9 +17,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
@@ -34,8 +36,30 @@ var (
 var (
 	// RetryInterval retry interval
 	RetryInterval = time.Second
+	// BusyRetryInterval the initial interval to retry the requests rejected by the busy
+	// servers, the interval doubles for each retry of the request up to MaxBusyRetryInterval
+	BusyRetryInterval = time.Millisecond * 50
+	// MaxBusyRetryInterval the max interval to retry the requests rejected by the busy servers
+	MaxBusyRetryInterval = time.Second * 2
 )
 
+// ShardsProxyOption the option to create the ShardsProxy
+type ShardsProxyOption func(*shardsProxyOptions)
+
+type shardsProxyOptions struct {
+	learnerReadLabels []metapb.Pair
+}
+
+// WithLearnerReadLabels routes the read requests which allow follower read to the learner
+// peers whose store has all the labels, the requests are routed to the leader if there is
+// no matched learner peer, and retried on a random peer. It's used to offload the reads to the learners in a dedicated
+// zone placed by the placement rules.
+func WithLearnerReadLabels(labels []metapb.Pair) ShardsProxyOption {
+	return func(opts *shardsProxyOptions) {
+		opts.learnerReadLabels = labels
+	}
+}
+
 type doneFunc func(*raftcmdpb.Response)
 type errorDoneFunc func(*raftcmdpb.Request, error)
 
@@ -50,18 +74,25 @@ type ShardsProxy interface {
 // NewShardsProxy returns a shard proxy
 func NewShardsProxy(router Router,
 	doneCB doneFunc,
-	errorDoneCB errorDoneFunc) ShardsProxy {
-	return &shardsProxy{
+	errorDoneCB errorDoneFunc,
+	opts ...ShardsProxyOption) ShardsProxy {
+	sp := &shardsProxy{
 		router:      router,
 		doneCB:      doneCB,
 		errorDoneCB: errorDoneCB,
 	}
+
+	for _, opt := range opts {
+		opt(&sp.opts)
+	}
+	return sp
 }
 
 // NewShardsProxyWithStore returns a shard proxy with a raftstore
 func NewShardsProxyWithStore(store Store,
 	doneCB doneFunc,
 	errorDoneCB errorDoneFunc,
+	opts ...ShardsProxyOption,
 ) (ShardsProxy, error) {
 	sp := &shardsProxy{
 		store:       store,
@@ -71,24 +102,71 @@ func NewShardsProxyWithStore(store Store,
 		errorDoneCB: errorDoneCB,
 	}
 
+	for _, opt := range opts {
+		opt(&sp.opts)
+	}
+
 	sp.store.RegisterLocalRequestCB(sp.onLocalResp)
 	return sp, nil
 }
 
+// newRPCShardsProxy returns a shard proxy which sends all the requests by rpc, even to the local
+// store. It's used by the jobs running in the store, the local request cb of the store is kept for
+// the proxy of the application.
+func newRPCShardsProxy(store Store,
+	doneCB doneFunc,
+	errorDoneCB errorDoneFunc) *shardsProxy {
+	return &shardsProxy{
+		store:       store,
+		router:      store.GetRouter(),
+		doneCB:      doneCB,
+		errorDoneCB: errorDoneCB,
+	}
+}
+
 type shardsProxy struct {
+	opts        shardsProxyOptions
 	local       bhmetapb.Store
 	store       Store
 	router      Router
 	doneCB      doneFunc
 	errorDoneCB errorDoneFunc
 	backends    sync.Map // store addr -> *backend
+	busyRetries sync.Map // request id -> the retry times of the request rejected by the busy servers
+	// busyRetrying the number of the requests in busyRetries
+	busyRetrying int64
 }
 
 func (p *shardsProxy) Dispatch(req *raftcmdpb.Request) error {
 	shard, to := p.router.SelectShard(req.Group, req.Key)
+	if store := p.selectReadStore(req, shard); store != "" {
+		to = store
+	}
 	return p.DispatchTo(req, shard, to)
 }
 
+// selectReadStore returns the store address to serve the read request which is not required
+// to be served by the leader. The stale read which allows follower read is served by the learner
+// store matches the learner read labels, and the linearizable read with the follower ReadIndex
+// is served by a store of all the peers in turn.
+func (p *shardsProxy) selectReadStore(req *raftcmdpb.Request, shard uint64) string {
+	if req.Type != raftcmdpb.CMDType_Read {
+		return ""
+	}
+
+	if req.AllowFollower && len(p.opts.learnerReadLabels) > 0 {
+		if store := p.router.LearnerPeerStore(shard, p.opts.learnerReadLabels).ClientAddr; store != "" {
+			return store
+		}
+	}
+
+	if req.FollowerReadIndex {
+		return p.router.RandomPeerStore(shard).ClientAddr
+	}
+
+	return ""
+}
+
 func (p *shardsProxy) DispatchTo(req *raftcmdpb.Request, shard uint64, to string) error {
 	// No leader, retry after a leader tick
 	if to == "" {
@@ -110,6 +188,16 @@ func (p *shardsProxy) Router() Router {
 	return p.router
 }
 
+// stop stops the write loops of all the backends and closes the connections
+func (p *shardsProxy) stop() {
+	p.backends.Range(func(key, value interface{}) bool {
+		bc := value.(*backend)
+		bc.reqs.Put(closeFlag)
+		bc.conn.Close()
+		return true
+	})
+}
+
 func (p *shardsProxy) forwardToBackend(req *raftcmdpb.Request, leader string) error {
 	if p.store != nil && p.local.ClientAddr == leader {
 		req.PID = 0
@@ -121,12 +209,16 @@ func (p *shardsProxy) forwardToBackend(req *raftcmdpb.Request, leader string) er
 		return err
 	}
 
+	// the requests without token are created by the store itself
+	if p.store != nil && req.Token == "" {
+		req.Token = p.store.GetConfig().InternalToken
+	}
 	return bc.addReq(req)
 }
 
 func (p *shardsProxy) onLocalResp(header *raftcmdpb.RaftResponseHeader, rsp *raftcmdpb.Response) {
 	if header != nil {
-		if header.Error.RaftEntryTooLarge == nil {
+		if header.Error.RaftEntryTooLarge == nil && header.Error.WriteFrozen == nil {
 			rsp.Type = raftcmdpb.CMDType_RaftError
 		} else {
 			rsp.Type = raftcmdpb.CMDType_Invalid
@@ -141,26 +233,34 @@ func (p *shardsProxy) onLocalResp(header *raftcmdpb.RaftResponseHeader, rsp *raf
 
 func (p *shardsProxy) done(rsp *raftcmdpb.Response) {
 	if rsp.Type == raftcmdpb.CMDType_Invalid && rsp.Error.Message != "" {
+		p.removeBusyRetries(rsp.ID)
 		p.errorDoneCB(rsp.OriginRequest, errors.New(rsp.Error.String()))
 		return
 	}
 
 	if rsp.Type != raftcmdpb.CMDType_RaftError && !rsp.Stale {
+		p.removeBusyRetries(rsp.ID)
 		p.doneCB(rsp)
 		return
 	}
 
-	p.retryWithRaftError(rsp.OriginRequest, rsp.Error.String(), RetryInterval)
+	later := RetryInterval
+	if rsp.Error.ServerIsBusy != nil {
+		later = p.nextBusyRetryInterval(rsp.OriginRequest)
+	}
+	p.retryWithRaftError(rsp.OriginRequest, rsp.Error.String(), later)
 	pb.ReleaseResponse(rsp)
 }
 
 func (p *shardsProxy) errorDone(req *raftcmdpb.Request, err error) {
+	p.removeBusyRetries(req.ID)
 	p.errorDoneCB(req, err)
 }
 
 func (p *shardsProxy) retryWithRaftError(req *raftcmdpb.Request, err string, later time.Duration) {
 	if req != nil {
 		if time.Now().Unix() >= req.StopAt {
+			p.removeBusyRetries(req.ID)
 			p.errorDoneCB(req, errors.New(err))
 			return
 		}
@@ -169,6 +269,41 @@ func (p *shardsProxy) retryWithRaftError(req *raftcmdpb.Request, err string, lat
 	}
 }
 
+// nextBusyRetryInterval returns the interval to retry the request rejected by the busy server,
+// the interval doubles for each retry of the request up to MaxBusyRetryInterval.
+func (p *shardsProxy) nextBusyRetryInterval(req *raftcmdpb.Request) time.Duration {
+	if req == nil {
+		return BusyRetryInterval
+	}
+
+	times := 0
+	if v, ok := p.busyRetries.Load(string(req.ID)); ok {
+		times = v.(int)
+	} else {
+		atomic.AddInt64(&p.busyRetrying, 1)
+	}
+	p.busyRetries.Store(string(req.ID), times+1)
+
+	later := BusyRetryInterval
+	for i := 0; i < times && later < MaxBusyRetryInterval; i++ {
+		later *= 2
+	}
+	if later > MaxBusyRetryInterval {
+		later = MaxBusyRetryInterval
+	}
+	return later
+}
+
+func (p *shardsProxy) removeBusyRetries(id []byte) {
+	if atomic.LoadInt64(&p.busyRetrying) == 0 {
+		return
+	}
+
+	if _, ok := p.busyRetries.LoadAndDelete(string(id)); ok {
+		atomic.AddInt64(&p.busyRetrying, -1)
+	}
+}
+
 func (p *shardsProxy) doRetry(arg interface{}) {
 	req := arg.(raftcmdpb.Request)
 	if req.ToShard == 0 {
@@ -176,14 +311,21 @@ func (p *shardsProxy) doRetry(arg interface{}) {
 		return
 	}
 
-	to := ""
-	if req.AllowFollower {
-		to = p.router.RandomPeerStore(req.ToShard).ClientAddr
-	} else {
-		to = p.router.LeaderPeerStore(req.ToShard).ClientAddr
+	p.DispatchTo(&req, req.ToShard, p.selectRetryStore(&req, req.ToShard))
+}
+
+// selectRetryStore returns the store address to retry the request. The retried read which allows
+// follower read is sent to a random peer if there is no learner matches the learner read labels,
+// since the leader of the shard may be unavailable, the others are sent to the leader.
+func (p *shardsProxy) selectRetryStore(req *raftcmdpb.Request, shard uint64) string {
+	if store := p.selectReadStore(req, shard); store != "" {
+		return store
 	}
 
-	p.DispatchTo(&req, req.ToShard, to)
+	if req.AllowFollower {
+		return p.router.RandomPeerStore(shard).ClientAddr
+	}
+	return p.router.LeaderPeerStore(shard).ClientAddr
 }
 
 func (p *shardsProxy) getConn(addr string) (*backend, error) {
@@ -205,8 +347,8 @@ func (p *shardsProxy) getConnLocked(addr string) *backend {
 
 func (p *shardsProxy) createConn(addr string) *backend {
 	encoder, decoder := p.store.CreateRPCCliendSideCodec()
-	bc := newBackend(p, addr,
-		goetty.NewIOSession(goetty.WithCodec(encoder, decoder)))
+	opts := append([]goetty.Option{goetty.WithCodec(encoder, decoder)}, p.store.GetTLS().SessionOptions()...)
+	bc := newBackend(p, addr, goetty.NewIOSession(opts...))
 
 	old, loaded := p.backends.LoadOrStore(addr, bc)
 	if loaded {
@@ -232,7 +374,15 @@ func (p *shardsProxy) checkConnect(bc *backend) bool {
 		return true
 	}
 
-	ok, err := bc.conn.Connect(bc.addr, defaultConnectTimeout)
+	addr, err := p.store.GetTLS().DialAddr(bc.addr)
+	if err != nil {
+		logger.Errorf("create tls tunnel to backend %s failed with %+v",
+			bc.addr,
+			err)
+		return false
+	}
+
+	ok, err := bc.conn.Connect(addr, defaultConnectTimeout)
 	if err != nil {
 		logger.Errorf("connect to backend %s failed with %+v",
 			bc.addr,
@@ -0,0 +1,234 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// metaRaftLogStorage saves the raft log entries as the keys of the MetadataStorage, it's
+// used if no RaftLogStorage is configured. The raft ready appends the entries into its own
+// write batch by `appendTo`, so the entries are written with the raft state in one write, and
+// the `Sync` does nothing.
+type metaRaftLogStorage struct {
+	meta storage.MetadataStorage
+}
+
+func newMetaRaftLogStorage(meta storage.MetadataStorage) storage.RaftLogStorage {
+	return &metaRaftLogStorage{meta: meta}
+}
+
+func (s *metaRaftLogStorage) Append(shardID uint64, entries []raftpb.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	lastIndex, err := s.LastIndex(shardID)
+	if err != nil {
+		return err
+	}
+
+	wb := util.NewWriteBatch()
+	if err := s.appendTo(wb, shardID, entries, lastIndex); err != nil {
+		return err
+	}
+	return s.meta.Write(wb, false)
+}
+
+// appendTo adds the entries into the write batch, prevLastIndex is the last index before the
+// entries appended.
+func (s *metaRaftLogStorage) appendTo(wb *util.WriteBatch, shardID uint64, entries []raftpb.Entry, prevLastIndex uint64) error {
+	for _, e := range entries {
+		err := wb.Set(getRaftLogKey(shardID, e.Index), protoc.MustMarshal(&e))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete any previously appended log entries which never committed.
+	for index := entries[len(entries)-1].Index + 1; index <= prevLastIndex; index++ {
+		err := wb.Delete(getRaftLogKey(shardID, index))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *metaRaftLogStorage) Sync() error {
+	return nil
+}
+
+func (s *metaRaftLogStorage) Entries(shardID uint64, low, high, maxSize uint64) ([]raftpb.Entry, error) {
+	var ents []raftpb.Entry
+	startKey := getRaftLogKey(shardID, low)
+
+	if low+1 == high {
+		// If election happens in inactive shards, they will just try
+		// to fetch one empty log.
+		v, err := s.meta.Get(startKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(v) == 0 {
+			return nil, raft.ErrUnavailable
+		}
+
+		e, err := unmarshalRaftLog(shardID, v, low)
+		if err != nil {
+			return nil, err
+		}
+
+		ents = append(ents, e)
+		return ents, nil
+	}
+
+	var totalSize uint64
+	nextIndex := low
+	exceededMaxSize := false
+	endKey := getRaftLogKey(shardID, high)
+	err := s.meta.Scan(startKey, endKey, func(key, value []byte) (bool, error) {
+		e := raftpb.Entry{}
+		protoc.MustUnmarshal(&e, value)
+
+		// May meet gap or has been compacted.
+		if e.Index != nextIndex {
+			return false, nil
+		}
+
+		nextIndex++
+		totalSize += uint64(len(value))
+
+		exceededMaxSize = totalSize > maxSize
+		if !exceededMaxSize || len(ents) == 0 {
+			ents = append(ents, e)
+		}
+
+		return !exceededMaxSize, nil
+	}, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// If we get the correct number of entries the total size exceeds max_size, returns.
+	if len(ents) == int(high-low) || exceededMaxSize {
+		return ents, nil
+	}
+
+	return nil, raft.ErrUnavailable
+}
+
+func (s *metaRaftLogStorage) Term(shardID uint64, index uint64) (uint64, error) {
+	v, err := s.meta.Get(getRaftLogKey(shardID, index))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(v) == 0 {
+		return 0, raft.ErrUnavailable
+	}
+
+	e, err := unmarshalRaftLog(shardID, v, index)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.Term, nil
+}
+
+// LastIndex returns the last index of the raft state, the raft state is always written
+// with the entries in the MetadataStorage.
+func (s *metaRaftLogStorage) LastIndex(shardID uint64) (uint64, error) {
+	v, err := s.meta.Get(getRaftLocalStateKey(shardID))
+	if err != nil || len(v) == 0 {
+		return 0, err
+	}
+
+	state := &bhraftpb.RaftLocalState{}
+	if err := state.Unmarshal(v); err != nil {
+		return 0, err
+	}
+
+	return state.LastIndex, nil
+}
+
+func (s *metaRaftLogStorage) Compact(shardID uint64, index uint64) error {
+	firstIndex := index
+	key, _, err := s.meta.Seek(getRaftLogKey(shardID, 0))
+	if err != nil {
+		return err
+	}
+
+	if len(key) > 0 && bytes.HasPrefix(key, getRaftLogPrefix(shardID)) {
+		firstIndex, err = getRaftLogIndex(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if firstIndex >= index {
+		logger.Infof("shard %d no need to gc raft log",
+			shardID)
+		return nil
+	}
+
+	wb := util.NewWriteBatch()
+	for i := firstIndex; i < index; i++ {
+		err := wb.Delete(getRaftLogKey(shardID, i))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.meta.Write(wb, false)
+	if err == nil {
+		logger.Debugf("shard %d raft log gc complete, entriesCount=<%d>",
+			shardID,
+			(index - firstIndex))
+	}
+
+	return err
+}
+
+// RemoveShard does nothing, the entries are removed with the other raft keys of the shard
+// by `clearMeta`.
+func (s *metaRaftLogStorage) RemoveShard(shardID uint64) error {
+	return nil
+}
+
+func (s *metaRaftLogStorage) Close() error {
+	return nil
+}
+
+func unmarshalRaftLog(shardID uint64, v []byte, expectIndex uint64) (raftpb.Entry, error) {
+	e := raftpb.Entry{}
+	protoc.MustUnmarshal(&e, v)
+	if e.Index != expectIndex {
+		logger.Fatalf("shard %d raft log index not match, logIndex %d expect %d",
+			shardID,
+			e.Index,
+			expectIndex)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"math"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/storage/mem"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestMetaRaftLogAppendAfterClear(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	meta := mem.NewStorage(fs)
+	defer meta.Close()
+	s := newMetaRaftLogStorage(meta).(*metaRaftLogStorage)
+
+	var entries []raftpb.Entry
+	for i := uint64(1); i <= 5; i++ {
+		entries = append(entries, raftpb.Entry{Index: i, Term: 1})
+	}
+	assert.NoError(t, s.Append(1, entries))
+
+	// the raft keys are cleared by the snapshot, and the entries after the snapshot are
+	// appended in the same write batch
+	wb := util.NewWriteBatch()
+	for i := uint64(1); i <= 5; i++ {
+		assert.NoError(t, wb.Delete(getRaftLogKey(1, i)))
+	}
+	assert.NoError(t, s.appendTo(wb, 1, []raftpb.Entry{{Index: 7, Term: 2}, {Index: 8, Term: 2}}, 6))
+	assert.NoError(t, meta.Write(wb, false))
+
+	_, err := s.Term(1, 5)
+	assert.Equal(t, raft.ErrUnavailable, err)
+	ents, err := s.Entries(1, 7, 9, math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Equal(t, []raftpb.Entry{{Index: 7, Term: 2}, {Index: 8, Term: 2}}, ents)
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"golang.org/x/time/rate"
+)
+
+type rateLimitKey struct {
+	group  uint64
+	tenant uint64
+}
+
+// rateLimitBucket the token buckets of a tenant in a shard group, the burst of
+// the buckets is the limit of one second.
+type rateLimitBucket struct {
+	limit    metapb.RateLimit
+	requests *rate.Limiter
+	bytes    *rate.Limiter
+
+	// metric labels
+	group  string
+	tenant string
+}
+
+func newRateLimitBucket(limit metapb.RateLimit) *rateLimitBucket {
+	b := &rateLimitBucket{
+		limit:  limit,
+		group:  strconv.FormatUint(limit.Group, 10),
+		tenant: strconv.FormatUint(limit.Tenant, 10),
+	}
+	if limit.Requests > 0 {
+		b.requests = rate.NewLimiter(rate.Limit(limit.Requests), int(limit.Requests))
+	}
+	if limit.Bytes > 0 {
+		b.bytes = rate.NewLimiter(rate.Limit(limit.Bytes), int(limit.Bytes))
+	}
+	return b
+}
+
+func (b *rateLimitBucket) allow(now time.Time, bytes int) bool {
+	var requests *rate.Reservation
+	if b.requests != nil {
+		r, ok := reserve(b.requests, now, 1)
+		if !ok {
+			metric.AddRateLimitRejectedCount(b.group, b.tenant)
+			return false
+		}
+		requests = r
+	}
+
+	if b.bytes != nil && bytes > 0 {
+		// the request larger than the burst is allowed if the bucket is full
+		n := bytes
+		if n > b.bytes.Burst() {
+			n = b.bytes.Burst()
+		}
+		if _, ok := reserve(b.bytes, now, n); !ok {
+			if requests != nil {
+				requests.CancelAt(now)
+			}
+			metric.AddRateLimitRejectedCount(b.group, b.tenant)
+			return false
+		}
+	}
+
+	metric.AddRateLimitAdmittedCount(b.group, b.tenant, uint64(bytes))
+	return true
+}
+
+// reserve takes n tokens from the bucket if they are available now
+func reserve(l *rate.Limiter, now time.Time, n int) (*rate.Reservation, bool) {
+	r := l.ReserveN(now, n)
+	if !r.OK() {
+		return nil, false
+	}
+	if r.DelayFrom(now) > 0 {
+		r.CancelAt(now)
+		return nil, false
+	}
+	return r, true
+}
+
+// rateLimiter limits the requests of the tenants in the shard groups with the token buckets
+// before proposing. The limits are managed by the prophet and updated by the store heartbeat
+// responses, and each store limits the requests it receives independently.
+type rateLimiter struct {
+	sync.Mutex // serialize updates
+
+	buckets atomic.Value // map[rateLimitKey]*rateLimitBucket
+}
+
+func newRateLimiter() *rateLimiter {
+	l := &rateLimiter{}
+	l.buckets.Store(make(map[rateLimitKey]*rateLimitBucket))
+	return l
+}
+
+// update replaces all the limits, the buckets of the unchanged limits are kept.
+func (l *rateLimiter) update(limits []metapb.RateLimit) {
+	l.Lock()
+	defer l.Unlock()
+
+	old := l.buckets.Load().(map[rateLimitKey]*rateLimitBucket)
+	changed := len(old) != len(limits)
+	buckets := make(map[rateLimitKey]*rateLimitBucket, len(limits))
+	for _, limit := range limits {
+		key := rateLimitKey{group: limit.Group, tenant: limit.Tenant}
+		if b, ok := old[key]; ok && b.limit.Requests == limit.Requests && b.limit.Bytes == limit.Bytes {
+			buckets[key] = b
+			continue
+		}
+
+		changed = true
+		buckets[key] = newRateLimitBucket(limit)
+	}
+
+	if changed {
+		logger.Infof("rate limits changed to %+v", limits)
+		l.buckets.Store(buckets)
+	}
+}
+
+// allow returns true if the request is allowed by the limit of the tenant in the group,
+// the request bytes are only counted for the write requests.
+func (l *rateLimiter) allow(group uint64, req *raftcmdpb.Request) bool {
+	bytes := 0
+	if req.Type == raftcmdpb.CMDType_Write {
+		bytes = len(req.Key) + len(req.Cmd)
+	}
+	return l.allowBytes(group, req.Tenant, bytes)
+}
+
+// allowIngest returns true if the ingest of the file is allowed by the limit of the group, the
+// ingest has no tenant, and the bytes of the file are counted as the written bytes.
+func (l *rateLimiter) allowIngest(group uint64, fileBytes uint64) bool {
+	return l.allowBytes(group, 0, int(fileBytes))
+}
+
+func (l *rateLimiter) allowBytes(group, tenant uint64, bytes int) bool {
+	buckets := l.buckets.Load().(map[rateLimitKey]*rateLimitBucket)
+	if len(buckets) == 0 {
+		return true
+	}
+
+	b, ok := buckets[rateLimitKey{group: group, tenant: tenant}]
+	if !ok {
+		return true
+	}
+	return b.allow(time.Now(), bytes)
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterRequests(t *testing.T) {
+	l := newRateLimiter()
+	req := createTestReadReq("r1", "key")
+	assert.True(t, l.allow(1, req))
+
+	l.update([]metapb.RateLimit{{Group: 1, Tenant: 1, Requests: 2}})
+	assert.True(t, l.allow(1, req), "other tenants are not limited")
+	assert.True(t, l.allow(2, req), "other groups are not limited")
+
+	req.Tenant = 1
+	assert.True(t, l.allow(1, req))
+	assert.True(t, l.allow(1, req))
+	assert.False(t, l.allow(1, req))
+
+	// the unchanged limits keep the buckets
+	l.update([]metapb.RateLimit{{Group: 1, Tenant: 1, Requests: 2}})
+	assert.False(t, l.allow(1, req))
+
+	l.update(nil)
+	assert.True(t, l.allow(1, req))
+}
+
+func TestRateLimiterBytes(t *testing.T) {
+	l := newRateLimiter()
+	l.update([]metapb.RateLimit{{Group: 1, Tenant: 1, Requests: 10, Bytes: 10}})
+
+	req := createTestWriteReq("w1", "k", "value")
+	req.Tenant = 1
+	assert.True(t, l.allow(1, req))
+	assert.False(t, l.allow(1, req))
+
+	// the reads are not limited by the bytes
+	read := createTestReadReq("r1", "k")
+	read.Tenant = 1
+	assert.True(t, l.allow(1, read))
+
+	// the request larger than the burst is allowed if the bucket is full
+	l.update([]metapb.RateLimit{{Group: 1, Tenant: 1, Requests: 10, Bytes: 4}})
+	assert.True(t, l.allow(1, req))
+	assert.False(t, l.allow(1, req))
+}
+
+func TestRateLimiterIngest(t *testing.T) {
+	l := newRateLimiter()
+	assert.True(t, l.allowIngest(1, 100))
+
+	// the ingest has no tenant, the bytes of the file are counted
+	l.update([]metapb.RateLimit{{Group: 1, Requests: 10, Bytes: 10}})
+	assert.True(t, l.allowIngest(1, 6))
+	assert.False(t, l.allowIngest(1, 6))
+	assert.True(t, l.allowIngest(2, 6), "other groups are not limited")
+}
+
+func TestNextBusyRetryInterval(t *testing.T) {
+	p := &shardsProxy{}
+	req := createTestWriteReq("w1", "k", "v")
+	assert.Equal(t, BusyRetryInterval, p.nextBusyRetryInterval(req))
+	assert.Equal(t, BusyRetryInterval*2, p.nextBusyRetryInterval(req))
+	assert.Equal(t, BusyRetryInterval*4, p.nextBusyRetryInterval(req))
+	for i := 0; i < 100; i++ {
+		p.nextBusyRetryInterval(req)
+	}
+	assert.Equal(t, MaxBusyRetryInterval, p.nextBusyRetryInterval(req))
+
+	p.removeBusyRetries(req.ID)
+	assert.Equal(t, int64(0), p.busyRetrying)
+	assert.Equal(t, BusyRetryInterval, p.nextBusyRetryInterval(req))
+}
+
+func TestRateLimitWithServerIsBusy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	assert.NoError(t, c.GetProphet().GetClient().PutRateLimit(metapb.RateLimit{Group: 0, Tenant: 1, Requests: 1}))
+	s := c.GetShardLeaderStore(c.GetShardByIndex(0, 0).ID)
+	timeoutC := time.After(testWaitTimeout)
+	for {
+		if len(s.(*store).limiter.buckets.Load().(map[rateLimitKey]*rateLimitBucket)) > 0 {
+			break
+		}
+
+		select {
+		case <-timeoutC:
+			assert.FailNow(t, "wait rate limits timeout")
+		default:
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+
+	conn := goetty.NewIOSession(goetty.WithCodec(s.CreateRPCCliendSideCodec()))
+	ok, err := conn.Connect(s.GetConfig().ClientAddr, time.Second*5)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	defer conn.Close()
+
+	send := func(req *raftcmdpb.Request) *raftcmdpb.Response {
+		assert.NoError(t, conn.WriteAndFlush(req))
+		value, err := conn.Read()
+		assert.NoError(t, err)
+		return value.(*raftcmdpb.Response)
+	}
+
+	busy := 0
+	for i := 0; i < 5; i++ {
+		req := createTestWriteReq("w1", "key", "value")
+		req.Tenant = 1
+		if rsp := send(req); rsp.Error.ServerIsBusy != nil {
+			busy++
+		}
+	}
+	assert.True(t, busy > 0)
+
+	// the requests of the other tenants are not limited
+	req := createTestWriteReq("w2", "key", "value")
+	req.Tenant = 2
+	assert.Nil(t, send(req).Error.ServerIsBusy)
+}
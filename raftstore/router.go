@@ -41,8 +41,11 @@ type Router interface {
 
 	// LeaderStore return leader peer store
 	LeaderPeerStore(shardID uint64) bhmetapb.Store
-	// RandomPeerStore return random peer store
+	// RandomPeerStore return random peer store, the witness peers are never returned
 	RandomPeerStore(shardID uint64) bhmetapb.Store
+	// LearnerPeerStore returns a learner peer store which has all the labels, returns the
+	// empty store if no learner peer store matches.
+	LearnerPeerStore(shardID uint64, labels []metapb.Pair) bhmetapb.Store
 
 	// GetShardStats returns the runtime stats info of the shard
 	GetShardStats(id uint64) *metapb.ResourceStats
@@ -77,17 +80,19 @@ type defaultRouter struct {
 	shardStats sync.Map // shard id -> ResourceStats
 	storeStats sync.Map // store id -> ContainerStats
 
-	removedHandleFunc func(id uint64)
-	createHandleFunc  func(shard bhmetapb.Shard)
+	removedHandleFunc     func(id uint64)
+	createHandleFunc      func(shard bhmetapb.Shard)
+	writeFreezeHandleFunc func(groups []uint64)
 }
 
-func newRouter(watcher prophet.Watcher, runner *task.Runner, removedHandleFunc func(id uint64), createHandleFunc func(shard bhmetapb.Shard)) (Router, error) {
+func newRouter(watcher prophet.Watcher, runner *task.Runner, removedHandleFunc func(id uint64), createHandleFunc func(shard bhmetapb.Shard), writeFreezeHandleFunc func(groups []uint64)) (Router, error) {
 	return &defaultRouter{
-		runner:            runner,
-		watcher:           watcher,
-		eventC:            watcher.GetNotify(),
-		removedHandleFunc: removedHandleFunc,
-		createHandleFunc:  createHandleFunc,
+		runner:                runner,
+		watcher:               watcher,
+		eventC:                watcher.GetNotify(),
+		removedHandleFunc:     removedHandleFunc,
+		createHandleFunc:      createHandleFunc,
+		writeFreezeHandleFunc: writeFreezeHandleFunc,
 	}, nil
 }
 
@@ -117,8 +122,11 @@ func (r *defaultRouter) Every(group uint64, mustLeader bool, doFunc func(*bhmeta
 			if mustLeader {
 				doFunc(&shard, r.LeaderPeerStore(shard.ID))
 			} else {
-				storeID := r.selectStore(&shard)
-				doFunc(&shard, r.mustGetStore(storeID))
+				var store bhmetapb.Store
+				if storeID := r.selectStore(&shard); storeID > 0 {
+					store = r.mustGetStore(storeID)
+				}
+				doFunc(&shard, store)
 			}
 		}
 
@@ -148,12 +156,42 @@ func (r *defaultRouter) LeaderPeerStore(shardID uint64) bhmetapb.Store {
 func (r *defaultRouter) RandomPeerStore(shardID uint64) bhmetapb.Store {
 	if value, ok := r.shards.Load(shardID); ok {
 		shard := value.(bhmetapb.Shard)
-		return r.mustGetStore(r.selectStore(&shard))
+		if storeID := r.selectStore(&shard); storeID > 0 {
+			return r.mustGetStore(storeID)
+		}
 	}
 
 	return bhmetapb.Store{}
 }
 
+func (r *defaultRouter) LearnerPeerStore(shardID uint64, labels []metapb.Pair) bhmetapb.Store {
+	value, ok := r.shards.Load(shardID)
+	if !ok {
+		return bhmetapb.Store{}
+	}
+
+	shard := value.(bhmetapb.Shard)
+	var stores []bhmetapb.Store
+	for _, p := range shard.Peers {
+		if p.Role != metapb.PeerRole_Learner {
+			continue
+		}
+
+		if v, ok := r.stores.Load(p.ContainerID); ok {
+			store := v.(bhmetapb.Store)
+			if hasLabels(store, labels) {
+				stores = append(stores, store)
+			}
+		}
+	}
+
+	if len(stores) == 0 {
+		return bhmetapb.Store{}
+	}
+
+	return stores[int(r.getOp(shardID).next())%len(stores)]
+}
+
 func (r *defaultRouter) GetShardStats(id uint64) *metapb.ResourceStats {
 	if v, ok := r.shardStats.Load(id); ok {
 		return v.(*metapb.ResourceStats)
@@ -170,19 +208,29 @@ func (r *defaultRouter) GetStoreStats(id uint64) *metapb.ContainerStats {
 	return nil
 }
 
+// selectStore selects a store of the shard's peers in turn, the witness peers are skipped
+// because they have no data to serve the requests.
 func (r *defaultRouter) selectStore(shard *bhmetapb.Shard) uint64 {
-	var ops *op
-	if v, ok := r.opts.Load(shard.ID); ok {
-		ops = v.(*op)
-	} else {
-		ops = &op{}
-		v, exists := r.opts.LoadOrStore(shard.ID, ops)
-		if exists {
-			ops = v.(*op)
+	peers := make([]metapb.Peer, 0, len(shard.Peers))
+	for _, p := range shard.Peers {
+		if p.Role != metapb.PeerRole_Witness {
+			peers = append(peers, p)
 		}
 	}
+	if len(peers) == 0 {
+		return 0
+	}
+
+	return peers[int(r.getOp(shard.ID).next())%len(peers)].ContainerID
+}
+
+func (r *defaultRouter) getOp(shardID uint64) *op {
+	if v, ok := r.opts.Load(shardID); ok {
+		return v.(*op)
+	}
 
-	return shard.Peers[int(ops.next())%len(shard.Peers)].ContainerID
+	v, _ := r.opts.LoadOrStore(shardID, &op{})
+	return v.(*op)
 }
 
 func (r *defaultRouter) searchShard(group uint64, key []byte) bhmetapb.Shard {
@@ -232,6 +280,10 @@ func (r *defaultRouter) handleEvent(evt rpcpb.EventNotify) {
 		r.shardStats.Store(evt.ResourceStatsEvent.ResourceID, evt.ResourceStatsEvent)
 	case event.EventContainerStats:
 		r.storeStats.Store(evt.ContainerStatsEvent.ContainerID, evt.ContainerStatsEvent)
+	case event.EventWriteFreeze:
+		if r.writeFreezeHandleFunc != nil {
+			r.writeFreezeHandleFunc(evt.WriteFrozenGroups)
+		}
 	}
 }
 
@@ -312,6 +364,24 @@ func (r *defaultRouter) mustGetShard(id uint64) bhmetapb.Shard {
 	return value.(bhmetapb.Shard)
 }
 
+func hasLabels(store bhmetapb.Store, labels []metapb.Pair) bool {
+	for _, label := range labels {
+		found := false
+		for _, l := range store.Labels {
+			if l.Key == label.Key && l.Value == label.Value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *defaultRouter) updateShardKeyRange(shard bhmetapb.Shard) {
 	if value, ok := r.keyRanges.Load(shard.Group); ok {
 		value.(*util.ShardTree).Update(shard)
@@ -0,0 +1,104 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLearnerPeerStore(t *testing.T) {
+	r := &defaultRouter{}
+	zone := func(name string) []metapb.Pair {
+		return []metapb.Pair{{Key: "zone", Value: name}}
+	}
+	r.stores.Store(uint64(1), bhmetapb.Store{ID: 1, ClientAddr: "s1", Labels: zone("z1")})
+	r.stores.Store(uint64(2), bhmetapb.Store{ID: 2, ClientAddr: "s2", Labels: zone("z2")})
+	r.stores.Store(uint64(3), bhmetapb.Store{ID: 3, ClientAddr: "s3", Labels: zone("z2")})
+	r.stores.Store(uint64(4), bhmetapb.Store{ID: 4, ClientAddr: "s4", Labels: zone("z2")})
+	r.shards.Store(uint64(1), bhmetapb.Shard{ID: 1, Peers: []metapb.Peer{
+		{ID: 1, ContainerID: 1},
+		{ID: 2, ContainerID: 2},
+		{ID: 3, ContainerID: 3, Role: metapb.PeerRole_Learner},
+		{ID: 4, ContainerID: 4, Role: metapb.PeerRole_Learner},
+	}})
+
+	selected := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		selected[r.LearnerPeerStore(1, zone("z2")).ClientAddr]++
+	}
+	assert.Equal(t, map[string]int{"s3": 5, "s4": 5}, selected)
+
+	assert.Equal(t, "", r.LearnerPeerStore(1, zone("z1")).ClientAddr)
+	assert.Equal(t, "", r.LearnerPeerStore(2, zone("z2")).ClientAddr)
+	assert.NotEqual(t, "", r.LearnerPeerStore(1, nil).ClientAddr)
+}
+
+func TestRandomPeerStore(t *testing.T) {
+	r := &defaultRouter{}
+	r.stores.Store(uint64(1), bhmetapb.Store{ID: 1, ClientAddr: "s1"})
+	r.stores.Store(uint64(2), bhmetapb.Store{ID: 2, ClientAddr: "s2"})
+	r.stores.Store(uint64(3), bhmetapb.Store{ID: 3, ClientAddr: "s3"})
+	r.shards.Store(uint64(1), bhmetapb.Shard{ID: 1, Peers: []metapb.Peer{
+		{ID: 1, ContainerID: 1},
+		{ID: 2, ContainerID: 2},
+		{ID: 3, ContainerID: 3, Role: metapb.PeerRole_Witness},
+	}})
+	r.shards.Store(uint64(2), bhmetapb.Shard{ID: 2, Peers: []metapb.Peer{
+		{ID: 4, ContainerID: 3, Role: metapb.PeerRole_Witness},
+	}})
+
+	// the witness never serves the requests
+	selected := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		selected[r.RandomPeerStore(1).ClientAddr]++
+	}
+	assert.Equal(t, map[string]int{"s1": 5, "s2": 5}, selected)
+	assert.Equal(t, "", r.RandomPeerStore(2).ClientAddr)
+}
+
+func TestSelectRetryStore(t *testing.T) {
+	r := &defaultRouter{}
+	r.stores.Store(uint64(1), bhmetapb.Store{ID: 1, ClientAddr: "s1"})
+	r.stores.Store(uint64(2), bhmetapb.Store{ID: 2, ClientAddr: "s2",
+		Labels: []metapb.Pair{{Key: "zone", Value: "z2"}}})
+	r.shards.Store(uint64(1), bhmetapb.Shard{ID: 1, Peers: []metapb.Peer{
+		{ID: 1, ContainerID: 1},
+		{ID: 2, ContainerID: 2, Role: metapb.PeerRole_Learner},
+	}})
+	r.leaders.Store(uint64(1), bhmetapb.Store{ID: 1, ClientAddr: "s1"})
+
+	read := &raftcmdpb.Request{Type: raftcmdpb.CMDType_Read, AllowFollower: true}
+	write := &raftcmdpb.Request{Type: raftcmdpb.CMDType_Write}
+
+	// the read which allows follower read is retried on a random peer without the learner labels
+	p := NewShardsProxy(r, nil, nil).(*shardsProxy)
+	selected := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		selected[p.selectRetryStore(read, 1)]++
+	}
+	assert.Equal(t, map[string]int{"s1": 5, "s2": 5}, selected)
+	assert.Equal(t, "s1", p.selectRetryStore(write, 1))
+
+	// the learner matches the labels is preferred
+	p = NewShardsProxy(r, nil, nil, WithLearnerReadLabels([]metapb.Pair{{Key: "zone", Value: "z2"}})).(*shardsProxy)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "s2", p.selectRetryStore(read, 1))
+	}
+	assert.Equal(t, "s1", p.selectRetryStore(write, 1))
+}
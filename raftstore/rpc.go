@@ -34,7 +34,7 @@ func newRPC(store *store) *defaultRPC {
 	}
 
 	encoder, decoder := length.NewWithSize(rc, rc, 0, 0, 0, int(store.cfg.Raft.MaxEntryBytes)*2)
-	app, err := goetty.NewTCPApplication(store.cfg.ClientAddr, rpc.onMessage,
+	app, err := store.tls.NewApplication(store.cfg.ClientAddr, rpc.onMessage,
 		goetty.WithAppSessionOptions(goetty.WithCodec(encoder, decoder),
 			goetty.WithEnableAsyncWrite(16),
 			goetty.WithLogger(zap.L().Named("raftstore-rpc")),
@@ -63,6 +63,18 @@ func releaseResponse(resp interface{}) {
 func (rpc *defaultRPC) onMessage(rs goetty.IOSession, value interface{}, seq uint64) error {
 	req := value.(*raftcmdpb.Request)
 	req.PID = int64(rs.ID())
+	if e := rpc.store.auth.Check(req); e != nil {
+		rsp := pb.AcquireResponse()
+		rsp.ID = req.ID
+		rsp.SID = req.SID
+		rsp.PID = req.PID
+		rsp.Type = raftcmdpb.CMDType_Invalid
+		rsp.Error = *e
+		rsp.OriginRequest = req
+		rs.WriteAndFlush(rsp)
+		return nil
+	}
+
 	err := rpc.store.OnRequest(req)
 	if err != nil {
 		rsp := pb.AcquireResponse()
@@ -77,7 +89,7 @@ func (rpc *defaultRPC) onMessage(rs goetty.IOSession, value interface{}, seq uin
 func (rpc *defaultRPC) onResp(header *raftcmdpb.RaftResponseHeader, rsp *raftcmdpb.Response) {
 	if rs, _ := rpc.app.GetSession(uint64(rsp.PID)); rs != nil {
 		if header != nil {
-			if header.Error.RaftEntryTooLarge == nil {
+			if header.Error.RaftEntryTooLarge == nil && header.Error.WriteFrozen == nil {
 				rsp.Type = raftcmdpb.CMDType_RaftError
 			} else {
 				rsp.Type = raftcmdpb.CMDType_Invalid
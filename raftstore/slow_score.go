@@ -0,0 +1,138 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+)
+
+const (
+	minSlowScore = 1
+	maxSlowScore = 100
+	// busySlowScore the store reports busy to the prophet if the slow score is not less than this
+	busySlowScore = 80
+	// slowScoreRecoverStep the slow score decreased in a period without enough slow operations
+	slowScoreRecoverStep = 10
+	// minSlowOps and minSlowRatio the score grows only if the slow operations in a period are
+	// not less than both, the occasional slow operations of a healthy store are ignored.
+	minSlowOps   = 3
+	minSlowRatio = 0.1
+)
+
+type opLatency struct {
+	count uint64
+	slow  uint64
+	nanos uint64
+}
+
+func (l *opLatency) observe(cost, threshold time.Duration) {
+	atomic.AddUint64(&l.count, 1)
+	atomic.AddUint64(&l.nanos, uint64(cost))
+	if cost >= threshold {
+		atomic.AddUint64(&l.slow, 1)
+	}
+}
+
+// reset returns the count, slow count and the average latency in milliseconds since the
+// last reset.
+func (l *opLatency) reset() (uint64, uint64, uint64) {
+	count := atomic.SwapUint64(&l.count, 0)
+	slow := atomic.SwapUint64(&l.slow, 0)
+	nanos := atomic.SwapUint64(&l.nanos, 0)
+	if count == 0 {
+		return 0, 0, 0
+	}
+	return count, slow, nanos / count / uint64(time.Millisecond)
+}
+
+// slowScore detects the slow store by the latencies of the raft log append and apply, and
+// the depth of the apply queue. In each store heartbeat period, the score grows exponentially
+// with the ratio of the slow operations if there are enough slow operations, and recovers
+// linearly otherwise.
+type slowScore struct {
+	latencyThreshold    time.Duration
+	applyQueueThreshold uint64
+
+	append     opLatency
+	apply      opLatency
+	applyQueue int64
+
+	// only accessed by the store heartbeat
+	score float64
+}
+
+func newSlowScore(latencyThreshold time.Duration, applyQueueThreshold uint64) *slowScore {
+	return &slowScore{
+		latencyThreshold:    latencyThreshold,
+		applyQueueThreshold: applyQueueThreshold,
+		score:               minSlowScore,
+	}
+}
+
+func (s *slowScore) observeAppend(start time.Time) {
+	s.append.observe(time.Since(start), s.latencyThreshold)
+}
+
+func (s *slowScore) observeApply(start time.Time) {
+	s.apply.observe(time.Since(start), s.latencyThreshold)
+}
+
+func (s *slowScore) incApplyQueue() {
+	atomic.AddInt64(&s.applyQueue, 1)
+}
+
+func (s *slowScore) decApplyQueue() {
+	atomic.AddInt64(&s.applyQueue, -1)
+}
+
+// tick updates the score with the operations since the last tick, and returns the score
+// and the op latencies to report in the store heartbeat.
+func (s *slowScore) tick() (uint64, []metapb.RecordPair) {
+	appendCount, appendSlow, appendLatency := s.append.reset()
+	applyCount, applySlow, applyLatency := s.apply.reset()
+
+	ratio := float64(0)
+	if total, slow := appendCount+applyCount, appendSlow+applySlow; slow >= minSlowOps {
+		ratio = float64(slow) / float64(total)
+		if ratio < minSlowRatio {
+			ratio = 0
+		}
+	}
+	if queue := atomic.LoadInt64(&s.applyQueue); queue > 0 && uint64(queue) >= s.applyQueueThreshold {
+		ratio = 1
+	}
+
+	if ratio > 0 {
+		s.score *= 1 + ratio
+		if s.score > maxSlowScore {
+			s.score = maxSlowScore
+		}
+	} else {
+		s.score -= slowScoreRecoverStep
+		if s.score < minSlowScore {
+			s.score = minSlowScore
+		}
+	}
+
+	score := uint64(s.score)
+	return score, []metapb.RecordPair{
+		{Key: core.RaftAppendLatencyKey, Value: appendLatency},
+		{Key: core.RaftApplyLatencyKey, Value: applyLatency},
+		{Key: core.SlowScoreKey, Value: score},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowScore(t *testing.T) {
+	s := newSlowScore(time.Millisecond*100, 2)
+	score, latencies := s.tick()
+	assert.Equal(t, uint64(minSlowScore), score)
+	assert.Equal(t, 3, len(latencies))
+
+	observe := func(slow, fast int) {
+		for i := 0; i < slow; i++ {
+			s.observeAppend(time.Now().Add(-time.Second))
+		}
+		for i := 0; i < fast; i++ {
+			s.observeApply(time.Now())
+		}
+	}
+
+	// all operations are slow, the score is doubled in each period
+	for i := 0; i < 3; i++ {
+		observe(minSlowOps, 0)
+		score, _ = s.tick()
+	}
+	assert.Equal(t, uint64(8), score)
+
+	// half of the operations are slow
+	observe(minSlowOps, minSlowOps)
+	score, latencies = s.tick()
+	assert.Equal(t, uint64(12), score)
+	assert.Equal(t, core.RaftAppendLatencyKey, latencies[0].Key)
+	assert.True(t, latencies[0].Value >= 1000)
+	assert.Equal(t, core.SlowScoreKey, latencies[2].Key)
+	assert.Equal(t, uint64(12), latencies[2].Value)
+
+	// the score recovers with too few slow operations
+	observe(minSlowOps-1, 0)
+	score, _ = s.tick()
+	assert.Equal(t, uint64(2), score)
+	observe(minSlowOps*4, minSlowOps*100)
+	score, _ = s.tick()
+	assert.Equal(t, uint64(minSlowScore), score)
+
+	// too many pending apply jobs
+	s.incApplyQueue()
+	s.incApplyQueue()
+	for i := 0; i < 10; i++ {
+		score, _ = s.tick()
+	}
+	assert.Equal(t, uint64(maxSlowScore), score)
+	assert.True(t, score >= busySlowScore)
+
+	s.decApplyQueue()
+	score, _ = s.tick()
+	assert.Equal(t, uint64(maxSlowScore-slowScoreRecoverStep), score)
+}
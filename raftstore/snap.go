@@ -16,20 +16,51 @@ package raftstore
 import (
 	"context"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"math"
 	"path"
 	"sync"
 	"time"
 
 	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
 	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/vfs"
 	"golang.org/x/time/rate"
 )
 
+const (
+	// snapAckWindowChunks the max number of the chunks sent but not yet received by the
+	// remote store
+	snapAckWindowChunks = 8
+	// maxSnapResendTimes the max times to send the chunks not received again without any
+	// progress of the remote store
+	maxSnapResendTimes = 3
+)
+
+var (
+	// snapProbeInterval the interval to probe the received bytes of the snapshot when the
+	// ack window is full
+	snapProbeInterval = time.Millisecond * 200
+	// snapProbeTimeout the remote store not answered the probe in the timeout is treated
+	// as not supporting the probes
+	snapProbeTimeout = time.Second * 10
+	// snapResendTimeout the chunks after the received bytes are sent again if the received
+	// bytes of the remote store stop growing for the timeout. The chunks are written to the
+	// snapshot file in the apply worker of the remote store, so the received bytes can lag
+	// behind the sent chunks for a while.
+	snapResendTimeout = time.Second * 30
+	// snapNoProbeTTL the time to send the snapshots to the store not supporting the probes
+	// without the probes, the probes are tried again after the time
+	snapNoProbeTTL = time.Minute * 10
+)
+
 type defaultSnapshotManager struct {
 	sync.RWMutex
 
@@ -40,6 +71,8 @@ type defaultSnapshotManager struct {
 	dir              string
 	registry         map[string]struct{}
 	receiveSnapCount uint64
+	// noProbeStores the stores not answered the probes, store id -> expired time
+	noProbeStores map[uint64]time.Time
 }
 
 func newDefaultSnapshotManager(s *store) snapshot.SnapshotManager {
@@ -57,9 +90,10 @@ func newDefaultSnapshotManager(s *store) snapshot.SnapshotManager {
 		stopC: make(chan struct{}),
 		limiter: rate.NewLimiter(rate.Every(time.Second/time.Duration(s.cfg.Snapshot.MaxConcurrencySnapChunks)),
 			int(s.cfg.Snapshot.MaxConcurrencySnapChunks)),
-		dir:      dir,
-		s:        s,
-		registry: make(map[string]struct{}),
+		dir:           dir,
+		s:             s,
+		registry:      make(map[string]struct{}),
+		noProbeStores: make(map[uint64]time.Time),
 	}
 
 	m.wg.Add(1)
@@ -165,26 +199,15 @@ func (m *defaultSnapshotManager) Deregister(msg *bhraftpb.SnapshotMessage, step
 func (m *defaultSnapshotManager) Create(msg *bhraftpb.SnapshotMessage) error {
 	path := m.getPathOfSnapKey(msg)
 	gzPath := m.getPathOfSnapKeyGZ(msg)
-	start := encStartKey(&msg.Header.Shard)
-	end := encEndKey(&msg.Header.Shard)
 	db := m.s.DataStorageByGroup(msg.Header.Shard.Group, msg.Header.Shard.ID)
 	fs := m.s.cfg.FS
 
 	if !exist(fs, gzPath) {
 		if !exist(fs, path) {
-			err := db.CreateSnapshot(path, start, end)
+			err := createShardSnapshot(m.s.cfg, db, path, msg.Header.Shard)
 			if err != nil {
 				return err
 			}
-
-			if m.s.cfg.Customize.CustomSnapshotDataCreateFuncFactory != nil {
-				if fn := m.s.cfg.Customize.CustomSnapshotDataCreateFuncFactory(msg.Header.Shard.Group); fn != nil {
-					err := fn(path, msg.Header.Shard)
-					if err != nil {
-						return err
-					}
-				}
-			}
 		}
 		err := util.GZIP(fs, path)
 		if err != nil {
@@ -219,7 +242,7 @@ func (m *defaultSnapshotManager) WriteTo(msg *bhraftpb.SnapshotMessage, conn goe
 	if err != nil {
 		return 0, err
 	}
-	fileSize := info.Size()
+	fileSize := uint64(info.Size())
 
 	f, err := fs.Open(file)
 	if err != nil {
@@ -227,47 +250,243 @@ func (m *defaultSnapshotManager) WriteTo(msg *bhraftpb.SnapshotMessage, conn goe
 	}
 	defer f.Close()
 
-	var written int64
+	checkSum, err := fileCheckSum(f)
+	if err != nil {
+		return 0, err
+	}
+
 	buf := make([]byte, m.s.cfg.Snapshot.SnapChunkSize)
-	ctx := context.TODO()
+	storeID := msg.Header.To.ContainerID
+	if !m.probeSupported(storeID) {
+		// the remote store does not answer the probes, send the whole snapshot file
+		// without resuming and resending.
+		logger.Infof("shard %d try to send snap without probes, header=<%s>,size=<%d>",
+			msg.Header.Shard.ID,
+			msg.Header.String(),
+			fileSize)
+
+		metric.AddSnapshotSendingPendingBytes(int64(fileSize))
+		defer metric.AddSnapshotSendingPendingBytes(-int64(fileSize))
+		written, err := m.writeChunks(msg, conn, f, buf, 0, fileSize, fileSize, checkSum)
+		if err != nil {
+			return written, err
+		}
+
+		logger.Infof("shard %d send snap complete",
+			msg.Header.Shard.ID)
+		return written, nil
+	}
+
+	// resume from the bytes already received by the remote store, the transfer
+	// interrupted by a broken connection does not need to start over.
+	offset, err := m.Probe(msg, conn)
+	if err != nil {
+		return 0, err
+	}
+	if offset > fileSize {
+		offset = 0
+	}
+	if offset > 0 && offset < fileSize {
+		metric.AddSnapshotResumedCount(1)
+	}
 
-	logger.Infof("shard %d try to send snap, header=<%s>,size=<%d>",
+	logger.Infof("shard %d try to send snap, header=<%s>,size=<%d>,offset=<%d>",
 		msg.Header.Shard.ID,
 		msg.Header.String(),
-		fileSize)
+		fileSize,
+		offset)
+
+	var written uint64
+	pending := int64(fileSize - offset)
+	metric.AddSnapshotSendingPendingBytes(pending)
+	defer func() {
+		metric.AddSnapshotSendingPendingBytes(-pending)
+	}()
+
+	// at most one ack window of chunks is sent after the bytes received by the remote
+	// store, the chunks in the window are sent again only if the received bytes make
+	// no progress in the resend timeout.
+	window := uint64(snapAckWindowChunks) * uint64(len(buf))
+	sent, acked := offset, offset
+	resends := 0
+	lastProgress := time.Now()
+	for acked < fileSize {
+		end := acked + window
+		if end > fileSize {
+			end = fileSize
+		}
+
+		if sent < end {
+			n, err := m.writeChunks(msg, conn, f, buf, sent, end, fileSize, checkSum)
+			sent += n
+			written += n
+			// the resent chunks are not pending
+			done := int64(n)
+			if done > pending {
+				done = pending
+			}
+			pending -= done
+			metric.AddSnapshotSendingPendingBytes(-done)
+			if err != nil {
+				return written, err
+			}
+		} else {
+			time.Sleep(snapProbeInterval)
+		}
 
-	for {
-		nr, er := f.Read(buf)
+		received, err := m.Probe(msg, conn)
+		if err != nil {
+			return written, err
+		}
+
+		switch {
+		case received > acked:
+			acked = received
+			resends = 0
+			lastProgress = time.Now()
+		case received < acked:
+			// the remote store dropped the received bytes, e.g. the checksum of the
+			// whole file mismatched
+			acked, sent = received, received
+			resends++
+			lastProgress = time.Now()
+		case time.Since(lastProgress) > snapResendTimeout:
+			// the chunks after the received bytes are lost
+			sent = acked
+			resends++
+			lastProgress = time.Now()
+		}
+
+		if resends > maxSnapResendTimes {
+			return written, fmt.Errorf("snapshot not fully received after %d resends, received=<%d> expect=<%d>",
+				resends-1,
+				acked,
+				fileSize)
+		}
+	}
+
+	logger.Infof("shard %d send snap complete",
+		msg.Header.Shard.ID)
+	return written, nil
+}
+
+// writeChunks sends the snapshot file in chunks from the offset to the end
+func (m *defaultSnapshotManager) writeChunks(msg *bhraftpb.SnapshotMessage, conn goetty.IOSession,
+	f vfs.File, buf []byte, offset, end, fileSize, checkSum uint64) (uint64, error) {
+	var written uint64
+	ctx := context.TODO()
+	for offset < end {
+		size := end - offset
+		if size > uint64(len(buf)) {
+			size = uint64(len(buf))
+		}
+
+		nr, er := f.ReadAt(buf[:size], int64(offset))
 		if nr > 0 {
 			dst := &bhraftpb.SnapshotMessage{}
 			dst.Header = msg.Header
 			dst.Data = buf[0:nr]
-			dst.FileSize = uint64(fileSize)
-			dst.First = written == 0
-			dst.Last = fileSize == written+int64(nr)
+			dst.FileSize = fileSize
+			dst.CheckSum = checkSum
+			dst.Offset = offset
+			dst.ChunkCheckSum = crc32.ChecksumIEEE(dst.Data)
+			dst.First = offset == 0
+			dst.Last = fileSize == offset+uint64(nr)
 
-			written += int64(nr)
 			err := m.limiter.Wait(ctx)
 			if err != nil {
-				return 0, err
+				return written, err
 			}
 
 			err = conn.WriteAndFlush(dst)
 			if err != nil {
-				return 0, err
+				return written, err
 			}
+
+			offset += uint64(nr)
+			written += uint64(nr)
+			metric.AddSnapshotSentBytes(uint64(nr))
 		}
 		if er != nil {
 			if er != io.EOF {
-				return 0, er
+				return written, er
 			}
 			break
 		}
 	}
 
-	logger.Infof("shard %d send snap complete",
-		msg.Header.Shard.ID)
-	return uint64(written), nil
+	return written, nil
+}
+
+// Probe asks the remote store how many bytes of the snapshot file are received. The
+// remote store not answered in the probe timeout is treated as not supporting the probes,
+// the connection is closed and the snapshots are sent to the store without the probes.
+func (m *defaultSnapshotManager) Probe(msg *bhraftpb.SnapshotMessage, conn goetty.IOSession) (uint64, error) {
+	req := &bhraftpb.SnapshotMessage{}
+	req.Header = msg.Header
+	req.Probe = true
+	err := conn.WriteAndFlush(req)
+	if err != nil {
+		return 0, err
+	}
+
+	type result struct {
+		value interface{}
+		err   error
+	}
+	c := make(chan result, 1)
+	go func() {
+		value, err := conn.Read()
+		c <- result{value: value, err: err}
+	}()
+
+	var ret result
+	timer := time.NewTimer(snapProbeTimeout)
+	defer timer.Stop()
+	select {
+	case ret = <-c:
+	case <-timer.C:
+		conn.Close()
+		<-c
+		m.disableProbe(msg.Header.To.ContainerID)
+		return 0, fmt.Errorf("snapshot probe not answered by store %d in %s",
+			msg.Header.To.ContainerID,
+			snapProbeTimeout)
+	}
+	if ret.err != nil {
+		return 0, ret.err
+	}
+
+	rsp, ok := ret.value.(*bhraftpb.SnapshotMessage)
+	if !ok || !rsp.Probe {
+		return 0, fmt.Errorf("unexpected snapshot probe response %+v", ret.value)
+	}
+
+	return rsp.Offset, nil
+}
+
+func (m *defaultSnapshotManager) probeSupported(storeID uint64) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	expired, ok := m.noProbeStores[storeID]
+	if !ok {
+		return true
+	}
+	if time.Now().After(expired) {
+		delete(m.noProbeStores, storeID)
+		return true
+	}
+	return false
+}
+
+func (m *defaultSnapshotManager) disableProbe(storeID uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	logger.Warningf("store %d not answered the snapshot probes, send the snapshots without probes",
+		storeID)
+	m.noProbeStores[storeID] = time.Now().Add(snapNoProbeTTL)
 }
 
 func (m *defaultSnapshotManager) CleanSnap(msg *bhraftpb.SnapshotMessage) error {
@@ -317,9 +536,12 @@ func (m *defaultSnapshotManager) ReceiveSnapData(msg *bhraftpb.SnapshotMessage)
 	var f vfs.File
 
 	if msg.First {
-		m.Lock()
-		m.receiveSnapCount++
-		m.Unlock()
+		// the first chunk is sent again after a stall, the transfer is already counted
+		if !exist(m.s.cfg.FS, m.getTmpPathOfSnapKeyGZ(msg)) {
+			m.Lock()
+			m.receiveSnapCount++
+			m.Unlock()
+		}
 		err = m.cleanTmp(msg)
 	}
 
@@ -327,36 +549,72 @@ func (m *defaultSnapshotManager) ReceiveSnapData(msg *bhraftpb.SnapshotMessage)
 		return err
 	}
 
+	// the chunk is dropped, the sender will find it by probe and send it again
+	if crc32.ChecksumIEEE(msg.Data) != msg.ChunkCheckSum {
+		metric.AddSnapshotChunkCheckSumFailedCount(1)
+		logger.Warningf("shard %d drop snap chunk with mismatched checksum, offset=<%d>, header=<%s>",
+			msg.Header.Shard.ID,
+			msg.Offset,
+			msg.Header.String())
+		return nil
+	}
+
 	fs := m.s.cfg.FS
 	file := m.getTmpPathOfSnapKeyGZ(msg)
+	var size uint64
 	if exist(fs, file) {
+		info, err := fs.Stat(file)
+		if err != nil {
+			return err
+		}
+		size = uint64(info.Size())
+	}
+
+	if msg.Offset > size {
+		logger.Warningf("shard %d drop snap chunk with gap, offset=<%d>, received=<%d>, header=<%s>",
+			msg.Header.Shard.ID,
+			msg.Offset,
+			size,
+			msg.Header.String())
+		return nil
+	}
+
+	// the chunk is sent again after resuming, skip the bytes already received
+	data := msg.Data
+	if skip := size - msg.Offset; skip > 0 {
+		if skip >= uint64(len(data)) {
+			return nil
+		}
+		data = data[skip:]
+	}
+
+	if size > 0 {
 		f, err = fs.OpenForAppend(file)
 		if err != nil {
-			f.Close()
 			return err
 		}
 	} else {
 		f, err = fs.Create(file)
 		if err != nil {
-			f.Close()
 			return err
 		}
 	}
 
-	n, err := f.Write(msg.Data)
+	n, err := f.Write(data)
 	if err != nil {
 		f.Close()
 		return err
 	}
 
-	if n != len(msg.Data) {
+	if n != len(data) {
 		f.Close()
 		return fmt.Errorf("write snapshot file failed, expect=<%d> actual=<%d>",
-			len(msg.Data),
+			len(data),
 			n)
 	}
 
 	f.Close()
+	metric.AddSnapshotReceivedBytes(uint64(n))
 
 	if msg.Last {
 		m.Lock()
@@ -385,21 +643,66 @@ func (m *defaultSnapshotManager) Apply(msg *bhraftpb.SnapshotMessage) error {
 	defer m.s.cfg.FS.RemoveAll(dir)
 
 	// apply snapshot of data
-	err = m.s.DataStorageByGroup(msg.Header.Shard.Group, msg.Header.Shard.ID).ApplySnapshot(dir)
+	return applyShardSnapshot(m.s.cfg, m.s.DataStorageByGroup(msg.Header.Shard.Group, msg.Header.Shard.ID),
+		dir, msg.Header.Shard)
+}
+
+func (m *defaultSnapshotManager) CreateIngest(msg *bhraftpb.SnapshotMessage, next func() ([]byte, []byte, bool)) error {
+	db, ok := m.s.DataStorageByGroup(msg.Header.Shard.Group, msg.Header.Shard.ID).(storage.IngestableStorage)
+	if !ok {
+		return errIngestNotSupported
+	}
+
+	fs := m.s.cfg.FS
+	path := m.getPathOfSnapKey(msg)
+	defer fs.RemoveAll(path)
+
+	err := db.CreateIngestFile(path, next)
+	if err != nil {
+		return err
+	}
+
+	return util.GZIP(fs, path)
+}
+
+func (m *defaultSnapshotManager) Ingest(msg *bhraftpb.SnapshotMessage) error {
+	file := m.getPathOfSnapKeyGZ(msg)
+	if !m.Exists(msg) {
+		return fmt.Errorf("missing ingest file, path=%s", file)
+	}
+
+	db, ok := m.s.DataStorageByGroup(msg.Header.Shard.Group, msg.Header.Shard.ID).(storage.IngestableStorage)
+	if !ok {
+		return errIngestNotSupported
+	}
+
+	err := util.UnGZIP(m.s.cfg.FS, file, m.dir)
 	if err != nil {
 		return err
 	}
+	dir := m.getPathOfSnapKey(msg)
+	defer m.s.cfg.FS.RemoveAll(dir)
 
-	if m.s.cfg.Customize.CustomSnapshotDataApplyFuncFactory != nil {
-		if fn := m.s.cfg.Customize.CustomSnapshotDataApplyFuncFactory(msg.Header.Shard.Group); fn != nil {
-			err := fn(dir, msg.Header.Shard)
+	return db.IngestFile(dir)
+}
+
+func (m *defaultSnapshotManager) ReceivedSnapBytes(msg *bhraftpb.SnapshotMessage) uint64 {
+	fs := m.s.cfg.FS
+	for _, file := range []string{m.getPathOfSnapKeyGZ(msg), m.getTmpPathOfSnapKeyGZ(msg)} {
+		if exist(fs, file) {
+			info, err := fs.Stat(file)
 			if err != nil {
-				return err
+				logger.Errorf("shard %d stat snap file %s failed with %+v",
+					msg.Header.Shard.ID,
+					file,
+					err)
+				return 0
 			}
+			return uint64(info.Size())
 		}
 	}
 
-	return nil
+	return 0
 }
 
 func (m *defaultSnapshotManager) ReceiveSnapCount() uint64 {
@@ -443,12 +746,47 @@ func (m *defaultSnapshotManager) check(msg *bhraftpb.SnapshotMessage) error {
 				file)
 		}
 
-		return fs.Rename(file, m.getPathOfSnapKeyGZ(msg))
+		f, err := fs.Open(file)
+		if err != nil {
+			return err
+		}
+		checkSum, err := fileCheckSum(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		// the sender will find the snapshot file is removed by probe and send it again
+		if msg.CheckSum != checkSum {
+			metric.AddSnapshotCheckSumFailedCount(1)
+			logger.Warningf("shard %d snap file checksum not match, got=<%d> expect=<%d> path=<%s>",
+				msg.Header.Shard.ID,
+				checkSum,
+				msg.CheckSum,
+				file)
+			return fs.RemoveAll(file)
+		}
+
+		err = fs.Rename(file, m.getPathOfSnapKeyGZ(msg))
+		if err != nil {
+			return err
+		}
+
+		metric.AddSnapshotReceivedCount(1)
+		return nil
 	}
 
 	return fmt.Errorf("missing snapshot file, path=%s", file)
 }
 
+func fileCheckSum(f vfs.File) (uint64, error) {
+	h := crc32.NewIEEE()
+	if _, err := io.Copy(h, io.NewSectionReader(f, 0, math.MaxInt64)); err != nil {
+		return 0, err
+	}
+	return uint64(h.Sum32()), nil
+}
+
 func exist(fs vfs.FS, name string) bool {
 	_, err := fs.Stat(name)
 	if err == nil {
@@ -459,3 +797,35 @@ func exist(fs vfs.FS, name string) bool {
 	}
 	panic(err)
 }
+
+// createShardSnapshot creates the snapshot of the shard's data in the path
+func createShardSnapshot(cfg *config.Config, db storage.DataStorage, path string, shard bhmetapb.Shard) error {
+	err := db.CreateSnapshot(path, encStartKey(&shard), encEndKey(&shard))
+	if err != nil {
+		return err
+	}
+
+	if cfg.Customize.CustomSnapshotDataCreateFuncFactory != nil {
+		if fn := cfg.Customize.CustomSnapshotDataCreateFuncFactory(shard.Group); fn != nil {
+			return fn(path, shard)
+		}
+	}
+
+	return nil
+}
+
+// applyShardSnapshot applies the snapshot of the shard's data in the dir
+func applyShardSnapshot(cfg *config.Config, db storage.DataStorage, dir string, shard bhmetapb.Shard) error {
+	err := db.ApplySnapshot(dir)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Customize.CustomSnapshotDataApplyFuncFactory != nil {
+		if fn := cfg.Customize.CustomSnapshotDataApplyFuncFactory(shard.Group); fn != nil {
+			return fn(dir, shard)
+		}
+	}
+
+	return nil
+}
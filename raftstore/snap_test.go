@@ -0,0 +1,357 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"hash/crc32"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	cpebble "github.com/cockroachdb/pebble"
+	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
+	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/pebble"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func newTestSnapshotManager(t *testing.T) *defaultSnapshotManager {
+	cfg := &config.Config{}
+	cfg.FS = vfs.GetTestFS()
+	cfg.DataPath = filepath.Join(util.GetTestDir(), "snap", fmt.Sprintf("%d", time.Now().UnixNano()))
+	cfg.Snapshot.MaxConcurrencySnapChunks = 1
+	return newDefaultSnapshotManager(&store{cfg: cfg}).(*defaultSnapshotManager)
+}
+
+func newTestSnapChunk(data []byte, offset int, size int, fileSize uint64, checkSum uint64) *bhraftpb.SnapshotMessage {
+	msg := &bhraftpb.SnapshotMessage{}
+	msg.Header.Shard.ID = 1
+	msg.Header.Term = 1
+	msg.Header.Index = 1
+	msg.Data = data[offset : offset+size]
+	msg.Offset = uint64(offset)
+	msg.ChunkCheckSum = crc32.ChecksumIEEE(msg.Data)
+	msg.FileSize = fileSize
+	msg.CheckSum = checkSum
+	msg.First = offset == 0
+	msg.Last = uint64(offset+size) == fileSize
+	return msg
+}
+
+func TestReceiveSnapDataResume(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	m := newTestSnapshotManager(t)
+	defer m.s.cfg.FS.RemoveAll(m.s.cfg.DataPath)
+	defer m.Close()
+
+	data := []byte("0123456789")
+	fileSize := uint64(len(data))
+	checkSum := uint64(crc32.ChecksumIEEE(data))
+
+	msg := newTestSnapChunk(data, 0, 4, fileSize, checkSum)
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.Equal(t, uint64(4), m.ReceivedSnapBytes(msg))
+	assert.Equal(t, uint64(1), m.ReceiveSnapCount())
+
+	// the resent first chunk is not a new transfer
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.Equal(t, uint64(4), m.ReceivedSnapBytes(msg))
+	assert.Equal(t, uint64(1), m.ReceiveSnapCount())
+
+	// chunk with mismatched checksum is dropped
+	msg = newTestSnapChunk(data, 4, 4, fileSize, checkSum)
+	msg.ChunkCheckSum++
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.Equal(t, uint64(4), m.ReceivedSnapBytes(msg))
+
+	// chunk after a gap is dropped
+	msg = newTestSnapChunk(data, 8, 2, fileSize, checkSum)
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.Equal(t, uint64(4), m.ReceivedSnapBytes(msg))
+	assert.False(t, m.Exists(msg))
+
+	// resent chunk overlapped with the received bytes
+	msg = newTestSnapChunk(data, 2, 6, fileSize, checkSum)
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.Equal(t, uint64(8), m.ReceivedSnapBytes(msg))
+
+	msg = newTestSnapChunk(data, 8, 2, fileSize, checkSum)
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.Equal(t, fileSize, m.ReceivedSnapBytes(msg))
+	assert.True(t, m.Exists(msg))
+	assert.Equal(t, uint64(0), m.ReceiveSnapCount())
+	assert.NoError(t, m.CleanSnap(msg))
+}
+
+func TestReceiveSnapDataWithMismatchedFileCheckSum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	m := newTestSnapshotManager(t)
+	defer m.s.cfg.FS.RemoveAll(m.s.cfg.DataPath)
+	defer m.Close()
+
+	data := []byte("0123456789")
+	fileSize := uint64(len(data))
+	checkSum := uint64(crc32.ChecksumIEEE(data)) + 1
+
+	msg := newTestSnapChunk(data, 0, 10, fileSize, checkSum)
+	assert.NoError(t, m.ReceiveSnapData(msg))
+	assert.False(t, m.Exists(msg))
+	assert.Equal(t, uint64(0), m.ReceivedSnapBytes(msg))
+	assert.Equal(t, uint64(0), m.ReceiveSnapCount())
+}
+
+func TestIngestKeepsFile(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	m := newTestSnapshotManager(t)
+	fs := m.s.cfg.FS
+	defer fs.RemoveAll(m.s.cfg.DataPath)
+	defer m.Close()
+
+	path := fs.PathJoin(m.s.cfg.DataPath, "data")
+	assert.NoError(t, fs.MkdirAll(path, 0755))
+	db, err := pebble.NewStorage(path, &cpebble.Options{FS: vfs.NewPebbleFS(fs)})
+	assert.NoError(t, err)
+	defer db.Close()
+	m.s.cfg.Storage.DataStorageFactory = func(group, shardID uint64) storage.DataStorage {
+		return db
+	}
+
+	msg := &bhraftpb.SnapshotMessage{}
+	msg.Header.Shard.ID = 1
+	msg.Header.Index = 1
+	done := false
+	assert.NoError(t, m.CreateIngest(msg, func() ([]byte, []byte, bool) {
+		if done {
+			return nil, nil, false
+		}
+		done = true
+		return []byte("key1"), []byte("value1"), true
+	}))
+
+	// the ingest is applied again if the applied index is not durable before restart
+	assert.NoError(t, m.Ingest(msg))
+	assert.True(t, m.Exists(msg))
+	assert.NoError(t, m.Ingest(msg))
+	value, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "value1", string(value))
+
+	assert.NoError(t, m.CleanSnap(msg))
+	assert.False(t, m.Exists(msg))
+}
+
+func TestBulkLoadWithCustomSnapshotManager(t *testing.T) {
+	// the custom snapshot manager implements only the snapshot.SnapshotManager
+	m := newTestSnapshotManager(t)
+	defer m.s.cfg.FS.RemoveAll(m.s.cfg.DataPath)
+	defer m.Close()
+	s := &store{snapshotManager: struct{ snapshot.SnapshotManager }{m}}
+
+	_, err := s.CreateIngestFiles(0, func() ([]byte, []byte, bool) { return nil, nil, false })
+	assert.Equal(t, errBulkLoadUnsupported, err)
+	assert.Equal(t, uint64(0), s.receivedSnapBytes(&bhraftpb.SnapshotMessage{}))
+}
+
+// testSnapSession sends the snapshot chunks to the receiver manager, the chunks are
+// written by the receiver asynchronously like the apply worker of the remote store.
+type testSnapSession struct {
+	goetty.IOSession
+
+	receiver *defaultSnapshotManager
+	probe    bool
+	delay    time.Duration
+	drop     func(*bhraftpb.SnapshotMessage) bool
+	sent     uint64
+	chunkC   chan *bhraftpb.SnapshotMessage
+	rspC     chan interface{}
+	closeC   chan struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+func newTestSnapSession(receiver *defaultSnapshotManager, probe bool) *testSnapSession {
+	s := &testSnapSession{
+		receiver: receiver,
+		probe:    probe,
+		chunkC:   make(chan *bhraftpb.SnapshotMessage, 1024),
+		rspC:     make(chan interface{}, 1),
+		closeC:   make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for msg := range s.chunkC {
+			time.Sleep(s.delay)
+			if s.drop != nil && s.drop(msg) {
+				continue
+			}
+			s.receiver.ReceiveSnapData(msg)
+		}
+	}()
+	return s
+}
+
+func (s *testSnapSession) WriteAndFlush(value interface{}) error {
+	msg := value.(*bhraftpb.SnapshotMessage)
+	if msg.Probe {
+		if s.probe {
+			rsp := &bhraftpb.SnapshotMessage{}
+			rsp.Probe = true
+			rsp.Offset = s.receiver.ReceivedSnapBytes(msg)
+			s.rspC <- rsp
+		}
+		return nil
+	}
+
+	chunk := *msg
+	chunk.Data = append([]byte(nil), msg.Data...)
+	s.sent += uint64(len(chunk.Data))
+	s.chunkC <- &chunk
+	return nil
+}
+
+func (s *testSnapSession) Read() (interface{}, error) {
+	select {
+	case rsp := <-s.rspC:
+		return rsp, nil
+	case <-s.closeC:
+		return nil, fmt.Errorf("closed")
+	}
+}
+
+func (s *testSnapSession) Close() error {
+	if !s.closed {
+		s.closed = true
+		close(s.closeC)
+	}
+	return nil
+}
+
+func (s *testSnapSession) stop() {
+	close(s.chunkC)
+	s.wg.Wait()
+}
+
+func newTestSnapSender(t *testing.T, msg *bhraftpb.SnapshotMessage, data []byte) *defaultSnapshotManager {
+	m := newTestSnapshotManager(t)
+	m.s.cfg.Snapshot.SnapChunkSize = 4
+	m.limiter = rate.NewLimiter(rate.Inf, 1)
+	f, err := m.s.cfg.FS.Create(m.getPathOfSnapKeyGZ(msg))
+	assert.NoError(t, err)
+	_, err = f.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	return m
+}
+
+func newTestSnapMessage() *bhraftpb.SnapshotMessage {
+	msg := &bhraftpb.SnapshotMessage{}
+	msg.Header.Shard.ID = 1
+	msg.Header.To.ContainerID = 2
+	msg.Header.Term = 1
+	msg.Header.Index = 1
+	return msg
+}
+
+func TestWriteToWithSlowReceiver(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	data := []byte("0123456789")
+	msg := newTestSnapMessage()
+	sender := newTestSnapSender(t, msg, data)
+	defer sender.s.cfg.FS.RemoveAll(sender.s.cfg.DataPath)
+	defer sender.Close()
+	receiver := newTestSnapshotManager(t)
+	defer receiver.s.cfg.FS.RemoveAll(receiver.s.cfg.DataPath)
+	defer receiver.Close()
+
+	// the received bytes lag behind the probes, but no chunks are sent again
+	conn := newTestSnapSession(receiver, true)
+	conn.delay = snapProbeInterval * 2
+	written, err := sender.WriteTo(msg, conn)
+	conn.stop()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(len(data)), written)
+	assert.Equal(t, uint64(len(data)), conn.sent)
+	assert.True(t, receiver.Exists(msg))
+}
+
+func TestWriteToResendLostChunks(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer func(old time.Duration) { snapResendTimeout = old }(snapResendTimeout)
+	snapResendTimeout = snapProbeInterval
+
+	data := []byte("0123456789")
+	msg := newTestSnapMessage()
+	sender := newTestSnapSender(t, msg, data)
+	defer sender.s.cfg.FS.RemoveAll(sender.s.cfg.DataPath)
+	defer sender.Close()
+	receiver := newTestSnapshotManager(t)
+	defer receiver.s.cfg.FS.RemoveAll(receiver.s.cfg.DataPath)
+	defer receiver.Close()
+
+	conn := newTestSnapSession(receiver, true)
+	dropped := false
+	conn.drop = func(chunk *bhraftpb.SnapshotMessage) bool {
+		if !dropped && chunk.Offset == 4 {
+			dropped = true
+			return true
+		}
+		return false
+	}
+	written, err := sender.WriteTo(msg, conn)
+	conn.stop()
+	assert.NoError(t, err)
+	assert.True(t, dropped)
+	assert.True(t, written > uint64(len(data)))
+	assert.True(t, receiver.Exists(msg))
+}
+
+func TestWriteToWithoutProbes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer func(old time.Duration) { snapProbeTimeout = old }(snapProbeTimeout)
+	snapProbeTimeout = snapProbeInterval
+
+	data := []byte("0123456789")
+	msg := newTestSnapMessage()
+	sender := newTestSnapSender(t, msg, data)
+	defer sender.s.cfg.FS.RemoveAll(sender.s.cfg.DataPath)
+	defer sender.Close()
+	receiver := newTestSnapshotManager(t)
+	defer receiver.s.cfg.FS.RemoveAll(receiver.s.cfg.DataPath)
+	defer receiver.Close()
+
+	// the store not answered the probe falls back to send the whole file without probes
+	conn := newTestSnapSession(receiver, false)
+	_, err := sender.WriteTo(msg, conn)
+	conn.stop()
+	assert.Error(t, err)
+	assert.True(t, conn.closed)
+	assert.False(t, sender.probeSupported(msg.Header.To.ContainerID))
+
+	conn = newTestSnapSession(receiver, false)
+	written, err := sender.WriteTo(msg, conn)
+	conn.stop()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(len(data)), written)
+	assert.True(t, receiver.Exists(msg))
+}
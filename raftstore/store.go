@@ -19,6 +19,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,12 +29,15 @@ import (
 	"github.com/fagongzi/log"
 	"github.com/fagongzi/util/protoc"
 	"github.com/fagongzi/util/task"
+	"github.com/matrixorigin/matrixcube/auth"
 	"github.com/matrixorigin/matrixcube/aware"
 	"github.com/matrixorigin/matrixcube/command"
 	"github.com/matrixorigin/matrixcube/components/prophet"
 	"github.com/matrixorigin/matrixcube/components/prophet/event"
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
@@ -76,6 +80,8 @@ type Store interface {
 	OnRequest(*raftcmdpb.Request) error
 	// MetadataStorage returns a MetadataStorage of the shard group
 	MetadataStorage() storage.MetadataStorage
+	// RaftLogStorage returns the RaftLogStorage to save the raft log entries
+	RaftLogStorage() storage.RaftLogStorage
 	// DataStorage returns a DataStorage of the shard group
 	DataStorageByGroup(uint64, uint64) storage.DataStorage
 	// MaybeLeader returns the shard replica maybe leader
@@ -86,6 +92,8 @@ type Store interface {
 	Prophet() prophet.Prophet
 	// CreateRPCCliendSideCodec returns the rpc codec at client side
 	CreateRPCCliendSideCodec() (codec.Encoder, codec.Decoder)
+	// GetTLS returns the tls of the store, nil if the tls is disabled
+	GetTLS() *tlsutil.TLS
 
 	// CreateResourcePool create resource pools, the resource pool will create shards,
 	// and try to maintain the number of shards in the pool not less than the `capacity`
@@ -93,12 +101,34 @@ type Store interface {
 	CreateResourcePool(...metapb.ResourcePool) (ShardsPool, error)
 	// GetResourcePool returns `ShardsPool`, nil if `CreateResourcePool` not completed
 	GetResourcePool() ShardsPool
+	// CreateShardGroup creates the shards of the group pre-split by the split keys, and scatters
+	// their peers and leaders across the stores once they are created. This is an idempotent
+	// operation.
+	CreateShardGroup(group uint64, unique string, splitKeys [][]byte) error
+
+	// SubscribeChanges subscribes the change events of the shard applied on the store from the
+	// log index, the events are replayed from the raft log if the index is applied. The events
+	// start from the next applied entry if the index is 0. The witness of the shard can not be
+	// subscribed, it does not apply the write requests.
+	SubscribeChanges(shardID uint64, fromIndex uint64) (*ChangeSubscription, error)
+	// CreateIngestFiles builds the files of the bulk load for the shards of the group, and sends
+	// them to all the peers of the shards. The files are ingested by the requests returned by
+	// NewIngestRequest. The files returned with the error may be sent to some of the peers, they
+	// need to be discarded by the requests returned by NewDiscardIngestRequest.
+	CreateIngestFiles(group uint64, next func() ([]byte, []byte, bool)) ([]IngestFile, error)
+	// Backup starts a job to backup all the shards of the cluster to the path, the backup is
+	// completed once LoadBackupMeta returns the meta of the backup. The new cluster is restored
+	// from the backup by RestoreFromBackup.
+	Backup(path string) error
 }
 
 const (
 	applyWorkerName      = "apply-%d-%d"
 	snapshotWorkerName   = "snapshot-%d"
 	splitCheckWorkerName = "split"
+	hashWorkerName       = "hash"
+	backupWorkerName     = "backup"
+	ingestWorkerName     = "ingest"
 )
 
 type store struct {
@@ -112,6 +142,7 @@ type store struct {
 	runner          *task.Runner
 	trans           transport.Transport
 	snapshotManager snapshot.SnapshotManager
+	raftLogStorage  storage.RaftLogStorage
 	rpc             *defaultRPC
 	router          Router
 	routerOnce      sync.Once
@@ -120,6 +151,7 @@ type store struct {
 	replicas        sync.Map // shard id -> *peerReplica
 	delegates       sync.Map // shard id -> *applyDelegate
 	droppedVoteMsgs sync.Map // shard id -> raftpb.Message
+	cdc             *cdcHub
 
 	readHandlers  map[uint64]command.ReadCommandFunc
 	writeHandlers map[uint64]command.WriteCommandFunc
@@ -138,8 +170,21 @@ type store struct {
 
 	aware aware.ShardStateAware
 
+	metricServer   *http.Server
+	stopMetricPush func()
+
 	// shard pool processor
 	shardPool *dynamicShardsPool
+	// backup processor
+	backupJob *backupJob
+
+	tls       *tlsutil.TLS
+	auth      *auth.Checker
+	limiter   *rateLimiter
+	freezer   *writeFreezer
+	slowScore *slowScore
+	// storeHeartbeatC triggers a store heartbeat immediately
+	storeHeartbeatC chan struct{}
 }
 
 // NewStore returns a raft store
@@ -154,8 +199,20 @@ func NewStore(cfg *config.Config) Store {
 		runner:        task.NewRunner(),
 		workReady:     newWorkReady(cfg.ShardGroups, cfg.Worker.RaftEventWorkers),
 		shardPool:     newDynamicShardsPool(cfg),
+		limiter:       newRateLimiter(),
+		freezer:       newWriteFreezer(),
+		slowScore: newSlowScore(cfg.Replication.SlowStoreLatencyThreshold.Duration,
+			cfg.Replication.SlowStoreApplyQueueThreshold),
+		storeHeartbeatC: make(chan struct{}, 1),
 	}
 
+	tls, err := tlsutil.New(cfg.TLS)
+	if err != nil {
+		logger.Fatalf("create tls failed with %+v", err)
+	}
+	s.tls = tls
+	s.auth = auth.NewChecker(cfg.Customize.CustomAuthenticator, cfg.Customize.CustomAuthorizer, cfg.InternalToken)
+
 	if s.cfg.Customize.CustomShardStateAwareFactory != nil {
 		s.aware = cfg.Customize.CustomShardStateAwareFactory()
 	}
@@ -166,11 +223,23 @@ func NewStore(cfg *config.Config) Store {
 		s.snapshotManager = newDefaultSnapshotManager(s)
 	}
 
+	if s.cfg.Storage.RaftLogStorage != nil {
+		s.raftLogStorage = s.cfg.Storage.RaftLogStorage
+	} else {
+		s.raftLogStorage = newMetaRaftLogStorage(s.cfg.Storage.MetaStorage)
+	}
+
 	s.rpc = newRPC(s)
+	s.cdc = newCDCHub(s)
+	s.backupJob = newBackupJob(s)
 	s.initWorkers()
 	return s
 }
 
+func (s *store) GetTLS() *tlsutil.TLS {
+	return s.tls
+}
+
 func (s *store) GetConfig() *config.Config {
 	return s.cfg
 }
@@ -199,6 +268,9 @@ func (s *store) Start() {
 	s.startRouter()
 	logger.Infof("router started")
 
+	s.startMetric()
+	logger.Infof("metric started")
+
 	s.doStoreHeartbeat(time.Now())
 }
 
@@ -228,6 +300,25 @@ func (s *store) Stop() {
 
 		s.rpc.Stop()
 		logger.Infof("store %d rpc stopped", s.Meta().ID)
+
+		s.cdc.closeAll()
+		logger.Infof("store %d change subscriptions closed", s.Meta().ID)
+
+		s.backupJob.stop()
+		logger.Infof("store %d backup job stopped", s.Meta().ID)
+
+		if s.metricServer != nil {
+			s.metricServer.Close()
+			logger.Infof("store %d metric server stopped", s.Meta().ID)
+		}
+
+		if s.stopMetricPush != nil {
+			s.stopMetricPush()
+			logger.Infof("store %d metric push stopped", s.Meta().ID)
+		}
+
+		s.tls.Close()
+		logger.Infof("store %d tls closed", s.Meta().ID)
 	})
 }
 
@@ -249,7 +340,7 @@ func (s *store) startRouter() {
 
 		r, err := newRouter(watcher, s.runner, func(id uint64) {
 			s.doDestroy(id, true, "remove by event")
-		}, s.doDynamicallyCreate)
+		}, s.doDynamicallyCreate, s.doWriteFreeze)
 		if err != nil {
 			logger.Fatalf("create router failed with %+v", err)
 		}
@@ -295,6 +386,9 @@ func (s *store) onRequestWithCB(req *raftcmdpb.Request, cb func(resp *raftcmdpb.
 		logger.Debugf("%s store received", hex.EncodeToString(req.ID))
 	}
 
+	// the request is already authenticated, the token must not be written into the raft log
+	req.Token = ""
+
 	var pr *peerReplica
 	var err error
 	if req.ToShard > 0 {
@@ -322,6 +416,10 @@ func (s *store) MetadataStorage() storage.MetadataStorage {
 	return s.cfg.Storage.MetaStorage
 }
 
+func (s *store) RaftLogStorage() storage.RaftLogStorage {
+	return s.raftLogStorage
+}
+
 func (s *store) DataStorageByGroup(group, shardID uint64) storage.DataStorage {
 	return s.cfg.Storage.DataStorageFactory(group, shardID)
 }
@@ -377,12 +475,15 @@ func (s *store) initWorkers() {
 	}
 
 	s.runner.AddNamedWorker(splitCheckWorkerName)
+	s.runner.AddNamedWorker(hashWorkerName)
+	s.runner.AddNamedWorker(backupWorkerName)
+	s.runner.AddNamedWorker(ingestWorkerName)
 }
 
 func (s *store) startProphet() {
 	logger.Infof("begin to start prophet")
 
-	s.cfg.Prophet.Adapter = newProphetAdapter()
+	s.cfg.Prophet.Adapter = NewProphetAdapter()
 	s.cfg.Prophet.Handler = s
 	s.cfg.Prophet.Adjust(nil, false)
 
@@ -407,6 +508,7 @@ func (s *store) startTransport() {
 				10*s.cfg.Raft.GetElectionTimeoutDuration()),
 			transport.WithSendBatch(int64(s.cfg.Raft.SendRaftBatchSize)),
 			transport.WithWorkerCount(s.cfg.Worker.SendRaftMsgWorkerCount, s.cfg.Snapshot.MaxConcurrencySnapChunks),
+			transport.WithTLS(s.tls),
 			transport.WithErrorHandler(func(msg *bhraftpb.RaftMessage, err error) {
 				if pr := s.getPR(msg.ShardID, true); pr != nil {
 					pr.addReport(msg.Message)
@@ -494,8 +596,16 @@ func (s *store) startShards() {
 		}
 
 		if localState.State == bhraftpb.PeerState_Tombstone {
-			tomebstoneShards = append(tomebstoneShards, localState.Shard)
 			tomebstoneCount++
+			// the data of the merged shard is owned by the target shard
+			if localState.MergeState != nil {
+				logger.Infof("shard %d is tombstone in store, merged into shard %d",
+					shardID,
+					localState.MergeState.Target.ID)
+				return true, nil
+			}
+
+			tomebstoneShards = append(tomebstoneShards, localState.Shard)
 			logger.Infof("shard %d is tombstone in store",
 				shardID)
 			return true, nil
@@ -506,6 +616,14 @@ func (s *store) startShards() {
 			return false, err
 		}
 
+		if localState.State == bhraftpb.PeerState_Merging {
+			logger.Infof("shard %d is merging into shard %d in store",
+				shardID,
+				localState.MergeState.Target.ID)
+			pr.ps.mergeState = localState.MergeState
+			pr.addAction(action{actionType: checkMergeAction})
+		}
+
 		if localState.State == bhraftpb.PeerState_Applying {
 			applyingCount++
 			logger.Infof("shard %d is applying in store", shardID)
@@ -554,6 +672,9 @@ func (s *store) startTimerTasks() {
 		storeheartbeatTicker := time.NewTicker(s.cfg.Replication.StoreHeartbeatDuration.Duration)
 		defer storeheartbeatTicker.Stop()
 
+		consistencyCheckTicker := time.NewTicker(s.cfg.Replication.ConsistencyCheckDuration.Duration)
+		defer consistencyCheckTicker.Stop()
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -562,7 +683,9 @@ func (s *store) startTimerTasks() {
 			case <-compactTicker.C:
 				s.handleCompactRaftLog()
 			case <-splitCheckTicker.C:
-				if !s.cfg.Replication.DisableShardSplit {
+				// the split check reads the runner state, which is locked by the
+				// runner until all the tasks stopped
+				if !s.cfg.Replication.DisableShardSplit && ctx.Err() == nil {
 					s.handleSplitCheck()
 				}
 			case <-stateCheckTicker.C:
@@ -572,6 +695,13 @@ func (s *store) startTimerTasks() {
 			case <-storeheartbeatTicker.C:
 				s.doStoreHeartbeat(last)
 				last = time.Now()
+			case <-s.storeHeartbeatC:
+				s.doStoreHeartbeat(last)
+				last = time.Now()
+			case <-consistencyCheckTicker.C:
+				if !s.cfg.Replication.DisableConsistencyCheck {
+					s.handleConsistencyCheck()
+				}
 			}
 		}
 	})
@@ -599,6 +729,22 @@ func (s *store) startRPC() {
 	}
 }
 
+func (s *store) startMetric() {
+	if s.cfg.Metric.EnablePush() {
+		s.stopMetricPush = metric.StartPush(s.cfg.Metric)
+	}
+
+	if s.cfg.Metric.EnablePull() {
+		server, err := metric.StartPull(s.cfg.Metric)
+		if err != nil {
+			logger.Fatalf("start metric at %s failed with %+v",
+				s.cfg.Metric.ListenAddr,
+				err)
+		}
+		s.metricServer = server
+	}
+}
+
 func (s *store) clearMeta(id uint64, wb *util.WriteBatch) error {
 	metaCount := 0
 	raftCount := 0
@@ -675,6 +821,18 @@ func (s *store) addSplitJob(task func() error) error {
 	return s.addNamedJob("", splitCheckWorkerName, task)
 }
 
+func (s *store) addHashJob(task func() error) error {
+	return s.addNamedJob("", hashWorkerName, task)
+}
+
+func (s *store) addBackupJob(task func() error) error {
+	return s.addNamedJob("", backupWorkerName, task)
+}
+
+func (s *store) addIngestJob(task func() error) error {
+	return s.addNamedJob("", ingestWorkerName, task)
+}
+
 func (s *store) addNamedJob(desc, worker string, task func() error) error {
 	return s.runner.RunJobWithNamedWorker(desc, worker, task)
 }
@@ -798,7 +956,8 @@ func (s *store) validateShard(req *raftcmdpb.RaftCMDRequest) *errorpb.Error {
 		}
 	}
 
-	allowFollow := req.AdminRequest == nil && len(req.Requests) > 0 && req.Requests[0].AllowFollower
+	allowFollow := req.AdminRequest == nil && len(req.Requests) > 0 &&
+		(req.Requests[0].AllowFollower || req.Requests[0].FollowerReadIndex)
 	if !allowFollow && !pr.isLeader() {
 		err := new(errorpb.NotLeader)
 		err.ShardID = shardID
@@ -856,7 +1015,11 @@ func checkEpoch(shard bhmetapb.Shard, req *raftcmdpb.RaftCMDRequest) bool {
 			checkVer = true
 		case raftcmdpb.AdminCmdType_ChangePeer:
 			checkConfVer = true
-		case raftcmdpb.AdminCmdType_TransferLeader:
+		case raftcmdpb.AdminCmdType_TransferLeader,
+			raftcmdpb.AdminCmdType_PrepareMerge,
+			raftcmdpb.AdminCmdType_CommitMerge,
+			raftcmdpb.AdminCmdType_RollbackMerge,
+			raftcmdpb.AdminCmdType_Ingest:
 			checkVer = true
 			checkConfVer = true
 		}
@@ -907,6 +1070,20 @@ func newAdminRaftCMDResponse(adminType raftcmdpb.AdminCmdType, rsp protoc.PB) *r
 		adminResp.CompactLog = rsp.(*raftcmdpb.CompactLogResponse)
 	case raftcmdpb.AdminCmdType_BatchSplit:
 		adminResp.Splits = rsp.(*raftcmdpb.BatchSplitResponse)
+	case raftcmdpb.AdminCmdType_PrepareMerge:
+		adminResp.PrepareMerge = rsp.(*raftcmdpb.PrepareMergeResponse)
+	case raftcmdpb.AdminCmdType_CommitMerge:
+		adminResp.CommitMerge = rsp.(*raftcmdpb.CommitMergeResponse)
+	case raftcmdpb.AdminCmdType_RollbackMerge:
+		adminResp.RollbackMerge = rsp.(*raftcmdpb.RollbackMergeResponse)
+	case raftcmdpb.AdminCmdType_ComputeHash:
+		adminResp.ComputeHash = rsp.(*raftcmdpb.ComputeHashResponse)
+	case raftcmdpb.AdminCmdType_VerifyHash:
+		adminResp.VerifyHash = rsp.(*raftcmdpb.VerifyHashResponse)
+	case raftcmdpb.AdminCmdType_Ingest:
+		adminResp.Ingest = rsp.(*raftcmdpb.IngestResponse)
+	case raftcmdpb.AdminCmdType_Backup:
+		adminResp.Backup = rsp.(*raftcmdpb.BackupResponse)
 	}
 
 	resp := pb.AcquireRaftCMDResponse()
@@ -979,6 +1156,19 @@ func (s *store) updatePeerState(shard bhmetapb.Shard, state bhraftpb.PeerState,
 	return s.MetadataStorage().Set(getShardLocalStateKey(shard.ID), protoc.MustMarshal(shardState))
 }
 
+func (s *store) updateMergeState(shard bhmetapb.Shard, state bhraftpb.PeerState, mergeState *bhraftpb.MergeState, wb *util.WriteBatch) error {
+	shardState := &bhraftpb.ShardLocalState{}
+	shardState.State = state
+	shardState.Shard = shard
+	shardState.MergeState = mergeState
+
+	if wb != nil {
+		return wb.Set(getShardLocalStateKey(shard.ID), protoc.MustMarshal(shardState))
+	}
+
+	return s.MetadataStorage().Set(getShardLocalStateKey(shard.ID), protoc.MustMarshal(shardState))
+}
+
 func (s *store) removePeerState(shard bhmetapb.Shard) error {
 	return s.MetadataStorage().Delete(getShardLocalStateKey(shard.ID))
 }
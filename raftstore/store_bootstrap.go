@@ -82,6 +82,11 @@ func (s *store) doBootstrapCluster() {
 				initShards = append(initShards, shard)
 				resources = append(resources, NewResourceAdapterWithShard(shard))
 			}
+			if s.cfg.Customize.CustomInitShardsRestoreFunc != nil {
+				if err := s.cfg.Customize.CustomInitShardsRestoreFunc(initShards); err != nil {
+					logger.Fatalf("restore init shards failed with %+v", err)
+				}
+			}
 		} else {
 			shard := bhmetapb.Shard{}
 			s.doCreateInitShard(&shard)
@@ -159,7 +164,10 @@ func (s *store) doCreateInitShard(shard *bhmetapb.Shard) {
 	shardID := s.MustAllocID()
 	peerID := s.MustAllocID()
 	shard.ID = shardID
-	shard.Epoch.Version = 1
+	// the init shards restored from the backup keep the versions
+	if shard.Epoch.Version == 0 {
+		shard.Epoch.Version = 1
+	}
 	shard.Epoch.ConfVer = 1
 	shard.Peers = append(shard.Peers, metapb.Peer{
 		ID:            peerID,
@@ -207,6 +215,13 @@ func (s *store) removeInitShards(shards ...bhmetapb.Shard) {
 	var ids []uint64
 	for _, shard := range shards {
 		ids = append(ids, shard.ID)
+		// the restored data of the init shards
+		if s.cfg.Customize.CustomInitShardsRestoreFunc != nil {
+			err := s.DataStorageByGroup(shard.Group, shard.ID).RemoveShardData(shard, encStartKey(&shard), encEndKey(&shard))
+			if err != nil {
+				logger.Fatalf("remove init shard %d data failed with %+v", shard.ID, err)
+			}
+		}
 	}
 
 	s.mustRemoveShards(ids...)
@@ -50,6 +50,15 @@ func (s *store) handleSplitCheck() {
 	})
 }
 
+func (s *store) handleConsistencyCheck() {
+	s.foreachPR(func(pr *peerReplica) bool {
+		if pr.isLeader() {
+			pr.addAction(action{actionType: checkConsistencyAction})
+		}
+		return true
+	})
+}
+
 func (s *store) handledCustomSplitCheck(group uint64) bool {
 	return s.cfg.Customize.CustomSplitCheckFuncFactory != nil && s.cfg.Customize.CustomSplitCheckFuncFactory(group) != nil
 }
@@ -58,6 +67,7 @@ func (s *store) handleShardStateCheck() {
 	bm := roaring64.NewBitmap()
 	s.foreachPR(func(pr *peerReplica) bool {
 		bm.Add(pr.shardID)
+		pr.addAction(action{actionType: checkMergeAction})
 		return true
 	})
 
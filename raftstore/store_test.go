@@ -16,6 +16,9 @@ package raftstore
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,10 +28,13 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/util/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,6 +74,53 @@ func TestClusterStartConcurrent(t *testing.T) {
 	c.CheckShardCount(1)
 }
 
+func TestClusterWithWALRaftLog(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, DiskTestCluster, WithTestClusterUseWAL(), GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, kv.Set(fmt.Sprintf("key-%d", i), "OK", testWaitTimeout))
+	}
+	kv.Close()
+
+	c.Restart()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv = c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		v, err := kv.Get(fmt.Sprintf("key-%d", i), testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, "OK", v)
+	}
+}
+
+func TestClusterWithLeaseRead(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Raft.EnableLeaseRead = true
+	}), GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		assert.NoError(t, kv.Set(key, key, testWaitTimeout))
+		v, err := kv.Get(key, testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, key, v)
+	}
+}
+
 func TestAdjustRaftTickerInterval(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	c := NewSingleTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
@@ -85,6 +138,31 @@ func TestAdjustRaftTickerInterval(t *testing.T) {
 	})
 }
 
+func TestMetricPull(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	addr := fmt.Sprintf("127.0.0.1:%d", testutil.GenTestPorts(1)[0])
+	c := NewSingleTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Metric.Mode = metric.ModePull
+		cfg.Metric.ListenAddr = addr
+	}))
+	defer c.Stop()
+
+	c.Start()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+metric.PullPath, nil)
+	assert.NoError(t, err)
+	req.Close = true
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, strings.Contains(string(data), "matrixcube_"))
+	assert.True(t, strings.Contains(string(data), "go_goroutines"))
+}
+
 func TestIssue123(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	c := NewSingleTestClusterStore(t,
@@ -187,6 +265,188 @@ func TestSplit(t *testing.T) {
 	c.CheckShardRange(2, []byte("key3"), nil)
 }
 
+func TestMerge(t *testing.T) {
+	testMerge(t, 1)
+}
+
+func TestMergeOnDifferentApplyWorkers(t *testing.T) {
+	testMerge(t, 2)
+}
+
+func testMerge(t *testing.T, applyWorkers uint64) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Worker.ApplyWorkerCount = applyWorkers
+		cfg.Customize.CustomInitShardsFactory = func() []bhmetapb.Shard {
+			return []bhmetapb.Shard{{Start: []byte("a"), End: []byte("b")}, {Start: []byte("b"), End: []byte("c")}}
+		}
+	}), GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitShardByCountPerNode(2, testWaitTimeout)
+	c.WaitLeadersByCount(2, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("a1", "v1", testWaitTimeout))
+	assert.NoError(t, kv.Set("b1", "v2", testWaitTimeout))
+
+	source := c.GetShardByIndex(0, 0)
+	target := c.GetShardByIndex(0, 1)
+	if bytes.Equal(source.Start, []byte("b")) {
+		source, target = target, source
+	}
+
+	// the source and the target are applied by the different apply workers, the target apply
+	// is paused until the source catches up
+	if applyWorkers > 1 {
+		c.EveryStore(func(i int, s Store) {
+			assert.NotEqual(t, s.(*store).getPR(source.ID, false).applyWorker,
+				s.(*store).getPR(target.ID, false).applyWorker)
+		})
+	}
+
+	s := c.GetShardLeaderStore(source.ID).(*store)
+	source = s.getPR(source.ID, false).ps.shard
+	target = s.getPR(target.ID, false).ps.shard
+	mergeShard(t, c, s, source, target)
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.CheckShardCount(1)
+	c.CheckShardRange(0, []byte("a"), []byte("c"))
+
+	for idx := 0; idx < 3; idx++ {
+		shard := c.GetShardByID(idx, target.ID)
+		assert.True(t, shard.Epoch.Version > target.Epoch.Version)
+	}
+
+	v, err := kv.Get("a1", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", v)
+	v, err = kv.Get("b1", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", v)
+
+	assert.NoError(t, kv.Set("a2", "v3", testWaitTimeout))
+	v, err = kv.Get("a2", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "v3", v)
+}
+
+// mergeShard asks the leader store of the source shard to merge the source shard into the target
+// shard, and waits until all the stores merged.
+func mergeShard(t *testing.T, c TestRaftCluster, s *store, source, target bhmetapb.Shard) {
+	merged := func() bool {
+		ok := true
+		c.EveryStore(func(i int, _ Store) {
+			if c.GetShardByID(i, target.ID).Epoch.Version <= target.Epoch.Version {
+				ok = false
+			}
+		})
+		return ok
+	}
+
+	s.doResourceHeartbeatRsp(rpcpb.ResourceHeartbeatRsp{
+		ResourceID:    source.ID,
+		ResourceEpoch: source.Epoch,
+		Merge:         &rpcpb.Merge{Target: protoc.MustMarshal(&target)},
+	})
+
+	timeout := time.After(testWaitTimeout)
+	for !merged() {
+		select {
+		case <-timeout:
+			assert.FailNowf(t, "", "wait shard %d merged into shard %d timeout", source.ID, target.ID)
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+}
+
+func TestRollbackMerge(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Customize.CustomInitShardsFactory = func() []bhmetapb.Shard {
+			return []bhmetapb.Shard{{Start: []byte("a"), End: []byte("b")}, {Start: []byte("b"), End: []byte("c")}}
+		}
+	}), GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitShardByCountPerNode(2, testWaitTimeout)
+	c.WaitLeadersByCount(2, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("a1", "v1", testWaitTimeout))
+
+	source := c.GetShardByIndex(0, 0)
+	target := c.GetShardByIndex(0, 1)
+	if bytes.Equal(source.Start, []byte("b")) {
+		source, target = target, source
+	}
+
+	// the merge is prepared with a target epoch never matched, the source leader rollbacks the
+	// merge once it found the target epoch changed
+	s := c.GetShardLeaderStore(source.ID).(*store)
+	pr := s.getPR(source.ID, false)
+	target = s.getPR(target.ID, false).ps.shard
+	target.Epoch.Version++
+	pr.onAdmin(&raftcmdpb.AdminRequest{
+		CmdType: raftcmdpb.AdminCmdType_PrepareMerge,
+		PrepareMerge: &raftcmdpb.PrepareMergeRequest{
+			MinIndex: 1,
+			Target:   target,
+		},
+	})
+
+	// the writes are rejected during the merge, and allowed after the merge rolled back
+	assert.Eventually(t, func() bool {
+		return kv.Set("a2", "v2", time.Second) == nil
+	}, testWaitTimeout, time.Millisecond*100)
+	v, err := kv.Get("a2", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", v)
+
+	c.CheckShardCount(2)
+	c.EveryStore(func(i int, s Store) {
+		assert.Eventually(t, func() bool {
+			pr := s.(*store).getPR(source.ID, false)
+			return pr != nil && pr.ps.mergeState == nil
+		}, testWaitTimeout, time.Millisecond*10)
+	})
+}
+
+func TestConsistencyCheck(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, GetCMDTestClusterHandler, SetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("a1", "v1", testWaitTimeout))
+
+	id := c.GetShardByIndex(0, 0).ID
+	leader := c.GetShardLeaderStore(id).(*store)
+	follower := 0
+	for idx := 0; idx < 3; idx++ {
+		if c.GetStore(idx).Meta().ID != leader.Meta().ID {
+			follower = idx
+			break
+		}
+	}
+
+	// write the data without raft to make the follower inconsistent with the leader
+	ds := c.GetStore(follower).DataStorageByGroup(0, id).(storage.KVStorage)
+	assert.NoError(t, ds.Set(EncodeDataKey(0, []byte("b1")), []byte("v2")))
+
+	leader.handleConsistencyCheck()
+	c.WaitShardInconsistentByCount(follower, id, 1, testWaitTimeout)
+}
+
 func TestCustomSplit(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	target := EncodeDataKey(0, []byte("key2"))
@@ -40,6 +40,7 @@ import (
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/storage/mem"
 	"github.com/matrixorigin/matrixcube/storage/pebble"
+	"github.com/matrixorigin/matrixcube/storage/wal"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/util/testutil"
 	"github.com/matrixorigin/matrixcube/vfs"
@@ -91,6 +92,7 @@ type testClusterOptions struct {
 	nodeStartFunc      func(node int, store Store)
 	logLevel           string
 	useDisk            bool
+	useWAL             bool
 	dataOpts, metaOpts *cpebble.Options
 
 	writeHandlers map[uint64]command.WriteCommandFunc
@@ -142,6 +144,13 @@ func WithTestClusterUseDisk() TestClusterOption {
 	}
 }
 
+// WithTestClusterUseWAL use the wal storage to save the raft log for testing
+func WithTestClusterUseWAL() TestClusterOption {
+	return func(opts *testClusterOptions) {
+		opts.useWAL = true
+	}
+}
+
 // WithTestClusterDisableSchedule disable pd schedule
 func WithTestClusterDisableSchedule() TestClusterOption {
 	return func(opts *testClusterOptions) {
@@ -225,6 +234,8 @@ type testShardAware struct {
 	leaders      map[uint64]bool
 	applied      map[uint64]int
 	splitedCount map[uint64]int
+	mergedCount  map[uint64]int
+	inconsistent map[uint64]int
 
 	removed map[uint64]bhmetapb.Shard
 }
@@ -235,6 +246,8 @@ func newTestShardAware() *testShardAware {
 		applied:      make(map[uint64]int),
 		removed:      make(map[uint64]bhmetapb.Shard),
 		splitedCount: make(map[uint64]int),
+		mergedCount:  make(map[uint64]int),
+		inconsistent: make(map[uint64]int),
 	}
 }
 
@@ -288,6 +301,36 @@ func (ts *testShardAware) waitByShardSplitCount(t *testing.T, id uint64, count i
 	}
 }
 
+func (ts *testShardAware) waitByShardMergedCount(t *testing.T, id uint64, count int, timeout time.Duration) {
+	timeoutC := time.After(timeout)
+	for {
+		select {
+		case <-timeoutC:
+			assert.FailNowf(t, "", "wait shard %d merged count %d timeout", id, count)
+		default:
+			if ts.shardMergedCount(id) >= count {
+				return
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
+func (ts *testShardAware) waitByShardInconsistentCount(t *testing.T, id uint64, count int, timeout time.Duration) {
+	timeoutC := time.After(timeout)
+	for {
+		select {
+		case <-timeoutC:
+			assert.FailNowf(t, "", "wait shard %d inconsistent count %d timeout", id, count)
+		default:
+			if ts.shardInconsistentCount(id) >= count {
+				return
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
 func (ts *testShardAware) hasShard(id uint64) bool {
 	ts.RLock()
 	defer ts.RUnlock()
@@ -334,6 +377,20 @@ func (ts *testShardAware) shardSplitedCount(id uint64) int {
 	return ts.splitedCount[id]
 }
 
+func (ts *testShardAware) shardMergedCount(id uint64) int {
+	ts.RLock()
+	defer ts.RUnlock()
+
+	return ts.mergedCount[id]
+}
+
+func (ts *testShardAware) shardInconsistentCount(id uint64) int {
+	ts.RLock()
+	defer ts.RUnlock()
+
+	return ts.inconsistent[id]
+}
+
 func (ts *testShardAware) leaderCount() int {
 	ts.RLock()
 	defer ts.RUnlock()
@@ -387,6 +444,22 @@ func (ts *testShardAware) Splited(shard bhmetapb.Shard) {
 	}
 }
 
+func (ts *testShardAware) Merged(shard bhmetapb.Shard) {
+	ts.Lock()
+	defer ts.Unlock()
+
+	for idx := range ts.shards {
+		if ts.shards[idx].ID == shard.ID {
+			ts.shards[idx] = shard
+			ts.mergedCount[shard.ID]++
+		}
+	}
+
+	if ts.wrapper != nil {
+		ts.wrapper.Merged(shard)
+	}
+}
+
 func (ts *testShardAware) Destory(shard bhmetapb.Shard) {
 	ts.Lock()
 	defer ts.Unlock()
@@ -439,6 +512,17 @@ func (ts *testShardAware) SnapshotApplied(shard bhmetapb.Shard) {
 	}
 }
 
+func (ts *testShardAware) ConsistencyCheckFailed(shard bhmetapb.Shard, index uint64) {
+	ts.Lock()
+	defer ts.Unlock()
+
+	ts.inconsistent[shard.ID]++
+
+	if ts.wrapper != nil {
+		ts.wrapper.ConsistencyCheckFailed(shard, index)
+	}
+}
+
 // TestRaftCluster is the test cluster is used to test starting N nodes in a process, and to provide
 // the start and stop capabilities of a single node, which is used to test `raftstore` more easily.
 type TestRaftCluster interface {
@@ -483,6 +567,11 @@ type TestRaftCluster interface {
 	WaitShardByCountPerNode(count int, timeout time.Duration)
 	// WaitShardSplitByCount check whether the count of shard split reaches a specific value until timeout
 	WaitShardSplitByCount(id uint64, count int, timeout time.Duration)
+	// WaitShardMergedByCount check whether the count of shard merged reaches a specific value until timeout
+	WaitShardMergedByCount(id uint64, count int, timeout time.Duration)
+	// WaitShardInconsistentByCount check whether the count of shard consistency check failed on the node
+	// reaches a specific value until timeout
+	WaitShardInconsistentByCount(node int, id uint64, count int, timeout time.Duration)
 	// WaitShardByCounts check whether the number of shards reaches a specific value until timeout
 	WaitShardByCounts(counts []int, timeout time.Duration)
 	// WaitShardStateChangedTo check whether the state of shard changes to the specific value until timeout
@@ -724,6 +813,7 @@ type testRaftCluster struct {
 	awares           []*testShardAware
 	dataStorages     []storage.DataStorage
 	metadataStorages []storage.MetadataStorage
+	raftLogStorages  []storage.RaftLogStorage
 }
 
 // NewSingleTestClusterStore create test cluster with 1 node
@@ -747,6 +837,7 @@ func (c *testRaftCluster) reset(init bool, opts ...TestClusterOption) {
 	c.awares = nil
 	c.dataStorages = nil
 	c.metadataStorages = nil
+	c.raftLogStorages = nil
 
 	for _, opt := range opts {
 		opt(c.opts)
@@ -853,6 +944,12 @@ func (c *testRaftCluster) reset(init bool, opts ...TestClusterOption) {
 			cfg.Storage.MetaStorage = metaStorage
 			c.metadataStorages = append(c.metadataStorages, metaStorage)
 		}
+		if cfg.Storage.RaftLogStorage == nil && c.opts.useWAL {
+			s, err := wal.NewStorage(cfg.FS, cfg.FS.PathJoin(cfg.DataPath, "wal"))
+			assert.NoError(c.t, err)
+			cfg.Storage.RaftLogStorage = s
+			c.raftLogStorages = append(c.raftLogStorages, s)
+		}
 		if cfg.Storage.DataStorageFactory == nil {
 			var dataStorage storage.DataStorage
 			dataStorage = mem.NewStorage(cfg.FS)
@@ -983,6 +1080,10 @@ func (c *testRaftCluster) Stop() {
 		s.Close()
 	}
 
+	for _, s := range c.raftLogStorages {
+		s.Close()
+	}
+
 	for _, s := range c.stores {
 		fs := s.cfg.FS
 		if fs == nil {
@@ -1079,6 +1180,16 @@ func (c *testRaftCluster) WaitShardSplitByCount(id uint64, count int, timeout ti
 	}
 }
 
+func (c *testRaftCluster) WaitShardMergedByCount(id uint64, count int, timeout time.Duration) {
+	for idx := range c.stores {
+		c.awares[idx].waitByShardMergedCount(c.t, id, count, timeout)
+	}
+}
+
+func (c *testRaftCluster) WaitShardInconsistentByCount(node int, id uint64, count int, timeout time.Duration) {
+	c.awares[node].waitByShardInconsistentCount(c.t, id, count, timeout)
+}
+
 func (c *testRaftCluster) WaitShardByCounts(counts []int, timeout time.Duration) {
 	for idx := range c.stores {
 		c.awares[idx].waitByShardCount(c.t, counts[idx], timeout)
@@ -0,0 +1,68 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterWithTLS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	dir := fmt.Sprintf("%s/tls-%d", util.GetTestDir(), time.Now().Nanosecond())
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	defer os.RemoveAll(dir)
+	tlsCfg, err := tlsutil.CreateTestFiles(dir)
+	assert.NoError(t, err)
+	tlsCfg.RequireClientCert = true
+
+	c := NewTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.TLS = tlsCfg
+		}))
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	// the requests from the follower stores are sent to the leader by the client rpc
+	for i := 0; i < 3; i++ {
+		kv := c.CreateTestKVClient(i)
+		key := fmt.Sprintf("key-%d", i)
+		assert.NoError(t, kv.Set(key, "OK", testWaitTimeout))
+		v, err := kv.Get(key, testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, "OK", v)
+		kv.Close()
+	}
+
+	// the plain tcp client is rejected
+	conn, err := net.Dial("tcp", c.GetStore(0).GetConfig().ClientAddr)
+	assert.NoError(t, err)
+	defer conn.Close()
+	conn.Write([]byte("hello"))
+	conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	_, err = conn.Read(make([]byte, 16))
+	assert.Error(t, err)
+}
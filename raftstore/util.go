@@ -13,6 +13,9 @@
 package raftstore
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 )
@@ -72,3 +75,53 @@ func removedPeers(new, old bhmetapb.Shard) []uint64 {
 
 	return ids
 }
+
+// isAdjacent returns true if the range of the shard a is next to the range of the shard b
+func isAdjacent(a, b bhmetapb.Shard) bool {
+	return (len(a.End) > 0 && bytes.Equal(a.End, b.Start)) ||
+		(len(b.End) > 0 && bytes.Equal(b.End, a.Start))
+}
+
+// containsShard returns true if the range of the shard a contains the range of the shard b
+func containsShard(a, b bhmetapb.Shard) bool {
+	return bytes.Compare(a.Start, b.Start) <= 0 &&
+		(len(a.End) == 0 || (len(b.End) > 0 && bytes.Compare(b.End, a.End) <= 0))
+}
+
+// checkMergeTarget check whether the source shard can be merged into the target shard.
+// The two shards must be adjacent and all the peers must be on the same stores.
+func checkMergeTarget(source, target bhmetapb.Shard) error {
+	if source.ID == target.ID {
+		return fmt.Errorf("shard %d can not merge into itself", source.ID)
+	}
+
+	if source.Group != target.Group {
+		return fmt.Errorf("shard %d and target shard %d not in the same group",
+			source.ID,
+			target.ID)
+	}
+
+	if !isAdjacent(source, target) {
+		return fmt.Errorf("shard %d and target shard %d are not adjacent",
+			source.ID,
+			target.ID)
+	}
+
+	if len(source.Peers) != len(target.Peers) {
+		return fmt.Errorf("shard %d and target shard %d have different peers count",
+			source.ID,
+			target.ID)
+	}
+
+	for _, p := range source.Peers {
+		if findPeer(&target, p.ContainerID) == nil {
+			return fmt.Errorf("shard %d peer %d on store %d, but target shard %d not",
+				source.ID,
+				p.ID,
+				p.ContainerID,
+				target.ID)
+		}
+	}
+
+	return nil
+}
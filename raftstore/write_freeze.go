@@ -0,0 +1,112 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+)
+
+// writeFreezer rejects the write requests of the write frozen shard groups before proposing,
+// the read requests are still served. The frozen groups are managed by the prophet, pushed by the
+// watcher events and also updated by the store heartbeat responses. The store reports the groups
+// in effect by the store heartbeat, so the prophet knows when the change is in effect.
+type writeFreezer struct {
+	sync.Mutex // serialize updates
+
+	groups atomic.Value // map[uint64]struct{}
+	sorted []uint64
+}
+
+func newWriteFreezer() *writeFreezer {
+	f := &writeFreezer{}
+	f.groups.Store(make(map[uint64]struct{}))
+	return f
+}
+
+// update replaces all the write frozen groups, and returns true if the groups changed
+func (f *writeFreezer) update(groups []uint64) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	old := f.groups.Load().(map[uint64]struct{})
+	changed := len(old) != len(groups)
+	values := make(map[uint64]struct{}, len(groups))
+	for _, group := range groups {
+		if _, ok := old[group]; !ok {
+			changed = true
+		}
+		values[group] = struct{}{}
+	}
+
+	if changed {
+		logger.Infof("write frozen groups changed to %+v", groups)
+		f.groups.Store(values)
+		f.sorted = append([]uint64(nil), groups...)
+		sort.Slice(f.sorted, func(i, j int) bool {
+			return f.sorted[i] < f.sorted[j]
+		})
+	}
+	return changed
+}
+
+// frozenGroups returns the write frozen groups in effect in ascending order
+func (f *writeFreezer) frozenGroups() []uint64 {
+	f.Lock()
+	defer f.Unlock()
+
+	return append([]uint64(nil), f.sorted...)
+}
+
+// frozen returns true if the request is a write request of a write frozen group, the ingest
+// of the bulk load is a write too.
+func (f *writeFreezer) frozen(group uint64, req reqCtx) bool {
+	if !isWrite(req) {
+		return false
+	}
+
+	groups := f.groups.Load().(map[uint64]struct{})
+	if len(groups) == 0 {
+		return false
+	}
+
+	_, ok := groups[group]
+	return ok
+}
+
+// isWrite returns true if the request writes the data of the shard
+func isWrite(req reqCtx) bool {
+	if req.req != nil {
+		return req.req.Type == raftcmdpb.CMDType_Write
+	}
+	return isIngest(req.admin) && !req.admin.Ingest.Discard
+}
+
+// doWriteFreeze handles the write frozen groups pushed by the prophet, and reports the groups
+// in effect by a store heartbeat immediately.
+func (s *store) doWriteFreeze(groups []uint64) {
+	if s.freezer.update(groups) {
+		s.triggerStoreHeartbeat()
+	}
+}
+
+func (s *store) triggerStoreHeartbeat() {
+	select {
+	case s.storeHeartbeatC <- struct{}{}:
+	default:
+	}
+}
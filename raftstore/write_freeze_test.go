@@ -0,0 +1,106 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fagongzi/goetty"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFreezer(t *testing.T) {
+	f := newWriteFreezer()
+	write := reqCtx{req: createTestWriteReq("w1", "key", "value")}
+	read := reqCtx{req: createTestReadReq("r1", "key")}
+	ingest := reqCtx{admin: &raftcmdpb.AdminRequest{CmdType: raftcmdpb.AdminCmdType_Ingest,
+		Ingest: &raftcmdpb.IngestRequest{}}}
+	discard := reqCtx{admin: &raftcmdpb.AdminRequest{CmdType: raftcmdpb.AdminCmdType_Ingest,
+		Ingest: &raftcmdpb.IngestRequest{Discard: true}}}
+	assert.False(t, f.frozen(1, write))
+
+	assert.True(t, f.update([]uint64{1}))
+	assert.False(t, f.update([]uint64{1}))
+	assert.Equal(t, []uint64{1}, f.frozenGroups())
+	assert.True(t, f.frozen(1, write))
+	assert.False(t, f.frozen(1, read), "the reads are not frozen")
+	assert.False(t, f.frozen(2, write), "other groups are not frozen")
+	assert.True(t, f.frozen(1, ingest), "the ingests are frozen")
+	assert.False(t, f.frozen(1, discard), "the discarded ingests are not frozen")
+
+	assert.True(t, f.update([]uint64{3, 2}))
+	assert.Equal(t, []uint64{2, 3}, f.frozenGroups())
+	assert.False(t, f.frozen(1, write))
+	assert.True(t, f.frozen(2, write))
+
+	assert.True(t, f.update(nil))
+	assert.Empty(t, f.frozenGroups())
+	assert.False(t, f.frozen(2, write))
+}
+
+func TestWriteFreezeWithWriteFrozen(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, SetCMDTestClusterHandler, GetCMDTestClusterHandler)
+	defer c.Stop()
+
+	c.Start()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	s := c.GetShardLeaderStore(c.GetShardByIndex(0, 0).ID)
+	// SetWriteFreeze returns once all the stores put the change in effect
+	checkFrozen := func(frozen bool) {
+		for i := 0; i < 3; i++ {
+			groups := c.GetStore(i).(*store).freezer.frozenGroups()
+			assert.Equal(t, frozen, len(groups) > 0)
+		}
+	}
+
+	conn := goetty.NewIOSession(goetty.WithCodec(s.CreateRPCCliendSideCodec()))
+	ok, err := conn.Connect(s.GetConfig().ClientAddr, time.Second*5)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	defer conn.Close()
+
+	send := func(req *raftcmdpb.Request) *raftcmdpb.Response {
+		assert.NoError(t, conn.WriteAndFlush(req))
+		value, err := conn.Read()
+		assert.NoError(t, err)
+		return value.(*raftcmdpb.Response)
+	}
+
+	rsp := send(createTestWriteReq("w1", "key", "value"))
+	assert.Equal(t, []byte("OK"), rsp.Value)
+
+	assert.NoError(t, c.GetProphet().GetClient().SetWriteFreeze(0, true))
+	checkFrozen(true)
+
+	rsp = send(createTestWriteReq("w2", "key", "value2"))
+	assert.NotNil(t, rsp.Error.WriteFrozen)
+	assert.Equal(t, raftcmdpb.CMDType_Invalid, rsp.Type)
+
+	// the reads are still served
+	rsp = send(createTestReadReq("r1", "key"))
+	assert.Nil(t, rsp.Error.WriteFrozen)
+	assert.Equal(t, []byte("value"), rsp.Value)
+
+	assert.NoError(t, c.GetProphet().GetClient().SetWriteFreeze(0, false))
+	checkFrozen(false)
+
+	rsp = send(createTestWriteReq("w3", "key", "value3"))
+	assert.Nil(t, rsp.Error.WriteFrozen)
+	assert.Equal(t, []byte("OK"), rsp.Value)
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+)
+
+var (
+	// discardIngestTimeout the timeout to discard an ingest file of the failed bulk load
+	discardIngestTimeout = time.Second * 10
+
+	// ErrBulkLoadAborted the shard is split, merged or its peers are changed during the bulk load
+	ErrBulkLoadAborted = errors.New("bulk load aborted by the changed shard")
+)
+
+// BulkLoad loads the pre-built key-value pairs into the shards of the group without writing them
+// through raft. The keys must be returned by next in ascending order, and the returned slices
+// must not be modified later. The key-value pairs of a shard are ingested atomically, the bulk
+// load is aborted with ErrBulkLoadAborted if a shard is split, merged or its peers are changed
+// before ingested. The shards ingested before aborted are not rolled back, it's safe to retry
+// the bulk load because the ingestion overwrites the existing values. The files not ingested are
+// discarded from all the replicas if the bulk load is failed. The ingested key-value pairs are
+// not captured by the change subscriptions.
+func (s *Application) BulkLoad(group uint64, next func() ([]byte, []byte, bool), timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	files, err := s.cfg.Store.CreateIngestFiles(group, next)
+	if err != nil {
+		s.discardIngestFiles(files)
+		return err
+	}
+
+	return s.ingestFiles(files, deadline)
+}
+
+func (s *Application) ingestFiles(files []raftstore.IngestFile, deadline time.Time) error {
+	for idx, file := range files {
+		if err := s.ingestFile(file, deadline); err != nil {
+			s.discardIngestFiles(files[idx:])
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Application) ingestFile(file raftstore.IngestFile, deadline time.Time) error {
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return raftstore.ErrTimeout
+	}
+
+	req := raftstore.NewIngestRequest(file)
+	req.StopAt = deadline.Unix()
+	value, err := s.execRequest(req, timeout)
+	if err != nil {
+		return err
+	}
+
+	rsp := &raftcmdpb.AdminResponse{}
+	if err := rsp.Unmarshal(value); err != nil {
+		return err
+	}
+	if rsp.Ingest == nil || rsp.Ingest.StaleEpoch {
+		return ErrBulkLoadAborted
+	}
+	if rsp.Ingest.Error != "" {
+		return fmt.Errorf("ingest file %d of shard %d failed with %s",
+			file.ID,
+			file.Shard.ID,
+			rsp.Ingest.Error)
+	}
+	return nil
+}
+
+// discardIngestFiles removes the files from all the replicas of the shards without waiting, the
+// files left by the failed discard requests are removed by the periodic scan of the snapshot dir.
+func (s *Application) discardIngestFiles(files []raftstore.IngestFile) {
+	for _, file := range files {
+		file := file
+		req := raftstore.NewDiscardIngestRequest(file)
+		s.asyncExecRequest(req, nil, func(_ interface{}, _ []byte, err error) {
+			if err != nil {
+				logger.Errorf("discard ingest file %d of shard %d failed with %+v",
+					file.ID,
+					file.Shard.ID,
+					err)
+			}
+		}, discardIngestTimeout, nil)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkLoad(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c, closer := createDiskDataStorageCluster(t)
+	defer closer()
+
+	app := c.Applications[0]
+	waitShardReplicas(t, c)
+	resp, err := app.Exec(&testRequest{Op: "SET", Key: "key-001", Value: "old"}, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", string(resp))
+
+	n := 0
+	next := func() ([]byte, []byte, bool) {
+		if n == 100 {
+			return nil, nil, false
+		}
+		n++
+		return []byte(fmt.Sprintf("key-%03d", n)), []byte(fmt.Sprintf("value-%03d", n)), true
+	}
+	assert.NoError(t, app.BulkLoad(0, next, 10*time.Second))
+
+	for i := 1; i <= 100; i++ {
+		value, err := app.Exec(&testRequest{Op: "GET", Key: fmt.Sprintf("key-%03d", i)}, 10*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("value-%03d", i), string(value))
+	}
+
+	// all the replicas ingested the file
+	c.RaftCluster.EveryStore(func(i int, s raftstore.Store) {
+		kv := s.DataStorageByGroup(0, 0).(storage.KVStorage)
+		assert.Eventually(t, func() bool {
+			value, err := kv.Get(raftstore.EncodeDataKey(0, []byte("key-100")))
+			return err == nil && string(value) == "value-100"
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+
+	// the keys must be in ascending order
+	keys := []string{"key-200", "key-199"}
+	unsorted := func() ([]byte, []byte, bool) {
+		if len(keys) == 0 {
+			return nil, nil, false
+		}
+		key := keys[0]
+		keys = keys[1:]
+		return []byte(key), []byte(key), true
+	}
+	assert.Error(t, app.BulkLoad(0, unsorted, 10*time.Second))
+}
+
+// waitShardReplicas waits until the routers of all the stores see the shard with a voter on
+// every store, the ingest files are aborted if the replicas are changed after created.
+func waitShardReplicas(t *testing.T, c *TestApplicationCluster) {
+	c.RaftCluster.WaitShardByCountPerNode(1, 10*time.Second)
+	assert.Eventually(t, func() bool {
+		var epochs []metapb.ResourceEpoch
+		c.RaftCluster.EveryStore(func(i int, s raftstore.Store) {
+			s.GetRouter().ForeachShards(0, func(shard *bhmetapb.Shard) bool {
+				voters := 0
+				for _, p := range shard.Peers {
+					if p.Role == metapb.PeerRole_Voter {
+						voters++
+					}
+				}
+				if voters == len(c.Applications) && len(shard.Peers) == voters {
+					epochs = append(epochs, shard.Epoch)
+				}
+				return true
+			})
+		})
+		if len(epochs) != len(c.Applications) {
+			return false
+		}
+		for _, epoch := range epochs {
+			if epoch.ConfVer != epochs[0].ConfVer || epoch.Version != epochs[0].Version {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestBulkLoadAbortedBySplit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c, closer := createDiskDataStorageCluster(t, raftstore.WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Replication.ShardCapacityBytes = typeutil.ByteSize(20)
+		cfg.Replication.ShardSplitCheckBytes = typeutil.ByteSize(10)
+	}))
+	defer closer()
+
+	app := c.Applications[0]
+	shard := c.RaftCluster.GetShardByIndex(0, 0)
+	keys := []string{"key1", "key3"}
+	next := func() ([]byte, []byte, bool) {
+		if len(keys) == 0 {
+			return nil, nil, false
+		}
+		key := keys[0]
+		keys = keys[1:]
+		return []byte(key), []byte("bulk"), true
+	}
+	files, err := c.RaftCluster.GetStore(0).CreateIngestFiles(0, next)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(files))
+
+	// split the shard before the file ingested
+	for _, key := range []string{"key1", "key2", "key3"} {
+		_, err := app.Exec(&testRequest{Op: "SET", Key: key, Value: "value-" + key}, 10*time.Second)
+		assert.NoError(t, err)
+	}
+	c.RaftCluster.WaitShardSplitByCount(shard.ID, 1, 10*time.Second)
+
+	assert.Equal(t, ErrBulkLoadAborted, app.ingestFiles(files, time.Now().Add(10*time.Second)))
+	value, err := app.Exec(&testRequest{Op: "GET", Key: "key1"}, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "value-key1", string(value))
+
+	// the file is removed from all the replicas
+	c.RaftCluster.EveryStore(func(i int, s raftstore.Store) {
+		cfg := s.GetConfig()
+		file := fmt.Sprintf("%s/%d_0_%d.gz", cfg.SnapshotDir(), shard.ID, files[0].ID)
+		assert.Eventually(t, func() bool {
+			_, err := cfg.FS.Stat(file)
+			return err != nil
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestIngestMissingFile(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c, closer := createDiskDataStorageCluster(t)
+	defer closer()
+
+	app := c.Applications[0]
+	file := raftstore.IngestFile{ID: 10000, Shard: c.RaftCluster.GetShardByIndex(0, 0)}
+	assert.Error(t, app.ingestFile(file, time.Now().Add(10*time.Second)))
+}
+
+func TestIngestFileMissingOnReplica(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c, closer := createDiskDataStorageCluster(t)
+	defer closer()
+
+	app := c.Applications[0]
+	waitShardReplicas(t, c)
+	keys := []string{"key1"}
+	next := func() ([]byte, []byte, bool) {
+		if len(keys) == 0 {
+			return nil, nil, false
+		}
+		key := keys[0]
+		keys = keys[1:]
+		return []byte(key), []byte("bulk"), true
+	}
+	files, err := c.RaftCluster.GetStore(0).CreateIngestFiles(0, next)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(files))
+
+	// one replica lost the file, the ingest is rejected before proposed
+	cfg := c.RaftCluster.GetStore(2).GetConfig()
+	file := fmt.Sprintf("%s/%d_0_%d.gz", cfg.SnapshotDir(), files[0].Shard.ID, files[0].ID)
+	assert.NoError(t, cfg.FS.RemoveAll(file))
+	assert.Error(t, app.ingestFile(files[0], time.Now().Add(10*time.Second)))
+
+	time.Sleep(time.Second)
+	c.RaftCluster.EveryStore(func(i int, s raftstore.Store) {
+		kv := s.DataStorageByGroup(0, 0).(storage.KVStorage)
+		value, err := kv.Get(raftstore.EncodeDataKey(0, []byte("key1")))
+		assert.NoError(t, err)
+		assert.Empty(t, value)
+	})
+}
@@ -14,6 +14,9 @@
 package server
 
 import (
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/pb/metapb"
 	"github.com/matrixorigin/matrixcube/raftstore"
 )
 
@@ -23,4 +26,10 @@ type Cfg struct {
 	Store          raftstore.Store
 	Handler        Handler
 	ExternalServer bool
+	// LearnerReadLabels the read requests which allow follower read are routed to the
+	// learners whose store has all the labels.
+	LearnerReadLabels []metapb.Pair
+	// TxnLockTTL the ttl of the transaction locks, the lock of a crashed transaction can be
+	// resolved by the other transactions after the ttl since the start ts of the transaction.
+	TxnLockTTL time.Duration
 }
@@ -22,7 +22,8 @@ import (
 // Handler is the request handler
 type Handler interface {
 	// BuildRequest build the request, fill the key, cmd, type,
-	// and the custom type
+	// the custom type, and the tenant if the requests are limited
+	// by the rate limits of the tenants
 	BuildRequest(*raftcmdpb.Request, interface{}) error
 	// Codec returns the decoder and encoder to transfer request and response
 	Codec() (codec.Encoder, codec.Decoder)
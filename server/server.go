@@ -24,10 +24,13 @@ import (
 	"github.com/fagongzi/log"
 	"github.com/fagongzi/util/hack"
 	"github.com/fagongzi/util/uuid"
+	"github.com/matrixorigin/matrixcube/auth"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"github.com/matrixorigin/matrixcube/pb"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
 	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/txn"
 	"github.com/matrixorigin/matrixcube/util"
 	"go.uber.org/zap"
 )
@@ -43,6 +46,7 @@ type Application struct {
 	shardsProxy raftstore.ShardsProxy
 	libaryCB    sync.Map // id -> application cb
 	dispatcher  func(req *raftcmdpb.Request, cmd interface{}, proxy raftstore.ShardsProxy) error
+	auth        *auth.Checker
 }
 
 // NewApplication returns a tcp application server
@@ -52,14 +56,27 @@ func NewApplication(cfg Cfg) *Application {
 
 // NewApplication returns a tcp application server
 func NewApplicationWithDispatcher(cfg Cfg, dispatcher func(req *raftcmdpb.Request, cmd interface{}, proxy raftstore.ShardsProxy) error) *Application {
+	if cfg.TxnLockTTL == 0 {
+		cfg.TxnLockTTL = defaultTxnLockTTL
+	}
+
 	s := &Application{
 		cfg:        cfg,
 		dispatcher: dispatcher,
 	}
+	var tls *tlsutil.TLS
+	if cfg.Store != nil {
+		txn.RegisterHandlers(cfg.Store)
+		tls = cfg.Store.GetTLS()
+		storeCfg := cfg.Store.GetConfig()
+		s.auth = auth.NewChecker(storeCfg.Customize.CustomAuthenticator,
+			storeCfg.Customize.CustomAuthorizer,
+			storeCfg.InternalToken)
+	}
 
 	if !cfg.ExternalServer {
 		encoder, decoder := cfg.Handler.Codec()
-		app, err := goetty.NewTCPApplication(cfg.Addr, s.onMessage,
+		app, err := tls.NewApplication(cfg.Addr, s.onMessage,
 			goetty.WithAppSessionOptions(goetty.WithCodec(encoder, decoder),
 				goetty.WithEnableAsyncWrite(16),
 				goetty.WithLogger(zap.L().Named("cube-app")),
@@ -75,7 +92,8 @@ func NewApplicationWithDispatcher(cfg Cfg, dispatcher func(req *raftcmdpb.Reques
 // Start start the application server
 func (s *Application) Start() error {
 	s.cfg.Store.Start()
-	sp, err := raftstore.NewShardsProxyWithStore(s.cfg.Store, s.done, s.doneError)
+	sp, err := raftstore.NewShardsProxyWithStore(s.cfg.Store, s.done, s.doneError,
+		raftstore.WithLearnerReadLabels(s.cfg.LearnerReadLabels))
 	if err != nil {
 		return err
 	}
@@ -110,6 +128,18 @@ func (s *Application) Exec(cmd interface{}, timeout time.Duration) ([]byte, erro
 
 // ExecWithGroup exec the request command
 func (s *Application) ExecWithGroup(cmd interface{}, group uint64, timeout time.Duration) ([]byte, error) {
+	return s.syncExec(func(cb func(interface{}, []byte, error)) {
+		s.AsyncExecWithGroupAndTimeout(cmd, group, cb, timeout, nil)
+	})
+}
+
+func (s *Application) execRequest(req *raftcmdpb.Request, timeout time.Duration) ([]byte, error) {
+	return s.syncExec(func(cb func(interface{}, []byte, error)) {
+		s.asyncExecRequest(req, nil, cb, timeout, nil)
+	})
+}
+
+func (s *Application) syncExec(fn func(cb func(interface{}, []byte, error))) ([]byte, error) {
 	completeC := make(chan interface{}, 1)
 	closed := uint32(0)
 	cb := func(cmd interface{}, resp []byte, err error) {
@@ -123,7 +153,7 @@ func (s *Application) ExecWithGroup(cmd interface{}, group uint64, timeout time.
 		}
 	}
 
-	s.AsyncExecWithGroupAndTimeout(cmd, group, cb, timeout, nil)
+	fn(cb)
 	value := <-completeC
 	switch v := value.(type) {
 	case error:
@@ -157,6 +187,12 @@ func (s *Application) AsyncExecWithGroupAndTimeout(cmd interface{}, group uint64
 		return
 	}
 
+	s.asyncExecRequest(req, cmd, cb, timeout, arg)
+}
+
+// asyncExecRequest async exec the built request, the request is dispatched by the dispatcher
+// if the cmd is not nil.
+func (s *Application) asyncExecRequest(req *raftcmdpb.Request, cmd interface{}, cb func(interface{}, []byte, error), timeout time.Duration, arg interface{}) {
 	s.libaryCB.Store(hack.SliceToString(req.ID), ctx{
 		arg: arg,
 		cb:  cb,
@@ -165,7 +201,8 @@ func (s *Application) AsyncExecWithGroupAndTimeout(cmd interface{}, group uint64
 		util.DefaultTimeoutWheel().Schedule(timeout, s.execTimeout, req.ID)
 	}
 
-	if s.dispatcher != nil {
+	var err error
+	if s.dispatcher != nil && cmd != nil {
 		err = s.dispatcher(req, cmd, s.shardsProxy)
 	} else {
 		err = s.shardsProxy.Dispatch(req)
@@ -226,6 +263,18 @@ func (s *Application) onMessage(conn goetty.IOSession, cmd interface{}, seq uint
 		return nil
 	}
 
+	if e := s.auth.Check(req); e != nil {
+		resp := &raftcmdpb.Response{}
+		resp.ID = req.ID
+		resp.SID = req.SID
+		resp.Error = *e
+		conn.WriteAndFlush(resp)
+		pb.ReleaseRequest(req)
+		return nil
+	}
+	// the request forwarded to other stores carries the internal token instead
+	req.Token = ""
+
 	if s.dispatcher != nil {
 		err = s.dispatcher(req, cmd, s.shardsProxy)
 	} else {
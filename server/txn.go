@@ -0,0 +1,302 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/fagongzi/util/hack"
+	"github.com/fagongzi/util/uuid"
+	"github.com/matrixorigin/matrixcube/pb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/txn"
+)
+
+const (
+	defaultTxnLockTTL   = time.Second * 3
+	txnResolveLockDelay = time.Millisecond * 50
+)
+
+// BeginTxn begins a cross-shard transaction on the keys of the group. The transaction reads the
+// values committed before it started, and fails with txn.ErrWriteConflict if any key written is
+// committed by others after the transaction started. The keys written by the transactions are
+// stored as txn.EncodeKey, they must be read and written by transactions only.
+func (s *Application) BeginTxn(group uint64) (*Txn, error) {
+	startTS, err := s.allocTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Txn{
+		app:       s,
+		group:     group,
+		startTS:   startTS,
+		mutations: make(map[string]txnMutation),
+	}, nil
+}
+
+func (s *Application) allocTimestamp() (uint64, error) {
+	return s.cfg.Store.Prophet().GetClient().AllocTimestamp()
+}
+
+type txnMutation struct {
+	op    txn.Op
+	value []byte
+}
+
+// Txn is a cross-shard transaction, the writes are buffered and committed atomically by the
+// two phase commit. Txn is not safe for concurrent use.
+type Txn struct {
+	app       *Application
+	group     uint64
+	startTS   uint64
+	keys      [][]byte
+	mutations map[string]txnMutation
+}
+
+// StartTS returns the start ts of the transaction
+func (t *Txn) StartTS() uint64 {
+	return t.startTS
+}
+
+// Set sets the value of the key in the transaction
+func (t *Txn) Set(key, value []byte) {
+	t.addMutation(key, txnMutation{op: txn.OpPut, value: value})
+}
+
+// Delete deletes the key in the transaction
+func (t *Txn) Delete(key []byte) {
+	t.addMutation(key, txnMutation{op: txn.OpDelete})
+}
+
+func (t *Txn) addMutation(key []byte, m txnMutation) {
+	if _, ok := t.mutations[hack.SliceToString(key)]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.mutations[string(key)] = m
+}
+
+// Get returns the value of the key, the locks of the other transactions are resolved or
+// waited until the timeout.
+func (t *Txn) Get(key []byte, timeout time.Duration) ([]byte, error) {
+	if m, ok := t.mutations[hack.SliceToString(key)]; ok {
+		if m.op == txn.OpDelete {
+			return nil, nil
+		}
+		return m.value, nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := t.exec(txn.GetType, key, txn.Request{StartTS: t.startTS}, deadline)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.Status {
+		case txn.StatusOK:
+			return resp.Value, nil
+		case txn.StatusLocked:
+			if err := t.waitLock(key, resp.Lock, deadline); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, resp.Status.Err()
+		}
+	}
+}
+
+// Commit commits the transaction, returns txn.ErrWriteConflict or txn.ErrAborted if the
+// transaction is rolled back. The transaction is committed if the primary key is committed,
+// the secondary keys failed to commit are committed by the lock resolution later.
+func (t *Txn) Commit(timeout time.Duration) error {
+	if len(t.keys) == 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	primary := t.keys[0]
+	secondaries := t.keys[1:]
+	for idx, key := range t.keys {
+		m := t.mutations[hack.SliceToString(key)]
+		req := txn.Request{
+			StartTS: t.startTS,
+			Primary: primary,
+			Op:      m.op,
+			Value:   m.value,
+			TTL:     int64(t.app.cfg.TxnLockTTL),
+		}
+		if idx == 0 {
+			req.Secondaries = secondaries
+		}
+
+		if err := t.prewrite(key, req, deadline); err != nil {
+			t.rollback(t.keys[:idx+1])
+			return err
+		}
+	}
+
+	commitTS, err := t.app.allocTimestamp()
+	if err != nil {
+		t.rollback(t.keys)
+		return err
+	}
+
+	req := txn.Request{StartTS: t.startTS, CommitTS: commitTS}
+	resp, err := t.exec(txn.CommitType, primary, req, deadline)
+	if err != nil {
+		// the status of the transaction is unknown
+		return err
+	}
+	if resp.Status != txn.StatusOK {
+		t.rollback(secondaries)
+		return resp.Status.Err()
+	}
+
+	if len(secondaries) > 0 && t.commitKeys(secondaries, req, deadline) {
+		t.exec(txn.CleanupType, primary, txn.Request{StartTS: t.startTS}, deadline)
+	}
+	return nil
+}
+
+func (t *Txn) prewrite(key []byte, req txn.Request, deadline time.Time) error {
+	for {
+		resp, err := t.exec(txn.PrewriteType, key, req, deadline)
+		if err != nil {
+			return err
+		}
+
+		switch resp.Status {
+		case txn.StatusOK:
+			return nil
+		case txn.StatusLocked:
+			if err := t.waitLock(key, resp.Lock, deadline); err != nil {
+				return err
+			}
+		default:
+			return resp.Status.Err()
+		}
+	}
+}
+
+// rollback rollbacks the keys, the rollback is not limited by the deadline of the commit, the
+// locks left are resolved by the others after the lock ttl.
+func (t *Txn) rollback(keys [][]byte) {
+	deadline := time.Now().Add(t.app.cfg.TxnLockTTL)
+	for _, key := range keys {
+		t.exec(txn.RollbackType, key, txn.Request{StartTS: t.startTS, Primary: t.keys[0]}, deadline)
+	}
+}
+
+// commitKeys commits the keys, returns true if all the keys are committed
+func (t *Txn) commitKeys(keys [][]byte, req txn.Request, deadline time.Time) bool {
+	committed := true
+	for _, key := range keys {
+		resp, err := t.exec(txn.CommitType, key, req, deadline)
+		if err != nil || resp.Status != txn.StatusOK {
+			committed = false
+		}
+	}
+	return committed
+}
+
+// waitLock resolves the lock of the key, and waits a while if the transaction of the lock is
+// still in progress.
+func (t *Txn) waitLock(key []byte, lock *txn.Lock, deadline time.Time) error {
+	err := t.resolveLock(key, lock, deadline)
+	if err != txn.ErrKeyLocked {
+		return err
+	}
+
+	if time.Now().Add(txnResolveLockDelay).After(deadline) {
+		return err
+	}
+	time.Sleep(txnResolveLockDelay)
+	return nil
+}
+
+// resolveLock resolves the lock left by the other transaction according to the status of
+// the primary key, returns txn.ErrKeyLocked if the transaction is still in progress.
+func (t *Txn) resolveLock(key []byte, lock *txn.Lock, deadline time.Time) error {
+	now, err := t.app.allocTimestamp()
+	if err != nil {
+		return err
+	}
+
+	req := txn.Request{StartTS: lock.StartTS, Now: now}
+	status, err := t.exec(txn.CheckTxnStatusType, lock.Primary, req, deadline)
+	if err != nil {
+		return err
+	}
+
+	switch status.Status {
+	case txn.StatusLocked:
+		return txn.ErrKeyLocked
+	case txn.StatusCommitted:
+		// commit all the secondary keys of the transaction, the status of the transaction is
+		// kept in the primary key until all of them committed.
+		keys := status.Secondaries
+		if len(keys) == 0 && !bytes.Equal(key, lock.Primary) {
+			keys = [][]byte{key}
+		}
+		req := txn.Request{StartTS: lock.StartTS, CommitTS: status.CommitTS}
+		if !t.commitKeys(keys, req, deadline) {
+			return txn.ErrKeyLocked
+		}
+		if len(status.Secondaries) > 0 {
+			t.exec(txn.CleanupType, lock.Primary, txn.Request{StartTS: lock.StartTS}, deadline)
+		}
+		return nil
+	case txn.StatusAborted:
+		if !bytes.Equal(key, lock.Primary) {
+			req := txn.Request{StartTS: lock.StartTS, Primary: lock.Primary}
+			if _, err := t.exec(txn.RollbackType, key, req, deadline); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return status.Status.Err()
+}
+
+func (t *Txn) exec(ct uint64, key []byte, req txn.Request, deadline time.Time) (txn.Response, error) {
+	resp := txn.Response{}
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return resp, raftstore.ErrTimeout
+	}
+
+	r := pb.AcquireRequest()
+	r.ID = uuid.NewV4().Bytes()
+	r.Group = t.group
+	r.StopAt = deadline.Unix()
+	r.Key = txn.EncodeKey(key)
+	r.CustemType = ct
+	r.Type = raftcmdpb.CMDType_Write
+	if ct == txn.GetType {
+		r.Type = raftcmdpb.CMDType_Read
+	}
+	r.Cmd = req.Marshal()
+
+	value, err := t.app.execRequest(r, timeout)
+	if err != nil {
+		return resp, err
+	}
+	if err := resp.Unmarshal(value); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/txn"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	c, closer := createDiskDataStorageCluster(t)
+	defer closer()
+
+	app := c.Applications[0]
+	app.cfg.TxnLockTTL = time.Millisecond * 200
+	timeout := time.Second * 10
+
+	get := func(key string) []byte {
+		tx, err := app.BeginTxn(0)
+		assert.NoError(t, err)
+		value, err := tx.Get([]byte(key), timeout)
+		assert.NoError(t, err)
+		return value
+	}
+
+	t1, err := app.BeginTxn(0)
+	assert.NoError(t, err)
+	t1.Set([]byte("k1"), []byte("v1"))
+	t1.Set([]byte("k2"), []byte("v2"))
+	assert.NoError(t, t1.Commit(timeout))
+	assert.Equal(t, []byte("v1"), get("k1"))
+	assert.Equal(t, []byte("v2"), get("k2"))
+
+	// write conflict
+	t2, err := app.BeginTxn(0)
+	assert.NoError(t, err)
+	t3, err := app.BeginTxn(0)
+	assert.NoError(t, err)
+	t3.Set([]byte("k1"), []byte("v3"))
+	assert.NoError(t, t3.Commit(timeout))
+	// t2 reads the value committed before it started
+	value, err := t2.Get([]byte("k1"), timeout)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), value)
+	t2.Set([]byte("k2"), []byte("v4"))
+	t2.Set([]byte("k1"), []byte("v4"))
+	assert.Equal(t, txn.ErrWriteConflict, t2.Commit(timeout))
+	assert.Equal(t, []byte("v3"), get("k1"))
+	assert.Equal(t, []byte("v2"), get("k2"))
+
+	// the transaction crashed before the primary key committed is rolled back
+	t4, err := app.BeginTxn(0)
+	assert.NoError(t, err)
+	prewrite(t, t4, "v5", timeout)
+	assert.Equal(t, []byte("v2"), get("k2"))
+	assert.Equal(t, []byte("v3"), get("k1"))
+
+	// the transaction crashed after the primary key committed is committed
+	t5, err := app.BeginTxn(0)
+	assert.NoError(t, err)
+	prewrite(t, t5, "v6", timeout)
+	commitTS, err := app.allocTimestamp()
+	assert.NoError(t, err)
+	resp, err := t5.exec(txn.CommitType, []byte("k1"), txn.Request{StartTS: t5.StartTS(), CommitTS: commitTS},
+		time.Now().Add(timeout))
+	assert.NoError(t, err)
+	assert.Equal(t, txn.StatusOK, resp.Status)
+	assert.Equal(t, []byte("v6"), get("k2"))
+	assert.Equal(t, []byte("v6"), get("k1"))
+}
+
+func prewrite(t *testing.T, tx *Txn, value string, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	req := txn.Request{
+		StartTS:     tx.StartTS(),
+		Primary:     []byte("k1"),
+		Value:       []byte(value),
+		TTL:         int64(tx.app.cfg.TxnLockTTL),
+		Secondaries: [][]byte{[]byte("k2")},
+	}
+	assert.NoError(t, tx.prewrite([]byte("k1"), req, deadline))
+	req.Secondaries = nil
+	assert.NoError(t, tx.prewrite([]byte("k2"), req, deadline))
+}
@@ -38,3 +38,28 @@ type SnapshotManager interface {
 	Apply(msg *bhraftpb.SnapshotMessage) error
 	ReceiveSnapCount() uint64
 }
+
+// SnapshotProber is implemented by the SnapshotManager which can resume the interrupted
+// transfers of the snapshot files. The probes to the SnapshotManager not implementing it are
+// not answered, the snapshots are sent to it from the beginning.
+type SnapshotProber interface {
+	// Probe asks the store of the `msg.Header.To` how many bytes of the snapshot file are
+	// received over the conn.
+	Probe(msg *bhraftpb.SnapshotMessage, conn goetty.IOSession) (uint64, error)
+	// ReceivedSnapBytes returns the bytes of the snapshot file received, the sender
+	// resumes the transfer from it.
+	ReceivedSnapBytes(msg *bhraftpb.SnapshotMessage) uint64
+}
+
+// IngestManager is implemented by the SnapshotManager which supports the bulk load, the bulk
+// load is unsupported if the SnapshotManager doesn't implement it. The ingest files are checked
+// held by all the replicas by the SnapshotProber.
+type IngestManager interface {
+	// CreateIngest creates the ingest file of the bulk load with the key-value pairs returned by
+	// next, the file is sent to the peers of the shard the same as the snapshot.
+	CreateIngest(msg *bhraftpb.SnapshotMessage, next func() ([]byte, []byte, bool)) error
+	// Ingest ingests the ingest file into the data storage of the shard. The file is kept, the
+	// caller removes it by CleanSnap once the applied index of the ingest is durable, so the
+	// ingest can be applied again after restart.
+	Ingest(msg *bhraftpb.SnapshotMessage) error
+}
@@ -16,6 +16,7 @@ package mem
 import (
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"sync/atomic"
 	"time"
@@ -59,11 +60,19 @@ func (s *Storage) Set(key []byte, value []byte) error {
 
 // SetWithTTL put the key, value pair to the storage with a ttl in seconds
 func (s *Storage) SetWithTTL(key []byte, value []byte, ttl int32) error {
+	return s.setWithTTL(key, value, ttl, 0)
+}
+
+// setWithTTL put the key, value pair with the ttl counted from start, 0 means the current time
+func (s *Storage) setWithTTL(key []byte, value []byte, ttl int32, start int64) error {
 	atomic.AddUint64(&s.stats.WrittenKeys, 1)
 	atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(value)+len(key)))
 
 	if ttl > 0 {
-		s.kv.Put(key, encodeValue(value, time.Now().Add(time.Second*time.Duration(ttl)).Unix()))
+		if start == 0 {
+			start = time.Now().Unix()
+		}
+		s.kv.Put(key, encodeValue(value, start+int64(ttl)))
 	} else {
 		s.kv.Put(key, encodeValue(value, 0))
 	}
@@ -197,6 +206,29 @@ func (s *Storage) SplitCheck(start []byte, end []byte, size uint64) (uint64, uin
 	return total, keys, splitKeys, nil
 }
 
+// HashSnapshot copies the key-value pairs in [start, end) and returns a function computing the
+// hash of the copied pairs
+func (s *Storage) HashSnapshot(start []byte, end []byte, at int64) (func() ([]byte, error), error) {
+	var pairs [][]byte
+	s.kv.Scan(start, end, func(key, value []byte) (bool, error) {
+		expireAt := buf.Byte2Int64(value)
+		if expireAt != 0 && expireAt < at {
+			return true, nil
+		}
+
+		pairs = append(pairs, append([]byte(nil), key...), append([]byte(nil), value...))
+		return true, nil
+	})
+
+	return func() ([]byte, error) {
+		h := crc32.NewIEEE()
+		for _, data := range pairs {
+			h.Write(data)
+		}
+		return h.Sum(nil), nil
+	}, nil
+}
+
 // Seek returns the first key-value that >= key
 func (s *Storage) Seek(key []byte) ([]byte, []byte, error) {
 	k, v := s.kv.Seek(key)
@@ -220,7 +252,7 @@ func (s *Storage) Write(wb *util.WriteBatch, sync bool) error {
 		case util.OpDelete:
 			s.Delete(wb.Keys[idx])
 		case util.OpSet:
-			s.SetWithTTL(wb.Keys[idx], wb.Values[idx], wb.TTLs[idx])
+			s.setWithTTL(wb.Keys[idx], wb.Values[idx], wb.TTLs[idx], wb.TTLStart)
 		}
 	}
 	return nil
@@ -233,6 +265,29 @@ func (s *Storage) RemoveShardData(shard bhmetapb.Shard, encodedStartKey, encoded
 
 // CreateSnapshot create a snapshot file under the giving path
 func (s *Storage) CreateSnapshot(path string, start, end []byte) error {
+	create, err := s.SnapshotView(start, end)
+	if err != nil {
+		return err
+	}
+
+	return create(path)
+}
+
+// SnapshotView copies the key-value pairs in [start, end), the returned function creates the
+// snapshot file of them as CreateSnapshot.
+func (s *Storage) SnapshotView(start, end []byte) (func(path string) error, error) {
+	var pairs [][]byte
+	s.kv.Scan(start, end, func(key, value []byte) (bool, error) {
+		pairs = append(pairs, append([]byte(nil), key...), append([]byte(nil), value...))
+		return true, nil
+	})
+
+	return func(path string) error {
+		return s.createSnapshot(pairs, path, start, end)
+	}, nil
+}
+
+func (s *Storage) createSnapshot(pairs [][]byte, path string, start, end []byte) error {
 	err := s.fs.MkdirAll(path, 0755)
 	if err != nil {
 		return err
@@ -253,19 +308,12 @@ func (s *Storage) CreateSnapshot(path string, start, end []byte) error {
 		return err
 	}
 
-	return s.kv.Scan(start, end, func(key, value []byte) (bool, error) {
-		err := writeBytes(f, key)
-		if err != nil {
-			return false, err
-		}
-
-		err = writeBytes(f, value)
-		if err != nil {
-			return false, err
+	for _, data := range pairs {
+		if err := writeBytes(f, data); err != nil {
+			return err
 		}
-
-		return true, nil
-	})
+	}
+	return nil
 }
 
 // ApplySnapshot apply a snapshort file from giving path
@@ -318,6 +366,73 @@ func (s *Storage) ApplySnapshot(path string) error {
 	return nil
 }
 
+// CreateIngestFile creates a file under the giving path with the key-value pairs returned by next
+func (s *Storage) CreateIngestFile(path string, next func() ([]byte, []byte, bool)) error {
+	err := s.fs.MkdirAll(path, 0755)
+	if err != nil {
+		return err
+	}
+	f, err := s.fs.Create(s.fs.PathJoin(path, "ingest.data"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for {
+		key, value, ok := next()
+		if !ok {
+			return nil
+		}
+
+		err = writeBytes(f, key)
+		if err != nil {
+			return err
+		}
+
+		err = writeBytes(f, encodeValue(value, 0))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// IngestFile puts the key-value pairs of the file created by CreateIngestFile
+func (s *Storage) IngestFile(path string) error {
+	f, err := s.fs.Open(s.fs.PathJoin(path, "ingest.data"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var pairs [][]byte
+	for {
+		key, err := readBytes(f)
+		if err != nil {
+			return err
+		}
+		if len(key) == 0 {
+			break
+		}
+
+		value, err := readBytes(f)
+		if err != nil {
+			return err
+		}
+		if len(value) == 0 {
+			return fmt.Errorf("error format, missing value field")
+		}
+
+		pairs = append(pairs, key, value)
+	}
+
+	for i := 0; i < len(pairs); i += 2 {
+		atomic.AddUint64(&s.stats.WrittenKeys, 1)
+		atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(pairs[i])+len(pairs[i+1])))
+		s.kv.Put(pairs[i], pairs[i+1])
+	}
+	return nil
+}
+
 // Close close the storage
 func (s *Storage) Close() error {
 	return nil
@@ -17,28 +17,51 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"sync"
 	"sync/atomic"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/fagongzi/goetty/buf"
 	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
 	"github.com/matrixorigin/matrixcube/storage/stats"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/vfs"
 )
 
+const (
+	// snapshotDataFile all key-value pairs of the snapshot created by the older version
+	snapshotDataFile = "db.data"
+	// snapshotRangeFile the range of the snapshot
+	snapshotRangeFile = "db.range"
+	// snapshotSSTFile the sst file of the snapshot
+	snapshotSSTFile = "db.sst"
+	// ingestSSTFile the sst file created for the bulk load
+	ingestSSTFile = "ingest.sst"
+)
+
 // Storage returns a kv storage based on badger
 type Storage struct {
 	db    *pebble.DB
 	fs    vfs.FS
 	stats stats.Stats
+	opts  options
+	// writerOpts options to write the sst file of the snapshot
+	writerOpts sstable.WriterOptions
+
+	// mu makes the sweeper's check and remove of the expired keys atomic with the writes
+	mu      sync.RWMutex
+	stopper chan struct{}
+	stopWG  sync.WaitGroup
 
 	// SyncCount number of `Sync` method called
 	SyncCount uint64
 }
 
 // NewStorage returns a pebble backed kv store
-func NewStorage(dir string, opts *pebble.Options) (*Storage, error) {
+func NewStorage(dir string, opts *pebble.Options, options ...Option) (*Storage, error) {
 	if !hasEventListener(opts.EventListener) {
 		opts.EventListener = getEventListener()
 	}
@@ -58,10 +81,20 @@ func NewStorage(dir string, opts *pebble.Options) (*Storage, error) {
 		panic("fs not set for pebble")
 	}
 
-	return &Storage{
-		db: db,
-		fs: fs,
-	}, nil
+	s := &Storage{
+		db:         db,
+		fs:         fs,
+		writerOpts: opts.Clone().EnsureDefaults().MakeWriterOptions(0),
+	}
+	for _, opt := range options {
+		opt(&s.opts)
+	}
+	s.opts.adjust()
+
+	if s.opts.ttl {
+		s.startSweeper()
+	}
+	return s, nil
 }
 
 func (s *Storage) Stats() stats.Stats {
@@ -70,14 +103,25 @@ func (s *Storage) Stats() stats.Stats {
 
 // Set put the key, value pair to the storage
 func (s *Storage) Set(key []byte, value []byte) error {
-	atomic.AddUint64(&s.stats.WrittenKeys, 1)
-	atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(value)+len(key)))
-	return s.db.Set(key, value, pebble.NoSync)
+	return s.set(key, value, 0)
 }
 
 // SetWithTTL put the key, value pair to the storage with a ttl in seconds
 func (s *Storage) SetWithTTL(key []byte, value []byte, ttl int32) error {
-	return fmt.Errorf("pebble storage not support set key-value with TTL")
+	if !s.opts.ttl {
+		return errTTLNotEnabled
+	}
+
+	return s.set(key, value, ttl)
+}
+
+func (s *Storage) set(key []byte, value []byte, ttl int32) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	atomic.AddUint64(&s.stats.WrittenKeys, 1)
+	atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(value)+len(key)))
+	return s.db.Set(key, s.encodeValue(value, ttl, 0), pebble.NoSync)
 }
 
 // BatchSet batch set
@@ -86,12 +130,15 @@ func (s *Storage) BatchSet(pairs ...[]byte) error {
 		return fmt.Errorf("invalid args len: %d", len(pairs))
 	}
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	b := s.db.NewBatch()
 	defer b.Close()
 
 	atomic.AddUint64(&s.stats.WrittenKeys, uint64(len(pairs)/2))
 	for i := 0; i < len(pairs)/2; i++ {
-		b.Set(pairs[2*i], pairs[2*i+1], nil)
+		b.Set(pairs[2*i], s.encodeValue(pairs[2*i+1], 0, 0), nil)
 		atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(pairs[2*i])+len(pairs[2*i+1])))
 	}
 
@@ -110,16 +157,15 @@ func (s *Storage) Get(key []byte) ([]byte, error) {
 	}
 
 	defer closer.Close()
-	if len(value) == 0 {
+	atomic.AddUint64(&s.stats.ReadKeys, 1)
+	atomic.AddUint64(&s.stats.ReadBytes, uint64(len(key)+len(value)))
+
+	value, ok := s.decodeValue(value)
+	if !ok || len(value) == 0 {
 		return nil, nil
 	}
 
-	v := make([]byte, len(value))
-	copy(v, value)
-
-	atomic.AddUint64(&s.stats.ReadKeys, 1)
-	atomic.AddUint64(&s.stats.ReadBytes, uint64(len(key)+len(value)))
-	return v, nil
+	return clone(value), nil
 }
 
 // MGet returns multi values
@@ -181,14 +227,20 @@ func (s *Storage) Scan(start, end []byte, handler func(key, value []byte) (bool,
 			return err
 		}
 
-		ok, err := handler(clone(iter.Key()), clone(iter.Value()))
+		atomic.AddUint64(&s.stats.ReadKeys, 1)
+		atomic.AddUint64(&s.stats.ReadBytes, uint64(len(iter.Key())+len(iter.Value())))
+
+		value, ok := s.decodeValue(iter.Value())
+		if !ok {
+			iter.Next()
+			continue
+		}
+
+		ok, err = handler(clone(iter.Key()), clone(value))
 		if err != nil {
 			return err
 		}
 
-		atomic.AddUint64(&s.stats.ReadKeys, 1)
-		atomic.AddUint64(&s.stats.ReadBytes, uint64(len(iter.Key())+len(iter.Value())))
-
 		if !ok {
 			break
 		}
@@ -215,12 +267,17 @@ func (s *Storage) PrefixScan(prefix []byte, handler func(key, value []byte) (boo
 		if ok := bytes.HasPrefix(iter.Key(), prefix); !ok {
 			break
 		}
-		ok, err := handler(clone(iter.Key()), clone(iter.Value()))
+		atomic.AddUint64(&s.stats.ReadKeys, 1)
+		atomic.AddUint64(&s.stats.ReadBytes, uint64(len(iter.Key())+len(iter.Value())))
+		value, ok := s.decodeValue(iter.Value())
+		if !ok {
+			iter.Next()
+			continue
+		}
+		ok, err = handler(clone(iter.Key()), clone(value))
 		if err != nil {
 			return err
 		}
-		atomic.AddUint64(&s.stats.ReadKeys, 1)
-		atomic.AddUint64(&s.stats.ReadBytes, uint64(len(iter.Key())+len(iter.Value())))
 		if !ok {
 			break
 		}
@@ -262,13 +319,19 @@ func (s *Storage) SplitCheck(start []byte, end []byte, size uint64) (uint64, uin
 			break
 		}
 
+		value, ok := s.decodeValue(iter.Value())
+		if !ok {
+			iter.Next()
+			continue
+		}
+
 		if appendSplitKey {
 			splitKeys = append(splitKeys, clone(iter.Key()))
 			appendSplitKey = false
 			sum = 0
 		}
 
-		n := uint64(len(iter.Key()) + len(iter.Value()))
+		n := uint64(len(iter.Key()) + len(value))
 		sum += n
 		total += n
 		keys++
@@ -286,6 +349,45 @@ func (s *Storage) SplitCheck(start []byte, end []byte, size uint64) (uint64, uin
 	return total, keys, splitKeys, nil
 }
 
+// HashSnapshot takes a snapshot of the key-value pairs in [start, end) and returns a function
+// computing the hash of the snapshot
+func (s *Storage) HashSnapshot(start []byte, end []byte, at int64) (func() ([]byte, error), error) {
+	snap := s.db.NewSnapshot()
+	return func() ([]byte, error) {
+		defer snap.Close()
+
+		h := crc32.NewIEEE()
+		iter := snap.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+		defer iter.Close()
+
+		// the value written without the TTL support is hashed as it never expires
+		expireAtData := make([]byte, expireAtSize)
+		for iter.First(); iter.Valid(); iter.Next() {
+			value := iter.Value()
+			atomic.AddUint64(&s.stats.ReadKeys, 1)
+			atomic.AddUint64(&s.stats.ReadBytes, uint64(len(iter.Key())+len(value)))
+
+			expireAt := int64(0)
+			if s.opts.ttl {
+				expireAt, value = parseValue(value)
+				if isExpiredAt(expireAt, at) {
+					continue
+				}
+			}
+
+			buf.Int64ToBytesTo(expireAt, expireAtData)
+			h.Write(iter.Key())
+			h.Write(expireAtData)
+			h.Write(value)
+		}
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+
+		return h.Sum(nil), nil
+	}, nil
+}
+
 // Seek returns the first key-value that >= key
 func (s *Storage) Seek(target []byte) ([]byte, []byte, error) {
 	var key, value []byte
@@ -294,17 +396,24 @@ func (s *Storage) Seek(target []byte) ([]byte, []byte, error) {
 	defer iter.Close()
 
 	iter.First()
-	if iter.Valid() {
+	for iter.Valid() {
 		err := iter.Error()
 		if err != nil {
 			return nil, nil, err
 		}
 
-		key = clone(iter.Key())
-		value = clone(iter.Value())
-
 		atomic.AddUint64(&s.stats.ReadKeys, 1)
 		atomic.AddUint64(&s.stats.ReadBytes, uint64(len(iter.Key())+len(iter.Value())))
+
+		v, ok := s.decodeValue(iter.Value())
+		if !ok {
+			iter.Next()
+			continue
+		}
+
+		key = clone(iter.Key())
+		value = clone(v)
+		break
 	}
 
 	return key, value, nil
@@ -316,6 +425,9 @@ func (s *Storage) Write(wb *util.WriteBatch, sync bool) error {
 		return nil
 	}
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	b := s.db.NewBatch()
 	defer b.Close()
 
@@ -327,12 +439,12 @@ func (s *Storage) Write(wb *util.WriteBatch, sync bool) error {
 			atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(wb.Keys[idx])))
 			err = b.Delete(wb.Keys[idx], nil)
 		case util.OpSet:
-			if wb.TTLs[idx] > 0 {
-				return fmt.Errorf("pebble storage not support set key-value with TTL")
+			if wb.TTLs[idx] > 0 && !s.opts.ttl {
+				return errTTLNotEnabled
 			}
 
 			atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(wb.Keys[idx])+len(wb.Values[idx])))
-			err = b.Set(wb.Keys[idx], wb.Values[idx], nil)
+			err = b.Set(wb.Keys[idx], s.encodeValue(wb.Values[idx], wb.TTLs[idx], wb.TTLStart), nil)
 		}
 
 		if err != nil {
@@ -354,30 +466,49 @@ func (s *Storage) Sync() error {
 	return s.db.Flush()
 }
 
-// CreateSnapshot create a snapshot file under the giving path
+// CreateSnapshot create a snapshot file under the giving path. The key-value pairs in
+// [start, end) are written into a sst file, which can be ingested into the pebble directly.
 func (s *Storage) CreateSnapshot(path string, start, end []byte) error {
-	err := s.fs.MkdirAll(path, 0755)
+	create, err := s.SnapshotView(start, end)
 	if err != nil {
 		return err
 	}
-	file := s.fs.PathJoin(path, "db.data")
-	f, err := s.fs.Create(file)
+
+	return create(path)
+}
+
+// SnapshotView takes a pebble snapshot of the key-value pairs in [start, end), the returned
+// function creates the snapshot file of it as CreateSnapshot.
+func (s *Storage) SnapshotView(start, end []byte) (func(path string) error, error) {
+	snap := s.db.NewSnapshot()
+	return func(path string) error {
+		defer snap.Close()
+		return s.createSnapshot(snap, path, start, end)
+	}, nil
+}
+
+func (s *Storage) createSnapshot(snap *pebble.Snapshot, path string, start, end []byte) error {
+	err := s.fs.MkdirAll(path, 0755)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	err = writeBytes(f, start)
+
+	err = s.writeSnapshotRange(path, start, end)
 	if err != nil {
 		return err
 	}
 
-	err = writeBytes(f, end)
+	f, err := s.fs.Create(s.fs.PathJoin(path, snapshotSSTFile))
 	if err != nil {
 		return err
 	}
-
-	snap := s.db.NewSnapshot()
-	defer snap.Close()
+	// the writer closes the file
+	w := sstable.NewWriter(f, s.writerOpts)
+	defer func() {
+		if w != nil {
+			w.Close()
+		}
+	}()
 
 	iter := snap.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
 	defer iter.Close()
@@ -393,12 +524,7 @@ func (s *Storage) CreateSnapshot(path string, start, end []byte) error {
 			break
 		}
 
-		err = writeBytes(f, iter.Key())
-		if err != nil {
-			return err
-		}
-
-		err = writeBytes(f, iter.Value())
+		err = w.Set(iter.Key(), iter.Value())
 		if err != nil {
 			return err
 		}
@@ -411,33 +537,146 @@ func (s *Storage) CreateSnapshot(path string, start, end []byte) error {
 		iter.Next()
 	}
 
-	return nil
+	err = w.Close()
+	w = nil
+	return err
 }
 
-// ApplySnapshot apply a snapshort file from giving path
+// ApplySnapshot apply a snapshort file from giving path. The snapshot created by the older
+// version, which all key-value pairs are written into the db.data file, is also supported.
 func (s *Storage) ApplySnapshot(path string) error {
-	f, err := s.fs.Open(s.fs.PathJoin(path, "db.data"))
+	if _, err := s.fs.Stat(s.fs.PathJoin(path, snapshotRangeFile)); err != nil {
+		if vfs.IsNotExist(err) {
+			return s.applyLegacySnapshot(path)
+		}
+		return err
+	}
+
+	start, end, err := s.readSnapshotRange(path)
+	if err != nil {
+		return err
+	}
+
+	file := s.fs.PathJoin(path, snapshotSSTFile)
+	info, err := s.fs.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.DeleteRange(start, end, pebble.NoSync)
+	if err != nil {
+		return err
+	}
+
+	// the ingested sst has a larger sequence number than the range deletion above,
+	// so the snapshot data will not be deleted.
+	err = s.db.Ingest([]string{file})
+	if err != nil {
+		return err
+	}
+
+	atomic.AddUint64(&s.stats.WrittenBytes, uint64(info.Size()))
+	return nil
+}
+
+// CreateIngestFile creates a sst file under the giving path with the key-value pairs returned by next
+func (s *Storage) CreateIngestFile(path string, next func() ([]byte, []byte, bool)) error {
+	err := s.fs.MkdirAll(path, 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := s.fs.Create(s.fs.PathJoin(path, ingestSSTFile))
+	if err != nil {
+		return err
+	}
+	// the writer closes the file
+	w := sstable.NewWriter(f, s.writerOpts)
+	defer func() {
+		if w != nil {
+			w.Close()
+		}
+	}()
+
+	for {
+		key, value, ok := next()
+		if !ok {
+			break
+		}
+
+		err = w.Set(key, s.encodeValue(value, 0, 0))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = w.Close()
+	w = nil
+	return err
+}
+
+// IngestFile ingests the sst file created by CreateIngestFile, the sst file is moved into the db.
+func (s *Storage) IngestFile(path string) error {
+	file := s.fs.PathJoin(path, ingestSSTFile)
+	info, err := s.fs.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.Ingest([]string{file})
+	if err != nil {
+		return err
+	}
+
+	atomic.AddUint64(&s.stats.WrittenBytes, uint64(info.Size()))
+	return nil
+}
+
+func (s *Storage) writeSnapshotRange(path string, start, end []byte) error {
+	f, err := s.fs.Create(s.fs.PathJoin(path, snapshotRangeFile))
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	start, err := readBytes(f)
+	err = writeBytes(f, start)
 	if err != nil {
 		return err
 	}
-	if len(start) == 0 {
-		return fmt.Errorf("error format, missing start field")
+	return writeBytes(f, end)
+}
+
+func (s *Storage) readSnapshotRange(path string) ([]byte, []byte, error) {
+	f, err := s.fs.Open(s.fs.PathJoin(path, snapshotRangeFile))
+	if err != nil {
+		return nil, nil, err
 	}
+	defer f.Close()
 
-	end, err := readBytes(f)
+	return readRange(f)
+}
+
+func (s *Storage) applyLegacySnapshot(path string) error {
+	f, err := s.fs.Open(s.fs.PathJoin(path, snapshotDataFile))
 	if err != nil {
 		return err
 	}
-	if len(end) == 0 {
-		return fmt.Errorf("error format, missing end field")
+	defer f.Close()
+
+	start, end, err := readRange(f)
+	if err != nil {
+		return err
 	}
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	err = s.db.DeleteRange(start, end, pebble.NoSync)
 	if err != nil {
 		return err
@@ -476,6 +715,7 @@ func (s *Storage) ApplySnapshot(path string) error {
 
 // Close close the storage
 func (s *Storage) Close() error {
+	s.stopSweeper()
 	return s.db.Close()
 }
 
@@ -500,6 +740,25 @@ func writeBytes(f vfs.File, data []byte) error {
 	return nil
 }
 
+func readRange(f vfs.File) ([]byte, []byte, error) {
+	start, err := readBytes(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(start) == 0 {
+		return nil, nil, fmt.Errorf("error format, missing start field")
+	}
+
+	end, err := readBytes(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(end) == 0 {
+		return nil, nil, fmt.Errorf("error format, missing end field")
+	}
+	return start, end, nil
+}
+
 func readBytes(f vfs.File) ([]byte, error) {
 	size := make([]byte, 4)
 	n, err := f.Read(size)
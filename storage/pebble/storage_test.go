@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/matrixorigin/matrixcube/util"
@@ -49,3 +50,143 @@ func recreateTestTempDir(tmpDir string) {
 	os.RemoveAll(tmpDir)
 	os.MkdirAll(tmpDir, 0755)
 }
+
+func TestSweepExpired(t *testing.T) {
+	opts := pebble.Options{FS: vfs.NewPebbleFS(vfs.Default)}
+	path := filepath.Join(util.GetTestDir(), "storage/pebble-ttl")
+	recreateTestTempDir(path)
+
+	s, err := NewStorage(path, &opts)
+	assert.NoError(t, err)
+	assert.Error(t, s.SetWithTTL([]byte("k"), []byte("v"), 1))
+	s.Close()
+
+	recreateTestTempDir(path)
+	s, err = NewStorage(path, &opts, WithTTL(time.Hour))
+	assert.NoError(t, err)
+	defer s.Close()
+
+	assert.NoError(t, s.SetWithTTL([]byte("k1"), []byte("v1"), 1))
+	assert.NoError(t, s.Set([]byte("k2"), []byte("v2")))
+	time.Sleep(time.Second * 2)
+
+	v, err := s.Get([]byte("k1"))
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+
+	n, err := s.sweep()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	_, _, err = s.db.Get([]byte("k1"))
+	assert.Equal(t, pebble.ErrNotFound, err)
+	v, err = s.Get([]byte("k2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v2"), v)
+}
+
+func TestTTLWithStartAndSweepRange(t *testing.T) {
+	opts := pebble.Options{FS: vfs.NewPebbleFS(vfs.Default)}
+	path := filepath.Join(util.GetTestDir(), "storage/pebble-ttl-range")
+	recreateTestTempDir(path)
+
+	s, err := NewStorage(path, &opts, WithTTL(time.Hour), WithTTLSweepRange([]byte("z"), []byte("{")))
+	assert.NoError(t, err)
+	defer s.Close()
+
+	// the values written with a start long ago are expired at once
+	wb := util.NewWriteBatch()
+	wb.SetWithTTL([]byte("z1"), []byte("v1"), 10)
+	wb.SetWithTTL([]byte("a1"), []byte("v1"), 10)
+	wb.TTLStart = time.Now().Unix() - 100
+	assert.NoError(t, s.Write(wb, false))
+
+	v, err := s.Get([]byte("z1"))
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+
+	// the keys out of the sweep range are never removed by the sweeper
+	n, err := s.sweep()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, _, err = s.db.Get([]byte("z1"))
+	assert.Equal(t, pebble.ErrNotFound, err)
+	_, c, err := s.db.Get([]byte("a1"))
+	assert.NoError(t, err)
+	c.Close()
+
+}
+
+func TestEnableTTLOnExistingData(t *testing.T) {
+	opts := pebble.Options{FS: vfs.NewPebbleFS(vfs.Default)}
+	path := filepath.Join(util.GetTestDir(), "storage/pebble-ttl-existing")
+	recreateTestTempDir(path)
+
+	s, err := NewStorage(path, &opts)
+	assert.NoError(t, err)
+	// the value longer than the ttl header is not read as the expiration time
+	old := []byte("value-written-without-the-ttl")
+	assert.NoError(t, s.Set([]byte("z1"), old))
+	assert.NoError(t, s.Set([]byte("z2"), []byte("v")))
+	hash, err := s.HashSnapshot([]byte("z"), []byte("{"), time.Now().Unix())
+	assert.NoError(t, err)
+	expected, err := hash()
+	assert.NoError(t, err)
+	s.Close()
+
+	s, err = NewStorage(path, &opts, WithTTL(time.Hour))
+	assert.NoError(t, err)
+	defer s.Close()
+
+	// the values written before the TTL enabled never expire
+	v, err := s.Get([]byte("z1"))
+	assert.NoError(t, err)
+	assert.Equal(t, old, v)
+	v, err = s.Get([]byte("z2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v"), v)
+	n, err := s.sweep()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// the hash is the same with or without the TTL support
+	hash, err = s.HashSnapshot([]byte("z"), []byte("{"), time.Now().Unix())
+	assert.NoError(t, err)
+	actual, err := hash()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	expireAt, value := parseValue(s.encodeValue([]byte("v1"), 10, 100))
+	assert.Equal(t, int64(110), expireAt)
+	assert.Equal(t, []byte("v1"), value)
+}
+
+func TestApplyLegacySnapshot(t *testing.T) {
+	opts := pebble.Options{FS: vfs.NewPebbleFS(vfs.Default)}
+	path := filepath.Join(util.GetTestDir(), "storage/pebble-legacy")
+	recreateTestTempDir(path)
+	snapPath := filepath.Join(util.GetTestDir(), "storage/pebble-legacy-snap")
+	recreateTestTempDir(snapPath)
+
+	f, err := vfs.Default.Create(filepath.Join(snapPath, snapshotDataFile))
+	assert.NoError(t, err)
+	for _, data := range []string{"k1", "k3", "k1", "v1", "k2", "v2"} {
+		assert.NoError(t, writeBytes(f, []byte(data)))
+	}
+	assert.NoError(t, f.Close())
+
+	s, err := NewStorage(path, &opts)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	assert.NoError(t, s.Set([]byte("k1"), []byte("old")))
+	assert.NoError(t, s.Set([]byte("k21"), []byte("v21")))
+	assert.NoError(t, s.Set([]byte("k3"), []byte("v3")))
+	assert.NoError(t, s.ApplySnapshot(snapPath))
+
+	for key, value := range map[string]string{"k1": "v1", "k2": "v2", "k21": "", "k3": "v3"} {
+		v, err := s.Get([]byte(key))
+		assert.NoError(t, err)
+		assert.Equal(t, value, string(v))
+	}
+}
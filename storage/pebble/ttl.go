@@ -0,0 +1,246 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/fagongzi/goetty/buf"
+)
+
+const (
+	defaultSweepInterval = time.Minute
+	sweepBatchSize       = 1024
+	expireAtSize         = 8
+
+	// ttlFormatV1 the version of the ttl header: the magic, the version and the expiration time
+	ttlFormatV1   = 1
+	ttlHeaderSize = 4 + expireAtSize
+)
+
+var (
+	errTTLNotEnabled = errors.New("pebble storage not support set key-value with TTL, use WithTTL to enable it")
+
+	// ttlMagic marks the values written with the TTL support, the values without the ttl header
+	// are written before the TTL enabled.
+	ttlMagic = []byte{0xc7, 0x54, 0x4c}
+)
+
+// Option pebble storage option
+type Option func(*options)
+
+type options struct {
+	ttl           bool
+	sweepInterval time.Duration
+	sweepStart    []byte
+	sweepEnd      []byte
+}
+
+// WithTTL enable the TTL support. All values are stored with the expiration time, the expired
+// key-value pairs are invisible to reads and removed by a background sweeper every sweepInterval,
+// 0 means use the default interval of 1 minute. The values are stored with a versioned header, so
+// the TTL can be enabled on an existing storage, the values written before never expire. The data
+// written with the TTL support can only be read with the TTL support.
+func WithTTL(sweepInterval time.Duration) Option {
+	return func(opts *options) {
+		opts.ttl = true
+		opts.sweepInterval = sweepInterval
+	}
+}
+
+// WithTTLSweepRange sets the key range [start, end) scanned by the TTL sweeper, the keys out of
+// the range are never written with a TTL, e.g. raftstore.GetDataKeyRange for a data storage. A nil
+// start or end means the range is unbounded at that side.
+func WithTTLSweepRange(start, end []byte) Option {
+	return func(opts *options) {
+		opts.sweepStart = start
+		opts.sweepEnd = end
+	}
+}
+
+func (opts *options) adjust() {
+	if opts.ttl && opts.sweepInterval <= 0 {
+		opts.sweepInterval = defaultSweepInterval
+	}
+}
+
+// encodeValue returns the value to store, the ttl header is encoded before the value if the
+// TTL is enabled. The ttl is counted from start, 0 means the current time.
+func (s *Storage) encodeValue(value []byte, ttl int32, start int64) []byte {
+	if !s.opts.ttl {
+		return value
+	}
+
+	expireAt := int64(0)
+	if ttl > 0 {
+		if start == 0 {
+			start = time.Now().Unix()
+		}
+		expireAt = start + int64(ttl)
+	}
+
+	data := make([]byte, len(value)+ttlHeaderSize)
+	copy(data, ttlMagic)
+	data[len(ttlMagic)] = ttlFormatV1
+	buf.Int64ToBytesTo(expireAt, data[len(ttlMagic)+1:])
+	copy(data[ttlHeaderSize:], value)
+	return data
+}
+
+// decodeValue returns the value and false if the value is expired.
+func (s *Storage) decodeValue(value []byte) ([]byte, bool) {
+	if !s.opts.ttl {
+		return value, true
+	}
+
+	expireAt, value := parseValue(value)
+	if isExpiredAt(expireAt, time.Now().Unix()) {
+		return nil, false
+	}
+	return value, true
+}
+
+// parseValue returns the expiration time and the value stored with the ttl header, the value
+// without the header is written before the TTL enabled, it never expires.
+func parseValue(value []byte) (int64, []byte) {
+	if len(value) < ttlHeaderSize ||
+		!bytes.HasPrefix(value, ttlMagic) ||
+		value[len(ttlMagic)] != ttlFormatV1 {
+		return 0, value
+	}
+
+	return buf.Byte2Int64(value[len(ttlMagic)+1:]), value[ttlHeaderSize:]
+}
+
+func isExpired(value []byte, now int64) bool {
+	expireAt, _ := parseValue(value)
+	return isExpiredAt(expireAt, now)
+}
+
+func isExpiredAt(expireAt int64, now int64) bool {
+	return expireAt != 0 && expireAt < now
+}
+
+func (s *Storage) startSweeper() {
+	s.stopper = make(chan struct{})
+	s.stopWG.Add(1)
+	go func() {
+		defer s.stopWG.Done()
+
+		ticker := time.NewTicker(s.opts.sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopper:
+				return
+			case <-ticker.C:
+				n, err := s.sweep()
+				if err != nil {
+					logger.Errorf("sweep expired key-value pairs failed with %+v", err)
+					continue
+				}
+				if n > 0 {
+					logger.Infof("%d expired key-value pairs removed", n)
+				}
+			}
+		}
+	}()
+}
+
+func (s *Storage) stopSweeper() {
+	if s.stopper != nil {
+		close(s.stopper)
+		s.stopWG.Wait()
+	}
+}
+
+// sweep removes the expired key-value pairs physically, and returns the number of the removed
+// key-value pairs.
+func (s *Storage) sweep() (int, error) {
+	now := time.Now().Unix()
+	removed := 0
+	var keys [][]byte
+
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: s.opts.sweepStart,
+		UpperBound: s.opts.sweepEnd,
+	})
+	defer iter.Close()
+
+	iter.First()
+	for iter.Valid() {
+		if err := iter.Error(); err != nil {
+			return removed, err
+		}
+
+		if isExpired(iter.Value(), now) {
+			keys = append(keys, clone(iter.Key()))
+			if len(keys) >= sweepBatchSize {
+				n, err := s.removeExpired(keys, now)
+				removed += n
+				if err != nil {
+					return removed, err
+				}
+				keys = keys[:0]
+			}
+		}
+		iter.Next()
+	}
+
+	n, err := s.removeExpired(keys, now)
+	return removed + n, err
+}
+
+// removeExpired removes the keys which are still expired. The keys are checked again under the
+// lock, to avoid removing a key which is set again after the sweeper found it expired.
+func (s *Storage) removeExpired(keys [][]byte, now int64) (int, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.db.NewBatch()
+	defer b.Close()
+
+	n := 0
+	for _, key := range keys {
+		value, closer, err := s.db.Get(key)
+		if err == pebble.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		expired := isExpired(value, now)
+		closer.Close()
+		if expired {
+			if err := b.Delete(key, nil); err != nil {
+				return 0, err
+			}
+			n++
+			atomic.AddUint64(&s.stats.WrittenKeys, 1)
+			atomic.AddUint64(&s.stats.WrittenBytes, uint64(len(key)))
+		}
+	}
+
+	return n, s.db.Apply(b, pebble.NoSync)
+}
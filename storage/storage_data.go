@@ -34,3 +34,34 @@ type DataStorage interface {
 	// ApplySnapshot apply a snapshort file from giving path
 	ApplySnapshot(path string) error
 }
+
+// HashableStorage is an optional interface of the DataStorage. If the DataStorage implements it,
+// raftstore will periodically check the consistency of the data between the replicas of a shard.
+type HashableStorage interface {
+	// HashSnapshot takes a consistent view of the key-value pairs in [start, end) and returns a function
+	// computing the hash of the view, the function is called once out of the apply path and releases the
+	// view. The replicas of a shard with the same data must return the same hash, so each key-value pair
+	// is hashed as the key and the value with its expiration time encoded before, and the key-value pairs
+	// expired at the unix time at are excluded.
+	HashSnapshot(start []byte, end []byte, at int64) (func() ([]byte, error), error)
+}
+
+// SnapshotableStorage is an optional interface of the DataStorage. If the DataStorage implements it,
+// the backups of the shards are created out of the apply path.
+type SnapshotableStorage interface {
+	// SnapshotView takes a consistent view of the key-value pairs in [start, end) and returns a function
+	// creating the snapshot of the view under the giving path as CreateSnapshot, the function is called
+	// once out of the apply path and releases the view.
+	SnapshotView(start []byte, end []byte) (func(path string) error, error)
+}
+
+// IngestableStorage is an optional interface of the DataStorage. If the DataStorage implements it,
+// the pre-built key-value pairs can be bulk loaded into the shards without writing them through raft.
+type IngestableStorage interface {
+	// CreateIngestFile creates a file under the giving path with the key-value pairs returned by
+	// next until it returns false. The keys must be returned in ascending order.
+	CreateIngestFile(path string, next func() ([]byte, []byte, bool)) error
+	// IngestFile ingests the file created by CreateIngestFile atomically, the existing values of
+	// the same keys are overwritten.
+	IngestFile(path string) error
+}
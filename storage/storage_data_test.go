@@ -30,8 +30,9 @@ import (
 
 var (
 	dataDactories = map[string]func(vfs.FS, *testing.T) DataStorage{
-		"memory": createDataMem,
-		"pebble": createDataPebble,
+		"memory":     createDataMem,
+		"pebble":     createDataPebble,
+		"pebble-ttl": createDataPebbleWithTTL,
 	}
 )
 
@@ -49,6 +50,16 @@ func createDataPebble(fs vfs.FS, t *testing.T) DataStorage {
 	return s
 }
 
+func createDataPebbleWithTTL(fs vfs.FS, t *testing.T) DataStorage {
+	path := filepath.Join(util.GetTestDir(), "pebble", fmt.Sprintf("%d", time.Now().UnixNano()))
+	fs.RemoveAll(path)
+	fs.MkdirAll(path, 0755)
+	opts := &cpebble.Options{FS: vfs.NewPebbleFS(fs)}
+	s, err := pebble.NewStorage(path, opts, pebble.WithTTL(0))
+	assert.NoError(t, err, "createDataPebbleWithTTL failed")
+	return s
+}
+
 func TestRangeDelete(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	fs := vfs.GetTestFS()
@@ -178,6 +189,72 @@ func TestSplitCheck(t *testing.T) {
 	}
 }
 
+func TestHashSnapshot(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	computeHash := func(hs HashableStorage, at int64) []byte {
+		compute, err := hs.HashSnapshot([]byte("k1"), []byte("k3"), at)
+		assert.NoError(t, err)
+		hash, err := compute()
+		assert.NoError(t, err)
+		return hash
+	}
+
+	now := time.Now().Unix()
+	var hashes, ttlHashes [][]byte
+	for name, factory := range dataDactories {
+		t.Run(name, func(t *testing.T) {
+			s := factory(fs, t)
+			defer s.Close()
+			kv := s.(KVStorage)
+			hs := s.(HashableStorage)
+
+			assert.NoError(t, kv.Set([]byte("k1"), []byte("v1")))
+			assert.NoError(t, kv.Set([]byte("k2"), []byte("v2")))
+			assert.NoError(t, kv.Set([]byte("k3"), []byte("v3")))
+
+			hash := computeHash(hs, now)
+			hashes = append(hashes, hash)
+
+			// keys out of range
+			assert.NoError(t, kv.Set([]byte("k4"), []byte("v4")))
+			assert.Equal(t, hash, computeHash(hs, now))
+
+			// the hash is computed on the data when the snapshot taken
+			compute, err := hs.HashSnapshot([]byte("k1"), []byte("k3"), now)
+			assert.NoError(t, err)
+			assert.NoError(t, kv.Set([]byte("k2"), []byte("v22")))
+			value, err := compute()
+			assert.NoError(t, err)
+			assert.Equal(t, hash, value)
+			assert.NotEqual(t, hash, computeHash(hs, now))
+			assert.NoError(t, kv.Set([]byte("k2"), []byte("v2")))
+
+			// keys with TTL are hashed with the expiration time until expired
+			if name != "pebble" {
+				wb := util.NewWriteBatch()
+				wb.SetWithTTL([]byte("k11"), []byte("v11"), 10)
+				wb.TTLStart = now
+				assert.NoError(t, kv.Write(wb, false))
+				value = computeHash(hs, now)
+				assert.NotEqual(t, hash, value)
+				ttlHashes = append(ttlHashes, value)
+				assert.Equal(t, hash, computeHash(hs, now+11))
+			}
+		})
+	}
+
+	// all the storages must compute the same hash with the same data
+	for _, hash := range hashes {
+		assert.Equal(t, hashes[0], hash)
+	}
+	for _, hash := range ttlHashes {
+		assert.Equal(t, ttlHashes[0], hash)
+	}
+}
+
 func TestCreateAndApply(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	fs := vfs.GetTestFS()
@@ -229,3 +306,42 @@ func TestCreateAndApply(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAndIngest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	for name, factory := range dataDactories {
+		t.Run(name, func(t *testing.T) {
+			s1 := factory(fs, t)
+			defer s1.Close()
+			s2 := factory(fs, t)
+			defer s2.Close()
+			kv2 := s2.(KVStorage)
+			path := fmt.Sprintf("%s-ingest", name)
+			path = filepath.Join(util.GetTestDir(), path)
+			fs.RemoveAll(path)
+
+			assert.NoError(t, kv2.Set([]byte("key1"), []byte("old1")))
+			assert.NoError(t, kv2.Set([]byte("key4"), []byte("value4")))
+
+			pairs := [][]byte{[]byte("key1"), []byte("value1"), []byte("key2"), []byte("value2")}
+			next := func() ([]byte, []byte, bool) {
+				if len(pairs) == 0 {
+					return nil, nil, false
+				}
+				key, value := pairs[0], pairs[1]
+				pairs = pairs[2:]
+				return key, value, true
+			}
+			assert.NoError(t, s1.(IngestableStorage).CreateIngestFile(path, next))
+			assert.NoError(t, s2.(IngestableStorage).IngestFile(path))
+
+			for key, expect := range map[string]string{"key1": "value1", "key2": "value2", "key4": "value4"} {
+				value, err := kv2.Get([]byte(key))
+				assert.NoError(t, err)
+				assert.Equal(t, expect, string(value))
+			}
+		})
+	}
+}
@@ -30,8 +30,9 @@ import (
 
 var (
 	factories = map[string]func(vfs.FS, *testing.T) MetadataStorage{
-		"memory": createMem,
-		"pebble": createPebble,
+		"memory":     createMem,
+		"pebble":     createPebble,
+		"pebble-ttl": createPebbleWithTTL,
 	}
 )
 
@@ -49,6 +50,16 @@ func createPebble(fs vfs.FS, t *testing.T) MetadataStorage {
 	return s
 }
 
+func createPebbleWithTTL(fs vfs.FS, t *testing.T) MetadataStorage {
+	path := filepath.Join(util.GetTestDir(), "pebble", fmt.Sprintf("%d", time.Now().UnixNano()))
+	fs.RemoveAll(path)
+	fs.MkdirAll(path, 0755)
+	opts := &cpebble.Options{FS: vfs.NewPebbleFS(fs)}
+	s, err := pebble.NewStorage(path, opts, pebble.WithTTL(0))
+	assert.NoError(t, err, "createPebbleWithTTL failed")
+	return s
+}
+
 func TestWriteBatch(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	fs := vfs.GetTestFS()
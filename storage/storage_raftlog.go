@@ -0,0 +1,42 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// RaftLogStorage the storage to save the raft log entries of all shards. Entries are
+// read with the etcd raft errors, `raft.ErrCompacted` if the entries were compacted and
+// `raft.ErrUnavailable` if the entries are missing.
+type RaftLogStorage interface {
+	CloseableStorage
+
+	// Append appends the entries of the shard, the entries of the shard after the
+	// first appended entry are replaced. The entries are durable after `Sync`.
+	Append(shardID uint64, entries []raftpb.Entry) error
+	// Sync makes all appended entries durable
+	Sync() error
+	// Entries returns the entries of the shard in [low, high), the total size of the
+	// returned entries is limited by maxSize, but at least one entry is returned.
+	Entries(shardID uint64, low, high, maxSize uint64) ([]raftpb.Entry, error)
+	// Term returns the term of the entry of the shard at the index
+	Term(shardID uint64, index uint64) (uint64, error)
+	// LastIndex returns the index of the last entry of the shard, 0 if there is no entry
+	LastIndex(shardID uint64) (uint64, error)
+	// Compact removes the entries of the shard before the index
+	Compact(shardID uint64, index uint64) error
+	// RemoveShard removes all entries of the shard
+	RemoveShard(shardID uint64) error
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+
+	"github.com/matrixorigin/matrixcube/vfs"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// record layout: crc32(4) | payload size(4) | payload
+// payload layout: type(1) | shard id(8) | index(8) | term(8) | entry data
+const (
+	recordEntry   byte = 1
+	recordCompact byte = 2
+	recordRemove  byte = 3
+
+	recordHeaderSize  = 8
+	payloadHeaderSize = 25
+)
+
+var (
+	errCorruptedRecord = errors.New("corrupted wal record")
+)
+
+func appendRecord(buf []byte, rt byte, shardID, index, term uint64, data []byte) []byte {
+	var header [recordHeaderSize + payloadHeaderSize]byte
+	payload := header[recordHeaderSize:]
+	payload[0] = rt
+	binary.BigEndian.PutUint64(payload[1:], shardID)
+	binary.BigEndian.PutUint64(payload[9:], index)
+	binary.BigEndian.PutUint64(payload[17:], term)
+
+	crc := crc32.Update(crc32.ChecksumIEEE(payload), crc32.IEEETable, data)
+	binary.BigEndian.PutUint32(header[0:], crc)
+	binary.BigEndian.PutUint32(header[4:], uint32(payloadHeaderSize+len(data)))
+
+	buf = append(buf, header[:]...)
+	return append(buf, data...)
+}
+
+func appendEntryRecord(buf []byte, shardID uint64, e *raftpb.Entry) []byte {
+	data, err := e.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return appendRecord(buf, recordEntry, shardID, e.Index, e.Term, data)
+}
+
+func appendControlRecord(buf []byte, rt byte, shardID, index uint64) []byte {
+	return appendRecord(buf, rt, shardID, index, 0, nil)
+}
+
+// decodeEntryRecord decodes the payload of an entry record, returns the shard id and
+// the entry data
+func decodeEntryRecord(payload []byte) (uint64, []byte, error) {
+	if len(payload) < payloadHeaderSize || payload[0] != recordEntry {
+		return 0, nil, errCorruptedRecord
+	}
+	return binary.BigEndian.Uint64(payload[1:]), payload[payloadHeaderSize:], nil
+}
+
+type segment struct {
+	id     uint64
+	path   string
+	size   int64
+	writer vfs.File
+	reader vfs.File
+	// shards shard id -> the max index of the entries of the shard in the segment
+	shards map[uint64]uint64
+}
+
+func createSegment(fs vfs.FS, path string, id uint64) (*segment, error) {
+	writer, err := fs.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := fs.Open(path)
+	if err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	return &segment{
+		id:     id,
+		path:   path,
+		writer: writer,
+		reader: reader,
+		shards: make(map[uint64]uint64),
+	}, nil
+}
+
+func openSegment(fs vfs.FS, path string, id uint64) (*segment, error) {
+	reader, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &segment{
+		id:     id,
+		path:   path,
+		reader: reader,
+		shards: make(map[uint64]uint64),
+	}, nil
+}
+
+func (seg *segment) write(buf []byte) (int64, error) {
+	if seg.writer == nil {
+		return 0, fmt.Errorf("segment %d is sealed", seg.id)
+	}
+
+	offset := seg.size
+	n, err := seg.writer.Write(buf)
+	seg.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+func (seg *segment) sync() error {
+	if seg.writer == nil {
+		return nil
+	}
+	return seg.writer.Sync()
+}
+
+func (seg *segment) addEntry(shardID, index uint64) {
+	if max, ok := seg.shards[shardID]; !ok || index > max {
+		seg.shards[shardID] = index
+	}
+}
+
+// replay reads all records of the segment, the records after the torn tail written by
+// a crash are ignored.
+func (seg *segment) replay(fn func(seg *segment, rt byte, shardID uint64, index uint64, offset int64, size int, term uint64)) error {
+	data, err := ioutil.ReadAll(io.NewSectionReader(seg.reader, 0, 1<<62))
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	for offset < len(data) {
+		if len(data)-offset < recordHeaderSize {
+			break
+		}
+
+		crc := binary.BigEndian.Uint32(data[offset:])
+		size := int(binary.BigEndian.Uint32(data[offset+4:]))
+		start := offset + recordHeaderSize
+		if size < payloadHeaderSize || start+size > len(data) {
+			break
+		}
+
+		payload := data[start : start+size]
+		if crc32.ChecksumIEEE(payload) != crc {
+			break
+		}
+
+		fn(seg, payload[0],
+			binary.BigEndian.Uint64(payload[1:]),
+			binary.BigEndian.Uint64(payload[9:]),
+			int64(start),
+			size,
+			binary.BigEndian.Uint64(payload[17:]))
+		offset = start + size
+	}
+
+	if offset < len(data) {
+		logger.Warningf("segment %d ignore the torn tail at offset %d, size %d",
+			seg.id,
+			offset,
+			len(data))
+	}
+
+	seg.size = int64(offset)
+	return nil
+}
+
+func (seg *segment) close() {
+	if seg.writer != nil {
+		seg.writer.Close()
+		seg.writer = nil
+	}
+	if seg.reader != nil {
+		seg.reader.Close()
+		seg.reader = nil
+	}
+}
@@ -0,0 +1,546 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fagongzi/log"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+var (
+	logger = log.NewLoggerWithPrefix("[wal]")
+)
+
+const (
+	segmentSuffix = ".wal"
+
+	defaultSegmentSize = 64 * 1024 * 1024
+)
+
+// Option wal storage option
+type Option func(*options)
+
+type options struct {
+	segmentSize int64
+}
+
+// WithSegmentSize set the size of the segment file, a new segment file will be created
+// after the size of the active segment file exceeds it.
+func WithSegmentSize(value int64) Option {
+	return func(opts *options) {
+		opts.segmentSize = value
+	}
+}
+
+// position the position of an entry in the segment files
+type position struct {
+	segment *segment
+	offset  int64
+	size    int
+	term    uint64
+}
+
+// shardLog the entries of a shard, entries[i] is the entry at first+i
+type shardLog struct {
+	first     uint64
+	positions []position
+	removed   bool
+}
+
+func (l *shardLog) lastIndex() uint64 {
+	if l.first == 0 {
+		return 0
+	}
+	return l.first + uint64(len(l.positions)) - 1
+}
+
+func (l *shardLog) append(index uint64, pos position) {
+	if index < l.first || index > l.first+uint64(len(l.positions)) || l.removed {
+		l.first = index
+		l.positions = l.positions[:0]
+		l.removed = false
+	} else {
+		l.positions = l.positions[:index-l.first]
+	}
+	l.positions = append(l.positions, pos)
+}
+
+func (l *shardLog) compact(index uint64) {
+	if index <= l.first {
+		return
+	}
+
+	n := index - l.first
+	if n >= uint64(len(l.positions)) {
+		l.positions = l.positions[:0]
+	} else {
+		l.positions = append(l.positions[:0], l.positions[n:]...)
+	}
+	l.first = index
+}
+
+func (l *shardLog) remove() {
+	l.first = 0
+	l.positions = nil
+	l.removed = true
+}
+
+// Storage is an append-only raft log storage based on segment files, the entries of all
+// shards are written into the active segment file and synced together. The segment file
+// is removed after all its entries were compacted.
+type Storage struct {
+	sync.RWMutex
+
+	fs       vfs.FS
+	dir      string
+	opts     options
+	segments []*segment
+	active   *segment
+	shards   map[uint64]*shardLog
+
+	// syncMu serializes the sync of the active segment file, the appends synced by others
+	// are skipped, so that the entries of all shards are synced in batch.
+	syncMu     sync.Mutex
+	writtenSeq uint64
+	syncedSeq  uint64
+}
+
+var _ storage.RaftLogStorage = (*Storage)(nil)
+
+// NewStorage returns a raft log storage which saves the segment files in the dir
+func NewStorage(fs vfs.FS, dir string, opts ...Option) (*Storage, error) {
+	s := &Storage{
+		fs:     fs,
+		dir:    dir,
+		shards: make(map[uint64]*shardLog),
+	}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+	if s.opts.segmentSize <= 0 {
+		s.opts.segmentSize = defaultSegmentSize
+	}
+
+	if err := fs.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := s.replay(); err != nil {
+		s.closeSegments()
+		return nil, err
+	}
+
+	if err := s.roll(); err != nil {
+		s.closeSegments()
+		return nil, err
+	}
+
+	if err := s.gc(); err != nil {
+		s.closeSegments()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Storage) Append(shardID uint64, entries []raftpb.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var buf []byte
+	offsets := make([]int, 0, len(entries))
+	for idx := range entries {
+		offsets = append(offsets, len(buf))
+		buf = appendEntryRecord(buf, shardID, &entries[idx])
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	offset, err := s.active.write(buf)
+	if err != nil {
+		return err
+	}
+
+	l := s.getShardLog(shardID)
+	for idx, e := range entries {
+		size := len(buf) - offsets[idx]
+		if idx+1 < len(offsets) {
+			size = offsets[idx+1] - offsets[idx]
+		}
+
+		s.active.addEntry(shardID, e.Index)
+		l.append(e.Index, position{
+			segment: s.active,
+			offset:  offset + int64(offsets[idx]) + recordHeaderSize,
+			size:    size - recordHeaderSize,
+			term:    e.Term,
+		})
+	}
+	atomic.AddUint64(&s.writtenSeq, 1)
+
+	if s.active.size >= s.opts.segmentSize {
+		return s.roll()
+	}
+	return nil
+}
+
+func (s *Storage) Sync() error {
+	seq := atomic.LoadUint64(&s.writtenSeq)
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	if atomic.LoadUint64(&s.syncedSeq) >= seq {
+		return nil
+	}
+
+	s.RLock()
+	active := s.active
+	seq = atomic.LoadUint64(&s.writtenSeq)
+	s.RUnlock()
+
+	if err := active.sync(); err != nil {
+		return err
+	}
+
+	atomic.StoreUint64(&s.syncedSeq, seq)
+	return nil
+}
+
+func (s *Storage) Entries(shardID uint64, low, high, maxSize uint64) ([]raftpb.Entry, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	l, ok := s.shards[shardID]
+	if !ok || l.first == 0 {
+		return nil, raft.ErrUnavailable
+	}
+	if low < l.first {
+		return nil, raft.ErrCompacted
+	}
+	if high > l.lastIndex()+1 {
+		return nil, raft.ErrUnavailable
+	}
+
+	var ents []raftpb.Entry
+	var totalSize uint64
+	for index := low; index < high; index++ {
+		pos := l.positions[index-l.first]
+		totalSize += uint64(pos.size)
+		if totalSize > maxSize && len(ents) > 0 {
+			break
+		}
+
+		e, err := s.readEntry(shardID, index, pos)
+		if err != nil {
+			return nil, err
+		}
+		ents = append(ents, e)
+	}
+
+	return ents, nil
+}
+
+func (s *Storage) Term(shardID uint64, index uint64) (uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	l, ok := s.shards[shardID]
+	if !ok || l.first == 0 {
+		return 0, raft.ErrUnavailable
+	}
+	if index < l.first {
+		return 0, raft.ErrCompacted
+	}
+	if index > l.lastIndex() {
+		return 0, raft.ErrUnavailable
+	}
+
+	return l.positions[index-l.first].term, nil
+}
+
+func (s *Storage) LastIndex(shardID uint64) (uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if l, ok := s.shards[shardID]; ok {
+		return l.lastIndex(), nil
+	}
+	return 0, nil
+}
+
+func (s *Storage) Compact(shardID uint64, index uint64) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	s.Lock()
+	defer s.Unlock()
+
+	l, ok := s.shards[shardID]
+	if !ok || l.removed || index <= l.first {
+		return nil
+	}
+
+	_, err := s.active.write(appendControlRecord(nil, recordCompact, shardID, index))
+	if err != nil {
+		return err
+	}
+
+	l.compact(index)
+	return s.gc()
+}
+
+func (s *Storage) RemoveShard(shardID uint64) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	s.Lock()
+	defer s.Unlock()
+
+	l, ok := s.shards[shardID]
+	if !ok || l.removed {
+		return nil
+	}
+
+	_, err := s.active.write(appendControlRecord(nil, recordRemove, shardID, 0))
+	if err != nil {
+		return err
+	}
+
+	l.remove()
+	return s.gc()
+}
+
+func (s *Storage) Close() error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	s.Lock()
+	defer s.Unlock()
+
+	var err error
+	if s.active != nil {
+		err = s.active.sync()
+	}
+	s.closeSegments()
+	return err
+}
+
+func (s *Storage) getShardLog(shardID uint64) *shardLog {
+	l, ok := s.shards[shardID]
+	if !ok {
+		l = &shardLog{}
+		s.shards[shardID] = l
+	}
+	return l
+}
+
+func (s *Storage) readEntry(shardID, index uint64, pos position) (raftpb.Entry, error) {
+	e := raftpb.Entry{}
+	data := make([]byte, pos.size)
+	if _, err := pos.segment.reader.ReadAt(data, pos.offset); err != nil {
+		return e, err
+	}
+
+	id, value, err := decodeEntryRecord(data)
+	if err != nil {
+		return e, err
+	}
+	if id != shardID {
+		return e, fmt.Errorf("shard %d entry %d not match, got shard %d", shardID, index, id)
+	}
+
+	if err := e.Unmarshal(value); err != nil {
+		return e, err
+	}
+	if e.Index != index {
+		return e, fmt.Errorf("shard %d entry index not match, got %d expect %d", shardID, e.Index, index)
+	}
+	return e, nil
+}
+
+// roll seals the active segment and creates a new one. The compact and remove state
+// of all shards is written at the beginning of the new segment, so that the older
+// segments can be removed without losing the state.
+func (s *Storage) roll() error {
+	var id uint64 = 1
+	if s.active != nil {
+		if err := s.active.sync(); err != nil {
+			return err
+		}
+		id = s.active.id + 1
+	}
+
+	seg, err := createSegment(s.fs, s.segmentPath(id), id)
+	if err != nil {
+		return err
+	}
+
+	var buf []byte
+	for shardID, l := range s.shards {
+		if l.removed {
+			buf = appendControlRecord(buf, recordRemove, shardID, 0)
+		} else if l.first > 0 {
+			buf = appendControlRecord(buf, recordCompact, shardID, l.first)
+		}
+	}
+	if len(buf) > 0 {
+		if _, err := seg.write(buf); err != nil {
+			seg.close()
+			return err
+		}
+	}
+
+	if err := s.syncDir(); err != nil {
+		seg.close()
+		return err
+	}
+
+	s.segments = append(s.segments, seg)
+	s.active = seg
+	return nil
+}
+
+// gc removes the sealed segments whose entries were all compacted, the caller must hold
+// both the syncMu and the lock, because the sealed segment may be syncing.
+func (s *Storage) gc() error {
+	segments := s.segments[:0]
+	var removed []*segment
+	for _, seg := range s.segments {
+		if seg != s.active && s.isCompacted(seg) {
+			removed = append(removed, seg)
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	s.segments = segments
+
+	for _, seg := range removed {
+		seg.close()
+		if err := s.fs.Remove(seg.path); err != nil {
+			return err
+		}
+		logger.Debugf("segment %d removed", seg.id)
+	}
+
+	// forget the removed shards which have no entry in any segment
+	for shardID, l := range s.shards {
+		if l.removed && !s.referenced(shardID) {
+			delete(s.shards, shardID)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) isCompacted(seg *segment) bool {
+	for shardID, maxIndex := range seg.shards {
+		l, ok := s.shards[shardID]
+		if ok && !l.removed && l.first <= maxIndex {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Storage) referenced(shardID uint64) bool {
+	for _, seg := range s.segments {
+		if _, ok := seg.shards[shardID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) replay() error {
+	names, err := s.fs.List(s.dir)
+	if err != nil {
+		return err
+	}
+
+	var ids []uint64
+	for _, name := range names {
+		if !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		seg, err := openSegment(s.fs, s.segmentPath(id), id)
+		if err != nil {
+			return err
+		}
+		s.segments = append(s.segments, seg)
+
+		err = seg.replay(s.replayRecord)
+		if err != nil {
+			return err
+		}
+		s.active = seg
+	}
+
+	return nil
+}
+
+func (s *Storage) replayRecord(seg *segment, rt byte, shardID uint64, index uint64, offset int64, size int, term uint64) {
+	switch rt {
+	case recordEntry:
+		seg.addEntry(shardID, index)
+		s.getShardLog(shardID).append(index, position{
+			segment: seg,
+			offset:  offset,
+			size:    size,
+			term:    term,
+		})
+	case recordCompact:
+		s.getShardLog(shardID).compact(index)
+	case recordRemove:
+		s.getShardLog(shardID).remove()
+	}
+}
+
+func (s *Storage) segmentPath(id uint64) string {
+	return s.fs.PathJoin(s.dir, fmt.Sprintf("%020d%s", id, segmentSuffix))
+}
+
+func (s *Storage) syncDir() error {
+	dir, err := s.fs.OpenDir(s.dir)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+func (s *Storage) closeSegments() {
+	for _, seg := range s.segments {
+		seg.close()
+	}
+	s.segments = nil
+	s.active = nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func createTestStorage(t *testing.T, fs vfs.FS, dir string, opts ...Option) *Storage {
+	s, err := NewStorage(fs, dir, opts...)
+	assert.NoError(t, err)
+	return s
+}
+
+func newTestDir(fs vfs.FS) string {
+	dir := filepath.Join(util.GetTestDir(), "wal", fmt.Sprintf("%d", time.Now().UnixNano()))
+	fs.RemoveAll(dir)
+	return dir
+}
+
+func newTestEntries(low, high, term uint64) []raftpb.Entry {
+	var ents []raftpb.Entry
+	for i := low; i < high; i++ {
+		ents = append(ents, raftpb.Entry{Index: i, Term: term, Data: []byte(fmt.Sprintf("data-%d", i))})
+	}
+	return ents
+}
+
+func TestAppendAndEntries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := newTestDir(fs)
+	defer fs.RemoveAll(dir)
+
+	s := createTestStorage(t, fs, dir)
+	defer s.Close()
+
+	assert.NoError(t, s.Append(1, newTestEntries(1, 11, 1)))
+	assert.NoError(t, s.Append(2, newTestEntries(5, 8, 2)))
+	assert.NoError(t, s.Sync())
+
+	ents, err := s.Entries(1, 1, 11, 1024*1024)
+	assert.NoError(t, err)
+	assert.Equal(t, newTestEntries(1, 11, 1), ents)
+
+	ents, err = s.Entries(1, 3, 11, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ents))
+	assert.Equal(t, uint64(3), ents[0].Index)
+
+	_, err = s.Entries(1, 1, 12, 1024)
+	assert.Equal(t, raft.ErrUnavailable, err)
+
+	term, err := s.Term(2, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), term)
+
+	last, err := s.LastIndex(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), last)
+
+	last, err = s.LastIndex(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), last)
+
+	// conflict entries replace the entries after them
+	assert.NoError(t, s.Append(1, newTestEntries(5, 7, 3)))
+	last, err = s.LastIndex(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), last)
+	term, err = s.Term(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), term)
+	term, err = s.Term(1, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), term)
+}
+
+func TestCompactAndRemoveShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := newTestDir(fs)
+	defer fs.RemoveAll(dir)
+
+	s := createTestStorage(t, fs, dir)
+	defer s.Close()
+
+	assert.NoError(t, s.Append(1, newTestEntries(1, 11, 1)))
+	assert.NoError(t, s.Compact(1, 5))
+
+	_, err := s.Entries(1, 4, 6, 1024)
+	assert.Equal(t, raft.ErrCompacted, err)
+	_, err = s.Term(1, 4)
+	assert.Equal(t, raft.ErrCompacted, err)
+	ents, err := s.Entries(1, 5, 11, 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, newTestEntries(5, 11, 1), ents)
+
+	assert.NoError(t, s.RemoveShard(1))
+	last, err := s.LastIndex(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), last)
+}
+
+func TestSegmentRollAndGC(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := newTestDir(fs)
+	defer fs.RemoveAll(dir)
+
+	s := createTestStorage(t, fs, dir, WithSegmentSize(1))
+	defer s.Close()
+
+	for i := uint64(1); i <= 10; i++ {
+		assert.NoError(t, s.Append(1, newTestEntries(i, i+1, 1)))
+		assert.NoError(t, s.Append(2, newTestEntries(i, i+1, 1)))
+	}
+	// one segment per append and the active segment
+	assert.Equal(t, 21, len(s.segments))
+
+	// segments are removed after all their entries were compacted
+	assert.NoError(t, s.Compact(1, 6))
+	assert.Equal(t, 16, len(s.segments))
+	assert.NoError(t, s.Compact(2, 6))
+	assert.Equal(t, 11, len(s.segments))
+
+	assert.NoError(t, s.RemoveShard(1))
+	assert.Equal(t, 6, len(s.segments))
+	assert.NoError(t, s.RemoveShard(2))
+	assert.Equal(t, 1, len(s.segments))
+}
+
+func TestReplay(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := newTestDir(fs)
+	defer fs.RemoveAll(dir)
+
+	s := createTestStorage(t, fs, dir, WithSegmentSize(256))
+	for i := uint64(1); i <= 20; i++ {
+		assert.NoError(t, s.Append(1, newTestEntries(i, i+1, 1)))
+		assert.NoError(t, s.Append(2, newTestEntries(i, i+1, 2)))
+	}
+	assert.NoError(t, s.Compact(1, 10))
+	assert.NoError(t, s.Append(2, newTestEntries(15, 16, 3)))
+	assert.NoError(t, s.Append(3, newTestEntries(1, 5, 1)))
+	assert.NoError(t, s.RemoveShard(3))
+	path := s.segmentPath(s.active.id)
+	assert.NoError(t, s.Close())
+
+	// the torn tail written by a crash is ignored
+	f, err := fs.OpenForAppend(path)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	s = createTestStorage(t, fs, dir, WithSegmentSize(256))
+	defer s.Close()
+
+	_, err = s.Entries(1, 9, 10, 1024)
+	assert.Equal(t, raft.ErrCompacted, err)
+	ents, err := s.Entries(1, 10, 21, 1024*1024)
+	assert.NoError(t, err)
+	assert.Equal(t, newTestEntries(10, 21, 1), ents)
+
+	last, err := s.LastIndex(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), last)
+	ents, err = s.Entries(2, 14, 16, 1024*1024)
+	assert.NoError(t, err)
+	assert.Equal(t, append(newTestEntries(14, 15, 2), newTestEntries(15, 16, 3)...), ents)
+
+	last, err = s.LastIndex(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), last)
+}
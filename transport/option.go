@@ -16,6 +16,7 @@ package transport
 import (
 	"time"
 
+	"github.com/matrixorigin/matrixcube/components/prophet/util/tlsutil"
 	"github.com/matrixorigin/matrixcube/pb/bhraftpb"
 )
 
@@ -30,6 +31,7 @@ type options struct {
 	raftWorkerCount  uint64
 	snapWorkerCount  uint64
 	errorHandlerFunc func(*bhraftpb.RaftMessage, error)
+	tls              *tlsutil.TLS
 }
 
 // WithTimeout set read and write timeout for rpc
@@ -68,3 +70,10 @@ func WithErrorHandler(value func(*bhraftpb.RaftMessage, error)) Option {
 		opts.errorHandlerFunc = value
 	}
 }
+
+// WithTLS set the tls of the listener and the dialers
+func WithTLS(value *tlsutil.TLS) Option {
+	return func(opts *options) {
+		opts.tls = value
+	}
+}
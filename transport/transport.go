@@ -27,7 +27,8 @@ var (
 )
 
 var (
-	errConnect = errors.New("not connected")
+	errConnect           = errors.New("not connected")
+	errProbeNotSupported = errors.New("the snapshot manager not support probes")
 )
 
 // Transport raft transport
@@ -38,10 +39,21 @@ type Transport interface {
 	Stop()
 	// Send send the raft message to other node
 	Send(*bhraftpb.RaftMessage)
+	// SendSnapshot send the snapshot file to the store of the `msg.Header.To`, and wait until
+	// the file is fully received.
+	SendSnapshot(*bhraftpb.SnapshotMessage) error
 	// SendingSnapshotCount returns the count of sending snapshots
 	SendingSnapshotCount() uint64
 }
 
+// SnapshotProber is implemented by the Transport which can probe the bytes of the snapshot file
+// received by the remote store.
+type SnapshotProber interface {
+	// ProbeSnapshot returns the bytes of the snapshot file received by the store of the
+	// `msg.Header.To`.
+	ProbeSnapshot(*bhraftpb.SnapshotMessage) (uint64, error)
+}
+
 // ContainerResolver container resolver func
 type ContainerResolver func(id uint64) (metadata.Container, error)
 
@@ -91,7 +103,7 @@ func NewDefaultTransport(
 	baseEncoder := newRaftEncoder()
 	baseDecoder := newRaftDecoder()
 	t.encoder, t.decoder = length.NewWithSize(baseEncoder, baseDecoder, 0, 0, 0, t.opts.maxBodySize)
-	app, err := goetty.NewTCPApplication(addr, t.onMessage,
+	app, err := t.opts.tls.NewApplication(addr, t.onMessage,
 		goetty.WithAppSessionOptions(goetty.WithCodec(t.encoder, t.decoder),
 			goetty.WithTimeout(t.opts.readTimeout, t.opts.writeTimeout),
 			goetty.WithLogger(zap.L().Named("cube-trans")),
@@ -159,7 +171,8 @@ func (t *defaultTransport) Send(msg *bhraftpb.RaftMessage) {
 		return
 	}
 
-	if msg.Message.Type == raftpb.MsgSnap {
+	// the witness holds no data, only the snapshot metadata in the raft message is sent to it
+	if msg.Message.Type == raftpb.MsgSnap && !metadata.IsWitness(msg.To) {
 		snapMsg := &bhraftpb.SnapshotMessage{}
 		protoc.MustUnmarshal(snapMsg, msg.Message.Snapshot.Data)
 		snapMsg.Header.From = msg.From
@@ -175,7 +188,57 @@ func (t *defaultTransport) Send(msg *bhraftpb.RaftMessage) {
 	metric.SetRaftMsgQueueMetric(q.Len())
 }
 
+func (t *defaultTransport) SendSnapshot(msg *bhraftpb.SnapshotMessage) error {
+	storeID := msg.Header.To.ContainerID
+	if storeID == t.storeID {
+		return nil
+	}
+
+	conn, err := t.getConn(storeID)
+	if err != nil {
+		return err
+	}
+
+	err = t.doSendSnapshotMessage(msg, conn)
+	t.putConn(storeID, conn)
+	return err
+}
+
+func (t *defaultTransport) ProbeSnapshot(msg *bhraftpb.SnapshotMessage) (uint64, error) {
+	prober, ok := t.snapMgr.(snapshot.SnapshotProber)
+	if !ok {
+		return 0, errProbeNotSupported
+	}
+
+	storeID := msg.Header.To.ContainerID
+	conn, err := t.getConn(storeID)
+	if err != nil {
+		return 0, err
+	}
+
+	received, err := prober.Probe(msg, conn)
+	if err != nil {
+		conn.Close()
+	}
+	t.putConn(storeID, conn)
+	return received, err
+}
+
 func (t *defaultTransport) onMessage(rs goetty.IOSession, msg interface{}, seq uint64) error {
+	if snap, ok := msg.(*bhraftpb.SnapshotMessage); ok && snap.Probe {
+		// the sender sends the snapshot from the beginning if the probe is not answered
+		prober, ok := t.snapMgr.(snapshot.SnapshotProber)
+		if !ok {
+			return nil
+		}
+
+		rsp := &bhraftpb.SnapshotMessage{}
+		rsp.Header = snap.Header
+		rsp.Probe = true
+		rsp.Offset = prober.ReceivedSnapBytes(snap)
+		return rs.WriteAndFlush(rsp)
+	}
+
 	t.handler(msg)
 	return nil
 }
@@ -391,6 +454,14 @@ func (t *defaultTransport) checkConnect(id uint64, conn goetty.IOSession) bool {
 		return false
 	}
 
+	addr, err = t.opts.tls.DialAddr(addr)
+	if err != nil {
+		logger.Errorf("create tls tunnel to store %d failed with %+v",
+			id,
+			err)
+		return false
+	}
+
 	ok, err := conn.Connect(addr, time.Second*10)
 	if err != nil {
 		logger.Errorf("connect to store %d failed with %+v",
@@ -404,8 +475,9 @@ func (t *defaultTransport) checkConnect(id uint64, conn goetty.IOSession) bool {
 }
 
 func (t *defaultTransport) createConn() (goetty.IOSession, error) {
-	return goetty.NewIOSession(goetty.WithCodec(t.encoder, t.decoder),
-		goetty.WithTimeout(t.opts.readTimeout, t.opts.writeTimeout)), nil
+	opts := []goetty.Option{goetty.WithCodec(t.encoder, t.decoder),
+		goetty.WithTimeout(t.opts.readTimeout, t.opts.writeTimeout)}
+	return goetty.NewIOSession(append(opts, t.opts.tls.SessionOptions()...)...), nil
 }
 
 func (t *defaultTransport) resolverStoreAddr(storeID uint64) (string, error) {
@@ -0,0 +1,317 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"bytes"
+
+	"github.com/fagongzi/util/hack"
+	"github.com/matrixorigin/matrixcube/command"
+	"github.com/matrixorigin/matrixcube/pb"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+const (
+	attrPrefix = "txn-record-"
+)
+
+// Registry the registry of the command handlers, raftstore.Store is a registry
+type Registry interface {
+	// RegisterReadFunc register read command handler
+	RegisterReadFunc(uint64, command.ReadCommandFunc)
+	// RegisterWriteFunc register write command handler
+	RegisterWriteFunc(uint64, command.WriteCommandFunc)
+}
+
+// RegisterHandlers register the transaction command handlers to the registry
+func RegisterHandlers(r Registry) {
+	r.RegisterWriteFunc(PrewriteType, writeHandler(prewrite))
+	r.RegisterWriteFunc(CommitType, writeHandler(commit))
+	r.RegisterWriteFunc(RollbackType, writeHandler(rollback))
+	r.RegisterWriteFunc(CheckTxnStatusType, writeHandler(checkTxnStatus))
+	r.RegisterWriteFunc(CleanupType, writeHandler(cleanup))
+	r.RegisterReadFunc(GetType, get)
+}
+
+// writeFunc handles the transaction command with the record of the encoded key, returns the
+// response and whether the record is changed.
+type writeFunc func(key []byte, rec *record, req *Request) (Response, bool)
+
+func writeHandler(fn writeFunc) command.WriteCommandFunc {
+	return func(shard bhmetapb.Shard, req *raftcmdpb.Request, ctx command.Context) (uint64, int64, *raftcmdpb.Response) {
+		resp := pb.AcquireResponse()
+
+		r := Request{}
+		if err := r.Unmarshal(req.Cmd); err != nil {
+			resp.Error.Message = err.Error()
+			return 0, 0, resp
+		}
+
+		old, err := loadRecord(req.Key, ctx)
+		if err != nil {
+			resp.Error.Message = err.Error()
+			return 0, 0, resp
+		}
+
+		rec := &record{}
+		if len(old) > 0 {
+			if err := rec.unmarshal(old); err != nil {
+				resp.Error.Message = err.Error()
+				return 0, 0, resp
+			}
+		}
+
+		result, changed := fn(raftstore.DecodeDataKey(req.Key), rec, &r)
+		resp.Value = result.Marshal()
+		if !changed {
+			return 0, 0, resp
+		}
+		if rec.written != nil && !inShard(shard, versionKey(req.Key, rec.commitTS)) {
+			resp.Value = nil
+			resp.Error.Message = errVersionNotInShard.Error()
+			return 0, 0, resp
+		}
+
+		data := rec.marshal()
+		if err := ctx.WriteBatch().Set(req.Key, data); err != nil {
+			resp.Error.Message = err.Error()
+			return 0, 0, resp
+		}
+		// the later commands in the same batch read the record from the attrs, the write
+		// batch is not written to the storage yet
+		ctx.Attrs()[attrPrefix+hack.SliceToString(req.Key)] = data
+
+		writtenBytes := uint64(len(req.Key) + len(data))
+		diffBytes := int64(len(data) - len(old))
+		if len(old) == 0 {
+			diffBytes += int64(len(req.Key))
+		}
+
+		if rec.written != nil {
+			key := versionKey(req.Key, rec.commitTS)
+			value := rec.written.marshal()
+			if err := ctx.WriteBatch().Set(key, value); err != nil {
+				resp.Error.Message = err.Error()
+				return 0, 0, resp
+			}
+			writtenBytes += uint64(len(key) + len(value))
+			diffBytes += int64(len(key) + len(value))
+		}
+		return writtenBytes, diffBytes, resp
+	}
+}
+
+// inShard returns true if the data key is in the range of the shard
+func inShard(shard bhmetapb.Shard, key []byte) bool {
+	key = raftstore.DecodeDataKey(key)
+	return bytes.Compare(key, shard.Start) >= 0 &&
+		(len(shard.End) == 0 || bytes.Compare(key, shard.End) < 0)
+}
+
+func loadRecord(key []byte, ctx command.Context) ([]byte, error) {
+	if value, ok := ctx.Attrs()[attrPrefix+hack.SliceToString(key)]; ok {
+		return value.([]byte), nil
+	}
+
+	return ctx.DataStorage().(storage.KVStorage).Get(key)
+}
+
+func prewrite(key []byte, rec *record, req *Request) (Response, bool) {
+	if rec.lock != nil {
+		if rec.lock.StartTS == req.StartTS {
+			return Response{Status: StatusOK}, false
+		}
+		return Response{Status: StatusLocked, Lock: rec.lock}, false
+	}
+
+	if s, ok := rec.getTxn(req.StartTS); ok && s.commitTS == 0 {
+		return Response{Status: StatusAborted}, false
+	}
+	if _, ok := rec.isCommitted(req.StartTS); ok {
+		return Response{Status: StatusOK}, false
+	}
+	if rec.commitTS > req.StartTS {
+		return Response{Status: StatusWriteConflict}, false
+	}
+
+	rec.lock = &Lock{
+		Primary:     req.Primary,
+		StartTS:     req.StartTS,
+		TTL:         req.TTL,
+		Op:          req.Op,
+		Value:       req.Value,
+		Secondaries: req.Secondaries,
+	}
+	return Response{Status: StatusOK}, true
+}
+
+func commit(key []byte, rec *record, req *Request) (Response, bool) {
+	if rec.lock != nil && rec.lock.StartTS == req.StartTS {
+		lock := rec.lock
+		rec.lock = nil
+		rec.written = &version{
+			startTS: req.StartTS,
+			deleted: lock.Op == OpDelete,
+			value:   lock.Value,
+		}
+		rec.commitTS = req.CommitTS
+		rec.commitStartTS = req.StartTS
+
+		// the late prewrite of the rolled back transactions started before the commit ts is
+		// rejected by the write conflict check, the rollback status is useless.
+		txns := rec.txns[:0]
+		for _, s := range rec.txns {
+			if s.commitTS > 0 || s.startTS > req.CommitTS {
+				txns = append(txns, s)
+			}
+		}
+		rec.txns = txns
+
+		// keep the status until all the secondary keys are committed
+		if len(lock.Secondaries) > 0 {
+			rec.txns = append(rec.txns, txnStatus{
+				startTS:     req.StartTS,
+				commitTS:    req.CommitTS,
+				secondaries: lock.Secondaries,
+			})
+		}
+		return Response{Status: StatusOK}, true
+	}
+
+	if _, ok := rec.isCommitted(req.StartTS); ok {
+		return Response{Status: StatusOK}, false
+	}
+	return Response{Status: StatusAborted}, false
+}
+
+func rollback(key []byte, rec *record, req *Request) (Response, bool) {
+	primary := bytes.Equal(key, EncodeKey(req.Primary))
+	if rec.lock != nil && rec.lock.StartTS == req.StartTS {
+		rec.lock = nil
+		if primary {
+			rec.txns = append(rec.txns, txnStatus{startTS: req.StartTS})
+		}
+		return Response{Status: StatusOK}, true
+	}
+
+	if commitTS, ok := rec.isCommitted(req.StartTS); ok {
+		return Response{Status: StatusCommitted, CommitTS: commitTS}, false
+	}
+
+	// the primary key keeps the rollback status to reject the late prewrite
+	if primary {
+		if _, ok := rec.getTxn(req.StartTS); !ok {
+			rec.txns = append(rec.txns, txnStatus{startTS: req.StartTS})
+			return Response{Status: StatusOK}, true
+		}
+	}
+	return Response{Status: StatusOK}, false
+}
+
+// checkTxnStatus checks the status of the transaction on the primary key, the expired lock
+// is rolled back.
+func checkTxnStatus(key []byte, rec *record, req *Request) (Response, bool) {
+	if rec.lock != nil && rec.lock.StartTS == req.StartTS {
+		if !rec.lock.Expired(req.Now) {
+			return Response{Status: StatusLocked, Lock: rec.lock}, false
+		}
+
+		rec.lock = nil
+		rec.txns = append(rec.txns, txnStatus{startTS: req.StartTS})
+		return Response{Status: StatusAborted}, true
+	}
+
+	if s, ok := rec.getTxn(req.StartTS); ok {
+		if s.commitTS > 0 {
+			return Response{Status: StatusCommitted, CommitTS: s.commitTS, Secondaries: s.secondaries}, false
+		}
+		return Response{Status: StatusAborted}, false
+	}
+	if commitTS, ok := rec.isCommitted(req.StartTS); ok {
+		return Response{Status: StatusCommitted, CommitTS: commitTS}, false
+	}
+
+	// the prewrite of the primary key is not arrived, rollback it to reject the late prewrite
+	rec.txns = append(rec.txns, txnStatus{startTS: req.StartTS})
+	return Response{Status: StatusAborted}, true
+}
+
+// cleanup removes the status of the committed transaction after all the secondary keys are committed
+func cleanup(key []byte, rec *record, req *Request) (Response, bool) {
+	if s, ok := rec.getTxn(req.StartTS); ok && s.commitTS > 0 {
+		rec.removeTxn(req.StartTS)
+		return Response{Status: StatusOK}, true
+	}
+	return Response{Status: StatusOK}, false
+}
+
+func get(shard bhmetapb.Shard, req *raftcmdpb.Request, ctx command.Context) (*raftcmdpb.Response, uint64) {
+	resp := pb.AcquireResponse()
+
+	r := Request{}
+	if err := r.Unmarshal(req.Cmd); err != nil {
+		resp.Error.Message = err.Error()
+		return resp, 0
+	}
+
+	kv := ctx.DataStorage().(storage.KVStorage)
+	value, err := kv.Get(req.Key)
+	if err != nil {
+		resp.Error.Message = err.Error()
+		return resp, 0
+	}
+
+	rec := &record{}
+	if len(value) > 0 {
+		if err := rec.unmarshal(value); err != nil {
+			resp.Error.Message = err.Error()
+			return resp, 0
+		}
+	}
+
+	result := Response{Status: StatusOK}
+	readBytes := uint64(len(value))
+	if rec.lock != nil && rec.lock.StartTS <= r.StartTS {
+		// the transaction may be committed before the start ts
+		result = Response{Status: StatusLocked, Lock: rec.lock}
+	} else if rec.commitTS > 0 {
+		// the first version committed before the start ts
+		start, end := versionKey(req.Key, r.StartTS), versionKey(req.Key, 0)
+		if !inShard(shard, start) || !inShard(shard, end) {
+			resp.Error.Message = errVersionNotInShard.Error()
+			return resp, 0
+		}
+
+		v := version{}
+		found := false
+		err := kv.Scan(start, end, func(key, value []byte) (bool, error) {
+			readBytes += uint64(len(value))
+			found = true
+			return false, v.unmarshal(value)
+		}, false)
+		if err != nil {
+			resp.Error.Message = err.Error()
+			return resp, 0
+		}
+		if found && !v.deleted {
+			result.Value = v.value
+		}
+	}
+
+	resp.Value = result.Marshal()
+	return resp, readBytes
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/goetty/buf"
+	"github.com/matrixorigin/matrixcube/command"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/tso"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/pb/raftcmdpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/mem"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+)
+
+type testContext struct {
+	wb    *util.WriteBatch
+	attrs map[string]interface{}
+	ds    storage.DataStorage
+}
+
+func newTestContext() *testContext {
+	return &testContext{
+		wb:    util.NewWriteBatch(),
+		attrs: make(map[string]interface{}),
+		ds:    mem.NewStorage(vfs.GetTestFS()),
+	}
+}
+
+func (ctx *testContext) WriteBatch() *util.WriteBatch     { return ctx.wb }
+func (ctx *testContext) LogIndex() uint64                 { return 0 }
+func (ctx *testContext) Offset() int                      { return 0 }
+func (ctx *testContext) BatchSize() int                   { return 1 }
+func (ctx *testContext) Attrs() map[string]interface{}    { return ctx.attrs }
+func (ctx *testContext) ByteBuf() *buf.ByteBuf            { return nil }
+func (ctx *testContext) DataStorage() storage.DataStorage { return ctx.ds }
+func (ctx *testContext) StoreID() uint64                  { return 0 }
+
+func (ctx *testContext) flush(t *testing.T) {
+	assert.NoError(t, ctx.ds.(storage.KVStorage).Write(ctx.wb, false))
+	ctx.wb.Reset()
+	for key := range ctx.attrs {
+		delete(ctx.attrs, key)
+	}
+}
+
+type testRegistry struct {
+	reads  map[uint64]command.ReadCommandFunc
+	writes map[uint64]command.WriteCommandFunc
+}
+
+func (r *testRegistry) RegisterReadFunc(ct uint64, fn command.ReadCommandFunc) {
+	r.reads[ct] = fn
+}
+
+func (r *testRegistry) RegisterWriteFunc(ct uint64, fn command.WriteCommandFunc) {
+	r.writes[ct] = fn
+}
+
+type testExecutor struct {
+	t     *testing.T
+	ctx   *testContext
+	r     *testRegistry
+	shard bhmetapb.Shard
+}
+
+func newTestExecutor(t *testing.T) *testExecutor {
+	r := &testRegistry{
+		reads:  make(map[uint64]command.ReadCommandFunc),
+		writes: make(map[uint64]command.WriteCommandFunc),
+	}
+	RegisterHandlers(r)
+	return &testExecutor{t: t, ctx: newTestContext(), r: r}
+}
+
+func (e *testExecutor) exec(ct uint64, key string, req Request, flush bool) Response {
+	resp := e.execRaw(ct, key, req, flush)
+	assert.Empty(e.t, resp.Error.Message)
+
+	result := Response{}
+	assert.NoError(e.t, result.Unmarshal(resp.Value))
+	return result
+}
+
+func (e *testExecutor) execRaw(ct uint64, key string, req Request, flush bool) *raftcmdpb.Response {
+	r := &raftcmdpb.Request{
+		Key:        raftstore.EncodeDataKey(0, EncodeKey([]byte(key))),
+		CustemType: ct,
+		Cmd:        req.Marshal(),
+	}
+
+	if fn, ok := e.r.writes[ct]; ok {
+		_, _, resp := fn(e.shard, r, e.ctx)
+		if flush {
+			e.ctx.flush(e.t)
+		}
+		return resp
+	}
+
+	resp, _ := e.r.reads[ct](e.shard, r, e.ctx)
+	return resp
+}
+
+func TestCommit(t *testing.T) {
+	e := newTestExecutor(t)
+	prewrite := Request{StartTS: 10, Primary: []byte("k1"), Value: []byte("v1"), TTL: int64(time.Millisecond * 100),
+		Secondaries: [][]byte{[]byte("k2")}}
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1", prewrite, true).Status)
+	prewrite.Value = []byte("v2")
+	prewrite.Secondaries = nil
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k2", prewrite, true).Status)
+
+	// the lock blocks the reads and the writes of the other transactions
+	resp := e.exec(GetType, "k1", Request{StartTS: 11}, false)
+	assert.Equal(t, StatusLocked, resp.Status)
+	assert.Equal(t, []byte("k1"), resp.Lock.Primary)
+	assert.Equal(t, uint64(10), resp.Lock.StartTS)
+	assert.Equal(t, StatusLocked, e.exec(PrewriteType, "k2", Request{StartTS: 12, Primary: []byte("k2")}, true).Status)
+	// the reads started before the transaction are not blocked
+	assert.Equal(t, StatusOK, e.exec(GetType, "k1", Request{StartTS: 9}, false).Status)
+
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k1", Request{StartTS: 10, CommitTS: 13}, true).Status)
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k1", Request{StartTS: 10, CommitTS: 13}, true).Status)
+	resp = e.exec(CheckTxnStatusType, "k1", Request{StartTS: 10, Now: tso.Compose(time.Unix(0, 0).Add(time.Second), 0)}, true)
+	assert.Equal(t, StatusCommitted, resp.Status)
+	assert.Equal(t, uint64(13), resp.CommitTS)
+	assert.Equal(t, [][]byte{[]byte("k2")}, resp.Secondaries)
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k2", Request{StartTS: 10, CommitTS: 13}, true).Status)
+	assert.Equal(t, StatusOK, e.exec(CleanupType, "k1", Request{StartTS: 10}, true).Status)
+
+	resp = e.exec(GetType, "k2", Request{StartTS: 14}, false)
+	assert.Equal(t, StatusOK, resp.Status)
+	assert.Equal(t, []byte("v2"), resp.Value)
+	// the reads started before the commit read the old version
+	resp = e.exec(GetType, "k2", Request{StartTS: 12}, false)
+	assert.Equal(t, StatusOK, resp.Status)
+	assert.Empty(t, resp.Value)
+	assert.Equal(t, StatusWriteConflict, e.exec(PrewriteType, "k2", Request{StartTS: 12, Primary: []byte("k2")}, true).Status)
+	assert.Equal(t, StatusCommitted, e.exec(RollbackType, "k2", Request{StartTS: 10, Primary: []byte("k1")}, true).Status)
+
+	// delete
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k2", Request{StartTS: 15, Primary: []byte("k2"), Op: OpDelete}, true).Status)
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k2", Request{StartTS: 15, CommitTS: 16}, true).Status)
+	resp = e.exec(GetType, "k2", Request{StartTS: 17}, false)
+	assert.Equal(t, StatusOK, resp.Status)
+	assert.Empty(t, resp.Value)
+	resp = e.exec(GetType, "k2", Request{StartTS: 15}, false)
+	assert.Equal(t, StatusOK, resp.Status)
+	assert.Equal(t, []byte("v2"), resp.Value)
+}
+
+func TestMultiVersions(t *testing.T) {
+	e := newTestExecutor(t)
+	for i := uint64(1); i <= 3; i++ {
+		req := Request{StartTS: i * 10, Primary: []byte("k1"), Value: []byte{byte(i)}}
+		assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1", req, true).Status)
+		assert.Equal(t, StatusOK, e.exec(CommitType, "k1", Request{StartTS: i * 10, CommitTS: i*10 + 5}, true).Status)
+	}
+	// the versions of the other keys are not mixed
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1\x00", Request{StartTS: 40, Primary: []byte("k1\x00")}, true).Status)
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k1\x00", Request{StartTS: 40, CommitTS: 45}, true).Status)
+
+	for startTS, value := range map[uint64][]byte{1: nil, 15: {1}, 24: {1}, 25: {2}, 100: {3}} {
+		resp := e.exec(GetType, "k1", Request{StartTS: startTS}, false)
+		assert.Equal(t, StatusOK, resp.Status)
+		assert.Equal(t, value, resp.Value, "start ts %d", startTS)
+	}
+}
+
+func TestEncodeKey(t *testing.T) {
+	keys := [][]byte{nil, []byte("a"), []byte("a\x00"), []byte("abcdefgh"), []byte("abcdefgh\x00"), []byte("b")}
+	for i := 1; i < len(keys); i++ {
+		assert.True(t, bytes.Compare(EncodeKey(keys[i-1]), EncodeKey(keys[i])) < 0)
+	}
+	for _, key := range keys {
+		encoded := EncodeKey(key)
+		assert.Equal(t, encoded, RecordKey(encoded))
+		assert.Equal(t, encoded, RecordKey(versionKey(encoded, 10)))
+	}
+	assert.Nil(t, RecordKey([]byte("abc")))
+}
+
+func TestRollback(t *testing.T) {
+	e := newTestExecutor(t)
+	prewrite := Request{StartTS: 10, Primary: []byte("k1"), Value: []byte("v1"), TTL: int64(time.Millisecond * 100),
+		Secondaries: [][]byte{[]byte("k2")}}
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1", prewrite, true).Status)
+
+	// the lock is not expired
+	// the lock is created at the physical time of the start ts
+	notExpired := tso.Compose(time.Unix(0, 0).Add(time.Millisecond*99), 1000)
+	expired := tso.Compose(time.Unix(0, 0).Add(time.Millisecond*100), 0)
+	assert.Equal(t, StatusLocked, e.exec(CheckTxnStatusType, "k1", Request{StartTS: 10, Now: notExpired}, true).Status)
+	// the expired lock is rolled back
+	assert.Equal(t, StatusAborted, e.exec(CheckTxnStatusType, "k1", Request{StartTS: 10, Now: expired}, true).Status)
+	assert.Equal(t, StatusAborted, e.exec(CheckTxnStatusType, "k1", Request{StartTS: 10, Now: expired}, true).Status)
+	assert.Equal(t, StatusAborted, e.exec(CommitType, "k1", Request{StartTS: 10, CommitTS: 11}, true).Status)
+	// the late prewrite is rejected
+	assert.Equal(t, StatusAborted, e.exec(PrewriteType, "k1", prewrite, true).Status)
+
+	// the status of the transaction without the primary lock
+	assert.Equal(t, StatusAborted, e.exec(CheckTxnStatusType, "k3", Request{StartTS: 12, Now: 1}, true).Status)
+	assert.Equal(t, StatusAborted, e.exec(PrewriteType, "k3", Request{StartTS: 12, Primary: []byte("k3")}, true).Status)
+
+	// the rollback status is removed by the later commit
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1", Request{StartTS: 13, Primary: []byte("k1")}, true).Status)
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k1", Request{StartTS: 13, CommitTS: 14}, true).Status)
+	assert.Equal(t, StatusWriteConflict, e.exec(PrewriteType, "k1", prewrite, true).Status)
+}
+
+func TestCommandsInSameBatch(t *testing.T) {
+	e := newTestExecutor(t)
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1", Request{StartTS: 10, Primary: []byte("k1")}, false).Status)
+	assert.Equal(t, StatusLocked, e.exec(PrewriteType, "k1", Request{StartTS: 11, Primary: []byte("k1")}, false).Status)
+	e.ctx.flush(t)
+	assert.Equal(t, StatusLocked, e.exec(GetType, "k1", Request{StartTS: 11}, false).Status)
+}
+
+func TestVersionNotInShard(t *testing.T) {
+	e := newTestExecutor(t)
+	// the shard is split between the record and its versions committed before 20
+	e.shard = bhmetapb.Shard{End: versionKey(EncodeKey([]byte("k1")), 20)}
+	prewrite := Request{StartTS: 10, Primary: []byte("k1"), Value: []byte("v1"), TTL: int64(time.Second)}
+	assert.Equal(t, StatusOK, e.exec(PrewriteType, "k1", prewrite, true).Status)
+
+	// the version is rejected, the record is not changed
+	resp := e.execRaw(CommitType, "k1", Request{StartTS: 10, CommitTS: 15}, true)
+	assert.Equal(t, errVersionNotInShard.Error(), resp.Error.Message)
+	assert.Equal(t, StatusLocked, e.exec(GetType, "k1", Request{StartTS: 31}, false).Status)
+
+	// the version committed after 20 is in the shard, but the scan of the versions is not
+	assert.Equal(t, StatusOK, e.exec(CommitType, "k1", Request{StartTS: 10, CommitTS: 30}, true).Status)
+	resp = e.execRaw(GetType, "k1", Request{StartTS: 31}, false)
+	assert.Equal(t, errVersionNotInShard.Error(), resp.Error.Message)
+}
+
+func TestAdjustSplitKeys(t *testing.T) {
+	k1, k2 := EncodeKey([]byte("k1")), EncodeKey([]byte("k2"))
+	start := raftstore.EncodeDataKey(0, k1)
+	splitKeys := [][]byte{
+		raftstore.EncodeDataKey(0, versionKey(k1, 10)),
+		raftstore.EncodeDataKey(0, versionKey(k2, 10)),
+		raftstore.EncodeDataKey(0, versionKey(k2, 5)),
+		raftstore.EncodeDataKey(0, []byte("plain")),
+	}
+	assert.Equal(t, [][]byte{
+		raftstore.EncodeDataKey(0, k2),
+		raftstore.EncodeDataKey(0, []byte("plain")),
+	}, adjustSplitKeys(0, start, splitKeys))
+
+	cfg := &config.Config{}
+	factory := SplitCheckFuncFactory(cfg, nil, 1)
+	assert.Nil(t, factory(0))
+	assert.NotNil(t, factory(1))
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"bytes"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/bhmetapb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+)
+
+// SplitCheckFuncFactory returns the config.CustomizeConfig.CustomSplitCheckFuncFactory which keeps
+// the record and the versions of a key in the same shard for the groups written by the
+// transactions. The split keys found by the default split check are adjusted to the encoded keys
+// of their records, so a shard never splits between a record and its versions. The other groups
+// use the split check of next, nil means the default split check.
+func SplitCheckFuncFactory(cfg *config.Config, next func(group uint64) func(bhmetapb.Shard) (uint64, uint64, [][]byte, error),
+	groups ...uint64) func(group uint64) func(bhmetapb.Shard) (uint64, uint64, [][]byte, error) {
+	txnGroups := make(map[uint64]struct{}, len(groups))
+	for _, g := range groups {
+		txnGroups[g] = struct{}{}
+	}
+
+	return func(group uint64) func(bhmetapb.Shard) (uint64, uint64, [][]byte, error) {
+		if _, ok := txnGroups[group]; !ok {
+			if next == nil {
+				return nil
+			}
+			return next(group)
+		}
+
+		return func(shard bhmetapb.Shard) (uint64, uint64, [][]byte, error) {
+			start := raftstore.EncodeDataKey(group, shard.Start)
+			end := raftstore.EncodeDataKey(group+1, nil)
+			if len(shard.End) > 0 {
+				end = raftstore.EncodeDataKey(group, shard.End)
+			}
+
+			ds := cfg.Storage.DataStorageFactory(group, shard.ID)
+			size, keys, splitKeys, err := ds.SplitCheck(start, end, uint64(cfg.Replication.ShardCapacityBytes))
+			if err != nil {
+				return 0, 0, nil, err
+			}
+			return size, keys, adjustSplitKeys(group, start, splitKeys), nil
+		}
+	}
+}
+
+// adjustSplitKeys returns the split keys moved to the encoded keys of their records, the keys
+// not written by the transactions are kept. The split key moved to the start of the shard, or
+// to the previous split key, is removed.
+func adjustSplitKeys(group uint64, start []byte, splitKeys [][]byte) [][]byte {
+	var adjusted [][]byte
+	last := start
+	for _, key := range splitKeys {
+		if rk := RecordKey(raftstore.DecodeDataKey(key)); rk != nil {
+			key = raftstore.EncodeDataKey(group, rk)
+		}
+		if bytes.Compare(key, last) <= 0 {
+			continue
+		}
+
+		adjusted = append(adjusted, key)
+		last = key
+	}
+	return adjusted
+}
@@ -0,0 +1,454 @@
+// Copyright 2020 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package txn implements the Percolator style cross-shard transactions over raftstore. Each key
+// written by the transactions is encoded by EncodeKey, the encoded key stores the record that
+// holds the lock of the transaction in progress, the commit ts of the latest write and, on the
+// primary key, the status of the finished transactions whose secondary keys may still be locked.
+// The committed values are stored in the versions keyed by the encoded key and the commit ts in
+// descending order, so the versions follow the record and the readers read the value committed
+// before their start ts. The shards of the group must be split at the encoded keys, the split
+// check returned by SplitCheckFuncFactory moves the split keys to the encoded keys, and the
+// versions out of the shard of the record are rejected. The committed versions are never
+// removed, the storage of the group grows with the writes, the old versions need to be
+// removed by the application if necessary.
+package txn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/tso"
+)
+
+// The custom types of the transaction commands, they are reserved from the max uint64.
+const (
+	PrewriteType uint64 = math.MaxUint64 - iota
+	CommitType
+	RollbackType
+	CheckTxnStatusType
+	CleanupType
+	GetType
+)
+
+var (
+	// ErrWriteConflict the key is committed by another transaction after the transaction started
+	ErrWriteConflict = errors.New("txn: write conflict")
+	// ErrKeyLocked the key is locked by another transaction in progress
+	ErrKeyLocked = errors.New("txn: key locked")
+	// ErrAborted the transaction is rolled back
+	ErrAborted = errors.New("txn: aborted")
+	// ErrCommitted the transaction is committed, it cannot be rolled back
+	ErrCommitted = errors.New("txn: committed")
+
+	errInvalidData       = errors.New("txn: invalid data")
+	errVersionNotInShard = errors.New("txn: the versions are not in the shard of the record, use SplitCheckFuncFactory to split the shards")
+)
+
+// Op the mutation op of the key
+type Op byte
+
+const (
+	// OpPut put the value
+	OpPut = Op(0)
+	// OpDelete delete the key
+	OpDelete = Op(1)
+)
+
+// Status the status of the transaction command
+type Status byte
+
+const (
+	// StatusOK the command succeed
+	StatusOK = Status(0)
+	// StatusLocked the key is locked, the lock is returned in the response
+	StatusLocked = Status(1)
+	// StatusWriteConflict the key is committed after the start ts
+	StatusWriteConflict = Status(2)
+	// StatusAborted the transaction is rolled back
+	StatusAborted = Status(3)
+	// StatusCommitted the transaction is committed, the commit ts and the secondary keys are returned
+	StatusCommitted = Status(4)
+)
+
+// Err returns the error of the status
+func (s Status) Err() error {
+	switch s {
+	case StatusOK:
+		return nil
+	case StatusLocked:
+		return ErrKeyLocked
+	case StatusWriteConflict:
+		return ErrWriteConflict
+	case StatusAborted:
+		return ErrAborted
+	case StatusCommitted:
+		return ErrCommitted
+	}
+	return errInvalidData
+}
+
+// Lock the lock of the transaction in progress
+type Lock struct {
+	// Primary the primary key of the transaction
+	Primary []byte
+	// StartTS the start ts of the transaction
+	StartTS uint64
+	// TTL the ttl of the lock in nanoseconds, the lock can be resolved after the physical time
+	// of the StartTS plus the TTL
+	TTL int64
+	// Op the mutation op
+	Op Op
+	// Value the value to put
+	Value []byte
+	// Secondaries the secondary keys of the transaction, only the primary lock has
+	Secondaries [][]byte
+}
+
+// Expired returns true if the lock is expired at the timestamp now allocated by the TSO. Both
+// the times are the physical times of the TSO, the wall clocks of the stores are never compared.
+func (l *Lock) Expired(now uint64) bool {
+	start, _ := tso.Parse(l.StartTS)
+	current, _ := tso.Parse(now)
+	return current.Sub(start) >= time.Duration(l.TTL)
+}
+
+// Request the transaction command request
+type Request struct {
+	StartTS     uint64
+	CommitTS    uint64
+	Primary     []byte
+	Op          Op
+	Value       []byte
+	TTL         int64
+	Now         uint64
+	Secondaries [][]byte
+}
+
+// Marshal marshal the request
+func (r *Request) Marshal() []byte {
+	e := encoder{}
+	e.uint64(r.StartTS)
+	e.uint64(r.CommitTS)
+	e.bytes(r.Primary)
+	e.uint64(uint64(r.Op))
+	e.bytes(r.Value)
+	e.uint64(uint64(r.TTL))
+	e.uint64(r.Now)
+	e.bytesSlice(r.Secondaries)
+	return e.data
+}
+
+// Unmarshal unmarshal the request
+func (r *Request) Unmarshal(data []byte) error {
+	d := decoder{data: data}
+	r.StartTS = d.uint64()
+	r.CommitTS = d.uint64()
+	r.Primary = d.bytes()
+	r.Op = Op(d.uint64())
+	r.Value = d.bytes()
+	r.TTL = int64(d.uint64())
+	r.Now = d.uint64()
+	r.Secondaries = d.bytesSlice()
+	return d.err
+}
+
+// Response the transaction command response
+type Response struct {
+	Status Status
+	// Value the value of the get command
+	Value []byte
+	// Lock the lock of the key if the status is StatusLocked
+	Lock *Lock
+	// CommitTS the commit ts if the status is StatusCommitted
+	CommitTS uint64
+	// Secondaries the secondary keys if the status is StatusCommitted
+	Secondaries [][]byte
+}
+
+// Marshal marshal the response
+func (r *Response) Marshal() []byte {
+	e := encoder{}
+	e.uint64(uint64(r.Status))
+	e.bytes(r.Value)
+	e.lock(r.Lock)
+	e.uint64(r.CommitTS)
+	e.bytesSlice(r.Secondaries)
+	return e.data
+}
+
+// Unmarshal unmarshal the response
+func (r *Response) Unmarshal(data []byte) error {
+	d := decoder{data: data}
+	r.Status = Status(d.uint64())
+	r.Value = d.bytes()
+	r.Lock = d.lock()
+	r.CommitTS = d.uint64()
+	r.Secondaries = d.bytesSlice()
+	return d.err
+}
+
+// txnStatus the status of the finished transaction, it's kept in the primary record until all
+// the secondary keys are resolved. The commitTS is 0 if the transaction is rolled back.
+type txnStatus struct {
+	startTS     uint64
+	commitTS    uint64
+	secondaries [][]byte
+}
+
+// record the stored record of the key written by the transactions
+type record struct {
+	commitTS      uint64
+	commitStartTS uint64
+	lock          *Lock
+	txns          []txnStatus
+	// written the version written by the commit, it's not stored in the record
+	written *version
+}
+
+// version the committed value of the key
+type version struct {
+	startTS uint64
+	deleted bool
+	value   []byte
+}
+
+func (r *record) getTxn(startTS uint64) (txnStatus, bool) {
+	for _, s := range r.txns {
+		if s.startTS == startTS {
+			return s, true
+		}
+	}
+	return txnStatus{}, false
+}
+
+func (r *record) removeTxn(startTS uint64) {
+	txns := r.txns[:0]
+	for _, s := range r.txns {
+		if s.startTS != startTS {
+			txns = append(txns, s)
+		}
+	}
+	r.txns = txns
+}
+
+// isCommitted returns the commit ts if the transaction committed the key
+func (r *record) isCommitted(startTS uint64) (uint64, bool) {
+	if r.commitStartTS == startTS && r.commitTS > 0 {
+		return r.commitTS, true
+	}
+	if s, ok := r.getTxn(startTS); ok && s.commitTS > 0 {
+		return s.commitTS, true
+	}
+	return 0, false
+}
+
+func (r *record) marshal() []byte {
+	e := encoder{}
+	e.uint64(r.commitTS)
+	e.uint64(r.commitStartTS)
+	e.lock(r.lock)
+	e.uint64(uint64(len(r.txns)))
+	for _, s := range r.txns {
+		e.uint64(s.startTS)
+		e.uint64(s.commitTS)
+		e.bytesSlice(s.secondaries)
+	}
+	return e.data
+}
+
+func (r *record) unmarshal(data []byte) error {
+	d := decoder{data: data}
+	r.commitTS = d.uint64()
+	r.commitStartTS = d.uint64()
+	r.lock = d.lock()
+	r.txns = nil
+	n := d.uint64()
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		s := txnStatus{}
+		s.startTS = d.uint64()
+		s.commitTS = d.uint64()
+		s.secondaries = d.bytesSlice()
+		r.txns = append(r.txns, s)
+	}
+	return d.err
+}
+
+func (v *version) marshal() []byte {
+	e := encoder{}
+	e.uint64(v.startTS)
+	if v.deleted {
+		e.uint64(1)
+	} else {
+		e.uint64(0)
+	}
+	e.bytes(v.value)
+	return e.data
+}
+
+func (v *version) unmarshal(data []byte) error {
+	d := decoder{data: data}
+	v.startTS = d.uint64()
+	v.deleted = d.uint64() == 1
+	v.value = d.bytes()
+	return d.err
+}
+
+const (
+	encGroupSize = 8
+	encMarker    = byte(0xff)
+	encPad       = byte(0x0)
+)
+
+// EncodeKey returns the encoded key of the key written by the transactions, the transaction
+// commands are routed by the encoded key. The key is encoded in the memcomparable format, the
+// encoded keys keep the order of the keys and none of them is the prefix of another, so the
+// versions of the key appended after the encoded key never mix with the other keys.
+func EncodeKey(key []byte) []byte {
+	data := make([]byte, 0, (len(key)/encGroupSize+1)*(encGroupSize+1))
+	for idx := 0; idx <= len(key); idx += encGroupSize {
+		remain := len(key) - idx
+		padCount := 0
+		if remain >= encGroupSize {
+			data = append(data, key[idx:idx+encGroupSize]...)
+		} else {
+			padCount = encGroupSize - remain
+			data = append(data, key[idx:]...)
+			data = append(data, bytes.Repeat([]byte{encPad}, padCount)...)
+		}
+		data = append(data, encMarker-byte(padCount))
+	}
+	return data
+}
+
+// RecordKey returns the encoded key of the record, the key is the encoded key or the version
+// key. It returns nil if the key is not written by the transactions.
+func RecordKey(key []byte) []byte {
+	for idx := encGroupSize; idx < len(key); idx += encGroupSize + 1 {
+		if key[idx] != encMarker {
+			if n := idx + 1; n == len(key) || n+8 == len(key) {
+				return key[:n]
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// versionKey returns the key of the version committed at the commit ts, the versions of the
+// key are in the descending order of the commit ts.
+func versionKey(recordKey []byte, commitTS uint64) []byte {
+	key := make([]byte, len(recordKey)+8)
+	copy(key, recordKey)
+	binary.BigEndian.PutUint64(key[len(recordKey):], ^commitTS)
+	return key
+}
+
+type encoder struct {
+	data []byte
+}
+
+func (e *encoder) uint64(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	e.data = append(e.data, buf[:n]...)
+}
+
+func (e *encoder) bytes(v []byte) {
+	e.uint64(uint64(len(v)))
+	e.data = append(e.data, v...)
+}
+
+func (e *encoder) bytesSlice(v [][]byte) {
+	e.uint64(uint64(len(v)))
+	for _, b := range v {
+		e.bytes(b)
+	}
+}
+
+func (e *encoder) lock(l *Lock) {
+	if l == nil {
+		e.uint64(0)
+		return
+	}
+
+	e.uint64(1)
+	e.bytes(l.Primary)
+	e.uint64(l.StartTS)
+	e.uint64(uint64(l.TTL))
+	e.uint64(uint64(l.Op))
+	e.bytes(l.Value)
+	e.bytesSlice(l.Secondaries)
+}
+
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) uint64() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errInvalidData
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.uint64()
+	if d.err != nil || n == 0 {
+		return nil
+	}
+	if uint64(len(d.data)) < n {
+		d.err = errInvalidData
+		return nil
+	}
+
+	v := make([]byte, n)
+	copy(v, d.data[:n])
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) bytesSlice() [][]byte {
+	n := d.uint64()
+	var v [][]byte
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		v = append(v, d.bytes())
+	}
+	return v
+}
+
+func (d *decoder) lock() *Lock {
+	if d.uint64() != 1 {
+		return nil
+	}
+
+	l := &Lock{}
+	l.Primary = d.bytes()
+	l.StartTS = d.uint64()
+	l.TTL = int64(d.uint64())
+	l.Op = Op(d.uint64())
+	l.Value = d.bytes()
+	l.Secondaries = d.bytesSlice()
+	return l
+}
@@ -31,6 +31,10 @@ type WriteBatch struct {
 	Keys   [][]byte
 	Values [][]byte
 	TTLs   []int32
+	// TTLStart the unix time in seconds the TTLs are counted from, 0 means the time the
+	// batch is written. The applied batches use the time of the proposal, so all the replicas
+	// have the same expiration time.
+	TTLStart int64
 }
 
 // Delete remove the key
@@ -70,4 +74,5 @@ func (wb *WriteBatch) Reset() {
 	wb.Keys = wb.Keys[:0]
 	wb.Values = wb.Values[:0]
 	wb.TTLs = wb.TTLs[:0]
+	wb.TTLStart = 0
 }